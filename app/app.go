@@ -2,22 +2,44 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
 	"os"
-	"os/signal"
-	"syscall"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/sprawl/sprawl/database/diskspace"
+	"github.com/sprawl/sprawl/database/encrypted"
 	"github.com/sprawl/sprawl/database/inmemory"
 	"github.com/sprawl/sprawl/database/leveldb"
+	"github.com/sprawl/sprawl/database/migrations"
+	"github.com/sprawl/sprawl/database/replicated"
+	"github.com/sprawl/sprawl/database/sqlite"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/p2p"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/service"
+	"github.com/sprawl/sprawl/settlement"
+	_ "github.com/sprawl/sprawl/settlement/ethereum" // Registers the ethereum settlement engine
 	"github.com/sprawl/sprawl/util"
+	"google.golang.org/grpc/keepalive"
 )
 
+// bytesPerMegabyte converts database.minFreeSpace to bytes
+const bytesPerMegabyte uint64 = 1 << 20
+
+// idleCheckInterval is how often joined channels are checked for being idle
+const idleCheckInterval time.Duration = time.Minute
+
+// outboxRetryInterval is how often the outbox retries broadcasting orders that couldn't be published
+const outboxRetryInterval time.Duration = 5 * time.Second
+
 // App ties Sprawl's services together
 type App struct {
 	Storage          interfaces.Storage
@@ -26,6 +48,19 @@ type App struct {
 	Logger           interfaces.Logger
 	config           interfaces.Config
 	WebsocketService interfaces.WebsocketService
+	apiKeys          map[string]string
+	apiRoles         map[string]service.Role
+	candleIntervals  []time.Duration
+	wsListeners      []service.WebsocketListener
+	settlement       settlement.Engine
+	noWebsocket      bool
+	host             host.Host
+	closeOnce        sync.Once
+	// stopOutbox stops broadcasting created orders from the outbox, and is nil until the node starts doing that
+	stopOutbox context.CancelFunc
+	// follower replicates the primary while the node stands by, and is nil otherwise
+	follower     *follower
+	followerLock sync.Mutex
 }
 
 func (app *App) debugPinger() {
@@ -45,76 +80,514 @@ func (app *App) debugPinger() {
 	}
 }
 
-// InitServices ties the services together before running
-func (app *App) InitServices(config interfaces.Config, Logger interfaces.Logger) {
-	app.config = config
-	if Logger == nil {
+// historyPruner prunes the order history once on startup. Orders deleted since expire from it by themselves,
+// this catches the ones stored without a TTL by older versions.
+func (app *App) historyPruner() {
+	retention := time.Duration(app.config.GetHistoryRetention()) * time.Hour
+	err := app.Server.Orders.PruneHistory(time.Now().Add(-retention))
+	if !errors.IsEmpty(err) {
+		app.Logger.Error(errors.E(errors.Op("Prune order history"), err))
+	}
+}
+
+func (app *App) lockExpirer() {
+	interval := time.Duration(app.config.GetUnlockInterval()) * time.Second
+
+	for {
+		err := app.Server.Orders.UnlockExpired(time.Now())
+		if !errors.IsEmpty(err) {
+			app.Logger.Error(errors.E(errors.Op("Unlock timed out orders"), err))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// digestPublisher publishes the order digests of joined channels, so peers with differing order books sync up
+func (app *App) digestPublisher() {
+	interval := time.Duration(app.config.GetAntiEntropyInterval()) * time.Second
+
+	for {
+		time.Sleep(interval)
+		// A standby isn't on the network
+		if app.Server == nil || app.following() {
+			continue
+		}
+		err := app.Server.Orders.PublishDigests(context.Background())
+		if !errors.IsEmpty(err) {
+			app.Logger.Error(errors.E(errors.Op("Publish order digests"), err))
+		}
+	}
+}
+
+func (app *App) counterSaver() {
+	interval := time.Duration(app.config.GetCountersInterval()) * time.Second
+
+	for {
+		time.Sleep(interval)
+		app.saveCounters()
+	}
+}
+
+func (app *App) saveCounters() {
+	// A standby hasn't loaded the counters, so it would overwrite the replicated totals
+	if app.Server == nil || app.following() {
+		return
+	}
+	err := app.Server.Orders.SaveCounters(context.Background())
+	if !errors.IsEmpty(err) {
+		app.Logger.Error(errors.E(errors.Op("Save counters"), err))
+	}
+}
+
+func (app *App) pprofListener() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	// Profiles expose the node's internals, so they're only served locally
+	address := fmt.Sprintf("localhost:%d", app.config.GetPprofPort())
+	app.Logger.Infof("Serving pprof on http://%s/debug/pprof/", address)
+	err := http.ListenAndServe(address, mux)
+	if err != nil {
+		app.Logger.Error(errors.E(errors.Op("Serve pprof"), err))
+	}
+}
+
+func (app *App) channelPruner() {
+	interval := time.Duration(app.config.GetChannelPruneInterval()) * time.Minute
+
+	for {
+		pruned, err := app.Server.Orders.PruneChannels(time.Now())
+		if !errors.IsEmpty(err) {
+			app.Logger.Error(errors.E(errors.Op("Prune channels"), err))
+		}
+		for channelID, count := range pruned {
+			app.Logger.Infof("Pruned %d orders past the retention policy from channel %s", count, channelID)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// idleChannelLeaver leaves the joined channels that have been idle for longer than channels.idleTimeout
+func (app *App) idleChannelLeaver() {
+	for {
+		time.Sleep(idleCheckInterval)
+		left, err := app.Server.Channels.LeaveIdle(context.Background(), time.Now())
+		if !errors.IsEmpty(err) {
+			app.Logger.Error(errors.E(errors.Op("Leave idle channels"), err))
+		}
+		for _, channelID := range left {
+			app.Logger.Infof("Left idle channel %s, it can be rejoined with ChannelHandler.Rejoin", channelID)
+		}
+	}
+}
+
+// diskMonitor turns the node read-only while the database's disk is running out of space, and back when it isn't
+func (app *App) diskMonitor() {
+	interval := time.Duration(app.config.GetDiskCheckInterval()) * time.Second
+	minFree := uint64(app.config.GetMinFreeSpace()) * bytesPerMegabyte
+
+	for {
+		app.checkDiskSpace(minFree)
+		time.Sleep(interval)
+	}
+}
+
+func (app *App) checkDiskSpace(minFree uint64) {
+	free, err := diskspace.Free(app.config.GetDatabasePath())
+	if !errors.IsEmpty(err) {
+		app.Logger.Error(errors.E(errors.Op("Check disk space"), err))
+		return
+	}
+	app.Server.Orders.SetFreeDiskSpace(free, minFree)
+}
+
+// nextCompaction returns the next time after now at the local time of day given as HH:MM
+func nextCompaction(now time.Time, at string) (time.Time, error) {
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return time.Time{}, errors.E(errors.Op("Parse compaction time"), errors.Invalid, "database.compactAt should be HH:MM")
+	}
+	next := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next, nil
+}
+
+// compactionScheduler compacts the database every day at the configured time
+func (app *App) compactionScheduler() {
+	for {
+		next, err := nextCompaction(time.Now(), app.config.GetCompactAt())
+		if !errors.IsEmpty(err) {
+			app.Logger.Error(err)
+			return
+		}
+		time.Sleep(time.Until(next))
+		_, err = app.Server.Admin.Compact(context.Background(), &pb.Empty{})
+		if !errors.IsEmpty(err) {
+			app.Logger.Error(errors.E(errors.Op("Compact database"), err))
+		}
+	}
+}
+
+// Option customizes the App constructed by New
+type Option func(*App) error
+
+// Storage makes the App use the given storage instead of the one selected in the config, for example to plug in another database
+func Storage(storage interfaces.Storage) Option {
+	return func(app *App) error {
+		app.Storage = storage
+		return nil
+	}
+}
+
+// Settlement makes the App settle fills with the given engine instead of the one registered under settlement.engine
+func Settlement(engine settlement.Engine) Option {
+	return func(app *App) error {
+		app.settlement = engine
+		return nil
+	}
+}
+
+// NoWebsocket keeps the websocket service from starting even when it's enabled in the config
+func NoWebsocket() Option {
+	return func(app *App) error {
+		app.noWebsocket = true
+		return nil
+	}
+}
+
+// Host makes the App run p2p on a host that is already set up and connected, like one of a mocknet,
+// instead of starting its own and looking for peers
+func Host(host host.Host) Option {
+	return func(app *App) error {
+		app.host = host
+		return nil
+	}
+}
+
+// New constructs a Sprawl node in dependency order: storage, identity, websockets, p2p and the gRPC services.
+// The p2p host is started right away, unless the node stands by as a follower of replication.primary,
+// while Run serves the gRPC API. Close shuts everything down.
+func New(config interfaces.Config, logger interfaces.Logger, options ...Option) (*App, error) {
+	app := &App{config: config, Logger: logger}
+	if app.Logger == nil {
 		app.Logger = new(util.PlaceholderLogger)
-	} else {
-		app.Logger = Logger
+	}
+	for _, option := range options {
+		err := option(app)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Apply app option"), err)
+		}
 	}
 	errors.SetDebug(app.config.GetStackTraceSetting())
 
-	app.Logger.Infof("Saving data to %s", app.config.GetDatabasePath())
+	// Check the API keys before starting anything that would have to be closed
+	apiKeys, err := service.ParseAPIKeys(app.config.GetAPIKeys())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	app.apiKeys = apiKeys
+	app.apiRoles, err = service.ParseAPIRoles(app.config.GetAPIRoles())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	app.candleIntervals, err = service.ParseIntervals(app.config.GetMarketDataIntervals())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	app.wsListeners, err = service.ParseWebsocketListeners(app.config.GetWebsocketListeners())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	err = service.CheckOrderIDPolicies(app.config.GetOrderIDMismatch(), app.config.GetOrderIDCollision())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
 
-	// Start up the database
-	if app.config.GetInMemoryDatabaseSetting() {
-		app.Storage = &inmemory.Storage{
-			Db: make(map[string]string),
+	if app.settlement == nil {
+		app.settlement, err = settlement.New(app.config.GetSettlementEngine(), app.config, app.Logger)
+		if !errors.IsEmpty(err) {
+			return nil, err
 		}
-	} else {
-		app.Storage = &leveldb.Storage{}
 	}
-	app.Storage.SetDbPath(app.config.GetDatabasePath())
-	app.Storage.Run()
 
-	privateKey, publicKey, err := identity.GetIdentity(app.Storage)
+	err = app.initStorage()
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
 
+	err = app.migrateStorage()
 	if !errors.IsEmpty(err) {
-		app.Logger.Error(errors.E(errors.Op("Get identity"), err))
+		app.Storage.Close()
+		return nil, err
+	}
+
+	if app.config.GetReplicationSetting() {
+		app.Storage = &replicated.Storage{Storage: app.Storage}
 	}
 
-	if app.config.GetWebsocketEnable() {
-		app.WebsocketService = &service.WebsocketService{Logger: Logger, Port: app.config.GetWebsocketPort()}
-		go app.WebsocketService.Start()
+	// A standby takes the identity replicated from its primary once it's promoted, so it doesn't make one of its own
+	standby := app.config.GetReplicationPrimary() != ""
+	var privateKey crypto.PrivKey
+	var publicKey crypto.PubKey
+	if !standby {
+		privateKey, publicKey, err = identity.GetIdentity(app.Storage)
+		if !errors.IsEmpty(err) {
+			app.Storage.Close()
+			return nil, errors.E(errors.Op("Get identity"), err)
+		}
 	}
 
+	app.initWebsocket()
+
 	// Run the P2P process
-	app.P2p = p2p.NewP2p(config, privateKey, publicKey, p2p.Logger(app.Logger), p2p.Storage(app.Storage))
+	p2pOptions := []p2p.Option{p2p.Logger(app.Logger), p2p.Storage(app.Storage)}
+	if app.host != nil {
+		p2pOptions = append(p2pOptions, p2p.Host(app.host))
+	}
+	app.P2p = p2p.NewP2p(config, privateKey, publicKey, p2pOptions...)
 
-	// Construct the server struct
-	app.Server = service.NewServer(Logger, app.Storage, app.P2p, app.WebsocketService)
+	app.initServer()
 
 	// Connect the order service as a receiver for p2p
 	app.P2p.AddReceiver(app.Server.Orders)
 
+	if standby {
+		app.startFollower()
+		return app, nil
+	}
+
+	// Carry the all-time totals of the counters on from the last run
+	err = app.Server.Orders.LoadCounters(context.Background())
+	if !errors.IsEmpty(err) {
+		app.Logger.Error(errors.E(errors.Op("Load counters"), err))
+	}
+
 	// Run the P2p service before running the gRPC server
 	app.P2p.Run()
+	app.startOutbox()
+
+	return app, nil
+}
+
+// startOutbox broadcasts the orders created on the node from the outbox until the node is closed,
+// starting with the ones left over from the last run
+func (app *App) startOutbox() {
+	ctx, cancel := context.WithCancel(context.Background())
+	app.stopOutbox = cancel
+	go app.Server.Orders.RunOutbox(ctx, outboxRetryInterval)
+}
+
+// initStorage starts the storage selected in the config, unless one has been given with the Storage option
+func (app *App) initStorage() error {
+	if app.Storage == nil {
+		app.Logger.Infof("Saving data to %s", app.config.GetDatabasePath())
+		if app.config.GetInMemoryDatabaseSetting() {
+			app.Storage = &inmemory.Storage{
+				Db: make(map[string]string),
+			}
+		} else {
+			// The storage holds the node's private key, so only its user may read the directory
+			storage, err := app.newDiskStorage(app.config.GetDatabasePath())
+			if !errors.IsEmpty(err) {
+				return err
+			}
+			app.Storage = storage
+		}
+		if identityPath := app.config.GetIdentityPath(); identityPath != "" {
+			keys, err := app.newDiskStorage(identityPath)
+			if !errors.IsEmpty(err) {
+				return err
+			}
+			keys.SetDbPath(identityPath)
+			app.Storage = &identity.Keystore{Storage: app.Storage, Keys: keys}
+		}
+	}
+	app.Storage.SetDbPath(app.config.GetDatabasePath())
+	app.Storage.SetBatchSize(app.config.GetDeleteBatchSize())
+	err := app.Storage.Run()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Run storage"), err)
+	}
+	return nil
+}
+
+// newDiskStorage creates the directory of a storage of the configured database engine, encrypted if a passphrase is set
+func (app *App) newDiskStorage(path string) (interfaces.Storage, error) {
+	err := os.MkdirAll(path, 0700)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Create database directory"), err)
+	}
+	var storage interfaces.Storage
+	if app.config.GetDatabaseEngine() == "sqlite" {
+		storage = &sqlite.Storage{}
+	} else {
+		levelDB := &leveldb.Storage{}
+		window := time.Duration(app.config.GetGroupCommitWindow()) * time.Millisecond
+		err = levelDB.SetWritePipeline(window, app.config.GetFsyncPolicy())
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Set LevelDB write pipeline"), err)
+		}
+		storage = levelDB
+	}
+	if passphrase := app.config.GetDatabaseEncryptionPassphrase(); passphrase != "" {
+		storage = &encrypted.Storage{Storage: storage, Passphrase: passphrase}
+	}
+	return storage, nil
+}
+
+// migrateStorage brings the stored data up to the schema version of this build before anything reads it
+func (app *App) migrateStorage() error {
+	dryRun := app.config.GetMigrationsDryRunSetting()
+	err := migrations.Run(context.Background(), app.Storage, app.Logger, dryRun)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Migrate storage"), err)
+	}
+	if dryRun {
+		return errors.E(errors.Op("Migrate storage"), "dry run of the storage migrations is done, not starting the node")
+	}
+	return nil
+}
+
+func (app *App) initWebsocket() {
+	if app.noWebsocket || !app.config.GetWebsocketEnable() {
+		return
+	}
+	app.WebsocketService = &service.WebsocketService{
+		Logger:        app.Logger,
+		Port:          app.config.GetWebsocketPort(),
+		PingInterval:  time.Duration(app.config.GetWebsocketPingInterval()) * time.Second,
+		PongTimeout:   time.Duration(app.config.GetWebsocketPongTimeout()) * time.Second,
+		FlushInterval: time.Duration(app.config.GetWebsocketFlushInterval()) * time.Millisecond,
+		Listeners:     app.wsListeners,
+		APIKeys:       app.apiKeys,
+	}
+	go app.WebsocketService.Start()
+}
+
+// initServer constructs the gRPC services on top of storage and p2p
+func (app *App) initServer() {
+	app.Server = service.NewServer(app.Logger, app.Storage, app.P2p, app.WebsocketService)
+	app.Server.EnableReflection = app.config.GetRPCReflectionSetting()
+	app.Server.APIKeys = app.apiKeys
+	app.Server.APIRoles = app.apiRoles
+	app.Server.WebPort = app.config.GetRPCWebPort()
+	app.Server.MarketAPIPort = app.config.GetMarketAPIPort()
+	app.Server.MarketAPIMaxAge = time.Duration(app.config.GetMarketAPIMaxAge()) * time.Second
+	app.Server.DashboardPort = app.config.GetDashboardPort()
+	if app.config.GetRPCWebOrigins() != "" {
+		app.Server.WebOrigins = strings.Split(app.config.GetRPCWebOrigins(), ",")
+	}
+	app.Server.Keepalive = keepalive.ServerParameters{
+		Time:              time.Duration(app.config.GetRPCKeepaliveTime()) * time.Second,
+		Timeout:           time.Duration(app.config.GetRPCKeepaliveTimeout()) * time.Second,
+		MaxConnectionIdle: time.Duration(app.config.GetRPCMaxConnectionIdle()) * time.Second,
+		MaxConnectionAge:  time.Duration(app.config.GetRPCMaxConnectionAge()) * time.Second,
+	}
+	app.Server.KeepalivePolicy = keepalive.EnforcementPolicy{
+		MinTime:             time.Duration(app.config.GetRPCKeepaliveMinTime()) * time.Second,
+		PermitWithoutStream: app.config.GetRPCKeepalivePermitWithoutStream(),
+	}
+	app.Server.MaxRecvMessageSize = int(app.config.GetRPCMaxRecvMessageSize())
+	app.Server.MaxSendMessageSize = int(app.config.GetRPCMaxSendMessageSize())
+	app.Server.MaxConcurrentStreams = uint32(app.config.GetRPCMaxConcurrentStreams())
+	app.Server.Orders.LockTimeout = time.Duration(app.config.GetLockTimeout()) * time.Second
+	app.Server.Channels.AllowCustomAssets = app.config.GetAllowCustomAssets()
+	app.Server.Channels.IdleTimeout = time.Duration(app.config.GetChannelIdleTimeout()) * time.Minute
+	app.Server.Orders.AllowCustomAssets = app.config.GetAllowCustomAssets()
+	app.Server.Orders.HistoryRetention = time.Duration(app.config.GetHistoryRetention()) * time.Hour
+	app.Server.Orders.MaxOrderAge = time.Duration(app.config.GetMaxOrderAge()) * time.Hour
+	app.Server.Orders.MaxOrdersPerChannel = app.config.GetMaxOrders()
+	app.Server.Orders.MaxClockSkew = time.Duration(app.config.GetMaxClockSkew()) * time.Second
+	app.Server.Admin.ProfileDir = app.config.GetProfileDir()
+	app.Server.Admin.Doctor = app.SelfTest
+	app.Server.Orders.MaxDeadLetters = app.config.GetDeadLetters()
+	app.Server.Orders.MakerRateLimit = app.config.GetMakerRateLimit()
+	app.Server.Orders.MaxMakerOrders = app.config.GetMaxMakerOrders()
+	app.Server.Orders.AntiEntropyInterval = time.Duration(app.config.GetAntiEntropyInterval()) * time.Second
+	app.Server.Orders.OrderIDMismatch = app.config.GetOrderIDMismatch()
+	app.Server.Orders.OrderIDCollision = app.config.GetOrderIDCollision()
+	if workers := app.config.GetOrderWorkers(); workers > 0 {
+		app.Server.Orders.RegisterWorkers(service.NewWorkers(workers))
+	}
+	app.Server.Orders.RegisterSettlement(app.settlement)
+
+	// Created orders are stored together with their broadcast, which the outbox sends once p2p is running
+	app.Server.Orders.RegisterOutbox(service.NewOutbox())
+	app.Server.MarketData.Intervals = app.candleIntervals
+
+	// Serve hot single order reads from memory unless the cache is disabled
+	if size := app.config.GetOrderCacheSize(); size > 0 {
+		cache := service.NewOrderCache(size)
+		app.Server.Orders.RegisterCache(cache)
+		app.Server.Node.OrderCache = cache
+	}
+
+	// Mirror orders between equivalent channels if any asset pairs are configured for routing
+	if app.config.GetRouterPairs() != "" {
+		app.Server.Orders.RegisterRouter(service.NewRouter(app.Storage, strings.Split(app.config.GetRouterPairs(), ",")))
+	}
 
-	systemSignals := make(chan os.Signal)
-	signal.Notify(systemSignals, syscall.SIGINT, syscall.SIGTERM)
+	// POST order events to webhooks if any are configured
+	if app.config.GetWebhookURLs() != "" {
+		app.Server.Orders.RegisterWebhooks(service.NewWebhooks(app.Logger, strings.Split(app.config.GetWebhookURLs(), ","), strings.Split(app.config.GetWebhookEvents(), ","), app.config.GetWebhookSecret(), app.config.GetWebhookRetries()))
+	}
+}
 
-	go func() {
-		select {
-		case sig := <-systemSignals:
-			app.Logger.Infof("Received %s signal, shutting down.\n", sig)
+// Close shuts down the servers, the p2p host and the storage. It's safe to call more than once.
+func (app *App) Close() {
+	app.closeOnce.Do(func() {
+		if closer, ok := app.settlement.(settlement.Closer); ok {
+			closer.Close()
+		}
+		if app.Server != nil {
 			app.Server.Close()
+		}
+		if app.WebsocketService != nil {
+			app.WebsocketService.Close()
+		}
+		app.followerLock.Lock()
+		if app.follower != nil {
+			app.follower.stop()
+		}
+		if app.stopOutbox != nil {
+			app.stopOutbox()
+		}
+		app.followerLock.Unlock()
+		if app.P2p != nil {
 			app.P2p.Close()
+		}
+		if app.Storage != nil {
+			app.saveCounters()
 			app.Storage.Close()
-			os.Exit(0)
 		}
-	}()
+	})
 }
 
-// Run is a separated main-function to ease testing
+// Run starts the background jobs and serves the gRPC API until the node is closed.
+// A standby only serves the API, and starts the jobs once it's promoted.
 func (app *App) Run() {
-	defer app.Server.Close()
-	defer app.Storage.Close()
-	defer app.P2p.Close()
-	if app.WebsocketService != nil {
-		defer app.WebsocketService.Close()
+	defer app.Close()
+
+	if app.config.GetPprofPort() > 0 {
+		go app.pprofListener()
 	}
 
+	if !app.following() {
+		app.startJobs()
+	}
+
+	// Run the gRPC API
+	app.Server.Run(app.config.GetRPCPort())
+}
+
+// startJobs starts the background jobs enabled in the config
+func (app *App) startJobs() {
 	if app.config.GetDebugSetting() {
 		if app.Logger != nil {
 			app.Logger.Info("Running the debug pinger on channel \"testChannel\"!")
@@ -122,6 +595,35 @@ func (app *App) Run() {
 		go app.debugPinger()
 	}
 
-	// Run the gRPC API
-	app.Server.Run(app.config.GetRPCPort())
+	if app.config.GetHistoryRetention() > 0 {
+		go app.historyPruner()
+	}
+
+	if app.config.GetChannelPruneInterval() > 0 && (app.config.GetMaxOrderAge() > 0 || app.config.GetMaxOrders() > 0) {
+		go app.channelPruner()
+	}
+
+	if app.config.GetChannelIdleTimeout() > 0 {
+		go app.idleChannelLeaver()
+	}
+
+	if app.config.GetLockTimeout() > 0 && app.config.GetUnlockInterval() > 0 {
+		go app.lockExpirer()
+	}
+
+	if app.config.GetCountersInterval() > 0 {
+		go app.counterSaver()
+	}
+
+	if app.config.GetAntiEntropyInterval() > 0 {
+		go app.digestPublisher()
+	}
+
+	if app.config.GetMinFreeSpace() > 0 && app.config.GetDiskCheckInterval() > 0 && !app.config.GetInMemoryDatabaseSetting() {
+		go app.diskMonitor()
+	}
+
+	if app.config.GetCompactAt() != "" && !app.config.GetInMemoryDatabaseSetting() {
+		go app.compactionScheduler()
+	}
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/database/inmemory"
 	"github.com/sprawl/sprawl/database/leveldb"
+	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/util"
 	"github.com/stretchr/testify/assert"
@@ -39,18 +42,42 @@ func resetEnv() {
 }
 
 func TestInit(t *testing.T) {
-	app := &App{}
 	os.Setenv(useInMemoryEnvVar, "false")
 	appConfig.ReadConfig(testConfigPath)
-	app.InitServices(appConfig, nil)
+	app, err := New(appConfig, nil)
+	assert.NoError(t, err)
+	defer app.Close()
 	assert.True(t, util.IsInstanceOf(app.Storage, (*leveldb.Storage)(nil)))
 	assert.Equal(t, app.Logger, new(util.PlaceholderLogger))
 }
 
+func TestStorageOption(t *testing.T) {
+	resetEnv()
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	app, err := New(appConfig, log, Storage(storage))
+	assert.NoError(t, err)
+	assert.Equal(t, storage, app.Storage)
+	assert.Equal(t, storage, app.Server.Orders.Storage)
+
+	// Closing more than once is harmless
+	app.Close()
+	app.Close()
+}
+
+func TestNoWebsocketOption(t *testing.T) {
+	resetEnv()
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	app, err := New(appConfig, log, Storage(storage), NoWebsocket())
+	assert.NoError(t, err)
+	defer app.Close()
+	assert.Nil(t, app.WebsocketService)
+}
+
 func TestApp(t *testing.T) {
 	resetEnv()
-	app := &App{}
-	app.InitServices(appConfig, log)
+	app, err := New(appConfig, log)
+	assert.NoError(t, err)
+	defer app.Close()
 
 	assert.NotNil(t, app.Storage)
 	assert.NotNil(t, app.WebsocketService)
@@ -64,10 +91,17 @@ func TestApp(t *testing.T) {
 
 	assert.Equal(t, app.Server.Orders, app.P2p.Receiver)
 
-	err := app.Server.Channels.Storage.Put([]byte(asset1), []byte(asset2))
+	// The gRPC settings of the config reach the server
+	assert.Equal(t, time.Duration(appConfig.GetRPCKeepaliveTime())*time.Second, app.Server.Keepalive.Time)
+	assert.Equal(t, time.Duration(appConfig.GetRPCKeepaliveMinTime())*time.Second, app.Server.KeepalivePolicy.MinTime)
+	assert.Equal(t, appConfig.GetRPCKeepalivePermitWithoutStream(), app.Server.KeepalivePolicy.PermitWithoutStream)
+	assert.Equal(t, int(appConfig.GetRPCMaxRecvMessageSize()), app.Server.MaxRecvMessageSize)
+	assert.Equal(t, uint32(appConfig.GetRPCMaxConcurrentStreams()), app.Server.MaxConcurrentStreams)
+
+	err = app.Server.Channels.Storage.Put(context.Background(), []byte(asset1), []byte(asset2))
 	assert.NoError(t, err)
 
-	err = app.Server.Orders.Storage.Put([]byte(asset1), []byte(asset2))
+	err = app.Server.Orders.Storage.Put(context.Background(), []byte(asset1), []byte(asset2))
 	assert.NoError(t, err)
 
 	ctx := context.Background()
@@ -81,25 +115,57 @@ func TestApp(t *testing.T) {
 
 	go app.Run()
 
-	app.Storage.DeleteAll()
+	app.Storage.DeleteAll(context.Background())
+}
 
-	defer app.Server.Close()
-	defer app.Storage.Close()
-	defer app.P2p.Close()
+func TestCheckDiskSpace(t *testing.T) {
+	// The disk is checked where the database is saved
+	os.Setenv(useInMemoryEnvVar, "false")
+	appConfig.ReadConfig(testConfigPath)
+	defer resetEnv()
+	app, err := New(appConfig, log)
+	assert.NoError(t, err)
+	defer app.Close()
+
+	app.checkDiskSpace(0)
+	assert.False(t, app.Server.Orders.IsReadOnly())
+	assert.NotZero(t, app.Server.Orders.FreeDiskSpace())
+
+	// No disk has this much space
+	app.checkDiskSpace(1 << 62)
+	assert.True(t, app.Server.Orders.IsReadOnly())
+}
+
+func TestNextCompaction(t *testing.T) {
+	now := time.Date(2020, 3, 14, 12, 30, 0, 0, time.Local)
+
+	next, err := nextCompaction(now, "03:15")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 3, 15, 3, 15, 0, 0, time.Local), next)
+
+	next, err = nextCompaction(now, "23:00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 3, 14, 23, 0, 0, 0, time.Local), next)
+
+	// A compaction at this very minute waits for tomorrow
+	next, err = nextCompaction(now, "12:30")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 3, 15, 12, 30, 0, 0, time.Local), next)
+
+	_, err = nextCompaction(now, "nightly")
+	assert.True(t, errors.Is(errors.Invalid, err))
 }
 
 // TODO: doesn't test now that the debugPinger actually joins any channel. Needs refactoring of the debugPinger functionality itself to make it more testable.
 func TestDebugPinger(t *testing.T) {
-	app := &App{}
 	os.Setenv(p2pDebugEnvVar, envTestP2PDebug)
 	appConfig.ReadConfig(testConfigPath)
-	app.InitServices(appConfig, log)
+	app, err := New(appConfig, log)
+	assert.NoError(t, err)
+	defer app.Close()
 
 	go app.debugPinger()
 
-	defer app.Storage.Close()
-	defer app.P2p.Close()
-
 	os.Clearenv()
-	app.Storage.DeleteAll()
+	app.Storage.DeleteAll(context.Background())
 }
@@ -0,0 +1,241 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	autonat "github.com/libp2p/go-libp2p-autonat"
+	"github.com/sprawl/sprawl/database/leveldb"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/p2p"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/service"
+	"github.com/sprawl/sprawl/settlement"
+	"github.com/sprawl/sprawl/util"
+)
+
+// selfTestTimeout limits how long the network checks wait for peers. It's longer than AutoNAT waits before asking them.
+const selfTestTimeout time.Duration = 45 * time.Second
+
+const probeKey = string(interfaces.ProbePrefix) + "selftest"
+
+func passedCheck(name string, message string) *pb.SelfTestResult {
+	return &pb.SelfTestResult{Name: name, Passed: true, Message: message}
+}
+
+func failedCheck(name string, message string, hint string) *pb.SelfTestResult {
+	return &pb.SelfTestResult{Name: name, Message: message, Hint: hint}
+}
+
+// warningCheck is a check that couldn't tell whether something is wrong, so it doesn't fail the report
+func warningCheck(name string, message string, hint string) *pb.SelfTestResult {
+	return &pb.SelfTestResult{Name: name, Warning: true, Message: message, Hint: hint}
+}
+
+// newSelfTestReport passes if every check passed or only warned
+func newSelfTestReport(results []*pb.SelfTestResult) *pb.SelfTestReport {
+	report := &pb.SelfTestReport{Results: results, Passed: true}
+	for _, result := range results {
+		if !result.GetPassed() && !result.GetWarning() {
+			report.Passed = false
+		}
+	}
+	return report
+}
+
+// checkConfig checks the config values that are parsed when the node starts
+func checkConfig(config interfaces.Config) *pb.SelfTestResult {
+	problems := []string{}
+	if _, err := service.ParseAPIKeys(config.GetAPIKeys()); !errors.IsEmpty(err) {
+		problems = append(problems, err.Error())
+	}
+	if _, err := service.ParseAPIRoles(config.GetAPIRoles()); !errors.IsEmpty(err) {
+		problems = append(problems, err.Error())
+	}
+	if _, err := service.ParseIntervals(config.GetMarketDataIntervals()); !errors.IsEmpty(err) {
+		problems = append(problems, err.Error())
+	}
+	if _, err := service.ParseWebsocketListeners(config.GetWebsocketListeners()); !errors.IsEmpty(err) {
+		problems = append(problems, err.Error())
+	}
+	if err := service.CheckOrderIDPolicies(config.GetOrderIDMismatch(), config.GetOrderIDCollision()); !errors.IsEmpty(err) {
+		problems = append(problems, err.Error())
+	}
+	if config.GetCompactAt() != "" {
+		if _, err := nextCompaction(time.Now(), config.GetCompactAt()); !errors.IsEmpty(err) {
+			problems = append(problems, err.Error())
+		}
+	}
+	if err := leveldb.CheckFsyncPolicy(config.GetFsyncPolicy()); !errors.IsEmpty(err) {
+		problems = append(problems, err.Error())
+	}
+	found := false
+	for _, engine := range settlement.Engines() {
+		found = found || engine == config.GetSettlementEngine()
+	}
+	if !found {
+		problems = append(problems, fmt.Sprintf("settlement engine %q isn't one of %s", config.GetSettlementEngine(), strings.Join(settlement.Engines(), ", ")))
+	}
+
+	if len(problems) > 0 {
+		return failedCheck("config", strings.Join(problems, "; "), "Fix the values in config.toml, or the SPRAWL_ environment variables or flags that override them")
+	}
+	return passedCheck("config", "The config is valid")
+}
+
+// listenPort is a port the node listens on, with the config key that sets it
+type listenPort struct {
+	key  string
+	port uint
+}
+
+// checkPorts checks that the ports the node listens on are free, so it only makes sense while the node isn't running
+func checkPorts(config interfaces.Config) []*pb.SelfTestResult {
+	ports := []listenPort{{"rpc.port", config.GetRPCPort()}, {"p2p.port", config.GetP2PPort()}}
+	// Websocket listeners replace websocket.port, and may be Unix sockets
+	if config.GetWebsocketEnable() && config.GetWebsocketListeners() == "" {
+		ports = append(ports, listenPort{"websocket.port", config.GetWebsocketPort()})
+	}
+	if config.GetBrowserTransportsSetting() {
+		ports = append(ports, listenPort{"p2p.browserPort", config.GetBrowserPort()})
+	}
+	if config.GetRPCWebPort() > 0 {
+		ports = append(ports, listenPort{"rpc.webPort", config.GetRPCWebPort()})
+	}
+	if config.GetDashboardPort() > 0 {
+		ports = append(ports, listenPort{"dashboard.port", config.GetDashboardPort()})
+	}
+	if config.GetPprofPort() > 0 {
+		ports = append(ports, listenPort{"debug.pprof.port", config.GetPprofPort()})
+	}
+
+	results := []*pb.SelfTestResult{}
+	for _, port := range ports {
+		// Port 0 picks a free port, so there's nothing to check
+		if port.port == 0 {
+			continue
+		}
+		name := "port " + port.key
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port.port))
+		if err != nil {
+			results = append(results, failedCheck(name, fmt.Sprintf("Port %d can't be listened on: %s", port.port, err), fmt.Sprintf("Stop whatever is using port %d, for example another node, or change %s", port.port, port.key)))
+			continue
+		}
+		listener.Close()
+		results = append(results, passedCheck(name, fmt.Sprintf("Port %d is free", port.port)))
+	}
+	return results
+}
+
+// checkStorage writes an entry to the storage, reads it back and deletes it
+func checkStorage(ctx context.Context, storage interfaces.Storage) *pb.SelfTestResult {
+	hint := "Make sure database.path is on a writable disk with free space, and that its files belong to the node's user"
+	value := []byte(time.Now().String())
+	err := storage.Put(ctx, []byte(probeKey), value)
+	if !errors.IsEmpty(err) {
+		return failedCheck("storage", "Writing to the storage failed: "+err.Error(), hint)
+	}
+	read, err := storage.Get(ctx, []byte(probeKey))
+	if !errors.IsEmpty(err) {
+		return failedCheck("storage", "Reading from the storage failed: "+err.Error(), hint)
+	}
+	if string(read) != string(value) {
+		return failedCheck("storage", "The storage returned something else than was written to it", "The database may be corrupted. Restore it from a backup")
+	}
+	err = storage.Delete(ctx, []byte(probeKey))
+	if !errors.IsEmpty(err) {
+		return failedCheck("storage", "Deleting from the storage failed: "+err.Error(), hint)
+	}
+	return passedCheck("storage", "The storage can be written, read and deleted from")
+}
+
+// checkIdentity checks the integrity of the node's stored key pair
+func checkIdentity(storage interfaces.Storage) *pb.SelfTestResult {
+	exists, err := identity.CheckKeyPair(storage)
+	if !errors.IsEmpty(err) {
+		return failedCheck("identity", "The node's key pair is broken: "+err.Error(), "Restore the database from a backup. Deleting the keys gives the node a new peer ID and loses its orders' ownership")
+	}
+	if !exists {
+		return passedCheck("identity", "There's no key pair yet, the node makes one when it first starts")
+	}
+	return passedCheck("identity", "The node's key pair signs and verifies")
+}
+
+// checkNetwork checks that the bootstrap peers can be connected to, and whether the peers can dial this node back
+func checkNetwork(ctx context.Context, host *p2p.P2p) []*pb.SelfTestResult {
+	results := []*pb.SelfTestResult{}
+	connected, total := host.CheckBootstrapPeers()
+	switch {
+	case total == 0:
+		results = append(results, warningCheck("bootstrap peers", "No bootstrap peers are configured", "Set p2p.bootstrapPeers or p2p.useIPFSPeers, unless the node is meant to be found only through mDNS or other nodes"))
+	case connected == 0:
+		results = append(results, failedCheck("bootstrap peers", fmt.Sprintf("None of the %d bootstrap peers could be connected to", total), "Check the addresses in p2p.bootstrapPeers, the DNS resolution of /dnsaddr/ addresses, and that outgoing connections aren't blocked by a firewall"))
+	default:
+		results = append(results, passedCheck("bootstrap peers", fmt.Sprintf("Connected to %d of %d bootstrap peers", connected, total)))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+	defer cancel()
+	switch host.CheckReachability(ctx) {
+	case autonat.NATStatusPublic:
+		results = append(results, passedCheck("reachability", "Peers can dial this node"))
+	case autonat.NATStatusPrivate:
+		results = append(results, failedCheck("reachability", "Peers can't dial this node, it's behind a NAT or a firewall", "Forward p2p.port on the router and set p2p.externalIP, or enable p2p.enableNATPortMap, or p2p.enableAutoRelay to be reached through relays"))
+	default:
+		results = append(results, warningCheck("reachability", "No peer answered whether it could dial this node", "AutoNAT needs connected peers that support it. Check again once the node has been connected for a while"))
+	}
+	return results
+}
+
+// SelfTest checks the running node's config, storage, identity and network.
+// The ports are in use by the node itself, so they aren't checked.
+func (app *App) SelfTest(ctx context.Context) *pb.SelfTestReport {
+	results := []*pb.SelfTestResult{
+		checkConfig(app.config),
+		checkStorage(ctx, app.Storage),
+		checkIdentity(app.Storage),
+	}
+	results = append(results, checkNetwork(ctx, app.P2p)...)
+	return newSelfTestReport(results)
+}
+
+// Doctor checks that a node could run with the config: that the config is valid, the ports are free,
+// the storage works, the identity is intact and the network can be reached. It starts a p2p host of its own
+// for the network checks, so it's meant to be run while the node isn't. A running node checks itself with SelfTest.
+func Doctor(ctx context.Context, config interfaces.Config, logger interfaces.Logger) *pb.SelfTestReport {
+	app := &App{config: config, Logger: logger}
+	if app.Logger == nil {
+		app.Logger = new(util.PlaceholderLogger)
+	}
+	results := []*pb.SelfTestResult{checkConfig(config)}
+	results = append(results, checkPorts(config)...)
+
+	err := app.initStorage()
+	if !errors.IsEmpty(err) {
+		results = append(results, failedCheck("storage", "The storage can't be opened: "+err.Error(), "Make sure database.path is writable and that no node is running on it. A running node checks itself with AdminHandler.SelfTest"))
+		return newSelfTestReport(results)
+	}
+	defer app.Storage.Close()
+	results = append(results, checkStorage(ctx, app.Storage), checkIdentity(app.Storage))
+
+	// Doctor doesn't make the node's identity, so the network is checked with a throwaway one if there's none yet
+	privateKey, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	if exists, _ := identity.CheckKeyPair(app.Storage); exists {
+		privateKey, publicKey, err = identity.GetIdentity(app.Storage)
+	}
+	if !errors.IsEmpty(err) {
+		results = append(results, failedCheck("network", "The network can't be checked without a key pair: "+err.Error(), "Fix the identity first"))
+		return newSelfTestReport(results)
+	}
+	host := p2p.NewP2p(config, privateKey, publicKey, p2p.Logger(app.Logger))
+	host.InitHost(host.CreateOptions()...)
+	defer host.Close()
+	results = append(results, checkNetwork(ctx, host)...)
+	return newSelfTestReport(results)
+}
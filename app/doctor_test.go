@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const compactAtEnvVar string = "SPRAWL_DATABASE_COMPACTAT"
+const rpcPortEnvVar string = "SPRAWL_RPC_PORT"
+
+func TestCheckConfig(t *testing.T) {
+	resetEnv()
+	assert.True(t, checkConfig(appConfig).GetPassed())
+
+	os.Setenv(compactAtEnvVar, "25:00")
+	defer os.Unsetenv(compactAtEnvVar)
+	appConfig.ReadConfig(testConfigPath)
+	defer resetEnv()
+	result := checkConfig(appConfig)
+	assert.False(t, result.GetPassed())
+	assert.Contains(t, result.GetMessage(), "compactAt")
+	assert.NotEmpty(t, result.GetHint())
+}
+
+func TestCheckPorts(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	os.Setenv(rpcPortEnvVar, fmt.Sprint(port))
+	defer os.Unsetenv(rpcPortEnvVar)
+	appConfig.ReadConfig(testConfigPath)
+	defer resetEnv()
+	for _, result := range checkPorts(appConfig) {
+		if result.GetName() == "port rpc.port" {
+			assert.False(t, result.GetPassed())
+			assert.Contains(t, result.GetHint(), "rpc.port")
+		}
+	}
+}
+
+func TestCheckStorageAndIdentity(t *testing.T) {
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	assert.True(t, checkStorage(context.Background(), storage).GetPassed())
+	has, err := storage.Has(context.Background(), []byte(probeKey))
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// A node without a key pair yet is fine, while a broken one isn't
+	assert.True(t, checkIdentity(storage).GetPassed())
+	storage.Put(context.Background(), []byte("private_key"), []byte("garbage"))
+	storage.Put(context.Background(), []byte("public_key"), []byte("garbage"))
+	result := checkIdentity(storage)
+	assert.False(t, result.GetPassed())
+	assert.NotEmpty(t, result.GetHint())
+}
+
+func TestSelfTestReport(t *testing.T) {
+	report := newSelfTestReport([]*pb.SelfTestResult{passedCheck("config", ""), warningCheck("reachability", "", "")})
+	assert.True(t, report.GetPassed())
+	report = newSelfTestReport([]*pb.SelfTestResult{passedCheck("config", ""), failedCheck("storage", "", "")})
+	assert.False(t, report.GetPassed())
+}
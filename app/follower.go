@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	sprawlclient "github.com/sprawl/sprawl/clients/go"
+	"github.com/sprawl/sprawl/database/replicated"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/service"
+)
+
+// followRetryInterval is how long a standby waits before replicating again after the stream to its primary breaks
+const followRetryInterval time.Duration = 5 * time.Second
+
+// follower replicates the storage of a primary into the local one while the node stands by
+type follower struct {
+	primary     string
+	apiKey      string
+	storage     interfaces.Storage
+	logger      interfaces.Logger
+	lock        sync.Mutex
+	connected   bool
+	sequence    uint64
+	lastApplied time.Time
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// start replicates the primary until stop is called, following it again whenever the stream breaks
+func (f *follower) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	f.done = make(chan struct{})
+	go func() {
+		defer close(f.done)
+		for {
+			err := f.follow(ctx)
+			f.setConnected(false)
+			if ctx.Err() != nil {
+				return
+			}
+			f.logger.Warn(errors.E(errors.Op("Replicate "+f.primary), err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(followRetryInterval):
+			}
+		}
+	}()
+}
+
+// stop stops replicating and waits until the changes being applied are done
+func (f *follower) stop() {
+	f.cancel()
+	<-f.done
+}
+
+// follow applies the snapshot and the changes streamed by the primary until the stream breaks
+func (f *follower) follow(ctx context.Context) error {
+	client, err := sprawlclient.New(f.primary, sprawlclient.Options{AuthToken: f.apiKey})
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	defer client.Close()
+
+	stream, err := client.Admin.Replicate(ctx, &pb.Empty{})
+	if err != nil {
+		return err
+	}
+	for {
+		entry, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if entry.GetSnapshotDone() {
+			f.logger.Infof("Replicated a snapshot of %s at sequence %d, following its changes", f.primary, entry.GetSequence())
+			f.setConnected(true)
+			continue
+		}
+		err = replicated.Apply(ctx, f.storage, entry)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Apply replicated change"), err)
+		}
+		f.lock.Lock()
+		f.sequence = entry.GetSequence()
+		f.lastApplied = time.Now()
+		f.lock.Unlock()
+	}
+}
+
+func (f *follower) setConnected(connected bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.connected = connected
+}
+
+// status reports the primary being followed and how far its changes have been applied
+func (f *follower) status() *pb.ReplicationStatus {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	replicationStatus := &pb.ReplicationStatus{
+		Role:      service.RoleFollower,
+		Primary:   f.primary,
+		Connected: f.connected,
+		Sequence:  f.sequence,
+	}
+	if !f.lastApplied.IsZero() {
+		replicationStatus.LastApplied, _ = ptypes.TimestampProto(f.lastApplied)
+	}
+	return replicationStatus
+}
+
+// startFollower puts the node on standby, replicating the primary set in replication.primary until it's promoted
+func (app *App) startFollower() {
+	app.follower = &follower{
+		primary: app.config.GetReplicationPrimary(),
+		apiKey:  app.config.GetReplicationAPIKey(),
+		storage: app.Storage,
+		logger:  app.Logger,
+	}
+	app.Server.SetStandby(true)
+	app.Server.Admin.ReplicationStatus = app.replicationStatus
+	app.Server.Admin.OnPromote = app.promote
+	app.Logger.Infof("Standing by as a follower of %s, promote the node with AdminHandler.Promote", app.follower.primary)
+	app.follower.start()
+}
+
+// replicationStatus reports the follower's status while the node stands by, and nil once it's promoted
+func (app *App) replicationStatus() *pb.ReplicationStatus {
+	app.followerLock.Lock()
+	defer app.followerLock.Unlock()
+	if app.follower == nil {
+		return nil
+	}
+	return app.follower.status()
+}
+
+// following tells if the node is standing by as a follower
+func (app *App) following() bool {
+	app.followerLock.Lock()
+	defer app.followerLock.Unlock()
+	return app.follower != nil
+}
+
+// promote stops following the primary and starts the node with the identity, counters and channels it replicated
+func (app *App) promote(ctx context.Context) error {
+	app.followerLock.Lock()
+	defer app.followerLock.Unlock()
+	if app.follower == nil {
+		return errors.E(errors.Op("Promote"), errors.Invalid, "the node has already been promoted")
+	}
+	app.follower.stop()
+
+	privateKey, publicKey, err := identity.GetIdentity(app.Storage)
+	if !errors.IsEmpty(err) {
+		app.follower.start()
+		return errors.E(errors.Op("Get identity"), err)
+	}
+	app.follower = nil
+	app.P2p.SetIdentity(privateKey, publicKey)
+
+	// Nothing read from the storage before the replicated data arrived may be kept
+	app.Server.Orders.ResetOrderBook()
+	err = app.Server.Orders.LoadCounters(ctx)
+	if !errors.IsEmpty(err) {
+		app.Logger.Error(errors.E(errors.Op("Load counters"), err))
+	}
+
+	app.P2p.Run()
+	app.startOutbox()
+	channels, err := app.Server.Channels.GetAllChannels(ctx, &pb.Empty{})
+	if !errors.IsEmpty(err) {
+		app.Logger.Error(errors.E(errors.Op("Get replicated channels"), err))
+	}
+	for _, channel := range channels.GetChannels() {
+		_, err = app.P2p.Subscribe(channel)
+		if !errors.IsEmpty(err) {
+			app.Logger.Error(errors.E(errors.Op("Subscribe to replicated channel"), err))
+		}
+	}
+
+	app.Server.SetStandby(false)
+	app.startJobs()
+	app.Logger.Infof("Promoted to primary as %s on %d channels", app.P2p.GetHostIDString(), len(channels.GetChannels()))
+	return nil
+}
@@ -1,25 +1,17 @@
-package main
+package app
 
 import (
-	"github.com/sprawl/sprawl/app"
-	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var appConfig *config.Config
-var logger *zap.Logger
-var log *zap.SugaredLogger
-var configPath = "./config/default"
-
-func init() {
-	// Read config
-	appConfig = &config.Config{}
-	appConfig.ReadConfig(configPath)
-
-	// Read logLevel from appConfig
+// NewLogger builds a zap logger with the level and format set in the config
+func NewLogger(config interfaces.Config) (*zap.SugaredLogger, error) {
+	// Read logLevel from config
 	var logLevel zapcore.Level
-	switch appConfig.GetLogLevel() {
+	switch config.GetLogLevel() {
 	case "DEBUG":
 		logLevel = zapcore.DebugLevel
 	case "INFO":
@@ -34,13 +26,12 @@ func init() {
 		logLevel = zapcore.InfoLevel
 	}
 
-	// Read logFormat ("console"/"json") from appConfig
-	logFormat := appConfig.GetLogFormat()
+	// Read logFormat ("console"/"json") from config
+	logFormat := config.GetLogFormat()
 	if logFormat == "" {
 		logFormat = "json"
 	}
 
-	// Create logger
 	cfg := zap.Config{
 		Encoding:         logFormat,
 		Level:            zap.NewAtomicLevelAt(logLevel),
@@ -56,12 +47,9 @@ func init() {
 			EncodeCaller: zapcore.ShortCallerEncoder,
 		},
 	}
-	logger, _ = cfg.Build()
-	log = logger.Sugar()
-}
-
-func main() {
-	app := &app.App{}
-	app.InitServices(appConfig, log)
-	app.Run()
+	logger, err := cfg.Build()
+	if err != nil {
+		return nil, errors.E(errors.Op("Build logger"), err)
+	}
+	return logger.Sugar(), nil
 }
@@ -0,0 +1,156 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// AmountDecimals is the fixed precision of order amounts, which covers the decimals of nearly every token
+const AmountDecimals uint32 = 18
+
+// symbolPattern keeps the separators of channel IDs, like "," and "#", out of asset symbols
+var symbolPattern = regexp.MustCompile(`^[A-Z0-9]{1,12}$`)
+
+// builtinAssets are the asset symbols that are known without registering them
+var builtinAssets = map[string]bool{
+	"ADA": true, "ATOM": true, "BAT": true, "BCH": true, "BNB": true, "BSV": true, "BTC": true,
+	"DAI": true, "DASH": true, "DOGE": true, "EOS": true, "ETC": true, "ETH": true, "EUR": true,
+	"LINK": true, "LTC": true, "MKR": true, "NEO": true, "TRX": true, "USD": true, "USDC": true,
+	"USDT": true, "XLM": true, "XMR": true, "XRP": true, "XTZ": true, "ZEC": true, "ZRX": true,
+}
+
+// Registry stores the metadata of assets in Storage, keyed by their symbol
+type Registry struct {
+	Storage interfaces.Storage
+}
+
+// NewRegistry returns a Registry on top of storage
+func NewRegistry(storage interfaces.Storage) *Registry {
+	return &Registry{Storage: storage}
+}
+
+func getAssetStorageKey(symbol string) []byte {
+	return []byte(string(interfaces.AssetPrefix) + symbol)
+}
+
+// Canonical normalizes the case and whitespace of an asset symbol
+func Canonical(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+// ValidateSymbol checks that a canonical symbol has only letters and digits
+func ValidateSymbol(symbol string) error {
+	if symbol == "" {
+		return errors.E(errors.Op("Validate asset symbol"), errors.Invalid, "asset symbol is empty")
+	}
+	if !symbolPattern.MatchString(symbol) {
+		return errors.E(errors.Op("Validate asset symbol"), errors.Invalid, "asset symbol "+symbol+" isn't 1 to 12 letters and digits")
+	}
+	return nil
+}
+
+// AmountUnit returns the smallest amount of an asset with the given decimals, in the fixed precision of order amounts
+func AmountUnit(decimals uint32) uint64 {
+	unit := uint64(1)
+	for i := decimals; i < AmountDecimals; i++ {
+		unit *= 10
+	}
+	return unit
+}
+
+// Register stores the metadata of an asset, replacing any earlier registration of the symbol
+func (r *Registry) Register(ctx context.Context, asset *pb.Asset) (*pb.Asset, error) {
+	registered := &pb.Asset{
+		Symbol:          Canonical(asset.GetSymbol()),
+		Decimals:        asset.GetDecimals(),
+		Chain:           strings.TrimSpace(asset.GetChain()),
+		ContractAddress: strings.TrimSpace(asset.GetContractAddress()),
+	}
+	err := ValidateSymbol(registered.GetSymbol())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	if registered.GetDecimals() > AmountDecimals {
+		return nil, errors.E(errors.Op("Validate asset decimals"), errors.Invalid, fmt.Sprintf("asset can't have more than %d decimals", AmountDecimals))
+	}
+
+	data, err := proto.Marshal(registered)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal asset"), err)
+	}
+	err = r.Storage.Put(ctx, getAssetStorageKey(registered.GetSymbol()), data)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put asset"), err)
+	}
+	return registered, nil
+}
+
+// Get fetches the metadata of a registered asset
+func (r *Registry) Get(ctx context.Context, symbol string) (*pb.Asset, error) {
+	data, err := r.Storage.Get(ctx, getAssetStorageKey(Canonical(symbol)))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get asset"), err)
+	}
+	asset := &pb.Asset{}
+	err = proto.Unmarshal(data, asset)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal asset"), err)
+	}
+	return asset, nil
+}
+
+// IsRegistered tells if an asset has been registered
+func (r *Registry) IsRegistered(ctx context.Context, symbol string) (bool, error) {
+	return r.Storage.Has(ctx, getAssetStorageKey(Canonical(symbol)))
+}
+
+// GetAll fetches all registered assets, sorted by symbol
+func (r *Registry) GetAll(ctx context.Context) ([]*pb.Asset, error) {
+	data, err := r.Storage.GetAllWithPrefix(ctx, string(interfaces.AssetPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get all assets"), err)
+	}
+	assets := make([]*pb.Asset, 0, len(data))
+	for _, value := range data {
+		asset := &pb.Asset{}
+		err = proto.Unmarshal([]byte(value), asset)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Unmarshal asset"), err)
+		}
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].GetSymbol() < assets[j].GetSymbol()
+	})
+	return assets, nil
+}
+
+// Check rejects malformed symbols, and unless custom assets are allowed, symbols that are neither built in nor registered
+func (r *Registry) Check(ctx context.Context, allowCustomAssets bool, symbols ...string) error {
+	for _, symbol := range symbols {
+		symbol = Canonical(symbol)
+		err := ValidateSymbol(symbol)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+		if allowCustomAssets || builtinAssets[symbol] {
+			continue
+		}
+		registered, err := r.IsRegistered(ctx, symbol)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check assets"), err)
+		}
+		if !registered {
+			return errors.E(errors.Op("Check assets"), errors.Invalid, "unrecognized asset "+symbol)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package assets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+const customAsset string = "NOTACOIN"
+
+func newTestRegistry() *Registry {
+	return NewRegistry(&inmemory.Storage{Db: make(map[string]string)})
+}
+
+func TestValidateSymbol(t *testing.T) {
+	assert.NoError(t, ValidateSymbol("ETH"))
+	assert.NoError(t, ValidateSymbol("USDC"))
+	assert.True(t, errors.Is(errors.Invalid, ValidateSymbol("")))
+	assert.True(t, errors.Is(errors.Invalid, ValidateSymbol("BTC,ETH")))
+	assert.True(t, errors.Is(errors.Invalid, ValidateSymbol("eth")))
+	assert.True(t, errors.Is(errors.Invalid, ValidateSymbol("AVERYLONGTOKEN")))
+}
+
+func TestAmountUnit(t *testing.T) {
+	assert.Equal(t, uint64(1), AmountUnit(AmountDecimals))
+	assert.Equal(t, uint64(10000000000), AmountUnit(8))
+	assert.Equal(t, uint64(1000000000000000000), AmountUnit(0))
+}
+
+func TestRegister(t *testing.T) {
+	registry := newTestRegistry()
+	asset, err := registry.Register(ctx, &pb.Asset{Symbol: " wbtc ", Decimals: 8, Chain: "ethereum", ContractAddress: "0x2260fac5e5542a773aa44fbcfedf7c193bc2c599"})
+	assert.NoError(t, err)
+	assert.Equal(t, "WBTC", asset.GetSymbol())
+
+	stored, err := registry.Get(ctx, "wbtc")
+	assert.NoError(t, err)
+	assert.Equal(t, asset, stored)
+
+	_, err = registry.Register(ctx, &pb.Asset{Symbol: "BAD#"})
+	assert.True(t, errors.Is(errors.Invalid, err))
+	_, err = registry.Register(ctx, &pb.Asset{Symbol: customAsset, Decimals: AmountDecimals + 1})
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	// Registering again updates the metadata
+	_, err = registry.Register(ctx, &pb.Asset{Symbol: "DAI", Decimals: 18})
+	assert.NoError(t, err)
+	_, err = registry.Register(ctx, &pb.Asset{Symbol: "WBTC", Decimals: 6})
+	assert.NoError(t, err)
+	all, err := registry.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "DAI", all[0].GetSymbol())
+	assert.Equal(t, uint32(6), all[1].GetDecimals())
+}
+
+func TestCheck(t *testing.T) {
+	registry := newTestRegistry()
+	assert.NoError(t, registry.Check(ctx, false, "ETH", "btc"))
+	assert.True(t, errors.Is(errors.Invalid, registry.Check(ctx, false, "ETH", customAsset)))
+	assert.NoError(t, registry.Check(ctx, true, "ETH", customAsset))
+	assert.True(t, errors.Is(errors.Invalid, registry.Check(ctx, true, "ETH", "")))
+
+	_, err := registry.Register(ctx, &pb.Asset{Symbol: customAsset, Decimals: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, registry.Check(ctx, false, "ETH", customAsset))
+}
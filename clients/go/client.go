@@ -0,0 +1,131 @@
+// Package sprawlclient is a thin wrapper around the generated gRPC stubs of Sprawl,
+// adding timeouts, retries and authentication to every call.
+package sprawlclient
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const defaultTimeout time.Duration = 10 * time.Second
+const defaultRetryBackoff time.Duration = 100 * time.Millisecond
+
+// Options configures a Client. The zero value connects without TLS, authentication or retries.
+type Options struct {
+	// Timeout is applied to every call that doesn't already have a deadline. Defaults to 10 seconds.
+	Timeout time.Duration
+	// Retries is how many times a call is retried when the node is unavailable
+	Retries uint
+	// RetryBackoff is the wait before the first retry, doubled on every retry. Defaults to 100 milliseconds.
+	RetryBackoff time.Duration
+	// AuthToken is sent as a bearer token in the authorization header of every call
+	AuthToken string
+	// TLS enables transport security with the given configuration
+	TLS *tls.Config
+}
+
+// Client holds a connection to a Sprawl node and a typed client for each of its services
+type Client struct {
+	Orders   pb.OrderHandlerClient
+	Channels pb.ChannelHandlerClient
+	Node     pb.NodeHandlerClient
+	Admin    pb.AdminHandlerClient
+	Assets   pb.AssetHandlerClient
+	conn     *grpc.ClientConn
+}
+
+// tokenAuth implements credentials.PerRPCCredentials with a bearer token
+type tokenAuth struct {
+	token  string
+	secure bool
+}
+
+func (t tokenAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t tokenAuth) RequireTransportSecurity() bool {
+	return t.secure
+}
+
+func (opts Options) timeout() time.Duration {
+	if opts.Timeout == 0 {
+		return defaultTimeout
+	}
+	return opts.Timeout
+}
+
+func (opts Options) retryBackoff() time.Duration {
+	if opts.RetryBackoff == 0 {
+		return defaultRetryBackoff
+	}
+	return opts.RetryBackoff
+}
+
+// timeoutInterceptor adds the configured timeout to calls without a deadline
+func (opts Options) timeoutInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout())
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, callOpts...)
+}
+
+// retryInterceptor retries calls that failed because the node was unavailable, backing off exponentially
+func (opts Options) retryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+	backoff := opts.retryBackoff()
+	err := invoker(ctx, method, req, reply, cc, callOpts...)
+	for retry := uint(0); retry < opts.Retries && status.Code(err) == codes.Unavailable; retry++ {
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+		err = invoker(ctx, method, req, reply, cc, callOpts...)
+	}
+	return err
+}
+
+// New connects to the Sprawl node at addr, like localhost:1337
+func New(addr string, opts Options) (*Client, error) {
+	dialOpts := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(opts.retryInterceptor, opts.timeoutInterceptor),
+	}
+	if opts.TLS != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(opts.TLS)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	if opts.AuthToken != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenAuth{token: opts.AuthToken, secure: opts.TLS != nil}))
+	}
+
+	conn, err := grpc.Dial(addr, dialOpts...)
+	if err != nil {
+		return nil, errors.E(errors.Op("Dial "+addr), err)
+	}
+
+	return &Client{
+		Orders:   pb.NewOrderHandlerClient(conn),
+		Channels: pb.NewChannelHandlerClient(conn),
+		Node:     pb.NewNodeHandlerClient(conn),
+		Admin:    pb.NewAdminHandlerClient(conn),
+		Assets:   pb.NewAssetHandlerClient(conn),
+		conn:     conn,
+	}, nil
+}
+
+// Close closes the connection to the node
+func (client *Client) Close() error {
+	return client.conn.Close()
+}
@@ -0,0 +1,70 @@
+package sprawlclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/service"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testPort uint = 1338
+const testAddr string = "localhost:1338"
+
+func TestRetryInterceptor(t *testing.T) {
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "node unavailable")
+		}
+		return nil
+	}
+
+	opts := Options{Retries: 1, RetryBackoff: time.Millisecond}
+	err := opts.retryInterceptor(context.Background(), "", nil, nil, nil, invoker)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	opts.Retries = 2
+	err = opts.retryInterceptor(context.Background(), "", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil
+	}
+
+	opts := Options{Timeout: time.Minute}
+	err := opts.timeoutInterceptor(context.Background(), "", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestClient(t *testing.T) {
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	server := service.NewServer(nil, storage, nil, nil)
+	go server.Run(testPort)
+	defer server.Close()
+
+	client, err := New(testAddr, Options{Retries: 5})
+	assert.NoError(t, err)
+	defer client.Close()
+
+	orders, err := client.Orders.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, orders.GetOrders())
+}
@@ -0,0 +1,133 @@
+package lightclient
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// OrderUpdate is a change to the order book whose order has been verified
+type OrderUpdate struct {
+	ChannelID []byte
+	Operation pb.Operation
+	Order     *pb.Order
+}
+
+// OrderBook keeps the open orders of channels on the client's side. Every order is checked against its maker's
+// signature before it goes in, so a node can't make up orders or change their prices and amounts. The state
+// of an order, like a lock, isn't signed by the maker, so it's taken from the node as it is.
+type OrderBook struct {
+	// rejected comes first so it's 64-bit aligned for atomic access on 32-bit platforms
+	rejected uint64
+	channels map[string]map[string]*pb.Order
+	lock     sync.RWMutex
+}
+
+// NewOrderBook returns an empty OrderBook
+func NewOrderBook() *OrderBook {
+	return &OrderBook{channels: make(map[string]map[string]*pb.Order)}
+}
+
+// Apply verifies the order operations of a websocket frame and applies them to the book.
+// It returns the changes that were made. Orders that fail verification are left out and counted as rejected.
+func (book *OrderBook) Apply(frame []byte) ([]*OrderUpdate, error) {
+	messages, err := DecodeFrame(frame)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	updates := make([]*OrderUpdate, 0, len(messages))
+	for _, message := range messages {
+		update, err := book.apply(message)
+		if !errors.IsEmpty(err) {
+			atomic.AddUint64(&book.rejected, 1)
+			continue
+		}
+		if update != nil {
+			updates = append(updates, update)
+		}
+	}
+	return updates, nil
+}
+
+// apply applies a single order operation, returning nil for messages that aren't about orders
+func (book *OrderBook) apply(message *pb.WireMessage) (*OrderUpdate, error) {
+	switch message.GetOperation() {
+	case pb.Operation_CREATE, pb.Operation_DELETE, pb.Operation_LOCK, pb.Operation_UNLOCK, pb.Operation_FILL:
+	default:
+		return nil, nil
+	}
+	order := &pb.Order{}
+	err := proto.Unmarshal(message.GetData(), order)
+	if err != nil {
+		return nil, errors.E(errors.Op("Unmarshal order"), errors.Malformed, err)
+	}
+	_, err = identity.VerifyOrder(order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Verify order"), err)
+	}
+
+	book.lock.Lock()
+	defer book.lock.Unlock()
+	channelID := string(message.GetChannelID())
+	if message.GetOperation() == pb.Operation_DELETE || order.GetState() == pb.State_FILLED {
+		delete(book.channels[channelID], string(order.GetId()))
+	} else {
+		book.put(channelID, order)
+	}
+	return &OrderUpdate{ChannelID: message.GetChannelID(), Operation: message.GetOperation(), Order: order}, nil
+}
+
+// put stores the order on the channel. The caller must hold book.lock.
+func (book *OrderBook) put(channelID string, order *pb.Order) {
+	orders, ok := book.channels[channelID]
+	if !ok {
+		orders = make(map[string]*pb.Order)
+		book.channels[channelID] = orders
+	}
+	orders[string(order.GetId())] = order
+}
+
+// Load adds orders fetched some other way to the book, for example with GetAllOrders over gRPC-web when the client starts.
+// The orders are verified like the ones pushed over the websocket, and the ones that fail are left out and counted as rejected.
+func (book *OrderBook) Load(channelID []byte, orders []*pb.Order) {
+	book.lock.Lock()
+	defer book.lock.Unlock()
+	for _, order := range orders {
+		if _, err := identity.VerifyOrder(order); !errors.IsEmpty(err) {
+			atomic.AddUint64(&book.rejected, 1)
+			continue
+		}
+		book.put(string(channelID), order)
+	}
+}
+
+// Orders returns the orders on the channel from the lowest price to the highest, oldest first at the same price
+func (book *OrderBook) Orders(channelID []byte) []*pb.Order {
+	book.lock.RLock()
+	orders := make([]*pb.Order, 0, len(book.channels[string(channelID)]))
+	for _, order := range book.channels[string(channelID)] {
+		orders = append(orders, order)
+	}
+	book.lock.RUnlock()
+
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].GetPrice() != orders[j].GetPrice() {
+			return orders[i].GetPrice() < orders[j].GetPrice()
+		}
+		created1, _ := ptypes.Timestamp(orders[i].GetCreated())
+		created2, _ := ptypes.Timestamp(orders[j].GetCreated())
+		return created1.Before(created2)
+	})
+	return orders
+}
+
+// Rejected returns how many orders have been left out for failing verification
+func (book *OrderBook) Rejected() uint64 {
+	return atomic.LoadUint64(&book.rejected)
+}
@@ -0,0 +1,105 @@
+// Package lightclient follows the order book of a Sprawl node without having to trust it. It only speaks the node's
+// websocket API, verifies the maker's signature of every order it receives and keeps the order book itself.
+// It compiles to WebAssembly with GOOS=js GOARCH=wasm, where it connects with the browser's WebSocket.
+package lightclient
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const defaultBufferSize int = 64
+
+// Options configures a Client. The zero value follows every message.
+type Options struct {
+	// Subscription filters the orders the node pushes
+	Subscription *pb.Subscription
+	// BufferSize is how many order updates are buffered for the reader. Defaults to 64.
+	BufferSize int
+}
+
+func (opts Options) bufferSize() int {
+	if opts.BufferSize == 0 {
+		return defaultBufferSize
+	}
+	return opts.BufferSize
+}
+
+// conn is a websocket connection. It uses the browser's WebSocket under js/wasm and gorilla/websocket elsewhere.
+type conn interface {
+	ReadMessage() ([]byte, error)
+	WriteMessage(data []byte) error
+	Close() error
+}
+
+// Client keeps a verified order book up to date from the websocket API of a Sprawl node
+type Client struct {
+	conn    conn
+	book    *OrderBook
+	updates chan *OrderUpdate
+}
+
+// Dial connects to the websocket API of a Sprawl node at url, like ws://localhost:3000/.
+// The client doesn't reconnect, so a dropped connection closes OrderUpdates and a new client has to be dialed.
+func Dial(ctx context.Context, url string, opts Options) (*Client, error) {
+	conn, err := dial(ctx, url)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Dial "+url), err)
+	}
+	if opts.Subscription != nil {
+		data, err := proto.Marshal(opts.Subscription)
+		if err != nil {
+			conn.Close()
+			return nil, errors.E(errors.Op("Marshal subscription"), err)
+		}
+		err = conn.WriteMessage(data)
+		if err != nil {
+			conn.Close()
+			return nil, errors.E(errors.Op("Send subscription"), err)
+		}
+	}
+
+	client := &Client{
+		conn:    conn,
+		book:    NewOrderBook(),
+		updates: make(chan *OrderUpdate, opts.bufferSize()),
+	}
+	go client.run()
+	return client, nil
+}
+
+// OrderUpdates returns the verified changes to the order book. It's closed once the connection is.
+func (client *Client) OrderUpdates() <-chan *OrderUpdate {
+	return client.updates
+}
+
+// Book returns the order book kept by the client
+func (client *Client) Book() *OrderBook {
+	return client.book
+}
+
+// Close disconnects from the node
+func (client *Client) Close() error {
+	return client.conn.Close()
+}
+
+// run applies the frames on the connection to the order book until the connection fails
+func (client *Client) run() {
+	defer close(client.updates)
+	for {
+		data, err := client.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		updates, err := client.book.Apply(data)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		for _, update := range updates {
+			client.updates <- update
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package lightclient
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/gorilla/websocket"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChannelID string = "BTC,ETH"
+const testTimeout time.Duration = 5 * time.Second
+
+func newSignedOrder(t *testing.T, id string, price float32) *pb.Order {
+	privateKey, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	makerID, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	publicKeyBytes, err := crypto.MarshalPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	order := &pb.Order{Id: []byte(id), Created: ptypes.TimestampNow(), Asset: "ETH", CounterAsset: "BTC", Amount: 1, Price: price, MakerPeerID: []byte(makerID), MakerPubKey: publicKeyBytes}
+	signingBytes, err := identity.GetOrderSigningBytes(order)
+	assert.NoError(t, err)
+	order.Signature, err = privateKey.Sign(signingBytes)
+	assert.NoError(t, err)
+	return order
+}
+
+func newFrame(t *testing.T, operation pb.Operation, order *pb.Order) []byte {
+	data, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	frame, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(testChannelID), Operation: operation, Data: data})
+	assert.NoError(t, err)
+	return frame
+}
+
+func TestOrderBook(t *testing.T) {
+	book := NewOrderBook()
+	expensive := newSignedOrder(t, "expensive", 2)
+	cheap := newSignedOrder(t, "cheap", 1)
+
+	updates, err := book.Apply(newFrame(t, pb.Operation_CREATE, expensive))
+	assert.NoError(t, err)
+	assert.Len(t, updates, 1)
+	_, err = book.Apply(newFrame(t, pb.Operation_CREATE, cheap))
+	assert.NoError(t, err)
+	orders := book.Orders([]byte(testChannelID))
+	assert.Len(t, orders, 2)
+	assert.Equal(t, []byte("cheap"), orders[0].GetId())
+
+	// A node can't change the price of someone else's order
+	tampered := *cheap
+	tampered.Price = 0.5
+	updates, err = book.Apply(newFrame(t, pb.Operation_CREATE, &tampered))
+	assert.NoError(t, err)
+	assert.Empty(t, updates)
+	assert.Equal(t, uint64(1), book.Rejected())
+	assert.Equal(t, float32(1), book.Orders([]byte(testChannelID))[0].GetPrice())
+
+	// The state isn't signed, so locks are taken as they are
+	locked := *cheap
+	locked.State = pb.State_LOCKED
+	_, err = book.Apply(newFrame(t, pb.Operation_LOCK, &locked))
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, book.Orders([]byte(testChannelID))[0].GetState())
+
+	_, err = book.Apply(newFrame(t, pb.Operation_DELETE, cheap))
+	assert.NoError(t, err)
+	assert.Len(t, book.Orders([]byte(testChannelID)), 1)
+
+	// Messages that aren't about orders are skipped
+	updates, err = book.Apply(newFrame(t, pb.Operation_MEMBERSHIP, cheap))
+	assert.NoError(t, err)
+	assert.Empty(t, updates)
+
+	_, err = book.Apply([]byte("not a frame"))
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	book := NewOrderBook()
+	forged := newSignedOrder(t, "forged", 1)
+	forged.Amount = 1000
+	book.Load([]byte(testChannelID), []*pb.Order{newSignedOrder(t, "order", 1), forged})
+	assert.Len(t, book.Orders([]byte(testChannelID)), 1)
+	assert.Equal(t, uint64(1), book.Rejected())
+}
+
+func TestDial(t *testing.T) {
+	order := newSignedOrder(t, "order", 1)
+	subscriptions := make(chan *pb.Subscription, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		subscription := &pb.Subscription{}
+		assert.NoError(t, proto.Unmarshal(data, subscription))
+		subscriptions <- subscription
+
+		batch, err := proto.Marshal(&pb.WireMessageBatch{Messages: []*pb.WireMessage{{ChannelID: []byte(testChannelID), Operation: pb.Operation_CREATE, Data: mustMarshal(t, order)}}})
+		assert.NoError(t, err)
+		conn.WriteMessage(websocket.BinaryMessage, batch)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client, err := Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), Options{Subscription: &pb.Subscription{Asset: "ETH"}})
+	assert.NoError(t, err)
+	defer client.Close()
+
+	assert.Equal(t, "ETH", (<-subscriptions).GetAsset())
+	select {
+	case update := <-client.OrderUpdates():
+		assert.Equal(t, pb.Operation_CREATE, update.Operation)
+		assert.Equal(t, order.GetId(), update.Order.GetId())
+	case <-time.After(testTimeout):
+		t.Fatal("no order update")
+	}
+	assert.Len(t, client.Book().Orders([]byte(testChannelID)), 1)
+}
+
+func mustMarshal(t *testing.T, order *pb.Order) []byte {
+	data, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	return data
+}
@@ -0,0 +1,32 @@
+//go:build !js
+// +build !js
+
+package lightclient
+
+import (
+	"context"
+
+	"github.com/gorilla/websocket"
+)
+
+// socketConn is a websocket connection made with gorilla/websocket
+type socketConn struct {
+	*websocket.Conn
+}
+
+func dial(ctx context.Context, url string) (conn, error) {
+	connection, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return socketConn{connection}, nil
+}
+
+func (c socketConn) ReadMessage() ([]byte, error) {
+	_, data, err := c.Conn.ReadMessage()
+	return data, err
+}
+
+func (c socketConn) WriteMessage(data []byte) error {
+	return c.Conn.WriteMessage(websocket.BinaryMessage, data)
+}
@@ -0,0 +1,130 @@
+//go:build js && wasm
+// +build js,wasm
+
+package lightclient
+
+import (
+	"context"
+	"sync"
+	"syscall/js"
+
+	"github.com/sprawl/sprawl/errors"
+)
+
+// browserBufferSize is how many frames are queued for ReadMessage. The browser's callbacks can't block,
+// so a reader that falls further behind loses the connection.
+const browserBufferSize int = 1024
+
+// browserConn is a websocket connection made with the browser's WebSocket
+type browserConn struct {
+	socket    js.Value
+	events    []string
+	callbacks []js.Func
+	frames    chan []byte
+	done      chan struct{}
+	err       error
+	closeOnce sync.Once
+}
+
+func dial(ctx context.Context, url string) (conn, error) {
+	c := &browserConn{
+		socket: js.Global().Get("WebSocket").New(url),
+		frames: make(chan []byte, browserBufferSize),
+		done:   make(chan struct{}),
+	}
+	c.socket.Set("binaryType", "arraybuffer")
+
+	opened := make(chan struct{})
+	c.on("open", func(event js.Value) {
+		close(opened)
+	})
+	c.on("message", func(event js.Value) {
+		data := event.Get("data")
+		var frame []byte
+		if data.Type() == js.TypeString {
+			frame = []byte(data.String())
+		} else {
+			array := js.Global().Get("Uint8Array").New(data)
+			frame = make([]byte, array.Get("length").Int())
+			js.CopyBytesToGo(frame, array)
+		}
+		select {
+		case c.frames <- frame:
+		default:
+			c.fail(errors.E(errors.Op("Queue websocket frame"), "the reader fell behind"))
+		}
+	})
+	c.on("error", func(event js.Value) {
+		c.fail(errors.E(errors.Op("Websocket"), "connection failed"))
+	})
+	c.on("close", func(event js.Value) {
+		c.fail(errors.E(errors.Op("Websocket"), "connection closed"))
+	})
+
+	select {
+	case <-opened:
+		return c, nil
+	case <-c.done:
+		return nil, c.err
+	case <-ctx.Done():
+		c.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// on calls handler for the socket's events of the given type
+func (c *browserConn) on(eventType string, handler func(event js.Value)) {
+	callback := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		handler(args[0])
+		return nil
+	})
+	c.events = append(c.events, eventType)
+	c.callbacks = append(c.callbacks, callback)
+	c.socket.Call("addEventListener", eventType, callback)
+}
+
+// fail closes the connection with err, once. The callbacks are removed before they're released,
+// since the socket still fires events while it closes.
+func (c *browserConn) fail(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.done)
+		for i, callback := range c.callbacks {
+			c.socket.Call("removeEventListener", c.events[i], callback)
+			callback.Release()
+		}
+		c.socket.Call("close")
+	})
+}
+
+// ReadMessage returns the next frame, reading the queued ones before reporting that the connection is closed
+func (c *browserConn) ReadMessage() ([]byte, error) {
+	select {
+	case frame := <-c.frames:
+		return frame, nil
+	default:
+	}
+	select {
+	case frame := <-c.frames:
+		return frame, nil
+	case <-c.done:
+		return nil, c.err
+	}
+}
+
+func (c *browserConn) WriteMessage(data []byte) error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+	}
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	c.socket.Call("send", array)
+	return nil
+}
+
+func (c *browserConn) Close() error {
+	c.fail(errors.E(errors.Op("Websocket"), "connection closed"))
+	return nil
+}
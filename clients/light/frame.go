@@ -0,0 +1,42 @@
+package lightclient
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// DecodeFrame reads the messages of a websocket frame, which holds either a single WireMessage or,
+// if the node batches its messages, a WireMessageBatch. Neither has fields of the other, so a
+// frame that isn't a batch unmarshals to an empty one.
+func DecodeFrame(data []byte) ([]*pb.WireMessage, error) {
+	batch := &pb.WireMessageBatch{}
+	if err := proto.Unmarshal(data, batch); err == nil && len(batch.GetMessages()) > 0 {
+		return batch.GetMessages(), nil
+	}
+	message, err := Decode(data)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return []*pb.WireMessage{message}, nil
+}
+
+// Decode reads a WireMessage written either as protobuf, which the node sends, or as JSON, which proxies may translate it to
+func Decode(data []byte) (*pb.WireMessage, error) {
+	message := &pb.WireMessage{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		err := jsonpb.Unmarshal(bytes.NewReader(trimmed), message)
+		if err != nil {
+			return nil, errors.E(errors.Op("Unmarshal JSON message"), errors.Malformed, err)
+		}
+		return message, nil
+	}
+	err := proto.Unmarshal(data, message)
+	if err != nil {
+		return nil, errors.E(errors.Op("Unmarshal message"), errors.Malformed, err)
+	}
+	return message, nil
+}
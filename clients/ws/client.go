@@ -0,0 +1,252 @@
+// Package wsclient follows the order feed of a Sprawl node over its websocket API,
+// reconnecting and subscribing again whenever the connection drops.
+package wsclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	lightclient "github.com/sprawl/sprawl/clients/light"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const defaultReconnectBackoff time.Duration = 500 * time.Millisecond
+const defaultMaxReconnectBackoff time.Duration = 30 * time.Second
+const defaultBufferSize int = 64
+
+// Options configures a Client. The zero value follows every message and reconnects with the default backoff.
+type Options struct {
+	// Subscription filters the orders the node pushes. It's sent again on every reconnect.
+	Subscription *pb.Subscription
+	// ReconnectBackoff is the wait before the first reconnect attempt, doubled on every failed attempt. Defaults to 500 milliseconds.
+	ReconnectBackoff time.Duration
+	// MaxReconnectBackoff caps the wait between reconnect attempts. Defaults to 30 seconds.
+	MaxReconnectBackoff time.Duration
+	// BufferSize is how many order updates are buffered for the reader. Defaults to 64.
+	BufferSize int
+	// Dialer opens the websocket connections. Defaults to websocket.DefaultDialer.
+	Dialer *websocket.Dialer
+}
+
+// OrderUpdate is an order operation pushed by the node
+type OrderUpdate struct {
+	ChannelID []byte
+	Operation pb.Operation
+	Order     *pb.Order
+}
+
+// Client holds a websocket connection to a Sprawl node
+type Client struct {
+	url          string
+	opts         Options
+	ctx          context.Context
+	cancel       context.CancelFunc
+	conn         *websocket.Conn
+	subscription *pb.Subscription
+	lock         sync.Mutex
+	updates      chan *OrderUpdate
+}
+
+func (opts Options) reconnectBackoff() time.Duration {
+	if opts.ReconnectBackoff == 0 {
+		return defaultReconnectBackoff
+	}
+	return opts.ReconnectBackoff
+}
+
+func (opts Options) maxReconnectBackoff() time.Duration {
+	if opts.MaxReconnectBackoff == 0 {
+		return defaultMaxReconnectBackoff
+	}
+	return opts.MaxReconnectBackoff
+}
+
+func (opts Options) bufferSize() int {
+	if opts.BufferSize == 0 {
+		return defaultBufferSize
+	}
+	return opts.BufferSize
+}
+
+func (opts Options) dialer() *websocket.Dialer {
+	if opts.Dialer == nil {
+		return websocket.DefaultDialer
+	}
+	return opts.Dialer
+}
+
+// Dial connects to the websocket API of a Sprawl node at url, like ws://localhost:3000/.
+// The first connection has to succeed, after which the client keeps reconnecting until it's closed.
+func Dial(ctx context.Context, url string, opts Options) (*Client, error) {
+	client := &Client{
+		url:          url,
+		opts:         opts,
+		subscription: opts.Subscription,
+		updates:      make(chan *OrderUpdate, opts.bufferSize()),
+	}
+	client.ctx, client.cancel = context.WithCancel(context.Background())
+
+	conn, err := client.connect(ctx)
+	if !errors.IsEmpty(err) {
+		client.cancel()
+		return nil, err
+	}
+	go client.run(conn)
+	return client, nil
+}
+
+// OrderUpdates returns the order operations pushed by the node. It's closed once the client is closed.
+// A reader that falls behind holds up the connection, and the node drops it if pings go unanswered for too long.
+func (client *Client) OrderUpdates() <-chan *OrderUpdate {
+	return client.updates
+}
+
+// Subscribe replaces the filters of the order feed, also for any later reconnects. An empty subscription removes all filters.
+func (client *Client) Subscribe(subscription *pb.Subscription) error {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	client.subscription = subscription
+	if client.conn == nil {
+		return nil
+	}
+	return client.sendSubscription(client.conn)
+}
+
+// Close disconnects from the node and stops reconnecting
+func (client *Client) Close() error {
+	client.cancel()
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.conn == nil {
+		return nil
+	}
+	return client.conn.Close()
+}
+
+// connect dials the node and sends the current subscription. The caller must not hold client.lock.
+func (client *Client) connect(ctx context.Context) (*websocket.Conn, error) {
+	conn, _, err := client.opts.dialer().DialContext(ctx, client.url, nil)
+	if err != nil {
+		return nil, errors.E(errors.Op("Dial "+client.url), err)
+	}
+
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	// Close may have been called while dialing
+	if client.ctx.Err() != nil {
+		conn.Close()
+		return nil, errors.E(errors.Op("Dial "+client.url), client.ctx.Err())
+	}
+	err = client.sendSubscription(conn)
+	if !errors.IsEmpty(err) {
+		conn.Close()
+		return nil, err
+	}
+	client.conn = conn
+	return conn, nil
+}
+
+// sendSubscription sends the current subscription, if there is one. The caller must hold client.lock.
+func (client *Client) sendSubscription(conn *websocket.Conn) error {
+	if client.subscription == nil {
+		return nil
+	}
+	data, err := proto.Marshal(client.subscription)
+	if err != nil {
+		return errors.E(errors.Op("Marshal subscription"), err)
+	}
+	err = conn.WriteMessage(websocket.BinaryMessage, data)
+	if err != nil {
+		return errors.E(errors.Op("Send subscription"), err)
+	}
+	return nil
+}
+
+// run reads from the connection, reconnecting whenever it drops, until the client is closed
+func (client *Client) run(conn *websocket.Conn) {
+	defer close(client.updates)
+	for conn != nil {
+		client.read(conn)
+		client.lock.Lock()
+		if client.conn == conn {
+			client.conn = nil
+		}
+		client.lock.Unlock()
+		conn.Close()
+		conn = client.reconnect()
+	}
+}
+
+// reconnect dials the node with an exponential backoff. It returns nil once the client is closed.
+func (client *Client) reconnect() *websocket.Conn {
+	backoff := client.opts.reconnectBackoff()
+	for {
+		select {
+		case <-time.After(backoff):
+		case <-client.ctx.Done():
+			return nil
+		}
+		conn, err := client.connect(client.ctx)
+		if errors.IsEmpty(err) {
+			return conn
+		}
+		backoff *= 2
+		if backoff > client.opts.maxReconnectBackoff() {
+			backoff = client.opts.maxReconnectBackoff()
+		}
+	}
+}
+
+// read passes the order operations on the connection to OrderUpdates until the connection fails
+func (client *Client) read(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		messages, err := decodeFrame(data)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		for _, message := range messages {
+			update, ok := toOrderUpdate(message)
+			if !ok {
+				continue
+			}
+			select {
+			case client.updates <- update:
+			case <-client.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// decodeFrame reads the messages of a frame, which holds either a single WireMessage or a WireMessageBatch
+func decodeFrame(data []byte) ([]*pb.WireMessage, error) {
+	return lightclient.DecodeFrame(data)
+}
+
+// decode reads a WireMessage written either as protobuf or as JSON
+func decode(data []byte) (*pb.WireMessage, error) {
+	return lightclient.Decode(data)
+}
+
+// toOrderUpdate unpacks the order of an order operation, leaving out other messages like memberships
+func toOrderUpdate(message *pb.WireMessage) (*OrderUpdate, bool) {
+	switch message.GetOperation() {
+	case pb.Operation_CREATE, pb.Operation_DELETE, pb.Operation_LOCK, pb.Operation_UNLOCK, pb.Operation_FILL:
+	default:
+		return nil, false
+	}
+	order := &pb.Order{}
+	err := proto.Unmarshal(message.GetData(), order)
+	if err != nil {
+		return nil, false
+	}
+	return &OrderUpdate{ChannelID: message.GetChannelID(), Operation: message.GetOperation(), Order: order}, true
+}
@@ -0,0 +1,133 @@
+package wsclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAsset string = "ETH"
+const testTimeout time.Duration = 5 * time.Second
+
+func newTestMessage(t *testing.T, operation pb.Operation, amount uint64) *pb.WireMessage {
+	data, err := proto.Marshal(&pb.Order{Asset: testAsset, CounterAsset: "BTC", Amount: amount})
+	assert.NoError(t, err)
+	return &pb.WireMessage{ChannelID: []byte("BTC,ETH"), Operation: operation, Data: data}
+}
+
+// newDroppingServer sends every connection one message and the subscription it received, then drops it
+func newDroppingServer(t *testing.T, subscriptions chan *pb.Subscription) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	amount := uint64(0)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		subscription := &pb.Subscription{}
+		assert.NoError(t, proto.Unmarshal(data, subscription))
+		subscriptions <- subscription
+
+		amount++
+		buf, err := proto.Marshal(newTestMessage(t, pb.Operation_CREATE, amount))
+		assert.NoError(t, err)
+		conn.WriteMessage(websocket.BinaryMessage, buf)
+	}))
+}
+
+func TestDecode(t *testing.T) {
+	message := newTestMessage(t, pb.Operation_LOCK, 1)
+	buf, err := proto.Marshal(message)
+	assert.NoError(t, err)
+	decoded, err := decode(buf)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(message, decoded))
+
+	json, err := (&jsonpb.Marshaler{}).MarshalToString(message)
+	assert.NoError(t, err)
+	decoded, err = decode([]byte(json))
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(message, decoded))
+
+	_, err = decode([]byte("{not json"))
+	assert.Error(t, err)
+}
+
+func TestDecodeFrame(t *testing.T) {
+	message := newTestMessage(t, pb.Operation_LOCK, 1)
+	buf, err := proto.Marshal(message)
+	assert.NoError(t, err)
+	messages, err := decodeFrame(buf)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.True(t, proto.Equal(message, messages[0]))
+
+	batch := &pb.WireMessageBatch{Messages: []*pb.WireMessage{message, newTestMessage(t, pb.Operation_DELETE, 2)}}
+	buf, err = proto.Marshal(batch)
+	assert.NoError(t, err)
+	messages, err = decodeFrame(buf)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, pb.Operation_DELETE, messages[1].GetOperation())
+}
+
+func TestToOrderUpdate(t *testing.T) {
+	update, ok := toOrderUpdate(newTestMessage(t, pb.Operation_DELETE, 2))
+	assert.True(t, ok)
+	assert.Equal(t, pb.Operation_DELETE, update.Operation)
+	assert.Equal(t, uint64(2), update.Order.GetAmount())
+
+	_, ok = toOrderUpdate(&pb.WireMessage{Operation: pb.Operation_MEMBERSHIP})
+	assert.False(t, ok)
+}
+
+func TestReconnect(t *testing.T) {
+	subscriptions := make(chan *pb.Subscription, 10)
+	server := newDroppingServer(t, subscriptions)
+	defer server.Close()
+
+	subscription := &pb.Subscription{Asset: testAsset}
+	client, err := Dial(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), Options{Subscription: subscription, ReconnectBackoff: time.Millisecond})
+	assert.NoError(t, err)
+
+	// Every connection gets the subscription, and the updates keep coming after the drops
+	for amount := uint64(1); amount <= 3; amount++ {
+		select {
+		case received := <-subscriptions:
+			assert.True(t, proto.Equal(subscription, received))
+		case <-time.After(testTimeout):
+			t.Fatal("no subscription received")
+		}
+		select {
+		case update := <-client.OrderUpdates():
+			assert.Equal(t, amount, update.Order.GetAmount())
+		case <-time.After(testTimeout):
+			t.Fatal("no order update received")
+		}
+	}
+
+	assert.NoError(t, client.Close())
+	for range client.OrderUpdates() {
+	}
+}
+
+func TestDialFails(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	_, err := Dial(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/pflag"
+	"github.com/sprawl/sprawl"
+	"github.com/sprawl/sprawl/app"
+	sprawlclient "github.com/sprawl/sprawl/clients/go"
+	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const defaultConfigPath = "./config/default"
+const defaultNodeAddress = "localhost:1337"
+const restoreChunkSize = 64 * 1024
+
+// readConfig reads the config from the files, the environment and the flags, which override the others
+func readConfig(args []string) *config.Config {
+	configPath := defaultConfigPath
+	flags := config.NewFlagSet(os.Args[0])
+	flags.StringVar(&configPath, "config", configPath, "directory to look for config.toml in")
+	if err := flags.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			os.Exit(0)
+		}
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	appConfig := &config.Config{}
+	appConfig.BindFlags(flags)
+	appConfig.ReadConfig(configPath)
+	return appConfig
+}
+
+// doctor checks the config and the environment the node would run in, prints the results and returns the exit code
+func doctor(args []string) int {
+	report := app.Doctor(context.Background(), readConfig(args), nil)
+	for _, result := range report.GetResults() {
+		label := "FAIL"
+		if result.GetPassed() {
+			label = "OK"
+		} else if result.GetWarning() {
+			label = "WARN"
+		}
+		fmt.Printf("%-4s  %s: %s\n", label, result.GetName(), result.GetMessage())
+		if result.GetHint() != "" {
+			fmt.Printf("      %s\n", result.GetHint())
+		}
+	}
+	if !report.GetPassed() {
+		return 1
+	}
+	return 0
+}
+
+// initConfig writes a commented config.toml with the default value of every key, and returns the exit code
+func initConfig(args []string) int {
+	path := "config.toml"
+	force := false
+	flags := pflag.NewFlagSet(os.Args[0]+" config init", pflag.ContinueOnError)
+	flags.StringVar(&path, "path", path, "file to write the config to, or a directory to write config.toml in")
+	flags.BoolVar(&force, "force", force, "overwrite the file if it exists")
+	if err := flags.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Println(err)
+		return 2
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "config.toml")
+	}
+
+	// The config may get API keys and passphrases added to it, so only the owner can read it
+	mode := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		mode = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, mode, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			fmt.Printf("%s already exists, use --force to overwrite it\n", path)
+		} else {
+			fmt.Println(err)
+		}
+		return 1
+	}
+	err = config.WriteDefaultConfig(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	fmt.Printf("Wrote the default config to %s\n", path)
+	return 0
+}
+
+// db streams a snapshot of a running node's database to stdout, or restores one from stdin, and returns the exit code
+func db(command string, args []string) int {
+	address := defaultNodeAddress
+	token := ""
+	flags := pflag.NewFlagSet(os.Args[0]+" db "+command, pflag.ContinueOnError)
+	flags.StringVar(&address, "address", address, "gRPC address of the node")
+	flags.StringVar(&token, "token", token, "API key to authenticate with")
+	if err := flags.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	client, err := sprawlclient.New(address, sprawlclient.Options{AuthToken: token})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer client.Close()
+
+	if command == "backup" {
+		err = backupDatabase(client, os.Stdout)
+	} else {
+		err = restoreDatabase(client, os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// backupDatabase writes the chunks of the node's snapshot to w as they arrive
+func backupDatabase(client *sprawlclient.Client, w io.Writer) error {
+	stream, err := client.Admin.Backup(context.Background(), &pb.Empty{})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return err
+		}
+	}
+}
+
+// restoreDatabase sends a snapshot read from r to the node in chunks
+func restoreDatabase(client *sprawlclient.Client, r io.Reader) error {
+	stream, err := client.Admin.Restore(context.Background())
+	if err != nil {
+		return err
+	}
+	buffer := make([]byte, restoreChunkSize)
+	for {
+		n, err := r.Read(buffer)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buffer[:n])
+			if sendErr := stream.Send(&pb.BackupChunk{Data: chunk}); sendErr != nil {
+				// The node's reason for ending the stream is only returned by CloseAndRecv
+				if _, err := stream.CloseAndRecv(); err != nil {
+					return err
+				}
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		os.Exit(doctor(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if len(os.Args) < 3 || os.Args[2] != "init" {
+			fmt.Printf("Usage: %s config init [--path config.toml] [--force]\n", os.Args[0])
+			os.Exit(2)
+		}
+		os.Exit(initConfig(os.Args[3:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "db" {
+		if len(os.Args) < 3 || (os.Args[2] != "backup" && os.Args[2] != "restore") {
+			fmt.Printf("Usage: %s db backup|restore [--address %s] [--token key]\n", os.Args[0], defaultNodeAddress)
+			os.Exit(2)
+		}
+		os.Exit(db(os.Args[2], os.Args[3:]))
+	}
+	appConfig := readConfig(os.Args[1:])
+
+	log, err := app.NewLogger(appConfig)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	node, err := sprawl.NewNode(sprawl.WithConfig(appConfig), sprawl.WithLogger(log))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	systemSignals := make(chan os.Signal, 1)
+	signal.Notify(systemSignals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-systemSignals
+		log.Infof("Received %s signal, shutting down.\n", sig)
+		node.Close()
+		os.Exit(0)
+	}()
+
+	node.Run()
+}
@@ -0,0 +1,310 @@
+// sprawlsim runs a network of Sprawl nodes in one process on a simulated network, creates orders on some of them
+// and reports how fast and at what cost the orders reached the rest. It's meant for checking how changes to the
+// gossip settings affect propagation before trying them on a real network.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-core/host"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/spf13/pflag"
+	"github.com/sprawl/sprawl"
+	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const defaultConfigPath = "./config/default"
+
+// simulation holds what the flags set, on top of the config every node shares
+type simulation struct {
+	nodes   uint
+	degree  uint
+	latency time.Duration
+	orders  uint
+	rate    uint
+	makers  uint
+	settle  time.Duration
+	timeout time.Duration
+	asset   string
+	counter string
+}
+
+// recorder sits between a node's p2p and its order service, and notes when each order first reached the node
+type recorder struct {
+	interfaces.Receiver
+	node  int
+	stats *stats
+}
+
+// stats collects what every recorder noted
+type stats struct {
+	sync.Mutex
+	// received counts every order a node was given, including the copies of ones it already had
+	received  uint
+	delivered map[string]bool
+	latencies []time.Duration
+	done      chan struct{}
+	expected  int
+}
+
+// Receive notes the latency of orders seen for the first time on this node before passing them on
+func (r *recorder) Receive(ctx context.Context, msg interfaces.IncomingMessage) error {
+	wireMessage := &pb.WireMessage{}
+	if err := proto.Unmarshal(msg.Data, wireMessage); err == nil && wireMessage.GetOperation() == pb.Operation_CREATE {
+		order := &pb.Order{}
+		if err := proto.Unmarshal(wireMessage.GetData(), order); err == nil {
+			r.stats.record(r.node, order, msg.ReceivedAt)
+		}
+	}
+	return r.Receiver.Receive(ctx, msg)
+}
+
+// record counts a received order, and notes its latency if it hadn't reached the node before
+func (s *stats) record(node int, order *pb.Order, receivedAt time.Time) {
+	created, err := ptypes.Timestamp(order.GetCreated())
+	if err != nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.received++
+	key := fmt.Sprintf("%d/%x", node, order.GetId())
+	if s.delivered[key] {
+		return
+	}
+	s.delivered[key] = true
+	s.latencies = append(s.latencies, receivedAt.Sub(created))
+	if len(s.latencies) == s.expected {
+		close(s.done)
+	}
+}
+
+// percentile returns the latency that p percent of the sorted latencies are at most
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	index := int(float64(len(latencies)-1) * p / 100)
+	return latencies[index]
+}
+
+// storedBytes adds up the keys and values stored on every node
+func storedBytes(ctx context.Context, storages []interfaces.Storage) (uint64, error) {
+	var total uint64
+	for _, storage := range storages {
+		entries, err := storage.GetAll(ctx)
+		if !errors.IsEmpty(err) {
+			return 0, errors.E(errors.Op("Get stored entries"), err)
+		}
+		for key, value := range entries {
+			total += uint64(len(key) + len(value))
+		}
+	}
+	return total, nil
+}
+
+// neighbours returns which nodes node i is linked to: every other one with degree 0,
+// otherwise the degree/2 next ones on a ring, so every node ends up with degree neighbours
+func neighbours(i int, nodes int, degree int) []int {
+	if degree == 0 || degree >= nodes-1 {
+		var all []int
+		for j := i + 1; j < nodes; j++ {
+			all = append(all, j)
+		}
+		return all
+	}
+	half := degree / 2
+	if half == 0 {
+		half = 1
+	}
+	var ring []int
+	for k := 1; k <= half; k++ {
+		ring = append(ring, (i+k)%nodes)
+	}
+	return ring
+}
+
+// run builds the network, creates the orders and prints the report
+func run(appConfig *config.Config, sim simulation) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if sim.nodes < 2 || sim.makers == 0 || sim.makers > sim.nodes || sim.rate == 0 {
+		return errors.E(errors.Op("Check flags"), errors.Invalid, "need at least 2 nodes, between 1 and nodes makers and a rate above 0")
+	}
+	if limit := appConfig.GetMakerRateLimit(); limit > 0 && sim.rate > limit*sim.makers {
+		fmt.Printf("Warning: each maker creates %d orders per second but orders.makerRateLimit lets only %d through. Add makers or set --orders.makerRateLimit 0.\n", sim.rate/sim.makers, limit)
+	}
+
+	network := mocknet.New(ctx)
+	network.SetLinkDefaults(mocknet.LinkOptions{Latency: sim.latency})
+
+	nodes := make([]*sprawl.Node, sim.nodes)
+	hosts := make([]host.Host, sim.nodes)
+	storages := make([]interfaces.Storage, sim.nodes)
+	for i := range nodes {
+		storage := &inmemory.Storage{Db: make(map[string]string)}
+		privateKey, _, err := identity.NewKeyPair(storage, rand.Reader)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Create key pair"), err)
+		}
+		address, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/100.64.%d.%d/tcp/4242", i/256, i%256))
+		if err != nil {
+			return errors.E(errors.Op("Create address"), err)
+		}
+		hosts[i], err = network.AddPeer(privateKey, address)
+		if err != nil {
+			return errors.E(errors.Op("Add peer to network"), err)
+		}
+		nodes[i], err = sprawl.NewNode(
+			sprawl.WithConfig(appConfig),
+			sprawl.WithStorage(storage),
+			sprawl.WithoutWebsocket(),
+			sprawl.WithHost(hosts[i]),
+		)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Start node"), err)
+		}
+		defer nodes[i].Close()
+		storages[i] = storage
+	}
+
+	// The hosts are connected once pubsub is running on all of them, so each learns of the others' subscriptions
+	for i := range hosts {
+		for _, j := range neighbours(i, len(hosts), int(sim.degree)) {
+			if _, err := network.LinkPeers(hosts[i].ID(), hosts[j].ID()); err != nil {
+				return errors.E(errors.Op("Link peers"), err)
+			}
+			if _, err := network.ConnectPeers(hosts[i].ID(), hosts[j].ID()); err != nil {
+				return errors.E(errors.Op("Connect peers"), err)
+			}
+		}
+	}
+
+	stats := &stats{
+		delivered: make(map[string]bool),
+		done:      make(chan struct{}),
+		expected:  int(sim.orders) * (len(nodes) - 1),
+	}
+	var channelID []byte
+	for i, node := range nodes {
+		joined, err := node.Server.Channels.Join(ctx, &pb.JoinRequest{Asset: sim.asset, CounterAsset: sim.counter})
+		if err != nil {
+			return errors.E(errors.Op("Join channel"), err)
+		}
+		channelID = joined.GetJoinedChannel().GetId()
+		node.P2p.AddReceiver(&recorder{Receiver: node.Server.Orders, node: i, stats: stats})
+	}
+	time.Sleep(sim.settle)
+
+	before, err := storedBytes(ctx, storages)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	fmt.Printf("Creating %d orders on %d of %d nodes at %d per second\n", sim.orders, sim.makers, sim.nodes, sim.rate)
+	created := 0
+	ticker := time.NewTicker(time.Second / time.Duration(sim.rate))
+	for i := 0; i < int(sim.orders); i++ {
+		<-ticker.C
+		maker := nodes[i%int(sim.makers)]
+		_, err := maker.Server.Orders.Create(ctx, &pb.CreateRequest{
+			ChannelID:    channelID,
+			Asset:        sim.asset,
+			CounterAsset: sim.counter,
+			Amount:       uint64(i + 1),
+			Price:        1,
+		})
+		if err != nil {
+			fmt.Printf("Creating order %d failed: %s\n", i, err)
+			continue
+		}
+		created++
+	}
+	ticker.Stop()
+
+	stats.Lock()
+	stats.expected = created * (len(nodes) - 1)
+	waiting := len(stats.latencies) < stats.expected
+	stats.Unlock()
+	if waiting {
+		select {
+		case <-stats.done:
+		case <-time.After(sim.timeout):
+		}
+	}
+
+	after, err := storedBytes(ctx, storages)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	stats.Lock()
+	defer stats.Unlock()
+	latencies := append([]time.Duration{}, stats.latencies...)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	delivered := len(latencies)
+	expected := created * (len(nodes) - 1)
+
+	fmt.Printf("Delivered:      %d of %d (%.1f%%)\n", delivered, expected, 100*float64(delivered)/float64(max(expected, 1)))
+	fmt.Printf("Latency:        p50 %s, p90 %s, p99 %s, max %s\n",
+		percentile(latencies, 50), percentile(latencies, 90), percentile(latencies, 99), percentile(latencies, 100))
+	fmt.Printf("Amplification:  %.2f messages received per delivered order\n", float64(stats.received)/float64(max(delivered, 1)))
+	growth := after - before
+	fmt.Printf("Storage growth: %d bytes, %d per node, %d per order\n", growth, growth/uint64(len(nodes)), growth/uint64(max(created, 1)))
+	return nil
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func main() {
+	sim := simulation{}
+	configPath := defaultConfigPath
+	flags := config.NewFlagSet(os.Args[0])
+	flags.StringVar(&configPath, "config", configPath, "directory to look for config.toml in")
+	flags.UintVar(&sim.nodes, "nodes", 10, "how many nodes to run")
+	flags.UintVar(&sim.degree, "degree", 0, "how many neighbours each node is linked to, 0 links every node to every other")
+	flags.DurationVar(&sim.latency, "latency", 20*time.Millisecond, "the latency of every link")
+	flags.UintVar(&sim.orders, "orders", 100, "how many orders to create")
+	flags.UintVar(&sim.rate, "rate", 10, "how many orders to create per second, spread over the makers")
+	flags.UintVar(&sim.makers, "makers", 1, "on how many nodes orders are created")
+	flags.DurationVar(&sim.settle, "settle", 2*time.Second, "how long to let the nodes find each other's subscriptions before creating orders")
+	flags.DurationVar(&sim.timeout, "timeout", 30*time.Second, "how long to wait for the last orders to arrive")
+	flags.StringVar(&sim.asset, "asset", "ETH", "the asset of the channel the orders are created on")
+	flags.StringVar(&sim.counter, "counterAsset", "BTC", "the counter asset of the channel the orders are created on")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if err == pflag.ErrHelp {
+			os.Exit(0)
+		}
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	appConfig := &config.Config{}
+	appConfig.BindFlags(flags)
+	appConfig.ReadConfig(configPath)
+
+	if err := run(appConfig, sim); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
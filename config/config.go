@@ -5,13 +5,38 @@ import (
 	"strings"
 
 	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/sprawl/sprawl/errors"
 )
 
 const dbPathVar string = "database.path"
 const dbInMemoryVar string = "database.inMemory"
+const dbDeleteBatchSizeVar string = "database.deleteBatchSize"
+const dbMigrationsDryRunVar string = "database.migrationsDryRun"
+const dbEngineVar string = "database.engine"
+const dbEncryptionPassphraseVar string = "database.encryptionPassphrase"
+const dbMinFreeSpaceVar string = "database.minFreeSpace"
+const dbDiskCheckVar string = "database.diskCheckInterval"
+const dbCompactAtVar string = "database.compactAt"
+const dbGroupCommitWindowVar string = "database.groupCommitWindow"
+const dbFsyncVar string = "database.fsync"
+const identityPathVar string = "identity.path"
 const rpcPortVar string = "rpc.port"
+const rpcReflectionVar string = "rpc.enableReflection"
+const rpcAPIKeysVar string = "rpc.apiKeys"
+const rpcAPIRolesVar string = "rpc.apiRoles"
+const rpcWebPortVar string = "rpc.webPort"
+const rpcWebOriginsVar string = "rpc.webOrigins"
+const rpcKeepaliveTimeVar string = "rpc.keepaliveTime"
+const rpcKeepaliveTimeoutVar string = "rpc.keepaliveTimeout"
+const rpcKeepaliveMinTimeVar string = "rpc.keepaliveMinTime"
+const rpcKeepaliveNoCallsVar string = "rpc.keepalivePermitWithoutStream"
+const rpcMaxConnectionIdleVar string = "rpc.maxConnectionIdle"
+const rpcMaxConnectionAgeVar string = "rpc.maxConnectionAge"
+const rpcMaxRecvMessageSizeVar string = "rpc.maxRecvMessageSize"
+const rpcMaxSendMessageSizeVar string = "rpc.maxSendMessageSize"
+const rpcMaxConcurrentStreamsVar string = "rpc.maxConcurrentStreams"
 const p2pExternalIPVar string = "p2p.externalIP"
 const p2pPortVar string = "p2p.port"
 const p2pDebugVar string = "p2p.debug"
@@ -19,33 +44,146 @@ const p2pRelayVar string = "p2p.enableRelay"
 const p2pAutoRelayVar string = "p2p.enableAutoRelay"
 const p2pNATPortMapVar string = "p2p.enableNATPortMap"
 const ipfsPeerVar string = "p2p.useIPFSPeers"
+const p2pBootstrapPeersVar string = "p2p.bootstrapPeers"
+const p2pBootstrapRefreshIntervalVar string = "p2p.bootstrapRefreshInterval"
+const p2pDiscoveryIntervalVar string = "p2p.discoveryInterval"
+const p2pBrowserTransportsVar string = "p2p.browserTransports"
+const p2pBrowserPortVar string = "p2p.browserPort"
+const p2pSecurityVar string = "p2p.security"
+const p2pPrivateNetworkKeyVar string = "p2p.privateNetworkKey"
+const p2pListenAddressesVar string = "p2p.listenAddresses"
+const p2pExternalIPv6Var string = "p2p.externalIPv6"
+const p2pQUICVar string = "p2p.enableQUIC"
+const p2pReusePortVar string = "p2p.reusePort"
+const p2pAnnounceAddressesVar string = "p2p.announceAddresses"
+const p2pNoAnnounceVar string = "p2p.noAnnounce"
+const p2pMessageRateLimitVar string = "p2p.messageRateLimit"
+const p2pMaxMessageSizeVar string = "p2p.maxMessageSize"
+const p2pThrottleScoreVar string = "p2p.throttleScore"
+const p2pDisconnectScoreVar string = "p2p.disconnectScore"
+const p2pConnLowVar string = "p2p.connLow"
+const p2pConnHighVar string = "p2p.connHigh"
+const p2pConnGracePeriodVar string = "p2p.connGracePeriod"
+const p2pJournalRetentionVar string = "p2p.journalRetention"
+const p2pFastSyncPeersVar string = "p2p.fastSyncPeers"
+const p2pNetworkVar string = "p2p.network"
+const p2pStreamReadVar string = "p2p.streamReadTimeout"
+const p2pStreamWriteVar string = "p2p.streamWriteTimeout"
+const p2pGossipDVar string = "p2p.gossip.d"
+const p2pGossipDloVar string = "p2p.gossip.dlo"
+const p2pGossipDhiVar string = "p2p.gossip.dhi"
+const p2pGossipHeartbeatIntervalVar string = "p2p.gossip.heartbeatInterval"
+const p2pGossipFloodPublishPeersVar string = "p2p.gossip.floodPublishPeers"
+const p2pGossipRelayVar string = "p2p.gossip.relay"
+const p2pGossipRelayHopsVar string = "p2p.gossip.relayHops"
+const p2pQueueDataRateVar string = "p2p.queue.dataRate"
+const p2pQueueBulkRateVar string = "p2p.queue.bulkRate"
+const p2pChaosLatencyVar string = "p2p.chaos.latency"
+const p2pChaosDropPercentVar string = "p2p.chaos.dropPercent"
+const p2pChaosCloseStreamPercentVar string = "p2p.chaos.closeStreamPercent"
 const errorsEnableStackTraceVar string = "errors.enableStackTrace"
 const logLevelVar string = "log.level"
 const logFormatVar string = "log.format"
 const websocketEnableVar string = "websocket.enable"
 const websocketPortVar string = "websocket.port"
+const websocketPingIntervalVar string = "websocket.pingInterval"
+const websocketPongTimeoutVar string = "websocket.pongTimeout"
+const websocketFlushIntervalVar string = "websocket.flushInterval"
+const websocketListenersVar string = "websocket.listeners"
+const marketAPIPortVar string = "marketapi.port"
+const marketAPIMaxAgeVar string = "marketapi.maxAge"
+const dashboardPortVar string = "dashboard.port"
+const routerPairsVar string = "router.pairs"
+const marketDataIntervalsVar string = "marketdata.intervals"
+const historyRetentionVar string = "history.retention"
+const ordersLockTimeoutVar string = "orders.lockTimeout"
+const ordersUnlockIntervalVar string = "orders.unlockInterval"
+const ordersCacheSizeVar string = "orders.cacheSize"
+const ordersMaxClockSkewVar string = "orders.maxClockSkew"
+const ordersMakerRateVar string = "orders.makerRateLimit"
+const ordersMaxMakerVar string = "orders.maxMakerOrders"
+const ordersWorkersVar string = "orders.workers"
+const ordersAntiEntropyVar string = "orders.antiEntropyInterval"
+const ordersIDMismatchVar string = "orders.idMismatch"
+const ordersIDCollisionVar string = "orders.idCollision"
+const channelsMaxOrderAgeVar string = "channels.maxOrderAge"
+const channelsMaxOrdersVar string = "channels.maxOrders"
+const channelsPruneIntervalVar string = "channels.pruneInterval"
+const channelsAllowCustomAssetsVar string = "channels.allowCustomAssets"
+const channelsIdleTimeoutVar string = "channels.idleTimeout"
+const webhooksURLsVar string = "webhooks.urls"
+const webhooksEventsVar string = "webhooks.events"
+const webhooksSecretVar string = "webhooks.secret"
+const webhooksRetriesVar string = "webhooks.retries"
+const settlementEngineVar string = "settlement.engine"
+const ethRPCURLVar string = "settlement.ethereum.rpcURL"
+const ethContractVar string = "settlement.ethereum.contract"
+const ethAccountVar string = "settlement.ethereum.account"
+const ethConfirmationsVar string = "settlement.ethereum.confirmations"
+const ethPollIntervalVar string = "settlement.ethereum.pollInterval"
+const ethTimeoutVar string = "settlement.ethereum.timeout"
+const replicationEnableVar string = "replication.enable"
+const replicationPrimaryVar string = "replication.primary"
+const replicationAPIKeyVar string = "replication.apiKey"
+const debugPprofPortVar string = "debug.pprof.port"
+const debugProfileDirVar string = "debug.profileDir"
+const debugDeadLettersVar string = "debug.deadLetters"
+const debugCountersIntervalVar string = "debug.countersInterval"
+
+// defaults are used for any key that isn't set by a flag, the environment or a config file
+var defaults = getDefaults()
+
+// NewFlagSet returns a flag for every config key, like --p2p.port, with the key's default value
+func NewFlagSet(name string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	for key, value := range defaults {
+		usage := fmt.Sprintf("overrides %s from the environment and config file", key)
+		switch value := value.(type) {
+		case string:
+			flags.String(key, value, usage)
+		case bool:
+			flags.Bool(key, value, usage)
+		case uint:
+			flags.Uint(key, value, usage)
+		}
+	}
+	return flags
+}
 
 // Config has an initialized version of spf13/viper
 type Config struct {
 	v        *viper.Viper
+	flags    *pflag.FlagSet
 	strings  map[string]string
 	booleans map[string]bool
 	uints    map[string]uint
 }
 
-// ReadConfig opens the configuration file and initializes viper
+// BindFlags makes flags that are set on the command line take precedence over the environment and config files.
+// It has to be called before ReadConfig.
+func (c *Config) BindFlags(flags *pflag.FlagSet) {
+	c.flags = flags
+}
+
+// SetDefaults sets the default value of every key, so that no config file is needed
+func (c *Config) SetDefaults() {
+	for key, value := range defaults {
+		c.v.SetDefault(key, value)
+	}
+}
+
+// ReadConfig opens the configuration file and initializes viper.
+// Values are read in the order flags, environment variables, config file and defaults.
 func (c *Config) ReadConfig(configPath string) {
 	// Init viper
 	c.v = viper.New()
+	c.SetDefaults()
 
 	// Init maps where the config will be stored
 	c.strings = make(map[string]string)
 	c.booleans = make(map[string]bool)
 	c.uints = make(map[string]uint)
 
-	// Define where viper tries to get config information
-	envPrefix := "sprawl"
-
 	// Set environment variable prefix, automatically transformed to uppercase
 	c.v.SetEnvPrefix(envPrefix)
 
@@ -72,7 +210,7 @@ func (c *Config) ReadConfig(configPath string) {
 	// Read config file
 	if err := c.v.ReadInConfig(); !errors.IsEmpty(err) {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			fmt.Println("Config file not found, using ENV")
+			fmt.Println("Config file not found, using ENV and defaults")
 		} else {
 			fmt.Println("Config file invalid!")
 		}
@@ -80,21 +218,134 @@ func (c *Config) ReadConfig(configPath string) {
 		fmt.Println("Config successfully loaded.")
 	}
 
+	// Only flags that were actually set override other sources
+	if c.flags != nil {
+		if err := c.v.BindPFlags(c.flags); !errors.IsEmpty(err) {
+			fmt.Println("Binding flags failed!")
+		}
+	}
+
 	c.AddString(dbPathVar)
+	c.strings[dbPathVar] = resolveDatabasePath(c.strings[dbPathVar])
+	c.AddString(dbEngineVar)
+	c.AddString(dbEncryptionPassphraseVar)
+	c.AddString(dbCompactAtVar)
+	c.AddString(dbFsyncVar)
+	c.AddString(identityPathVar)
+	c.strings[identityPathVar] = resolveIdentityPath(c.strings[identityPathVar])
+	c.AddString(rpcAPIKeysVar)
+	c.AddString(rpcAPIRolesVar)
+	c.AddString(rpcWebOriginsVar)
 	c.AddString(p2pExternalIPVar)
 	c.AddString(logLevelVar)
 	c.AddString(logFormatVar)
+	c.AddString(routerPairsVar)
+	c.AddString(marketDataIntervalsVar)
+	c.AddString(p2pBootstrapPeersVar)
+	c.AddString(p2pSecurityVar)
+	c.AddString(p2pPrivateNetworkKeyVar)
+	c.AddString(p2pListenAddressesVar)
+	c.AddString(p2pExternalIPv6Var)
+	c.AddString(p2pAnnounceAddressesVar)
+	c.AddString(p2pNoAnnounceVar)
+	c.AddString(webhooksURLsVar)
+	c.AddString(webhooksEventsVar)
+	c.AddString(webhooksSecretVar)
+	c.AddString(debugProfileDirVar)
 	c.AddUint(p2pPortVar)
+	c.AddUint(dbDeleteBatchSizeVar)
+	c.AddUint(dbMinFreeSpaceVar)
+	c.AddUint(dbDiskCheckVar)
+	c.AddUint(dbGroupCommitWindowVar)
 	c.AddUint(rpcPortVar)
+	c.AddUint(rpcWebPortVar)
+	c.AddUint(rpcKeepaliveTimeVar)
+	c.AddUint(rpcKeepaliveTimeoutVar)
+	c.AddUint(rpcKeepaliveMinTimeVar)
+	c.AddUint(rpcMaxConnectionIdleVar)
+	c.AddUint(rpcMaxConnectionAgeVar)
+	c.AddUint(rpcMaxRecvMessageSizeVar)
+	c.AddUint(rpcMaxSendMessageSizeVar)
+	c.AddUint(rpcMaxConcurrentStreamsVar)
+	c.AddUint(p2pMessageRateLimitVar)
+	c.AddUint(p2pMaxMessageSizeVar)
+	c.AddUint(p2pThrottleScoreVar)
+	c.AddUint(p2pDisconnectScoreVar)
+	c.AddUint(p2pConnLowVar)
+	c.AddUint(p2pConnHighVar)
+	c.AddUint(p2pConnGracePeriodVar)
+	c.AddUint(p2pJournalRetentionVar)
+	c.AddString(p2pFastSyncPeersVar)
+	c.AddString(p2pNetworkVar)
+	c.AddUint(p2pStreamReadVar)
+	c.AddUint(p2pStreamWriteVar)
+	c.AddUint(p2pGossipDVar)
+	c.AddUint(p2pGossipDloVar)
+	c.AddUint(p2pGossipDhiVar)
+	c.AddUint(p2pGossipHeartbeatIntervalVar)
+	c.AddUint(p2pGossipFloodPublishPeersVar)
+	c.AddUint(p2pGossipRelayHopsVar)
+	c.AddUint(p2pQueueDataRateVar)
+	c.AddUint(p2pQueueBulkRateVar)
+	c.AddUint(p2pChaosLatencyVar)
+	c.AddUint(p2pChaosDropPercentVar)
+	c.AddUint(p2pChaosCloseStreamPercentVar)
+	c.AddUint(p2pBootstrapRefreshIntervalVar)
+	c.AddUint(p2pDiscoveryIntervalVar)
+	c.AddUint(p2pBrowserPortVar)
 	c.AddUint(websocketPortVar)
+	c.AddUint(websocketPingIntervalVar)
+	c.AddUint(websocketPongTimeoutVar)
+	c.AddUint(websocketFlushIntervalVar)
+	c.AddUint(marketAPIPortVar)
+	c.AddUint(marketAPIMaxAgeVar)
+	c.AddUint(dashboardPortVar)
+	c.AddUint(historyRetentionVar)
+	c.AddUint(ordersLockTimeoutVar)
+	c.AddUint(ordersUnlockIntervalVar)
+	c.AddUint(ordersCacheSizeVar)
+	c.AddUint(ordersMaxClockSkewVar)
+	c.AddUint(ordersMakerRateVar)
+	c.AddUint(ordersMaxMakerVar)
+	c.AddUint(ordersWorkersVar)
+	c.AddUint(ordersAntiEntropyVar)
+	c.AddUint(channelsMaxOrderAgeVar)
+	c.AddUint(channelsMaxOrdersVar)
+	c.AddUint(channelsPruneIntervalVar)
+	c.AddUint(webhooksRetriesVar)
+	c.AddString(websocketListenersVar)
+	c.AddString(ordersIDMismatchVar)
+	c.AddString(ordersIDCollisionVar)
+	c.AddString(settlementEngineVar)
+	c.AddString(ethRPCURLVar)
+	c.AddString(ethContractVar)
+	c.AddString(ethAccountVar)
+	c.AddUint(ethConfirmationsVar)
+	c.AddUint(ethPollIntervalVar)
+	c.AddUint(ethTimeoutVar)
+	c.AddBoolean(replicationEnableVar)
+	c.AddString(replicationPrimaryVar)
+	c.AddString(replicationAPIKeyVar)
+	c.AddUint(debugPprofPortVar)
+	c.AddUint(debugDeadLettersVar)
+	c.AddUint(debugCountersIntervalVar)
+	c.AddBoolean(rpcReflectionVar)
+	c.AddBoolean(rpcKeepaliveNoCallsVar)
 	c.AddBoolean(websocketEnableVar)
 	c.AddBoolean(dbInMemoryVar)
+	c.AddBoolean(dbMigrationsDryRunVar)
 	c.AddBoolean(p2pNATPortMapVar)
 	c.AddBoolean(p2pRelayVar)
 	c.AddBoolean(p2pAutoRelayVar)
 	c.AddBoolean(p2pDebugVar)
 	c.AddBoolean(errorsEnableStackTraceVar)
 	c.AddBoolean(ipfsPeerVar)
+	c.AddBoolean(p2pBrowserTransportsVar)
+	c.AddBoolean(p2pQUICVar)
+	c.AddBoolean(p2pReusePortVar)
+	c.AddBoolean(p2pGossipRelayVar)
+	c.AddBoolean(channelsAllowCustomAssetsVar)
+	c.AddUint(channelsIdleTimeoutVar)
 
 }
 
@@ -143,7 +394,7 @@ func (c *Config) AddUintE(key string) error {
 	return err
 }
 
-// GetDatabasePath defines the host directory for the database
+// GetDatabasePath defines the host directory for the database. An empty path is the OS data directory.
 func (c *Config) GetDatabasePath() string {
 	return c.strings[dbPathVar]
 }
@@ -168,26 +419,318 @@ func (c *Config) GetP2PPort() uint {
 	return c.uints[p2pPortVar]
 }
 
+// GetMessageRateLimit defines how many messages per second a peer may send before its messages are dropped
+func (c *Config) GetMessageRateLimit() uint {
+	return c.uints[p2pMessageRateLimitVar]
+}
+
+// GetMaxMessageSize defines how many bytes a message from a peer may have before it's rejected. 0 doesn't limit it.
+func (c *Config) GetMaxMessageSize() uint {
+	return c.uints[p2pMaxMessageSizeVar]
+}
+
+// GetThrottleScore defines the reputation score under which a peer's message rate limit is lowered
+func (c *Config) GetThrottleScore() uint {
+	return c.uints[p2pThrottleScoreVar]
+}
+
+// GetDisconnectScore defines the reputation score under which a peer is disconnected and blacklisted
+func (c *Config) GetDisconnectScore() uint {
+	return c.uints[p2pDisconnectScoreVar]
+}
+
+// GetConnLow defines how many connections the connection manager trims down to
+func (c *Config) GetConnLow() uint {
+	return c.uints[p2pConnLowVar]
+}
+
+// GetConnHigh defines how many connections trigger the connection manager to trim them. 0 disables the connection manager.
+func (c *Config) GetConnHigh() uint {
+	return c.uints[p2pConnHighVar]
+}
+
+// GetConnGracePeriod defines how many seconds new connections are kept before they can be trimmed
+func (c *Config) GetConnGracePeriod() uint {
+	return c.uints[p2pConnGracePeriodVar]
+}
+
+// GetJournalRetention defines how many hours messages published with no peers on the channel are kept for resending. 0 disables the journal.
+func (c *Config) GetJournalRetention() uint {
+	return c.uints[p2pJournalRetentionVar]
+}
+
+// GetFastSyncPeers defines the comma separated peer IDs of trusted peers that joined channels are downloaded from as a single snapshot.
+// Empty replays the orders from the first peer on the channel instead.
+func (c *Config) GetFastSyncPeers() string {
+	return c.strings[p2pFastSyncPeersVar]
+}
+
+// GetNetwork defines the network the node is on. Nodes only connect to and exchange orders with nodes on the same network.
+func (c *Config) GetNetwork() string {
+	return c.strings[p2pNetworkVar]
+}
+
+// GetStreamReadTimeout defines how many seconds a stream can wait for the next message from the other peer before it's closed. 0 waits forever.
+func (c *Config) GetStreamReadTimeout() uint {
+	return c.uints[p2pStreamReadVar]
+}
+
+// GetStreamWriteTimeout defines how many seconds writing a message to a stream can take before the stream is closed. 0 waits forever.
+func (c *Config) GetStreamWriteTimeout() uint {
+	return c.uints[p2pStreamWriteVar]
+}
+
+// GetGossipD defines how many peers gossipsub keeps in the mesh of each channel
+func (c *Config) GetGossipD() uint {
+	return c.uints[p2pGossipDVar]
+}
+
+// GetGossipDlo defines how few mesh peers make gossipsub graft more
+func (c *Config) GetGossipDlo() uint {
+	return c.uints[p2pGossipDloVar]
+}
+
+// GetGossipDhi defines how many mesh peers make gossipsub prune some
+func (c *Config) GetGossipDhi() uint {
+	return c.uints[p2pGossipDhiVar]
+}
+
+// GetGossipHeartbeatInterval defines how often, in milliseconds, gossipsub maintains the mesh and gossips about recent messages
+func (c *Config) GetGossipHeartbeatInterval() uint {
+	return c.uints[p2pGossipHeartbeatIntervalVar]
+}
+
+// GetFloodPublishPeers defines the peer count under which messages are sent to all peers of a channel. 0 disables flood publishing.
+func (c *Config) GetFloodPublishPeers() uint {
+	return c.uints[p2pGossipFloodPublishPeersVar]
+}
+
+// GetGossipRelaySetting defines whether to forward the messages of channels this node hasn't joined between peers that can't reach each other
+func (c *Config) GetGossipRelaySetting() bool {
+	return c.booleans[p2pGossipRelayVar]
+}
+
+// GetGossipRelayHops defines how many relays a message may pass through before it's dropped
+func (c *Config) GetGossipRelayHops() uint {
+	return c.uints[p2pGossipRelayHopsVar]
+}
+
+// GetQueueDataRate defines how many new orders a second are published at most. 0 doesn't limit them.
+func (c *Config) GetQueueDataRate() uint {
+	return c.uints[p2pQueueDataRateVar]
+}
+
+// GetQueueBulkRate defines how many sync and market data messages a second are published at most. 0 doesn't limit them.
+func (c *Config) GetQueueBulkRate() uint {
+	return c.uints[p2pQueueBulkRateVar]
+}
+
+// GetChaosLatency defines how many milliseconds every received message is delayed for testing. Don't use in production.
+func (c *Config) GetChaosLatency() uint {
+	return c.uints[p2pChaosLatencyVar]
+}
+
+// GetChaosDropPercent defines the percentage of received messages that are dropped for testing. Don't use in production.
+func (c *Config) GetChaosDropPercent() uint {
+	return c.uints[p2pChaosDropPercentVar]
+}
+
+// GetChaosCloseStreamPercent defines the percentage of stream writes that close the stream instead for testing. Don't use in production.
+func (c *Config) GetChaosCloseStreamPercent() uint {
+	return c.uints[p2pChaosCloseStreamPercentVar]
+}
+
 // GetRPCPort defines the port the gRPC is running at
 func (c *Config) GetRPCPort() uint {
 	return c.uints[rpcPortVar]
 }
 
+// GetRPCReflectionSetting defines if the gRPC server reflection service is registered
+func (c *Config) GetRPCReflectionSetting() bool {
+	return c.booleans[rpcReflectionVar]
+}
+
+// GetAPIKeys defines comma separated namespace:key pairs that clients have to authenticate with. Empty doesn't require authentication.
+func (c *Config) GetAPIKeys() string {
+	return c.strings[rpcAPIKeysVar]
+}
+
+// GetAPIRoles defines comma separated namespace:role pairs, with read-only, trader or admin roles. Namespaces left out are traders, except admin.
+func (c *Config) GetAPIRoles() string {
+	return c.strings[rpcAPIRolesVar]
+}
+
+// GetRPCWebPort defines the port the gRPC API is served at over gRPC-Web for browsers. 0 disables it.
+func (c *Config) GetRPCWebPort() uint {
+	return c.uints[rpcWebPortVar]
+}
+
+// GetRPCWebOrigins defines the comma separated origins of the pages that may call the gRPC-Web API. Empty denies cross-origin calls.
+func (c *Config) GetRPCWebOrigins() string {
+	return c.strings[rpcWebOriginsVar]
+}
+
+// GetRPCKeepaliveTime defines how many seconds a gRPC connection may be idle before the server pings the client to keep it open. 0 keeps gRPC's default of two hours.
+func (c *Config) GetRPCKeepaliveTime() uint {
+	return c.uints[rpcKeepaliveTimeVar]
+}
+
+// GetRPCKeepaliveTimeout defines how many seconds the server waits for the answer to a keepalive ping before closing the connection
+func (c *Config) GetRPCKeepaliveTimeout() uint {
+	return c.uints[rpcKeepaliveTimeoutVar]
+}
+
+// GetRPCKeepaliveMinTime defines how many seconds clients must wait between keepalive pings. Clients that ping more often are disconnected.
+func (c *Config) GetRPCKeepaliveMinTime() uint {
+	return c.uints[rpcKeepaliveMinTimeVar]
+}
+
+// GetRPCKeepalivePermitWithoutStream defines if clients may send keepalive pings when they have no calls open
+func (c *Config) GetRPCKeepalivePermitWithoutStream() bool {
+	return c.booleans[rpcKeepaliveNoCallsVar]
+}
+
+// GetRPCMaxConnectionIdle defines how many seconds a gRPC connection without open calls is kept before it's closed. 0 never closes idle connections.
+func (c *Config) GetRPCMaxConnectionIdle() uint {
+	return c.uints[rpcMaxConnectionIdleVar]
+}
+
+// GetRPCMaxConnectionAge defines how many seconds a gRPC connection is kept before the client is asked to reconnect. 0 keeps connections for as long as they're used.
+func (c *Config) GetRPCMaxConnectionAge() uint {
+	return c.uints[rpcMaxConnectionAgeVar]
+}
+
+// GetRPCMaxRecvMessageSize defines the largest message, in bytes, that the gRPC server accepts. 0 keeps gRPC's default of 4 MiB.
+func (c *Config) GetRPCMaxRecvMessageSize() uint {
+	return c.uints[rpcMaxRecvMessageSizeVar]
+}
+
+// GetRPCMaxSendMessageSize defines the largest message, in bytes, that the gRPC server sends. 0 doesn't limit it.
+func (c *Config) GetRPCMaxSendMessageSize() uint {
+	return c.uints[rpcMaxSendMessageSizeVar]
+}
+
+// GetRPCMaxConcurrentStreams defines how many calls each gRPC client connection may have open at once. 0 keeps gRPC's default.
+func (c *Config) GetRPCMaxConcurrentStreams() uint {
+	return c.uints[rpcMaxConcurrentStreamsVar]
+}
+
 // GetWebsocketPort defines port for websocket connections. websocket.enable must be true or the port is not used.
 func (c *Config) GetWebsocketPort() uint {
 	return c.uints[websocketPortVar]
 }
 
+// GetWebsocketPingInterval defines how often, in seconds, websocket clients are pinged
+func (c *Config) GetWebsocketPingInterval() uint {
+	return c.uints[websocketPingIntervalVar]
+}
+
+// GetWebsocketPongTimeout defines how long, in seconds, a websocket client has to answer a ping before it's dropped
+func (c *Config) GetWebsocketPongTimeout() uint {
+	return c.uints[websocketPongTimeoutVar]
+}
+
+// GetWebsocketFlushInterval defines how long, in milliseconds, messages are collected into a single frame for each websocket client. 0 disables batching.
+func (c *Config) GetWebsocketFlushInterval() uint {
+	return c.uints[websocketFlushIntervalVar]
+}
+
+// GetWebsocketListeners defines comma separated listener URLs for websocket clients, like unix:///run/sprawl/ws.sock or tcp://0.0.0.0:3443?cert=ws.crt&key=ws.key&auth=apiKey. Empty listens on localhost at websocket.port.
+func (c *Config) GetWebsocketListeners() string {
+	return c.strings[websocketListenersVar]
+}
+
+// GetMarketAPIPort defines the port the read-only market data API is served at over HTTP. 0 disables it.
+func (c *Config) GetMarketAPIPort() uint {
+	return c.uints[marketAPIPortVar]
+}
+
+// GetMarketAPIMaxAge defines how many seconds clients and CDNs may cache the responses of the market data API
+func (c *Config) GetMarketAPIMaxAge() uint {
+	return c.uints[marketAPIMaxAgeVar]
+}
+
+// GetDashboardPort defines the port the operator dashboard is served at over HTTP. 0 disables it.
+func (c *Config) GetDashboardPort() uint {
+	return c.uints[dashboardPortVar]
+}
+
 // GetWebsocketEnable defines if websocket connections are allowed. Starts waiting http request using websocket.port
 func (c *Config) GetWebsocketEnable() bool {
 	return c.booleans[websocketEnableVar]
 }
 
+// GetRouterPairs defines the comma separated asset pairs, like BTC/ETH, that are mirrored between equivalent channels. "*" mirrors all pairs.
+func (c *Config) GetRouterPairs() string {
+	return c.strings[routerPairsVar]
+}
+
+// GetMarketDataIntervals defines the comma separated durations, like 1m or 1h, that candles of each channel's trades are aggregated over. Empty disables market data.
+func (c *Config) GetMarketDataIntervals() string {
+	return c.strings[marketDataIntervalsVar]
+}
+
+// GetHistoryRetention defines how long, in hours, deleted orders are kept in the order history
+func (c *Config) GetHistoryRetention() uint {
+	return c.uints[historyRetentionVar]
+}
+
 // GetInMemoryDatabaseSetting defines if RAM is used instead of LevelDB for storage
 func (c *Config) GetInMemoryDatabaseSetting() bool {
 	return c.booleans[dbInMemoryVar]
 }
 
+// GetIdentityPath defines the directory the node's key pair is stored in, apart from the database. Empty keeps it in the database.
+func (c *Config) GetIdentityPath() string {
+	return c.strings[identityPathVar]
+}
+
+// GetDatabaseEngine defines the database used for storage, "leveldb" or "sqlite". database.inMemory overrides it.
+func (c *Config) GetDatabaseEngine() string {
+	return c.strings[dbEngineVar]
+}
+
+// GetDatabaseEncryptionPassphrase defines the passphrase the database values are encrypted with. Empty stores them unencrypted.
+func (c *Config) GetDatabaseEncryptionPassphrase() string {
+	return c.strings[dbEncryptionPassphraseVar]
+}
+
+// GetMinFreeSpace defines how many megabytes have to be free on the database's disk for the node to accept new orders. 0 doesn't check.
+func (c *Config) GetMinFreeSpace() uint {
+	return c.uints[dbMinFreeSpaceVar]
+}
+
+// GetDiskCheckInterval defines how many seconds there are between checks of the free space on the database's disk
+func (c *Config) GetDiskCheckInterval() uint {
+	return c.uints[dbDiskCheckVar]
+}
+
+// GetCompactAt defines the local time of day, as HH:MM, when the database is compacted every day. Empty doesn't schedule compactions.
+func (c *Config) GetCompactAt() string {
+	return c.strings[dbCompactAtVar]
+}
+
+// GetGroupCommitWindow defines how many milliseconds LevelDB writes are gathered for before they're written as one batch. 0 writes each one right away.
+func (c *Config) GetGroupCommitWindow() uint {
+	return c.uints[dbGroupCommitWindowVar]
+}
+
+// GetFsyncPolicy defines when LevelDB writes are synced to disk, "never" or "always"
+func (c *Config) GetFsyncPolicy() string {
+	return c.strings[dbFsyncVar]
+}
+
+// GetDeleteBatchSize defines how many deletes are written to the database at once when deleting a whole prefix,
+// and how many entries of a backup are written at once when it's restored
+func (c *Config) GetDeleteBatchSize() uint {
+	return c.uints[dbDeleteBatchSizeVar]
+}
+
+// GetMigrationsDryRunSetting defines if the storage migrations only log what they would change, after which the node doesn't start
+func (c *Config) GetMigrationsDryRunSetting() bool {
+	return c.booleans[dbMigrationsDryRunVar]
+}
+
 // GetNATPortMapSetting defines whether to use NAT port mapping or not
 func (c *Config) GetNATPortMapSetting() bool {
 	return c.booleans[p2pNATPortMapVar]
@@ -217,3 +760,234 @@ func (c *Config) GetStackTraceSetting() bool {
 func (c *Config) GetIPFSPeerSetting() bool {
 	return c.booleans[ipfsPeerVar]
 }
+
+// GetBootstrapPeers defines the comma separated multiaddresses of bootstrap peers. /dnsaddr/ addresses are resolved when connecting.
+func (c *Config) GetBootstrapPeers() string {
+	return c.strings[p2pBootstrapPeersVar]
+}
+
+// GetBootstrapRefreshInterval defines how often, in minutes, bootstrap addresses are resolved again and reconnected to
+func (c *Config) GetBootstrapRefreshInterval() uint {
+	return c.uints[p2pBootstrapRefreshIntervalVar]
+}
+
+// GetDiscoveryInterval defines how often, in minutes, the node advertises itself and looks for new peers on the DHT. 0 looks for peers only at startup.
+func (c *Config) GetDiscoveryInterval() uint {
+	return c.uints[p2pDiscoveryIntervalVar]
+}
+
+// GetBrowserTransportsSetting defines whether to accept read-only connections from browsers over websockets
+func (c *Config) GetBrowserTransportsSetting() bool {
+	return c.booleans[p2pBrowserTransportsVar]
+}
+
+// GetBrowserPort defines the websocket port browsers connect to when browser transports are enabled
+func (c *Config) GetBrowserPort() uint {
+	return c.uints[p2pBrowserPortVar]
+}
+
+// GetSecurity defines the security transport used to encrypt connections to peers, "secio" or "tls"
+func (c *Config) GetSecurity() string {
+	return c.strings[p2pSecurityVar]
+}
+
+// GetPrivateNetworkKey defines the hex encoded 32 byte key of a private network. Empty joins the public network.
+func (c *Config) GetPrivateNetworkKey() string {
+	return c.strings[p2pPrivateNetworkKeyVar]
+}
+
+// GetListenAddresses defines the comma separated multiaddresses to listen on, replacing the ones made from p2p.externalIP and p2p.port.
+// Port 0 lets the OS pick a free port.
+func (c *Config) GetListenAddresses() string {
+	return c.strings[p2pListenAddressesVar]
+}
+
+// GetExternalIPv6 defines the listened external IPv6 address for P2P, next to p2p.externalIP
+func (c *Config) GetExternalIPv6() string {
+	return c.strings[p2pExternalIPv6Var]
+}
+
+// GetQUICSetting defines whether to listen and dial over QUIC as well as TCP. QUIC can't be used on private networks.
+func (c *Config) GetQUICSetting() bool {
+	return c.booleans[p2pQUICVar]
+}
+
+// GetReusePortSetting defines whether TCP connections are dialed from the listened port with SO_REUSEPORT, where the OS supports it
+func (c *Config) GetReusePortSetting() bool {
+	return c.booleans[p2pReusePortVar]
+}
+
+// GetAnnounceAddresses defines the comma separated multiaddresses announced to other peers instead of the listened ones
+func (c *Config) GetAnnounceAddresses() string {
+	return c.strings[p2pAnnounceAddressesVar]
+}
+
+// GetNoAnnounce defines the comma separated multiaddresses and IP ranges, like 10.0.0.0/8, that are never announced to other peers
+func (c *Config) GetNoAnnounce() string {
+	return c.strings[p2pNoAnnounceVar]
+}
+
+// GetLockTimeout defines how long, in seconds, an order stays locked before it's unlocked automatically. 0 keeps locks forever.
+func (c *Config) GetLockTimeout() uint {
+	return c.uints[ordersLockTimeoutVar]
+}
+
+// GetUnlockInterval defines how often, in seconds, timed out locks are looked for
+func (c *Config) GetUnlockInterval() uint {
+	return c.uints[ordersUnlockIntervalVar]
+}
+
+// GetOrderCacheSize defines how many single orders are cached in memory for GetOrder. 0 disables the cache.
+func (c *Config) GetOrderCacheSize() uint {
+	return c.uints[ordersCacheSizeVar]
+}
+
+// GetMaxClockSkew defines how many seconds clocks may differ between peers before orders are flagged as coming from the future. 0 disables the checks.
+func (c *Config) GetMaxClockSkew() uint {
+	return c.uints[ordersMaxClockSkewVar]
+}
+
+// GetMakerRateLimit defines how many orders per second a single maker may create on a channel before the rest are ignored. 0 doesn't limit them.
+func (c *Config) GetMakerRateLimit() uint {
+	return c.uints[ordersMakerRateVar]
+}
+
+// GetMaxMakerOrders defines how many open orders a single maker may have on a channel before its new ones are ignored. 0 doesn't limit them.
+func (c *Config) GetMaxMakerOrders() uint {
+	return c.uints[ordersMaxMakerVar]
+}
+
+// GetOrderWorkers defines how many orders are created and received at once. Creating more is refused until one is done. 0 doesn't limit them.
+func (c *Config) GetOrderWorkers() uint {
+	return c.uints[ordersWorkersVar]
+}
+
+// GetAntiEntropyInterval defines how often, in seconds, the digests of joined channels' order books are compared with peers. 0 doesn't compare them.
+func (c *Config) GetAntiEntropyInterval() uint {
+	return c.uints[ordersAntiEntropyVar]
+}
+
+// GetOrderIDMismatch defines what's done with received orders whose ID doesn't match their content: accept, reject or quarantine
+func (c *Config) GetOrderIDMismatch() string {
+	return c.strings[ordersIDMismatchVar]
+}
+
+// GetOrderIDCollision defines what's done when a received order claims the ID of a different stored order: reject or lastWriteWins
+func (c *Config) GetOrderIDCollision() string {
+	return c.strings[ordersIDCollisionVar]
+}
+
+// GetMaxOrderAge defines how old, in hours, open orders may get before they're moved into the order history. 0 keeps them forever.
+func (c *Config) GetMaxOrderAge() uint {
+	return c.uints[channelsMaxOrderAgeVar]
+}
+
+// GetMaxOrders defines how many open orders are kept on each channel, moving the oldest ones into the order history. 0 doesn't limit them.
+func (c *Config) GetMaxOrders() uint {
+	return c.uints[channelsMaxOrdersVar]
+}
+
+// GetChannelPruneInterval defines how often, in minutes, channels are pruned down to their retention policy
+func (c *Config) GetChannelPruneInterval() uint {
+	return c.uints[channelsPruneIntervalVar]
+}
+
+// GetAllowCustomAssets defines whether channels can be joined with asset symbols that Sprawl doesn't recognize
+func (c *Config) GetAllowCustomAssets() bool {
+	return c.booleans[channelsAllowCustomAssetsVar]
+}
+
+// GetChannelIdleTimeout defines how many minutes a joined channel may go without orders or peers before the node leaves it. 0 never leaves channels.
+func (c *Config) GetChannelIdleTimeout() uint {
+	return c.uints[channelsIdleTimeoutVar]
+}
+
+// GetWebhookURLs defines the comma separated URLs that order events are POSTed to
+func (c *Config) GetWebhookURLs() string {
+	return c.strings[webhooksURLsVar]
+}
+
+// GetWebhookEvents defines the comma separated order events sent to webhooks: created, deleted, locked and unlocked. Empty sends all of them.
+func (c *Config) GetWebhookEvents() string {
+	return c.strings[webhooksEventsVar]
+}
+
+// GetWebhookSecret defines the key webhook payloads are signed with
+func (c *Config) GetWebhookSecret() string {
+	return c.strings[webhooksSecretVar]
+}
+
+// GetWebhookRetries defines how many times a failed webhook is retried, waiting twice as long each time
+func (c *Config) GetWebhookRetries() uint {
+	return c.uints[webhooksRetriesVar]
+}
+
+// GetSettlementEngine defines the registered settlement engine that settles the fills of this node's orders
+func (c *Config) GetSettlementEngine() string {
+	return c.strings[settlementEngineVar]
+}
+
+// GetEthereumRPCURL defines the JSON-RPC endpoint of the Ethereum node that the ethereum settlement engine sends transactions to
+func (c *Config) GetEthereumRPCURL() string {
+	return c.strings[ethRPCURLVar]
+}
+
+// GetEthereumContract defines the address of the escrow contract that trades are settled with
+func (c *Config) GetEthereumContract() string {
+	return c.strings[ethContractVar]
+}
+
+// GetEthereumAccount defines the address that escrow transactions are sent from. The Ethereum node has to be able to sign for it.
+func (c *Config) GetEthereumAccount() string {
+	return c.strings[ethAccountVar]
+}
+
+// GetEthereumConfirmations defines how many blocks have to confirm an escrow transaction before the trade is settled
+func (c *Config) GetEthereumConfirmations() uint {
+	return c.uints[ethConfirmationsVar]
+}
+
+// GetEthereumPollInterval defines how many seconds there are between checks of pending escrow transactions
+func (c *Config) GetEthereumPollInterval() uint {
+	return c.uints[ethPollIntervalVar]
+}
+
+// GetEthereumTimeout defines how many seconds an escrow has to be confirmed in before the trade is aborted and the escrow refunded
+func (c *Config) GetEthereumTimeout() uint {
+	return c.uints[ethTimeoutVar]
+}
+
+// GetReplicationSetting defines whether other nodes can follow this one with AdminHandler.Replicate, replicating its storage
+func (c *Config) GetReplicationSetting() bool {
+	return c.booleans[replicationEnableVar]
+}
+
+// GetReplicationPrimary defines the gRPC address of the primary that this node follows as a standby. Empty runs the node as a primary.
+func (c *Config) GetReplicationPrimary() string {
+	return c.strings[replicationPrimaryVar]
+}
+
+// GetReplicationAPIKey defines the admin API key this node authenticates to its primary with
+func (c *Config) GetReplicationAPIKey() string {
+	return c.strings[replicationAPIKeyVar]
+}
+
+// GetPprofPort defines the port of the pprof HTTP listener. 0 disables it.
+func (c *Config) GetPprofPort() uint {
+	return c.uints[debugPprofPortVar]
+}
+
+// GetProfileDir defines the directory profiles captured over RPC are written to. Empty uses the system's temporary directory.
+func (c *Config) GetProfileDir() string {
+	return c.strings[debugProfileDirVar]
+}
+
+// GetDeadLetters defines how many received messages that failed processing are kept for inspection, dropping the oldest ones first. 0 doesn't keep them.
+func (c *Config) GetDeadLetters() uint {
+	return c.uints[debugDeadLettersVar]
+}
+
+// GetCountersInterval defines how many seconds there are between storing the all-time totals of the node's counters. 0 only stores them when the node closes.
+func (c *Config) GetCountersInterval() uint {
+	return c.uints[debugCountersIntervalVar]
+}
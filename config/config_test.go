@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sprawl/sprawl/interfaces"
@@ -11,7 +12,10 @@ import (
 
 const defaultConfigPath string = "default"
 const invalidConfigPath string = "invalid"
-const defaultDBPath string = "/var/lib/sprawl/data"
+
+// defaultDBPath depends on the OS and the user running the tests
+var defaultDBPath = defaultDatabasePath()
+
 const defaultExternalIP string = ""
 const defaultAPIPort uint = 1337
 const defaultP2PPort uint = 4001
@@ -69,52 +73,188 @@ func resetEnv() {
 	os.Unsetenv(websocketEnableEnvVar)
 }
 
+// defaultValue is what a getter returns for a key, and the default it should return when the key isn't set
+type defaultValue struct {
+	key      string
+	value    interface{}
+	expected interface{}
+}
+
+// getDefaultValues reads every key through its getter, together with its default
+func getDefaultValues(c interfaces.Config) []defaultValue {
+	return []defaultValue{
+		{dbPathVar, c.GetDatabasePath(), defaultDBPath},
+		{dbInMemoryVar, c.GetInMemoryDatabaseSetting(), defaultDatabaseInMemorySetting},
+		{rpcPortVar, c.GetRPCPort(), defaultAPIPort},
+		{p2pDebugVar, c.GetDebugSetting(), defaultDebugSetting},
+		{errorsEnableStackTraceVar, c.GetStackTraceSetting(), defaultStackTraceSetting},
+		{p2pExternalIPVar, c.GetExternalIP(), defaultExternalIP},
+		{p2pPortVar, c.GetP2PPort(), defaultP2PPort},
+		{p2pNATPortMapVar, c.GetNATPortMapSetting(), defaultNATPortMapSetting},
+		{p2pRelayVar, c.GetRelaySetting(), defaultRelaySetting},
+		{p2pAutoRelayVar, c.GetAutoRelaySetting(), defaultAutoRelaySetting},
+		{logLevelVar, c.GetLogLevel(), defaultLogLevel},
+		{logFormatVar, c.GetLogFormat(), defaultLogFormat},
+		{ipfsPeerVar, c.GetIPFSPeerSetting(), defaultIPFSPeerSetting},
+		{websocketEnableVar, c.GetWebsocketEnable(), defaultWebsocketEnableSetting},
+		{websocketPortVar, c.GetWebsocketPort(), defaultWebsocketPort},
+		{websocketPingIntervalVar, c.GetWebsocketPingInterval(), uint(30)},
+		{websocketPongTimeoutVar, c.GetWebsocketPongTimeout(), uint(10)},
+		{websocketFlushIntervalVar, c.GetWebsocketFlushInterval(), uint(0)},
+		{websocketListenersVar, c.GetWebsocketListeners(), ""},
+		{marketAPIPortVar, c.GetMarketAPIPort(), uint(0)},
+		{marketAPIMaxAgeVar, c.GetMarketAPIMaxAge(), uint(5)},
+		{dashboardPortVar, c.GetDashboardPort(), uint(0)},
+		{routerPairsVar, c.GetRouterPairs(), ""},
+		{marketDataIntervalsVar, c.GetMarketDataIntervals(), "1m,5m,1h,24h"},
+		{rpcReflectionVar, c.GetRPCReflectionSetting(), false},
+		{rpcAPIKeysVar, c.GetAPIKeys(), ""},
+		{rpcAPIRolesVar, c.GetAPIRoles(), ""},
+		{rpcWebPortVar, c.GetRPCWebPort(), uint(0)},
+		{rpcWebOriginsVar, c.GetRPCWebOrigins(), ""},
+		{rpcKeepaliveTimeVar, c.GetRPCKeepaliveTime(), uint(60)},
+		{rpcKeepaliveTimeoutVar, c.GetRPCKeepaliveTimeout(), uint(20)},
+		{rpcKeepaliveMinTimeVar, c.GetRPCKeepaliveMinTime(), uint(10)},
+		{rpcKeepaliveNoCallsVar, c.GetRPCKeepalivePermitWithoutStream(), true},
+		{rpcMaxConnectionIdleVar, c.GetRPCMaxConnectionIdle(), uint(0)},
+		{rpcMaxConnectionAgeVar, c.GetRPCMaxConnectionAge(), uint(0)},
+		{rpcMaxRecvMessageSizeVar, c.GetRPCMaxRecvMessageSize(), uint(4194304)},
+		{rpcMaxSendMessageSizeVar, c.GetRPCMaxSendMessageSize(), uint(0)},
+		{rpcMaxConcurrentStreamsVar, c.GetRPCMaxConcurrentStreams(), uint(0)},
+		{historyRetentionVar, c.GetHistoryRetention(), uint(168)},
+		{p2pMessageRateLimitVar, c.GetMessageRateLimit(), uint(50)},
+		{p2pMaxMessageSizeVar, c.GetMaxMessageSize(), uint(1048576)},
+		{p2pThrottleScoreVar, c.GetThrottleScore(), uint(50)},
+		{p2pDisconnectScoreVar, c.GetDisconnectScore(), uint(20)},
+		{p2pConnLowVar, c.GetConnLow(), uint(50)},
+		{p2pConnHighVar, c.GetConnHigh(), uint(200)},
+		{p2pConnGracePeriodVar, c.GetConnGracePeriod(), uint(20)},
+		{p2pJournalRetentionVar, c.GetJournalRetention(), uint(24)},
+		{p2pFastSyncPeersVar, c.GetFastSyncPeers(), ""},
+		{p2pNetworkVar, c.GetNetwork(), "mainnet"},
+		{p2pStreamReadVar, c.GetStreamReadTimeout(), uint(60)},
+		{p2pStreamWriteVar, c.GetStreamWriteTimeout(), uint(30)},
+		{p2pGossipDVar, c.GetGossipD(), uint(6)},
+		{p2pGossipDloVar, c.GetGossipDlo(), uint(4)},
+		{p2pGossipDhiVar, c.GetGossipDhi(), uint(12)},
+		{p2pGossipHeartbeatIntervalVar, c.GetGossipHeartbeatInterval(), uint(1000)},
+		{p2pGossipFloodPublishPeersVar, c.GetFloodPublishPeers(), uint(0)},
+		{p2pGossipRelayVar, c.GetGossipRelaySetting(), false},
+		{p2pGossipRelayHopsVar, c.GetGossipRelayHops(), uint(3)},
+		{p2pQueueDataRateVar, c.GetQueueDataRate(), uint(0)},
+		{p2pQueueBulkRateVar, c.GetQueueBulkRate(), uint(10)},
+		{p2pChaosLatencyVar, c.GetChaosLatency(), uint(0)},
+		{p2pChaosDropPercentVar, c.GetChaosDropPercent(), uint(0)},
+		{p2pChaosCloseStreamPercentVar, c.GetChaosCloseStreamPercent(), uint(0)},
+		{p2pBootstrapPeersVar, c.GetBootstrapPeers(), ""},
+		{p2pBootstrapRefreshIntervalVar, c.GetBootstrapRefreshInterval(), uint(10)},
+		{p2pDiscoveryIntervalVar, c.GetDiscoveryInterval(), uint(5)},
+		{dbDeleteBatchSizeVar, c.GetDeleteBatchSize(), uint(1000)},
+		{dbMigrationsDryRunVar, c.GetMigrationsDryRunSetting(), false},
+		{dbEngineVar, c.GetDatabaseEngine(), "leveldb"},
+		{identityPathVar, c.GetIdentityPath(), ""},
+		{dbEncryptionPassphraseVar, c.GetDatabaseEncryptionPassphrase(), ""},
+		{dbMinFreeSpaceVar, c.GetMinFreeSpace(), uint(512)},
+		{dbDiskCheckVar, c.GetDiskCheckInterval(), uint(60)},
+		{dbCompactAtVar, c.GetCompactAt(), ""},
+		{dbGroupCommitWindowVar, c.GetGroupCommitWindow(), uint(0)},
+		{dbFsyncVar, c.GetFsyncPolicy(), "never"},
+		{p2pBrowserTransportsVar, c.GetBrowserTransportsSetting(), false},
+		{p2pBrowserPortVar, c.GetBrowserPort(), uint(4002)},
+		{p2pSecurityVar, c.GetSecurity(), "secio"},
+		{p2pPrivateNetworkKeyVar, c.GetPrivateNetworkKey(), ""},
+		{p2pListenAddressesVar, c.GetListenAddresses(), ""},
+		{p2pExternalIPv6Var, c.GetExternalIPv6(), ""},
+		{p2pQUICVar, c.GetQUICSetting(), false},
+		{p2pReusePortVar, c.GetReusePortSetting(), true},
+		{p2pAnnounceAddressesVar, c.GetAnnounceAddresses(), ""},
+		{p2pNoAnnounceVar, c.GetNoAnnounce(), ""},
+		{ordersLockTimeoutVar, c.GetLockTimeout(), uint(300)},
+		{ordersUnlockIntervalVar, c.GetUnlockInterval(), uint(10)},
+		{ordersCacheSizeVar, c.GetOrderCacheSize(), uint(1024)},
+		{ordersMaxClockSkewVar, c.GetMaxClockSkew(), uint(30)},
+		{ordersMakerRateVar, c.GetMakerRateLimit(), uint(10)},
+		{ordersMaxMakerVar, c.GetMaxMakerOrders(), uint(1000)},
+		{ordersWorkersVar, c.GetOrderWorkers(), uint(64)},
+		{ordersAntiEntropyVar, c.GetAntiEntropyInterval(), uint(300)},
+		{ordersIDMismatchVar, c.GetOrderIDMismatch(), "accept"},
+		{ordersIDCollisionVar, c.GetOrderIDCollision(), "reject"},
+		{channelsMaxOrderAgeVar, c.GetMaxOrderAge(), uint(0)},
+		{channelsMaxOrdersVar, c.GetMaxOrders(), uint(0)},
+		{channelsPruneIntervalVar, c.GetChannelPruneInterval(), uint(60)},
+		{channelsAllowCustomAssetsVar, c.GetAllowCustomAssets(), false},
+		{channelsIdleTimeoutVar, c.GetChannelIdleTimeout(), uint(0)},
+		{webhooksURLsVar, c.GetWebhookURLs(), ""},
+		{webhooksEventsVar, c.GetWebhookEvents(), ""},
+		{webhooksSecretVar, c.GetWebhookSecret(), ""},
+		{webhooksRetriesVar, c.GetWebhookRetries(), uint(3)},
+		{settlementEngineVar, c.GetSettlementEngine(), "noop"},
+		{ethRPCURLVar, c.GetEthereumRPCURL(), ""},
+		{ethContractVar, c.GetEthereumContract(), ""},
+		{ethAccountVar, c.GetEthereumAccount(), ""},
+		{ethConfirmationsVar, c.GetEthereumConfirmations(), uint(12)},
+		{ethPollIntervalVar, c.GetEthereumPollInterval(), uint(15)},
+		{ethTimeoutVar, c.GetEthereumTimeout(), uint(3600)},
+		{replicationEnableVar, c.GetReplicationSetting(), false},
+		{replicationPrimaryVar, c.GetReplicationPrimary(), ""},
+		{replicationAPIKeyVar, c.GetReplicationAPIKey(), ""},
+		{debugPprofPortVar, c.GetPprofPort(), uint(0)},
+		{debugProfileDirVar, c.GetProfileDir(), ""},
+		{debugDeadLettersVar, c.GetDeadLetters(), uint(1000)},
+		{debugCountersIntervalVar, c.GetCountersInterval(), uint(60)},
+	}
+}
+
 func TestErrors(t *testing.T) {
 	resetEnv()
 	var dbPath string
 	dbPath = config.GetDatabasePath()
 	assert.Equal(t, dbPath, "")
-	// Test an invalid config file
+	// Test an invalid config file, which falls back to defaults
 	config.ReadConfig(invalidConfigPath)
 	dbPath = config.GetDatabasePath()
-	assert.Equal(t, dbPath, "")
+	assert.Equal(t, dbPath, defaultDBPath)
+}
+
+// TestNoConfigFile tests that every key has a default without any config file
+func TestNoConfigFile(t *testing.T) {
+	resetEnv()
+	config.ReadConfig("")
+
+	for _, value := range getDefaultValues(config) {
+		assert.Equal(t, value.expected, value.value, value.key)
+	}
+}
+
+// TestFlags tests that flags set on the command line overwrite environment variables and config files
+func TestFlags(t *testing.T) {
+	resetEnv()
+	os.Setenv(dbPathEnvVar, envTestDBPath)
+	os.Setenv(rpcPortEnvVar, "9002")
+	defer resetEnv()
+
+	flags := NewFlagSet("test")
+	err := flags.Parse([]string{"--rpc.port=9001", "--p2p.debug"})
+	assert.NoError(t, err)
+
+	flagConfig := &Config{}
+	flagConfig.BindFlags(flags)
+	flagConfig.ReadConfig(defaultConfigPath)
+
+	assert.Equal(t, flagConfig.GetRPCPort(), envTestAPIPort)
+	assert.Equal(t, flagConfig.GetDebugSetting(), true)
+	// Flags that aren't set don't overwrite anything
+	assert.Equal(t, flagConfig.GetDatabasePath(), envTestDBPath)
+	assert.Equal(t, flagConfig.GetP2PPort(), defaultP2PPort)
 }
 
 func TestDefaults(t *testing.T) {
 	resetEnv()
 	config.ReadConfig(defaultConfigPath)
 
-	databasePath := config.GetDatabasePath()
-	inMemory := config.GetInMemoryDatabaseSetting()
-	rpcPort := config.GetRPCPort()
-	p2pDebug := config.GetDebugSetting()
-	errorsEnableStackTrace := config.GetStackTraceSetting()
-	externalIP := config.GetExternalIP()
-	p2pPort := config.GetP2PPort()
-	NATPortMap := config.GetNATPortMapSetting()
-	relay := config.GetRelaySetting()
-	autoRelay := config.GetAutoRelaySetting()
-	logLevel := config.GetLogLevel()
-	logFormat := config.GetLogFormat()
-	ipfsPeers := config.GetIPFSPeerSetting()
-	websocketEnable := config.GetWebsocketEnable()
-	websocketPort := config.GetWebsocketPort()
-
-	assert.Equal(t, databasePath, defaultDBPath)
-	assert.Equal(t, inMemory, defaultDatabaseInMemorySetting)
-	assert.Equal(t, rpcPort, defaultAPIPort)
-	assert.Equal(t, p2pDebug, defaultDebugSetting)
-	assert.Equal(t, errorsEnableStackTrace, defaultStackTraceSetting)
-	assert.Equal(t, externalIP, defaultExternalIP)
-	assert.Equal(t, p2pPort, defaultP2PPort)
-	assert.Equal(t, NATPortMap, defaultNATPortMapSetting)
-	assert.Equal(t, relay, defaultRelaySetting)
-	assert.Equal(t, autoRelay, defaultAutoRelaySetting)
-	assert.Equal(t, logLevel, defaultLogLevel)
-	assert.Equal(t, logFormat, defaultLogFormat)
-	assert.Equal(t, ipfsPeers, defaultIPFSPeerSetting)
-	assert.Equal(t, websocketEnable, defaultWebsocketEnableSetting)
-	assert.Equal(t, websocketPort, defaultWebsocketPort)
+	for _, value := range getDefaultValues(config) {
+		assert.Equal(t, value.expected, value.value, value.key)
+	}
 }
 
 // TestEnvironment tests that environment variables overwrite any other configuration
@@ -128,3 +268,17 @@ func TestEnvironment(t *testing.T) {
 
 	resetEnv()
 }
+
+func TestExpandPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "sprawl"), expandPath("~/sprawl"))
+
+	os.Setenv("SPRAWL_TEST_DATA", "/tmp/sprawltest")
+	defer os.Unsetenv("SPRAWL_TEST_DATA")
+	assert.Equal(t, filepath.Clean("/tmp/sprawltest/data"), expandPath("$SPRAWL_TEST_DATA/data"))
+
+	// No path means the OS data directory
+	assert.Equal(t, defaultDatabasePath(), resolveDatabasePath(""))
+	assert.Equal(t, filepath.Clean("/var/lib/sprawl/data"), resolveDatabasePath("/var/lib/sprawl/data"))
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// legacyDatabasePath is where data was stored before the default depended on the OS.
+// Linux nodes that already have it keep using it.
+const legacyDatabasePath string = "/var/lib/sprawl/data"
+
+// windowsEnvVar matches %NAME% references to environment variables in Windows paths
+var windowsEnvVar = regexp.MustCompile(`%([^%]+)%`)
+
+// defaultDatabasePath returns the data directory of the operating system for Sprawl:
+// %APPDATA%\Sprawl\data on Windows, ~/Library/Application Support/Sprawl/data on macOS
+// and $XDG_DATA_HOME/sprawl/data, or ~/.local/share/sprawl/data, elsewhere
+func defaultDatabasePath() string {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		if dir, err := os.UserConfigDir(); err == nil {
+			return filepath.Join(dir, "Sprawl", "data")
+		}
+	default:
+		if _, err := os.Stat(legacyDatabasePath); err == nil {
+			return legacyDatabasePath
+		}
+		if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+			return filepath.Join(dir, "sprawl", "data")
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local", "share", "sprawl", "data")
+		}
+	}
+	return legacyDatabasePath
+}
+
+// expandPath replaces a leading ~ with the home directory and environment variables with their values.
+// Windows paths can refer to variables as %NAME% too.
+func expandPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + path[1:]
+		}
+	}
+	if runtime.GOOS == "windows" {
+		path = windowsEnvVar.ReplaceAllStringFunc(path, func(variable string) string {
+			return os.Getenv(strings.Trim(variable, "%"))
+		})
+	}
+	return filepath.Clean(os.ExpandEnv(path))
+}
+
+// resolveIdentityPath returns the configured identity path expanded, or an empty path if none is set
+func resolveIdentityPath(path string) string {
+	if strings.TrimSpace(path) == "" {
+		return ""
+	}
+	return expandPath(path)
+}
+
+// resolveDatabasePath returns the configured database path expanded, or the OS default if none is set
+func resolveDatabasePath(path string) string {
+	if strings.TrimSpace(path) == "" {
+		return defaultDatabasePath()
+	}
+	return expandPath(path)
+}
@@ -0,0 +1,141 @@
+package config
+
+// setting is a config key with its default value and what it's for
+type setting struct {
+	key          string
+	defaultValue interface{}
+	description  string
+}
+
+// settings are every key the node reads, grouped by the table they're in. The defaults, the flags and the config
+// file written by WriteDefaultConfig are all made from them, so a key added here is never missing from any of them.
+var settings = []setting{
+	{dbPathVar, "", "The host directory for the database. An empty path is the OS data directory."},
+	{dbInMemoryVar, false, "Whether RAM is used instead of LevelDB for storage"},
+	{dbDeleteBatchSizeVar, uint(1000), "How many deletes are written to the database at once when deleting a whole prefix, and how many entries at once when restoring a backup"},
+	{dbMigrationsDryRunVar, false, "Whether the storage migrations only log what they would change, after which the node doesn't start"},
+	{dbEngineVar, "leveldb", "The database used for storage, \"leveldb\" or \"sqlite\". database.inMemory overrides it."},
+	{dbEncryptionPassphraseVar, "", "The passphrase the database values are encrypted with. Empty stores them unencrypted."},
+	{dbMinFreeSpaceVar, uint(512), "How many megabytes have to be free on the database's disk for the node to accept new orders. 0 doesn't check."},
+	{dbDiskCheckVar, uint(60), "How many seconds there are between checks of the free space on the database's disk"},
+	{dbCompactAtVar, "", "The local time of day, as HH:MM, when the database is compacted every day. Empty doesn't schedule compactions."},
+	{dbGroupCommitWindowVar, uint(0), "How many milliseconds LevelDB writes are gathered for before they're written as one batch. 0 writes each one right away."},
+	{dbFsyncVar, "never", "When LevelDB writes are synced to disk, \"never\" leaving it to the OS or \"always\" before the write returns"},
+	{identityPathVar, "", "The directory the node's key pair is stored in, apart from the database. Empty keeps it in the database."},
+	{rpcPortVar, uint(1337), "The port the gRPC API is served at"},
+	{rpcReflectionVar, false, "Whether the gRPC server reflection service is registered"},
+	{rpcAPIKeysVar, "", "Comma separated namespace:key pairs that clients have to authenticate with. Empty doesn't require authentication."},
+	{rpcAPIRolesVar, "", "Comma separated namespace:role pairs, with read-only, trader or admin roles. Namespaces left out are traders, except admin."},
+	{rpcWebPortVar, uint(0), "The port the gRPC API is served at over gRPC-Web for browsers. 0 disables it."},
+	{rpcWebOriginsVar, "", "The comma separated origins of the pages that may call the gRPC-Web API. Empty denies cross-origin calls."},
+	{rpcKeepaliveTimeVar, uint(60), "How many seconds a gRPC connection may be idle before the server pings the client to keep it open. 0 keeps gRPC's default of two hours."},
+	{rpcKeepaliveTimeoutVar, uint(20), "How many seconds the server waits for the answer to a keepalive ping before closing the connection"},
+	{rpcKeepaliveMinTimeVar, uint(10), "How many seconds clients must wait between keepalive pings. Clients that ping more often are disconnected."},
+	{rpcKeepaliveNoCallsVar, true, "Whether clients may send keepalive pings when they have no calls open"},
+	{rpcMaxConnectionIdleVar, uint(0), "How many seconds a gRPC connection without open calls is kept before it's closed. 0 never closes idle connections."},
+	{rpcMaxConnectionAgeVar, uint(0), "How many seconds a gRPC connection is kept before the client is asked to reconnect. 0 keeps connections for as long as they're used."},
+	{rpcMaxRecvMessageSizeVar, uint(4194304), "The largest message, in bytes, that the gRPC server accepts. 0 keeps gRPC's default of 4 MiB."},
+	{rpcMaxSendMessageSizeVar, uint(0), "The largest message, in bytes, that the gRPC server sends. 0 doesn't limit it."},
+	{rpcMaxConcurrentStreamsVar, uint(0), "How many calls each gRPC client connection may have open at once. 0 keeps gRPC's default."},
+	{p2pExternalIPVar, "", "The external IPv4 address P2P listens on"},
+	{p2pPortVar, uint(4001), "The port P2P listens on"},
+	{p2pDebugVar, false, "Whether to run the debug pinger, which creates and deletes an order on \"testChannel\" every few seconds"},
+	{p2pRelayVar, true, "Whether the node relays connections for peers that can't be reached directly"},
+	{p2pAutoRelayVar, true, "Whether the node finds relays and announces addresses through them when it can't be reached directly"},
+	{p2pNATPortMapVar, true, "Whether to map the listened ports on the router with UPnP or NAT-PMP"},
+	{ipfsPeerVar, true, "Whether the IPFS bootstrap peers are used for discovery, next to p2p.bootstrapPeers"},
+	{p2pBootstrapPeersVar, "", "The comma separated multiaddresses of bootstrap peers. /dnsaddr/ addresses are resolved when connecting."},
+	{p2pBootstrapRefreshIntervalVar, uint(10), "How often, in minutes, bootstrap addresses are resolved again and reconnected to"},
+	{p2pDiscoveryIntervalVar, uint(5), "How often, in minutes, the node advertises itself and looks for new peers on the DHT. 0 looks for peers only at startup."},
+	{p2pBrowserTransportsVar, false, "Whether to accept read-only connections from browsers over websockets"},
+	{p2pBrowserPortVar, uint(4002), "The websocket port browsers connect to when browser transports are enabled"},
+	{p2pSecurityVar, "secio", "The security transport used to encrypt connections to peers, \"secio\" or \"tls\""},
+	{p2pPrivateNetworkKeyVar, "", "The hex encoded 32 byte key of a private network. Empty joins the public network."},
+	{p2pListenAddressesVar, "", "The comma separated multiaddresses to listen on, replacing the ones made from p2p.externalIP and p2p.port. Port 0 lets the OS pick a free port."},
+	{p2pExternalIPv6Var, "", "The listened external IPv6 address for P2P, next to p2p.externalIP"},
+	{p2pQUICVar, false, "Whether to listen and dial over QUIC as well as TCP. QUIC can't be used on private networks."},
+	{p2pReusePortVar, true, "Whether TCP connections are dialed from the listened port with SO_REUSEPORT, where the OS supports it"},
+	{p2pAnnounceAddressesVar, "", "The comma separated multiaddresses announced to other peers instead of the listened ones"},
+	{p2pNoAnnounceVar, "", "The comma separated multiaddresses and IP ranges, like 10.0.0.0/8, that are never announced to other peers"},
+	{p2pMessageRateLimitVar, uint(50), "How many messages per second a peer may send before its messages are dropped"},
+	{p2pMaxMessageSizeVar, uint(1048576), "How many bytes a message from a peer may have before it's rejected. 0 doesn't limit it."},
+	{p2pThrottleScoreVar, uint(50), "The reputation score under which a peer's message rate limit is lowered"},
+	{p2pDisconnectScoreVar, uint(20), "The reputation score under which a peer is disconnected and blacklisted"},
+	{p2pConnLowVar, uint(50), "How many connections the connection manager trims down to"},
+	{p2pConnHighVar, uint(200), "How many connections trigger the connection manager to trim them. 0 disables the connection manager."},
+	{p2pConnGracePeriodVar, uint(20), "How many seconds new connections are kept before they can be trimmed"},
+	{p2pJournalRetentionVar, uint(24), "How many hours messages published with no peers on the channel are kept for resending. 0 disables the journal."},
+	{p2pFastSyncPeersVar, "", "The comma separated peer IDs of trusted peers that joined channels are downloaded from as a single snapshot. Empty replays the orders from the first peer on the channel instead."},
+	{p2pNetworkVar, "mainnet", "The network the node is on. Nodes only connect to and exchange orders with nodes on the same network."},
+	{p2pStreamReadVar, uint(60), "How many seconds a stream can wait for the next message from the other peer before it's closed. 0 waits forever."},
+	{p2pStreamWriteVar, uint(30), "How many seconds writing a message to a stream can take before the stream is closed. 0 waits forever."},
+	{p2pGossipDVar, uint(6), "How many peers gossipsub keeps in the mesh of each channel"},
+	{p2pGossipDloVar, uint(4), "How few mesh peers make gossipsub graft more"},
+	{p2pGossipDhiVar, uint(12), "How many mesh peers make gossipsub prune some"},
+	{p2pGossipHeartbeatIntervalVar, uint(1000), "How often, in milliseconds, gossipsub maintains the mesh and gossips about recent messages"},
+	{p2pGossipFloodPublishPeersVar, uint(0), "The peer count under which messages are sent to all peers of a channel. 0 disables flood publishing."},
+	{p2pGossipRelayVar, false, "Whether to forward the messages of channels this node hasn't joined between peers that can't reach each other"},
+	{p2pGossipRelayHopsVar, uint(3), "How many relays a message may pass through before it's dropped"},
+	{p2pQueueDataRateVar, uint(0), "How many new orders a second are published at most. 0 doesn't limit them."},
+	{p2pQueueBulkRateVar, uint(10), "How many sync and market data messages a second are published at most. 0 doesn't limit them."},
+	{p2pChaosLatencyVar, uint(0), "How many milliseconds every received message is delayed for testing. Don't use in production."},
+	{p2pChaosDropPercentVar, uint(0), "The percentage of received messages that are dropped for testing. Don't use in production."},
+	{p2pChaosCloseStreamPercentVar, uint(0), "The percentage of stream writes that close the stream instead for testing. Don't use in production."},
+	{errorsEnableStackTraceVar, false, "Whether errors are logged with stack traces"},
+	{logLevelVar, "INFO", "The lowest level of log lines that are printed: DEBUG, INFO, WARN or ERROR"},
+	{logFormatVar, "console", "The format of log lines, \"console\" or \"json\""},
+	{websocketEnableVar, false, "Whether websocket clients can follow the orders, on websocket.port"},
+	{websocketPortVar, uint(3000), "The port websocket clients connect to when websocket.enable is true"},
+	{websocketPingIntervalVar, uint(30), "How often, in seconds, websocket clients are pinged"},
+	{websocketPongTimeoutVar, uint(10), "How long, in seconds, a websocket client has to answer a ping before it's dropped"},
+	{websocketFlushIntervalVar, uint(0), "How long, in milliseconds, messages are collected into a single frame for each websocket client. 0 disables batching."},
+	{websocketListenersVar, "", "Comma separated listener URLs for websocket clients, like unix:///run/sprawl/ws.sock or tcp://0.0.0.0:3443?cert=ws.crt&key=ws.key&auth=apiKey. Empty listens on localhost at websocket.port."},
+	{marketAPIPortVar, uint(0), "The port the read-only market data API is served at over HTTP. 0 disables it."},
+	{marketAPIMaxAgeVar, uint(5), "How many seconds clients and CDNs may cache the responses of the market data API"},
+	{dashboardPortVar, uint(0), "The port the operator dashboard is served at over HTTP. 0 disables it."},
+	{routerPairsVar, "", "The comma separated asset pairs, like BTC/ETH, that are mirrored between equivalent channels. \"*\" mirrors all pairs."},
+	{marketDataIntervalsVar, "1m,5m,1h,24h", "The comma separated durations, like 1m or 1h, that candles of each channel's trades are aggregated over. Empty disables market data."},
+	{historyRetentionVar, uint(168), "How long, in hours, deleted orders are kept in the order history"},
+	{ordersLockTimeoutVar, uint(300), "How long, in seconds, an order stays locked before it's unlocked automatically. 0 keeps locks forever."},
+	{ordersUnlockIntervalVar, uint(10), "How often, in seconds, timed out locks are looked for"},
+	{ordersCacheSizeVar, uint(1024), "How many single orders are cached in memory for GetOrder. 0 disables the cache."},
+	{ordersMaxClockSkewVar, uint(30), "How many seconds clocks may differ between peers before orders are flagged as coming from the future. 0 disables the checks."},
+	{ordersMakerRateVar, uint(10), "How many orders per second a single maker may create on a channel before the rest are ignored. 0 doesn't limit them."},
+	{ordersMaxMakerVar, uint(1000), "How many open orders a single maker may have on a channel before its new ones are ignored. 0 doesn't limit them."},
+	{ordersWorkersVar, uint(64), "How many orders are created and received at once. Creating more is refused until one is done. 0 doesn't limit them."},
+	{ordersAntiEntropyVar, uint(300), "How often, in seconds, the digests of joined channels' order books are compared with peers. 0 doesn't compare them."},
+	{ordersIDMismatchVar, "accept", "What's done with received orders whose ID doesn't match their content: accept, reject or quarantine"},
+	{ordersIDCollisionVar, "reject", "What's done when a received order claims the ID of a different stored order: reject keeps the stored one, lastWriteWins keeps the one created last"},
+	{channelsMaxOrderAgeVar, uint(0), "How old, in hours, open orders may get before they're moved into the order history. 0 keeps them forever."},
+	{channelsMaxOrdersVar, uint(0), "How many open orders are kept on each channel, moving the oldest ones into the order history. 0 doesn't limit them."},
+	{channelsPruneIntervalVar, uint(60), "How often, in minutes, channels are pruned down to their retention policy"},
+	{channelsAllowCustomAssetsVar, false, "Whether channels can be joined with asset symbols that Sprawl doesn't recognize"},
+	{channelsIdleTimeoutVar, uint(0), "How many minutes a joined channel may go without orders or peers before the node leaves it. 0 never leaves channels."},
+	{webhooksURLsVar, "", "The comma separated URLs that order events are POSTed to"},
+	{webhooksEventsVar, "", "The comma separated order events sent to webhooks: created, deleted, locked and unlocked. Empty sends all of them."},
+	{webhooksSecretVar, "", "The key webhook payloads are signed with"},
+	{webhooksRetriesVar, uint(3), "How many times a failed webhook is retried, waiting twice as long each time"},
+	{settlementEngineVar, "noop", "The registered settlement engine that settles the fills of this node's orders"},
+	{ethRPCURLVar, "", "The JSON-RPC endpoint of the Ethereum node that the ethereum settlement engine sends transactions to"},
+	{ethContractVar, "", "The address of the escrow contract that trades are settled with"},
+	{ethAccountVar, "", "The address that escrow transactions are sent from. The Ethereum node has to be able to sign for it."},
+	{ethConfirmationsVar, uint(12), "How many blocks have to confirm an escrow transaction before the trade is settled"},
+	{ethPollIntervalVar, uint(15), "How many seconds there are between checks of pending escrow transactions"},
+	{ethTimeoutVar, uint(3600), "How many seconds an escrow has to be confirmed in before the trade is aborted and the escrow refunded"},
+	{replicationEnableVar, false, "Whether other nodes can follow this one with AdminHandler.Replicate, replicating its storage"},
+	{replicationPrimaryVar, "", "The gRPC address of the primary that this node follows as a standby. Empty runs the node as a primary."},
+	{replicationAPIKeyVar, "", "The admin API key this node authenticates to its primary with"},
+	{debugProfileDirVar, "", "The directory profiles captured over RPC are written to. Empty uses the system's temporary directory."},
+	{debugDeadLettersVar, uint(1000), "How many received messages that failed processing are kept for inspection, dropping the oldest ones first. 0 doesn't keep them."},
+	{debugCountersIntervalVar, uint(60), "How many seconds there are between storing the all-time totals of the node's counters. 0 only stores them when the node closes."},
+	{debugPprofPortVar, uint(0), "The port of the pprof HTTP listener. 0 disables it."},
+}
+
+// getDefaults returns the default value of every key
+func getDefaults() map[string]interface{} {
+	values := make(map[string]interface{}, len(settings))
+	for _, setting := range settings {
+		values[setting.key] = setting.defaultValue
+	}
+	return values
+}
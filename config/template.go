@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// envPrefix is what the environment variables of the keys start with
+const envPrefix string = "sprawl"
+
+// EnvVar returns the environment variable that sets a key, like SPRAWL_P2P_PORT for p2p.port
+func EnvVar(key string) string {
+	return strings.ToUpper(envPrefix + "_" + strings.Replace(key, ".", "_", -1))
+}
+
+// splitKey splits a key into the TOML table it's in and its name in the table
+func splitKey(key string) (string, string) {
+	dot := strings.LastIndex(key, ".")
+	return key[:dot], key[dot+1:]
+}
+
+// formatValue writes a default value as a TOML value
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return strconv.Quote(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case uint:
+		return strconv.FormatUint(uint64(value), 10)
+	}
+	return fmt.Sprint(value)
+}
+
+// WriteDefaultConfig writes a config.toml with every key set to its default value,
+// each commented with what it's for and the environment variable that overrides it
+func WriteDefaultConfig(w io.Writer) error {
+	var builder strings.Builder
+	builder.WriteString("# Sprawl node config. Every key is set to its default value.\n")
+	builder.WriteString("# Environment variables override the keys, and flags like --p2p.port override both.\n")
+	table := ""
+	for _, setting := range settings {
+		keyTable, name := splitKey(setting.key)
+		if keyTable != table {
+			table = keyTable
+			fmt.Fprintf(&builder, "\n[%s]\n", table)
+		}
+		fmt.Fprintf(&builder, "# %s\n# %s\n%s = %s\n", setting.description, EnvVar(setting.key), name, formatValue(setting.defaultValue))
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteDefaultConfig(t *testing.T) {
+	var file bytes.Buffer
+	assert.NoError(t, WriteDefaultConfig(&file))
+	assert.Contains(t, file.String(), "# SPRAWL_P2P_GOSSIP_D\nd = 6\n")
+
+	// The written file alone sets every key to its default
+	v := viper.New()
+	v.SetConfigType("toml")
+	assert.NoError(t, v.ReadConfig(&file))
+	seen := make(map[string]bool)
+	for _, setting := range settings {
+		assert.False(t, seen[setting.key], setting.key+" is listed twice")
+		seen[setting.key] = true
+		assert.NotEmpty(t, setting.description, setting.key)
+		assert.True(t, v.IsSet(setting.key), setting.key)
+		switch value := setting.defaultValue.(type) {
+		case string:
+			assert.Equal(t, value, cast.ToString(v.Get(setting.key)), setting.key)
+		case bool:
+			assert.Equal(t, value, cast.ToBool(v.Get(setting.key)), setting.key)
+		case uint:
+			assert.Equal(t, value, cast.ToUint(v.Get(setting.key)), setting.key)
+		default:
+			t.Errorf("%s has a default of an unsupported type", setting.key)
+		}
+	}
+}
+
+func TestEnvVar(t *testing.T) {
+	assert.Equal(t, "SPRAWL_P2P_PORT", EnvVar(p2pPortVar))
+	assert.Equal(t, "SPRAWL_MARKETAPI_MAXAGE", EnvVar(marketAPIMaxAgeVar))
+}
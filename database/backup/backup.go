@@ -0,0 +1,189 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/sprawl/sprawl/errors"
+)
+
+// magic identifies a Sprawl storage snapshot and its format version
+var magic = []byte("SPRAWLDB\x01")
+
+const entryMarker byte = 1
+const endMarker byte = 0
+
+// maxEntrySize is the longest key or value read from a snapshot, which may come from a peer
+const maxEntrySize uint64 = 16 << 20
+
+// readChunkSize is how much of a long key or value is allocated at a time, so a snapshot that
+// ends early doesn't get the whole length it claims allocated
+const readChunkSize uint64 = 64 << 10
+
+// Writer writes a checksummed snapshot of key-value pairs into an io.Writer
+type Writer struct {
+	output *bufio.Writer
+	hash   hash.Hash
+	writer io.Writer
+}
+
+// NewWriter writes the snapshot header and returns a Writer for the entries
+func NewWriter(w io.Writer) (*Writer, error) {
+	bw := &Writer{output: bufio.NewWriter(w), hash: sha256.New()}
+	bw.writer = io.MultiWriter(bw.output, bw.hash)
+	_, err := bw.writer.Write(magic)
+	if err != nil {
+		return nil, errors.E(errors.Op("Write snapshot header"), err)
+	}
+	return bw, nil
+}
+
+func (bw *Writer) writeBytes(data []byte) error {
+	lengthPrefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lengthPrefix, uint64(len(data)))
+	_, err := bw.writer.Write(lengthPrefix[:n])
+	if err != nil {
+		return err
+	}
+	_, err = bw.writer.Write(data)
+	return err
+}
+
+// Write adds a single key-value pair to the snapshot
+func (bw *Writer) Write(key []byte, value []byte) error {
+	_, err := bw.writer.Write([]byte{entryMarker})
+	if err != nil {
+		return errors.E(errors.Op("Write snapshot entry"), err)
+	}
+	err = bw.writeBytes(key)
+	if err != nil {
+		return errors.E(errors.Op("Write snapshot key"), err)
+	}
+	err = bw.writeBytes(value)
+	if err != nil {
+		return errors.E(errors.Op("Write snapshot value"), err)
+	}
+	return nil
+}
+
+// Close ends the snapshot with a SHA-256 checksum over everything written before it
+func (bw *Writer) Close() error {
+	_, err := bw.writer.Write([]byte{endMarker})
+	if err != nil {
+		return errors.E(errors.Op("Write snapshot end"), err)
+	}
+	_, err = bw.output.Write(bw.hash.Sum(nil))
+	if err != nil {
+		return errors.E(errors.Op("Write snapshot checksum"), err)
+	}
+	err = bw.output.Flush()
+	if err != nil {
+		return errors.E(errors.Op("Flush snapshot"), err)
+	}
+	return nil
+}
+
+type hashingReader struct {
+	reader *bufio.Reader
+	hash   hash.Hash
+	// remaining tells how much of the input is left, if the input knows it
+	remaining interface{ Len() int }
+}
+
+func (hr *hashingReader) ReadByte() (byte, error) {
+	b, err := hr.reader.ReadByte()
+	if err == nil {
+		hr.hash.Write([]byte{b})
+	}
+	return b, err
+}
+
+func (hr *hashingReader) Read(p []byte) (int, error) {
+	n, err := hr.reader.Read(p)
+	hr.hash.Write(p[:n])
+	return n, err
+}
+
+func (hr *hashingReader) readBytes() ([]byte, error) {
+	length, err := binary.ReadUvarint(hr)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxEntrySize {
+		return nil, errors.E(errors.Op("Check entry length"), fmt.Sprintf("entry of %d bytes is over the maximum of %d", length, maxEntrySize))
+	}
+	if hr.remaining != nil && length > uint64(hr.reader.Buffered()+hr.remaining.Len()) {
+		return nil, errors.E(errors.Op("Check entry length"), io.ErrUnexpectedEOF)
+	}
+	if length <= readChunkSize {
+		data := make([]byte, length)
+		_, err = io.ReadFull(hr, data)
+		return data, err
+	}
+	data := bytes.NewBuffer(make([]byte, 0, readChunkSize))
+	_, err = io.CopyN(data, hr, int64(length))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return data.Bytes(), err
+}
+
+// Read reads a snapshot written by Writer, calling fn for every entry.
+// The checksum can only be verified at the end of the snapshot, so callers should
+// only commit the entries after Read has returned without an error.
+func Read(r io.Reader, fn func(key []byte, value []byte) error) error {
+	hr := &hashingReader{reader: bufio.NewReader(r), hash: sha256.New()}
+	if remaining, ok := r.(interface{ Len() int }); ok {
+		hr.remaining = remaining
+	}
+
+	header := make([]byte, len(magic))
+	_, err := io.ReadFull(hr, header)
+	if err != nil {
+		return errors.E(errors.Op("Read snapshot header"), err)
+	}
+	if !bytes.Equal(header, magic) {
+		return errors.E(errors.Op("Read snapshot header"), "not a Sprawl snapshot or unsupported version")
+	}
+
+	for {
+		marker, err := hr.ReadByte()
+		if err != nil {
+			return errors.E(errors.Op("Read snapshot entry"), err)
+		}
+		if marker == endMarker {
+			break
+		}
+		if marker != entryMarker {
+			return errors.E(errors.Op("Read snapshot entry"), "invalid entry marker")
+		}
+		key, err := hr.readBytes()
+		if err != nil {
+			return errors.E(errors.Op("Read snapshot key"), err)
+		}
+		value, err := hr.readBytes()
+		if err != nil {
+			return errors.E(errors.Op("Read snapshot value"), err)
+		}
+		err = fn(key, value)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+
+	expected := hr.hash.Sum(nil)
+	checksum := make([]byte, sha256.Size)
+	_, err = io.ReadFull(hr.reader, checksum)
+	if err != nil {
+		return errors.E(errors.Op("Read snapshot checksum"), err)
+	}
+	if !bytes.Equal(expected, checksum) {
+		return errors.E(errors.Op("Verify snapshot checksum"), "checksum mismatch, snapshot is corrupted")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var testEntries = map[string]string{
+	"order-test1":   "test1",
+	"order-test2":   "test2",
+	"channel-test3": "test3",
+	"empty":         "",
+}
+
+func writeTestSnapshot(t *testing.T) []byte {
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf)
+	assert.NoError(t, err)
+	for key, value := range testEntries {
+		err = writer.Write([]byte(key), []byte(value))
+		assert.NoError(t, err)
+	}
+	err = writer.Close()
+	assert.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	snapshot := writeTestSnapshot(t)
+
+	entries := make(map[string]string)
+	err := Read(bytes.NewReader(snapshot), func(key []byte, value []byte) error {
+		entries[string(key)] = string(value)
+		return nil
+	})
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, testEntries, entries)
+}
+
+func TestCorruptedSnapshot(t *testing.T) {
+	snapshot := writeTestSnapshot(t)
+	snapshot[len(magic)+3] ^= 0xff
+
+	err := Read(bytes.NewReader(snapshot), func(key []byte, value []byte) error {
+		return nil
+	})
+	assert.False(t, errors.IsEmpty(err))
+}
+
+func TestTruncatedSnapshot(t *testing.T) {
+	snapshot := writeTestSnapshot(t)
+
+	err := Read(bytes.NewReader(snapshot[:len(snapshot)-1]), func(key []byte, value []byte) error {
+		return nil
+	})
+	assert.False(t, errors.IsEmpty(err))
+}
+
+func TestInvalidHeader(t *testing.T) {
+	err := Read(bytes.NewReader([]byte("not a snapshot")), func(key []byte, value []byte) error {
+		return nil
+	})
+	assert.False(t, errors.IsEmpty(err))
+}
+
+func TestEntryLength(t *testing.T) {
+	length := make([]byte, binary.MaxVarintLen64)
+	entry := func(keyLength uint64) []byte {
+		snapshot := append([]byte{}, magic...)
+		snapshot = append(snapshot, entryMarker)
+		return append(snapshot, length[:binary.PutUvarint(length, keyLength)]...)
+	}
+	discard := func(key []byte, value []byte) error {
+		return nil
+	}
+
+	// A key over the maximum entry size is rejected
+	err := Read(bytes.NewReader(entry(maxEntrySize+1)), discard)
+	assert.False(t, errors.IsEmpty(err))
+
+	// A key longer than the rest of the input is rejected, whether the reader knows its length or not
+	err = Read(bytes.NewReader(entry(maxEntrySize)), discard)
+	assert.False(t, errors.IsEmpty(err))
+	err = Read(io.MultiReader(bytes.NewReader(entry(maxEntrySize))), discard)
+	assert.False(t, errors.IsEmpty(err))
+}
@@ -0,0 +1,13 @@
+// Package diskspace tells how much space is left on the disk of a path
+package diskspace
+
+import "github.com/sprawl/sprawl/errors"
+
+// Free returns the bytes available to the node on the file system that path is on
+func Free(path string) (uint64, error) {
+	free, err := free(path)
+	if !errors.IsEmpty(err) {
+		return 0, errors.E(errors.Op("Get free disk space"), err)
+	}
+	return free, nil
+}
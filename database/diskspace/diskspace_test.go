@@ -0,0 +1,17 @@
+package diskspace
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFree(t *testing.T) {
+	free, err := Free(os.TempDir())
+	assert.NoError(t, err)
+	assert.NotZero(t, free)
+
+	_, err = Free("/this/path/does/not/exist")
+	assert.Error(t, err)
+}
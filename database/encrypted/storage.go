@@ -0,0 +1,316 @@
+package encrypted
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sprawl/sprawl/database/expiry"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"golang.org/x/crypto/scrypt"
+)
+
+const saltKey string = string(interfaces.EncryptionPrefix) + "salt"
+const checkKey string = string(interfaces.EncryptionPrefix) + "check"
+const checkValue string = "sprawl"
+const saltLength int = 16
+const keyLength int = 32
+
+// scrypt parameters recommended for interactive logins, as the key is only derived once on startup
+const scryptN int = 1 << 15
+const scryptR int = 8
+const scryptP int = 1
+
+// Storage encrypts the values of another Storage with AES-GCM, using a key derived from a passphrase.
+// Keys are stored as they are so that prefix queries keep working.
+type Storage struct {
+	Storage    interfaces.Storage
+	Passphrase string
+	salt       []byte
+	aead       cipher.AEAD
+}
+
+// SetDbPath sets the path of the wrapped storage
+func (storage *Storage) SetDbPath(dbPath string) {
+	storage.Storage.SetDbPath(dbPath)
+}
+
+// SetBatchSize sets the batch size of the wrapped storage
+func (storage *Storage) SetBatchSize(batchSize uint) {
+	storage.Storage.SetBatchSize(batchSize)
+}
+
+// Run starts the wrapped storage and derives the encryption key, failing if the passphrase doesn't match the stored data
+func (storage *Storage) Run() error {
+	if storage.Passphrase == "" {
+		return errors.E(errors.Op("Check encryption passphrase"), "no passphrase given for the encrypted database")
+	}
+	err := storage.Storage.Run()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return storage.loadKey(context.Background())
+}
+
+// Close closes the wrapped storage
+func (storage *Storage) Close() {
+	storage.Storage.Close()
+}
+
+// loadKey derives the key from the passphrase and the stored salt, creating both the salt and a check value on first run
+func (storage *Storage) loadKey(ctx context.Context) error {
+	hasSalt, err := storage.Storage.Has(ctx, []byte(saltKey))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check encryption salt"), err)
+	}
+	if hasSalt {
+		storage.salt, err = storage.Storage.Get(ctx, []byte(saltKey))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Get encryption salt"), err)
+		}
+	} else {
+		storage.salt = make([]byte, saltLength)
+		_, err = rand.Read(storage.salt)
+		if err != nil {
+			return errors.E(errors.Op("Generate encryption salt"), err)
+		}
+	}
+
+	key, err := scrypt.Key([]byte(storage.Passphrase), storage.salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return errors.E(errors.Op("Derive encryption key"), err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return errors.E(errors.Op("Create cipher"), err)
+	}
+	storage.aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return errors.E(errors.Op("Create GCM"), err)
+	}
+
+	if !hasSalt {
+		return storage.storeKeyInfo(ctx)
+	}
+	check, err := storage.Get(ctx, []byte(checkKey))
+	if !errors.IsEmpty(err) || !bytes.Equal(check, []byte(checkValue)) {
+		return errors.E(errors.Op("Check encryption passphrase"), "the passphrase doesn't decrypt the database")
+	}
+	return nil
+}
+
+// storeKeyInfo writes the salt and an encrypted check value, used to tell a wrong passphrase on the next start
+func (storage *Storage) storeKeyInfo(ctx context.Context) error {
+	err := storage.Storage.Put(ctx, []byte(saltKey), storage.salt)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put encryption salt"), err)
+	}
+	err = storage.Put(ctx, []byte(checkKey), []byte(checkValue))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put encryption check"), err)
+	}
+	return nil
+}
+
+// encrypt seals the value with a random nonce, which is prepended to the result.
+// The key is authenticated too, so a value can't be moved under another key.
+func (storage *Storage) encrypt(key []byte, data []byte) ([]byte, error) {
+	nonce := make([]byte, storage.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return storage.aead.Seal(nonce, nonce, data, key), nil
+}
+
+func (storage *Storage) decrypt(key []byte, data []byte) ([]byte, error) {
+	nonceSize := storage.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.E(errors.Op("Decrypt value"), errors.Malformed, "encrypted value is too short")
+	}
+	plaintext, err := storage.aead.Open(nil, data[:nonceSize], data[nonceSize:], key)
+	if err != nil {
+		return nil, errors.E(errors.Op("Decrypt value"), errors.Malformed, err)
+	}
+	return plaintext, nil
+}
+
+// decryptAll decrypts a map of entries from the wrapped storage, leaving out the encryption's own entries.
+// The entries the wrapped storage keeps to expire keys aren't encrypted, so they're passed as they are.
+func (storage *Storage) decryptAll(entries map[string]string) (map[string]string, error) {
+	decrypted := make(map[string]string)
+	for key, value := range entries {
+		if strings.HasPrefix(key, string(interfaces.EncryptionPrefix)) {
+			continue
+		}
+		if expiry.IsExpiryKey(key) {
+			decrypted[key] = value
+			continue
+		}
+		plaintext, err := storage.decrypt([]byte(key), []byte(value))
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		decrypted[key] = string(plaintext)
+	}
+	return decrypted, nil
+}
+
+// Has checks if the key exists in the wrapped storage
+func (storage *Storage) Has(ctx context.Context, key []byte) (bool, error) {
+	return storage.Storage.Has(ctx, key)
+}
+
+// Get fetches and decrypts a value
+func (storage *Storage) Get(ctx context.Context, key []byte) ([]byte, error) {
+	data, err := storage.Storage.Get(ctx, key)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return storage.decrypt(key, data)
+}
+
+// Put encrypts a value and stores it
+func (storage *Storage) Put(ctx context.Context, key []byte, data []byte) error {
+	ciphertext, err := storage.encrypt(key, data)
+	if err != nil {
+		return errors.E(errors.Op("Encrypt value"), err)
+	}
+	return storage.Storage.Put(ctx, key, ciphertext)
+}
+
+// PutBatch encrypts the values of all entries and puts them at once, if the wrapped storage can, and one by one otherwise
+func (storage *Storage) PutBatch(ctx context.Context, entries []interfaces.Entry) error {
+	encrypted := make([]interfaces.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Delete {
+			encrypted = append(encrypted, entry)
+			continue
+		}
+		ciphertext, err := storage.encrypt(entry.Key, entry.Value)
+		if err != nil {
+			return errors.E(errors.Op("Encrypt value"), err)
+		}
+		encrypted = append(encrypted, interfaces.Entry{Key: entry.Key, Value: ciphertext})
+	}
+	if batcher, ok := storage.Storage.(interfaces.Batcher); ok {
+		return batcher.PutBatch(ctx, encrypted)
+	}
+	for _, entry := range encrypted {
+		var err error
+		if entry.Delete {
+			err = storage.Storage.Delete(ctx, entry.Key)
+		} else {
+			err = storage.Storage.Put(ctx, entry.Key, entry.Value)
+		}
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutWithTTL encrypts a value and stores it until ttl has passed
+func (storage *Storage) PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error {
+	ciphertext, err := storage.encrypt(key, data)
+	if err != nil {
+		return errors.E(errors.Op("Encrypt value"), err)
+	}
+	return storage.Storage.PutWithTTL(ctx, key, ciphertext, ttl)
+}
+
+// Delete removes a value from the wrapped storage
+func (storage *Storage) Delete(ctx context.Context, key []byte) error {
+	return storage.Storage.Delete(ctx, key)
+}
+
+// GetAll fetches and decrypts all values
+func (storage *Storage) GetAll(ctx context.Context) (map[string]string, error) {
+	entries, err := storage.Storage.GetAll(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return storage.decryptAll(entries)
+}
+
+// GetAllWithPrefix fetches and decrypts all values whose keys start with prefix
+func (storage *Storage) GetAllWithPrefix(ctx context.Context, prefix string) (map[string]string, error) {
+	entries, err := storage.Storage.GetAllWithPrefix(ctx, prefix)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return storage.decryptAll(entries)
+}
+
+// GetRange fetches and decrypts all values whose keys are at least start and less than end
+func (storage *Storage) GetRange(ctx context.Context, start []byte, end []byte) (map[string]string, error) {
+	entries, err := storage.Storage.GetRange(ctx, start, end)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return storage.decryptAll(entries)
+}
+
+// DeleteAll deletes all values, keeping the salt so that the current key stays usable
+func (storage *Storage) DeleteAll(ctx context.Context) error {
+	err := storage.Storage.DeleteAll(ctx)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return storage.storeKeyInfo(context.Background())
+}
+
+// DeleteAllWithPrefix deletes all values whose keys start with prefix, keeping the salt
+func (storage *Storage) DeleteAllWithPrefix(ctx context.Context, prefix string) error {
+	err := storage.Storage.DeleteAllWithPrefix(ctx, prefix)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if strings.HasPrefix(saltKey, prefix) {
+		return storage.storeKeyInfo(context.Background())
+	}
+	return nil
+}
+
+// Count counts the entries whose keys start with prefix
+func (storage *Storage) Count(ctx context.Context, prefix string) (int, error) {
+	return storage.Storage.Count(ctx, prefix)
+}
+
+// Backup writes a snapshot of the wrapped storage, so the values stay encrypted and the salt is included
+func (storage *Storage) Backup(ctx context.Context, w io.Writer) error {
+	return storage.Storage.Backup(ctx, w)
+}
+
+// Restore replaces the wrapped storage with a snapshot and derives the key again from the snapshot's salt
+func (storage *Storage) Restore(ctx context.Context, r io.Reader) error {
+	err := storage.Storage.Restore(ctx, r)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return storage.loadKey(context.Background())
+}
+
+// Compact compacts the wrapped storage, if it can be compacted
+func (storage *Storage) Compact(ctx context.Context) error {
+	compactor, ok := storage.Storage.(interfaces.Compactor)
+	if !ok {
+		return errors.E(errors.Op("Compact"), errors.Invalid, "wrapped storage can't be compacted")
+	}
+	return compactor.Compact(ctx)
+}
+
+// Size returns how many bytes the wrapped storage takes on disk, if it can be compacted
+func (storage *Storage) Size() (uint64, error) {
+	compactor, ok := storage.Storage.(interfaces.Compactor)
+	if !ok {
+		return 0, errors.E(errors.Op("Get database size"), errors.Invalid, "wrapped storage can't be compacted")
+	}
+	return compactor.Size()
+}
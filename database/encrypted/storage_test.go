@@ -0,0 +1,85 @@
+package encrypted
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+const testPassphrase string = "correct horse battery staple"
+const testKey string = "order-test"
+const testValue string = "testing"
+
+func TestEncryptedStorage(t *testing.T) {
+	plain := &inmemory.Storage{Db: make(map[string]string)}
+	storage := &Storage{Storage: plain, Passphrase: testPassphrase}
+	assert.NoError(t, storage.Run())
+
+	assert.NoError(t, storage.Put(ctx, []byte(testKey), []byte(testValue)))
+	value, err := storage.Get(ctx, []byte(testKey))
+	assert.NoError(t, err)
+	assert.Equal(t, testValue, string(value))
+
+	// Nothing readable is stored underneath
+	raw, err := plain.Get(ctx, []byte(testKey))
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(raw, []byte(testValue)))
+
+	all, err := storage.GetAllWithPrefix(ctx, "order-")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{testKey: testValue}, all)
+	all, err = storage.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{testKey: testValue}, all)
+	all, err = storage.GetRange(ctx, []byte("order-"), []byte("order-~"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{testKey: testValue}, all)
+
+	// A value moved under another key doesn't decrypt
+	assert.NoError(t, plain.Put(ctx, []byte("order-moved"), raw))
+	_, err = storage.Get(ctx, []byte("order-moved"))
+	assert.Error(t, err)
+
+	// The key is derived again from the stored salt
+	reopened := &Storage{Storage: plain, Passphrase: testPassphrase}
+	assert.NoError(t, reopened.Run())
+	value, err = reopened.Get(ctx, []byte(testKey))
+	assert.NoError(t, err)
+	assert.Equal(t, testValue, string(value))
+
+	wrong := &Storage{Storage: plain, Passphrase: "wrong"}
+	assert.Error(t, wrong.Run())
+	assert.Error(t, (&Storage{Storage: plain}).Run())
+
+	// Emptying the storage keeps the current key usable after a restart
+	assert.NoError(t, storage.DeleteAll(ctx))
+	assert.NoError(t, storage.Put(ctx, []byte(testKey), []byte(testValue)))
+	reopened = &Storage{Storage: plain, Passphrase: testPassphrase}
+	assert.NoError(t, reopened.Run())
+	value, err = reopened.Get(ctx, []byte(testKey))
+	assert.NoError(t, err)
+	assert.Equal(t, testValue, string(value))
+}
+
+func TestEncryptedBackup(t *testing.T) {
+	storage := &Storage{Storage: &inmemory.Storage{Db: make(map[string]string)}, Passphrase: testPassphrase}
+	assert.NoError(t, storage.Run())
+	assert.NoError(t, storage.Put(ctx, []byte(testKey), []byte(testValue)))
+
+	var snapshot bytes.Buffer
+	assert.NoError(t, storage.Backup(ctx, &snapshot))
+	assert.False(t, bytes.Contains(snapshot.Bytes(), []byte(testValue)))
+
+	// Another node with the same passphrase takes over the snapshot's salt
+	restored := &Storage{Storage: &inmemory.Storage{Db: make(map[string]string)}, Passphrase: testPassphrase}
+	assert.NoError(t, restored.Run())
+	assert.NoError(t, restored.Restore(ctx, &snapshot))
+	value, err := restored.Get(ctx, []byte(testKey))
+	assert.NoError(t, err)
+	assert.Equal(t, testValue, string(value))
+}
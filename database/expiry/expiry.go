@@ -0,0 +1,102 @@
+package expiry
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// DefaultInterval is how often storages sweep the entries whose TTL has passed
+const DefaultInterval time.Duration = time.Minute
+
+// deadlineLength is the length of an encoded deadline
+const deadlineLength int = 8
+
+// EncodeDeadline encodes a deadline so that earlier deadlines sort first
+func EncodeDeadline(deadline time.Time) []byte {
+	encoded := make([]byte, deadlineLength)
+	binary.BigEndian.PutUint64(encoded, uint64(deadline.UnixNano()))
+	return encoded
+}
+
+// DecodeDeadline decodes a deadline encoded with EncodeDeadline
+func DecodeDeadline(encoded []byte) (time.Time, error) {
+	if len(encoded) != deadlineLength {
+		return time.Time{}, errors.E(errors.Op("Decode deadline"), errors.Malformed, "deadline should be 8 bytes")
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(encoded))), nil
+}
+
+// DeadlineKey is where the deadline of a key with a TTL is stored
+func DeadlineKey(key []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.TTLPrefix), string(key)}, ""))
+}
+
+// IndexKey orders the keys with a TTL by their deadline, so the sweeper only reads the ones that are due
+func IndexKey(key []byte, deadline time.Time) []byte {
+	return []byte(strings.Join([]string{string(interfaces.ExpiryPrefix), string(EncodeDeadline(deadline)), string(key)}, ""))
+}
+
+// IsExpiryKey tells if the key is kept by the storage to expire other keys, rather than put by its user
+func IsExpiryKey(key string) bool {
+	return strings.HasPrefix(key, string(interfaces.TTLPrefix)) || strings.HasPrefix(key, string(interfaces.ExpiryPrefix))
+}
+
+// Sweep deletes the entries whose TTL had passed by now and returns how many it deleted.
+// Index entries of keys that have been put again or deleted since are dropped without deleting the key.
+// The storage's Delete has to delete the deadline of the key too.
+func Sweep(ctx context.Context, storage interfaces.Storage, now time.Time) (int, error) {
+	due, err := storage.GetRange(ctx, []byte(interfaces.ExpiryPrefix), IndexKey(nil, now.Add(time.Nanosecond)))
+	if !errors.IsEmpty(err) {
+		return 0, errors.E(errors.Op("Get expired entries"), err)
+	}
+
+	deleted := 0
+	for indexKey := range due {
+		deadline := []byte(indexKey[len(interfaces.ExpiryPrefix) : len(interfaces.ExpiryPrefix)+deadlineLength])
+		key := []byte(indexKey[len(interfaces.ExpiryPrefix)+deadlineLength:])
+		current, err := storage.Get(ctx, DeadlineKey(key))
+		if errors.IsEmpty(err) && bytes.Equal(current, deadline) {
+			err = storage.Delete(ctx, key)
+			if !errors.IsEmpty(err) {
+				return deleted, errors.E(errors.Op("Delete expired entry"), err)
+			}
+			deleted++
+		}
+		err = storage.Delete(ctx, []byte(indexKey))
+		if !errors.IsEmpty(err) {
+			return deleted, errors.E(errors.Op("Delete expiry index entry"), err)
+		}
+	}
+	return deleted, nil
+}
+
+// Start sweeps the storage every interval in the background. Calling the returned function stops it
+// and waits for a sweep in progress to finish.
+func Start(storage interfaces.Storage, interval time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				// The entries that couldn't be deleted are due on the next sweep too
+				Sweep(context.Background(), storage, now)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
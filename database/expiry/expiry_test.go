@@ -0,0 +1,32 @@
+package expiry
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadline(t *testing.T) {
+	now := time.Now()
+	decoded, err := DecodeDeadline(EncodeDeadline(now))
+	assert.NoError(t, err)
+	assert.True(t, now.Equal(decoded))
+
+	_, err = DecodeDeadline([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestIndexKey(t *testing.T) {
+	now := time.Now()
+	key := []byte("order-1")
+
+	// Earlier deadlines sort first whatever the key, so a sweep reads only the due part of the index
+	assert.Equal(t, -1, bytes.Compare(IndexKey([]byte("order-2"), now), IndexKey(key, now.Add(time.Second))))
+	assert.Equal(t, -1, bytes.Compare(IndexKey(key, now), IndexKey(nil, now.Add(time.Nanosecond))))
+
+	assert.True(t, IsExpiryKey(string(IndexKey(key, now))))
+	assert.True(t, IsExpiryKey(string(DeadlineKey(key))))
+	assert.False(t, IsExpiryKey(string(key)))
+}
@@ -1,14 +1,22 @@
 package inmemory
 
 import (
+	"context"
+	"io"
 	"strings"
+	"time"
 
+	"github.com/sprawl/sprawl/database/backup"
+	"github.com/sprawl/sprawl/database/expiry"
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
 )
 
 // Storage is a struct containing a database and its address
 type Storage struct {
 	Db map[string]string
+	// lastSweep is when the entries whose TTL had passed were last deleted
+	lastSweep time.Time
 }
 
 var err error
@@ -18,6 +26,10 @@ var data []byte
 func (storage *Storage) SetDbPath(dbPath string) {
 }
 
+// SetBatchSize does nothing, as deletes from memory don't need batching
+func (storage *Storage) SetBatchSize(batchSize uint) {
+}
+
 // Run starts the database connection for Storage
 func (storage *Storage) Run() error {
 	return nil
@@ -28,13 +40,13 @@ func (storage *Storage) Close() {
 }
 
 // Has uses LevelDB's method Has to check does the data exists in LevelDB
-func (storage *Storage) Has(key []byte) (bool, error) {
+func (storage *Storage) Has(ctx context.Context, key []byte) (bool, error) {
 	_, ok := storage.Db[string(key)]
 	return ok, nil
 }
 
 // Get uses LevelDB's method Get to fetch data from LevelDB
-func (storage *Storage) Get(key []byte) ([]byte, error) {
+func (storage *Storage) Get(ctx context.Context, key []byte) ([]byte, error) {
 	value, ok := storage.Db[string(key)]
 	var err error
 	if !ok {
@@ -44,24 +56,60 @@ func (storage *Storage) Get(key []byte) ([]byte, error) {
 }
 
 // Put uses LevelDB's Put method to put data into LevelDB
-func (storage *Storage) Put(key []byte, data []byte) error {
+func (storage *Storage) Put(ctx context.Context, key []byte, data []byte) error {
+	storage.Db[string(key)] = string(data)
+	delete(storage.Db, string(expiry.DeadlineKey(key)))
+	return nil
+}
+
+// PutBatch puts or deletes all entries in memory
+func (storage *Storage) PutBatch(ctx context.Context, entries []interfaces.Entry) error {
+	for _, entry := range entries {
+		if entry.Delete {
+			storage.Delete(ctx, entry.Key)
+		} else {
+			storage.Put(ctx, entry.Key, entry.Value)
+		}
+	}
+	return nil
+}
+
+// PutWithTTL puts data into memory with its deadline and an expiry index entry.
+// The map isn't safe for a sweeper running in the background, so the expired entries are swept
+// here instead, at most once every expiry.DefaultInterval.
+func (storage *Storage) PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return storage.Put(ctx, key, data)
+	}
+	now := time.Now()
+	if now.Sub(storage.lastSweep) >= expiry.DefaultInterval {
+		storage.lastSweep = now
+		_, err := expiry.Sweep(ctx, storage, now)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	deadline := now.Add(ttl)
 	storage.Db[string(key)] = string(data)
+	storage.Db[string(expiry.DeadlineKey(key))] = string(expiry.EncodeDeadline(deadline))
+	storage.Db[string(expiry.IndexKey(key, deadline))] = ""
 	return nil
 }
 
 // Delete uses LevelDB's Delete method to remove data from LevelDB
-func (storage *Storage) Delete(key []byte) error {
+func (storage *Storage) Delete(ctx context.Context, key []byte) error {
 	delete(storage.Db, string(key))
+	delete(storage.Db, string(expiry.DeadlineKey(key)))
 	return nil
 }
 
 // GetAll returns all entries in the database regardless of key or prefix
-func (storage *Storage) GetAll() (map[string]string, error) {
+func (storage *Storage) GetAll(ctx context.Context) (map[string]string, error) {
 	return storage.Db, nil
 }
 
 // GetAllWithPrefix returns all entries in the database with the specified prefix
-func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, error) {
+func (storage *Storage) GetAllWithPrefix(ctx context.Context, prefix string) (map[string]string, error) {
 	entries := make(map[string]string)
 	for k, v := range storage.Db {
 		if strings.HasPrefix(k, prefix) {
@@ -71,19 +119,74 @@ func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, erro
 	return entries, nil
 }
 
+// GetRange returns all entries in the database whose keys are at least start and less than end
+func (storage *Storage) GetRange(ctx context.Context, start []byte, end []byte) (map[string]string, error) {
+	entries := make(map[string]string)
+	for k, v := range storage.Db {
+		if k >= string(start) && k < string(end) {
+			entries[k] = v
+		}
+	}
+	return entries, nil
+}
+
 // DeleteAll deletes all entries from the database
 // USE CAREFULLY
-func (storage *Storage) DeleteAll() error {
+func (storage *Storage) DeleteAll(ctx context.Context) error {
 	storage.Db = make(map[string]string)
 	return nil
 }
 
-// DeleteAllWithPrefix deletes all entries starting with a prefix
-func (storage *Storage) DeleteAllWithPrefix(prefix string) error {
+// DeleteAllWithPrefix deletes all entries starting with a prefix, and their TTLs like Delete does
+func (storage *Storage) DeleteAllWithPrefix(ctx context.Context, prefix string) error {
 	for k := range storage.Db {
 		if strings.HasPrefix(k, prefix) {
 			delete(storage.Db, k)
+			if !expiry.IsExpiryKey(k) {
+				delete(storage.Db, string(expiry.DeadlineKey([]byte(k))))
+			}
+		}
+	}
+	return nil
+}
+
+// Count returns the number of entries starting with a prefix
+func (storage *Storage) Count(ctx context.Context, prefix string) (int, error) {
+	count := 0
+	for k := range storage.Db {
+		if strings.HasPrefix(k, prefix) {
+			count++
 		}
 	}
+	return count, nil
+}
+
+// Backup writes a snapshot of the whole database into w
+func (storage *Storage) Backup(ctx context.Context, w io.Writer) error {
+	writer, err := backup.NewWriter(w)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	for k, v := range storage.Db {
+		err = writer.Write([]byte(k), []byte(v))
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// Restore replaces the contents of the database with a snapshot written by Backup.
+// The database is left untouched if the snapshot fails verification.
+func (storage *Storage) Restore(ctx context.Context, r io.Reader) error {
+	entries := make(map[string]string)
+	err := backup.Read(r, func(key []byte, value []byte) error {
+		entries[string(key)] = string(value)
+		return nil
+	})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Read backup"), err)
+	}
+	storage.Db = entries
 	return nil
 }
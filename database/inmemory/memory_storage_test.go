@@ -1,13 +1,19 @@
 package inmemory
 
 import (
+	"bytes"
+	"context"
 	"testing"
+	"time"
 
+	"github.com/sprawl/sprawl/database/expiry"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/stretchr/testify/assert"
 )
 
+var ctx = context.Background()
+
 const dbPathVar = "database.path"
 const testID = "0"
 const testMessage = "testing"
@@ -30,7 +36,7 @@ func initTestMessages() {
 }
 
 func deleteAllFromDatabase() {
-	storage.DeleteAll()
+	storage.DeleteAll(ctx)
 }
 
 func TestStorageCRUD(t *testing.T) {
@@ -38,18 +44,18 @@ func TestStorageCRUD(t *testing.T) {
 	defer storage.Close()
 	deleteAllFromDatabase()
 
-	storage.Put([]byte(testID), []byte(testMessage))
+	storage.Put(ctx, []byte(testID), []byte(testMessage))
 
-	testBytes, err := storage.Get([]byte(testID))
-	testBool, err := storage.Has([]byte(testID))
+	testBytes, err := storage.Get(ctx, []byte(testID))
+	testBool, err := storage.Has(ctx, []byte(testID))
 	assert.True(t, testBool)
 	assert.Equal(t, testMessage, string(testBytes))
 	assert.True(t, errors.IsEmpty(err))
 	assert.NotEmpty(t, testBytes)
 
-	storage.Delete([]byte(testID))
-	deleted, err := storage.Get([]byte(testID))
-	testBool, err = storage.Has([]byte(testID))
+	storage.Delete(ctx, []byte(testID))
+	deleted, err := storage.Get(ctx, []byte(testID))
+	testBool, err = storage.Has(ctx, []byte(testID))
 	assert.False(t, testBool)
 	assert.Empty(t, deleted)
 }
@@ -60,11 +66,11 @@ func TestStorageGetAll(t *testing.T) {
 	deleteAllFromDatabase()
 
 	for key, value := range testMessages {
-		storage.Put([]byte(key), []byte(value))
+		storage.Put(ctx, []byte(key), []byte(value))
 	}
 
 	var allItems map[string]string
-	allItems, err = storage.GetAll()
+	allItems, err = storage.GetAll(ctx)
 
 	assert.True(t, errors.IsEmpty(err))
 	assert.Equal(t, len(testMessages), len(allItems))
@@ -77,24 +83,39 @@ func TestStorageGetAllWithPrefix(t *testing.T) {
 
 	for key, value := range testMessages {
 		key = orderPrefix + key
-		storage.Put([]byte(key), []byte(value))
+		storage.Put(ctx, []byte(key), []byte(value))
 	}
 
 	for key, value := range testMessages {
 		key = channelPrefix + key
-		storage.Put([]byte(key), []byte(value))
+		storage.Put(ctx, []byte(key), []byte(value))
 	}
 
 	var prefixedItems map[string]string
-	prefixedItems, err = storage.GetAllWithPrefix(orderPrefix)
+	prefixedItems, err = storage.GetAllWithPrefix(ctx, orderPrefix)
 	var allItems map[string]string
-	allItems, err = storage.GetAll()
+	allItems, err = storage.GetAll(ctx)
 
 	assert.True(t, errors.IsEmpty(err))
 	assert.Equal(t, len(testMessages), len(prefixedItems))
 	assert.Equal(t, len(testMessages)*2, len(allItems))
 }
 
+func TestStorageGetRange(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put(ctx, []byte(orderPrefix+key), []byte(value))
+	}
+
+	var rangeItems map[string]string
+	rangeItems, err = storage.GetRange(ctx, []byte(orderPrefix+"test2"), []byte(orderPrefix+"test4"))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, map[string]string{orderPrefix + "test2": "test2", orderPrefix + "test3": "test3"}, rangeItems)
+}
+
 func TestStorageDeleteAllWithPrefix(t *testing.T) {
 	storage.Run()
 	defer storage.Close()
@@ -102,25 +123,87 @@ func TestStorageDeleteAllWithPrefix(t *testing.T) {
 
 	for key, value := range testMessages {
 		key = orderPrefix + key
-		storage.Put([]byte(key), []byte(value))
+		storage.Put(ctx, []byte(key), []byte(value))
 	}
 
 	for key, value := range testMessages {
 		key = channelPrefix + key
-		storage.Put([]byte(key), []byte(value))
+		storage.Put(ctx, []byte(key), []byte(value))
 	}
 
-	storage.DeleteAllWithPrefix(orderPrefix)
+	storage.DeleteAllWithPrefix(ctx, orderPrefix)
 
 	var prefixedItems map[string]string
-	prefixedItems, err := storage.GetAllWithPrefix(orderPrefix)
+	prefixedItems, err := storage.GetAllWithPrefix(ctx, orderPrefix)
 	assert.True(t, errors.IsEmpty(err))
-	allItems, err := storage.GetAll()
+	allItems, err := storage.GetAll(ctx)
 	assert.True(t, errors.IsEmpty(err))
 	assert.Zero(t, len(prefixedItems))
 	assert.Equal(t, len(testMessages), len(allItems))
 }
 
+func TestStorageCount(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put(ctx, []byte(orderPrefix+key), []byte(value))
+	}
+	storage.Put(ctx, []byte(channelPrefix+testID), []byte(testMessage))
+
+	count, err := storage.Count(ctx, orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages), count)
+	count, err = storage.Count(ctx, "")
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages)+1, count)
+
+	// Delete in batches smaller than the number of entries
+	storage.SetBatchSize(uint(len(testMessages) - 1))
+	defer storage.SetBatchSize(0)
+	err = storage.DeleteAllWithPrefix(ctx, orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	count, err = storage.Count(ctx, orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Zero(t, count)
+	count, err = storage.Count(ctx, channelPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, 1, count)
+}
+
+func TestStorageBackupRestore(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put(ctx, []byte(key), []byte(value))
+	}
+
+	var snapshot bytes.Buffer
+	err := storage.Backup(ctx, &snapshot)
+	assert.True(t, errors.IsEmpty(err))
+
+	deleteAllFromDatabase()
+	storage.Put(ctx, []byte(testID), []byte(testMessage))
+
+	err = storage.Restore(ctx, bytes.NewReader(snapshot.Bytes()))
+	assert.True(t, errors.IsEmpty(err))
+	allItems, err := storage.GetAll(ctx)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, testMessages, allItems)
+
+	// A corrupted snapshot must leave the database untouched
+	corrupted := snapshot.Bytes()
+	corrupted[len(corrupted)-1] ^= 0xff
+	storage.Put(ctx, []byte(testID), []byte(testMessage))
+	err = storage.Restore(ctx, bytes.NewReader(corrupted))
+	assert.False(t, errors.IsEmpty(err))
+	testBool, err := storage.Has(ctx, []byte(testID))
+	assert.True(t, testBool)
+}
+
 func BenchmarkAdd(b *testing.B) {
 	storage.Run()
 	defer storage.Close()
@@ -128,7 +211,7 @@ func BenchmarkAdd(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 1; i < b.N; i++ {
-		storage.Put([]byte(string(i)), []byte(testMessage+string(i)))
+		storage.Put(ctx, []byte(string(i)), []byte(testMessage+string(i)))
 	}
 }
 
@@ -138,6 +221,40 @@ func BenchmarkRead(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 1; i < b.N; i++ {
-		storage.Get([]byte(string(i)))
+		storage.Get(ctx, []byte(string(i)))
 	}
 }
+
+func TestStorageTTL(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte(orderPrefix+"expiring"), []byte(testMessage), time.Millisecond))
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte(orderPrefix+"kept"), []byte(testMessage), time.Hour))
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte(orderPrefix+"putAgain"), []byte(testMessage), time.Millisecond))
+	assert.NoError(t, storage.Put(ctx, []byte(orderPrefix+"putAgain"), []byte(testMessage)))
+
+	// Only the entry whose TTL has passed is deleted, putting a key again without a TTL keeps it
+	deleted, err := expiry.Sweep(ctx, storage, time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	has, err := storage.Has(ctx, []byte(orderPrefix+"expiring"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+	count, err := storage.Count(ctx, orderPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, err = storage.Count(ctx, string(interfaces.ExpiryPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = storage.Count(ctx, string(interfaces.TTLPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Deleting with a prefix deletes the TTLs of the deleted keys too
+	assert.NoError(t, storage.DeleteAllWithPrefix(ctx, orderPrefix))
+	count, err = storage.Count(ctx, string(interfaces.TTLPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,117 @@
+package leveldb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// FsyncNever leaves syncing written data to disk to the OS, so a power loss can lose the latest writes
+const FsyncNever string = "never"
+
+// FsyncAlways syncs every write to disk before it returns, or every group of writes with group commit
+const FsyncAlways string = "always"
+
+// maxGroupSize bounds how many writes are committed as one batch, so a steady stream of writes can't hold back the first of them
+const maxGroupSize int = 1000
+
+// CheckFsyncPolicy tells if policy is one of the fsync policies
+func CheckFsyncPolicy(policy string) error {
+	if policy != FsyncNever && policy != FsyncAlways {
+		return errors.E(errors.Op("Check fsync policy"), fmt.Sprintf("fsync policy %q isn't %q or %q", policy, FsyncNever, FsyncAlways))
+	}
+	return nil
+}
+
+// pendingWrite is a batch waiting for its group to be written, and where the result of the write is sent
+type pendingWrite struct {
+	batch *leveldb.Batch
+	done  chan error
+}
+
+// pipeline writes the batches handed to it in groups. The first batch waits for window to pass, and the batches
+// that arrive meanwhile are written together with it, so concurrent writers share a single write and fsync.
+type pipeline struct {
+	db      *leveldb.DB
+	window  time.Duration
+	options *opt.WriteOptions
+	writes  chan *pendingWrite
+	stopped chan struct{}
+	closed  bool
+	lock    sync.RWMutex
+}
+
+func newPipeline(db *leveldb.DB, window time.Duration, options *opt.WriteOptions) *pipeline {
+	p := &pipeline{
+		db:      db,
+		window:  window,
+		options: options,
+		writes:  make(chan *pendingWrite, maxGroupSize),
+		stopped: make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// write hands the batch to the pipeline and waits until its group has been written
+func (p *pipeline) write(batch *leveldb.Batch) error {
+	p.lock.RLock()
+	if p.closed {
+		p.lock.RUnlock()
+		return errors.E(errors.Op("Write to pipeline"), "the write pipeline is closed")
+	}
+	write := &pendingWrite{batch: batch, done: make(chan error, 1)}
+	p.writes <- write
+	p.lock.RUnlock()
+	return <-write.done
+}
+
+// run writes groups of batches until the pipeline is closed and every batch handed to it has been written
+func (p *pipeline) run() {
+	defer close(p.stopped)
+	for first := range p.writes {
+		group := []*pendingWrite{first}
+		timer := time.NewTimer(p.window)
+	gather:
+		for len(group) < maxGroupSize {
+			select {
+			case write, ok := <-p.writes:
+				if !ok {
+					break gather
+				}
+				group = append(group, write)
+			case <-timer.C:
+				break gather
+			}
+		}
+		timer.Stop()
+		p.commit(group)
+	}
+}
+
+// commit writes the batches of a group as one batch and tells each writer how it went
+func (p *pipeline) commit(group []*pendingWrite) {
+	batch := new(leveldb.Batch)
+	for _, write := range group {
+		write.batch.Replay(batch)
+	}
+	err := p.db.Write(batch, p.options)
+	for _, write := range group {
+		write.done <- err
+	}
+}
+
+// close stops taking writes, and returns once the ones already handed to the pipeline are written
+func (p *pipeline) close() {
+	p.lock.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.writes)
+	}
+	p.lock.Unlock()
+	<-p.stopped
+}
@@ -1,15 +1,35 @@
 package leveldb
 
 import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/sprawl/sprawl/database/backup"
+	"github.com/sprawl/sprawl/database/expiry"
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	util "github.com/syndtr/goleveldb/leveldb/util"
 )
 
+const defaultBatchSize uint = 1000
+
 // Storage is a struct containing a database and its address
 type Storage struct {
-	dbPath string
-	db     *leveldb.DB
+	dbPath    string
+	batchSize uint
+	db        *leveldb.DB
+	// groupCommitWindow is how long writes are gathered for to be written as one batch, and 0 writes each one right away
+	groupCommitWindow time.Duration
+	writeOptions      *opt.WriteOptions
+	pipeline          *pipeline
+	// stopSweeper stops deleting the entries whose TTL has passed
+	stopSweeper func()
 }
 
 var err error
@@ -20,62 +40,153 @@ func (storage *Storage) SetDbPath(dbPath string) {
 	storage.dbPath = dbPath
 }
 
-// Run starts the database connection for Storage
+// SetBatchSize sets how many records are written to LevelDB at once when deleting with a prefix or restoring a backup
+func (storage *Storage) SetBatchSize(batchSize uint) {
+	storage.batchSize = batchSize
+}
+
+// getBatchSize returns how many records are written to LevelDB at once when deleting or restoring many entries
+func (storage *Storage) getBatchSize() uint {
+	if storage.batchSize == 0 {
+		return defaultBatchSize
+	}
+	return storage.batchSize
+}
+
+// SetWritePipeline makes writes from concurrent calls wait for window and be written together as one batch,
+// and sets when writes are synced to disk. It has to be called before Run.
+func (storage *Storage) SetWritePipeline(window time.Duration, fsync string) error {
+	err := CheckFsyncPolicy(fsync)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	storage.groupCommitWindow = window
+	storage.writeOptions = &opt.WriteOptions{Sync: fsync == FsyncAlways}
+	return nil
+}
+
+// Run starts the database connection for Storage, and the sweeper that deletes the entries whose TTL has passed
 func (storage *Storage) Run() error {
 	storage.db, err = leveldb.OpenFile(storage.dbPath, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	if storage.groupCommitWindow > 0 {
+		storage.pipeline = newPipeline(storage.db, storage.groupCommitWindow, storage.writeOptions)
+	}
+	storage.stopSweeper = expiry.Start(storage, expiry.DefaultInterval)
+	return nil
 }
 
-// Close closes the underlying LevelDB connection
+// Close stops the sweeper, writes what's left in the write pipeline and closes the underlying LevelDB connection
 func (storage *Storage) Close() {
+	if storage.stopSweeper != nil {
+		storage.stopSweeper()
+		storage.stopSweeper = nil
+	}
+	if storage.pipeline != nil {
+		storage.pipeline.close()
+		storage.pipeline = nil
+	}
 	storage.db.Close()
 }
 
+// write writes the batch through the write pipeline, or straight to LevelDB without one
+func (storage *Storage) write(batch *leveldb.Batch) error {
+	if storage.pipeline != nil {
+		return storage.pipeline.write(batch)
+	}
+	return storage.db.Write(batch, storage.writeOptions)
+}
+
 // Has uses LevelDB's method Has to check does the data exists in LevelDB
-func (storage *Storage) Has(key []byte) (bool, error) {
+func (storage *Storage) Has(ctx context.Context, key []byte) (bool, error) {
+	if ctx.Err() != nil {
+		return false, errors.E(errors.Op("Has"), ctx.Err())
+	}
 	return storage.db.Has(key, nil)
 }
 
 // Get uses LevelDB's method Get to fetch data from LevelDB
-func (storage *Storage) Get(key []byte) ([]byte, error) {
+func (storage *Storage) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if ctx.Err() != nil {
+		return nil, errors.E(errors.Op("Get"), ctx.Err())
+	}
 	return storage.db.Get(key, nil)
 }
 
-// Put uses LevelDB's Put method to put data into LevelDB
-func (storage *Storage) Put(key []byte, data []byte) error {
-	return storage.db.Put(key, data, nil)
+// Put puts data into LevelDB, dropping any TTL the key had
+func (storage *Storage) Put(ctx context.Context, key []byte, data []byte) error {
+	if ctx.Err() != nil {
+		return errors.E(errors.Op("Put"), ctx.Err())
+	}
+	batch := new(leveldb.Batch)
+	batch.Put(key, data)
+	batch.Delete(expiry.DeadlineKey(key))
+	return storage.write(batch)
 }
 
-// Delete uses LevelDB's Delete method to remove data from LevelDB
-func (storage *Storage) Delete(key []byte) error {
-	return storage.db.Delete(key, nil)
+// PutBatch puts or deletes all entries in LevelDB in one write
+func (storage *Storage) PutBatch(ctx context.Context, entries []interfaces.Entry) error {
+	if ctx.Err() != nil {
+		return errors.E(errors.Op("Put batch"), ctx.Err())
+	}
+	batch := new(leveldb.Batch)
+	for _, entry := range entries {
+		if entry.Delete {
+			batch.Delete(entry.Key)
+		} else {
+			batch.Put(entry.Key, entry.Value)
+		}
+		batch.Delete(expiry.DeadlineKey(entry.Key))
+	}
+	return storage.write(batch)
 }
 
-// GetAll returns all entries in the database regardless of key or prefix
-func (storage *Storage) GetAll() (map[string]string, error) {
-	entries := make(map[string]string)
-	iter := storage.db.NewIterator(nil, nil)
-
-	// Iterate over every key in the database, append to entries
-	for iter.Next() {
-		key := iter.Key()
-		value := iter.Value()
-		entries[string(key)] = string(value)
+// PutWithTTL puts data into LevelDB together with its deadline and an expiry index entry,
+// so that the sweeper deletes it once ttl has passed
+func (storage *Storage) PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return storage.Put(ctx, key, data)
+	}
+	if ctx.Err() != nil {
+		return errors.E(errors.Op("Put with TTL"), ctx.Err())
 	}
+	deadline := time.Now().Add(ttl)
+	batch := new(leveldb.Batch)
+	batch.Put(key, data)
+	batch.Put(expiry.DeadlineKey(key), expiry.EncodeDeadline(deadline))
+	batch.Put(expiry.IndexKey(key, deadline), nil)
+	return storage.write(batch)
+}
 
-	iter.Release()
-	err = errors.E(errors.Op("Get all using iterator"), iter.Error())
+// Delete removes data and its TTL from LevelDB
+func (storage *Storage) Delete(ctx context.Context, key []byte) error {
+	if ctx.Err() != nil {
+		return errors.E(errors.Op("Delete"), ctx.Err())
+	}
+	batch := new(leveldb.Batch)
+	batch.Delete(key)
+	batch.Delete(expiry.DeadlineKey(key))
+	return storage.write(batch)
+}
 
-	return entries, err
+// GetAll returns all entries in the database regardless of key or prefix
+func (storage *Storage) GetAll(ctx context.Context) (map[string]string, error) {
+	return storage.GetAllWithPrefix(ctx, "")
 }
 
 // GetAllWithPrefix returns all entries in the database with the specified prefix
-func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, error) {
+func (storage *Storage) GetAllWithPrefix(ctx context.Context, prefix string) (map[string]string, error) {
 	entries := make(map[string]string)
 	iter := storage.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
 
 	// Iterate over every key in the database, append to entries
 	for iter.Next() {
+		if ctx.Err() != nil {
+			iter.Release()
+			return nil, errors.E(errors.Op("Get all with prefix using iterator"), ctx.Err())
+		}
 		key := iter.Key()
 		value := iter.Value()
 		entries[string(key)] = string(value)
@@ -87,35 +198,214 @@ func (storage *Storage) GetAllWithPrefix(prefix string) (map[string]string, erro
 	return entries, err
 }
 
+// GetRange returns all entries in the database whose keys are at least start and less than end
+func (storage *Storage) GetRange(ctx context.Context, start []byte, end []byte) (map[string]string, error) {
+	entries := make(map[string]string)
+	iter := storage.db.NewIterator(&util.Range{Start: start, Limit: end}, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if ctx.Err() != nil {
+			return nil, errors.E(errors.Op("Get range using iterator"), ctx.Err())
+		}
+		entries[string(iter.Key())] = string(iter.Value())
+	}
+	if iter.Error() != nil {
+		return nil, errors.E(errors.Op("Get range using iterator"), iter.Error())
+	}
+	return entries, nil
+}
+
 // DeleteAll deletes all entries from the database
 // USE CAREFULLY
-func (storage *Storage) DeleteAll() error {
-	iter := storage.db.NewIterator(nil, nil)
+func (storage *Storage) DeleteAll(ctx context.Context) error {
+	return storage.DeleteAllWithPrefix(ctx, "")
+}
 
-	// Iterate over every key in the database, append to entries
+// DeleteAllWithPrefix deletes all entries starting with a prefix, and their TTLs like Delete does.
+// Deletes are written in batches, yielding between them so other writers don't stall.
+func (storage *Storage) DeleteAllWithPrefix(ctx context.Context, prefix string) error {
+	batchSize := storage.getBatchSize()
+
+	iter := storage.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	defer iter.Release()
+
+	batch := new(leveldb.Batch)
 	for iter.Next() {
-		key := iter.Key()
-		err = errors.E(errors.Op("Delete from storage"), storage.Delete(key))
+		batch.Delete(iter.Key())
+		if !expiry.IsExpiryKey(string(iter.Key())) {
+			batch.Delete(expiry.DeadlineKey(iter.Key()))
+		}
+		if uint(batch.Len()) < batchSize {
+			continue
+		}
+		err := storage.write(batch)
+		if err != nil {
+			return errors.E(errors.Op("Write delete batch"), err)
+		}
+		batch.Reset()
+		// Batches already written stay deleted when the caller gives up
+		if ctx.Err() != nil {
+			return errors.E(errors.Op("Delete all with prefix using iterator"), ctx.Err())
+		}
+		runtime.Gosched()
+	}
+	if iter.Error() != nil {
+		return errors.E(errors.Op("Delete all with prefix using iterator"), iter.Error())
 	}
 
+	err := storage.write(batch)
+	if err != nil {
+		return errors.E(errors.Op("Write delete batch"), err)
+	}
+	return nil
+}
+
+// Count returns the number of entries starting with a prefix
+func (storage *Storage) Count(ctx context.Context, prefix string) (int, error) {
+	count := 0
+	iter := storage.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	for iter.Next() {
+		if ctx.Err() != nil {
+			iter.Release()
+			return 0, errors.E(errors.Op("Count using iterator"), ctx.Err())
+		}
+		count++
+	}
 	iter.Release()
-	err = errors.E(errors.Op("Delete all from storage"), iter.Error())
+	if iter.Error() != nil {
+		return 0, errors.E(errors.Op("Count using iterator"), iter.Error())
+	}
+	return count, nil
+}
 
-	return err
+// Backup writes a consistent snapshot of the whole database into w
+func (storage *Storage) Backup(ctx context.Context, w io.Writer) error {
+	snapshot, err := storage.db.GetSnapshot()
+	if err != nil {
+		return errors.E(errors.Op("Get database snapshot"), err)
+	}
+	defer snapshot.Release()
+
+	writer, err := backup.NewWriter(w)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	iter := snapshot.NewIterator(nil, nil)
+	for iter.Next() {
+		if ctx.Err() != nil {
+			iter.Release()
+			return errors.E(errors.Op("Backup using iterator"), ctx.Err())
+		}
+		err = writer.Write(iter.Key(), iter.Value())
+		if !errors.IsEmpty(err) {
+			iter.Release()
+			return err
+		}
+	}
+	iter.Release()
+	if iter.Error() != nil {
+		return errors.E(errors.Op("Backup using iterator"), iter.Error())
+	}
+
+	return writer.Close()
 }
 
-// DeleteAllWithPrefix deletes all entries starting with a prefix
-func (storage *Storage) DeleteAllWithPrefix(prefix string) error {
-	iter := storage.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+// restoreDirSuffix names the directory next to the database a snapshot is staged in while it's restored
+const restoreDirSuffix string = ".restore"
 
-	// Iterate over every key in the database, append to entries
+// Restore replaces the contents of the database with a snapshot written by Backup.
+// The database is left untouched if the snapshot fails verification.
+func (storage *Storage) Restore(ctx context.Context, r io.Reader) error {
+	// The snapshot is staged in a database of its own, written every batch size entries, since its checksum
+	// can only be verified at the end and the whole snapshot shouldn't have to fit in memory
+	stagingPath := filepath.Clean(storage.dbPath) + restoreDirSuffix
+	err := os.RemoveAll(stagingPath)
+	if err != nil {
+		return errors.E(errors.Op("Remove old restore staging"), err)
+	}
+	staging, err := leveldb.OpenFile(stagingPath, nil)
+	if err != nil {
+		return errors.E(errors.Op("Open restore staging"), err)
+	}
+	defer os.RemoveAll(stagingPath)
+	defer staging.Close()
+
+	batchSize := storage.getBatchSize()
+	batch := new(leveldb.Batch)
+	err = backup.Read(r, func(key []byte, value []byte) error {
+		batch.Put(key, value)
+		if uint(batch.Len()) < batchSize {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err := staging.Write(batch, nil)
+		batch.Reset()
+		return err
+	})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Read backup"), err)
+	}
+	err = staging.Write(batch, nil)
+	if err != nil {
+		return errors.E(errors.Op("Write restore staging"), err)
+	}
+
+	// Nothing is written if the caller gave up while the backup was read
+	if ctx.Err() != nil {
+		return errors.E(errors.Op("Read backup"), ctx.Err())
+	}
+	err = storage.DeleteAll(ctx)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Delete storage to restore over"), err)
+	}
+
+	batch.Reset()
+	iter := staging.NewIterator(nil, nil)
+	defer iter.Release()
 	for iter.Next() {
-		key := iter.Key()
-		err = errors.E(errors.Op("Delete with prefix from storage"), storage.Delete(key))
+		batch.Put(iter.Key(), iter.Value())
+		if uint(batch.Len()) < batchSize {
+			continue
+		}
+		err = storage.write(batch)
+		if err != nil {
+			return errors.E(errors.Op("Write backup to storage"), err)
+		}
+		batch.Reset()
+		runtime.Gosched()
+	}
+	if iter.Error() != nil {
+		return errors.E(errors.Op("Read restore staging"), iter.Error())
 	}
+	return errors.E(errors.Op("Write backup to storage"), storage.write(batch))
+}
 
-	iter.Release()
-	err = errors.E(errors.Op("Delete all with prefix from storage"), iter.Error())
+// Compact compacts the whole key range, dropping deleted and overwritten entries from LevelDB's tables
+func (storage *Storage) Compact(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return errors.E(errors.Op("Compact"), ctx.Err())
+	}
+	return errors.E(errors.Op("Compact"), storage.db.CompactRange(util.Range{}))
+}
 
-	return err
+// Size returns how many bytes the files in the database directory take
+func (storage *Storage) Size() (uint64, error) {
+	var size uint64
+	err := filepath.Walk(storage.dbPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.E(errors.Op("Get database size"), err)
+	}
+	return size, nil
 }
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// indexOrderKeys returns the keys of an order's search index entries as of schema version 2
+func indexOrderKeys(channelID []byte, order *pb.Order) [][]byte {
+	priceBits := math.Float32bits(order.GetPrice())
+	if priceBits&(1<<31) != 0 {
+		priceBits = ^priceBits
+	} else {
+		priceBits |= 1 << 31
+	}
+	price := make([]byte, 4)
+	binary.BigEndian.PutUint32(price, priceBits)
+
+	created, err := ptypes.Timestamp(order.GetCreated())
+	if !errors.IsEmpty(err) {
+		created = time.Unix(0, 0)
+	}
+	createdAt := make([]byte, 8)
+	binary.BigEndian.PutUint64(createdAt, uint64(created.UnixNano()))
+
+	key := func(index string, value []byte) []byte {
+		return []byte(strings.Join([]string{string(interfaces.IndexPrefix), index, string(channelID), string(value), string(order.GetId())}, ""))
+	}
+	return [][]byte{
+		key("price-", price),
+		key("created-", createdAt),
+		key("state-", []byte{byte(order.GetState())}),
+		key("maker-", order.GetMakerPeerID()),
+	}
+}
+
+// indexOrders adds the open orders stored before search to the search indexes of their channels
+func indexOrders(ctx context.Context, storage interfaces.Storage) error {
+	orders, err := storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get orders"), err)
+	}
+	for key, value := range orders {
+		order := &pb.Order{}
+		if err := proto.Unmarshal([]byte(value), order); !errors.IsEmpty(err) || len(order.GetId()) == 0 {
+			continue
+		}
+		if !bytes.HasSuffix([]byte(key), order.GetId()) {
+			continue
+		}
+		channelID := []byte(key[len(interfaces.OrderPrefix) : len(key)-len(order.GetId())])
+		for _, indexKey := range indexOrderKeys(channelID, order) {
+			err = storage.Put(ctx, indexKey, order.GetId())
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Put index entry"), err)
+			}
+		}
+	}
+	return nil
+}
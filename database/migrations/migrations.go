@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// versionKey is the storage key of the schema version the stored data is in
+var versionKey = []byte(string(interfaces.SchemaPrefix) + "version")
+
+// Migration upgrades the stored data from the previous schema version to Version
+type Migration struct {
+	Version     uint64
+	Description string
+	Up          func(ctx context.Context, storage interfaces.Storage) error
+}
+
+// migrations are run in order of their versions, starting from the one after the stored version
+var migrations = []Migration{
+	{Version: 1, Description: "Re-key orders to channel scoped keys", Up: scopeOrderKeys},
+	{Version: 2, Description: "Index open orders for search", Up: indexOrders},
+}
+
+// Latest returns the schema version this build stores data in
+func Latest() uint64 {
+	return migrations[len(migrations)-1].Version
+}
+
+// GetVersion returns the schema version of the stored data. Storage from before versioning is at version 0.
+func GetVersion(ctx context.Context, storage interfaces.Storage) (uint64, error) {
+	found, err := storage.Has(ctx, versionKey)
+	if !errors.IsEmpty(err) {
+		return 0, errors.E(errors.Op("Get schema version"), err)
+	}
+	if !found {
+		return 0, nil
+	}
+	data, err := storage.Get(ctx, versionKey)
+	if !errors.IsEmpty(err) {
+		return 0, errors.E(errors.Op("Get schema version"), err)
+	}
+	if len(data) != 8 {
+		return 0, errors.E(errors.Op("Get schema version"), errors.Malformed, "schema version isn't 8 bytes")
+	}
+	return binary.BigEndian.Uint64(data), nil
+}
+
+func putVersion(ctx context.Context, storage interfaces.Storage, version uint64) error {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, version)
+	return storage.Put(ctx, versionKey, data)
+}
+
+// Run migrates the storage to the latest schema version, storing the version after each migration
+// so an interrupted run continues from where it stopped. It refuses storage from a newer build.
+// In a dry run the migrations only log the writes they would make.
+func Run(ctx context.Context, storage interfaces.Storage, logger interfaces.Logger, dryRun bool) error {
+	return run(ctx, storage, logger, dryRun, migrations)
+}
+
+func run(ctx context.Context, storage interfaces.Storage, logger interfaces.Logger, dryRun bool, migrations []Migration) error {
+	version, err := GetVersion(ctx, storage)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	latest := migrations[len(migrations)-1].Version
+	if version > latest {
+		return errors.E(errors.Op("Check schema version"), errors.Invalid, fmt.Sprintf("storage is at schema version %d, but this build only knows versions up to %d", version, latest))
+	}
+
+	target := storage
+	if dryRun {
+		target = &dryRunStorage{Storage: storage, logger: logger}
+	}
+	for _, migration := range migrations {
+		if migration.Version <= version {
+			continue
+		}
+		logger.Infof("Migrating storage to schema version %d: %s", migration.Version, migration.Description)
+		err = migration.Up(ctx, target)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op(fmt.Sprintf("Migrate to schema version %d", migration.Version)), err)
+		}
+		err = putVersion(ctx, target, migration.Version)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put schema version"), err)
+		}
+	}
+	return nil
+}
+
+// dryRunStorage reads from the storage, but only logs the writes made to it.
+// Migrations in a dry run don't see their own writes or those of earlier migrations.
+type dryRunStorage struct {
+	interfaces.Storage
+	logger interfaces.Logger
+}
+
+func (storage *dryRunStorage) Put(ctx context.Context, key []byte, data []byte) error {
+	storage.logger.Infof("Dry run: would put %d bytes at %q", len(data), key)
+	return nil
+}
+
+func (storage *dryRunStorage) PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error {
+	storage.logger.Infof("Dry run: would put %d bytes at %q for %s", len(data), key, ttl)
+	return nil
+}
+
+func (storage *dryRunStorage) Delete(ctx context.Context, key []byte) error {
+	storage.logger.Infof("Dry run: would delete %q", key)
+	return nil
+}
+
+func (storage *dryRunStorage) DeleteAll(ctx context.Context) error {
+	storage.logger.Info("Dry run: would delete everything")
+	return nil
+}
+
+func (storage *dryRunStorage) DeleteAllWithPrefix(ctx context.Context, prefix string) error {
+	storage.logger.Infof("Dry run: would delete everything with prefix %q", prefix)
+	return nil
+}
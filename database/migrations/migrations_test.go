@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStorage() *inmemory.Storage {
+	return &inmemory.Storage{Db: make(map[string]string)}
+}
+
+func TestRunInOrder(t *testing.T) {
+	storage := newTestStorage()
+	ran := []uint64{}
+	testMigrations := []Migration{
+		{Version: 1, Up: func(ctx context.Context, storage interfaces.Storage) error { ran = append(ran, 1); return nil }},
+		{Version: 2, Up: func(ctx context.Context, storage interfaces.Storage) error { ran = append(ran, 2); return nil }},
+	}
+
+	err := run(context.Background(), storage, new(util.PlaceholderLogger), false, testMigrations)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, ran)
+	version, err := GetVersion(context.Background(), storage)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), version)
+
+	// Migrations that have already run are skipped
+	err = run(context.Background(), storage, new(util.PlaceholderLogger), false, testMigrations)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, ran)
+
+	// Storage from a newer build is refused
+	err = run(context.Background(), storage, new(util.PlaceholderLogger), false, testMigrations[:1])
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestDryRun(t *testing.T) {
+	storage := newTestStorage()
+	testMigrations := []Migration{
+		{Version: 1, Up: func(ctx context.Context, storage interfaces.Storage) error {
+			return storage.Put(ctx, []byte("key"), []byte("value"))
+		}},
+	}
+
+	err := run(context.Background(), storage, new(util.PlaceholderLogger), true, testMigrations)
+	assert.NoError(t, err)
+	assert.Empty(t, storage.Db)
+	version, err := GetVersion(context.Background(), storage)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), version)
+}
+
+func TestScopeOrderKeys(t *testing.T) {
+	storage := newTestStorage()
+	ctx := context.Background()
+
+	channel := &pb.Channel{Id: []byte("channel"), Options: &pb.ChannelOptions{AssetPair: "ETHBTC"}}
+	channelInBytes, err := proto.Marshal(channel)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Put(ctx, []byte(string(interfaces.ChannelPrefix)+"channel"), channelInBytes))
+
+	orderID := sha256.Sum256([]byte("order"))
+	order := &pb.Order{Id: orderID[:], Asset: "BTC", CounterAsset: "ETH"}
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	unscopedKey := []byte(string(interfaces.OrderPrefix) + string(orderID[:]))
+	assert.NoError(t, storage.Put(ctx, unscopedKey, orderInBytes))
+
+	orphanID := sha256.Sum256([]byte("orphan"))
+	orphan := &pb.Order{Id: orphanID[:], Asset: "XRP", CounterAsset: "ETH"}
+	orphanInBytes, err := proto.Marshal(orphan)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Put(ctx, []byte(string(interfaces.OrderPrefix)+string(orphanID[:])), orphanInBytes))
+
+	assert.NoError(t, scopeOrderKeys(ctx, storage))
+
+	orders, err := storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix))
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	scoped, err := storage.Get(ctx, []byte(string(interfaces.OrderPrefix)+"channel"+string(orderID[:])))
+	assert.NoError(t, err)
+	assert.Equal(t, orderInBytes, scoped)
+}
+
+func TestIndexOrders(t *testing.T) {
+	storage := newTestStorage()
+	ctx := context.Background()
+
+	orderID := sha256.Sum256([]byte("order"))
+	order := &pb.Order{Id: orderID[:], Asset: "BTC", CounterAsset: "ETH", Price: 2, MakerPeerID: []byte("maker")}
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Put(ctx, []byte(string(interfaces.OrderPrefix)+"channel"+string(orderID[:])), orderInBytes))
+
+	assert.NoError(t, indexOrders(ctx, storage))
+
+	entries, err := storage.GetAllWithPrefix(ctx, string(interfaces.IndexPrefix)+"maker-channelmaker")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{string(interfaces.IndexPrefix) + "maker-channelmaker" + string(orderID[:]): string(orderID[:])}, entries)
+	entries, err = storage.GetAllWithPrefix(ctx, string(interfaces.IndexPrefix))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// scopeOrderKeys moves orders stored under their ID alone to the keys of the joined channels of their asset pair.
+// Orders of pairs that aren't joined anymore are dropped, since nothing can look them up.
+func scopeOrderKeys(ctx context.Context, storage interfaces.Storage) error {
+	channels, err := storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get channels"), err)
+	}
+	channelIDs := make(map[string][][]byte)
+	for _, value := range channels {
+		channel := &pb.Channel{}
+		if err := proto.Unmarshal([]byte(value), channel); !errors.IsEmpty(err) {
+			continue
+		}
+		pair := channel.GetOptions().GetAssetPair()
+		channelIDs[pair] = append(channelIDs[pair], channel.GetId())
+	}
+
+	orders, err := storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get orders"), err)
+	}
+	for key, value := range orders {
+		// Order IDs are SHA-256 HMACs, so unscoped keys are exactly the prefix and the ID
+		orderID := []byte(key[len(interfaces.OrderPrefix):])
+		if len(orderID) != sha256.Size {
+			continue
+		}
+		order := &pb.Order{}
+		if err := proto.Unmarshal([]byte(value), order); !errors.IsEmpty(err) || !bytes.Equal(order.GetId(), orderID) {
+			continue
+		}
+
+		var ids [][]byte
+		ids = append(ids, channelIDs[order.GetAsset()+order.GetCounterAsset()]...)
+		ids = append(ids, channelIDs[order.GetCounterAsset()+order.GetAsset()]...)
+		for _, channelID := range ids {
+			err = storage.Put(ctx, []byte(string(interfaces.OrderPrefix)+string(channelID)+string(orderID)), []byte(value))
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Put channel scoped order"), err)
+			}
+		}
+		err = storage.Delete(ctx, []byte(key))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete unscoped order"), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package replicated
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/database/expiry"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// followerBuffer is how many changes can wait for a follower before it's dropped for falling behind
+const followerBuffer int = 4096
+
+// Storage publishes every change made to another Storage to the followers replicating it.
+// Writes are serialized so that followers apply the changes in the order they were made.
+type Storage struct {
+	Storage   interfaces.Storage
+	lock      sync.Mutex
+	sequence  uint64
+	followers map[chan *pb.ReplicationEntry]bool
+}
+
+// replicates tells if changes to the key are sent to followers. The salt of an encrypted storage and the relayed messages seen
+// are the follower's own, and so are the entries expiring keys, since followers are told when a key expires and sweep it themselves.
+func replicates(key string) bool {
+	return !strings.HasPrefix(key, string(interfaces.EncryptionPrefix)) && !strings.HasPrefix(key, string(interfaces.RelayPrefix)) && !expiry.IsExpiryKey(key)
+}
+
+// copyBytes copies a key or value, since callers may reuse theirs while followers are still sending it
+func copyBytes(data []byte) []byte {
+	return append([]byte(nil), data...)
+}
+
+// publish numbers a change and sends it to the followers, dropping the ones that have fallen behind.
+// The lock must be held.
+func (storage *Storage) publish(entry *pb.ReplicationEntry) {
+	storage.sequence++
+	entry.Sequence = storage.sequence
+	for follower := range storage.followers {
+		select {
+		case follower <- entry:
+		default:
+			delete(storage.followers, follower)
+			close(follower)
+		}
+	}
+}
+
+// dropFollowers closes the streams of all followers, so that they start over from a new snapshot. The lock must be held.
+func (storage *Storage) dropFollowers() {
+	for follower := range storage.followers {
+		delete(storage.followers, follower)
+		close(follower)
+	}
+}
+
+// Follow returns a snapshot of the storage and the sequence number of its latest change, together with a channel
+// that receives every change made after it. The channel is closed if the follower falls behind or the storage is restored.
+// Calling stop unregisters the follower.
+func (storage *Storage) Follow(ctx context.Context) ([]*pb.ReplicationEntry, uint64, <-chan *pb.ReplicationEntry, func(), error) {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	all, err := storage.Storage.GetAll(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, 0, nil, nil, errors.E(errors.Op("Snapshot storage"), err)
+	}
+	snapshot := make([]*pb.ReplicationEntry, 0, len(all))
+	for key, value := range all {
+		if !replicates(key) {
+			continue
+		}
+		entry := &pb.ReplicationEntry{Sequence: storage.sequence, Key: []byte(key), Value: []byte(value)}
+		if deadline, ok := all[string(expiry.DeadlineKey([]byte(key)))]; ok {
+			expires, err := expiry.DecodeDeadline([]byte(deadline))
+			if !errors.IsEmpty(err) {
+				return nil, 0, nil, nil, errors.E(errors.Op("Snapshot storage"), err)
+			}
+			entry.Expires, _ = ptypes.TimestampProto(expires)
+		}
+		snapshot = append(snapshot, entry)
+	}
+
+	follower := make(chan *pb.ReplicationEntry, followerBuffer)
+	if storage.followers == nil {
+		storage.followers = make(map[chan *pb.ReplicationEntry]bool)
+	}
+	storage.followers[follower] = true
+	stop := func() {
+		storage.lock.Lock()
+		defer storage.lock.Unlock()
+		if storage.followers[follower] {
+			delete(storage.followers, follower)
+			close(follower)
+		}
+	}
+	return snapshot, storage.sequence, follower, stop, nil
+}
+
+// Followers returns how many followers are replicating the storage
+func (storage *Storage) Followers() int {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	return len(storage.followers)
+}
+
+// Sequence returns the sequence number of the latest change
+func (storage *Storage) Sequence() uint64 {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	return storage.sequence
+}
+
+// SetDbPath sets the path of the wrapped storage
+func (storage *Storage) SetDbPath(dbPath string) {
+	storage.Storage.SetDbPath(dbPath)
+}
+
+// SetBatchSize sets the batch size of the wrapped storage
+func (storage *Storage) SetBatchSize(batchSize uint) {
+	storage.Storage.SetBatchSize(batchSize)
+}
+
+// Run starts the wrapped storage
+func (storage *Storage) Run() error {
+	return storage.Storage.Run()
+}
+
+// Close drops the followers and closes the wrapped storage
+func (storage *Storage) Close() {
+	storage.lock.Lock()
+	storage.dropFollowers()
+	storage.lock.Unlock()
+	storage.Storage.Close()
+}
+
+// Has checks if the key exists in the wrapped storage
+func (storage *Storage) Has(ctx context.Context, key []byte) (bool, error) {
+	return storage.Storage.Has(ctx, key)
+}
+
+// Get fetches a value from the wrapped storage
+func (storage *Storage) Get(ctx context.Context, key []byte) ([]byte, error) {
+	return storage.Storage.Get(ctx, key)
+}
+
+// Put stores a value and sends it to the followers
+func (storage *Storage) Put(ctx context.Context, key []byte, data []byte) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	err := storage.Storage.Put(ctx, key, data)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if replicates(string(key)) {
+		storage.publish(&pb.ReplicationEntry{Key: copyBytes(key), Value: copyBytes(data)})
+	}
+	return nil
+}
+
+// PutBatch stores or deletes all entries at once, if the wrapped storage can, and sends them to the followers
+func (storage *Storage) PutBatch(ctx context.Context, entries []interfaces.Entry) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	if batcher, ok := storage.Storage.(interfaces.Batcher); ok {
+		err := batcher.PutBatch(ctx, entries)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	} else {
+		for _, entry := range entries {
+			var err error
+			if entry.Delete {
+				err = storage.Storage.Delete(ctx, entry.Key)
+			} else {
+				err = storage.Storage.Put(ctx, entry.Key, entry.Value)
+			}
+			if !errors.IsEmpty(err) {
+				return err
+			}
+		}
+	}
+	for _, entry := range entries {
+		if !replicates(string(entry.Key)) {
+			continue
+		}
+		if entry.Delete {
+			storage.publish(&pb.ReplicationEntry{Key: copyBytes(entry.Key), Delete: true})
+		} else {
+			storage.publish(&pb.ReplicationEntry{Key: copyBytes(entry.Key), Value: copyBytes(entry.Value)})
+		}
+	}
+	return nil
+}
+
+// PutWithTTL stores a value until ttl has passed and sends it to the followers together with when it expires
+func (storage *Storage) PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	err := storage.Storage.PutWithTTL(ctx, key, data, ttl)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if replicates(string(key)) {
+		entry := &pb.ReplicationEntry{Key: copyBytes(key), Value: copyBytes(data)}
+		if ttl > 0 {
+			entry.Expires, _ = ptypes.TimestampProto(time.Now().Add(ttl))
+		}
+		storage.publish(entry)
+	}
+	return nil
+}
+
+// Delete removes a value and tells the followers to remove it too
+func (storage *Storage) Delete(ctx context.Context, key []byte) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	err := storage.Storage.Delete(ctx, key)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if replicates(string(key)) {
+		storage.publish(&pb.ReplicationEntry{Key: copyBytes(key), Delete: true})
+	}
+	return nil
+}
+
+// GetAll fetches all values from the wrapped storage
+func (storage *Storage) GetAll(ctx context.Context) (map[string]string, error) {
+	return storage.Storage.GetAll(ctx)
+}
+
+// GetAllWithPrefix fetches all values whose keys start with prefix from the wrapped storage
+func (storage *Storage) GetAllWithPrefix(ctx context.Context, prefix string) (map[string]string, error) {
+	return storage.Storage.GetAllWithPrefix(ctx, prefix)
+}
+
+// GetRange fetches all values whose keys are at least start and less than end from the wrapped storage
+func (storage *Storage) GetRange(ctx context.Context, start []byte, end []byte) (map[string]string, error) {
+	return storage.Storage.GetRange(ctx, start, end)
+}
+
+// DeleteAll deletes all values and tells the followers to delete theirs
+func (storage *Storage) DeleteAll(ctx context.Context) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	err := storage.Storage.DeleteAll(ctx)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	storage.publish(&pb.ReplicationEntry{Delete: true, Prefix: true})
+	return nil
+}
+
+// DeleteAllWithPrefix deletes all values whose keys start with prefix and tells the followers to delete theirs
+func (storage *Storage) DeleteAllWithPrefix(ctx context.Context, prefix string) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	err := storage.Storage.DeleteAllWithPrefix(ctx, prefix)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	storage.publish(&pb.ReplicationEntry{Key: []byte(prefix), Delete: true, Prefix: true})
+	return nil
+}
+
+// Count counts the entries whose keys start with prefix
+func (storage *Storage) Count(ctx context.Context, prefix string) (int, error) {
+	return storage.Storage.Count(ctx, prefix)
+}
+
+// Backup writes a snapshot of the wrapped storage
+func (storage *Storage) Backup(ctx context.Context, w io.Writer) error {
+	return storage.Storage.Backup(ctx, w)
+}
+
+// Restore replaces the wrapped storage with a snapshot. The followers are dropped, so that they start over from the restored data.
+func (storage *Storage) Restore(ctx context.Context, r io.Reader) error {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	err := storage.Storage.Restore(ctx, r)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	storage.dropFollowers()
+	return nil
+}
+
+// Compact compacts the wrapped storage, if it can be compacted
+func (storage *Storage) Compact(ctx context.Context) error {
+	compactor, ok := storage.Storage.(interfaces.Compactor)
+	if !ok {
+		return errors.E(errors.Op("Compact"), errors.Invalid, "wrapped storage can't be compacted")
+	}
+	return compactor.Compact(ctx)
+}
+
+// Size returns how many bytes the wrapped storage takes on disk, if it can be compacted
+func (storage *Storage) Size() (uint64, error) {
+	compactor, ok := storage.Storage.(interfaces.Compactor)
+	if !ok {
+		return 0, errors.E(errors.Op("Get database size"), errors.Invalid, "wrapped storage can't be compacted")
+	}
+	return compactor.Size()
+}
+
+// Apply makes a change received from the storage being followed to another storage.
+// A value that expires is put with the TTL it has left, or deleted if it has already expired.
+func Apply(ctx context.Context, storage interfaces.Storage, entry *pb.ReplicationEntry) error {
+	if entry.GetExpires() != nil && !entry.GetDelete() {
+		expires, err := ptypes.Timestamp(entry.GetExpires())
+		if err != nil {
+			return errors.E(errors.Op("Apply replication entry"), errors.Malformed, err)
+		}
+		ttl := time.Until(expires)
+		if ttl <= 0 {
+			return storage.Delete(ctx, entry.GetKey())
+		}
+		return storage.PutWithTTL(ctx, entry.GetKey(), entry.GetValue(), ttl)
+	}
+	switch {
+	case entry.GetDelete() && entry.GetPrefix() && len(entry.GetKey()) == 0:
+		return storage.DeleteAll(ctx)
+	case entry.GetDelete() && entry.GetPrefix():
+		return storage.DeleteAllWithPrefix(ctx, string(entry.GetKey()))
+	case entry.GetDelete():
+		return storage.Delete(ctx, entry.GetKey())
+	default:
+		return storage.Put(ctx, entry.GetKey(), entry.GetValue())
+	}
+}
@@ -0,0 +1,115 @@
+package replicated
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+func TestReplicatedStorage(t *testing.T) {
+	storage := &Storage{Storage: &inmemory.Storage{Db: make(map[string]string)}}
+	assert.NoError(t, storage.Put(ctx, []byte("order-1"), []byte("first")))
+	assert.NoError(t, storage.Put(ctx, []byte("encryption-salt"), []byte("salt")))
+
+	snapshot, sequence, changes, stop, err := storage.Follow(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(snapshot))
+	assert.Equal(t, "order-1", string(snapshot[0].GetKey()))
+	assert.Equal(t, uint64(1), sequence)
+	assert.Equal(t, 1, storage.Followers())
+
+	follower := &inmemory.Storage{Db: make(map[string]string)}
+	for _, entry := range snapshot {
+		assert.NoError(t, Apply(ctx, follower, entry))
+	}
+	assert.NoError(t, storage.Put(ctx, []byte("order-2"), []byte("second")))
+	assert.NoError(t, storage.Put(ctx, []byte("channel-1"), []byte("channel")))
+	assert.NoError(t, storage.DeleteAllWithPrefix(ctx, "order-"))
+	assert.NoError(t, storage.Delete(ctx, []byte("channel-1")))
+	assert.NoError(t, storage.Put(ctx, []byte("order-3"), []byte("third")))
+	for i := uint64(2); i <= 6; i++ {
+		entry := <-changes
+		assert.Equal(t, i, entry.GetSequence())
+		assert.NoError(t, Apply(ctx, follower, entry))
+	}
+	assert.Equal(t, map[string]string{"order-3": "third"}, follower.Db)
+	assert.Equal(t, uint64(6), storage.Sequence())
+
+	stop()
+	stop()
+	assert.Equal(t, 0, storage.Followers())
+	_, ok := <-changes
+	assert.False(t, ok)
+}
+
+func TestSlowFollower(t *testing.T) {
+	storage := &Storage{Storage: &inmemory.Storage{Db: make(map[string]string)}}
+	_, _, changes, stop, err := storage.Follow(ctx)
+	assert.NoError(t, err)
+	defer stop()
+
+	// A follower that falls behind is dropped instead of holding up writes
+	for i := 0; i <= followerBuffer; i++ {
+		assert.NoError(t, storage.Put(ctx, []byte("order-1"), []byte("value")))
+	}
+	assert.Equal(t, 0, storage.Followers())
+	received := 0
+	for range changes {
+		received++
+	}
+	assert.Equal(t, followerBuffer, received)
+}
+
+func TestRestoreDropsFollowers(t *testing.T) {
+	source := &inmemory.Storage{Db: map[string]string{"order-1": "first"}}
+	var snapshot bytes.Buffer
+	assert.NoError(t, source.Backup(ctx, &snapshot))
+
+	storage := &Storage{Storage: &inmemory.Storage{Db: make(map[string]string)}}
+	_, _, changes, _, err := storage.Follow(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Restore(ctx, &snapshot))
+	_, ok := <-changes
+	assert.False(t, ok)
+	assert.Equal(t, 0, storage.Followers())
+}
+
+func TestApplyDeleteAll(t *testing.T) {
+	follower := &inmemory.Storage{Db: map[string]string{"order-1": "first", "channel-1": "channel"}}
+	assert.NoError(t, Apply(ctx, follower, &pb.ReplicationEntry{Delete: true, Prefix: true}))
+	assert.Empty(t, follower.Db)
+}
+
+func TestReplicatedTTL(t *testing.T) {
+	storage := &Storage{Storage: &inmemory.Storage{Db: make(map[string]string)}}
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte("history-1"), []byte("deleted"), time.Hour))
+
+	// The snapshot tells when the value expires, and leaves out the entries expiring it
+	snapshot, _, changes, stop, err := storage.Follow(ctx)
+	assert.NoError(t, err)
+	defer stop()
+	assert.Equal(t, 1, len(snapshot))
+	assert.NotNil(t, snapshot[0].GetExpires())
+
+	follower := &inmemory.Storage{Db: make(map[string]string)}
+	assert.NoError(t, Apply(ctx, follower, snapshot[0]))
+	assert.Equal(t, 3, len(follower.Db))
+
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte("history-2"), []byte("deleted"), time.Hour))
+	entry := <-changes
+	assert.NotNil(t, entry.GetExpires())
+
+	// A value that expired on its way to the follower isn't kept
+	entry.Expires.Seconds = time.Now().Add(-time.Minute).Unix()
+	assert.NoError(t, Apply(ctx, follower, entry))
+	has, err := follower.Has(ctx, []byte("history-2"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
@@ -0,0 +1,397 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/sprawl/sprawl/database/backup"
+	"github.com/sprawl/sprawl/database/expiry"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+
+	// Registers the cgo-free "sqlite" driver
+	_ "modernc.org/sqlite"
+)
+
+const defaultBatchSize uint = 1000
+const dbFileName string = "sprawl.db"
+
+const createTableQuery string = "CREATE TABLE IF NOT EXISTS entries (key BLOB PRIMARY KEY, value BLOB NOT NULL) WITHOUT ROWID"
+const selectQuery string = "SELECT value FROM entries WHERE key = ?"
+const upsertQuery string = "INSERT INTO entries (key, value) VALUES (?, ?) ON CONFLICT (key) DO UPDATE SET value = excluded.value"
+const deleteQuery string = "DELETE FROM entries WHERE key = ?"
+
+// Storage is a struct containing a database and its address
+type Storage struct {
+	dbPath    string
+	batchSize uint
+	db        *sql.DB
+	// stopSweeper stops deleting the entries whose TTL has passed
+	stopSweeper func()
+}
+
+// prefixRange returns the where clause and arguments that select all keys starting with prefix,
+// using a key range so the primary key index is used
+func prefixRange(prefix string) (string, []interface{}) {
+	if prefix == "" {
+		return "1 = 1", nil
+	}
+	limit := []byte(prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return "key >= ? AND key < ?", []interface{}{[]byte(prefix), limit[:i+1]}
+		}
+	}
+	return "key >= ?", []interface{}{[]byte(prefix)}
+}
+
+// SetDbPath sets the directory the database file is located in
+func (storage *Storage) SetDbPath(dbPath string) {
+	storage.dbPath = dbPath
+}
+
+// SetBatchSize sets how many entries are deleted from SQLite at once when deleting with a prefix
+func (storage *Storage) SetBatchSize(batchSize uint) {
+	storage.batchSize = batchSize
+}
+
+// Run opens the SQLite database file, creating it if it doesn't exist,
+// and starts the sweeper that deletes the entries whose TTL has passed
+func (storage *Storage) Run() error {
+	err := os.MkdirAll(storage.dbPath, 0755)
+	if err != nil {
+		return errors.E(errors.Op("Create SQLite directory"), err)
+	}
+	storage.db, err = sql.Open("sqlite", filepath.Join(storage.dbPath, dbFileName))
+	if err != nil {
+		return errors.E(errors.Op("Open SQLite database"), err)
+	}
+	// SQLite allows a single writer, so sharing one connection avoids busy errors
+	storage.db.SetMaxOpenConns(1)
+	_, err = storage.db.Exec(createTableQuery)
+	if err != nil {
+		return errors.E(errors.Op("Create SQLite table"), err)
+	}
+	storage.stopSweeper = expiry.Start(storage, expiry.DefaultInterval)
+	return nil
+}
+
+// Close stops the sweeper and closes the underlying SQLite connection
+func (storage *Storage) Close() {
+	if storage.stopSweeper != nil {
+		storage.stopSweeper()
+		storage.stopSweeper = nil
+	}
+	storage.db.Close()
+}
+
+// Has checks if the key exists in SQLite
+func (storage *Storage) Has(ctx context.Context, key []byte) (bool, error) {
+	var exists bool
+	err := storage.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM entries WHERE key = ?)", key).Scan(&exists)
+	if err != nil {
+		return false, errors.E(errors.Op("Check key in SQLite"), err)
+	}
+	return exists, nil
+}
+
+// Get fetches the value of a key from SQLite
+func (storage *Storage) Get(ctx context.Context, key []byte) ([]byte, error) {
+	var value []byte
+	err := storage.db.QueryRowContext(ctx, selectQuery, key).Scan(&value)
+	if err != nil {
+		return nil, errors.E(errors.Op("Get value from SQLite"), err)
+	}
+	return value, nil
+}
+
+// Put inserts or replaces the value of a key in SQLite, dropping any TTL the key had
+func (storage *Storage) Put(ctx context.Context, key []byte, data []byte) error {
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.E(errors.Op("Begin put transaction"), err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, upsertQuery, key, data)
+	if err != nil {
+		return errors.E(errors.Op("Put value to SQLite"), err)
+	}
+	_, err = tx.ExecContext(ctx, deleteQuery, expiry.DeadlineKey(key))
+	if err != nil {
+		return errors.E(errors.Op("Delete deadline from SQLite"), err)
+	}
+	return errors.E(errors.Op("Commit put transaction"), tx.Commit())
+}
+
+// PutBatch inserts, replaces or deletes the values of all entries in SQLite in one transaction
+func (storage *Storage) PutBatch(ctx context.Context, entries []interfaces.Entry) error {
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.E(errors.Op("Begin put batch transaction"), err)
+	}
+	defer tx.Rollback()
+
+	for _, entry := range entries {
+		if entry.Delete {
+			_, err = tx.ExecContext(ctx, deleteQuery, entry.Key)
+		} else {
+			_, err = tx.ExecContext(ctx, upsertQuery, entry.Key, entry.Value)
+		}
+		if err != nil {
+			return errors.E(errors.Op("Put value to SQLite"), err)
+		}
+		_, err = tx.ExecContext(ctx, deleteQuery, expiry.DeadlineKey(entry.Key))
+		if err != nil {
+			return errors.E(errors.Op("Delete deadline from SQLite"), err)
+		}
+	}
+	return errors.E(errors.Op("Commit put batch transaction"), tx.Commit())
+}
+
+// PutWithTTL inserts or replaces the value of a key in SQLite together with its deadline
+// and an expiry index entry, so that the sweeper deletes it once ttl has passed
+func (storage *Storage) PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return storage.Put(ctx, key, data)
+	}
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.E(errors.Op("Begin put with TTL transaction"), err)
+	}
+	defer tx.Rollback()
+
+	deadline := time.Now().Add(ttl)
+	encoded := expiry.EncodeDeadline(deadline)
+	_, err = tx.ExecContext(ctx, upsertQuery, key, data)
+	if err != nil {
+		return errors.E(errors.Op("Put value to SQLite"), err)
+	}
+	_, err = tx.ExecContext(ctx, upsertQuery, expiry.DeadlineKey(key), encoded)
+	if err != nil {
+		return errors.E(errors.Op("Put deadline to SQLite"), err)
+	}
+	// Values can't be NULL, so the index entry holds the deadline too
+	_, err = tx.ExecContext(ctx, upsertQuery, expiry.IndexKey(key, deadline), encoded)
+	if err != nil {
+		return errors.E(errors.Op("Put expiry index entry to SQLite"), err)
+	}
+	return errors.E(errors.Op("Commit put with TTL transaction"), tx.Commit())
+}
+
+// Delete removes a key and its TTL from SQLite
+func (storage *Storage) Delete(ctx context.Context, key []byte) error {
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.E(errors.Op("Begin delete transaction"), err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, deleteQuery, key)
+	if err != nil {
+		return errors.E(errors.Op("Delete value from SQLite"), err)
+	}
+	_, err = tx.ExecContext(ctx, deleteQuery, expiry.DeadlineKey(key))
+	if err != nil {
+		return errors.E(errors.Op("Delete deadline from SQLite"), err)
+	}
+	return errors.E(errors.Op("Commit delete transaction"), tx.Commit())
+}
+
+// GetAll returns all entries in the database regardless of key or prefix
+func (storage *Storage) GetAll(ctx context.Context) (map[string]string, error) {
+	return storage.GetAllWithPrefix(ctx, "")
+}
+
+// GetAllWithPrefix returns all entries in the database with the specified prefix
+func (storage *Storage) GetAllWithPrefix(ctx context.Context, prefix string) (map[string]string, error) {
+	where, args := prefixRange(prefix)
+	rows, err := storage.db.QueryContext(ctx, "SELECT key, value FROM entries WHERE "+where, args...)
+	if err != nil {
+		return nil, errors.E(errors.Op("Get all with prefix from SQLite"), err)
+	}
+	defer rows.Close()
+
+	entries := make(map[string]string)
+	for rows.Next() {
+		var key, value []byte
+		err = rows.Scan(&key, &value)
+		if err != nil {
+			return nil, errors.E(errors.Op("Read entry from SQLite"), err)
+		}
+		entries[string(key)] = string(value)
+	}
+	if rows.Err() != nil {
+		return nil, errors.E(errors.Op("Get all with prefix from SQLite"), rows.Err())
+	}
+	return entries, nil
+}
+
+// GetRange returns all entries in the database whose keys are at least start and less than end
+func (storage *Storage) GetRange(ctx context.Context, start []byte, end []byte) (map[string]string, error) {
+	rows, err := storage.db.QueryContext(ctx, "SELECT key, value FROM entries WHERE key >= ? AND key < ?", start, end)
+	if err != nil {
+		return nil, errors.E(errors.Op("Get range from SQLite"), err)
+	}
+	defer rows.Close()
+
+	entries := make(map[string]string)
+	for rows.Next() {
+		var key, value []byte
+		err = rows.Scan(&key, &value)
+		if err != nil {
+			return nil, errors.E(errors.Op("Read entry from SQLite"), err)
+		}
+		entries[string(key)] = string(value)
+	}
+	if rows.Err() != nil {
+		return nil, errors.E(errors.Op("Get range from SQLite"), rows.Err())
+	}
+	return entries, nil
+}
+
+// DeleteAll deletes all entries from the database
+// USE CAREFULLY
+func (storage *Storage) DeleteAll(ctx context.Context) error {
+	return storage.DeleteAllWithPrefix(ctx, "")
+}
+
+// DeleteAllWithPrefix deletes all entries starting with a prefix, and their TTLs like Delete does.
+// Deletes are done in batches, yielding between them so other writers don't stall.
+func (storage *Storage) DeleteAllWithPrefix(ctx context.Context, prefix string) error {
+	err := storage.deleteRange(ctx, prefix)
+	if err != nil || prefix == "" || expiry.IsExpiryKey(prefix) {
+		return err
+	}
+	// The deadlines of the deleted keys are the ones under the deadline key of the prefix
+	return storage.deleteRange(ctx, string(expiry.DeadlineKey([]byte(prefix))))
+}
+
+// deleteRange deletes all entries starting with a prefix in batches
+func (storage *Storage) deleteRange(ctx context.Context, prefix string) error {
+	batchSize := storage.batchSize
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+
+	where, args := prefixRange(prefix)
+	query := "DELETE FROM entries WHERE key IN (SELECT key FROM entries WHERE " + where + " LIMIT ?)"
+	args = append(args, batchSize)
+	for {
+		result, err := storage.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return errors.E(errors.Op("Delete batch from SQLite"), err)
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return errors.E(errors.Op("Count deleted entries"), err)
+		}
+		if uint(deleted) < batchSize {
+			return nil
+		}
+		runtime.Gosched()
+	}
+}
+
+// Count returns the number of entries starting with a prefix
+func (storage *Storage) Count(ctx context.Context, prefix string) (int, error) {
+	where, args := prefixRange(prefix)
+	var count int
+	err := storage.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM entries WHERE "+where, args...).Scan(&count)
+	if err != nil {
+		return 0, errors.E(errors.Op("Count entries in SQLite"), err)
+	}
+	return count, nil
+}
+
+// Backup writes a consistent snapshot of the whole database into w
+func (storage *Storage) Backup(ctx context.Context, w io.Writer) error {
+	// Reading in a transaction sees the database as it was when the read started
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.E(errors.Op("Begin backup transaction"), err)
+	}
+	defer tx.Rollback()
+
+	writer, err := backup.NewWriter(w)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT key, value FROM entries")
+	if err != nil {
+		return errors.E(errors.Op("Read entries for backup"), err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key, value []byte
+		err = rows.Scan(&key, &value)
+		if err != nil {
+			return errors.E(errors.Op("Read entry for backup"), err)
+		}
+		err = writer.Write(key, value)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	if rows.Err() != nil {
+		return errors.E(errors.Op("Read entries for backup"), rows.Err())
+	}
+
+	return writer.Close()
+}
+
+// Restore replaces the contents of the database with a snapshot written by Backup.
+// The database is left untouched if the snapshot fails verification.
+func (storage *Storage) Restore(ctx context.Context, r io.Reader) error {
+	entries := make(map[string][]byte)
+	err := backup.Read(r, func(key []byte, value []byte) error {
+		entries[string(key)] = value
+		return nil
+	})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Read backup"), err)
+	}
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.E(errors.Op("Begin restore transaction"), err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM entries")
+	if err != nil {
+		return errors.E(errors.Op("Delete entries before restore"), err)
+	}
+	for key, value := range entries {
+		_, err = tx.ExecContext(ctx, upsertQuery, []byte(key), value)
+		if err != nil {
+			return errors.E(errors.Op("Write backup to storage"), err)
+		}
+	}
+	return errors.E(errors.Op("Commit restore transaction"), tx.Commit())
+}
+
+// Compact rebuilds the database file with VACUUM, so the pages freed by deleted entries are given back to the disk
+func (storage *Storage) Compact(ctx context.Context) error {
+	_, err := storage.db.ExecContext(ctx, "VACUUM")
+	if err != nil {
+		return errors.E(errors.Op("Compact"), err)
+	}
+	return nil
+}
+
+// Size returns how many bytes the database file takes
+func (storage *Storage) Size() (uint64, error) {
+	info, err := os.Stat(filepath.Join(storage.dbPath, dbFileName))
+	if err != nil {
+		return 0, errors.E(errors.Op("Get database size"), err)
+	}
+	return uint64(info.Size()), nil
+}
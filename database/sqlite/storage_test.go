@@ -0,0 +1,285 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/database/expiry"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+const testID = "0"
+const testMessage = "testing"
+const orderPrefix = "order-"
+const channelPrefix = "channel-"
+
+var testMessages = make(map[string]string)
+var err error
+
+var storage interfaces.Storage = &Storage{}
+
+func init() {
+	initTestMessages()
+	// The test config uses the in-memory database, so SQLite gets a directory of its own
+	dbPath, err := ioutil.TempDir("", "sprawl-sqlite")
+	if err != nil {
+		panic(err)
+	}
+	storage.SetDbPath(dbPath)
+}
+
+func initTestMessages() {
+	testMessages["test1"] = "test1"
+	testMessages["test2"] = "test2"
+	testMessages["test3"] = "test3"
+	testMessages["test4"] = "test4"
+}
+
+func deleteAllFromDatabase() {
+	storage.DeleteAll(ctx)
+}
+
+func TestStorageCRUD(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	storage.Put(ctx, []byte(testID), []byte(testMessage))
+	// Putting again replaces the value
+	storage.Put(ctx, []byte(testID), []byte(testMessage))
+
+	testBytes, err := storage.Get(ctx, []byte(testID))
+	testBool, err := storage.Has(ctx, []byte(testID))
+	assert.True(t, testBool)
+	assert.Equal(t, testMessage, string(testBytes))
+	assert.True(t, errors.IsEmpty(err))
+	assert.NotEmpty(t, testBytes)
+
+	storage.Delete(ctx, []byte(testID))
+	deleted, err := storage.Get(ctx, []byte(testID))
+	testBool, err = storage.Has(ctx, []byte(testID))
+	assert.False(t, testBool)
+	assert.Empty(t, deleted)
+}
+
+func TestPrefixRange(t *testing.T) {
+	where, args := prefixRange("")
+	assert.Equal(t, "1 = 1", where)
+	assert.Empty(t, args)
+
+	where, args = prefixRange("ab")
+	assert.Equal(t, "key >= ? AND key < ?", where)
+	assert.Equal(t, []interface{}{[]byte("ab"), []byte("ac")}, args)
+
+	_, args = prefixRange("a\xff")
+	assert.Equal(t, []byte("b"), args[1])
+
+	where, _ = prefixRange("\xff")
+	assert.Equal(t, "key >= ?", where)
+}
+
+func TestStorageGetAll(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put(ctx, []byte(key), []byte(value))
+	}
+
+	var allItems map[string]string
+	allItems, err = storage.GetAll(ctx)
+
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages), len(allItems))
+}
+
+func TestStorageGetAllWithPrefix(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		key = orderPrefix + key
+		storage.Put(ctx, []byte(key), []byte(value))
+	}
+
+	for key, value := range testMessages {
+		key = channelPrefix + key
+		storage.Put(ctx, []byte(key), []byte(value))
+	}
+
+	var prefixedItems map[string]string
+	prefixedItems, err = storage.GetAllWithPrefix(ctx, orderPrefix)
+	var allItems map[string]string
+	allItems, err = storage.GetAll(ctx)
+
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages), len(prefixedItems))
+	assert.Equal(t, len(testMessages)*2, len(allItems))
+}
+
+func TestStorageGetRange(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put(ctx, []byte(orderPrefix+key), []byte(value))
+	}
+
+	var rangeItems map[string]string
+	rangeItems, err = storage.GetRange(ctx, []byte(orderPrefix+"test2"), []byte(orderPrefix+"test4"))
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, map[string]string{orderPrefix + "test2": "test2", orderPrefix + "test3": "test3"}, rangeItems)
+}
+
+func TestStorageDeleteAllWithPrefix(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		key = orderPrefix + key
+		storage.Put(ctx, []byte(key), []byte(value))
+	}
+
+	for key, value := range testMessages {
+		key = channelPrefix + key
+		storage.Put(ctx, []byte(key), []byte(value))
+	}
+
+	storage.DeleteAllWithPrefix(ctx, orderPrefix)
+
+	var prefixedItems map[string]string
+	prefixedItems, err = storage.GetAllWithPrefix(ctx, orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	allItems, err := storage.GetAll(ctx)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Zero(t, len(prefixedItems))
+	assert.Equal(t, len(testMessages), len(allItems))
+}
+
+func TestStorageCount(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put(ctx, []byte(orderPrefix+key), []byte(value))
+	}
+	storage.Put(ctx, []byte(channelPrefix+testID), []byte(testMessage))
+
+	count, err := storage.Count(ctx, orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages), count)
+	count, err = storage.Count(ctx, "")
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, len(testMessages)+1, count)
+
+	// Delete in batches smaller than the number of entries
+	storage.SetBatchSize(uint(len(testMessages) - 1))
+	defer storage.SetBatchSize(0)
+	err = storage.DeleteAllWithPrefix(ctx, orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	count, err = storage.Count(ctx, orderPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Zero(t, count)
+	count, err = storage.Count(ctx, channelPrefix)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, 1, count)
+}
+
+func TestStorageBackupRestore(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	for key, value := range testMessages {
+		storage.Put(ctx, []byte(key), []byte(value))
+	}
+
+	var snapshot bytes.Buffer
+	err := storage.Backup(ctx, &snapshot)
+	assert.True(t, errors.IsEmpty(err))
+
+	deleteAllFromDatabase()
+	storage.Put(ctx, []byte(testID), []byte(testMessage))
+
+	err = storage.Restore(ctx, bytes.NewReader(snapshot.Bytes()))
+	assert.True(t, errors.IsEmpty(err))
+	allItems, err := storage.GetAll(ctx)
+	assert.True(t, errors.IsEmpty(err))
+	assert.Equal(t, testMessages, allItems)
+
+	// A corrupted snapshot must leave the database untouched
+	corrupted := snapshot.Bytes()
+	corrupted[len(corrupted)-1] ^= 0xff
+	storage.Put(ctx, []byte(testID), []byte(testMessage))
+	err = storage.Restore(ctx, bytes.NewReader(corrupted))
+	assert.False(t, errors.IsEmpty(err))
+	testBool, err := storage.Has(ctx, []byte(testID))
+	assert.True(t, testBool)
+}
+
+func BenchmarkAdd(b *testing.B) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	b.ResetTimer()
+	for i := 1; i < b.N; i++ {
+		storage.Put(ctx, []byte(string(i)), []byte(testMessage+string(i)))
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	storage.Run()
+	defer storage.Close()
+
+	b.ResetTimer()
+	for i := 1; i < b.N; i++ {
+		storage.Get(ctx, []byte(string(i)))
+	}
+}
+
+func TestStorageTTL(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+	deleteAllFromDatabase()
+
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte(orderPrefix+"expiring"), []byte(testMessage), time.Millisecond))
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte(orderPrefix+"kept"), []byte(testMessage), time.Hour))
+	assert.NoError(t, storage.PutWithTTL(ctx, []byte(orderPrefix+"putAgain"), []byte(testMessage), time.Millisecond))
+	assert.NoError(t, storage.Put(ctx, []byte(orderPrefix+"putAgain"), []byte(testMessage)))
+
+	// Only the entry whose TTL has passed is deleted, putting a key again without a TTL keeps it
+	deleted, err := expiry.Sweep(ctx, storage, time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	has, err := storage.Has(ctx, []byte(orderPrefix+"expiring"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+	count, err := storage.Count(ctx, orderPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, err = storage.Count(ctx, string(interfaces.ExpiryPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = storage.Count(ctx, string(interfaces.TTLPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Deleting with a prefix deletes the TTLs of the deleted keys too
+	assert.NoError(t, storage.DeleteAllWithPrefix(ctx, orderPrefix))
+	count, err = storage.Count(ctx, string(interfaces.TTLPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
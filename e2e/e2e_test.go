@@ -0,0 +1,242 @@
+//go:build e2e
+// +build e2e
+
+// Package e2e tests a network of Sprawl nodes running in Docker containers, checking how orders travel over real
+// connections. It needs Docker and docker-compose, and is run with make e2e.
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	sprawlclient "github.com/sprawl/sprawl/clients/go"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const composeFile = "docker-compose.yml"
+const project = "sprawl-e2e"
+const image = "sprawl-e2e"
+const networkName = "sprawl-e2e"
+const bootstrapIP = "172.28.0.10"
+
+// propagationTimeout is how long a change made on one node may take to reach the others
+const propagationTimeout = 30 * time.Second
+
+// recoveryTimeout is how long a node may take to catch up after a partition, which waits for the next order digests
+const recoveryTimeout = time.Minute
+
+// pollInterval is how often a condition is checked while waiting for it
+const pollInterval = 500 * time.Millisecond
+
+// node is a container of the network, and the client connected to its gRPC API
+type node struct {
+	service   string
+	container string
+	ip        string
+	addr      string
+	client    *sprawlclient.Client
+}
+
+func newNodes() []*node {
+	return []*node{
+		{service: "bootstrap", container: "sprawl-e2e-bootstrap", ip: bootstrapIP, addr: "localhost:13370"},
+		{service: "node1", container: "sprawl-e2e-node1", ip: "172.28.0.11", addr: "localhost:13371"},
+		{service: "node2", container: "sprawl-e2e-node2", ip: "172.28.0.12", addr: "localhost:13372"},
+		{service: "node3", container: "sprawl-e2e-node3", ip: "172.28.0.13", addr: "localhost:13373"},
+		{service: "node4", container: "sprawl-e2e-node4", ip: "172.28.0.14", addr: "localhost:13374"},
+	}
+}
+
+// run runs a command with env added to the environment, failing the test if it fails
+func run(t *testing.T, env []string, name string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s %s failed: %s\n%s", name, strings.Join(args, " "), err, output)
+	}
+	return string(output)
+}
+
+func compose(t *testing.T, env []string, args ...string) string {
+	t.Helper()
+	return run(t, env, "docker-compose", append([]string{"-f", composeFile, "-p", project}, args...)...)
+}
+
+// waitFor checks condition until it holds, failing the test if it doesn't within timeout
+func waitFor(t *testing.T, timeout time.Duration, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out after %s waiting for %s", timeout, what)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// connect connects a client to the node and waits until its API answers
+func connect(t *testing.T, n *node) {
+	t.Helper()
+	client, err := sprawlclient.New(n.addr, sprawlclient.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.client = client
+	waitFor(t, propagationTimeout, n.service+" to start", func() bool {
+		_, err := n.client.Node.GetNodeInfo(context.Background(), &pb.Empty{})
+		return err == nil
+	})
+}
+
+// startNetwork builds the node image and starts the bootstrap node, then the other nodes bootstrapping from it
+func startNetwork(t *testing.T, nodes []*node) {
+	run(t, nil, "docker", "build", "-t", image, "..")
+	compose(t, nil, "up", "-d", nodes[0].service)
+	connect(t, nodes[0])
+	info, err := nodes[0].client.Node.GetNodeInfo(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := []string{"BOOTSTRAP_PEERS=/ip4/" + bootstrapIP + "/tcp/4001/p2p/" + info.GetId()}
+	args := []string{"up", "-d", "--no-deps"}
+	for _, n := range nodes[1:] {
+		args = append(args, n.service)
+	}
+	compose(t, env, args...)
+	for _, n := range nodes[1:] {
+		connect(t, n)
+	}
+}
+
+// stopNetwork removes the containers and the network, printing the nodes' logs first if the test failed
+func stopNetwork(t *testing.T, nodes []*node) {
+	if t.Failed() {
+		t.Log(compose(t, nil, "logs", "--no-color"))
+	}
+	for _, n := range nodes {
+		if n.client != nil {
+			n.client.Close()
+		}
+	}
+	compose(t, nil, "down", "--volumes")
+}
+
+// hasChannelPeers tells if the node is connected to a peer that has joined the channel
+func hasChannelPeers(n *node, channelID []byte) bool {
+	peers, err := n.client.Node.GetPeers(context.Background(), &pb.Empty{})
+	if err != nil {
+		return false
+	}
+	for _, peer := range peers.GetPeers() {
+		for _, channel := range peer.GetChannels() {
+			if bytes.Equal(channel, channelID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getOrder returns the order from the node, or nil if the node doesn't have it
+func getOrder(n *node, channelID []byte, orderID []byte) *pb.Order {
+	order, err := n.client.Orders.GetOrder(context.Background(), &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: orderID})
+	if err != nil {
+		return nil
+	}
+	return order
+}
+
+// waitForOrder waits until every node sees the order in a state that satisfies condition
+func waitForOrder(t *testing.T, nodes []*node, channelID []byte, orderID []byte, what string, condition func(order *pb.Order) bool) {
+	t.Helper()
+	for _, n := range nodes {
+		waitFor(t, propagationTimeout, what+" on "+n.service, func() bool {
+			return condition(getOrder(n, channelID, orderID))
+		})
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	ctx := context.Background()
+	nodes := newNodes()
+	defer stopNetwork(t, nodes)
+	startNetwork(t, nodes)
+
+	var channelID []byte
+	for _, n := range nodes {
+		joined, err := n.client.Channels.Join(ctx, &pb.JoinRequest{Asset: "ETH", CounterAsset: "BTC"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		channelID = joined.GetJoinedChannel().GetId()
+	}
+	for _, n := range nodes {
+		waitFor(t, propagationTimeout, n.service+" to find peers on the channel", func() bool {
+			return hasChannelPeers(n, channelID)
+		})
+	}
+	maker := nodes[1]
+
+	// An order created on one node reaches every other node
+	created, err := maker.client.Orders.Create(ctx, &pb.CreateRequest{ChannelID: channelID, Asset: "ETH", CounterAsset: "BTC", Amount: 100, Price: 0.05})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderID := created.GetCreatedOrder().GetId()
+	waitForOrder(t, nodes, channelID, orderID, "the created order", func(order *pb.Order) bool {
+		return order != nil
+	})
+
+	// So do locks and unlocks
+	_, err = maker.client.Orders.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: orderID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForOrder(t, nodes, channelID, orderID, "the order to be locked", func(order *pb.Order) bool {
+		return order != nil && order.GetState() == pb.State_LOCKED
+	})
+	_, err = maker.client.Orders.Unlock(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: orderID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForOrder(t, nodes, channelID, orderID, "the order to be unlocked", func(order *pb.Order) bool {
+		return order != nil && order.GetState() == pb.State_OPEN
+	})
+
+	// And deletes
+	_, err = maker.client.Orders.Delete(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: orderID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForOrder(t, nodes, channelID, orderID, "the order to be deleted", func(order *pb.Order) bool {
+		return order == nil
+	})
+
+	// A node cut off from the network misses the orders created meanwhile, and catches up once it's back
+	partitioned := nodes[4]
+	run(t, nil, "docker", "network", "disconnect", networkName, partitioned.container)
+	created, err = maker.client.Orders.Create(ctx, &pb.CreateRequest{ChannelID: channelID, Asset: "ETH", CounterAsset: "BTC", Amount: 200, Price: 0.06})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderID = created.GetCreatedOrder().GetId()
+	waitForOrder(t, nodes[:4], channelID, orderID, "the order created during the partition", func(order *pb.Order) bool {
+		return order != nil
+	})
+	if getOrder(partitioned, channelID, orderID) != nil {
+		t.Fatalf("%s got the order while it was cut off from the network", partitioned.service)
+	}
+
+	run(t, nil, "docker", "network", "connect", "--ip", partitioned.ip, networkName, partitioned.container)
+	waitFor(t, recoveryTimeout, partitioned.service+" to recover the order after the partition", func() bool {
+		return getOrder(partitioned, channelID, orderID) != nil
+	})
+}
@@ -23,6 +23,14 @@ var debug = false
 const (
 	Ignore Kind = iota //Unclassified
 	Placeholder
+	Malformed        // Data that can't be unmarshaled
+	InvalidSignature // Data not signed by its sender
+	Replay           // Data that is older than what has already been processed
+	Unauthorized     // Operation not permitted for the requester
+	Duplicate        // Data that has already been processed with the same result
+	Invalid          // Data that breaks the rules of its channel
+	Throttled        // Data over the rate or open order limits of its sender
+	Oversized        // Data larger than the maximum message size
 )
 
 func (e *Error) isZero() bool {
@@ -39,6 +47,22 @@ func (k Kind) String() string {
 		return "ignored kind of error"
 	case Placeholder:
 		return "placeholder error"
+	case Malformed:
+		return "malformed data"
+	case InvalidSignature:
+		return "invalid signature"
+	case Replay:
+		return "replayed data"
+	case Unauthorized:
+		return "unauthorized"
+	case Duplicate:
+		return "already processed"
+	case Invalid:
+		return "invalid"
+	case Throttled:
+		return "throttled"
+	case Oversized:
+		return "oversized data"
 	}
 	return "unknown error kind"
 }
@@ -91,6 +115,22 @@ func IsEmpty(err error) bool {
 	}
 }
 
+// Is reports whether err is an *Error of the given Kind.
+// If err is nil or its Kind is Ignore, the wrapped errors are checked.
+func Is(kind Kind, err error) bool {
+	e, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	if e.Kind != Ignore {
+		return e.Kind == kind
+	}
+	if e.Err != nil {
+		return Is(kind, e.Err)
+	}
+	return false
+}
+
 // pad appends str to the buffer if the buffer already has some data.
 func pad(b *bytes.Buffer, str string) {
 	if b.Len() == 0 {
@@ -96,3 +96,16 @@ func TestBufferWriting(t *testing.T) {
 	assert.Equal(t, buffer.String(), "Get: placeholder error:\n\tnetwork unreachable")
 
 }
+
+func TestIs(t *testing.T) {
+	e1 := E(testOpGet, Replay, testStringNetworkUnreachable)
+	e2 := E(testOpSet, e1)
+	e3 := E(testOpSet, Malformed, e1)
+
+	assert.True(t, Is(Replay, e1))
+	assert.True(t, Is(Replay, e2))
+	assert.False(t, Is(Replay, e3))
+	assert.True(t, Is(Malformed, e3))
+	assert.False(t, Is(Replay, nil))
+	assert.False(t, Is(Replay, errors.New(testStringNetworkUnreachable)))
+}
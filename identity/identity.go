@@ -1,12 +1,17 @@
 package identity
 
 import (
+	"context"
 	"crypto/rand"
 	"io"
 
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
 )
 
 const privateKeyDbKey = "private_key"
@@ -36,12 +41,12 @@ func storeKeyPair(storage interfaces.Storage, privateKey crypto.PrivKey, publicK
 		return errors.E(errors.Op("Marshal Public Key"), err)
 	}
 
-	err = storage.Put([]byte(privateKeyDbKey), privateKeyBytes)
+	err = storage.Put(context.Background(), []byte(privateKeyDbKey), privateKeyBytes)
 	if !errors.IsEmpty(err) {
 		return errors.E(errors.Op("Store Private Key"), err)
 	}
 
-	err = storage.Put([]byte(publicKeyDbKey), publicKeyBytes)
+	err = storage.Put(context.Background(), []byte(publicKeyDbKey), publicKeyBytes)
 	if !errors.IsEmpty(err) {
 		return errors.E(errors.Op("Store Public Key"), err)
 	}
@@ -50,14 +55,14 @@ func storeKeyPair(storage interfaces.Storage, privateKey crypto.PrivKey, publicK
 }
 
 func hasKeyPair(storage interfaces.Storage) (bool, error) {
-	hasPrivateKey, err := storage.Has([]byte(privateKeyDbKey))
+	hasPrivateKey, err := storage.Has(context.Background(), []byte(privateKeyDbKey))
 	if !errors.IsEmpty(err) {
 		return false, errors.E(errors.Op("Check private key from storage"), err)
 	}
 	if !hasPrivateKey {
 		return false, nil
 	}
-	hasPublicKey, err := storage.Has([]byte(publicKeyDbKey))
+	hasPublicKey, err := storage.Has(context.Background(), []byte(publicKeyDbKey))
 	if !errors.IsEmpty(err) {
 		return false, errors.E(errors.Op("Check public key from storage"), err)
 	}
@@ -65,11 +70,11 @@ func hasKeyPair(storage interfaces.Storage) (bool, error) {
 }
 
 func getKeyPair(storage interfaces.Storage) (crypto.PrivKey, crypto.PubKey, error) {
-	privateKeyBytes, err := storage.Get([]byte(privateKeyDbKey))
+	privateKeyBytes, err := storage.Get(context.Background(), []byte(privateKeyDbKey))
 	if !errors.IsEmpty(err) {
 		return nil, nil, errors.E(errors.Op("Get private key from storage"), err)
 	}
-	publicKeyBytes, err := storage.Get([]byte(publicKeyDbKey))
+	publicKeyBytes, err := storage.Get(context.Background(), []byte(publicKeyDbKey))
 	if !errors.IsEmpty(err) {
 		return nil, nil, errors.E(errors.Op("Get public key from storage"), err)
 	}
@@ -105,6 +110,39 @@ func GetIdentity(storage interfaces.Storage) (crypto.PrivKey, crypto.PubKey, err
 	}
 }
 
+// CheckKeyPair checks that the stored key pair can be read, that its keys belong together and that they can sign and verify.
+// It returns false without an error if there's no key pair yet, since one is made when the node first starts.
+func CheckKeyPair(storage interfaces.Storage) (bool, error) {
+	hasKeyPair, err := hasKeyPair(storage)
+	if !errors.IsEmpty(err) {
+		return false, errors.E(errors.Op("Has key pair"), err)
+	}
+	if !hasKeyPair {
+		return false, nil
+	}
+	privateKey, publicKey, err := getKeyPair(storage)
+	if !errors.IsEmpty(err) {
+		return true, errors.E(errors.Op("Get key pair"), errors.Malformed, err)
+	}
+	if !privateKey.GetPublic().Equals(publicKey) {
+		return true, errors.E(errors.Op("Check key pair"), errors.Invalid, "the stored public key doesn't belong to the private key")
+	}
+
+	data := make([]byte, 32)
+	if _, err = rand.Read(data); !errors.IsEmpty(err) {
+		return true, errors.E(errors.Op("Check key pair"), err)
+	}
+	signature, err := privateKey.Sign(data)
+	if !errors.IsEmpty(err) {
+		return true, errors.E(errors.Op("Sign with key pair"), err)
+	}
+	valid, err := Verify(publicKey, data, signature)
+	if !errors.IsEmpty(err) || !valid {
+		return true, errors.E(errors.Op("Verify with key pair"), errors.InvalidSignature, "a signature made with the private key doesn't verify")
+	}
+	return true, nil
+}
+
 // Sign returns a signature for given data with this node's identity
 func Sign(storage interfaces.Storage, data []byte) (signature []byte, err error) {
 	privateKey, _, err := GetIdentity(storage)
@@ -118,3 +156,85 @@ func Sign(storage interfaces.Storage, data []byte) (signature []byte, err error)
 func Verify(publicKey crypto.PubKey, data []byte, signature []byte) (success bool, err error) {
 	return publicKey.Verify(data, signature)
 }
+
+// getTransitionSigningBytes returns the bytes of the transition that the old key signs
+func getTransitionSigningBytes(transition *pb.IdentityTransition) ([]byte, error) {
+	transitionCopy := *transition
+	transitionCopy.Signature = nil
+	return proto.Marshal(&transitionCopy)
+}
+
+// Rotate replaces this node's key pair with a new one.
+// It returns a transition record signed with the old key, which lets other peers trust the new key in place of the old one.
+func Rotate(storage interfaces.Storage) (*pb.IdentityTransition, error) {
+	oldPrivateKey, oldPublicKey, err := GetIdentity(storage)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get old identity"), err)
+	}
+	newPrivateKey, newPublicKey, err := GenerateKeyPair(rand.Reader)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Generate new key pair"), err)
+	}
+
+	oldPublicKeyBytes, err := crypto.MarshalPublicKey(oldPublicKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal old public key"), err)
+	}
+	newPublicKeyBytes, err := crypto.MarshalPublicKey(newPublicKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal new public key"), err)
+	}
+	transition := &pb.IdentityTransition{
+		OldPubKey: oldPublicKeyBytes,
+		NewPubKey: newPublicKeyBytes,
+		Created:   ptypes.TimestampNow(),
+	}
+	signingBytes, err := getTransitionSigningBytes(transition)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal transition"), err)
+	}
+	transition.Signature, err = oldPrivateKey.Sign(signingBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Sign transition"), err)
+	}
+
+	err = storeKeyPair(storage, newPrivateKey, newPublicKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Store new key pair"), err)
+	}
+	return transition, nil
+}
+
+// VerifyTransition checks that the transition is signed by its old key, and returns the peer IDs of the old and the new key
+func VerifyTransition(transition *pb.IdentityTransition) (peer.ID, peer.ID, error) {
+	oldPublicKey, err := crypto.UnmarshalPublicKey(transition.GetOldPubKey())
+	if !errors.IsEmpty(err) {
+		return "", "", errors.E(errors.Op("Unmarshal old public key"), errors.Malformed, err)
+	}
+	newPublicKey, err := crypto.UnmarshalPublicKey(transition.GetNewPubKey())
+	if !errors.IsEmpty(err) {
+		return "", "", errors.E(errors.Op("Unmarshal new public key"), errors.Malformed, err)
+	}
+
+	signingBytes, err := getTransitionSigningBytes(transition)
+	if !errors.IsEmpty(err) {
+		return "", "", errors.E(errors.Op("Marshal transition"), err)
+	}
+	valid, err := Verify(oldPublicKey, signingBytes, transition.GetSignature())
+	if !errors.IsEmpty(err) {
+		return "", "", errors.E(errors.Op("Verify transition signature"), errors.InvalidSignature, err)
+	}
+	if !valid {
+		return "", "", errors.E(errors.Op("Verify transition signature"), errors.InvalidSignature, "transition isn't signed by the old key")
+	}
+
+	oldID, err := peer.IDFromPublicKey(oldPublicKey)
+	if !errors.IsEmpty(err) {
+		return "", "", errors.E(errors.Op("Get old peer ID"), err)
+	}
+	newID, err := peer.IDFromPublicKey(newPublicKey)
+	if !errors.IsEmpty(err) {
+		return "", "", errors.E(errors.Op("Get new peer ID"), err)
+	}
+	return oldID, newID, nil
+}
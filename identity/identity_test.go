@@ -1,10 +1,12 @@
 package identity
 
 import (
+	"context"
 	"crypto/rand"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/sprawl/sprawl/config"
 	"github.com/sprawl/sprawl/database/leveldb"
 	"github.com/sprawl/sprawl/errors"
@@ -33,7 +35,7 @@ func TestKeyPairStorage(t *testing.T) {
 	storage.SetDbPath(testConfig.GetDatabasePath())
 	storage.Run()
 	defer storage.Close()
-	storage.DeleteAll()
+	storage.DeleteAll(context.Background())
 	privateKey1, publicKey1, err := NewKeyPair(storage, rand.Reader)
 	assert.True(t, errors.IsEmpty(err))
 	privateKey2, publicKey2, errStorage := getKeyPair(storage)
@@ -47,7 +49,7 @@ func TestGetIdentity(t *testing.T) {
 	storage.SetDbPath(testConfig.GetDatabasePath())
 	storage.Run()
 	defer storage.Close()
-	storage.DeleteAll()
+	storage.DeleteAll(context.Background())
 	privateKey1, publicKey1, err := GetIdentity(storage)
 	assert.True(t, errors.IsEmpty(err))
 	assert.NotNil(t, privateKey1)
@@ -58,12 +60,43 @@ func TestGetIdentity(t *testing.T) {
 	assert.Equal(t, publicKey1, publicKey2)
 }
 
+func TestCheckKeyPair(t *testing.T) {
+	storage.SetDbPath(testConfig.GetDatabasePath())
+	storage.Run()
+	defer storage.Close()
+	storage.DeleteAll(context.Background())
+
+	exists, err := CheckKeyPair(storage)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, _, err = GetIdentity(storage)
+	assert.True(t, errors.IsEmpty(err))
+	exists, err = CheckKeyPair(storage)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	// A public key of another key pair is caught
+	_, otherPublicKey, err := GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	otherPublicKeyBytes, err := crypto.MarshalPublicKey(otherPublicKey)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Put(context.Background(), []byte(publicKeyDbKey), otherPublicKeyBytes))
+	exists, err = CheckKeyPair(storage)
+	assert.True(t, exists)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	assert.NoError(t, storage.Put(context.Background(), []byte(publicKeyDbKey), []byte("garbage")))
+	_, err = CheckKeyPair(storage)
+	assert.True(t, errors.Is(errors.Malformed, err))
+}
+
 func TestSignAndVerify(t *testing.T) {
 	t.Logf("Database path: %s", testConfig.GetDatabasePath())
 	storage.SetDbPath(testConfig.GetDatabasePath())
 	storage.Run()
 	defer storage.Close()
-	storage.DeleteAll()
+	storage.DeleteAll(context.Background())
 	_, publicKey, err := GetIdentity(storage)
 	assert.True(t, errors.IsEmpty(err))
 	testOrder := &pb.Order{Asset: string("ETH"), CounterAsset: string("BTC"), Amount: 52152, Price: 0.2, Id: []byte("jgkahgkjal")}
@@ -76,3 +109,29 @@ func TestSignAndVerify(t *testing.T) {
 	assert.True(t, legit)
 
 }
+
+func TestRotate(t *testing.T) {
+	storage.SetDbPath(testConfig.GetDatabasePath())
+	storage.Run()
+	defer storage.Close()
+	storage.DeleteAll(context.Background())
+	_, oldPublicKey, err := GetIdentity(storage)
+	assert.NoError(t, err)
+
+	transition, err := Rotate(storage)
+	assert.NoError(t, err)
+	_, newPublicKey, err := GetIdentity(storage)
+	assert.NoError(t, err)
+	assert.False(t, oldPublicKey.Equals(newPublicKey))
+
+	oldID, newID, err := VerifyTransition(transition)
+	assert.NoError(t, err)
+	assert.True(t, oldID.MatchesPublicKey(oldPublicKey))
+	assert.True(t, newID.MatchesPublicKey(newPublicKey))
+
+	// The transition can't be pointed at another key
+	tampered := *transition
+	tampered.NewPubKey = tampered.OldPubKey
+	_, _, err = VerifyTransition(&tampered)
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+}
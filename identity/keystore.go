@@ -0,0 +1,197 @@
+package identity
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// Keystore keeps the node's key pair in a storage of its own, so that the order database can be wiped
+// without changing the node's peer ID. Every other key is passed to the order database.
+type Keystore struct {
+	interfaces.Storage
+	Keys interfaces.Storage
+}
+
+// isKeyPairKey tells if the key holds half of the node's key pair
+func isKeyPairKey(key []byte) bool {
+	return string(key) == privateKeyDbKey || string(key) == publicKeyDbKey
+}
+
+// Run starts both storages and moves a key pair stored in the order database by an older version into the keystore
+func (keystore *Keystore) Run() error {
+	err := keystore.Storage.Run()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = keystore.Keys.Run()
+	if !errors.IsEmpty(err) {
+		keystore.Storage.Close()
+		return errors.E(errors.Op("Run keystore"), err)
+	}
+	err = MoveKeyPair(context.Background(), keystore.Storage, keystore.Keys)
+	if !errors.IsEmpty(err) {
+		keystore.Close()
+		return err
+	}
+	return nil
+}
+
+// Close closes both storages
+func (keystore *Keystore) Close() {
+	keystore.Keys.Close()
+	keystore.Storage.Close()
+}
+
+// Has checks if the key exists in the storage it's kept in
+func (keystore *Keystore) Has(ctx context.Context, key []byte) (bool, error) {
+	if isKeyPairKey(key) {
+		return keystore.Keys.Has(ctx, key)
+	}
+	return keystore.Storage.Has(ctx, key)
+}
+
+// Get fetches a value from the storage its key is kept in
+func (keystore *Keystore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if isKeyPairKey(key) {
+		return keystore.Keys.Get(ctx, key)
+	}
+	return keystore.Storage.Get(ctx, key)
+}
+
+// Put stores a value in the storage its key is kept in
+func (keystore *Keystore) Put(ctx context.Context, key []byte, data []byte) error {
+	if isKeyPairKey(key) {
+		return keystore.Keys.Put(ctx, key, data)
+	}
+	return keystore.Storage.Put(ctx, key, data)
+}
+
+// PutWithTTL stores a value until ttl has passed in the storage its key is kept in
+func (keystore *Keystore) PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error {
+	if isKeyPairKey(key) {
+		return keystore.Keys.PutWithTTL(ctx, key, data, ttl)
+	}
+	return keystore.Storage.PutWithTTL(ctx, key, data, ttl)
+}
+
+// PutBatch puts or deletes all entries in the order database at once, if it can, and one by one otherwise.
+// The key pair isn't written in batches.
+func (keystore *Keystore) PutBatch(ctx context.Context, entries []interfaces.Entry) error {
+	for _, entry := range entries {
+		if isKeyPairKey(entry.Key) {
+			return errors.E(errors.Op("Put batch"), errors.Invalid, "the key pair can't be put in a batch")
+		}
+	}
+	if batcher, ok := keystore.Storage.(interfaces.Batcher); ok {
+		return batcher.PutBatch(ctx, entries)
+	}
+	for _, entry := range entries {
+		var err error
+		if entry.Delete {
+			err = keystore.Storage.Delete(ctx, entry.Key)
+		} else {
+			err = keystore.Storage.Put(ctx, entry.Key, entry.Value)
+		}
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes a value from the storage its key is kept in
+func (keystore *Keystore) Delete(ctx context.Context, key []byte) error {
+	if isKeyPairKey(key) {
+		return keystore.Keys.Delete(ctx, key)
+	}
+	return keystore.Storage.Delete(ctx, key)
+}
+
+// GetAll returns all entries of the order database together with the key pair, so that followers replicate the identity too
+func (keystore *Keystore) GetAll(ctx context.Context) (map[string]string, error) {
+	entries, err := keystore.Storage.GetAll(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	hasKeyPair, err := hasKeyPair(keystore.Keys)
+	if !errors.IsEmpty(err) || !hasKeyPair {
+		return entries, err
+	}
+	for _, key := range []string{privateKeyDbKey, publicKeyDbKey} {
+		value, err := keystore.Keys.Get(ctx, []byte(key))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Get key from keystore"), err)
+		}
+		entries[key] = string(value)
+	}
+	return entries, nil
+}
+
+// Compact compacts the order database, if it can be compacted
+func (keystore *Keystore) Compact(ctx context.Context) error {
+	compactor, ok := keystore.Storage.(interfaces.Compactor)
+	if !ok {
+		return errors.E(errors.Op("Compact"), errors.Invalid, "wrapped storage can't be compacted")
+	}
+	return compactor.Compact(ctx)
+}
+
+// Size returns how many bytes the order database takes on disk, if it can be compacted
+func (keystore *Keystore) Size() (uint64, error) {
+	compactor, ok := keystore.Storage.(interfaces.Compactor)
+	if !ok {
+		return 0, errors.E(errors.Op("Get database size"), errors.Invalid, "wrapped storage can't be compacted")
+	}
+	return compactor.Size()
+}
+
+// MoveKeyPair moves the key pair from one storage to another, deleting it from the first once the second has it.
+// Nothing is moved if the first storage has no key pair. It fails if both storages have a key pair and they differ,
+// since either one may be the identity the node's peers know.
+func MoveKeyPair(ctx context.Context, from interfaces.Storage, to interfaces.Storage) error {
+	hasKeyPair, err := hasKeyPair(from)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check key pair to move"), err)
+	}
+	if !hasKeyPair {
+		return nil
+	}
+
+	for _, key := range []string{privateKeyDbKey, publicKeyDbKey} {
+		value, err := from.Get(ctx, []byte(key))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Get key to move"), err)
+		}
+		exists, err := to.Has(ctx, []byte(key))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check key in keystore"), err)
+		}
+		if exists {
+			stored, err := to.Get(ctx, []byte(key))
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Get key from keystore"), err)
+			}
+			if !bytes.Equal(stored, value) {
+				return errors.E(errors.Op("Move key pair"), errors.Invalid, "the database and the keystore have different key pairs, remove the one that isn't the node's identity")
+			}
+			continue
+		}
+		err = to.Put(ctx, []byte(key), value)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put key to keystore"), err)
+		}
+	}
+
+	// The keys are only deleted once both are in the keystore, so a crash in between leaves them in both
+	for _, key := range []string{privateKeyDbKey, publicKeyDbKey} {
+		err = from.Delete(ctx, []byte(key))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete moved key"), err)
+		}
+	}
+	return nil
+}
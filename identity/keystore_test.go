@@ -0,0 +1,51 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystore(t *testing.T) {
+	ctx := context.Background()
+	database := &inmemory.Storage{Db: make(map[string]string)}
+	keys := &inmemory.Storage{Db: make(map[string]string)}
+
+	// A key pair stored in the database by an older version is moved into the keystore
+	privateKey, publicKey, err := NewKeyPair(database, rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, database.Put(ctx, []byte("order-1"), []byte("order")))
+	keystore := &Keystore{Storage: database, Keys: keys}
+	assert.NoError(t, keystore.Run())
+	assert.Equal(t, map[string]string{"order-1": "order"}, database.Db)
+
+	storedPrivateKey, storedPublicKey, err := GetIdentity(keystore)
+	assert.NoError(t, err)
+	assert.True(t, privateKey.Equals(storedPrivateKey))
+	assert.True(t, publicKey.Equals(storedPublicKey))
+
+	// Wiping the database keeps the identity, and the snapshot for followers includes it
+	assert.NoError(t, keystore.DeleteAll(ctx))
+	all, err := keystore.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(all))
+	_, storedPublicKey, err = GetIdentity(keystore)
+	assert.NoError(t, err)
+	assert.True(t, publicKey.Equals(storedPublicKey))
+}
+
+func TestMoveKeyPairConflict(t *testing.T) {
+	database := &inmemory.Storage{Db: make(map[string]string)}
+	keys := &inmemory.Storage{Db: make(map[string]string)}
+	_, _, err := NewKeyPair(database, rand.Reader)
+	assert.NoError(t, err)
+	_, _, err = NewKeyPair(keys, rand.Reader)
+	assert.NoError(t, err)
+
+	// Neither key pair is thrown away
+	assert.Error(t, MoveKeyPair(context.Background(), database, keys))
+	assert.Equal(t, 2, len(database.Db))
+}
@@ -0,0 +1,132 @@
+package identity
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// GetOrderSigningBytes returns the bytes of the order that its maker signs, leaving out the fields that change after it's created
+func GetOrderSigningBytes(order *pb.Order) ([]byte, error) {
+	orderCopy := *order
+	orderCopy.State = pb.State_OPEN
+	orderCopy.Signature = nil
+	orderCopy.Nonce = 0
+	orderCopy.DeletedAt = nil
+	orderCopy.LockedUntil = nil
+	orderCopy.FilledAmount = 0
+	orderCopy.Fills = nil
+	return proto.Marshal(&orderCopy)
+}
+
+// VerifyOrderSignature verifies that the order is signed with the private key of publicKey
+func VerifyOrderSignature(publicKey crypto.PubKey, order *pb.Order) (bool, error) {
+	signingBytes, err := GetOrderSigningBytes(order)
+	if !errors.IsEmpty(err) {
+		return false, errors.E(errors.Op("Marshal order"), err)
+	}
+	return Verify(publicKey, signingBytes, order.GetSignature())
+}
+
+// VerifyOrder checks that the order's maker fields belong together and that the maker has signed the order,
+// and returns the maker's peer ID. It needs nothing but the order, so clients can check orders relayed by any node.
+func VerifyOrder(order *pb.Order) (peer.ID, error) {
+	makerID, publicKey, err := getMakerKey(order)
+	if !errors.IsEmpty(err) {
+		return "", err
+	}
+	err = checkOrderSignature(publicKey, order)
+	if !errors.IsEmpty(err) {
+		return "", err
+	}
+	return makerID, nil
+}
+
+// getMakerKey unmarshals the maker's public key of the order and checks that it belongs to the maker's peer ID
+func getMakerKey(order *pb.Order) (peer.ID, crypto.PubKey, error) {
+	makerID, err := peer.IDFromBytes(order.GetMakerPeerID())
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Parse maker peer ID"), errors.Malformed, err)
+	}
+	publicKey, err := crypto.UnmarshalPublicKey(order.GetMakerPubKey())
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Unmarshal maker public key"), errors.Malformed, err)
+	}
+	if !makerID.MatchesPublicKey(publicKey) {
+		return "", nil, errors.E(errors.Op("Match maker public key"), errors.InvalidSignature, "maker public key doesn't match the maker peer ID")
+	}
+	return makerID, publicKey, nil
+}
+
+func checkOrderSignature(publicKey crypto.PubKey, order *pb.Order) error {
+	valid, err := VerifyOrderSignature(publicKey, order)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify order signature"), err)
+	}
+	if !valid {
+		return errors.E(errors.Op("Verify order signature"), errors.InvalidSignature, "order isn't signed by its maker")
+	}
+	return nil
+}
+
+// makerKey is a maker's public key, checked once for all of its orders in a batch
+type makerKey struct {
+	id        peer.ID
+	publicKey crypto.PubKey
+	err       error
+}
+
+// VerifyOrders checks a batch of orders like VerifyOrder, as when a peer sends the open orders of a channel.
+// Each maker's public key is unmarshaled and matched to its peer ID once for all of its orders,
+// and the signatures are verified on every CPU at once. None of the key types libp2p has can aggregate
+// signatures, so each one is still verified on its own. It returns the maker of each order, or the error
+// the order failed with, at the same index as the order.
+func VerifyOrders(orders []*pb.Order) ([]peer.ID, []error) {
+	makerIDs := make([]peer.ID, len(orders))
+	errs := make([]error, len(orders))
+
+	keys := make(map[string]*makerKey)
+	orderKeys := make([]*makerKey, len(orders))
+	for i, order := range orders {
+		cacheKey := string(order.GetMakerPeerID()) + string(order.GetMakerPubKey())
+		key, ok := keys[cacheKey]
+		if !ok {
+			key = &makerKey{}
+			key.id, key.publicKey, key.err = getMakerKey(order)
+			keys[cacheKey] = key
+		}
+		orderKeys[i] = key
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(orders) {
+		workers = len(orders)
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func(worker int) {
+			defer wg.Done()
+			for i := worker; i < len(orders); i += workers {
+				key := orderKeys[i]
+				if !errors.IsEmpty(key.err) {
+					errs[i] = key.err
+					continue
+				}
+				err := checkOrderSignature(key.publicKey, orders[i])
+				if !errors.IsEmpty(err) {
+					errs[i] = err
+					continue
+				}
+				makerIDs[i] = key.id
+			}
+		}(worker)
+	}
+	wg.Wait()
+	return makerIDs, errs
+}
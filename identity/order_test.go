@@ -0,0 +1,99 @@
+package identity
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncedOrders is the size of the order book a node syncs from a peer in the benchmarks
+const syncedOrders int = 100000
+
+// newSignedOrders makes orders signed by the given number of makers, taking turns
+func newSignedOrders(count int, makers int) ([]*pb.Order, error) {
+	privateKeys := make([]crypto.PrivKey, makers)
+	for i := range privateKeys {
+		privateKey, _, err := GenerateKeyPair(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		privateKeys[i] = privateKey
+	}
+	orders := make([]*pb.Order, count)
+	for i := range orders {
+		privateKey := privateKeys[i%makers]
+		makerID, err := peer.IDFromPrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		makerPubKey, err := crypto.MarshalPublicKey(privateKey.GetPublic())
+		if err != nil {
+			return nil, err
+		}
+		order := &pb.Order{Id: []byte(fmt.Sprint(i)), Asset: "ETH", CounterAsset: "BTC", Amount: uint64(i + 1), Price: 0.1, MakerPeerID: []byte(makerID), MakerPubKey: makerPubKey}
+		signingBytes, err := GetOrderSigningBytes(order)
+		if err != nil {
+			return nil, err
+		}
+		order.Signature, err = privateKey.Sign(signingBytes)
+		if err != nil {
+			return nil, err
+		}
+		orders[i] = order
+	}
+	return orders, nil
+}
+
+func TestVerifyOrders(t *testing.T) {
+	orders, err := newSignedOrders(20, 3)
+	assert.NoError(t, err)
+	orders[5].Price = 0.2
+	orders[7].MakerPubKey = orders[8].GetMakerPubKey()
+	orders[9].MakerPeerID = []byte("garbage")
+
+	makerIDs, errs := VerifyOrders(orders)
+	assert.Len(t, makerIDs, len(orders))
+	assert.Len(t, errs, len(orders))
+	for i, order := range orders {
+		makerID, err := VerifyOrder(order)
+		assert.Equal(t, makerID, makerIDs[i])
+		assert.Equal(t, errors.IsEmpty(err), errors.IsEmpty(errs[i]))
+	}
+	assert.True(t, errors.Is(errors.InvalidSignature, errs[5]))
+	assert.True(t, errors.Is(errors.InvalidSignature, errs[7]))
+	assert.True(t, errors.Is(errors.Malformed, errs[9]))
+
+	makerIDs, errs = VerifyOrders([]*pb.Order{})
+	assert.Empty(t, makerIDs)
+	assert.Empty(t, errs)
+}
+
+func BenchmarkVerifyOrder(b *testing.B) {
+	orders, err := newSignedOrders(syncedOrders, 100)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, order := range orders {
+			VerifyOrder(order)
+		}
+	}
+}
+
+func BenchmarkVerifyOrders(b *testing.B) {
+	orders, err := newSignedOrders(syncedOrders, 100)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyOrders(orders)
+	}
+}
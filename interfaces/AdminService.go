@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/pb"
+)
+
+// AdminService is an interface to the Admin endpoints in sprawl.proto
+type AdminService interface {
+	RegisterStorage(db Storage)
+	Backup(in *pb.Empty, stream pb.AdminHandler_BackupServer) error
+	Restore(stream pb.AdminHandler_RestoreServer) error
+	CaptureProfile(ctx context.Context, in *pb.ProfileRequest) (*pb.ProfileResponse, error)
+	ExportAuditLog(in *pb.AuditLogRequest, stream pb.AdminHandler_ExportAuditLogServer) error
+	GetDeadLetters(ctx context.Context, in *pb.Empty) (*pb.DeadLetterList, error)
+	ReplayDeadLetters(ctx context.Context, in *pb.DeadLetterRequest) (*pb.DeadLetterList, error)
+	PurgeDeadLetters(ctx context.Context, in *pb.DeadLetterRequest) (*pb.Empty, error)
+	Compact(ctx context.Context, in *pb.Empty) (*pb.CompactionReport, error)
+	SelfTest(ctx context.Context, in *pb.Empty) (*pb.SelfTestReport, error)
+	Replicate(in *pb.Empty, stream pb.AdminHandler_ReplicateServer) error
+	GetReplicationStatus(ctx context.Context, in *pb.Empty) (*pb.ReplicationStatus, error)
+	Promote(ctx context.Context, in *pb.Empty) (*pb.ReplicationStatus, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/pb"
+)
+
+// AssetService is an interface to the Asset endpoints in sprawl.proto
+type AssetService interface {
+	RegisterStorage(db Storage)
+	Register(ctx context.Context, in *pb.Asset) (*pb.Asset, error)
+	GetAllAssets(ctx context.Context, in *pb.Empty) (*pb.AssetList, error)
+}
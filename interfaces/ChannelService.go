@@ -14,4 +14,8 @@ type ChannelService interface {
 	Leave(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Empty, error)
 	GetChannel(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Channel, error)
 	GetAllChannels(ctx context.Context, in *pb.Empty) (*pb.ChannelList, error)
+	SetMembers(ctx context.Context, in *pb.MembershipRequest) (*pb.Channel, error)
+	PublishConfig(ctx context.Context, in *pb.ChannelConfigRequest) (*pb.Channel, error)
+	GetIdleChannels(ctx context.Context, in *pb.Empty) (*pb.IdleChannelList, error)
+	Rejoin(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.JoinResponse, error)
 }
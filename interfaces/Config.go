@@ -1,5 +1,7 @@
 package interfaces
 
+import "github.com/spf13/pflag"
+
 // Config is an interface to viper
 type Config interface {
 	AddString(key string)
@@ -9,19 +11,125 @@ type Config interface {
 	AddBooleanE(key string) error
 	AddUintE(key string) error
 	ReadConfig(configPath string)
+	BindFlags(flags *pflag.FlagSet)
+	SetDefaults()
 	GetDatabasePath() string
 	GetExternalIP() string
 	GetLogLevel() string
 	GetLogFormat() string
 	GetP2PPort() uint
+	GetMessageRateLimit() uint
+	GetMaxMessageSize() uint
+	GetThrottleScore() uint
+	GetDisconnectScore() uint
+	GetConnLow() uint
+	GetConnHigh() uint
+	GetConnGracePeriod() uint
+	GetJournalRetention() uint
+	GetFastSyncPeers() string
+	GetNetwork() string
+	GetStreamReadTimeout() uint
+	GetStreamWriteTimeout() uint
+	GetGossipD() uint
+	GetGossipDlo() uint
+	GetGossipDhi() uint
+	GetGossipHeartbeatInterval() uint
+	GetFloodPublishPeers() uint
+	GetGossipRelaySetting() bool
+	GetGossipRelayHops() uint
+	GetQueueDataRate() uint
+	GetQueueBulkRate() uint
+	GetChaosLatency() uint
+	GetChaosDropPercent() uint
+	GetChaosCloseStreamPercent() uint
 	GetRPCPort() uint
+	GetRPCReflectionSetting() bool
+	GetAPIKeys() string
+	GetAPIRoles() string
+	GetRPCWebPort() uint
+	GetRPCWebOrigins() string
+	GetRPCKeepaliveTime() uint
+	GetRPCKeepaliveTimeout() uint
+	GetRPCKeepaliveMinTime() uint
+	GetRPCKeepalivePermitWithoutStream() bool
+	GetRPCMaxConnectionIdle() uint
+	GetRPCMaxConnectionAge() uint
+	GetRPCMaxRecvMessageSize() uint
+	GetRPCMaxSendMessageSize() uint
+	GetRPCMaxConcurrentStreams() uint
 	GetWebsocketPort() uint
+	GetWebsocketPingInterval() uint
+	GetWebsocketPongTimeout() uint
+	GetWebsocketFlushInterval() uint
+	GetWebsocketListeners() string
 	GetWebsocketEnable() bool
+	GetMarketAPIPort() uint
+	GetMarketAPIMaxAge() uint
+	GetDashboardPort() uint
+	GetRouterPairs() string
+	GetMarketDataIntervals() string
+	GetHistoryRetention() uint
 	GetInMemoryDatabaseSetting() bool
+	GetDeleteBatchSize() uint
+	GetMigrationsDryRunSetting() bool
+	GetDatabaseEngine() string
+	GetIdentityPath() string
+	GetDatabaseEncryptionPassphrase() string
+	GetMinFreeSpace() uint
+	GetDiskCheckInterval() uint
+	GetCompactAt() string
+	GetGroupCommitWindow() uint
+	GetFsyncPolicy() string
 	GetNATPortMapSetting() bool
 	GetRelaySetting() bool
 	GetAutoRelaySetting() bool
 	GetDebugSetting() bool
 	GetStackTraceSetting() bool
 	GetIPFSPeerSetting() bool
+	GetBootstrapPeers() string
+	GetBootstrapRefreshInterval() uint
+	GetDiscoveryInterval() uint
+	GetBrowserTransportsSetting() bool
+	GetBrowserPort() uint
+	GetSecurity() string
+	GetPrivateNetworkKey() string
+	GetListenAddresses() string
+	GetExternalIPv6() string
+	GetQUICSetting() bool
+	GetReusePortSetting() bool
+	GetAnnounceAddresses() string
+	GetNoAnnounce() string
+	GetLockTimeout() uint
+	GetUnlockInterval() uint
+	GetOrderCacheSize() uint
+	GetMaxClockSkew() uint
+	GetMakerRateLimit() uint
+	GetMaxMakerOrders() uint
+	GetOrderWorkers() uint
+	GetAntiEntropyInterval() uint
+	GetOrderIDMismatch() string
+	GetOrderIDCollision() string
+	GetMaxOrderAge() uint
+	GetMaxOrders() uint
+	GetChannelPruneInterval() uint
+	GetChannelIdleTimeout() uint
+	GetAllowCustomAssets() bool
+	GetWebhookURLs() string
+	GetWebhookEvents() string
+	GetWebhookSecret() string
+	GetWebhookRetries() uint
+	GetSettlementEngine() string
+	GetEthereumRPCURL() string
+	GetEthereumContract() string
+	GetEthereumAccount() string
+	GetEthereumConfirmations() uint
+	GetEthereumPollInterval() uint
+	GetEthereumTimeout() uint
+	GetReplicationSetting() bool
+	GetReplicationPrimary() string
+	GetReplicationAPIKey() string
+	GetPprofPort() uint
+	GetProfileDir() string
+	GetDeadLetters() uint
+	GetCountersInterval() uint
 }
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/pb"
+)
+
+// MarketDataService is an interface to the MarketData endpoints in sprawl.proto
+type MarketDataService interface {
+	RegisterStorage(db Storage)
+	RegisterWebsocket(websocket WebsocketService)
+	GetCandles(ctx context.Context, in *pb.CandleRequest) (*pb.CandleList, error)
+	GetTicker(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Ticker, error)
+}
@@ -10,4 +10,6 @@ type NodeService interface {
 	RegisterP2p(p2p P2p)
 	GetAllPeers(ctx context.Context, in *pb.Empty) (*pb.PeerListResponse, error)
 	BlacklistPeer(ctx context.Context, in *pb.Peer) (*pb.Empty, error)
+	GetNodeInfo(ctx context.Context, in *pb.Empty) (*pb.NodeInfo, error)
+	GetPeers(ctx context.Context, in *pb.Empty) (*pb.PeerDetailsList, error)
 }
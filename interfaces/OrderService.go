@@ -2,9 +2,9 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
-	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/pb"
 )
 
@@ -14,12 +14,23 @@ type OrderService interface {
 	RegisterP2p(p2p P2p)
 	RegisterWebsocket(websocket WebsocketService)
 	Create(ctx context.Context, in *pb.CreateRequest) (*pb.CreateResponse, error)
-	Receive(data []byte, from peer.ID) error
+	Receive(ctx context.Context, msg IncomingMessage) error
 	Delete(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error)
+	DeleteAll(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.OrderList, error)
+	KillSwitch(ctx context.Context, in *pb.KillSwitchRequest) (*pb.OrderList, error)
 	Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error)
 	Unlock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error)
+	Remove(ctx context.Context, in *pb.RemovalRequest) (*pb.Removal, error)
+	ReportFill(ctx context.Context, in *pb.FillRequest) (*pb.Order, error)
 	GetOrder(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Order, error)
-	GetAllOrders(ctx context.Context, in *pb.Empty) (*pb.OrderList, error)
+	GetAllOrders(ctx context.Context, in *pb.OrderListRequest) (*pb.OrderList, error)
+	GetOrderBook(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.OrderList, error)
+	GetOrderHistory(ctx context.Context, in *pb.OrderHistoryRequest) (*pb.OrderHistoryResponse, error)
+	Search(ctx context.Context, in *pb.SearchRequest) (*pb.OrderList, error)
+	SubscribeOrderBook(in *pb.ChannelSpecificRequest, stream pb.OrderHandler_SubscribeOrderBookServer) error
+	PruneHistory(before time.Time) error
+	Negotiate(stream pb.OrderHandler_NegotiateServer) error
+	RotateIdentity(ctx context.Context, in *pb.Empty) (*pb.IdentityTransition, error)
 	GetSignature(order *pb.Order) ([]byte, error)
 	VerifyOrder(publicKey crypto.PubKey, order *pb.Order) (bool, error)
 }
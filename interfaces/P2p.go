@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/pb"
@@ -11,12 +12,19 @@ import (
 type P2p interface {
 	GetHostID() peer.ID
 	GetHostIDString() string
+	GetListenAddresses() []string
+	GetAnnouncedAddresses() []string
 	AddReceiver(receiver Receiver)
-	Send(message *pb.WireMessage)
+	Send(ctx context.Context, message *pb.WireMessage) error
+	Broadcast(ctx context.Context, message *pb.WireMessage) error
+	SendToPeer(peerID peer.ID, message *pb.WireMessage) error
 	Subscribe(channel *pb.Channel) (context.Context, error)
 	Unsubscribe(channel *pb.Channel)
 	GetAllPeers() []peer.ID
 	BlacklistPeer(peerID *pb.Peer)
+	GetPeerScores() []*pb.PeerScore
+	GetPeerDetails() []*pb.PeerDetails
+	GetClockSkew() (time.Duration, int)
 	OpenStream(peerID peer.ID) (Stream, error)
 	CloseStream(peerID peer.ID) error
 	Run()
@@ -26,4 +34,11 @@ type P2p interface {
 // Stream is a single stream instance between two peers
 type Stream interface {
 	WriteToStream(data []byte) error
+	HasCapability(capability string) bool
 }
+
+// CapabilityMembership is the capability of receiving channel memberships over streams
+const CapabilityMembership string = "membership"
+
+// CapabilityChannelConfig is the capability of receiving signed channel configs over streams
+const CapabilityChannelConfig string = "channelConfig"
@@ -1,8 +1,43 @@
 package interfaces
 
-import peer "github.com/libp2p/go-libp2p-core/peer"
+import (
+	"context"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+// Transport tells how a message reached the node
+type Transport string
+
+const (
+	// TransportGossip is used for messages received from a channel's pubsub topic
+	TransportGossip Transport = "gossip"
+	// TransportStream is used for messages a peer sent directly over a stream
+	TransportStream Transport = "stream"
+	// TransportRelay is used for messages passed on by gossip relays, from the peer that signed them
+	TransportRelay Transport = "relay"
+	// TransportReplay is used for dead letters that are processed again
+	TransportReplay Transport = "replay"
+)
+
+// IncomingMessage is a marshaled WireMessage received from another node, with where and when it came from
+type IncomingMessage struct {
+	// From is the peer that sent the message, or signed it if it was relayed
+	From peer.ID
+	// ChannelID is the channel whose topic the message arrived on. It's empty for messages sent directly over a stream.
+	ChannelID  []byte
+	Transport  Transport
+	ReceivedAt time.Time
+	Data       []byte
+}
 
 // Receiver receives and parses all Wiremessages from p2p
 type Receiver interface {
-	Receive(data []byte, from peer.ID) error
+	Receive(ctx context.Context, msg IncomingMessage) error
+}
+
+// SnapshotReceiver stores the open orders of a channel downloaded from a trusted peer with fast-sync
+type SnapshotReceiver interface {
+	ReceiveSnapshot(channelID []byte, orders [][]byte, from peer.ID) error
 }
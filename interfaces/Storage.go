@@ -1,18 +1,53 @@
 package interfaces
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
 // Storage defines a database interface that works with Sprawl
 type Storage interface {
 	SetDbPath(dbPath string)
+	SetBatchSize(batchSize uint)
 	Run() error
 	Close()
-	Has(key []byte) (bool, error)
-	Get(key []byte) ([]byte, error)
-	Put(key []byte, data []byte) error
-	Delete(key []byte) error
-	GetAll() (map[string]string, error)
-	GetAllWithPrefix(prefix string) (map[string]string, error)
-	DeleteAll() error
-	DeleteAllWithPrefix(prefix string) error
+	Has(ctx context.Context, key []byte) (bool, error)
+	Get(ctx context.Context, key []byte) ([]byte, error)
+	Put(ctx context.Context, key []byte, data []byte) error
+	// PutWithTTL puts a value that's deleted once ttl has passed, at the storage's next sweep.
+	// Putting the key again without a TTL keeps it, and a ttl of 0 is the same as Put.
+	PutWithTTL(ctx context.Context, key []byte, data []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key []byte) error
+	GetAll(ctx context.Context) (map[string]string, error)
+	GetAllWithPrefix(ctx context.Context, prefix string) (map[string]string, error)
+	GetRange(ctx context.Context, start []byte, end []byte) (map[string]string, error)
+	DeleteAll(ctx context.Context) error
+	DeleteAllWithPrefix(ctx context.Context, prefix string) error
+	Count(ctx context.Context, prefix string) (int, error)
+	Backup(ctx context.Context, w io.Writer) error
+	Restore(ctx context.Context, r io.Reader) error
+}
+
+// Compactor is implemented by storages that can compact their files on disk,
+// reclaiming the space taken by deleted and overwritten entries
+type Compactor interface {
+	Compact(ctx context.Context) error
+	// Size returns how many bytes the storage's files take on disk
+	Size() (uint64, error)
+}
+
+// Entry is a key and its value, put together with other entries by a Batcher
+type Entry struct {
+	Key   []byte
+	Value []byte
+	// Delete removes Key instead of putting Value
+	Delete bool
+}
+
+// Batcher is implemented by storages that can put or delete several entries at once, so that a crash leaves either all of them or none
+type Batcher interface {
+	PutBatch(ctx context.Context, entries []Entry) error
 }
 
 // Prefix is a type used to prefix all entries in Storage
@@ -23,4 +58,52 @@ const (
 	OrderPrefix Prefix = "order-"
 	// ChannelPrefix is the prefix used to signify all channels in Storage
 	ChannelPrefix Prefix = "channel-"
+	// HistoryPrefix is the prefix used to signify deleted orders kept in Storage for the order history
+	HistoryPrefix Prefix = "history-"
+	// NegotiationPrefix is the prefix used to signify the persisted state of order negotiations in Storage
+	NegotiationPrefix Prefix = "negotiation-"
+	// EncryptionPrefix is the prefix used to signify the salt and check value of an encrypted Storage
+	EncryptionPrefix Prefix = "encryption-"
+	// TransitionPrefix is the prefix used to signify identity transitions in Storage, keyed by the peer ID of the old key
+	TransitionPrefix Prefix = "transition-"
+	// AssetPrefix is the prefix used to signify registered asset metadata in Storage, keyed by the asset symbol
+	AssetPrefix Prefix = "asset-"
+	// JournalPrefix is the prefix used to signify outbound messages kept in Storage until a peer joins their channel
+	JournalPrefix Prefix = "journal-"
+	// NamespacePrefix is the prefix used to signify the client namespaces that own orders in Storage, keyed like the orders
+	NamespacePrefix Prefix = "namespace-"
+	// SchemaPrefix is the prefix used to signify the schema version of the data in Storage
+	SchemaPrefix Prefix = "schema-"
+	// SequencePrefix is the prefix used to signify the sequence number of the latest change to a channel's order book in Storage, keyed by channel
+	SequencePrefix Prefix = "sequence-"
+	// IndexPrefix is the prefix used to signify the secondary indexes of open orders in Storage, keyed by index, channel and the indexed field
+	IndexPrefix Prefix = "index-"
+	// DeadLetterPrefix is the prefix used to signify received messages that failed processing in Storage, keyed by time
+	DeadLetterPrefix Prefix = "deadletter-"
+	// CounterPrefix is the prefix used to signify the all-time totals of the node's counters in Storage, keyed by counter name
+	CounterPrefix Prefix = "counter-"
+	// AuditPrefix is the prefix used to signify the append-only log of API calls that changed orders in Storage, keyed by time
+	AuditPrefix Prefix = "audit-"
+	// BanPrefix is the prefix used to signify the makers banned from a channel by its operators in Storage, keyed by channel and maker
+	BanPrefix Prefix = "ban-"
+	// CandlePrefix is the prefix used to signify the candles aggregated from each channel's trades in Storage, keyed by channel, interval and start time
+	CandlePrefix Prefix = "candle-"
+	// ProbePrefix is the prefix used to signify the entry the self-test writes, reads back and deletes to check Storage
+	ProbePrefix Prefix = "probe-"
+	// IdlePrefix is the prefix used to signify the channels left for being idle in Storage, keyed by channel, so they can be rejoined
+	IdlePrefix Prefix = "idle-"
+	// TradePrefix is the prefix used to signify the trades recorded for market data in Storage, keyed by channel and time
+	TradePrefix Prefix = "trade-"
+	// TTLPrefix is the prefix used to signify the deadlines of the entries put with a TTL in Storage, keyed by the entry's key
+	TTLPrefix Prefix = "ttl-"
+	// ExpiryPrefix is the prefix used to signify the index of the entries put with a TTL in Storage, keyed by deadline and the entry's key
+	ExpiryPrefix Prefix = "expiry-"
+	// RelayPrefix is the prefix used to signify the relayed messages this node has handled in Storage, keyed by message
+	RelayPrefix Prefix = "relay-"
+	// OutboxPrefix is the prefix used to signify the messages of orders created on this node that haven't been broadcast yet in Storage, keyed by time
+	OutboxPrefix Prefix = "outbox-"
+	// QuarantinePrefix is the prefix used to signify received orders kept out of the order book because their ID doesn't match their content in Storage, keyed like the orders
+	QuarantinePrefix Prefix = "quarantine-"
+	// SettlementPrefix is the prefix used to signify the trades a settlement engine is still settling in Storage, keyed by engine and trade
+	SettlementPrefix Prefix = "settlement-"
 )
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/pb"
+)
+
+// StorageService is an interface to the Storage endpoints in sprawl.proto
+type StorageService interface {
+	RegisterStorage(db Storage)
+	List(ctx context.Context, in *pb.StorageListRequest) (*pb.StorageKeyList, error)
+	Dump(in *pb.StorageDumpRequest, stream pb.StorageHandler_DumpServer) error
+	Stat(ctx context.Context, in *pb.Empty) (*pb.StorageStat, error)
+}
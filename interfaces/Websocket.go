@@ -1,9 +1,13 @@
 package interfaces
 
-import "github.com/sprawl/sprawl/pb"
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/pb"
+)
 
 type WebsocketService interface {
 	Start()
 	Close()
-	PushToWebsockets(message *pb.WireMessage)
+	PushToWebsockets(ctx context.Context, message *pb.WireMessage, buf []byte)
 }
@@ -0,0 +1,19 @@
+// Package mocks has testify mocks of the interfaces in package interfaces, for unit testing code that embeds Sprawl
+// without running LevelDB or libp2p. Regenerate them with go generate after changing an interface.
+package mocks
+
+//go:generate mockery -dir .. -output . -name Storage
+//go:generate mockery -dir .. -output . -name Compactor
+//go:generate mockery -dir .. -output . -name Batcher
+//go:generate mockery -dir .. -output . -name P2p
+//go:generate mockery -dir .. -output . -name Stream
+//go:generate mockery -dir .. -output . -name Receiver
+//go:generate mockery -dir .. -output . -name SnapshotReceiver
+//go:generate mockery -dir .. -output . -name AdminService
+//go:generate mockery -dir .. -output . -name AssetService
+//go:generate mockery -dir .. -output . -name ChannelService
+//go:generate mockery -dir .. -output . -name MarketDataService
+//go:generate mockery -dir .. -output . -name NodeService
+//go:generate mockery -dir .. -output . -name OrderService
+//go:generate mockery -dir .. -output . -name StorageService
+//go:generate mockery -dir .. -output . -name WebsocketService
@@ -0,0 +1,95 @@
+// Package sprawl runs a Sprawl node inside another Go program, instead of as the sprawl binary
+package sprawl
+
+import (
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/sprawl/sprawl/app"
+	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/settlement"
+)
+
+// Node is a Sprawl node running in-process. Its services can be called directly through the embedded App, for example node.Server.Orders.
+type Node struct {
+	*app.App
+	config     interfaces.Config
+	logger     interfaces.Logger
+	appOptions []app.Option
+}
+
+// Option customizes the Node constructed by NewNode
+type Option func(*Node) error
+
+// WithConfig makes the Node use the given config instead of the defaults and SPRAWL_ environment variables
+func WithConfig(config interfaces.Config) Option {
+	return func(node *Node) error {
+		node.config = config
+		return nil
+	}
+}
+
+// WithStorage makes the Node use the given storage instead of the one selected in the config
+func WithStorage(storage interfaces.Storage) Option {
+	return func(node *Node) error {
+		node.appOptions = append(node.appOptions, app.Storage(storage))
+		return nil
+	}
+}
+
+// WithSettlement makes the Node settle fills with the given engine instead of the one registered under settlement.engine
+func WithSettlement(engine settlement.Engine) Option {
+	return func(node *Node) error {
+		node.appOptions = append(node.appOptions, app.Settlement(engine))
+		return nil
+	}
+}
+
+// WithLogger makes the Node log to the given logger. Without it, the logs are discarded.
+func WithLogger(logger interfaces.Logger) Option {
+	return func(node *Node) error {
+		node.logger = logger
+		return nil
+	}
+}
+
+// WithoutWebsocket keeps the Node from serving websockets, which the embedding program rarely needs
+func WithoutWebsocket() Option {
+	return func(node *Node) error {
+		node.appOptions = append(node.appOptions, app.NoWebsocket())
+		return nil
+	}
+}
+
+// WithHost makes the Node run on a libp2p host that is already set up and connected, like one of a mocknet.
+// The host should use the node's identity, and the node doesn't look for peers on its own.
+func WithHost(host host.Host) Option {
+	return func(node *Node) error {
+		node.appOptions = append(node.appOptions, app.Host(host))
+		return nil
+	}
+}
+
+// NewNode constructs and starts a Sprawl node with the given options. The p2p host is started right away,
+// while Run serves the gRPC API. Close shuts the node down.
+func NewNode(options ...Option) (*Node, error) {
+	node := &Node{}
+	for _, option := range options {
+		err := option(node)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Apply node option"), err)
+		}
+	}
+	if node.config == nil {
+		defaultConfig := &config.Config{}
+		defaultConfig.ReadConfig("")
+		node.config = defaultConfig
+	}
+
+	var err error
+	node.App, err = app.New(node.config, node.logger, node.appOptions...)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return node, nil
+}
@@ -0,0 +1,42 @@
+package sprawl
+
+import (
+	"testing"
+
+	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const testConfigPath = "./config/test"
+
+func TestNewNode(t *testing.T) {
+	testConfig := &config.Config{}
+	testConfig.ReadConfig(testConfigPath)
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	logger := zap.NewNop().Sugar()
+
+	node, err := NewNode(WithConfig(testConfig), WithStorage(storage), WithLogger(logger), WithoutWebsocket())
+	assert.NoError(t, err)
+	defer node.Close()
+
+	assert.Equal(t, storage, node.Storage)
+	assert.Equal(t, storage, node.Server.Orders.Storage)
+	assert.Equal(t, logger, node.Logger)
+	assert.Nil(t, node.WebsocketService)
+	assert.NotNil(t, node.P2p)
+}
+
+func TestNewNodeDefaults(t *testing.T) {
+	testConfig := &config.Config{}
+	testConfig.ReadConfig(testConfigPath)
+
+	node, err := NewNode(WithConfig(testConfig), WithStorage(&inmemory.Storage{Db: make(map[string]string)}))
+	assert.NoError(t, err)
+	defer node.Close()
+
+	assert.Equal(t, node.Logger, new(util.PlaceholderLogger))
+	assert.NotNil(t, node.WebsocketService)
+}
@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"net"
+	"strings"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/errors"
+)
+
+// addrFilter matches addresses that shouldn't be announced, either exactly or by IP range
+type addrFilter struct {
+	addrs []ma.Multiaddr
+	nets  []*net.IPNet
+}
+
+// parseMultiAddrs parses a comma separated list of multiaddresses, logging and skipping invalid ones
+func (p2p *P2p) parseMultiAddrs(list string, name string) []ma.Multiaddr {
+	mAddrs := []ma.Multiaddr{}
+	for _, addr := range strings.Split(list, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		mAddr, err := ma.NewMultiaddr(addr)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Errorf("%s %s is invalid: %s", name, addr, err)
+			continue
+		}
+		mAddrs = append(mAddrs, mAddr)
+	}
+	return mAddrs
+}
+
+// noAnnounceFilter reads p2p.noAnnounce, where entries are either multiaddresses or IP ranges in CIDR notation
+func (p2p *P2p) noAnnounceFilter() *addrFilter {
+	filter := &addrFilter{}
+	for _, entry := range strings.Split(p2p.Config.GetNoAnnounce(), ",") {
+		entry = strings.TrimSpace(entry)
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			filter.nets = append(filter.nets, ipNet)
+			continue
+		}
+		filter.addrs = append(filter.addrs, p2p.parseMultiAddrs(entry, "No announce address")...)
+	}
+	return filter
+}
+
+func addrIP(addr ma.Multiaddr) net.IP {
+	for _, code := range []int{ma.P_IP4, ma.P_IP6} {
+		if value, err := addr.ValueForProtocol(code); err == nil {
+			return net.ParseIP(value)
+		}
+	}
+	return nil
+}
+
+func (filter *addrFilter) matches(addr ma.Multiaddr) bool {
+	for _, filtered := range filter.addrs {
+		if addr.Equal(filtered) {
+			return true
+		}
+	}
+	ip := addrIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range filter.nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// announceAddrsFactory creates the libp2p AddrsFactory that decides which addresses are announced to other peers and the DHT.
+// Fixed addresses, or p2p.announceAddresses if it's set, replace the listened ones. Anything matching p2p.noAnnounce is left out.
+func (p2p *P2p) announceAddrsFactory(fixed []ma.Multiaddr) func([]ma.Multiaddr) []ma.Multiaddr {
+	if announce := p2p.parseMultiAddrs(p2p.Config.GetAnnounceAddresses(), "Announce address"); len(announce) > 0 {
+		fixed = announce
+	}
+	filter := p2p.noAnnounceFilter()
+	return func(addrs []ma.Multiaddr) []ma.Multiaddr {
+		if fixed != nil {
+			addrs = fixed
+		}
+		announced := make([]ma.Multiaddr, 0, len(addrs))
+		for _, addr := range addrs {
+			if !filter.matches(addr) {
+				announced = append(announced, addr)
+			}
+		}
+		return announced
+	}
+}
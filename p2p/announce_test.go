@@ -0,0 +1,82 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"os"
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	config "github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsAnnounceAddresses string = "SPRAWL_P2P_ANNOUNCEADDRESSES"
+const optionsNoAnnounce string = "SPRAWL_P2P_NOANNOUNCE"
+const optionsListenAddresses string = "SPRAWL_P2P_LISTENADDRESSES"
+const optionsNATPortMap string = "SPRAWL_P2P_ENABLENATPORTMAP"
+const testPublicAddr string = "/ip4/1.2.3.4/tcp/4001"
+const testDockerAddr string = "/ip4/172.17.0.2/tcp/4001"
+const testPrivateAddr string = "/ip4/192.168.1.10/tcp/4001"
+const testLoopbackAddr string = "/ip4/127.0.0.1/tcp/4001"
+
+func newAnnounceTestP2p(t *testing.T) *P2p {
+	privateKey, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	announceConfig := &config.Config{}
+	announceConfig.ReadConfig(testConfigPath)
+	return NewP2p(announceConfig, privateKey, publicKey, Logger(new(util.PlaceholderLogger)))
+}
+
+func parseTestAddrs(t *testing.T, addrs ...string) []ma.Multiaddr {
+	mAddrs := []ma.Multiaddr{}
+	for _, addr := range addrs {
+		mAddr, err := ma.NewMultiaddr(addr)
+		assert.NoError(t, err)
+		mAddrs = append(mAddrs, mAddr)
+	}
+	return mAddrs
+}
+
+func TestNoAnnounce(t *testing.T) {
+	defer os.Unsetenv(optionsNoAnnounce)
+	os.Setenv(optionsNoAnnounce, "172.16.0.0/12, 192.168.0.0/16,"+testLoopbackAddr+",not-a-multiaddr")
+
+	factory := newAnnounceTestP2p(t).announceAddrsFactory(nil)
+	announced := factory(parseTestAddrs(t, testPublicAddr, testDockerAddr, testPrivateAddr, testLoopbackAddr))
+	assert.Len(t, announced, 1)
+	assert.True(t, announced[0].Equal(parseTestAddrs(t, testPublicAddr)[0]))
+}
+
+func TestAnnounceAddresses(t *testing.T) {
+	defer os.Unsetenv(optionsAnnounceAddresses)
+
+	// Without any configuration, everything is announced
+	listened := parseTestAddrs(t, testPublicAddr, testDockerAddr)
+	assert.Len(t, newAnnounceTestP2p(t).announceAddrsFactory(nil)(listened), 2)
+
+	os.Setenv(optionsAnnounceAddresses, testPublicAddr)
+	announced := newAnnounceTestP2p(t).announceAddrsFactory(nil)(parseTestAddrs(t, testDockerAddr))
+	assert.Len(t, announced, 1)
+	assert.True(t, announced[0].Equal(listened[0]))
+}
+
+func TestListenAddresses(t *testing.T) {
+	defer os.Unsetenv(optionsListenAddresses)
+	defer os.Unsetenv(optionsNATPortMap)
+	os.Setenv(optionsListenAddresses, "/ip4/127.0.0.1/tcp/0")
+	os.Setenv(optionsNATPortMap, "false")
+
+	p2pInstance := newAnnounceTestP2p(t)
+	p2pInstance.Run()
+	defer p2pInstance.Close()
+
+	// The OS assigned port is reported instead of 0
+	listened := p2pInstance.GetListenAddresses()
+	assert.Len(t, listened, 1)
+	port, err := parseTestAddrs(t, listened[0])[0].ValueForProtocol(ma.P_TCP)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "0", port)
+	assert.Contains(t, p2pInstance.GetAnnouncedAddresses(), listened[0])
+}
@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	ma "github.com/multiformats/go-multiaddr"
+	madns "github.com/multiformats/go-multiaddr-dns"
+	"github.com/sprawl/sprawl/errors"
+)
+
+const bootstrapResolveTimeout time.Duration = 30 * time.Second
+
+// bootstrapPeers returns the configured bootstrap addresses, and the IPFS bootstrap peers if they're enabled
+func (p2p *P2p) bootstrapPeers() []ma.Multiaddr {
+	peers := []ma.Multiaddr{}
+	if p2p.Config.GetIPFSPeerSetting() {
+		peers = append(peers, dht.DefaultBootstrapPeers...)
+	}
+	return append(peers, p2p.parseMultiAddrs(p2p.Config.GetBootstrapPeers(), "Bootstrap peer multiaddress")...)
+}
+
+// resolveBootstrapPeers resolves any /dnsaddr/ bootstrap addresses and groups the addresses by peer
+func (p2p *P2p) resolveBootstrapPeers(ctx context.Context) []peer.AddrInfo {
+	resolved := []ma.Multiaddr{}
+	for _, peerAddr := range p2p.bootstrapPeers() {
+		if !madns.Matches(peerAddr) {
+			resolved = append(resolved, peerAddr)
+			continue
+		}
+		resolveCtx, cancel := context.WithTimeout(ctx, bootstrapResolveTimeout)
+		addrs, err := madns.Resolve(resolveCtx, peerAddr)
+		cancel()
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Errorf("Resolving bootstrap peer %s failed: %s", peerAddr, err)
+			continue
+		}
+		resolved = append(resolved, addrs...)
+	}
+
+	peerinfos, err := peer.AddrInfosFromP2pAddrs(resolved...)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Errorf("Bootstrap peer multiaddresses %s are invalid: %s", resolved, err)
+	}
+	return peerinfos
+}
+
+// connectToBootstrapPeers connects to all bootstrap peers that this node isn't already connected to
+func (p2p *P2p) connectToBootstrapPeers() {
+	p2p.connectToPeers(p2p.resolveBootstrapPeers(p2p.ctx))
+}
+
+// connectToPeers connects to the given peers in parallel, skipping the ones this node is already connected to
+func (p2p *P2p) connectToPeers(peerinfos []peer.AddrInfo) {
+	var wg sync.WaitGroup
+	for _, peerinfo := range peerinfos {
+		if p2p.host.Network().Connectedness(peerinfo.ID) == network.Connected {
+			continue
+		}
+		wg.Add(1)
+		go func(peerinfo peer.AddrInfo) {
+			defer wg.Done()
+			if err := p2p.host.Connect(p2p.ctx, peerinfo); !errors.IsEmpty(err) {
+				p2p.Logger.Debugf("Error connecting to bootstrap peer %s", err)
+			} else {
+				p2p.Logger.Debugf("Successfully connected to bootstrap peer %s", peerinfo)
+			}
+		}(peerinfo)
+	}
+	wg.Wait()
+}
+
+// refreshBootstrapPeers periodically resolves the bootstrap addresses again,
+// so the node keeps finding the network when bootstrap peers change their IPs
+func (p2p *P2p) refreshBootstrapPeers(interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p2p.connectToBootstrapPeers()
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"testing"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	ma "github.com/multiformats/go-multiaddr"
+	config "github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsBootstrapPeers string = "SPRAWL_P2P_BOOTSTRAPPEERS"
+const optionsUseIPFSPeers string = "SPRAWL_P2P_USEIPFSPEERS"
+const testBootstrapPeer string = "/ip4/127.0.0.1/tcp/4001/p2p/QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN"
+
+func TestBootstrapPeers(t *testing.T) {
+	defer os.Unsetenv(optionsBootstrapPeers)
+	defer os.Unsetenv(optionsUseIPFSPeers)
+
+	privateKey, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+
+	bootstrapConfig := &config.Config{}
+	os.Setenv(optionsBootstrapPeers, testBootstrapPeer+", not-a-multiaddr,")
+	bootstrapConfig.ReadConfig(testConfigPath)
+	p2pInstance := NewP2p(bootstrapConfig, privateKey, publicKey, Logger(new(util.PlaceholderLogger)))
+
+	peers := p2pInstance.bootstrapPeers()
+	assert.Len(t, peers, 1)
+	expectedAddr, err := ma.NewMultiaddr(testBootstrapPeer)
+	assert.NoError(t, err)
+	assert.True(t, expectedAddr.Equal(peers[0]))
+
+	peerinfos := p2pInstance.resolveBootstrapPeers(context.Background())
+	assert.Len(t, peerinfos, 1)
+	expectedID, err := peer.IDB58Decode("QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedID, peerinfos[0].ID)
+
+	os.Setenv(optionsUseIPFSPeers, "true")
+	bootstrapConfig.ReadConfig(testConfigPath)
+	assert.Len(t, p2pInstance.bootstrapPeers(), len(dht.DefaultBootstrapPeers)+1)
+}
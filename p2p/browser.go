@@ -0,0 +1,36 @@
+package p2p
+
+import (
+	"strconv"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/sprawl/sprawl/errors"
+)
+
+// browserMultiAddr returns the websocket address browsers connect to, using libp2p's default websocket transport
+func (p2p *P2p) browserMultiAddr(ip string) []ma.Multiaddr {
+	addr, err := createWebsocketMultiAddr(ip, strconv.FormatUint(uint64(p2p.Config.GetBrowserPort()), 10))
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Creating websocket multiaddr"), err))
+		return nil
+	}
+	return []ma.Multiaddr{addr}
+}
+
+// isBrowserPeer tells if all connections to the peer are websocket connections, as browsers can't connect otherwise
+func (p2p *P2p) isBrowserPeer(peerID peer.ID) bool {
+	if p2p.host == nil {
+		return false
+	}
+	conns := p2p.host.Network().ConnsToPeer(peerID)
+	if len(conns) == 0 {
+		return false
+	}
+	for _, conn := range conns {
+		if _, err := conn.RemoteMultiaddr().ValueForProtocol(ma.P_WS); err != nil {
+			return false
+		}
+	}
+	return true
+}
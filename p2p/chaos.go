@@ -0,0 +1,57 @@
+package p2p
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// chaos injects faults into the network for resilience tests: it delays and drops received messages
+// and resets streams instead of writing to them. A nil chaos injects nothing.
+type chaos struct {
+	latency            time.Duration
+	dropPercent        int
+	closeStreamPercent int
+}
+
+// newChaos returns the fault injection set in the config, or nil if there's none
+func newChaos(config interfaces.Config) *chaos {
+	if config == nil {
+		return nil
+	}
+	c := &chaos{
+		latency:            time.Duration(config.GetChaosLatency()) * time.Millisecond,
+		dropPercent:        int(config.GetChaosDropPercent()),
+		closeStreamPercent: int(config.GetChaosCloseStreamPercent()),
+	}
+	if c.latency == 0 && c.dropPercent == 0 && c.closeStreamPercent == 0 {
+		return nil
+	}
+	return c
+}
+
+func chance(percent int) bool {
+	return percent > 0 && rand.Intn(100) < percent
+}
+
+// delay waits for the configured latency and tells if the message should be dropped after all
+func (c *chaos) delay() (drop bool) {
+	if c == nil {
+		return false
+	}
+	time.Sleep(c.latency)
+	return chance(c.dropPercent)
+}
+
+// closesStream tells if the next write to a stream should reset it instead
+func (c *chaos) closesStream() bool {
+	return c != nil && chance(c.closeStreamPercent)
+}
+
+// resetStream resets a stream on behalf of chaos, so its peer sees a broken connection
+func (c *chaos) resetStream(stream *Stream) error {
+	stream.stream.Reset()
+	return errors.E(errors.Op("Write to stream"), "stream reset by chaos mode")
+}
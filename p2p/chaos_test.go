@@ -0,0 +1,47 @@
+package p2p
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsChaosLatency string = "SPRAWL_P2P_CHAOS_LATENCY"
+const optionsChaosDropPercent string = "SPRAWL_P2P_CHAOS_DROPPERCENT"
+
+func TestChaosDisabled(t *testing.T) {
+	c := newChaos(testConfig)
+	assert.Nil(t, c)
+	assert.False(t, c.delay())
+	assert.False(t, c.closesStream())
+}
+
+func TestChaos(t *testing.T) {
+	defer os.Unsetenv(optionsChaosLatency)
+	defer os.Unsetenv(optionsChaosDropPercent)
+	os.Setenv(optionsChaosLatency, "10")
+	os.Setenv(optionsChaosDropPercent, "100")
+	chaosConfig := &config.Config{}
+	chaosConfig.ReadConfig(testConfigPath)
+
+	c := newChaos(chaosConfig)
+	assert.NotNil(t, c)
+	start := time.Now()
+	assert.True(t, c.delay())
+	assert.True(t, time.Since(start) >= 10*time.Millisecond)
+	assert.False(t, c.closesStream())
+
+	// Every message is dropped before it reaches the receiver
+	p2pInstance := NewP2p(chaosConfig, privateKey, publicKey, Logger(log))
+	receiver := new(TestReceiver)
+	receiver.Test(t)
+	p2pInstance.AddReceiver(receiver)
+	assert.NoError(t, p2pInstance.receive(context.Background(), interfaces.IncomingMessage{From: peer.ID("peer"), Data: []byte("message")}))
+	receiver.AssertNotCalled(t, "Receive", []byte("message"))
+}
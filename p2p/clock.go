@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+// minClockSamples is how many peers have to be measured before the local clock is judged
+const minClockSamples int = 3
+
+// maxClockSamples limits how many peer clocks are remembered
+const maxClockSamples int = 64
+
+// clock keeps how far each peer's clock was from the local one during its latest handshake.
+// The median of the offsets estimates how far off the local clock is from the network.
+type clock struct {
+	offsets map[peer.ID]time.Duration
+	skewed  bool
+	lock    sync.Mutex
+}
+
+func newClock() *clock {
+	return &clock{offsets: make(map[peer.ID]time.Duration)}
+}
+
+// record stores the offset of a peer's clock and returns the new median offset and how many peers it's from
+func (c *clock) record(peerID peer.ID, offset time.Duration) (time.Duration, int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.offsets[peerID]; !ok && len(c.offsets) >= maxClockSamples {
+		for oldPeer := range c.offsets {
+			delete(c.offsets, oldPeer)
+			break
+		}
+	}
+	c.offsets[peerID] = offset
+	return c.median()
+}
+
+func (c *clock) median() (time.Duration, int) {
+	if len(c.offsets) == 0 {
+		return 0, 0
+	}
+	offsets := make([]time.Duration, 0, len(c.offsets))
+	for _, offset := range c.offsets {
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	middle := len(offsets) / 2
+	if len(offsets)%2 == 0 {
+		return (offsets[middle-1] + offsets[middle]) / 2, len(offsets)
+	}
+	return offsets[middle], len(offsets)
+}
+
+// GetClockSkew returns how far ahead the clocks of peers are from the local clock, as the median of the offsets
+// measured during handshakes, and how many peers have been measured
+func (p2p *P2p) GetClockSkew() (time.Duration, int) {
+	p2p.clock.lock.Lock()
+	defer p2p.clock.lock.Unlock()
+	return p2p.clock.median()
+}
+
+// recordClockOffset compares the time a peer sent in its handshake with the local time it was sent at.
+// A warning is logged once the local clock is further off from the median of enough peers than orders.maxClockSkew.
+func (p2p *P2p) recordClockOffset(peerID peer.ID, remoteTime *timestamp.Timestamp, localTime time.Time) {
+	// Nodes older than the time field don't send it
+	if remoteTime == nil {
+		return
+	}
+	sent, err := ptypes.Timestamp(remoteTime)
+	if err != nil {
+		return
+	}
+	skew, samples := p2p.clock.record(peerID, sent.Sub(localTime))
+	if samples < minClockSamples || p2p.Config.GetMaxClockSkew() == 0 {
+		return
+	}
+
+	maxSkew := time.Duration(p2p.Config.GetMaxClockSkew()) * time.Second
+	skewed := skew > maxSkew || skew < -maxSkew
+	p2p.clock.lock.Lock()
+	changed := skewed != p2p.clock.skewed
+	p2p.clock.skewed = skewed
+	p2p.clock.lock.Unlock()
+	if changed && skewed && skew > 0 {
+		p2p.Logger.Warnf("The local clock is %s behind the median of %d peers, check that it's synchronized", skew, samples)
+	} else if changed && skewed {
+		p2p.Logger.Warnf("The local clock is %s ahead of the median of %d peers, check that it's synchronized", -skew, samples)
+	} else if changed {
+		p2p.Logger.Infof("The local clock is back within %s of the median of %d peers", maxSkew, samples)
+	}
+}
@@ -0,0 +1,37 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockMedian(t *testing.T) {
+	c := newClock()
+	skew, samples := c.median()
+	assert.Equal(t, time.Duration(0), skew)
+	assert.Equal(t, 0, samples)
+
+	c.record(peer.ID("a"), 10*time.Second)
+	c.record(peer.ID("b"), -time.Hour)
+	skew, samples = c.record(peer.ID("c"), 20*time.Second)
+	assert.Equal(t, 10*time.Second, skew)
+	assert.Equal(t, 3, samples)
+
+	// A peer's new offset replaces its old one
+	skew, samples = c.record(peer.ID("b"), 30*time.Second)
+	assert.Equal(t, 20*time.Second, skew)
+	assert.Equal(t, 3, samples)
+
+	skew, samples = c.record(peer.ID("d"), 40*time.Second)
+	assert.Equal(t, 25*time.Second, skew)
+	assert.Equal(t, 4, samples)
+
+	for i := 0; i < maxClockSamples*2; i++ {
+		_, samples = c.record(peer.ID(fmt.Sprintf("peer%d", i)), 0)
+	}
+	assert.Equal(t, maxClockSamples, samples)
+}
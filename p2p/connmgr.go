@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"time"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	libp2pConfig "github.com/libp2p/go-libp2p/config"
+)
+
+// channelTagPrefix prefixes the connection manager tags of peers we trade with on a channel
+const channelTagPrefix string = "sprawl-channel-"
+
+func getChannelTag(channelID []byte) string {
+	return channelTagPrefix + string(channelID)
+}
+
+// connectionManager trims connections down to the low watermark once there are more than the high watermark.
+// It returns nil if the high watermark isn't set.
+func (p2p *P2p) connectionManager() libp2pConfig.Option {
+	high := p2p.Config.GetConnHigh()
+	if high == 0 {
+		return nil
+	}
+	low := p2p.Config.GetConnLow()
+	if low > high {
+		low = high
+	}
+	gracePeriod := time.Duration(p2p.Config.GetConnGracePeriod()) * time.Second
+	return libp2p.ConnectionManager(connmgr.NewConnManager(int(low), int(high), gracePeriod))
+}
+
+// atConnectionLimit tells if there are so many connections that dialing new peers would only get them trimmed
+func (p2p *P2p) atConnectionLimit() bool {
+	high := p2p.Config.GetConnHigh()
+	return high > 0 && uint(len(p2p.host.Network().Peers())) >= high
+}
+
+// protectPeer keeps the connection manager from trimming a peer that has sent valid messages on the channel
+func (p2p *P2p) protectPeer(channelID []byte, peerID peer.ID) {
+	p2p.host.ConnManager().Protect(peerID, getChannelTag(channelID))
+}
+
+// unprotectChannel lets the connection manager trim the peers of a channel that has been left
+func (p2p *P2p) unprotectChannel(channelID []byte) {
+	tag := getChannelTag(channelID)
+	for _, peerID := range p2p.host.Peerstore().Peers() {
+		p2p.host.ConnManager().Unprotect(peerID, tag)
+	}
+}
@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"os"
+	"testing"
+
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsConnHigh string = "SPRAWL_P2P_CONNHIGH"
+const otherChannelID string = "otherChannel"
+
+func TestConnectionManager(t *testing.T) {
+	defer os.Unsetenv(optionsListenAddresses)
+	defer os.Unsetenv(optionsNATPortMap)
+	os.Setenv(optionsListenAddresses, "/ip4/127.0.0.1/tcp/0")
+	os.Setenv(optionsNATPortMap, "false")
+
+	p2pInstance := newAnnounceTestP2p(t)
+	p2pInstance.InitHost(p2pInstance.CreateOptions()...)
+	defer p2pInstance.host.Close()
+	connManager, ok := p2pInstance.host.ConnManager().(*connmgr.BasicConnMgr)
+	assert.True(t, ok)
+	assert.False(t, p2pInstance.atConnectionLimit())
+
+	_, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	assert.NoError(t, p2pInstance.host.Peerstore().AddPubKey(peerID, publicKey))
+
+	// A peer stays protected until it's been unprotected on every channel
+	p2pInstance.protectPeer(testChannel.GetId(), peerID)
+	p2pInstance.protectPeer([]byte(otherChannelID), peerID)
+	assert.True(t, connManager.Unprotect(peerID, getChannelTag([]byte(otherChannelID))))
+	p2pInstance.protectPeer([]byte(otherChannelID), peerID)
+	p2pInstance.unprotectChannel(testChannel.GetId())
+	assert.False(t, connManager.Unprotect(peerID, getChannelTag([]byte(otherChannelID))))
+}
+
+func TestConnectionManagerDisabled(t *testing.T) {
+	defer os.Unsetenv(optionsConnHigh)
+	os.Setenv(optionsConnHigh, "0")
+
+	p2pInstance := newAnnounceTestP2p(t)
+	assert.Nil(t, p2pInstance.connectionManager())
+}
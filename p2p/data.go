@@ -2,9 +2,11 @@ package p2p
 
 import (
 	"context"
+	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
 )
 
@@ -22,9 +24,17 @@ func (p2p *P2p) listenToChannel(ctx context.Context, sub *pubsub.Subscription, c
 
 			if peer != p2p.host.ID() {
 				if p2p.Receiver != nil {
-					err = p2p.Receiver.Receive(data, peer)
+					err = p2p.receive(ctx, interfaces.IncomingMessage{
+						From:       peer,
+						ChannelID:  channel.GetId(),
+						Transport:  interfaces.TransportGossip,
+						ReceivedAt: time.Now(),
+						Data:       data,
+					})
 					if !errors.IsEmpty(err) {
 						p2p.Logger.Error(errors.E(errors.Op("Receive data"), err))
+					} else {
+						p2p.protectPeer(channel.GetId(), peer)
 					}
 				} else {
 					p2p.Logger.Warn("Receiver not registered with p2p, not parsing any incoming data!")
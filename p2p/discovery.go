@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	"github.com/sprawl/sprawl/errors"
+)
+
+// discoveryJitter is the largest fraction of the discovery interval added to or taken from each wait,
+// so nodes started together don't all query the DHT at the same time
+const discoveryJitter float64 = 0.2
+
+// jitter returns the interval moved randomly by up to discoveryJitter of it in either direction
+func jitter(interval time.Duration) time.Duration {
+	return interval + time.Duration((rand.Float64()*2-1)*discoveryJitter*float64(interval))
+}
+
+func (p2p *P2p) startDiscovery() {
+	// Add Kademlia routing discovery
+	p2p.routingDiscovery = discovery.NewRoutingDiscovery(p2p.kademliaDHT)
+
+	interval := time.Duration(p2p.Config.GetDiscoveryInterval()) * time.Minute
+	if interval == 0 {
+		// The advertiser service advertises again when the advertisement expires, but peers are only looked for once
+		discovery.Advertise(p2p.ctx, p2p.routingDiscovery, p2p.rendezvous())
+
+		var err error
+		// Ingest newly found peers into p2p.peerChan
+		p2p.peerChan, err = p2p.routingDiscovery.FindPeers(p2p.ctx, p2p.rendezvous())
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Error(errors.E(errors.Op("Find peers"), err))
+		}
+		return
+	}
+
+	peers := make(chan peer.AddrInfo)
+	p2p.peerChan = peers
+	p2p.discoveryDone = make(chan struct{})
+	go p2p.refreshDiscovery(peers, interval, p2p.discoveryDone)
+}
+
+// refreshDiscovery advertises the rendezvous string and looks for peers on it again every interval, with jitter,
+// so long running nodes keep finding peers that join later
+func (p2p *P2p) refreshDiscovery(peers chan<- peer.AddrInfo, interval time.Duration, done chan struct{}) {
+	defer close(peers)
+	for {
+		ctx, cancel := context.WithTimeout(p2p.ctx, interval)
+		_, err := p2p.routingDiscovery.Advertise(ctx, p2p.rendezvous())
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Warn(errors.E(errors.Op("Advertise"), err))
+		}
+		p2p.findPeers(ctx, peers, done)
+		cancel()
+
+		select {
+		case <-time.After(jitter(interval)):
+		case <-done:
+			return
+		}
+	}
+}
+
+// findPeers passes the peers found on the rendezvous string to peers, skipping the ones this node is already connected to
+func (p2p *P2p) findPeers(ctx context.Context, peers chan<- peer.AddrInfo, done chan struct{}) {
+	found, err := p2p.routingDiscovery.FindPeers(ctx, p2p.rendezvous())
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Warn(errors.E(errors.Op("Find peers"), err))
+		return
+	}
+	for info := range found {
+		if p2p.host.Network().Connectedness(info.ID) == network.Connected {
+			continue
+		}
+		select {
+		case peers <- info:
+		case <-done:
+			return
+		}
+	}
+}
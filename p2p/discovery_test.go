@@ -0,0 +1,17 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitter(t *testing.T) {
+	interval := 10 * time.Minute
+	for i := 0; i < 100; i++ {
+		wait := jitter(interval)
+		assert.True(t, wait >= 8*time.Minute, wait)
+		assert.True(t, wait <= 12*time.Minute, wait)
+	}
+}
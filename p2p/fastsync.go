@@ -0,0 +1,194 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/database/backup"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// fastSyncVersion is the version of the fast-sync protocol, whose ID is made by fastSyncProtocolID
+const fastSyncVersion = "1.0.0"
+
+// fastSyncTimeout is how long a single snapshot download may take
+const fastSyncTimeout = 10 * time.Minute
+
+// maxChannelIDLength bounds the channel ID of a fast-sync request
+const maxChannelIDLength = 1024
+
+// getFastSyncPeers returns the trusted peers configured for fast-sync, skipping the invalid IDs
+func (p2p *P2p) getFastSyncPeers() []peer.ID {
+	peerIDs := []peer.ID{}
+	for _, id := range strings.Split(p2p.Config.GetFastSyncPeers(), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		peerID, err := peer.IDB58Decode(id)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Warn(errors.E(errors.Op("Parse fast-sync peer "+id), err))
+			continue
+		}
+		peerIDs = append(peerIDs, peerID)
+	}
+	return peerIDs
+}
+
+// writeSnapshot writes the open orders of a channel into w as a checksummed snapshot.
+// Only the current state of each order is stored, so the snapshot is already compacted.
+func (p2p *P2p) writeSnapshot(ctx context.Context, w io.Writer, channelID []byte) error {
+	orders, err := p2p.storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix)+string(channelID))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get orders for snapshot"), err)
+	}
+	writer, err := backup.NewWriter(w)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	for key, value := range orders {
+		err = writer.Write([]byte(key), []byte(value))
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// readSnapshot reads the orders of a channel from a snapshot written by writeSnapshot.
+// Nothing is returned unless the whole snapshot passes its checksum.
+func readSnapshot(r io.Reader, channelID []byte) ([][]byte, error) {
+	prefix := string(interfaces.OrderPrefix) + string(channelID)
+	orders := [][]byte{}
+	err := backup.Read(r, func(key []byte, value []byte) error {
+		if !strings.HasPrefix(string(key), prefix) {
+			return errors.E(errors.Op("Check snapshot key"), errors.Malformed, "snapshot has an entry outside of the channel")
+		}
+		orders = append(orders, value)
+		return nil
+	})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read snapshot"), err)
+	}
+	return orders, nil
+}
+
+// handleFastSync answers a fast-sync request with a snapshot of a joined channel
+func (p2p *P2p) handleFastSync(stream network.Stream) {
+	defer stream.Close()
+	remotePeer := stream.Conn().RemotePeer()
+	stream.SetDeadline(time.Now().Add(fastSyncTimeout))
+
+	reader := bufio.NewReader(stream)
+	length, err := binary.ReadUvarint(reader)
+	if err != nil || length > maxChannelIDLength {
+		p2p.Logger.Debugf("Invalid fast-sync request from %s", remotePeer)
+		stream.Reset()
+		return
+	}
+	channelID := make([]byte, length)
+	_, err = io.ReadFull(reader, channelID)
+	if err != nil {
+		stream.Reset()
+		return
+	}
+
+	p2p.subLock.RLock()
+	_, joined := p2p.subscriptions[string(channelID)]
+	p2p.subLock.RUnlock()
+	if !joined || p2p.storage == nil {
+		p2p.Logger.Debugf("Refusing fast-sync of channel %s to %s, the channel isn't joined", string(channelID), remotePeer)
+		stream.Reset()
+		return
+	}
+
+	err = p2p.writeSnapshot(p2p.ctx, stream, channelID)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Write snapshot to "+remotePeer.String()), err))
+		stream.Reset()
+		return
+	}
+	p2p.Logger.Debugf("Sent a snapshot of channel %s to %s", string(channelID), remotePeer)
+}
+
+// downloadSnapshot requests the snapshot of a channel from a peer and returns its orders
+func (p2p *P2p) downloadSnapshot(ctx context.Context, peerID peer.ID, channelID []byte) ([][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, fastSyncTimeout)
+	defer cancel()
+	stream, err := p2p.host.NewStream(ctx, peerID, p2p.fastSyncProtocolID())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Open fast-sync stream"), err)
+	}
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(fastSyncTimeout))
+
+	request := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(request, uint64(len(channelID)))
+	_, err = stream.Write(append(request[:n], channelID...))
+	if err != nil {
+		stream.Reset()
+		return nil, errors.E(errors.Op("Write fast-sync request"), err)
+	}
+
+	orders, err := readSnapshot(stream, channelID)
+	if !errors.IsEmpty(err) {
+		stream.Reset()
+		return nil, err
+	}
+	return orders, nil
+}
+
+// fastSync downloads the open orders of a newly joined channel from the first trusted peer
+// that has them. It returns false if no trusted peer could provide a snapshot.
+func (p2p *P2p) fastSync(ctx context.Context, channelID []byte) bool {
+	receiver, ok := p2p.Receiver.(interfaces.SnapshotReceiver)
+	if !ok {
+		return false
+	}
+	for _, peerID := range p2p.getFastSyncPeers() {
+		if peerID == p2p.host.ID() {
+			continue
+		}
+		orders, err := p2p.downloadSnapshot(ctx, peerID, channelID)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Warn(errors.E(errors.Op("Fast-sync from "+peerID.String()), err))
+			continue
+		}
+		err = receiver.ReceiveSnapshot(channelID, orders, peerID)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Warn(errors.E(errors.Op("Store snapshot from "+peerID.String()), err))
+			continue
+		}
+		p2p.Logger.Infof("Fast-synced %d orders on channel %s from %s", len(orders), string(channelID), peerID)
+		return true
+	}
+	return false
+}
+
+// syncChannel fills the order book of a newly joined channel as deep as history asks, from a snapshot of a trusted peer
+// if fast-sync is configured and otherwise by asking the first peer that joins to replay its orders.
+// Snapshots only have the open orders, so the full history is always asked from a peer.
+func (p2p *P2p) syncChannel(ctx context.Context, topicString string, topic *pubsub.Topic, history pb.History) {
+	if history == pb.History_NONE {
+		p2p.Logger.Debugf("Not syncing channel %s, only new orders are received", topicString)
+		return
+	}
+	if history == pb.History_FULL || len(p2p.getFastSyncPeers()) == 0 {
+		p2p.requestSync(ctx, topicString, topic, history)
+		return
+	}
+	go func() {
+		if !p2p.fastSync(ctx, []byte(topicString)) {
+			p2p.requestSync(ctx, topicString, topic, history)
+		}
+	}()
+}
@@ -0,0 +1,53 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/backup"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsFastSyncPeers string = "SPRAWL_P2P_FASTSYNCPEERS"
+
+func TestSnapshot(t *testing.T) {
+	p2pInstance := newJournalTestP2p(t)
+	ctx := context.Background()
+	orderKey := []byte(string(interfaces.OrderPrefix) + string(testChannel.GetId()) + "order")
+	assert.NoError(t, p2pInstance.storage.Put(ctx, orderKey, []byte("order")))
+	assert.NoError(t, p2pInstance.storage.Put(ctx, []byte(string(interfaces.HistoryPrefix)+string(testChannel.GetId())+"old"), []byte("old")))
+
+	var snapshot bytes.Buffer
+	assert.NoError(t, p2pInstance.writeSnapshot(ctx, &snapshot, testChannel.GetId()))
+	orders, err := readSnapshot(bytes.NewReader(snapshot.Bytes()), testChannel.GetId())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("order")}, orders)
+
+	// A corrupted snapshot is rejected as a whole
+	corrupted := append([]byte{}, snapshot.Bytes()...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	_, err = readSnapshot(bytes.NewReader(corrupted), testChannel.GetId())
+	assert.False(t, errors.IsEmpty(err))
+
+	// So is a snapshot with entries of another channel
+	var foreign bytes.Buffer
+	writer, err := backup.NewWriter(&foreign)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Write([]byte(string(interfaces.OrderPrefix)+"other"), []byte("order")))
+	assert.NoError(t, writer.Close())
+	_, err = readSnapshot(bytes.NewReader(foreign.Bytes()), testChannel.GetId())
+	assert.True(t, errors.Is(errors.Malformed, err))
+}
+
+func TestGetFastSyncPeers(t *testing.T) {
+	os.Setenv(optionsFastSyncPeers, "QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN, invalid")
+	defer os.Unsetenv(optionsFastSyncPeers)
+	p2pInstance := newJournalTestP2p(t)
+	peerIDs := p2pInstance.getFastSyncPeers()
+	assert.Len(t, peerIDs, 1)
+	assert.Equal(t, "QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN", peerIDs[0].Pretty())
+}
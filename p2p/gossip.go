@@ -0,0 +1,233 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// configureGossip sets the gossipsub mesh degree and heartbeat interval from the config.
+// gossipsub reads them from package variables, so they apply to every router created in this process afterwards.
+func (p2p *P2p) configureGossip() {
+	d := int(p2p.Config.GetGossipD())
+	dlo := int(p2p.Config.GetGossipDlo())
+	dhi := int(p2p.Config.GetGossipDhi())
+	if dlo > d || d > dhi || d == 0 {
+		p2p.Logger.Warnf("Invalid gossip degrees dlo %d, d %d and dhi %d, using the defaults", dlo, d, dhi)
+	} else {
+		pubsub.GossipSubD = d
+		pubsub.GossipSubDlo = dlo
+		pubsub.GossipSubDhi = dhi
+	}
+
+	if interval := p2p.Config.GetGossipHeartbeatInterval(); interval > 0 {
+		pubsub.GossipSubHeartbeatInterval = time.Duration(interval) * time.Millisecond
+	}
+}
+
+// shouldFloodPublish tells if a channel is small enough that messages are sent to all of its peers instead of just the mesh
+func (p2p *P2p) shouldFloodPublish(peerCount int) bool {
+	return peerCount > 0 && uint(peerCount) < p2p.Config.GetFloodPublishPeers()
+}
+
+// floodPublish sends a published message straight to every peer of a small channel.
+// The peers get it from the mesh as well, but that's a duplicate the receiver ignores.
+func (p2p *P2p) floodPublish(peers []peer.ID, data []byte) {
+	if !p2p.shouldFloodPublish(len(peers)) {
+		return
+	}
+	for _, peerID := range peers {
+		// Browser peers can't open Sprawl streams
+		if p2p.isBrowserPeer(peerID) {
+			continue
+		}
+		go func(peerID peer.ID) {
+			err := p2p.sendDirect(peerID, data)
+			if !errors.IsEmpty(err) {
+				p2p.Logger.Debug(errors.E(errors.Op("Flood publish to "+peerID.String()), err))
+			}
+		}(peerID)
+	}
+}
+
+// sendDirect writes a single message to a peer over a stream of its own
+func (p2p *P2p) sendDirect(peerID peer.ID, data []byte) error {
+	stream, err := p2p.openStream(peerID)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Open a stream"), err)
+	}
+	defer stream.stream.Close()
+	err = stream.WriteToStream(data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Write to stream"), err)
+	}
+	return nil
+}
+
+// checkMessageSize tells if a message is larger than the configured maximum message size
+func (p2p *P2p) checkMessageSize(data []byte) error {
+	maxSize := p2p.Config.GetMaxMessageSize()
+	if maxSize > 0 && uint(len(data)) > maxSize {
+		return errors.E(errors.Op("Check message size"), errors.Oversized, fmt.Sprintf("message of %d bytes is over the maximum of %d", len(data), maxSize))
+	}
+	return nil
+}
+
+// gossipNonceTTL is how long the nonce of an order's latest lock, unlock or delete is remembered
+const gossipNonceTTL = 10 * time.Minute
+
+// gossipSchemas are the messages that the data of each gossiped operation decodes to
+var gossipSchemas = map[pb.Operation]func() proto.Message{
+	pb.Operation_CREATE:              func() proto.Message { return &pb.Order{} },
+	pb.Operation_DELETE:              func() proto.Message { return &pb.Order{} },
+	pb.Operation_LOCK:                func() proto.Message { return &pb.Order{} },
+	pb.Operation_UNLOCK:              func() proto.Message { return &pb.Order{} },
+	pb.Operation_FILL:                func() proto.Message { return &pb.Order{} },
+	pb.Operation_SYNC_REQUEST:        func() proto.Message { return &pb.SyncRequest{} },
+	pb.Operation_SYNC_RECEIVE:        func() proto.Message { return &pb.OrderList{} },
+	pb.Operation_SYNC_HISTORY:        func() proto.Message { return &pb.OrderList{} },
+	pb.Operation_MEMBERSHIP:          func() proto.Message { return &pb.Membership{} },
+	pb.Operation_CHANNEL_CONFIG:      func() proto.Message { return &pb.ChannelConfig{} },
+	pb.Operation_IDENTITY_TRANSITION: func() proto.Message { return &pb.IdentityTransition{} },
+	pb.Operation_REMOVE:              func() proto.Message { return &pb.Removal{} },
+	pb.Operation_ORDER_DIGEST:        func() proto.Message { return &pb.OrderDigest{} },
+	pb.Operation_DELETE_ALL:          func() proto.Message { return &pb.OrderList{} },
+}
+
+// gossipNonce is the latest nonce an order's maker has published and when it was seen
+type gossipNonce struct {
+	nonce uint32
+	seen  time.Time
+}
+
+// gossipNonces remembers the latest nonce of each order's state changes, so old ones replayed to the channel are dropped
+type gossipNonces struct {
+	nonces map[string]gossipNonce
+	// expiry holds the recorded keys oldest first, so expired nonces are dropped without walking the whole map
+	expiry []gossipNonceKey
+	lock   sync.Mutex
+}
+
+// gossipNonceKey is a recorded key and when it was recorded
+type gossipNonceKey struct {
+	key  string
+	seen time.Time
+}
+
+func newGossipNonces() *gossipNonces {
+	return &gossipNonces{nonces: make(map[string]gossipNonce)}
+}
+
+// expire forgets the nonces that haven't been recorded again within gossipNonceTTL.
+// A key recorded again is queued again, so only its latest queue entry removes it.
+func (n *gossipNonces) expire(now time.Time) {
+	for len(n.expiry) > 0 && now.Sub(n.expiry[0].seen) > gossipNonceTTL {
+		oldest := n.expiry[0]
+		n.expiry = n.expiry[1:]
+		if seen, ok := n.nonces[oldest.key]; ok && seen.seen.Equal(oldest.seen) {
+			delete(n.nonces, oldest.key)
+		}
+	}
+}
+
+// check tells if the nonce of a lock, unlock or delete of the order isn't behind the latest one seen.
+// A lock or unlock always bumps the nonce, so it can't repeat the latest one either, while a delete keeps it.
+// The nonce is remembered only if record is set, as only the maker's own messages may move it forward.
+func (n *gossipNonces) check(key string, op pb.Operation, nonce uint32, record bool, now time.Time) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.expire(now)
+	if latest, ok := n.nonces[key]; ok {
+		if nonce < latest.nonce || (nonce == latest.nonce && op != pb.Operation_DELETE) {
+			return errors.E(errors.Op("Compare nonces"), errors.Replay, fmt.Sprintf("nonce %d is behind the latest %d", nonce, latest.nonce))
+		}
+	}
+	if record {
+		n.nonces[key] = gossipNonce{nonce: nonce, seen: now}
+		n.expiry = append(n.expiry, gossipNonceKey{key: key, seen: now})
+	}
+	return nil
+}
+
+// checkGossip checks a message published on a channel before it's delivered or forwarded: its size, that it's a
+// well-formed message of the channel, that orders are signed by their maker and that order state changes aren't replayed
+func (p2p *P2p) checkGossip(channelID []byte, publisher peer.ID, data []byte) error {
+	err := p2p.checkMessageSize(data)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	wireMessage := &pb.WireMessage{}
+	err = proto.Unmarshal(data, wireMessage)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal gossip message"), errors.Malformed, err)
+	}
+	if !bytes.Equal(wireMessage.GetChannelID(), channelID) {
+		return errors.E(errors.Op("Check gossip channel"), errors.Malformed, fmt.Sprintf("message of channel %s published on channel %s", wireMessage.GetChannelID(), channelID))
+	}
+	op := wireMessage.GetOperation()
+	schema, ok := gossipSchemas[op]
+	if !ok {
+		return errors.E(errors.Op("Check gossip operation"), errors.Malformed, fmt.Sprintf("operation %s isn't gossiped", op))
+	}
+	message := schema()
+	err = proto.Unmarshal(wireMessage.GetData(), message)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal gossip data"), errors.Malformed, err)
+	}
+
+	// Cancelling all of a maker's orders is checked like deleting each of them
+	if op == pb.Operation_DELETE_ALL {
+		for _, order := range message.(*pb.OrderList).GetOrders() {
+			err = p2p.checkGossipOrder(channelID, publisher, pb.Operation_DELETE, order)
+			if !errors.IsEmpty(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	order, ok := message.(*pb.Order)
+	if !ok || op == pb.Operation_FILL {
+		return nil
+	}
+	return p2p.checkGossipOrder(channelID, publisher, op, order)
+}
+
+// checkGossipOrder checks that a gossiped order is signed by its maker and that a change of its state isn't replayed
+func (p2p *P2p) checkGossipOrder(channelID []byte, publisher peer.ID, op pb.Operation, order *pb.Order) error {
+	makerID, err := identity.VerifyOrder(order)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify gossiped order"), err)
+	}
+	if op == pb.Operation_CREATE {
+		return nil
+	}
+	key := string(channelID) + string(order.GetId())
+	return p2p.gossipNonces.check(key, op, order.GetNonce(), publisher == makerID, time.Now())
+}
+
+// validateGossip returns the gossip validator of a channel. Messages that fail checkGossip are neither delivered
+// nor forwarded, so invalid traffic stops at the first node it reaches, and count against the reputation
+// of the peer that passed them on.
+func (p2p *P2p) validateGossip(channelID []byte) pubsub.Validator {
+	return func(ctx context.Context, from peer.ID, message *pubsub.Message) bool {
+		err := p2p.checkGossip(channelID, message.GetFrom(), message.GetData())
+		if errors.IsEmpty(err) {
+			return true
+		}
+		if from != p2p.host.ID() {
+			p2p.Logger.Debugf("Rejecting gossip from %s: %s", from, err)
+			p2p.penalize(from, err)
+		}
+		return false
+	}
+}
@@ -0,0 +1,156 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsGossipD string = "SPRAWL_P2P_GOSSIP_D"
+const optionsGossipDhi string = "SPRAWL_P2P_GOSSIP_DHI"
+const optionsGossipHeartbeatInterval string = "SPRAWL_P2P_GOSSIP_HEARTBEATINTERVAL"
+const optionsFloodPublishPeers string = "SPRAWL_P2P_GOSSIP_FLOODPUBLISHPEERS"
+const optionsMaxMessageSize string = "SPRAWL_P2P_MAXMESSAGESIZE"
+
+func TestConfigureGossip(t *testing.T) {
+	d, dlo, dhi, heartbeatInterval := pubsub.GossipSubD, pubsub.GossipSubDlo, pubsub.GossipSubDhi, pubsub.GossipSubHeartbeatInterval
+	defer func() {
+		pubsub.GossipSubD, pubsub.GossipSubDlo, pubsub.GossipSubDhi, pubsub.GossipSubHeartbeatInterval = d, dlo, dhi, heartbeatInterval
+	}()
+	defer os.Unsetenv(optionsGossipD)
+	defer os.Unsetenv(optionsGossipDhi)
+	defer os.Unsetenv(optionsGossipHeartbeatInterval)
+
+	os.Setenv(optionsGossipD, "8")
+	os.Setenv(optionsGossipDhi, "16")
+	os.Setenv(optionsGossipHeartbeatInterval, "500")
+	p2pInstance := newAnnounceTestP2p(t)
+	p2pInstance.configureGossip()
+	assert.Equal(t, 8, pubsub.GossipSubD)
+	assert.Equal(t, 4, pubsub.GossipSubDlo)
+	assert.Equal(t, 16, pubsub.GossipSubDhi)
+	assert.Equal(t, 500*time.Millisecond, pubsub.GossipSubHeartbeatInterval)
+
+	// Degrees out of order are ignored
+	pubsub.GossipSubD, pubsub.GossipSubDlo, pubsub.GossipSubDhi = d, dlo, dhi
+	os.Setenv(optionsGossipD, "20")
+	p2pInstance = newAnnounceTestP2p(t)
+	p2pInstance.configureGossip()
+	assert.Equal(t, d, pubsub.GossipSubD)
+	assert.Equal(t, dhi, pubsub.GossipSubDhi)
+}
+
+func TestShouldFloodPublish(t *testing.T) {
+	p2pInstance := newAnnounceTestP2p(t)
+	assert.False(t, p2pInstance.shouldFloodPublish(1))
+
+	defer os.Unsetenv(optionsFloodPublishPeers)
+	os.Setenv(optionsFloodPublishPeers, "4")
+	p2pInstance = newAnnounceTestP2p(t)
+	assert.False(t, p2pInstance.shouldFloodPublish(0))
+	assert.True(t, p2pInstance.shouldFloodPublish(3))
+	assert.False(t, p2pInstance.shouldFloodPublish(4))
+}
+
+func TestCheckMessageSize(t *testing.T) {
+	defer os.Unsetenv(optionsMaxMessageSize)
+	os.Setenv(optionsMaxMessageSize, "16")
+	p2pInstance := newAnnounceTestP2p(t)
+	assert.NoError(t, p2pInstance.checkMessageSize(make([]byte, 16)))
+	assert.True(t, errors.Is(errors.Oversized, p2pInstance.checkMessageSize(make([]byte, 17))))
+
+	// 0 doesn't limit the size
+	os.Setenv(optionsMaxMessageSize, "0")
+	p2pInstance = newAnnounceTestP2p(t)
+	assert.NoError(t, p2pInstance.checkMessageSize(make([]byte, 1<<20)))
+}
+
+func newGossip(t *testing.T, channelID []byte, op pb.Operation, data proto.Message) []byte {
+	dataInBytes, err := proto.Marshal(data)
+	assert.NoError(t, err)
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: op, Data: dataInBytes})
+	assert.NoError(t, err)
+	return wireMessage
+}
+
+func TestCheckGossip(t *testing.T) {
+	p2pInstance := newAnnounceTestP2p(t)
+	channelID := []byte("ETH,BTC")
+	privateKey, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	makerID, err := peer.IDFromPrivateKey(privateKey)
+	assert.NoError(t, err)
+	makerPubKey, err := crypto.MarshalPublicKey(publicKey)
+	assert.NoError(t, err)
+	order := &pb.Order{Id: []byte("order"), Asset: "ETH", CounterAsset: "BTC", Amount: 100, Price: 0.1, MakerPeerID: []byte(makerID), MakerPubKey: makerPubKey}
+	signingBytes, err := identity.GetOrderSigningBytes(order)
+	assert.NoError(t, err)
+	order.Signature, err = privateKey.Sign(signingBytes)
+	assert.NoError(t, err)
+	otherID, err := peer.IDFromPublicKey(p2pInstance.publicKey)
+	assert.NoError(t, err)
+
+	assert.NoError(t, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_CREATE, order)))
+	assert.NoError(t, p2pInstance.checkGossip(channelID, otherID, newGossip(t, channelID, pb.Operation_SYNC_REQUEST, &pb.SyncRequest{})))
+
+	// Messages that aren't Sprawl messages of the channel are malformed
+	assert.True(t, errors.Is(errors.Malformed, p2pInstance.checkGossip(channelID, makerID, []byte("garbage"))))
+	assert.True(t, errors.Is(errors.Malformed, p2pInstance.checkGossip(channelID, makerID, newGossip(t, []byte("BTC,ETH"), pb.Operation_CREATE, order))))
+	assert.True(t, errors.Is(errors.Malformed, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_CANDLE, order))))
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CREATE, Data: []byte("garbage")})
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(errors.Malformed, p2pInstance.checkGossip(channelID, makerID, wireMessage)))
+
+	// Orders must be signed by their maker
+	forged := *order
+	forged.Amount = 1000
+	assert.True(t, errors.Is(errors.InvalidSignature, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_CREATE, &forged))))
+
+	// Locks and unlocks can't go back to an earlier nonce
+	locked := *order
+	locked.State = pb.State_LOCKED
+	locked.Nonce = 1
+	unlocked := *order
+	unlocked.Nonce = 2
+	assert.NoError(t, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_LOCK, &locked)))
+	assert.NoError(t, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_UNLOCK, &unlocked)))
+	assert.True(t, errors.Is(errors.Replay, p2pInstance.checkGossip(channelID, otherID, newGossip(t, channelID, pb.Operation_LOCK, &locked))))
+	assert.True(t, errors.Is(errors.Replay, p2pInstance.checkGossip(channelID, otherID, newGossip(t, channelID, pb.Operation_UNLOCK, &unlocked))))
+	assert.NoError(t, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_DELETE, &unlocked)))
+
+	// Others than the maker can't move the nonce forward
+	locked.Nonce = 100
+	assert.NoError(t, p2pInstance.checkGossip(channelID, otherID, newGossip(t, channelID, pb.Operation_LOCK, &locked)))
+	locked.Nonce = 3
+	assert.NoError(t, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_LOCK, &locked)))
+
+	// Cancelling all orders checks each of them like a delete
+	assert.NoError(t, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_DELETE_ALL, &pb.OrderList{Orders: []*pb.Order{&locked}})))
+	assert.True(t, errors.Is(errors.InvalidSignature, p2pInstance.checkGossip(channelID, makerID, newGossip(t, channelID, pb.Operation_DELETE_ALL, &pb.OrderList{Orders: []*pb.Order{&locked, &forged}}))))
+}
+
+func TestGossipNonceExpiry(t *testing.T) {
+	nonces := newGossipNonces()
+	now := time.Now()
+	assert.NoError(t, nonces.check("order", pb.Operation_LOCK, 2, true, now))
+	assert.True(t, errors.Is(errors.Replay, nonces.check("order", pb.Operation_UNLOCK, 1, true, now)))
+
+	// A nonce recorded again is remembered from the latest time
+	assert.NoError(t, nonces.check("order", pb.Operation_UNLOCK, 3, true, now.Add(gossipNonceTTL/2)))
+	assert.True(t, errors.Is(errors.Replay, nonces.check("order", pb.Operation_LOCK, 2, false, now.Add(gossipNonceTTL+time.Second))))
+
+	// Nonces that haven't been recorded within the TTL are forgotten
+	assert.NoError(t, nonces.check("order", pb.Operation_LOCK, 1, false, now.Add(2*gossipNonceTTL)))
+	assert.Empty(t, nonces.nonces)
+	assert.Empty(t, nonces.expiry)
+}
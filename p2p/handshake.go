@@ -0,0 +1,207 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const protocolVersion string = "1.1.0"
+const challengeLength int = 32
+
+// capabilities are the optional features of this node, sent in the handshake.
+// A feature is only used on a stream if both peers have it.
+var capabilities = []string{interfaces.CapabilityMembership, interfaces.CapabilityChannelConfig}
+
+// isCompatibleVersion tells if a peer with the given protocol version can talk to this node.
+// Versions with the same major version are compatible, and newer features are negotiated with capabilities.
+func isCompatibleVersion(version string) bool {
+	major := strings.SplitN(protocolVersion, ".", 2)[0]
+	return strings.SplitN(version, ".", 2)[0] == major
+}
+
+// negotiateCapabilities returns the capabilities both peers have
+func negotiateCapabilities(remoteCapabilities []string) map[string]bool {
+	negotiated := make(map[string]bool)
+	for _, remoteCapability := range remoteCapabilities {
+		for _, capability := range capabilities {
+			if remoteCapability == capability {
+				negotiated[capability] = true
+			}
+		}
+	}
+	return negotiated
+}
+
+func newChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeLength)
+	_, err := rand.Read(challenge)
+	return challenge, err
+}
+
+// getSubscribedChannels returns the IDs of all channels this node is subscribed to
+func (p2p *P2p) getSubscribedChannels() [][]byte {
+	p2p.subLock.RLock()
+	defer p2p.subLock.RUnlock()
+	channels := make([][]byte, 0, len(p2p.subscriptions))
+	for channelID := range p2p.subscriptions {
+		channels = append(channels, []byte(channelID))
+	}
+	return channels
+}
+
+// newHandshake constructs this node's handshake message with a challenge for the remote peer to sign
+func (p2p *P2p) newHandshake(challenge []byte) (*pb.Handshake, error) {
+	publicKeyBytes, err := crypto.MarshalPublicKey(p2p.publicKey)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal public key for handshake"), err)
+	}
+	return &pb.Handshake{
+		PublicKey:       publicKeyBytes,
+		Challenge:       challenge,
+		ProtocolVersion: protocolVersion,
+		Channels:        p2p.getSubscribedChannels(),
+		Capabilities:    capabilities,
+		Time:            ptypes.TimestampNow(),
+	}, nil
+}
+
+// verifyRemoteKey checks that the public key presented in a handshake belongs to the remote peer
+func verifyRemoteKey(remotePeer peer.ID, publicKeyBytes []byte) (crypto.PubKey, error) {
+	publicKey, err := crypto.UnmarshalPublicKey(publicKeyBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal remote public key"), err)
+	}
+	if !remotePeer.MatchesPublicKey(publicKey) {
+		return nil, errors.E(errors.Op("Match remote public key"), "public key doesn't match the peer ID")
+	}
+	return publicKey, nil
+}
+
+func (stream *Stream) writeHandshake(handshake *pb.Handshake) error {
+	data, err := proto.Marshal(handshake)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal handshake"), err)
+	}
+	return stream.writeFrame(data)
+}
+
+func (stream *Stream) readHandshake() (*pb.Handshake, error) {
+	data, err := stream.readFrame()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Read handshake"), err)
+	}
+	handshake := &pb.Handshake{}
+	err = proto.Unmarshal(data, handshake)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal handshake"), err)
+	}
+	return handshake, nil
+}
+
+// acceptRemoteHandshake stores the remote peer's identity, version, channels and the negotiated capabilities on the stream
+func (stream *Stream) acceptRemoteHandshake(handshake *pb.Handshake) error {
+	publicKey, err := verifyRemoteKey(stream.remotePeer, handshake.GetPublicKey())
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if !isCompatibleVersion(handshake.GetProtocolVersion()) {
+		return errors.E(errors.Op("Check protocol version"), "incompatible protocol version "+handshake.GetProtocolVersion())
+	}
+	stream.remotePublicKey = publicKey
+	stream.remoteVersion = handshake.GetProtocolVersion()
+	stream.remoteChannels = handshake.GetChannels()
+	stream.capabilities = negotiateCapabilities(handshake.GetCapabilities())
+	return nil
+}
+
+// initiateHandshake is run by the peer opening the stream.
+// Both sides send a challenge that the other side has to sign with its identity key.
+func (p2p *P2p) initiateHandshake(stream *Stream) error {
+	challenge, err := newChallenge()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Create handshake challenge"), err)
+	}
+	hello, err := p2p.newHandshake(challenge)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	sent := time.Now()
+	err = stream.writeHandshake(hello)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send handshake"), err)
+	}
+
+	response, err := stream.readHandshake()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	// The response was timestamped about halfway through the round trip
+	received := time.Now()
+	responded := sent.Add(received.Sub(sent) / 2)
+	err = stream.acceptRemoteHandshake(response)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	valid, err := stream.remotePublicKey.Verify(challenge, response.GetSignature())
+	if !errors.IsEmpty(err) || !valid {
+		return errors.E(errors.Op("Verify handshake signature"), "remote peer failed to sign the challenge")
+	}
+
+	p2p.recordClockOffset(stream.remotePeer, response.GetTime(), responded)
+
+	signature, err := p2p.privateKey.Sign(response.GetChallenge())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Sign handshake challenge"), err)
+	}
+	return stream.writeHandshake(&pb.Handshake{Signature: signature})
+}
+
+// acceptHandshake is run by the peer receiving the stream, before any data is passed to the Receiver
+func (p2p *P2p) acceptHandshake(stream *Stream) error {
+	hello, err := stream.readHandshake()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	received := time.Now()
+	err = stream.acceptRemoteHandshake(hello)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	challenge, err := newChallenge()
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Create handshake challenge"), err)
+	}
+	response, err := p2p.newHandshake(challenge)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	response.Signature, err = p2p.privateKey.Sign(hello.GetChallenge())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Sign handshake challenge"), err)
+	}
+	err = stream.writeHandshake(response)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send handshake response"), err)
+	}
+
+	proof, err := stream.readHandshake()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	valid, err := stream.remotePublicKey.Verify(challenge, proof.GetSignature())
+	if !errors.IsEmpty(err) || !valid {
+		return errors.E(errors.Op("Verify handshake signature"), "remote peer failed to sign the challenge")
+	}
+	p2p.recordClockOffset(stream.remotePeer, hello.GetTime(), received)
+	return nil
+}
@@ -0,0 +1,133 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// journalSeparator separates the channel ID of a journal key from the time the message was journaled.
+// It can't appear in channel IDs, so the journal of one channel never matches the prefix of another.
+const journalSeparator string = "/"
+
+func getJournalQueryPrefix(channelID []byte) string {
+	return string(interfaces.JournalPrefix) + string(channelID) + journalSeparator
+}
+
+// getJournalStorageKey orders the journal of a channel by time, with the nanoseconds zero-padded so they sort as strings
+func getJournalStorageKey(channelID []byte, journaled time.Time) []byte {
+	return []byte(getJournalQueryPrefix(channelID) + fmt.Sprintf("%020d", journaled.UnixNano()))
+}
+
+// journalEntry is a marshaled WireMessage waiting to be published again
+type journalEntry struct {
+	key  string
+	data []byte
+}
+
+// journal stores a message that was published while no peers were on its channel, so it can be resent once one joins.
+// The message expires from the journal after the retention even if no peer ever joins.
+func (p2p *P2p) journal(channelID []byte, data []byte) {
+	if p2p.storage == nil || p2p.Config.GetJournalRetention() == 0 {
+		return
+	}
+	retention := time.Duration(p2p.Config.GetJournalRetention()) * time.Hour
+	err := p2p.storage.PutWithTTL(p2p.ctx, getJournalStorageKey(channelID, time.Now()), data, retention)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Journal message"), err))
+		return
+	}
+	p2p.Logger.Debugf("No peers on channel %s, journaled the message to resend later", string(channelID))
+}
+
+// readJournal returns the journaled messages of a channel oldest first, deleting the ones older than the retention
+func (p2p *P2p) readJournal(ctx context.Context, channelID []byte, now time.Time) ([]journalEntry, error) {
+	prefix := getJournalQueryPrefix(channelID)
+	data, err := p2p.storage.GetAllWithPrefix(ctx, prefix)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get journal"), err)
+	}
+
+	retention := time.Duration(p2p.Config.GetJournalRetention()) * time.Hour
+	entries := make([]journalEntry, 0, len(data))
+	for key, value := range data {
+		journaled, err := strconv.ParseInt(strings.TrimPrefix(key, prefix), 10, 64)
+		if err != nil || time.Unix(0, journaled).Before(now.Add(-retention)) {
+			err = p2p.storage.Delete(ctx, []byte(key))
+			if !errors.IsEmpty(err) {
+				return nil, errors.E(errors.Op("Delete expired journal entry"), err)
+			}
+			continue
+		}
+		entries = append(entries, journalEntry{key: key, data: []byte(value)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries, nil
+}
+
+// flushJournal publishes the journaled messages of a channel again, removing each one that got published to a peer
+func (p2p *P2p) flushJournal(ctx context.Context, channelID []byte) {
+	entries, err := p2p.readJournal(ctx, channelID, time.Now())
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Read journal"), err))
+		return
+	}
+	if len(entries) > 0 {
+		p2p.Logger.Infof("Resending %d journaled messages on channel %s", len(entries), string(channelID))
+	}
+	for _, entry := range entries {
+		if len(p2p.ps.ListPeers(p2p.topic(channelID))) == 0 {
+			return
+		}
+		err = p2p.ps.Publish(p2p.topic(channelID), entry.data)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Error(errors.E(errors.Op("Resend journaled message"), err))
+			return
+		}
+		err = p2p.storage.Delete(ctx, []byte(entry.key))
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Error(errors.E(errors.Op("Delete journal entry"), err))
+		}
+	}
+}
+
+// watchJournal resends the journaled messages of a channel whenever a peer joins it
+func (p2p *P2p) watchJournal(ctx context.Context, channelID []byte, topic *pubsub.Topic) {
+	if p2p.storage == nil || p2p.Config.GetJournalRetention() == 0 {
+		return
+	}
+	eventHandler, err := topic.EventHandler()
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Return topic's event handler"), err))
+		return
+	}
+
+	go func(ctx context.Context) {
+		defer eventHandler.Cancel()
+		for {
+			peerEvent, err := eventHandler.NextPeerEvent(ctx)
+			if !errors.IsEmpty(err) {
+				return
+			}
+			if peerEvent.Type != pubsub.PeerJoin {
+				continue
+			}
+			// Give the heartbeat time to graft the peer into the mesh, or the messages would only reach the fanout
+			select {
+			case <-time.After(pubsub.GossipSubHeartbeatInterval):
+			case <-ctx.Done():
+				return
+			}
+			p2p.flushJournal(ctx, channelID)
+		}
+	}(ctx)
+}
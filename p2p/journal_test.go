@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsJournalRetention string = "SPRAWL_P2P_JOURNALRETENTION"
+
+func newJournalTestP2p(t *testing.T) *P2p {
+	p2pInstance := newAnnounceTestP2p(t)
+	p2pInstance.storage = &inmemory.Storage{Db: make(map[string]string)}
+	return p2pInstance
+}
+
+func TestJournal(t *testing.T) {
+	p2pInstance := newJournalTestP2p(t)
+	p2pInstance.journal(testChannel.GetId(), []byte("first"))
+	p2pInstance.journal(testChannel.GetId(), []byte("second"))
+	p2pInstance.journal([]byte(string(testChannel.GetId())+"#options"), []byte("other"))
+
+	entries, err := p2pInstance.readJournal(context.Background(), testChannel.GetId(), time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, []byte("first"), entries[0].data)
+	assert.Equal(t, []byte("second"), entries[1].data)
+
+	// Entries past the retention are dropped
+	entries, err = p2pInstance.readJournal(context.Background(), testChannel.GetId(), time.Now().Add(25*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	count, err := p2pInstance.storage.Count(context.Background(), getJournalQueryPrefix(testChannel.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestJournalDisabled(t *testing.T) {
+	defer os.Unsetenv(optionsJournalRetention)
+	os.Setenv(optionsJournalRetention, "0")
+
+	p2pInstance := newJournalTestP2p(t)
+	p2pInstance.journal(testChannel.GetId(), []byte("first"))
+	entries, err := p2pInstance.readJournal(context.Background(), testChannel.GetId(), time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"strings"
+
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// mainnet is the network of nodes that don't set p2p.network. Its protocol IDs, rendezvous string and topics
+// don't name the network, so nodes from before p2p.network keep talking to it.
+const mainnet = "mainnet"
+
+// getNetwork returns the network in the config, without the slashes that would mix it up with the rest of an ID
+func getNetwork(config interfaces.Config) string {
+	network := strings.Trim(strings.TrimSpace(config.GetNetwork()), "/")
+	if network == "" {
+		return mainnet
+	}
+	return network
+}
+
+// rendezvous returns the string that peers of the node's network advertise and look for in the DHT
+func (p2p *P2p) rendezvous() string {
+	if p2p.network == mainnet {
+		return networkID
+	}
+	return networkID + p2p.network + "/"
+}
+
+// protocolIDs returns the stream protocols the node speaks, newest first. Only mainnet falls back to the
+// legacy protocol, since nodes that old can't be on any other network.
+func (p2p *P2p) protocolIDs() []protocol.ID {
+	if p2p.network == mainnet {
+		return []protocol.ID{protocol.ID(networkID + "orders/" + protocolVersion), protocol.ID(networkID)}
+	}
+	return []protocol.ID{protocol.ID(p2p.rendezvous() + "orders/" + protocolVersion)}
+}
+
+// fastSyncProtocolID returns the stream protocol for downloading a snapshot of a channel's open orders
+func (p2p *P2p) fastSyncProtocolID() protocol.ID {
+	return protocol.ID(p2p.rendezvous() + "fastsync/" + fastSyncVersion)
+}
+
+// relayProtocolID returns the stream protocol over which relayed messages of channels are delivered
+func (p2p *P2p) relayProtocolID() protocol.ID {
+	return protocol.ID(p2p.rendezvous() + "relay/" + relayVersion)
+}
+
+// relayHopProtocolID returns the stream protocol of nodes that forward relayed messages.
+// Only relays speak it, so their peers can tell them apart from the protocols they support.
+func (p2p *P2p) relayHopProtocolID() protocol.ID {
+	return protocol.ID(p2p.rendezvous() + "relay/hop/" + relayVersion)
+}
+
+// topic returns the pubsub topic of a channel on the node's network
+func (p2p *P2p) topic(channelID []byte) string {
+	if p2p.network == mainnet {
+		return string(channelID)
+	}
+	return p2p.rendezvous() + string(channelID)
+}
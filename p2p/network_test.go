@@ -0,0 +1,56 @@
+package p2p
+
+import (
+	"os"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/sprawl/sprawl/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const optionsNetwork string = "SPRAWL_P2P_NETWORK"
+
+func newNetworkTestConfig(network string) *config.Config {
+	os.Setenv(optionsNetwork, network)
+	defer os.Unsetenv(optionsNetwork)
+	networkConfig := &config.Config{}
+	networkConfig.ReadConfig(testConfigPath)
+	return networkConfig
+}
+
+func TestMainnetIDs(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	assert.Equal(t, mainnet, p2pInstance.network)
+	assert.Equal(t, "/sprawl/", p2pInstance.rendezvous())
+	assert.Equal(t, []protocol.ID{protocol.ID("/sprawl/orders/" + protocolVersion), "/sprawl/"}, p2pInstance.protocolIDs())
+	assert.Equal(t, protocol.ID("/sprawl/fastsync/1.0.0"), p2pInstance.fastSyncProtocolID())
+	assert.Equal(t, string(testChannel.GetId()), p2pInstance.topic(testChannel.GetId()))
+}
+
+func TestNetworkIDs(t *testing.T) {
+	p2pInstance := NewP2p(newNetworkTestConfig(" /testnet/ "), privateKey, publicKey, Logger(log))
+	assert.Equal(t, "testnet", p2pInstance.network)
+	assert.Equal(t, "/sprawl/testnet/", p2pInstance.rendezvous())
+	assert.Equal(t, []protocol.ID{protocol.ID("/sprawl/testnet/orders/" + protocolVersion)}, p2pInstance.protocolIDs())
+	assert.Equal(t, protocol.ID("/sprawl/testnet/fastsync/1.0.0"), p2pInstance.fastSyncProtocolID())
+	assert.Equal(t, "/sprawl/testnet/"+string(testChannel.GetId()), p2pInstance.topic(testChannel.GetId()))
+
+	p2pInstance = NewP2p(newNetworkTestConfig(""), privateKey, publicKey, Logger(log))
+	assert.Equal(t, mainnet, p2pInstance.network)
+}
+
+func TestNetworksDontConnect(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(newNetworkTestConfig("testnet"), privateKey2, publicKey2, Logger(log))
+
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+
+	err := p2pInstance1.host.Connect(p2pInstance1.ctx, p2pInstance2.GetAddrInfo())
+	assert.NoError(t, err)
+
+	// The hosts can connect, but they share no protocol to open a stream on
+	_, err = p2pInstance1.OpenStream(p2pInstance2.GetHostID())
+	assert.Error(t, err)
+}
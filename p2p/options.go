@@ -16,6 +16,8 @@ import (
 )
 
 const addrTemplate string = "/ip4/%s/tcp/%s"
+const wsAddrTemplate string = "/ip4/%s/tcp/%s/ws"
+const anyIPv4 string = "0.0.0.0"
 
 // Options for this p2p package, unrelated to libp2pConfig.Option
 type Options struct {
@@ -50,21 +52,23 @@ func Receiver(receiver interfaces.Receiver) Option {
 	}
 }
 
-func (p2p *P2p) defaultBootstrapPeers() []ma.Multiaddr {
-	peers := []ma.Multiaddr{}
-	peers = append(peers, dht.DefaultBootstrapPeers...)
-	/* sprawlBootstrapAddresses := []string{"/dnsaddr/bootstrap.sprawl.equilibrium.co"}
-	for _, addr := range sprawlBootstrapAddresses {
-		mAddr, _ := ma.NewMultiaddr(addr)
-		peers = append(peers, mAddr)
-	} */
-	return peers
+// Host makes p2p run on a host that is already set up and connected, like the ones of a mocknet, instead of starting its own.
+// The host isn't bootstrapped and doesn't look for peers.
+func Host(host host.Host) Option {
+	return func(p *P2p) error {
+		p.host = host
+		return nil
+	}
 }
 
 func createMultiAddr(externalIP string, p2pPort string) (ma.Multiaddr, error) {
 	return ma.NewMultiaddr(fmt.Sprintf(addrTemplate, externalIP, p2pPort))
 }
 
+func createWebsocketMultiAddr(ip string, browserPort string) (ma.Multiaddr, error) {
+	return ma.NewMultiaddr(fmt.Sprintf(wsAddrTemplate, ip, browserPort))
+}
+
 func (p2p *P2p) initDHT() libp2pConfig.Option {
 	NewDHT := func(h host.Host) (routing.PeerRouting, error) {
 		var err error
@@ -86,6 +90,13 @@ func (p2p *P2p) CreateOptions() []libp2pConfig.Option {
 	// Non-configurable options, since we always need an identity and the DHT discovery
 	options = append(options, p2p.initDHT())
 	options = append(options, libp2p.Identity(p2p.privateKey))
+	options = append(options, libp2p.BandwidthReporter(p2p.bandwidth))
+	if connectionManager := p2p.connectionManager(); connectionManager != nil {
+		options = append(options, connectionManager)
+	}
+	options = append(options, p2p.securityOptions()...)
+	quic := p2p.useQUIC()
+	options = append(options, p2p.transportOptions(quic)...)
 
 	// libp2p relay options
 	if p2p.Config.GetRelaySetting() {
@@ -95,23 +106,47 @@ func (p2p *P2p) CreateOptions() []libp2pConfig.Option {
 		options = append(options, libp2p.EnableAutoRelay())
 	}
 
-	// If NAT port map is not enabled, define listened addresses and port manually
-	if p2p.Config.GetNATPortMapSetting() {
+	// Configured listen addresses replace the ones made from the external IP and port
+	if listenAddrs := p2p.parseMultiAddrs(p2p.Config.GetListenAddresses(), "Listen address"); len(listenAddrs) > 0 {
+		options = append(options, libp2p.ListenAddrs(listenAddrs...))
+		if p2p.Config.GetNATPortMapSetting() {
+			options = append(options, libp2p.NATPortMap())
+		}
+		options = append(options, libp2p.AddrsFactory(p2p.announceAddrsFactory(nil)))
+	} else if p2p.Config.GetNATPortMapSetting() {
 		options = append(options, libp2p.NATPortMap())
+		// Listening on a websocket or QUIC replaces the default listen addresses, so they're added back
+		extraAddrs := []ma.Multiaddr{}
+		if p2p.Config.GetBrowserTransportsSetting() {
+			extraAddrs = append(extraAddrs, p2p.browserMultiAddr(anyIPv4)...)
+		}
+		if quic {
+			extraAddrs = append(extraAddrs, anyQUICMultiAddrs()...)
+		}
+		if len(extraAddrs) > 0 {
+			options = append(options, libp2p.DefaultListenAddrs)
+			options = append(options, libp2p.ListenAddrs(extraAddrs...))
+		}
+		options = append(options, libp2p.AddrsFactory(p2p.announceAddrsFactory(nil)))
 	} else {
+		// If NAT port map is not enabled, define listened addresses and port manually
+		// Both an IPv4 and an IPv6 address can be listened on, with TCP and QUIC on the same port number
 		multiaddrs := []ma.Multiaddr{}
-		if externalIP != "" {
-			extMultiAddr, err := createMultiAddr(externalIP, strconv.FormatUint(uint64(p2pPort), 10))
+		for _, ip := range []string{externalIP, p2p.Config.GetExternalIPv6()} {
+			if ip == "" {
+				continue
+			}
+			extMultiAddrs, err := createListenMultiAddrs(ip, strconv.FormatUint(uint64(p2pPort), 10), quic)
 			if !errors.IsEmpty(err) {
 				p2p.Logger.Error(errors.E(errors.Op("Creating multiaddr"), err))
 			}
-			multiaddrs = append(multiaddrs, extMultiAddr)
+			multiaddrs = append(multiaddrs, extMultiAddrs...)
 		}
-		addrFactory := func(addrs []ma.Multiaddr) []ma.Multiaddr {
-			return multiaddrs
+		if externalIP != "" && p2p.Config.GetBrowserTransportsSetting() {
+			multiaddrs = append(multiaddrs, p2p.browserMultiAddr(externalIP)...)
 		}
 		options = append(options, libp2p.ListenAddrs(multiaddrs...))
-		options = append(options, libp2p.AddrsFactory(addrFactory))
+		options = append(options, libp2p.AddrsFactory(p2p.announceAddrsFactory(multiaddrs)))
 	}
 
 	return options
@@ -101,3 +101,37 @@ func TestCreateOptions(t *testing.T) {
 
 	resetOptions()
 }
+
+func TestBrowserMultiAddr(t *testing.T) {
+	readTestConfig()
+
+	privateKey, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	p2pInstance := NewP2p(appConfig, privateKey, publicKey, Logger(new(util.PlaceholderLogger)))
+
+	addrs := p2pInstance.browserMultiAddr(anyIPv4)
+	assert.Len(t, addrs, 1)
+	expectedAddr, err := multiaddr.NewMultiaddr("/ip4/0.0.0.0/tcp/4002/ws")
+	assert.NoError(t, err)
+	assert.True(t, expectedAddr.Equal(addrs[0]))
+
+	_, err = addrs[0].ValueForProtocol(ma.P_WS)
+	assert.NoError(t, err)
+
+	// Without a host there are no connections, so no peer is a browser
+	assert.False(t, p2pInstance.isBrowserPeer(""))
+}
+
+func TestCreateListenMultiAddrs(t *testing.T) {
+	addrs, err := createListenMultiAddrs("127.0.0.1", "4001", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ip4/127.0.0.1/tcp/4001"}, multiAddrStrings(addrs))
+
+	addrs, err = createListenMultiAddrs("::1", "4001", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ip6/::1/tcp/4001", "/ip6/::1/udp/4001/quic"}, multiAddrStrings(addrs))
+
+	_, err = createListenMultiAddrs("not an ip", "4001", false)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"/ip4/0.0.0.0/udp/0/quic", "/ip6/::/udp/0/quic"}, multiAddrStrings(anyQUICMultiAddrs()))
+}
@@ -3,15 +3,19 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/util"
 
 	libp2p "github.com/libp2p/go-libp2p"
+	autonat "github.com/libp2p/go-libp2p-autonat"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	discovery "github.com/libp2p/go-libp2p-discovery"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
@@ -20,29 +24,46 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
 )
 
+// networkID is the rendezvous point where mainnet peers find each other, and the protocol ID of nodes before 1.1.0
 const networkID = "/sprawl/"
 
+// inputQueueSize is how many outgoing messages of each class can wait for publishing before Send blocks
+const inputQueueSize = 64
+
 // P2p stores all things required to converse with other peers in the Sprawl network and save data locally
 type P2p struct {
-	Config           interfaces.Config
-	privateKey       crypto.PrivKey
-	publicKey        crypto.PubKey
-	ps               *pubsub.PubSub
-	ctx              context.Context
-	host             host.Host
-	kademliaDHT      *dht.IpfsDHT
-	routingDiscovery *discovery.RoutingDiscovery
-	peerChan         <-chan peer.AddrInfo
-	input            chan pb.WireMessage
-	subscriptions    map[string]context.CancelFunc
-	subLock          sync.RWMutex
-	streams          map[string]*Stream
-	streamLock       sync.RWMutex
-	Logger           interfaces.Logger
-	storage          interfaces.Storage
-	Receiver         interfaces.Receiver
+	Config             interfaces.Config
+	network            string
+	privateKey         crypto.PrivKey
+	publicKey          crypto.PubKey
+	ps                 *pubsub.PubSub
+	ctx                context.Context
+	host               host.Host
+	nat                autonat.AutoNAT
+	kademliaDHT        *dht.IpfsDHT
+	routingDiscovery   *discovery.RoutingDiscovery
+	peerChan           <-chan peer.AddrInfo
+	outbox             *outbox
+	subscriptions      map[string]context.CancelFunc
+	subLock            sync.RWMutex
+	streams            map[string]*Stream
+	streamLock         sync.RWMutex
+	streamReadTimeout  time.Duration
+	streamWriteTimeout time.Duration
+	relaySeen          *relaySeen
+	gossipNonces       *gossipNonces
+	reputation         *reputation
+	bandwidth          *metrics.BandwidthCounter
+	clock              *clock
+	chaos              *chaos
+	bootstrapDone      chan struct{}
+	discoveryDone      chan struct{}
+	Logger             interfaces.Logger
+	storage            interfaces.Storage
+	Receiver           interfaces.Receiver
 }
 
 // NewP2p returns a P2p struct with an input channel
@@ -50,12 +71,20 @@ func NewP2p(config interfaces.Config, privateKey crypto.PrivKey, publicKey crypt
 	p2p = &P2p{
 		ctx:           context.Background(),
 		Config:        config,
+		network:       getNetwork(config),
 		privateKey:    privateKey,
 		publicKey:     publicKey,
-		input:         make(chan pb.WireMessage),
+		outbox:        newOutbox(config),
 		subscriptions: make(map[string]context.CancelFunc),
 		streams:       make(map[string]*Stream),
+		reputation:    newReputation(),
+		bandwidth:     metrics.NewBandwidthCounter(),
+		clock:         newClock(),
+		relaySeen:     newRelaySeen(),
+		gossipNonces:  newGossipNonces(),
+		chaos:         newChaos(config),
 	}
+	p2p.streamReadTimeout, p2p.streamWriteTimeout = streamTimeouts(config)
 
 	for _, opt := range opts {
 		err := opt(p2p)
@@ -67,6 +96,10 @@ func NewP2p(config interfaces.Config, privateKey crypto.PrivKey, publicKey crypt
 	if p2p.Logger == nil {
 		p2p.Logger = new(util.PlaceholderLogger)
 	}
+	p2p.relaySeen.storage = p2p.storage
+	if p2p.chaos != nil {
+		p2p.Logger.Warn("Chaos mode is on, messages are delayed and dropped and streams are reset on purpose. Only use it for testing!")
+	}
 
 	return p2p
 }
@@ -76,6 +109,12 @@ func (p2p *P2p) AddReceiver(receiver interfaces.Receiver) {
 	p2p.Receiver = receiver
 }
 
+// SetIdentity replaces the key pair the host is started with. It has no effect on a host that is already running.
+func (p2p *P2p) SetIdentity(privateKey crypto.PrivKey, publicKey crypto.PubKey) {
+	p2p.privateKey = privateKey
+	p2p.publicKey = publicKey
+}
+
 // InitHost creates a libp2p host with given options
 func (p2p *P2p) InitHost(options ...libp2pConfig.Option) {
 	var err error
@@ -85,13 +124,18 @@ func (p2p *P2p) InitHost(options ...libp2pConfig.Option) {
 		p2p.ctx,
 		options...)
 
-	// Set stream handler for libp2p host
-	p2p.host.SetStreamHandler(networkID, p2p.handleStream)
-
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(errors.E(errors.Op("Creating host"), err))
 	}
 
+	if p2p.host != nil {
+		p2p.setStreamHandlers()
+		p2p.Logger.Infof("Listening on %s", strings.Join(p2p.GetListenAddresses(), ", "))
+		p2p.checkExternalAddrs()
+		// AutoNAT learns of the peers that can dial back from new connections, so it's started before any are made
+		p2p.nat = autonat.NewAutoNAT(p2p.ctx, p2p.host, nil)
+	}
+
 	err = p2p.kademliaDHT.Bootstrap(p2p.ctx)
 
 	if !errors.IsEmpty(err) {
@@ -99,6 +143,18 @@ func (p2p *P2p) InitHost(options ...libp2pConfig.Option) {
 	}
 }
 
+// setStreamHandlers handles the protocols Sprawl's peers speak to the host
+func (p2p *P2p) setStreamHandlers() {
+	for _, protocolID := range p2p.protocolIDs() {
+		p2p.host.SetStreamHandler(protocolID, p2p.handleStream)
+	}
+	p2p.host.SetStreamHandler(p2p.fastSyncProtocolID(), p2p.handleFastSync)
+	p2p.host.SetStreamHandler(p2p.relayProtocolID(), p2p.handleRelay)
+	if p2p.Config.GetGossipRelaySetting() {
+		p2p.host.SetStreamHandler(p2p.relayHopProtocolID(), p2p.handleRelay)
+	}
+}
+
 // GetHostIDString returns the underlying libp2p host's peer.ID as a string
 func (p2p *P2p) GetHostIDString() string {
 	return p2p.host.ID().String()
@@ -109,6 +165,28 @@ func (p2p *P2p) GetHostID() peer.ID {
 	return p2p.host.ID()
 }
 
+// GetListenAddresses returns the addresses the host is bound to, with the ports the OS assigned for port 0
+func (p2p *P2p) GetListenAddresses() []string {
+	addrs, err := p2p.host.Network().InterfaceListenAddresses()
+	if !errors.IsEmpty(err) {
+		addrs = p2p.host.Network().ListenAddresses()
+	}
+	return multiAddrStrings(addrs)
+}
+
+// GetAnnouncedAddresses returns the addresses other peers are told to connect to
+func (p2p *P2p) GetAnnouncedAddresses() []string {
+	return multiAddrStrings(p2p.host.Addrs())
+}
+
+func multiAddrStrings(addrs []ma.Multiaddr) []string {
+	strs := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		strs = append(strs, addr.String())
+	}
+	return strs
+}
+
 // GetAddrInfo uses p2p.ConstructAddrInfo to get this peer's own AddrInfo
 func (p2p *P2p) GetAddrInfo() peer.AddrInfo {
 	return p2p.ConstructAddrInfo(p2p.GetHostID(), p2p.host.Addrs())
@@ -121,50 +199,21 @@ func (p2p *P2p) ConstructAddrInfo(id peer.ID, addrs []ma.Multiaddr) peer.AddrInf
 
 func (p2p *P2p) initPubSub() {
 	var err error
-	p2p.ps, err = pubsub.NewGossipSub(p2p.ctx, p2p.host)
+	p2p.configureGossip()
+	p2p.ps, err = pubsub.NewGossipSub(p2p.ctx, p2p.host, pubsub.WithStrictSignatureVerification(true))
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(err)
 	}
 }
 
 func (p2p *P2p) connectToNetwork() {
-	var wg sync.WaitGroup
 	p2p.Logger.Info("Connecting to bootstrap peers")
-	for _, peerAddr := range p2p.defaultBootstrapPeers() {
-		// Parse URLs from each bootstrap peer
-		peerinfo, err := peer.AddrInfoFromP2pAddr(peerAddr)
-		if err != nil {
-			p2p.Logger.Errorf("Bootstrap peer multiaddress %s is invalid: %s", peerAddr, err)
-		} else {
-			// Connect to the peer synchronically if the URL is correct
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				if err := p2p.host.Connect(p2p.ctx, *peerinfo); !errors.IsEmpty(err) {
-					p2p.Logger.Debugf("Error connecting to bootstrap peer %s", err)
-				} else {
-					p2p.Logger.Debugf("Successfully connected to bootstrap peer %s", peerinfo)
-				}
-			}()
-		}
-	}
-
-	wg.Wait()
-}
+	p2p.connectToBootstrapPeers()
 
-func (p2p *P2p) startDiscovery() {
-	// Add Kademlia routing discovery
-	p2p.routingDiscovery = discovery.NewRoutingDiscovery(p2p.kademliaDHT)
-
-	// Start the advertiser service
-	discovery.Advertise(p2p.ctx, p2p.routingDiscovery, networkID)
-
-	var err error
-	// Ingest newly found peers into p2p.peerChan
-	p2p.peerChan, err = p2p.routingDiscovery.FindPeers(p2p.ctx, networkID)
-
-	if !errors.IsEmpty(err) {
-		p2p.Logger.Error(errors.E(errors.Op("Find peers"), err))
+	// Keep resolving the bootstrap addresses in case they change
+	if interval := p2p.Config.GetBootstrapRefreshInterval(); interval > 0 {
+		p2p.bootstrapDone = make(chan struct{})
+		go p2p.refreshBootstrapPeers(time.Duration(interval)*time.Minute, p2p.bootstrapDone)
 	}
 }
 
@@ -180,6 +229,10 @@ func (p2p *P2p) listenForPeers() {
 				continue
 			}
 			p2p.Logger.Infof("Found a new peer: %s\n", peer.ID)
+			if p2p.atConnectionLimit() {
+				p2p.Logger.Debugf("Not dialing %s, the connection limit has been reached", peer.ID)
+				continue
+			}
 
 			// Waits on each peerInfo until they are connected or the connection failed
 			wg.Add(1)
@@ -202,30 +255,82 @@ func (p2p *P2p) handleInput(message *pb.WireMessage) {
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(errors.E(errors.Op("Marshal proto"), err))
 	}
+	err = p2p.checkMessageSize(buf)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Error(errors.E(errors.Op("Publish to "+string(message.GetChannelID())), err))
+		return
+	}
 	p2p.Logger.Debugf("Publishing to topic %s!", string(message.GetChannelID()))
-	err = p2p.ps.Publish(string(message.GetChannelID()), buf)
+	err = p2p.ps.Publish(p2p.topic(message.GetChannelID()), buf)
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(errors.E(errors.Op("Marshal proto"), fmt.Sprintf("%v, message data: %s", err.Error(), message.Data)))
+		p2p.journal(message.GetChannelID(), buf)
+		return
+	}
+	p2p.relay(message.GetChannelID(), buf)
+
+	// Nobody got the message, so keep it until a peer joins the channel
+	peers := p2p.ps.ListPeers(p2p.topic(message.GetChannelID()))
+	if len(peers) == 0 {
+		p2p.journal(message.GetChannelID(), buf)
+		return
 	}
+	p2p.floodPublish(peers, buf)
 }
 
-// listenForInput pushes new items in channel p2p.input to p2p.handleInput
+// listenForInput pushes queued messages to p2p.handleInput, control messages first
 func (p2p *P2p) listenForInput() {
 	go func() {
 		for {
-			select {
-			case message := <-p2p.input:
-				p2p.handleInput(&message)
+			message, ok := p2p.outbox.next(p2p.ctx)
+			if !ok {
+				return
+			}
+			p2p.handleInput(&message.WireMessage)
+			if message.published != nil {
+				close(message.published)
 			}
 		}
 	}()
 }
 
-// Send queues a message for sending to other peers
-func (p2p *P2p) Send(message *pb.WireMessage) {
-	go func(ctx context.Context) {
-		p2p.input <- *message
-	}(p2p.ctx)
+// Send queues a message for sending to other peers, in the queue of its class.
+// It blocks while the queue is full, giving up when ctx is done so a stuck broadcast doesn't hang the caller.
+func (p2p *P2p) Send(ctx context.Context, message *pb.WireMessage) error {
+	logger := requestid.Logger(ctx, p2p.Logger)
+	class := classify(message.GetOperation())
+	select {
+	case p2p.outbox.queue(class) <- queuedMessage{WireMessage: *message}:
+		logger.Debugf("Queued %s message for channel %s as %s", message.GetOperation(), message.GetChannelID(), class)
+		return nil
+	case <-ctx.Done():
+		logger.Debugf("Gave up queueing %s message for channel %s: %s", message.GetOperation(), message.GetChannelID(), ctx.Err())
+		return errors.E(errors.Op("Send message"), ctx.Err())
+	case <-p2p.ctx.Done():
+		return errors.E(errors.Op("Send message"), p2p.ctx.Err())
+	}
+}
+
+// Broadcast queues a message like Send, but returns only once it has been published, or journaled if no peer got it.
+// It gives up when ctx is done, in which case the message may still be published later.
+func (p2p *P2p) Broadcast(ctx context.Context, message *pb.WireMessage) error {
+	class := classify(message.GetOperation())
+	published := make(chan struct{})
+	select {
+	case p2p.outbox.queue(class) <- queuedMessage{WireMessage: *message, published: published}:
+	case <-ctx.Done():
+		return errors.E(errors.Op("Broadcast message"), ctx.Err())
+	case <-p2p.ctx.Done():
+		return errors.E(errors.Op("Broadcast message"), p2p.ctx.Err())
+	}
+	select {
+	case <-published:
+		return nil
+	case <-ctx.Done():
+		return errors.E(errors.Op("Wait for broadcast"), ctx.Err())
+	case <-p2p.ctx.Done():
+		return errors.E(errors.Op("Wait for broadcast"), p2p.ctx.Err())
+	}
 }
 
 // GetAllPeers returns all peers that we are currently connected to
@@ -245,7 +350,12 @@ func (p2p *P2p) Subscribe(channel *pb.Channel) (context.Context, error) {
 
 	p2p.Logger.Infof("Subscribing to channel %s with options: %s", channel.GetId(), channel.GetOptions())
 
-	topic, err := p2p.ps.Join(string(channel.GetId()))
+	err := p2p.ps.RegisterTopicValidator(p2p.topic(channel.GetId()), p2p.validateGossip(channel.GetId()))
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Warn(errors.E(errors.Op("Register gossip validator"), err))
+	}
+
+	topic, err := p2p.ps.Join(p2p.topic(channel.GetId()))
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Join libp2p Topic"), err)
 	}
@@ -263,13 +373,17 @@ func (p2p *P2p) Subscribe(channel *pb.Channel) (context.Context, error) {
 	// Listen for new data
 	p2p.listenToChannel(subCtx, sub, channel)
 
-	p2p.requestSync(subCtx, sub.Topic(), topic)
+	p2p.syncChannel(subCtx, string(channel.GetId()), topic, channel.GetHistory())
+
+	p2p.watchJournal(subCtx, channel.GetId(), topic)
 
 	go func(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			sub.Cancel()
 			topic.Close()
+			p2p.ps.UnregisterTopicValidator(p2p.topic(channel.GetId()))
+			p2p.unprotectChannel(channel.GetId())
 
 			p2p.subLock.Lock()
 			delete(p2p.subscriptions, string(channel.GetId()))
@@ -284,13 +398,26 @@ func (p2p *P2p) Subscribe(channel *pb.Channel) (context.Context, error) {
 	return subCtx, nil
 }
 
-// Unsubscribe sends a quit signal to a channel goroutine
+// Unsubscribe sends a quit signal to a channel goroutine. Channels that aren't subscribed to are ignored.
 func (p2p *P2p) Unsubscribe(channel *pb.Channel) {
-	p2p.subscriptions[string(channel.GetId())]()
+	p2p.subLock.RLock()
+	cancel, ok := p2p.subscriptions[string(channel.GetId())]
+	p2p.subLock.RUnlock()
+	if ok {
+		cancel()
+	}
 }
 
 // Run runs the p2p network
 func (p2p *P2p) Run() {
+	// A host given with the Host option is connected by whoever made it
+	if p2p.host != nil {
+		p2p.setStreamHandlers()
+		p2p.initPubSub()
+		p2p.listenForInput()
+		return
+	}
+
 	// Initialize the p2p host with options
 	p2p.InitHost(p2p.CreateOptions()...)
 
@@ -313,5 +440,16 @@ func (p2p *P2p) Run() {
 // Close closes the underlying libp2p host
 func (p2p *P2p) Close() {
 	p2p.Logger.Debug("P2P shutting down")
-	p2p.host.Close()
+	if p2p.bootstrapDone != nil {
+		close(p2p.bootstrapDone)
+		p2p.bootstrapDone = nil
+	}
+	if p2p.discoveryDone != nil {
+		close(p2p.discoveryDone)
+		p2p.discoveryDone = nil
+	}
+	// A standby node's host is only started once it's promoted
+	if p2p.host != nil {
+		p2p.host.Close()
+	}
 }
@@ -3,16 +3,20 @@ package p2p
 import (
 	"context"
 	"crypto/rand"
+	"encoding/binary"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	libp2p "github.com/libp2p/go-libp2p"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/config"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/service"
 	"github.com/sprawl/sprawl/util"
@@ -47,8 +51,8 @@ type TestReceiver struct {
 	mock.Mock
 }
 
-func (r *TestReceiver) Receive(data []byte, from peer.ID) error {
-	r.Called(data)
+func (r *TestReceiver) Receive(ctx context.Context, msg interfaces.IncomingMessage) error {
+	r.Called(msg.Data)
 	return nil
 }
 
@@ -79,13 +83,28 @@ func TestSend(t *testing.T) {
 	assert.NoError(t, err)
 
 	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
-	p2pInstance.Send(testWireMessage)
+	assert.NoError(t, p2pInstance.Send(context.Background(), testWireMessage))
 
-	message := <-p2pInstance.input
+	message := <-p2pInstance.outbox.queue(dataClass)
 	assert.Equal(t, message.ChannelID, testChannel.GetId())
 	assert.Equal(t, message.GetData(), testOrderInBytes)
 }
 
+func TestSendCancelled(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE}
+
+	// Nothing publishes the queue, so once it's full Send waits for the context
+	for i := 0; i < inputQueueSize; i++ {
+		assert.NoError(t, p2pInstance.Send(context.Background(), testWireMessage))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := p2pInstance.Send(ctx, testWireMessage)
+	assert.Error(t, err)
+	assert.Len(t, p2pInstance.outbox.queue(dataClass), inputQueueSize)
+}
+
 func TestSubscription(t *testing.T) {
 	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
 
@@ -105,7 +124,7 @@ func TestSubscription(t *testing.T) {
 	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
 
 	p2pInstance.listenForInput()
-	p2pInstance.Send(testWireMessage)
+	assert.NoError(t, p2pInstance.Send(context.Background(), testWireMessage))
 
 	p2pInstance.Unsubscribe(testChannel)
 
@@ -123,12 +142,12 @@ func TestPublish(t *testing.T) {
 	assert.NoError(t, err)
 
 	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
-	p2pInstance.Send(testWireMessage)
+	assert.NoError(t, p2pInstance.Send(context.Background(), testWireMessage))
 	wireMessageAsBytes, err := proto.Marshal(testWireMessage)
 	assert.NoError(t, err)
 	select {
-	case message := <-p2pInstance.input:
-		p2pInstance.handleInput(&message)
+	case message := <-p2pInstance.outbox.queue(dataClass):
+		p2pInstance.handleInput(&message.WireMessage)
 		msg, _ := sub.Next(p2pInstance.ctx)
 		assert.Equal(t, msg.GetData(), wireMessageAsBytes)
 	}
@@ -169,7 +188,7 @@ func TestChannelListener(t *testing.T) {
 	subCtx2, err := p2pInstance2.Subscribe(testChannel)
 	assert.NoError(t, err)
 
-	p2pInstance1.Send(testWireMessage)
+	assert.NoError(t, p2pInstance1.Send(context.Background(), testWireMessage))
 
 	p2pInstance1.Unsubscribe(testChannel)
 	p2pInstance2.Unsubscribe(testChannel)
@@ -228,6 +247,111 @@ func TestStreams(t *testing.T) {
 	assert.Len(t, p2pInstance1.streams, 0)
 }
 
+// pipeStream is a network.Stream over one end of a net.Pipe, standing in for a peer that stops responding
+type pipeStream struct {
+	network.Stream
+	conn  net.Conn
+	reset bool
+}
+
+func (s *pipeStream) Read(p []byte) (int, error)  { return s.conn.Read(p) }
+func (s *pipeStream) Write(p []byte) (int, error) { return s.conn.Write(p) }
+func (s *pipeStream) Close() error                { return s.conn.Close() }
+func (s *pipeStream) Reset() error {
+	s.reset = true
+	return s.conn.Close()
+}
+func (s *pipeStream) SetReadDeadline(t time.Time) error  { return s.conn.SetReadDeadline(t) }
+func (s *pipeStream) SetWriteDeadline(t time.Time) error { return s.conn.SetWriteDeadline(t) }
+
+func TestStreamDeadlines(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	assert.Equal(t, time.Duration(testConfig.GetStreamReadTimeout())*time.Second, p2pInstance.streamReadTimeout)
+	assert.Equal(t, time.Duration(testConfig.GetStreamWriteTimeout())*time.Second, p2pInstance.streamWriteTimeout)
+	p2pInstance.streamReadTimeout = 100 * time.Millisecond
+	p2pInstance.streamWriteTimeout = 100 * time.Millisecond
+
+	// A read deadline is reset by every frame
+	local, remote := net.Pipe()
+	defer remote.Close()
+	readStream := p2pInstance.newStream(&pipeStream{conn: local}, "")
+	go func() {
+		for i := 0; i < 3; i++ {
+			remote.Write([]byte{1, byte(i)})
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+	received := 0
+	err := readStream.receiveStream(context.Background(), func(ctx context.Context, msg interfaces.IncomingMessage) error {
+		assert.Equal(t, interfaces.TransportStream, msg.Transport)
+		received++
+		return nil
+	})
+	assert.False(t, errors.IsEmpty(err))
+	assert.Equal(t, 3, received)
+	assert.True(t, readStream.expired)
+	assert.True(t, readStream.stream.(*pipeStream).reset)
+
+	// A stream that the peer stops reading is reset and forgotten
+	local, remote = net.Pipe()
+	defer remote.Close()
+	writeStream := p2pInstance.newStream(&pipeStream{conn: local}, "")
+	p2pInstance.streams["peer"] = writeStream
+	writeStream.release = func() {
+		delete(p2pInstance.streams, "peer")
+	}
+	err = writeStream.WriteToStream([]byte("unread"))
+	assert.False(t, errors.IsEmpty(err))
+	assert.True(t, writeStream.expired)
+	assert.Empty(t, p2pInstance.streams)
+}
+
+func TestStreamFrameSize(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	length := make([]byte, binary.MaxVarintLen64)
+
+	// A frame is capped even if the maximum message size isn't limited
+	for _, maxFrameSize := range []uint64{0, maxStreamFrameSize * 2} {
+		local, remote := net.Pipe()
+		stream := p2pInstance.newStream(&pipeStream{conn: local}, "")
+		stream.maxFrameSize = maxFrameSize
+		go remote.Write(length[:binary.PutUvarint(length, maxStreamFrameSize+1)])
+		_, err := stream.readFrame()
+		assert.True(t, errors.Is(errors.Oversized, err))
+		assert.True(t, stream.stream.(*pipeStream).reset)
+		remote.Close()
+	}
+}
+
+func TestStreamStaleMessages(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+
+	// Duplicates and replays in a synced stream are skipped, other errors end it
+	local, remote := net.Pipe()
+	stream := p2pInstance.newStream(&pipeStream{conn: local}, "")
+	go func() {
+		for i := 0; i < 4; i++ {
+			remote.Write([]byte{1, byte(i)})
+		}
+		remote.Close()
+	}()
+	received := 0
+	err := stream.receiveStream(context.Background(), func(ctx context.Context, msg interfaces.IncomingMessage) error {
+		received++
+		switch msg.Data[0] {
+		case 0:
+			return errors.E(errors.Op("Compare membership versions"), errors.Replay, "older membership")
+		case 1:
+			return errors.E(errors.Op("Check for duplicate order"), errors.Duplicate, "duplicate order")
+		case 2:
+			return nil
+		}
+		return errors.E(errors.Op("Verify order"), errors.InvalidSignature, "invalid order")
+	})
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+	assert.Equal(t, 4, received)
+}
+
 func TestSyncRequest(t *testing.T) {
 	// Initialize p2p instances
 	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
@@ -254,10 +378,70 @@ func TestSyncRequest(t *testing.T) {
 	assert.NotEmpty(t, peerList)
 	assert.Contains(t, peerList, p2pInstance2.GetHostID())
 
-	err = p2pInstance1.sendSyncRequest(p2pInstance2.GetHostID(), string(testChannel.GetId()))
+	err = p2pInstance1.sendSyncRequest(p2pInstance2.GetHostID(), string(testChannel.GetId()), pb.History_OPEN_ORDERS)
 	time.Sleep(time.Second / 2)
 	assert.True(t, errors.IsEmpty(err))
 
 	// Check that the message was received on p2pInstance2's end
 	receiver.AssertCalled(t, "Receive", wireMessageAsBytes)
 }
+
+func TestSendToPeer(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.InitHost(p2pInstance.CreateOptions()...)
+
+	// A node can't send direct messages to itself
+	err := p2pInstance.SendToPeer(p2pInstance.GetHostID(), &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST, ChannelID: testChannel.GetId()})
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestVerifyRemoteKey(t *testing.T) {
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	publicKeyBytes, err := crypto.MarshalPublicKey(publicKey)
+	assert.NoError(t, err)
+	verifiedKey, err := verifyRemoteKey(peerID, publicKeyBytes)
+	assert.NoError(t, err)
+	assert.True(t, verifiedKey.Equals(publicKey))
+
+	// A peer presenting someone else's public key is rejected
+	publicKeyBytes2, err := crypto.MarshalPublicKey(publicKey2)
+	assert.NoError(t, err)
+	_, err = verifyRemoteKey(peerID, publicKeyBytes2)
+	assert.Error(t, err)
+}
+
+func TestHandshake(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+	p2pInstance2.subscriptions[string(testChannel.GetId())] = func() {}
+
+	err := p2pInstance1.host.Connect(p2pInstance1.ctx, p2pInstance2.GetAddrInfo())
+	assert.NoError(t, err)
+
+	stream, err := p2pInstance1.OpenStream(p2pInstance2.GetHostID())
+	assert.NoError(t, err)
+	openedStream := stream.(*Stream)
+	assert.True(t, openedStream.remotePublicKey.Equals(publicKey2))
+	assert.Equal(t, protocolVersion, openedStream.remoteVersion)
+	assert.Equal(t, [][]byte{testChannel.GetId()}, openedStream.remoteChannels)
+	assert.Equal(t, p2pInstance1.protocolIDs()[0], openedStream.stream.Protocol())
+	assert.True(t, openedStream.HasCapability(interfaces.CapabilityMembership))
+
+	p2pInstance1.CloseStream(p2pInstance2.GetHostID())
+}
+
+func TestProtocolVersions(t *testing.T) {
+	assert.True(t, isCompatibleVersion(protocolVersion))
+	assert.True(t, isCompatibleVersion("1.0.0"))
+	assert.False(t, isCompatibleVersion("2.0.0"))
+	assert.False(t, isCompatibleVersion(""))
+
+	// Nodes before 1.1.0 don't send capabilities
+	assert.Empty(t, negotiateCapabilities(nil))
+	assert.Equal(t, map[string]bool{interfaces.CapabilityMembership: true}, negotiateCapabilities([]string{"unknown", interfaces.CapabilityMembership}))
+}
@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// identify stores the versions a peer sends when connecting under these peerstore keys
+const agentVersionKey string = "AgentVersion"
+const protocolVersionKey string = "ProtocolVersion"
+
+// GetPeerDetails returns the connected peers with their addresses, versions, the channels they're on with this node,
+// the direction of the connection, their latency and how much data has been exchanged with them
+func (p2p *P2p) GetPeerDetails() []*pb.PeerDetails {
+	channels := p2p.getSharedChannels()
+	peerIDs := p2p.host.Network().Peers()
+	details := make([]*pb.PeerDetails, 0, len(peerIDs))
+	for _, peerID := range peerIDs {
+		detail := &pb.PeerDetails{
+			Id:              peerID.String(),
+			AgentVersion:    p2p.getPeerstoreString(peerID, agentVersionKey),
+			ProtocolVersion: p2p.getPeerstoreString(peerID, protocolVersionKey),
+			Channels:        channels[peerID],
+			Latency:         int64(p2p.host.Peerstore().LatencyEWMA(peerID) / time.Millisecond),
+		}
+		for _, conn := range p2p.host.Network().ConnsToPeer(peerID) {
+			detail.Addresses = append(detail.Addresses, conn.RemoteMultiaddr().String())
+			if detail.Direction == pb.Direction_UNKNOWN_DIRECTION {
+				detail.Direction = getDirection(conn.Stat().Direction)
+			}
+		}
+		stats := p2p.bandwidth.GetBandwidthForPeer(peerID)
+		detail.BytesIn, detail.BytesOut = uint64(stats.TotalIn), uint64(stats.TotalOut)
+		detail.RateIn, detail.RateOut = stats.RateIn, stats.RateOut
+
+		p2p.streamLock.RLock()
+		if stream, ok := p2p.streams[peerID.String()]; ok {
+			detail.SprawlVersion = stream.remoteVersion
+		}
+		p2p.streamLock.RUnlock()
+
+		details = append(details, detail)
+	}
+	return details
+}
+
+// getSharedChannels returns the joined channels each peer is on too
+func (p2p *P2p) getSharedChannels() map[peer.ID][][]byte {
+	channels := make(map[peer.ID][][]byte)
+	if p2p.ps == nil {
+		return channels
+	}
+	p2p.subLock.RLock()
+	defer p2p.subLock.RUnlock()
+	for channelID := range p2p.subscriptions {
+		for _, peerID := range p2p.ps.ListPeers(p2p.topic([]byte(channelID))) {
+			channels[peerID] = append(channels[peerID], []byte(channelID))
+		}
+	}
+	return channels
+}
+
+// getPeerstoreString returns a string the peerstore has on a peer, or "" if it has none
+func (p2p *P2p) getPeerstoreString(peerID peer.ID, key string) string {
+	value, err := p2p.host.Peerstore().Get(peerID, key)
+	if err != nil {
+		return ""
+	}
+	text, _ := value.(string)
+	return text
+}
+
+// getDirection converts the direction of a libp2p connection to its protobuf counterpart
+func getDirection(direction network.Direction) pb.Direction {
+	switch direction {
+	case network.DirInbound:
+		return pb.Direction_INBOUND
+	case network.DirOutbound:
+		return pb.Direction_OUTBOUND
+	default:
+		return pb.Direction_UNKNOWN_DIRECTION
+	}
+}
@@ -0,0 +1,42 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPeerDetails(t *testing.T) {
+	p2pInstance1 := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance2 := NewP2p(testConfig, privateKey2, publicKey2, Logger(log))
+
+	p2pInstance1.InitHost(p2pInstance1.CreateOptions()...)
+	p2pInstance2.InitHost(p2pInstance2.CreateOptions()...)
+
+	err := p2pInstance1.host.Connect(p2pInstance1.ctx, p2pInstance2.GetAddrInfo())
+	assert.NoError(t, err)
+	_, err = p2pInstance1.OpenStream(p2pInstance2.GetHostID())
+	assert.NoError(t, err)
+	defer p2pInstance1.CloseStream(p2pInstance2.GetHostID())
+
+	details := findPeerDetails(p2pInstance1.GetPeerDetails(), p2pInstance2.GetHostIDString())
+	assert.NotNil(t, details)
+	assert.NotEmpty(t, details.GetAddresses())
+	assert.Equal(t, pb.Direction_OUTBOUND, details.GetDirection())
+	assert.Equal(t, protocolVersion, details.GetSprawlVersion())
+	assert.NotZero(t, details.GetBytesOut())
+
+	details = findPeerDetails(p2pInstance2.GetPeerDetails(), p2pInstance1.GetHostIDString())
+	assert.NotNil(t, details)
+	assert.Equal(t, pb.Direction_INBOUND, details.GetDirection())
+}
+
+func findPeerDetails(details []*pb.PeerDetails, peerID string) *pb.PeerDetails {
+	for _, detail := range details {
+		if detail.GetId() == peerID {
+			return detail
+		}
+	}
+	return nil
+}
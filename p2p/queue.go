@@ -0,0 +1,157 @@
+package p2p
+
+import (
+	"context"
+	"time"
+
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// messageClass sets which outbound queue a message waits in before it's published
+type messageClass int
+
+const (
+	// controlClass is for time-sensitive changes to existing orders and channels, like locks, unlocks and deletes.
+	// They're published before anything else and never rate limited.
+	controlClass messageClass = iota
+	// dataClass is for new orders
+	dataClass
+	// bulkClass is for sync requests, snapshots and market data, which can wait
+	bulkClass
+	messageClasses
+)
+
+func (class messageClass) String() string {
+	switch class {
+	case controlClass:
+		return "control"
+	case dataClass:
+		return "data"
+	default:
+		return "bulk"
+	}
+}
+
+// classify returns the class of a message by its operation
+func classify(operation pb.Operation) messageClass {
+	switch operation {
+	case pb.Operation_CREATE:
+		return dataClass
+	case pb.Operation_SYNC_REQUEST, pb.Operation_SYNC_RECEIVE, pb.Operation_SYNC_HISTORY, pb.Operation_CANDLE, pb.Operation_ORDER_DIGEST:
+		return bulkClass
+	default:
+		return controlClass
+	}
+}
+
+// queuedMessage is a message waiting in a queue, with a channel that's closed once it has been published if the sender waits for that
+type queuedMessage struct {
+	pb.WireMessage
+	published chan struct{}
+}
+
+// outbox holds a queue for each message class. Only the goroutine publishing the messages reads it.
+type outbox struct {
+	queues [messageClasses]chan queuedMessage
+	// interval is the least time between the messages of a class, 0 if the class isn't rate limited
+	interval [messageClasses]time.Duration
+	// notBefore is when the next message of a class may be published
+	notBefore [messageClasses]time.Time
+}
+
+// rateInterval converts a rate limit in messages per second to the time between messages
+func rateInterval(rate uint) time.Duration {
+	if rate == 0 {
+		return 0
+	}
+	return time.Second / time.Duration(rate)
+}
+
+func newOutbox(config interfaces.Config) *outbox {
+	box := &outbox{}
+	for class := range box.queues {
+		box.queues[class] = make(chan queuedMessage, inputQueueSize)
+	}
+	box.interval[dataClass] = rateInterval(config.GetQueueDataRate())
+	box.interval[bulkClass] = rateInterval(config.GetQueueBulkRate())
+	return box
+}
+
+// queue returns the queue of a message class
+func (box *outbox) queue(class messageClass) chan queuedMessage {
+	return box.queues[class]
+}
+
+// ready returns the queue of a class if its next message may be published now, and nil otherwise,
+// so that receiving from it in a select blocks until the class's turn comes
+func (box *outbox) ready(class messageClass, now time.Time) chan queuedMessage {
+	if now.Before(box.notBefore[class]) {
+		return nil
+	}
+	return box.queues[class]
+}
+
+// sent holds the class's next message back by its rate limit
+func (box *outbox) sent(class messageClass, now time.Time) {
+	box.notBefore[class] = now.Add(box.interval[class])
+}
+
+// untilReady returns how long until a rate limited class that has messages waiting may publish again, 0 if none is waiting
+func (box *outbox) untilReady(now time.Time) time.Duration {
+	var wait time.Duration
+	for class := range box.queues {
+		if len(box.queues[class]) == 0 || !now.Before(box.notBefore[class]) {
+			continue
+		}
+		if until := box.notBefore[class].Sub(now); wait == 0 || until < wait {
+			wait = until
+		}
+	}
+	return wait
+}
+
+// next waits for the next message to publish. Control messages go first, then new orders and then bulk messages,
+// each no faster than its class's rate limit, so a burst of bulk messages can't hold up locks and deletes.
+// It returns false once ctx is done.
+func (box *outbox) next(ctx context.Context) (queuedMessage, bool) {
+	for {
+		now := time.Now()
+		// Take a waiting message of the highest class whose turn it is
+		for class := controlClass; class < messageClasses; class++ {
+			select {
+			case message := <-box.ready(class, now):
+				box.sent(class, now)
+				return message, true
+			default:
+			}
+		}
+
+		// Otherwise wait for a message, or for a rate limited class to get its turn
+		var timer *time.Timer
+		var turn <-chan time.Time
+		if wait := box.untilReady(now); wait > 0 {
+			timer = time.NewTimer(wait)
+			turn = timer.C
+		}
+		class, message, ok := controlClass, queuedMessage{}, true
+		select {
+		case message = <-box.ready(controlClass, now):
+		case message = <-box.ready(dataClass, now):
+			class = dataClass
+		case message = <-box.ready(bulkClass, now):
+			class = bulkClass
+		case <-turn:
+			continue
+		case <-ctx.Done():
+			ok = false
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ok {
+			box.sent(class, time.Now())
+		}
+		return message, ok
+	}
+}
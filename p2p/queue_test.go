@@ -0,0 +1,100 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, controlClass, classify(pb.Operation_LOCK))
+	assert.Equal(t, controlClass, classify(pb.Operation_UNLOCK))
+	assert.Equal(t, controlClass, classify(pb.Operation_DELETE))
+	assert.Equal(t, controlClass, classify(pb.Operation_FILL))
+	assert.Equal(t, dataClass, classify(pb.Operation_CREATE))
+	assert.Equal(t, bulkClass, classify(pb.Operation_SYNC_REQUEST))
+	assert.Equal(t, bulkClass, classify(pb.Operation_SYNC_RECEIVE))
+	assert.Equal(t, bulkClass, classify(pb.Operation_CANDLE))
+}
+
+func TestSendControlFirst(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	ctx := context.Background()
+
+	// A full queue of bulk messages doesn't hold up a lock sent after them
+	for i := 0; i < inputQueueSize; i++ {
+		assert.NoError(t, p2pInstance.Send(ctx, &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_SYNC_REQUEST}))
+	}
+	assert.NoError(t, p2pInstance.Send(ctx, &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE}))
+	assert.NoError(t, p2pInstance.Send(ctx, &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_LOCK}))
+
+	message, ok := p2pInstance.outbox.next(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, pb.Operation_LOCK, message.GetOperation())
+	message, ok = p2pInstance.outbox.next(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, pb.Operation_CREATE, message.GetOperation())
+	message, ok = p2pInstance.outbox.next(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, pb.Operation_SYNC_REQUEST, message.GetOperation())
+}
+
+func TestSendRateLimited(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	p2pInstance.outbox.interval[bulkClass] = 50 * time.Millisecond
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, p2pInstance.Send(ctx, &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_SYNC_REQUEST}))
+	}
+	_, ok := p2pInstance.outbox.next(ctx)
+	assert.True(t, ok)
+
+	// The second bulk message waits for its turn, but a delete doesn't
+	assert.NoError(t, p2pInstance.Send(ctx, &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_DELETE}))
+	start := time.Now()
+	message, ok := p2pInstance.outbox.next(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, pb.Operation_DELETE, message.GetOperation())
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+
+	message, ok = p2pInstance.outbox.next(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, pb.Operation_SYNC_REQUEST, message.GetOperation())
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+
+	// Nothing is left, so next gives up once the context is done
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, ok = p2pInstance.outbox.next(cancelled)
+	assert.False(t, ok)
+}
+
+func TestBroadcast(t *testing.T) {
+	p2pInstance := NewP2p(testConfig, privateKey, publicKey, Logger(log))
+	ctx := context.Background()
+
+	// Broadcast only returns once the queued message has been published
+	done := make(chan error)
+	go func() {
+		done <- p2pInstance.Broadcast(ctx, &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE})
+	}()
+	message, ok := p2pInstance.outbox.next(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, pb.Operation_CREATE, message.GetOperation())
+	select {
+	case <-done:
+		t.Fatal("Broadcast returned before the message was published")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(message.published)
+	assert.NoError(t, <-done)
+
+	// Nobody publishes the message, so Broadcast gives up once the context is done
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, p2pInstance.Broadcast(cancelled, &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE}))
+}
@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	autonat "github.com/libp2p/go-libp2p-autonat"
+	"github.com/libp2p/go-libp2p-core/network"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// natPollInterval is how often CheckReachability looks whether AutoNAT has an answer yet
+const natPollInterval time.Duration = time.Second
+
+// privateNets are the IP ranges that can't be reached from outside a local network
+var privateNets = parseCIDRs("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7")
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		_, ipNet, _ := net.ParseCIDR(cidr)
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+// isPublicAddr tells if an address could be reached from outside the local network. DNS addresses are assumed to be.
+func isPublicAddr(addr ma.Multiaddr) bool {
+	if _, err := addr.ValueForProtocol(ma.P_CIRCUIT); err == nil {
+		return false
+	}
+	ip := addrIP(addr)
+	if ip == nil {
+		return true
+	}
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for _, ipNet := range privateNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkExternalAddrs warns if none of the addresses the node announces can be reached from outside its local network.
+// NAT port mappings and relay addresses are only found a while after the host starts, so it can't tell about them yet.
+func (p2p *P2p) checkExternalAddrs() {
+	addrs := p2p.host.Addrs()
+	for _, addr := range addrs {
+		if isPublicAddr(addr) {
+			return
+		}
+	}
+	announced := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		announced = append(announced, addr.String())
+	}
+	if p2p.Config.GetNATPortMapSetting() || p2p.Config.GetAutoRelaySetting() {
+		p2p.Logger.Warnf("None of the announced addresses [%s] is reachable from outside the local network yet, so peers can only connect once a NAT port mapping or a relay is found", strings.Join(announced, ", "))
+		return
+	}
+	p2p.Logger.Warnf("None of the announced addresses [%s] is reachable from outside the local network, so only local peers can connect. Set p2p.externalIP or p2p.announceAddresses to a public address", strings.Join(announced, ", "))
+}
+
+// CheckBootstrapPeers connects to the bootstrap peers this node isn't connected to yet,
+// and returns how many of them it's connected to out of how many the bootstrap addresses resolved to
+func (p2p *P2p) CheckBootstrapPeers() (int, int) {
+	peerinfos := p2p.resolveBootstrapPeers(p2p.ctx)
+	if p2p.host == nil {
+		return 0, len(peerinfos)
+	}
+	p2p.connectToPeers(peerinfos)
+
+	connected := 0
+	for _, peerinfo := range peerinfos {
+		if p2p.host.Network().Connectedness(peerinfo.ID) == network.Connected {
+			connected++
+		}
+	}
+	return connected, len(peerinfos)
+}
+
+// CheckReachability waits until AutoNAT has had a connected peer dial this node back, and tells if it could.
+// AutoNAT waits a while after the host starts before asking, so the status stays unknown if ctx is done first.
+func (p2p *P2p) CheckReachability(ctx context.Context) autonat.NATStatus {
+	if p2p.nat == nil {
+		return autonat.NATStatusUnknown
+	}
+	ticker := time.NewTicker(natPollInterval)
+	defer ticker.Stop()
+	for {
+		if status := p2p.nat.Status(); status != autonat.NATStatusUnknown {
+			return status
+		}
+		select {
+		case <-ctx.Done():
+			return autonat.NATStatusUnknown
+		case <-ticker.C:
+		}
+	}
+}
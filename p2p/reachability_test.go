@@ -0,0 +1,27 @@
+package p2p
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"/ip4/1.2.3.4/tcp/4001":                true,
+		"/ip6/2001:db8::1/udp/4001/quic":       true,
+		"/dns4/bootstrap.example.com/tcp/4001": true,
+		"/ip4/127.0.0.1/tcp/4001":              false,
+		"/ip4/0.0.0.0/tcp/4001":                false,
+		"/ip4/192.168.1.10/tcp/4001":           false,
+		"/ip4/100.64.0.1/tcp/4001":             false,
+		"/ip6/fd00::1/tcp/4001":                false,
+		"/ip6/fe80::1/tcp/4001":                false,
+		"/ip4/1.2.3.4/tcp/4001/p2p-circuit":    false,
+	} {
+		mAddr, err := ma.NewMultiaddr(addr)
+		assert.NoError(t, err)
+		assert.Equal(t, public, isPublicAddr(mAddr), addr)
+	}
+}
@@ -0,0 +1,287 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// relayVersion is the version of the relay protocols, whose IDs are made by relayProtocolID and relayHopProtocolID
+const relayVersion = "1.0.0"
+
+// relaySeenTTL is how long a relayed message is remembered, so copies arriving over other paths are dropped
+const relaySeenTTL = 2 * time.Minute
+
+// maxRelayedMessageSize bounds a relayed message read from a stream
+const maxRelayedMessageSize = 1 << 20
+
+// relaySendTimeout is how long handing a relayed message to a single peer may take
+const relaySendTimeout = 10 * time.Second
+
+// relaySeen remembers the relayed messages this node has already handled.
+// They're kept in the storage when there is one, and in memory otherwise.
+type relaySeen struct {
+	seen    map[string]time.Time
+	storage interfaces.Storage
+	lock    sync.Mutex
+}
+
+func newRelaySeen() *relaySeen {
+	return &relaySeen{seen: make(map[string]time.Time)}
+}
+
+func getRelaySeenStorageKey(id string) []byte {
+	return []byte(string(interfaces.RelayPrefix) + id)
+}
+
+// add records a message and tells if it hadn't been seen yet. Messages seen before relaySeenTTL are forgotten.
+func (r *relaySeen) add(id string, now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.storage != nil {
+		return r.addToStorage(id)
+	}
+	for seenID, seen := range r.seen {
+		if now.Sub(seen) > relaySeenTTL {
+			delete(r.seen, seenID)
+		}
+	}
+	if _, ok := r.seen[id]; ok {
+		return false
+	}
+	r.seen[id] = now
+	return true
+}
+
+// addToStorage records a message in the storage, which forgets it once relaySeenTTL has passed.
+// A message is handled again if the storage fails, as dropping it could lose an order.
+func (r *relaySeen) addToStorage(id string) bool {
+	ctx := context.Background()
+	key := getRelaySeenStorageKey(id)
+	seen, err := r.storage.Has(ctx, key)
+	if errors.IsEmpty(err) && seen {
+		return false
+	}
+	r.storage.PutWithTTL(ctx, key, nil, relaySeenTTL)
+	return true
+}
+
+// getRelayedID identifies a relayed message by its origin and data, whatever path it took
+func getRelayedID(origin peer.ID, data []byte) string {
+	hash := sha256.Sum256(append([]byte(origin), data...))
+	return string(hash[:])
+}
+
+// verifyRelayedMessage checks that the relayed message is signed by the peer whose public key it carries, and returns that peer.
+// Relays can't change what the origin sent or pretend to be it.
+func verifyRelayedMessage(relayed *pb.RelayedMessage) (peer.ID, error) {
+	publicKey, err := crypto.UnmarshalPublicKey(relayed.GetPublicKey())
+	if !errors.IsEmpty(err) {
+		return "", errors.E(errors.Op("Unmarshal relayed message public key"), errors.Malformed, err)
+	}
+	origin, err := peer.IDFromPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return "", errors.E(errors.Op("Get relayed message origin"), errors.Malformed, err)
+	}
+	valid, err := publicKey.Verify(relayed.GetData(), relayed.GetSignature())
+	if !errors.IsEmpty(err) || !valid {
+		return "", errors.E(errors.Op("Verify relayed message"), errors.InvalidSignature, "relayed message isn't signed by its origin")
+	}
+	return origin, nil
+}
+
+// getRelayPeers returns the connected peers that forward relayed messages
+func (p2p *P2p) getRelayPeers() []peer.ID {
+	relays := []peer.ID{}
+	for _, peerID := range p2p.host.Network().Peers() {
+		supported, err := p2p.host.Peerstore().SupportsProtocols(peerID, string(p2p.relayHopProtocolID()))
+		if errors.IsEmpty(err) && len(supported) > 0 {
+			relays = append(relays, peerID)
+		}
+	}
+	return relays
+}
+
+// relay hands a message this node published to the relays it's connected to,
+// so members of the channel that this node can't reach still get it
+func (p2p *P2p) relay(channelID []byte, data []byte) {
+	hops := p2p.Config.GetGossipRelayHops()
+	relays := p2p.getRelayPeers()
+	if hops == 0 || len(relays) == 0 {
+		return
+	}
+	signature, err := p2p.privateKey.Sign(data)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Warn(errors.E(errors.Op("Sign relayed message"), err))
+		return
+	}
+	publicKey, err := crypto.MarshalPublicKey(p2p.publicKey)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Warn(errors.E(errors.Op("Marshal public key for relaying"), err))
+		return
+	}
+	relayed := &pb.RelayedMessage{Data: data, PublicKey: publicKey, Signature: signature, Hops: uint32(hops)}
+	p2p.relaySeen.add(getRelayedID(p2p.host.ID(), data), time.Now())
+	p2p.Logger.Debugf("Handing a message of channel %s to %d relays", string(channelID), len(relays))
+	for _, relayID := range relays {
+		go p2p.sendRelayed(relayID, p2p.relayHopProtocolID(), relayed)
+	}
+}
+
+// sendRelayed writes a relayed message to a peer over a stream of its own, prefixed with its length as a varint
+func (p2p *P2p) sendRelayed(peerID peer.ID, protocolID protocol.ID, relayed *pb.RelayedMessage) {
+	data, err := proto.Marshal(relayed)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Warn(errors.E(errors.Op("Marshal relayed message"), err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(p2p.ctx, relaySendTimeout)
+	defer cancel()
+	stream, err := p2p.host.NewStream(ctx, peerID, protocolID)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Debug(errors.E(errors.Op("Open relay stream to "+peerID.String()), err))
+		return
+	}
+	defer stream.Close()
+	stream.SetWriteDeadline(time.Now().Add(relaySendTimeout))
+
+	lengthPrefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lengthPrefix, uint64(len(data)))
+	_, err = stream.Write(append(lengthPrefix[:n], data...))
+	if err != nil {
+		stream.Reset()
+		p2p.Logger.Debug(errors.E(errors.Op("Write relayed message to "+peerID.String()), err))
+	}
+}
+
+// handleRelay reads a relayed message from a peer, delivers it if this node is on its channel and forwards it if this node relays
+func (p2p *P2p) handleRelay(stream network.Stream) {
+	defer stream.Close()
+	remotePeer := stream.Conn().RemotePeer()
+	if p2p.streamReadTimeout > 0 {
+		stream.SetReadDeadline(time.Now().Add(p2p.streamReadTimeout))
+	}
+
+	reader := bufio.NewReader(stream)
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		p2p.Logger.Debugf("Invalid relayed message from %s", remotePeer)
+		stream.Reset()
+		return
+	}
+	if length > maxRelayedMessageSize {
+		p2p.Logger.Debugf("Rejecting relayed message of %d bytes from %s", length, remotePeer)
+		stream.Reset()
+		p2p.penalize(remotePeer, errors.E(errors.Op("Read relayed message"), errors.Oversized, "relayed message is too large"))
+		return
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(reader, data)
+	if err != nil {
+		stream.Reset()
+		return
+	}
+
+	err = p2p.receiveRelayed(data, remotePeer)
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Debug(errors.E(errors.Op("Receive relayed message from "+remotePeer.String()), err))
+		p2p.penalize(remotePeer, err)
+	}
+}
+
+// receiveRelayed handles a relayed message once, however many relays it arrives from.
+// The message is passed to the Receiver as if the origin had sent it, since the origin signed it.
+func (p2p *P2p) receiveRelayed(data []byte, from peer.ID) error {
+	throttleScore := int32(p2p.Config.GetThrottleScore())
+	if !p2p.reputation.allow(from, time.Now(), p2p.Config.GetMessageRateLimit(), throttleScore) {
+		p2p.Logger.Debugf("Dropping relayed message from %s, rate limit exceeded", from)
+		return nil
+	}
+
+	relayed := &pb.RelayedMessage{}
+	err := proto.Unmarshal(data, relayed)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal relayed message"), errors.Malformed, err)
+	}
+	origin, err := verifyRelayedMessage(relayed)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = p2p.checkMessageSize(relayed.GetData())
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if origin == p2p.host.ID() || !p2p.relaySeen.add(getRelayedID(origin, relayed.GetData()), time.Now()) {
+		return nil
+	}
+	wireMessage := &pb.WireMessage{}
+	err = proto.Unmarshal(relayed.GetData(), wireMessage)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal relayed wiremessage"), errors.Malformed, err)
+	}
+	channelID := wireMessage.GetChannelID()
+
+	p2p.subLock.RLock()
+	_, joined := p2p.subscriptions[string(channelID)]
+	p2p.subLock.RUnlock()
+	if joined && p2p.Receiver != nil {
+		err = p2p.receive(p2p.ctx, interfaces.IncomingMessage{
+			From:       origin,
+			ChannelID:  channelID,
+			Transport:  interfaces.TransportRelay,
+			ReceivedAt: time.Now(),
+			Data:       relayed.GetData(),
+		})
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Debug(errors.E(errors.Op("Receive message relayed from "+origin.String()), err))
+		}
+	}
+
+	if p2p.Config.GetGossipRelaySetting() && relayed.GetHops() > 0 {
+		p2p.forwardRelayed(relayed, channelID, from, origin)
+	}
+	return nil
+}
+
+// forwardRelayed passes a relayed message on to the members of its channel and to other relays, with one hop less.
+// Members get it over the relay protocol, and relays over the hop protocol, which they deliver and forward again.
+func (p2p *P2p) forwardRelayed(relayed *pb.RelayedMessage, channelID []byte, from peer.ID, origin peer.ID) {
+	forwarded := &pb.RelayedMessage{Data: relayed.GetData(), PublicKey: relayed.GetPublicKey(), Signature: relayed.GetSignature(), Hops: relayed.GetHops() - 1}
+
+	targets := make(map[peer.ID]protocol.ID)
+	for _, peerID := range p2p.ps.ListPeers(p2p.topic(channelID)) {
+		targets[peerID] = p2p.relayProtocolID()
+	}
+	if forwarded.GetHops() > 0 {
+		for _, peerID := range p2p.getRelayPeers() {
+			targets[peerID] = p2p.relayHopProtocolID()
+		}
+	}
+	delete(targets, from)
+	delete(targets, origin)
+	delete(targets, p2p.host.ID())
+
+	for peerID, protocolID := range targets {
+		// Browser peers can't open Sprawl streams
+		if p2p.isBrowserPeer(peerID) {
+			continue
+		}
+		go p2p.sendRelayed(peerID, protocolID, forwarded)
+	}
+	if len(targets) > 0 {
+		p2p.Logger.Debugf("Relayed a message of channel %s from %s to %d peers", string(channelID), origin, len(targets))
+	}
+}
@@ -0,0 +1,78 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRelayedMessage(t *testing.T, data []byte) *pb.RelayedMessage {
+	signature, err := privateKey.Sign(data)
+	assert.NoError(t, err)
+	publicKeyBytes, err := crypto.MarshalPublicKey(publicKey)
+	assert.NoError(t, err)
+	return &pb.RelayedMessage{Data: data, PublicKey: publicKeyBytes, Signature: signature, Hops: 2}
+}
+
+func TestRelaySeen(t *testing.T) {
+	seen := newRelaySeen()
+	now := time.Now()
+	origin, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	id := getRelayedID(origin, []byte("message"))
+
+	assert.True(t, seen.add(id, now))
+	assert.False(t, seen.add(id, now.Add(time.Minute)))
+	assert.True(t, seen.add(getRelayedID(origin, []byte("other")), now))
+
+	// Messages are forgotten after a while
+	assert.True(t, seen.add(id, now.Add(relaySeenTTL+time.Second)))
+}
+
+func TestVerifyRelayedMessage(t *testing.T) {
+	relayed := newTestRelayedMessage(t, []byte("message"))
+	origin, err := verifyRelayedMessage(relayed)
+	assert.NoError(t, err)
+	assert.True(t, origin.MatchesPublicKey(publicKey))
+
+	// A relay can't change the message
+	relayed.Data = []byte("changed")
+	_, err = verifyRelayedMessage(relayed)
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+
+	// Nor sign it as someone else
+	otherKey, err := crypto.MarshalPublicKey(publicKey2)
+	assert.NoError(t, err)
+	relayed = newTestRelayedMessage(t, []byte("message"))
+	relayed.PublicKey = otherKey
+	_, err = verifyRelayedMessage(relayed)
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+
+	relayed.PublicKey = []byte("invalid")
+	_, err = verifyRelayedMessage(relayed)
+	assert.True(t, errors.Is(errors.Malformed, err))
+}
+
+func TestRelaySeenStorage(t *testing.T) {
+	seen := newRelaySeen()
+	seen.storage = &inmemory.Storage{Db: make(map[string]string)}
+	origin, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	id := getRelayedID(origin, []byte("message"))
+
+	assert.True(t, seen.add(id, time.Now()))
+	assert.False(t, seen.add(id, time.Now()))
+	assert.True(t, seen.add(getRelayedID(origin, []byte("other")), time.Now()))
+	assert.Empty(t, seen.seen)
+
+	has, err := seen.storage.Has(context.Background(), getRelaySeenStorageKey(id))
+	assert.NoError(t, err)
+	assert.True(t, has)
+}
@@ -0,0 +1,197 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const maxScore int32 = 100
+const invalidSignaturePenalty int32 = 10
+const replayPenalty int32 = 5
+const malformedPenalty int32 = 5
+const spamPenalty int32 = 10
+const oversizedPenalty int32 = 10
+const rateWindow time.Duration = time.Second
+
+// throttledRateDivisor divides the message rate limit of peers that are being throttled
+const throttledRateDivisor uint = 10
+
+// peerReputation counts the misbehaviour of a single peer
+type peerReputation struct {
+	invalidSignatures uint32
+	replays           uint32
+	malformed         uint32
+	spam              uint32
+	oversized         uint32
+	windowStart       time.Time
+	windowMessages    uint
+}
+
+func (rep *peerReputation) score() int32 {
+	score := maxScore -
+		int32(rep.invalidSignatures)*invalidSignaturePenalty -
+		int32(rep.replays)*replayPenalty -
+		int32(rep.malformed)*malformedPenalty -
+		int32(rep.spam)*spamPenalty -
+		int32(rep.oversized)*oversizedPenalty
+	if score < 0 {
+		return 0
+	}
+	return score
+}
+
+// reputation keeps score of all peers that have sent data to this node
+type reputation struct {
+	peers map[peer.ID]*peerReputation
+	lock  sync.Mutex
+}
+
+func newReputation() *reputation {
+	return &reputation{peers: make(map[peer.ID]*peerReputation)}
+}
+
+func (r *reputation) get(peerID peer.ID) *peerReputation {
+	rep, ok := r.peers[peerID]
+	if !ok {
+		rep = &peerReputation{}
+		r.peers[peerID] = rep
+	}
+	return rep
+}
+
+// allow counts a message from the peer and tells if it's within the peer's rate limit.
+// Going over the limit counts as spam once per window.
+func (r *reputation) allow(peerID peer.ID, now time.Time, rateLimit uint, throttleScore int32) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	rep := r.get(peerID)
+
+	if now.Sub(rep.windowStart) >= rateWindow {
+		rep.windowStart = now
+		rep.windowMessages = 0
+	}
+	rep.windowMessages++
+
+	limit := rateLimit
+	if rep.score() < throttleScore {
+		limit = rateLimit / throttledRateDivisor
+		if limit == 0 {
+			limit = 1
+		}
+	}
+	if rateLimit == 0 || rep.windowMessages <= limit {
+		return true
+	}
+	if rep.windowMessages == limit+1 {
+		rep.spam++
+	}
+	return false
+}
+
+// record lowers the peer's score if err tells that the data it sent was invalid
+func (r *reputation) record(peerID peer.ID, err error) int32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	rep := r.get(peerID)
+
+	switch {
+	case errors.Is(errors.InvalidSignature, err):
+		rep.invalidSignatures++
+	case errors.Is(errors.Replay, err):
+		rep.replays++
+	case errors.Is(errors.Malformed, err):
+		rep.malformed++
+	case errors.Is(errors.Throttled, err):
+		rep.spam++
+	case errors.Is(errors.Oversized, err):
+		rep.oversized++
+	}
+	return rep.score()
+}
+
+func (r *reputation) scores() []*pb.PeerScore {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	scores := make([]*pb.PeerScore, 0, len(r.peers))
+	for peerID, rep := range r.peers {
+		scores = append(scores, &pb.PeerScore{
+			Id:                peerID.String(),
+			Score:             rep.score(),
+			InvalidSignatures: rep.invalidSignatures,
+			Replays:           rep.replays,
+			Malformed:         rep.malformed,
+			Spam:              rep.spam,
+			Oversized:         rep.oversized,
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].GetId() < scores[j].GetId() })
+	return scores
+}
+
+// receive passes a message from a peer to the Receiver, scoring the peer by how valid its data is.
+// Peers that send too much are throttled, and peers whose score drops too low are disconnected.
+// Messages from browser peers are dropped, since they're read-only.
+func (p2p *P2p) receive(ctx context.Context, msg interfaces.IncomingMessage) error {
+	from := msg.From
+	if p2p.chaos.delay() {
+		p2p.Logger.Debugf("Chaos mode dropped a message from %s", from)
+		return nil
+	}
+
+	// Browser clients only follow the order feed
+	if p2p.Config.GetBrowserTransportsSetting() && p2p.isBrowserPeer(from) {
+		p2p.Logger.Debugf("Dropping message from browser peer %s", from)
+		return nil
+	}
+
+	throttleScore := int32(p2p.Config.GetThrottleScore())
+	if !p2p.reputation.allow(from, time.Now(), p2p.Config.GetMessageRateLimit(), throttleScore) {
+		p2p.Logger.Debugf("Dropping message from %s, rate limit exceeded", from)
+		return nil
+	}
+
+	err := p2p.Receiver.Receive(ctx, msg)
+	if errors.Is(errors.Duplicate, err) {
+		p2p.Logger.Debugf("Ignoring message from %s, already processed", from)
+		return nil
+	}
+	if errors.Is(errors.Replay, err) && msg.Transport == interfaces.TransportStream {
+		p2p.Logger.Debugf("Ignoring message from %s, older than the current state", from)
+		return nil
+	}
+
+	p2p.penalize(from, err)
+	return err
+}
+
+// penalize lowers the peer's score if err tells that the data it sent was invalid, and disconnects it if the score drops too low
+func (p2p *P2p) penalize(peerID peer.ID, err error) {
+	score := p2p.reputation.record(peerID, err)
+	if score < int32(p2p.Config.GetDisconnectScore()) {
+		p2p.Logger.Warnf("Disconnecting peer %s with reputation score %d", peerID, score)
+		p2p.disconnectPeer(peerID)
+	}
+}
+
+// disconnectPeer blacklists the peer from pubsub and closes all connections to it
+func (p2p *P2p) disconnectPeer(peerID peer.ID) {
+	if p2p.ps != nil {
+		p2p.ps.BlacklistPeer(peerID)
+	}
+	if p2p.host != nil {
+		p2p.host.Network().ClosePeer(peerID)
+	}
+}
+
+// GetPeerScores returns the reputation scores of all peers that have sent data to this node
+func (p2p *P2p) GetPeerScores() []*pb.PeerScore {
+	return p2p.reputation.scores()
+}
@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRateLimit uint = 10
+const testThrottleScore int32 = 50
+
+func TestReputationRecord(t *testing.T) {
+	rep := newReputation()
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	assert.Equal(t, maxScore, rep.record(peerID, nil))
+	assert.Equal(t, maxScore, rep.record(peerID, errors.E(errors.Op("Unrelated"), "some error")))
+	assert.Equal(t, maxScore-invalidSignaturePenalty, rep.record(peerID, errors.E(errors.Op("Verify"), errors.InvalidSignature, "invalid")))
+	assert.Equal(t, maxScore-invalidSignaturePenalty-replayPenalty, rep.record(peerID, errors.E(errors.Op("Receive"), errors.E(errors.Op("Compare nonces"), errors.Replay, "replay"))))
+	assert.Equal(t, maxScore-invalidSignaturePenalty-replayPenalty-malformedPenalty, rep.record(peerID, errors.E(errors.Op("Unmarshal"), errors.Malformed, "malformed")))
+	assert.Equal(t, maxScore-invalidSignaturePenalty-replayPenalty-malformedPenalty-oversizedPenalty, rep.record(peerID, errors.E(errors.Op("Read frame"), errors.Oversized, "oversized")))
+
+	for i := 0; i < 20; i++ {
+		rep.record(peerID, errors.E(errors.Op("Verify"), errors.InvalidSignature, "invalid"))
+	}
+	assert.Equal(t, int32(0), rep.record(peerID, nil))
+
+	scores := rep.scores()
+	assert.Equal(t, 1, len(scores))
+	assert.Equal(t, peerID.String(), scores[0].GetId())
+	assert.Equal(t, uint32(21), scores[0].GetInvalidSignatures())
+	assert.Equal(t, uint32(1), scores[0].GetOversized())
+}
+
+func TestReputationRateLimit(t *testing.T) {
+	rep := newReputation()
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	now := time.Now()
+
+	for i := uint(0); i < testRateLimit; i++ {
+		assert.True(t, rep.allow(peerID, now, testRateLimit, testThrottleScore))
+	}
+	assert.False(t, rep.allow(peerID, now, testRateLimit, testThrottleScore))
+	assert.False(t, rep.allow(peerID, now, testRateLimit, testThrottleScore))
+	assert.Equal(t, uint32(1), rep.scores()[0].GetSpam())
+
+	// A new window resets the message count
+	assert.True(t, rep.allow(peerID, now.Add(rateWindow), testRateLimit, testThrottleScore))
+
+	// Throttled peers get a fraction of the rate limit
+	for i := 0; i < 5; i++ {
+		rep.record(peerID, errors.E(errors.Op("Verify"), errors.InvalidSignature, "invalid"))
+	}
+	later := now.Add(2 * rateWindow)
+	assert.True(t, rep.allow(peerID, later, testRateLimit, testThrottleScore))
+	assert.False(t, rep.allow(peerID, later, testRateLimit, testThrottleScore))
+
+	// A zero rate limit disables limiting
+	assert.True(t, rep.allow(peerID, later, 0, testThrottleScore))
+}
@@ -0,0 +1,76 @@
+package p2p
+
+import (
+	"encoding/hex"
+	"strings"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	pnet "github.com/libp2p/go-libp2p-pnet"
+	tls "github.com/libp2p/go-libp2p-tls"
+	libp2pConfig "github.com/libp2p/go-libp2p/config"
+	"github.com/sprawl/sprawl/errors"
+)
+
+const secioSecurity string = "secio"
+const tlsSecurity string = "tls"
+
+// noiseSecurity is recognized but can't be selected: go-libp2p-noise needs go-libp2p v0.8 and go-libp2p-core v0.5,
+// newer than the libp2p Sprawl is built on
+const noiseSecurity string = "noise"
+
+// privateNetworkKeyLength is the length of a private network key in bytes, before hex encoding
+const privateNetworkKeyLength int = 32
+
+// securityOption returns the security transport selected in the config.
+// Secio is libp2p's default, so it doesn't need an option.
+func securityOption(security string) (libp2pConfig.Option, error) {
+	switch strings.ToLower(security) {
+	case "", secioSecurity:
+		return nil, nil
+	case tlsSecurity:
+		return libp2p.Security(tls.ID, tls.New), nil
+	case noiseSecurity:
+		return nil, errors.E(errors.Op("Select security transport"), errors.Invalid, "the noise security transport isn't supported by the libp2p version Sprawl is built on, use \""+secioSecurity+"\" or \""+tlsSecurity+"\"")
+	}
+	return nil, errors.E(errors.Op("Select security transport"), errors.Invalid, "unknown security transport "+security+", use \""+secioSecurity+"\" or \""+tlsSecurity+"\"")
+}
+
+// privateNetworkOption returns the option that only lets nodes with the same hex encoded key connect to this one
+func privateNetworkOption(key string) (libp2pConfig.Option, error) {
+	if key == "" {
+		return nil, nil
+	}
+	decoded, err := hex.DecodeString(strings.TrimSpace(key))
+	if !errors.IsEmpty(err) || len(decoded) != privateNetworkKeyLength {
+		return nil, errors.E(errors.Op("Decode private network key"), errors.Invalid, "private network key should be 32 hex encoded bytes")
+	}
+	var psk [32]byte
+	copy(psk[:], decoded)
+	protector, err := pnet.NewV1ProtectorFromBytes(&psk)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Create private network protector"), err)
+	}
+	return libp2p.PrivateNetwork(protector), nil
+}
+
+// securityOptions returns the security transport and private network options from the config.
+// A node that can't use them would connect to the wrong network, so it doesn't start at all.
+func (p2p *P2p) securityOptions() []libp2pConfig.Option {
+	options := []libp2pConfig.Option{}
+	security, err := securityOption(p2p.Config.GetSecurity())
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Fatal(err)
+	}
+	if security != nil {
+		options = append(options, security)
+	}
+	privateNetwork, err := privateNetworkOption(p2p.Config.GetPrivateNetworkKey())
+	if !errors.IsEmpty(err) {
+		p2p.Logger.Fatal(err)
+	}
+	if privateNetwork != nil {
+		p2p.Logger.Info("Joining a private network, only peers with the same private network key can connect")
+		options = append(options, privateNetwork)
+	}
+	return options
+}
@@ -0,0 +1,37 @@
+package p2p
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityOption(t *testing.T) {
+	option, err := securityOption("secio")
+	assert.NoError(t, err)
+	assert.Nil(t, option)
+	option, err = securityOption("TLS")
+	assert.NoError(t, err)
+	assert.NotNil(t, option)
+	_, err = securityOption("noise")
+	assert.True(t, errors.Is(errors.Invalid, err))
+	assert.Contains(t, err.Error(), "isn't supported")
+	_, err = securityOption("plaintext")
+	assert.True(t, errors.Is(errors.Invalid, err))
+	assert.Contains(t, err.Error(), "unknown security transport")
+}
+
+func TestPrivateNetworkOption(t *testing.T) {
+	option, err := privateNetworkOption("")
+	assert.NoError(t, err)
+	assert.Nil(t, option)
+	option, err = privateNetworkOption(strings.Repeat("ab", 32))
+	assert.NoError(t, err)
+	assert.NotNil(t, option)
+	_, err = privateNetworkOption(strings.Repeat("ab", 16))
+	assert.True(t, errors.Is(errors.Invalid, err))
+	_, err = privateNetworkOption(strings.Repeat("zz", 32))
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
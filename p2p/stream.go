@@ -2,74 +2,283 @@ package p2p
 
 import (
 	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/network"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
 )
 
+// maxStreamFrameSize is the largest frame read from a stream even when the maximum message size isn't limited in the config,
+// since the frame is allocated from the length the peer sends before anything else about it is known
+const maxStreamFrameSize uint64 = 64 << 20
+
 // Stream is a single streaming connection between two peers
 type Stream struct {
-	stream     network.Stream
-	remotePeer peer.ID
-	input      *bufio.Writer
-	output     *bufio.Reader
+	stream          network.Stream
+	chaos           *chaos
+	remotePeer      peer.ID
+	remotePublicKey crypto.PubKey
+	remoteVersion   string
+	remoteChannels  [][]byte
+	capabilities    map[string]bool
+	input           *bufio.Writer
+	output          *bufio.Reader
+	readTimeout     time.Duration
+	writeTimeout    time.Duration
+	maxFrameSize    uint64
+	expired         bool
+	release         func()
+}
+
+// streamTimeouts returns the read and write deadlines of streams set in the config
+func streamTimeouts(config interfaces.Config) (time.Duration, time.Duration) {
+	return time.Duration(config.GetStreamReadTimeout()) * time.Second, time.Duration(config.GetStreamWriteTimeout()) * time.Second
+}
+
+func wrapStream(stream network.Stream, remotePeer peer.ID) *Stream {
+	return &Stream{
+		stream:     stream,
+		remotePeer: remotePeer,
+		input:      bufio.NewWriter(stream),
+		output:     bufio.NewReader(stream),
+	}
+}
+
+// newStream wraps a libp2p stream with the node's fault injection and deadlines
+func (p2p *P2p) newStream(stream network.Stream, remotePeer peer.ID) *Stream {
+	newStream := wrapStream(stream, remotePeer)
+	newStream.chaos = p2p.chaos
+	newStream.readTimeout, newStream.writeTimeout = p2p.streamReadTimeout, p2p.streamWriteTimeout
+	newStream.maxFrameSize = uint64(p2p.Config.GetMaxMessageSize())
+	return newStream
+}
+
+// isTimeout tells if err is caused by an expired deadline
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// expire resets a stream whose deadline has passed, so a half-dead peer doesn't keep its goroutine around,
+// and forgets the stream if it was opened with OpenStream
+func (stream *Stream) expire() {
+	stream.expired = true
+	stream.stream.Reset()
+	if stream.release != nil {
+		stream.release()
+	}
 }
 
 func (p2p *P2p) handleStream(buf network.Stream) {
-	p2p.Logger.Debugf("New stream opened with %s", buf.Conn().RemotePeer())
-	reader := bufio.NewReader(bufio.NewReader(buf))
 	remotePeer := buf.Conn().RemotePeer()
-	stream := &Stream{stream: buf, output: reader, remotePeer: remotePeer}
+	p2p.Logger.Debugf("New stream opened with %s", remotePeer)
+	stream := p2p.newStream(buf, remotePeer)
 	go func() {
-		stream.receiveStream(reader, p2p.Receiver)
+		err := p2p.acceptHandshake(stream)
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Warn(errors.E(errors.Op("Handshake with "+remotePeer.String()), err))
+			stream.stream.Reset()
+			return
+		}
+		err = stream.receiveStream(p2p.ctx, p2p.receive)
+		if stream.expired {
+			p2p.Logger.Debugf("Reset stream with %s after it was idle for %s", remotePeer, stream.readTimeout)
+			return
+		}
+		if errors.Is(errors.Oversized, err) {
+			p2p.Logger.Debugf("Reset stream with %s: %s", remotePeer, err)
+			p2p.penalize(remotePeer, err)
+			return
+		}
+		if !errors.IsEmpty(err) {
+			p2p.Logger.Error(errors.E(errors.Op("Receive stream"), err))
+		}
 		stream.stream.Close()
 	}()
 }
 
-func (stream *Stream) receiveStream(reader *bufio.Reader, receiver interfaces.Receiver) error {
-	data := []byte{}
+// writeFrame writes data prefixed with its length as a varint
+func (stream *Stream) writeFrame(data []byte) error {
+	if stream.chaos.closesStream() {
+		return stream.chaos.resetStream(stream)
+	}
+	if stream.writeTimeout > 0 {
+		stream.stream.SetWriteDeadline(time.Now().Add(stream.writeTimeout))
+	}
+	lengthPrefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lengthPrefix, uint64(len(data)))
+	_, err := stream.input.Write(lengthPrefix[:n])
+	if err != nil {
+		return stream.writeFailed(errors.Op("Write length prefix to stream"), err)
+	}
+	_, err = stream.input.Write(data)
+	if err != nil {
+		return stream.writeFailed(errors.Op("Write to stream"), err)
+	}
+	err = stream.input.Flush()
+	if err != nil {
+		return stream.writeFailed(errors.Op("Flush the stream"), err)
+	}
+	return nil
+}
+
+// writeFailed expires the stream if the write failed because the peer stopped reading
+func (stream *Stream) writeFailed(op errors.Op, err error) error {
+	if isTimeout(err) {
+		stream.expire()
+	}
+	return errors.E(op, err)
+}
+
+// readFrame reads a single length-prefixed frame written by writeFrame
+// The read deadline starts over with every frame, so only streams that stay quiet for too long expire.
+func (stream *Stream) readFrame() ([]byte, error) {
+	if stream.readTimeout > 0 {
+		stream.stream.SetReadDeadline(time.Now().Add(stream.readTimeout))
+	}
+	length, err := binary.ReadUvarint(stream.output)
+	if isTimeout(err) {
+		stream.expire()
+		return nil, errors.E(errors.Op("Read frame length from stream"), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Don't allocate a frame a peer claims to be huge
+	maxFrameSize := stream.maxFrameSize
+	if maxFrameSize == 0 || maxFrameSize > maxStreamFrameSize {
+		maxFrameSize = maxStreamFrameSize
+	}
+	if length > maxFrameSize {
+		stream.stream.Reset()
+		return nil, errors.E(errors.Op("Read frame from stream"), errors.Oversized, fmt.Sprintf("frame of %d bytes is over the maximum of %d", length, maxFrameSize))
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(stream.output, data)
+	if err != nil {
+		if isTimeout(err) {
+			stream.expire()
+		}
+		return nil, errors.E(errors.Op("Read frame from stream"), err)
+	}
+	return data, nil
+}
+
+func (stream *Stream) receiveStream(ctx context.Context, receive func(ctx context.Context, msg interfaces.IncomingMessage) error) error {
 	for {
-		line, _ := reader.ReadByte()
-		data = append(data, line)
-		if reader.Buffered() == 0 {
-			err := receiver.Receive(data, stream.remotePeer)
-			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Passing data from stream to receiver"), err)
-			}
+		data, err := stream.readFrame()
+		if err == io.EOF {
 			return nil
 		}
+		if err != nil {
+			return errors.E(errors.Op("Read from stream"), err)
+		}
+		err = receive(ctx, interfaces.IncomingMessage{
+			From:       stream.remotePeer,
+			Transport:  interfaces.TransportStream,
+			ReceivedAt: time.Now(),
+			Data:       data,
+		})
+		// A synced stream carries the peer's whole state, parts of which may be older than ours without ending the sync
+		if errors.Is(errors.Duplicate, err) || errors.Is(errors.Replay, err) {
+			continue
+		}
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Passing data from stream to receiver"), err)
+		}
 	}
 }
 
 // WriteToStream writes data as bytes to specified stream
 func (stream *Stream) WriteToStream(data []byte) error {
-	_, err := stream.input.Write(data)
-	if err != nil {
-		return errors.E(errors.Op("Write to stream"), err)
-	}
-	err = stream.input.Flush()
-	return errors.E(errors.Op("Flush the stream"), err)
+	return stream.writeFrame(data)
+}
+
+// HasCapability tells if both ends of the stream support an optional feature
+func (stream *Stream) HasCapability(capability string) bool {
+	return stream.capabilities[capability]
 }
 
-// OpenStream opens a stream with another Sprawl peer
+// OpenStream opens a stream with another Sprawl peer, using the newest protocol both peers support
 func (p2p *P2p) OpenStream(peerID peer.ID) (interfaces.Stream, error) {
-	stream, err := p2p.host.NewStream(p2p.ctx, peerID, networkID)
-	var newStream *Stream
+	newStream, err := p2p.openStream(peerID)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+
+	p2p.streamLock.Lock()
+	p2p.streams[peerID.String()] = newStream
+	p2p.streamLock.Unlock()
+	newStream.release = func() {
+		p2p.streamLock.Lock()
+		defer p2p.streamLock.Unlock()
+		if p2p.streams[peerID.String()] == newStream {
+			delete(p2p.streams, peerID.String())
+		}
+	}
+	return newStream, nil
+}
+
+// openStream opens a stream and completes the handshake without registering the stream for CloseStream
+func (p2p *P2p) openStream(peerID peer.ID) (*Stream, error) {
+	protocolIDs := p2p.protocolIDs()
+	stream, err := p2p.host.NewStream(p2p.ctx, peerID, protocolIDs...)
 	if err != nil {
-		p2p.Logger.Errorf("Stream open failed with peer %s on network %s: %s", peerID, networkID, err)
-	} else {
-		writer := bufio.NewWriter(bufio.NewWriter(stream))
-		newStream = &Stream{stream: stream, input: writer, remotePeer: peerID}
-		p2p.streams[peerID.String()] = newStream
+		p2p.Logger.Errorf("Stream open failed with peer %s on protocols %s: %s", peerID, protocolIDs, err)
+		return nil, err
+	}
+	p2p.Logger.Debugf("Opened stream with %s on protocol %s", peerID, stream.Protocol())
+
+	newStream := p2p.newStream(stream, peerID)
+	err = p2p.initiateHandshake(newStream)
+	if !errors.IsEmpty(err) {
+		stream.Reset()
+		return nil, errors.E(errors.Op("Handshake with "+peerID.String()), err)
+	}
+	return newStream, nil
+}
+
+// SendToPeer sends a single message to one peer over a stream of its own, instead of publishing it on the message's channel.
+// The peer receives it like a message from the channel.
+func (p2p *P2p) SendToPeer(peerID peer.ID, message *pb.WireMessage) error {
+	if peerID == p2p.host.ID() {
+		return errors.E(errors.Op("Send to peer"), errors.Invalid, "can't send a message to this node itself")
 	}
-	return newStream, err
+	// Browser peers can't open Sprawl streams
+	if p2p.isBrowserPeer(peerID) {
+		return errors.E(errors.Op("Send to peer"), errors.Invalid, "browser peers can't receive direct messages")
+	}
+	data, err := proto.Marshal(message)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal wireMessage"), err)
+	}
+	err = p2p.sendDirect(peerID, data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send to "+peerID.String()), err)
+	}
+	return nil
 }
 
 // CloseStream removes and closes a stream
 func (p2p *P2p) CloseStream(peerID peer.ID) error {
-	err := p2p.streams[peerID.String()].stream.Close()
+	p2p.streamLock.Lock()
+	defer p2p.streamLock.Unlock()
+	stream, ok := p2p.streams[peerID.String()]
+	if !ok {
+		// The stream has already expired
+		return nil
+	}
+	err := stream.stream.Close()
 	delete(p2p.streams, peerID.String())
 	return err
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/sprawl/sprawl/pb"
 )
 
-func (p2p *P2p) requestSync(ctx context.Context, topicString string, topic *pubsub.Topic) {
+func (p2p *P2p) requestSync(ctx context.Context, topicString string, topic *pubsub.Topic, history pb.History) {
 	eventHandler, err := topic.EventHandler()
 	if !errors.IsEmpty(err) {
 		p2p.Logger.Error(errors.E(errors.Op("Return topic's event handler"), err))
@@ -26,7 +26,7 @@ func (p2p *P2p) requestSync(ctx context.Context, topicString string, topic *pubs
 				break
 			}
 			if peerEvent.Type == 0 && peerEvent.Peer.String() != p2p.host.ID().String() {
-				err = p2p.sendSyncRequest(peerEvent.Peer, topicString)
+				err = p2p.sendSyncRequest(peerEvent.Peer, topicString, history)
 				if !errors.IsEmpty(err) {
 					p2p.Logger.Error(errors.E(errors.Op("Request sync"), err))
 				} else {
@@ -37,24 +37,20 @@ func (p2p *P2p) requestSync(ctx context.Context, topicString string, topic *pubs
 	}(ctx)
 }
 
-func (p2p *P2p) sendSyncRequest(peerID peer.ID, topicString string) error {
-	stream, err := p2p.OpenStream(peerID)
-	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Open a sync stream"), err)
-	}
+// sendSyncRequest asks a peer that joined the channel for a snapshot of its order book, and its order history too
+// if history is FULL. Requests for the open orders have no data, like those of nodes that can't ask for more.
+func (p2p *P2p) sendSyncRequest(peerID peer.ID, topicString string, history pb.History) error {
 	syncMessage := &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST, ChannelID: []byte(topicString), Data: nil}
-
-	marshaledData, err := proto.Marshal(syncMessage)
-	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Marshal sync request wireMessage"), err)
-	}
-	err = stream.WriteToStream(marshaledData)
-	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Write sync request to stream"), err)
+	if history != pb.History_OPEN_ORDERS {
+		data, err := proto.Marshal(&pb.SyncRequest{History: history})
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Marshal sync request"), err)
+		}
+		syncMessage.Data = data
 	}
-	err = p2p.CloseStream(peerID)
+	err := p2p.SendToPeer(peerID, syncMessage)
 	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Close the stream"), err)
+		return errors.E(errors.Op("Send sync request"), err)
 	}
 	return nil
 }
@@ -0,0 +1,87 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	libp2pquic "github.com/libp2p/go-libp2p-quic-transport"
+	tptu "github.com/libp2p/go-libp2p-transport-upgrader"
+	libp2pConfig "github.com/libp2p/go-libp2p/config"
+	tcp "github.com/libp2p/go-tcp-transport"
+	ws "github.com/libp2p/go-ws-transport"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const ip6AddrTemplate string = "/ip6/%s/tcp/%s"
+const quicAddrTemplate string = "/ip4/%s/udp/%s/quic"
+const quic6AddrTemplate string = "/ip6/%s/udp/%s/quic"
+const anyIPv6 string = "::"
+
+// useQUIC tells if QUIC is enabled. QUIC encrypts connections itself, so they can't be protected with a private network key.
+func (p2p *P2p) useQUIC() bool {
+	if !p2p.Config.GetQUICSetting() {
+		return false
+	}
+	if p2p.Config.GetPrivateNetworkKey() != "" {
+		p2p.Logger.Warn("QUIC can't be used on a private network, so only TCP is used")
+		return false
+	}
+	return true
+}
+
+// transportOptions returns the transports to listen and dial with. The libp2p defaults, TCP with port reuse
+// and websockets, are only replaced when QUIC is used or port reuse is disabled.
+func (p2p *P2p) transportOptions(quic bool) []libp2pConfig.Option {
+	reusePort := p2p.Config.GetReusePortSetting()
+	if !quic && reusePort {
+		return nil
+	}
+	options := []libp2pConfig.Option{
+		libp2p.Transport(func(upgrader *tptu.Upgrader) *tcp.TcpTransport {
+			transport := tcp.NewTCPTransport(upgrader)
+			transport.DisableReuseport = !reusePort
+			return transport
+		}),
+		libp2p.Transport(ws.New),
+	}
+	if quic {
+		options = append(options, libp2p.Transport(libp2pquic.NewTransport))
+	}
+	return options
+}
+
+// createListenMultiAddrs returns the TCP address to listen on for an IPv4 or IPv6 address,
+// and the QUIC address on the same port number if QUIC is used
+func createListenMultiAddrs(ip string, port string, quic bool) ([]ma.Multiaddr, error) {
+	templates := []string{addrTemplate}
+	if quic {
+		templates = append(templates, quicAddrTemplate)
+	}
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		templates = []string{ip6AddrTemplate}
+		if quic {
+			templates = append(templates, quic6AddrTemplate)
+		}
+	}
+	addrs := []ma.Multiaddr{}
+	for _, template := range templates {
+		addr, err := ma.NewMultiaddr(fmt.Sprintf(template, ip, port))
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// anyQUICMultiAddrs returns the QUIC addresses to listen on every interface with a port the OS picks,
+// like the default TCP listen addresses of libp2p
+func anyQUICMultiAddrs() []ma.Multiaddr {
+	addrs := []ma.Multiaddr{}
+	for _, ip := range []string{anyIPv4, anyIPv6} {
+		listenAddrs, _ := createListenMultiAddrs(ip, "0", true)
+		addrs = append(addrs, listenAddrs[1:]...)
+	}
+	return addrs
+}
@@ -12,6 +12,10 @@ It has these top-level commands:
 	OrderHandlerClientCommand
 	ChannelHandlerClientCommand
 	NodeHandlerClientCommand
+	AssetHandlerClientCommand
+	AdminHandlerClientCommand
+	StorageHandlerClientCommand
+	MarketDataHandlerClientCommand
 */
 
 package pb
@@ -471,16 +475,2156 @@ Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
 	echo '{json}' | getallorders --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v OrderListRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetAllOrders(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerGetAllOrdersClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetAllOrdersClientCommand.Flags())
+}
+
+var _OrderHandlerGetOrderBookClientCommand = &cobra.Command{
+	Use:  "getorderbook",
+	Long: "GetOrderBook client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getorderbook -p > req.json
+
+Submit request using file:
+	getorderbook -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getorderbook --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetOrderBook(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerGetOrderBookClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetOrderBookClientCommand.Flags())
+}
+
+var _OrderHandlerGetOrderHistoryClientCommand = &cobra.Command{
+	Use:  "getorderhistory",
+	Long: "GetOrderHistory client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getorderhistory -p > req.json
+
+Submit request using file:
+	getorderhistory -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getorderhistory --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v OrderHistoryRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetOrderHistory(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerGetOrderHistoryClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetOrderHistoryClientCommand.Flags())
+}
+
+var _OrderHandlerNegotiateClientCommand = &cobra.Command{
+	Use:  "negotiate",
+	Long: "Negotiate client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	negotiate -p > req.json
+
+Submit request using file:
+	negotiate -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | negotiate --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v NegotiationMessage
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			stream, err := cli.Negotiate(context.Background())
+			if err != nil {
+				return err
+			}
+			for {
+				err = in.Decode(&v)
+				if err == io.EOF {
+					stream.CloseSend()
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = stream.Send(&v)
+				if err != nil {
+					return err
+				}
+			}
+
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerNegotiateClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerNegotiateClientCommand.Flags())
+}
+
+var _OrderHandlerRotateIdentityClientCommand = &cobra.Command{
+	Use:  "rotateidentity",
+	Long: "RotateIdentity client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	rotateidentity -p > req.json
+
+Submit request using file:
+	rotateidentity -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | rotateidentity --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.RotateIdentity(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerRotateIdentityClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerRotateIdentityClientCommand.Flags())
+}
+
+var _OrderHandlerReportFillClientCommand = &cobra.Command{
+	Use:  "reportfill",
+	Long: "ReportFill client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	reportfill -p > req.json
+
+Submit request using file:
+	reportfill -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | reportfill --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v FillRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ReportFill(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerReportFillClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerReportFillClientCommand.Flags())
+}
+
+var _OrderHandlerSearchClientCommand = &cobra.Command{
+	Use:  "search",
+	Long: "Search client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	search -p > req.json
+
+Submit request using file:
+	search -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | search --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v SearchRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Search(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerSearchClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerSearchClientCommand.Flags())
+}
+
+var _OrderHandlerSubscribeOrderBookClientCommand = &cobra.Command{
+	Use:  "subscribeorderbook",
+	Long: "SubscribeOrderBook client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	subscribeorderbook -p > req.json
+
+Submit request using file:
+	subscribeorderbook -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | subscribeorderbook --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			stream, err := cli.SubscribeOrderBook(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerSubscribeOrderBookClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerSubscribeOrderBookClientCommand.Flags())
+}
+
+var _OrderHandlerRemoveClientCommand = &cobra.Command{
+	Use:  "remove",
+	Long: "Remove client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	remove -p > req.json
+
+Submit request using file:
+	remove -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | remove --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v RemovalRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Remove(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerRemoveClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerRemoveClientCommand.Flags())
+}
+
+var _OrderHandlerDeleteAllClientCommand = &cobra.Command{
+	Use:  "deleteall",
+	Long: "DeleteAll client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	deleteall -p > req.json
+
+Submit request using file:
+	deleteall -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | deleteall --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.DeleteAll(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerDeleteAllClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerDeleteAllClientCommand.Flags())
+}
+
+var _OrderHandlerKillSwitchClientCommand = &cobra.Command{
+	Use:  "killswitch",
+	Long: "KillSwitch client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	killswitch -p > req.json
+
+Submit request using file:
+	killswitch -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | killswitch --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v KillSwitchRequest
+		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.KillSwitch(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	OrderHandlerClientCommand.AddCommand(_OrderHandlerKillSwitchClientCommand)
+	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerKillSwitchClientCommand.Flags())
+}
+
+var _DefaultChannelHandlerClientCommandConfig = _NewChannelHandlerClientCommandConfig()
+
+type _ChannelHandlerClientCommandConfig struct {
+	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
+	RequestFile        string        `envconfig:"REQUEST_FILE"`
+	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
+	ResponseFormat     string        `envconfig:"RESPONSE_FORMAT" default:"json"`
+	Timeout            time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	TLS                bool          `envconfig:"TLS"`
+	ServerName         string        `envconfig:"TLS_SERVER_NAME"`
+	InsecureSkipVerify bool          `envconfig:"TLS_INSECURE_SKIP_VERIFY"`
+	CACertFile         string        `envconfig:"TLS_CA_CERT_FILE"`
+	CertFile           string        `envconfig:"TLS_CERT_FILE"`
+	KeyFile            string        `envconfig:"TLS_KEY_FILE"`
+	AuthToken          string        `envconfig:"AUTH_TOKEN"`
+	AuthTokenType      string        `envconfig:"AUTH_TOKEN_TYPE" default:"Bearer"`
+	JWTKey             string        `envconfig:"JWT_KEY"`
+	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
+}
+
+func _NewChannelHandlerClientCommandConfig() *_ChannelHandlerClientCommandConfig {
+	c := &_ChannelHandlerClientCommandConfig{}
+	envconfig.Process("", c)
+	return c
+}
+
+func (o *_ChannelHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
+	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
+	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
+	fs.StringVarP(&o.ResponseFormat, "response-format", "o", o.ResponseFormat, "response format (json, prettyjson, yaml, or xml)")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "client connection timeout")
+	fs.BoolVar(&o.TLS, "tls", o.TLS, "enable tls")
+	fs.StringVar(&o.ServerName, "tls-server-name", o.ServerName, "tls server name override")
+	fs.BoolVar(&o.InsecureSkipVerify, "tls-insecure-skip-verify", o.InsecureSkipVerify, "INSECURE: skip tls checks")
+	fs.StringVar(&o.CACertFile, "tls-ca-cert-file", o.CACertFile, "ca certificate file")
+	fs.StringVar(&o.CertFile, "tls-cert-file", o.CertFile, "client certificate file")
+	fs.StringVar(&o.KeyFile, "tls-key-file", o.KeyFile, "client key file")
+	fs.StringVar(&o.AuthToken, "auth-token", o.AuthToken, "authorization token")
+	fs.StringVar(&o.AuthTokenType, "auth-token-type", o.AuthTokenType, "authorization token type")
+	fs.StringVar(&o.JWTKey, "jwt-key", o.JWTKey, "jwt key")
+	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
+}
+
+var ChannelHandlerClientCommand = &cobra.Command{
+	Use: "channelhandler",
+}
+
+func _DialChannelHandler() (*grpc.ClientConn, ChannelHandlerClient, error) {
+	cfg := _DefaultChannelHandlerClientCommandConfig
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout),
+	}
+	if cfg.TLS {
+		tlsConfig := &tls.Config{}
+		if cfg.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if cfg.CACertFile != "" {
+			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("ca cert: %v", err)
+			}
+			certpool := x509.NewCertPool()
+			certpool.AppendCertsFromPEM(cacert)
+			tlsConfig.RootCAs = certpool
+		}
+		if cfg.CertFile != "" {
+			if cfg.KeyFile == "" {
+				return nil, nil, fmt.Errorf("missing key file")
+			}
+			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cert/key: %v", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else {
+			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
+			tlsConfig.ServerName = addr
+		}
+		//tlsConfig.BuildNameToCertificate()
+		cred := credentials.NewTLS(tlsConfig)
+		opts = append(opts, grpc.WithTransportCredentials(cred))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if cfg.AuthToken != "" {
+		cred := oauth.NewOauthAccess(&oauth2.Token{
+			AccessToken: cfg.AuthToken,
+			TokenType:   cfg.AuthTokenType,
+		})
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKey != "" {
+		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKeyFile != "" {
+		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewChannelHandlerClient(conn), nil
+}
+
+type _ChannelHandlerRoundTripFunc func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+
+func _ChannelHandlerRoundTrip(sample interface{}, fn _ChannelHandlerRoundTripFunc) error {
+	cfg := _DefaultChannelHandlerClientCommandConfig
+	var em iocodec.EncoderMaker
+	var ok bool
+	if cfg.ResponseFormat == "" {
+		em = iocodec.DefaultEncoders["json"]
+	} else {
+		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
+		if !ok {
+			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
+		}
+	}
+	if cfg.PrintSampleRequest {
+		return em.NewEncoder(os.Stdout).Encode(sample)
+	}
+	var d iocodec.Decoder
+	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
+		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
+	} else {
+		f, err := os.Open(cfg.RequestFile)
+		if err != nil {
+			return fmt.Errorf("request file: %v", err)
+		}
+		defer f.Close()
+		ext := filepath.Ext(cfg.RequestFile)
+		if len(ext) > 0 && ext[0] == '.' {
+			ext = ext[1:]
+		}
+		dm, ok := iocodec.DefaultDecoders[ext]
+		if !ok {
+			return fmt.Errorf("invalid request file format: %q", ext)
+		}
+		d = dm.NewDecoder(f)
+	}
+	conn, client, err := _DialChannelHandler()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(client, d, em.NewEncoder(os.Stdout))
+}
+
+var _ChannelHandlerJoinClientCommand = &cobra.Command{
+	Use:  "join",
+	Long: "Join client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	join -p > req.json
+
+Submit request using file:
+	join -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | join --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v JoinRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Join(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerJoinClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerJoinClientCommand.Flags())
+}
+
+var _ChannelHandlerLeaveClientCommand = &cobra.Command{
+	Use:  "leave",
+	Long: "Leave client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	leave -p > req.json
+
+Submit request using file:
+	leave -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | leave --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Leave(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerLeaveClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerLeaveClientCommand.Flags())
+}
+
+var _ChannelHandlerGetChannelClientCommand = &cobra.Command{
+	Use:  "getchannel",
+	Long: "GetChannel client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getchannel -p > req.json
+
+Submit request using file:
+	getchannel -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getchannel --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetChannel(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetChannelClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetChannelClientCommand.Flags())
+}
+
+var _ChannelHandlerGetAllChannelsClientCommand = &cobra.Command{
+	Use:  "getallchannels",
+	Long: "GetAllChannels client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getallchannels -p > req.json
+
+Submit request using file:
+	getallchannels -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getallchannels --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetAllChannels(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetAllChannelsClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetAllChannelsClientCommand.Flags())
+}
+
+var _ChannelHandlerSetMembersClientCommand = &cobra.Command{
+	Use:  "setmembers",
+	Long: "SetMembers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	setmembers -p > req.json
+
+Submit request using file:
+	setmembers -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | setmembers --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v MembershipRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.SetMembers(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerSetMembersClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerSetMembersClientCommand.Flags())
+}
+
+var _ChannelHandlerPublishConfigClientCommand = &cobra.Command{
+	Use:  "publishconfig",
+	Long: "PublishConfig client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	publishconfig -p > req.json
+
+Submit request using file:
+	publishconfig -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | publishconfig --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelConfigRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.PublishConfig(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerPublishConfigClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerPublishConfigClientCommand.Flags())
+}
+
+var _ChannelHandlerGetIdleChannelsClientCommand = &cobra.Command{
+	Use:  "getidlechannels",
+	Long: "GetIdleChannels client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getidlechannels -p > req.json
+
+Submit request using file:
+	getidlechannels -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getidlechannels --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetIdleChannels(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetIdleChannelsClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetIdleChannelsClientCommand.Flags())
+}
+
+var _ChannelHandlerRejoinClientCommand = &cobra.Command{
+	Use:  "rejoin",
+	Long: "Rejoin client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	rejoin -p > req.json
+
+Submit request using file:
+	rejoin -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | rejoin --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ChannelSpecificRequest
+		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Rejoin(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerRejoinClientCommand)
+	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerRejoinClientCommand.Flags())
+}
+
+var _DefaultNodeHandlerClientCommandConfig = _NewNodeHandlerClientCommandConfig()
+
+type _NodeHandlerClientCommandConfig struct {
+	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
+	RequestFile        string        `envconfig:"REQUEST_FILE"`
+	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
+	ResponseFormat     string        `envconfig:"RESPONSE_FORMAT" default:"json"`
+	Timeout            time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	TLS                bool          `envconfig:"TLS"`
+	ServerName         string        `envconfig:"TLS_SERVER_NAME"`
+	InsecureSkipVerify bool          `envconfig:"TLS_INSECURE_SKIP_VERIFY"`
+	CACertFile         string        `envconfig:"TLS_CA_CERT_FILE"`
+	CertFile           string        `envconfig:"TLS_CERT_FILE"`
+	KeyFile            string        `envconfig:"TLS_KEY_FILE"`
+	AuthToken          string        `envconfig:"AUTH_TOKEN"`
+	AuthTokenType      string        `envconfig:"AUTH_TOKEN_TYPE" default:"Bearer"`
+	JWTKey             string        `envconfig:"JWT_KEY"`
+	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
+}
+
+func _NewNodeHandlerClientCommandConfig() *_NodeHandlerClientCommandConfig {
+	c := &_NodeHandlerClientCommandConfig{}
+	envconfig.Process("", c)
+	return c
+}
+
+func (o *_NodeHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
+	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
+	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
+	fs.StringVarP(&o.ResponseFormat, "response-format", "o", o.ResponseFormat, "response format (json, prettyjson, yaml, or xml)")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "client connection timeout")
+	fs.BoolVar(&o.TLS, "tls", o.TLS, "enable tls")
+	fs.StringVar(&o.ServerName, "tls-server-name", o.ServerName, "tls server name override")
+	fs.BoolVar(&o.InsecureSkipVerify, "tls-insecure-skip-verify", o.InsecureSkipVerify, "INSECURE: skip tls checks")
+	fs.StringVar(&o.CACertFile, "tls-ca-cert-file", o.CACertFile, "ca certificate file")
+	fs.StringVar(&o.CertFile, "tls-cert-file", o.CertFile, "client certificate file")
+	fs.StringVar(&o.KeyFile, "tls-key-file", o.KeyFile, "client key file")
+	fs.StringVar(&o.AuthToken, "auth-token", o.AuthToken, "authorization token")
+	fs.StringVar(&o.AuthTokenType, "auth-token-type", o.AuthTokenType, "authorization token type")
+	fs.StringVar(&o.JWTKey, "jwt-key", o.JWTKey, "jwt key")
+	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
+}
+
+var NodeHandlerClientCommand = &cobra.Command{
+	Use: "nodehandler",
+}
+
+func _DialNodeHandler() (*grpc.ClientConn, NodeHandlerClient, error) {
+	cfg := _DefaultNodeHandlerClientCommandConfig
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout),
+	}
+	if cfg.TLS {
+		tlsConfig := &tls.Config{}
+		if cfg.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if cfg.CACertFile != "" {
+			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("ca cert: %v", err)
+			}
+			certpool := x509.NewCertPool()
+			certpool.AppendCertsFromPEM(cacert)
+			tlsConfig.RootCAs = certpool
+		}
+		if cfg.CertFile != "" {
+			if cfg.KeyFile == "" {
+				return nil, nil, fmt.Errorf("missing key file")
+			}
+			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cert/key: %v", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else {
+			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
+			tlsConfig.ServerName = addr
+		}
+		//tlsConfig.BuildNameToCertificate()
+		cred := credentials.NewTLS(tlsConfig)
+		opts = append(opts, grpc.WithTransportCredentials(cred))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if cfg.AuthToken != "" {
+		cred := oauth.NewOauthAccess(&oauth2.Token{
+			AccessToken: cfg.AuthToken,
+			TokenType:   cfg.AuthTokenType,
+		})
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKey != "" {
+		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKeyFile != "" {
+		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewNodeHandlerClient(conn), nil
+}
+
+type _NodeHandlerRoundTripFunc func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+
+func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) error {
+	cfg := _DefaultNodeHandlerClientCommandConfig
+	var em iocodec.EncoderMaker
+	var ok bool
+	if cfg.ResponseFormat == "" {
+		em = iocodec.DefaultEncoders["json"]
+	} else {
+		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
+		if !ok {
+			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
+		}
+	}
+	if cfg.PrintSampleRequest {
+		return em.NewEncoder(os.Stdout).Encode(sample)
+	}
+	var d iocodec.Decoder
+	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
+		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
+	} else {
+		f, err := os.Open(cfg.RequestFile)
+		if err != nil {
+			return fmt.Errorf("request file: %v", err)
+		}
+		defer f.Close()
+		ext := filepath.Ext(cfg.RequestFile)
+		if len(ext) > 0 && ext[0] == '.' {
+			ext = ext[1:]
+		}
+		dm, ok := iocodec.DefaultDecoders[ext]
+		if !ok {
+			return fmt.Errorf("invalid request file format: %q", ext)
+		}
+		d = dm.NewDecoder(f)
+	}
+	conn, client, err := _DialNodeHandler()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(client, d, em.NewEncoder(os.Stdout))
+}
+
+var _NodeHandlerGetAllPeersClientCommand = &cobra.Command{
+	Use:  "getallpeers",
+	Long: "GetAllPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getallpeers -p > req.json
+
+Submit request using file:
+	getallpeers -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getallpeers --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetAllPeers(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetAllPeersClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetAllPeersClientCommand.Flags())
+}
+
+var _NodeHandlerBlacklistPeerClientCommand = &cobra.Command{
+	Use:  "blacklistpeer",
+	Long: "BlacklistPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	blacklistpeer -p > req.json
+
+Submit request using file:
+	blacklistpeer -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | blacklistpeer --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Peer
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.BlacklistPeer(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerBlacklistPeerClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerBlacklistPeerClientCommand.Flags())
+}
+
+var _NodeHandlerGetNodeInfoClientCommand = &cobra.Command{
+	Use:  "getnodeinfo",
+	Long: "GetNodeInfo client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getnodeinfo -p > req.json
+
+Submit request using file:
+	getnodeinfo -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getnodeinfo --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetNodeInfo(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetNodeInfoClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetNodeInfoClientCommand.Flags())
+}
+
+var _NodeHandlerGetPeersClientCommand = &cobra.Command{
+	Use:  "getpeers",
+	Long: "GetPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getpeers -p > req.json
+
+Submit request using file:
+	getpeers -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getpeers --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetPeers(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetPeersClientCommand)
+	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetPeersClientCommand.Flags())
+}
+
+var _DefaultAssetHandlerClientCommandConfig = _NewAssetHandlerClientCommandConfig()
+
+type _AssetHandlerClientCommandConfig struct {
+	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
+	RequestFile        string        `envconfig:"REQUEST_FILE"`
+	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
+	ResponseFormat     string        `envconfig:"RESPONSE_FORMAT" default:"json"`
+	Timeout            time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	TLS                bool          `envconfig:"TLS"`
+	ServerName         string        `envconfig:"TLS_SERVER_NAME"`
+	InsecureSkipVerify bool          `envconfig:"TLS_INSECURE_SKIP_VERIFY"`
+	CACertFile         string        `envconfig:"TLS_CA_CERT_FILE"`
+	CertFile           string        `envconfig:"TLS_CERT_FILE"`
+	KeyFile            string        `envconfig:"TLS_KEY_FILE"`
+	AuthToken          string        `envconfig:"AUTH_TOKEN"`
+	AuthTokenType      string        `envconfig:"AUTH_TOKEN_TYPE" default:"Bearer"`
+	JWTKey             string        `envconfig:"JWT_KEY"`
+	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
+}
+
+func _NewAssetHandlerClientCommandConfig() *_AssetHandlerClientCommandConfig {
+	c := &_AssetHandlerClientCommandConfig{}
+	envconfig.Process("", c)
+	return c
+}
+
+func (o *_AssetHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
+	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
+	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
+	fs.StringVarP(&o.ResponseFormat, "response-format", "o", o.ResponseFormat, "response format (json, prettyjson, yaml, or xml)")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "client connection timeout")
+	fs.BoolVar(&o.TLS, "tls", o.TLS, "enable tls")
+	fs.StringVar(&o.ServerName, "tls-server-name", o.ServerName, "tls server name override")
+	fs.BoolVar(&o.InsecureSkipVerify, "tls-insecure-skip-verify", o.InsecureSkipVerify, "INSECURE: skip tls checks")
+	fs.StringVar(&o.CACertFile, "tls-ca-cert-file", o.CACertFile, "ca certificate file")
+	fs.StringVar(&o.CertFile, "tls-cert-file", o.CertFile, "client certificate file")
+	fs.StringVar(&o.KeyFile, "tls-key-file", o.KeyFile, "client key file")
+	fs.StringVar(&o.AuthToken, "auth-token", o.AuthToken, "authorization token")
+	fs.StringVar(&o.AuthTokenType, "auth-token-type", o.AuthTokenType, "authorization token type")
+	fs.StringVar(&o.JWTKey, "jwt-key", o.JWTKey, "jwt key")
+	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
+}
+
+var AssetHandlerClientCommand = &cobra.Command{
+	Use: "assethandler",
+}
+
+func _DialAssetHandler() (*grpc.ClientConn, AssetHandlerClient, error) {
+	cfg := _DefaultAssetHandlerClientCommandConfig
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout),
+	}
+	if cfg.TLS {
+		tlsConfig := &tls.Config{}
+		if cfg.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if cfg.CACertFile != "" {
+			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("ca cert: %v", err)
+			}
+			certpool := x509.NewCertPool()
+			certpool.AppendCertsFromPEM(cacert)
+			tlsConfig.RootCAs = certpool
+		}
+		if cfg.CertFile != "" {
+			if cfg.KeyFile == "" {
+				return nil, nil, fmt.Errorf("missing key file")
+			}
+			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cert/key: %v", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else {
+			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
+			tlsConfig.ServerName = addr
+		}
+		//tlsConfig.BuildNameToCertificate()
+		cred := credentials.NewTLS(tlsConfig)
+		opts = append(opts, grpc.WithTransportCredentials(cred))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if cfg.AuthToken != "" {
+		cred := oauth.NewOauthAccess(&oauth2.Token{
+			AccessToken: cfg.AuthToken,
+			TokenType:   cfg.AuthTokenType,
+		})
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKey != "" {
+		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKeyFile != "" {
+		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewAssetHandlerClient(conn), nil
+}
+
+type _AssetHandlerRoundTripFunc func(cli AssetHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+
+func _AssetHandlerRoundTrip(sample interface{}, fn _AssetHandlerRoundTripFunc) error {
+	cfg := _DefaultAssetHandlerClientCommandConfig
+	var em iocodec.EncoderMaker
+	var ok bool
+	if cfg.ResponseFormat == "" {
+		em = iocodec.DefaultEncoders["json"]
+	} else {
+		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
+		if !ok {
+			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
+		}
+	}
+	if cfg.PrintSampleRequest {
+		return em.NewEncoder(os.Stdout).Encode(sample)
+	}
+	var d iocodec.Decoder
+	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
+		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
+	} else {
+		f, err := os.Open(cfg.RequestFile)
+		if err != nil {
+			return fmt.Errorf("request file: %v", err)
+		}
+		defer f.Close()
+		ext := filepath.Ext(cfg.RequestFile)
+		if len(ext) > 0 && ext[0] == '.' {
+			ext = ext[1:]
+		}
+		dm, ok := iocodec.DefaultDecoders[ext]
+		if !ok {
+			return fmt.Errorf("invalid request file format: %q", ext)
+		}
+		d = dm.NewDecoder(f)
+	}
+	conn, client, err := _DialAssetHandler()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(client, d, em.NewEncoder(os.Stdout))
+}
+
+var _AssetHandlerRegisterClientCommand = &cobra.Command{
+	Use:  "register",
+	Long: "Register client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	register -p > req.json
+
+Submit request using file:
+	register -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | register --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Asset
+		err := _AssetHandlerRoundTrip(v, func(cli AssetHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Register(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AssetHandlerClientCommand.AddCommand(_AssetHandlerRegisterClientCommand)
+	_DefaultAssetHandlerClientCommandConfig.AddFlags(_AssetHandlerRegisterClientCommand.Flags())
+}
+
+var _AssetHandlerGetAllAssetsClientCommand = &cobra.Command{
+	Use:  "getallassets",
+	Long: "GetAllAssets client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getallassets -p > req.json
+
+Submit request using file:
+	getallassets -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getallassets --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _AssetHandlerRoundTrip(v, func(cli AssetHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetAllAssets(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AssetHandlerClientCommand.AddCommand(_AssetHandlerGetAllAssetsClientCommand)
+	_DefaultAssetHandlerClientCommandConfig.AddFlags(_AssetHandlerGetAllAssetsClientCommand.Flags())
+}
+
+var _DefaultAdminHandlerClientCommandConfig = _NewAdminHandlerClientCommandConfig()
+
+type _AdminHandlerClientCommandConfig struct {
+	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
+	RequestFile        string        `envconfig:"REQUEST_FILE"`
+	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
+	ResponseFormat     string        `envconfig:"RESPONSE_FORMAT" default:"json"`
+	Timeout            time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	TLS                bool          `envconfig:"TLS"`
+	ServerName         string        `envconfig:"TLS_SERVER_NAME"`
+	InsecureSkipVerify bool          `envconfig:"TLS_INSECURE_SKIP_VERIFY"`
+	CACertFile         string        `envconfig:"TLS_CA_CERT_FILE"`
+	CertFile           string        `envconfig:"TLS_CERT_FILE"`
+	KeyFile            string        `envconfig:"TLS_KEY_FILE"`
+	AuthToken          string        `envconfig:"AUTH_TOKEN"`
+	AuthTokenType      string        `envconfig:"AUTH_TOKEN_TYPE" default:"Bearer"`
+	JWTKey             string        `envconfig:"JWT_KEY"`
+	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
+}
+
+func _NewAdminHandlerClientCommandConfig() *_AdminHandlerClientCommandConfig {
+	c := &_AdminHandlerClientCommandConfig{}
+	envconfig.Process("", c)
+	return c
+}
+
+func (o *_AdminHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
+	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
+	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
+	fs.StringVarP(&o.ResponseFormat, "response-format", "o", o.ResponseFormat, "response format (json, prettyjson, yaml, or xml)")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "client connection timeout")
+	fs.BoolVar(&o.TLS, "tls", o.TLS, "enable tls")
+	fs.StringVar(&o.ServerName, "tls-server-name", o.ServerName, "tls server name override")
+	fs.BoolVar(&o.InsecureSkipVerify, "tls-insecure-skip-verify", o.InsecureSkipVerify, "INSECURE: skip tls checks")
+	fs.StringVar(&o.CACertFile, "tls-ca-cert-file", o.CACertFile, "ca certificate file")
+	fs.StringVar(&o.CertFile, "tls-cert-file", o.CertFile, "client certificate file")
+	fs.StringVar(&o.KeyFile, "tls-key-file", o.KeyFile, "client key file")
+	fs.StringVar(&o.AuthToken, "auth-token", o.AuthToken, "authorization token")
+	fs.StringVar(&o.AuthTokenType, "auth-token-type", o.AuthTokenType, "authorization token type")
+	fs.StringVar(&o.JWTKey, "jwt-key", o.JWTKey, "jwt key")
+	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
+}
+
+var AdminHandlerClientCommand = &cobra.Command{
+	Use: "adminhandler",
+}
+
+func _DialAdminHandler() (*grpc.ClientConn, AdminHandlerClient, error) {
+	cfg := _DefaultAdminHandlerClientCommandConfig
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout),
+	}
+	if cfg.TLS {
+		tlsConfig := &tls.Config{}
+		if cfg.InsecureSkipVerify {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if cfg.CACertFile != "" {
+			cacert, err := ioutil.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("ca cert: %v", err)
+			}
+			certpool := x509.NewCertPool()
+			certpool.AppendCertsFromPEM(cacert)
+			tlsConfig.RootCAs = certpool
+		}
+		if cfg.CertFile != "" {
+			if cfg.KeyFile == "" {
+				return nil, nil, fmt.Errorf("missing key file")
+			}
+			pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cert/key: %v", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+		if cfg.ServerName != "" {
+			tlsConfig.ServerName = cfg.ServerName
+		} else {
+			addr, _, _ := net.SplitHostPort(cfg.ServerAddr)
+			tlsConfig.ServerName = addr
+		}
+		//tlsConfig.BuildNameToCertificate()
+		cred := credentials.NewTLS(tlsConfig)
+		opts = append(opts, grpc.WithTransportCredentials(cred))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if cfg.AuthToken != "" {
+		cred := oauth.NewOauthAccess(&oauth2.Token{
+			AccessToken: cfg.AuthToken,
+			TokenType:   cfg.AuthTokenType,
+		})
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKey != "" {
+		cred, err := oauth.NewJWTAccessFromKey([]byte(cfg.JWTKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	if cfg.JWTKeyFile != "" {
+		cred, err := oauth.NewJWTAccessFromFile(cfg.JWTKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("jwt key file: %v", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(cred))
+	}
+	conn, err := grpc.Dial(cfg.ServerAddr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, NewAdminHandlerClient(conn), nil
+}
+
+type _AdminHandlerRoundTripFunc func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+
+func _AdminHandlerRoundTrip(sample interface{}, fn _AdminHandlerRoundTripFunc) error {
+	cfg := _DefaultAdminHandlerClientCommandConfig
+	var em iocodec.EncoderMaker
+	var ok bool
+	if cfg.ResponseFormat == "" {
+		em = iocodec.DefaultEncoders["json"]
+	} else {
+		em, ok = iocodec.DefaultEncoders[cfg.ResponseFormat]
+		if !ok {
+			return fmt.Errorf("invalid response format: %q", cfg.ResponseFormat)
+		}
+	}
+	if cfg.PrintSampleRequest {
+		return em.NewEncoder(os.Stdout).Encode(sample)
+	}
+	var d iocodec.Decoder
+	if cfg.RequestFile == "" || cfg.RequestFile == "-" {
+		d = iocodec.DefaultDecoders["json"].NewDecoder(os.Stdin)
+	} else {
+		f, err := os.Open(cfg.RequestFile)
+		if err != nil {
+			return fmt.Errorf("request file: %v", err)
+		}
+		defer f.Close()
+		ext := filepath.Ext(cfg.RequestFile)
+		if len(ext) > 0 && ext[0] == '.' {
+			ext = ext[1:]
+		}
+		dm, ok := iocodec.DefaultDecoders[ext]
+		if !ok {
+			return fmt.Errorf("invalid request file format: %q", ext)
+		}
+		d = dm.NewDecoder(f)
+	}
+	conn, client, err := _DialAdminHandler()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(client, d, em.NewEncoder(os.Stdout))
+}
+
+var _AdminHandlerBackupClientCommand = &cobra.Command{
+	Use:  "backup",
+	Long: "Backup client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	backup -p > req.json
+
+Submit request using file:
+	backup -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | backup --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			stream, err := cli.Backup(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerBackupClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerBackupClientCommand.Flags())
+}
+
+var _AdminHandlerRestoreClientCommand = &cobra.Command{
+	Use:  "restore",
+	Long: "Restore client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	restore -p > req.json
+
+Submit request using file:
+	restore -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | restore --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v BackupChunk
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			stream, err := cli.Restore(context.Background())
+			if err != nil {
+				return err
+			}
+			for {
+				err = in.Decode(&v)
+				if err == io.EOF {
+					stream.CloseSend()
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = stream.Send(&v)
+				if err != nil {
+					return err
+				}
+			}
+
+			resp, err := stream.CloseAndRecv()
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerRestoreClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerRestoreClientCommand.Flags())
+}
+
+var _AdminHandlerCaptureProfileClientCommand = &cobra.Command{
+	Use:  "captureprofile",
+	Long: "CaptureProfile client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	captureprofile -p > req.json
+
+Submit request using file:
+	captureprofile -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | captureprofile --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v ProfileRequest
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.CaptureProfile(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerCaptureProfileClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerCaptureProfileClientCommand.Flags())
+}
+
+var _AdminHandlerExportAuditLogClientCommand = &cobra.Command{
+	Use:  "exportauditlog",
+	Long: "ExportAuditLog client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	exportauditlog -p > req.json
+
+Submit request using file:
+	exportauditlog -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | exportauditlog --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v AuditLogRequest
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			stream, err := cli.ExportAuditLog(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerExportAuditLogClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerExportAuditLogClientCommand.Flags())
+}
+
+var _AdminHandlerGetDeadLettersClientCommand = &cobra.Command{
+	Use:  "getdeadletters",
+	Long: "GetDeadLetters client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getdeadletters -p > req.json
+
+Submit request using file:
+	getdeadletters -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getdeadletters --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetDeadLetters(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerGetDeadLettersClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerGetDeadLettersClientCommand.Flags())
+}
+
+var _AdminHandlerReplayDeadLettersClientCommand = &cobra.Command{
+	Use:  "replaydeadletters",
+	Long: "ReplayDeadLetters client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	replaydeadletters -p > req.json
+
+Submit request using file:
+	replaydeadletters -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | replaydeadletters --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v DeadLetterRequest
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.ReplayDeadLetters(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerReplayDeadLettersClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerReplayDeadLettersClientCommand.Flags())
+}
+
+var _AdminHandlerPurgeDeadLettersClientCommand = &cobra.Command{
+	Use:  "purgedeadletters",
+	Long: "PurgeDeadLetters client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	purgedeadletters -p > req.json
+
+Submit request using file:
+	purgedeadletters -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | purgedeadletters --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v DeadLetterRequest
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.PurgeDeadLetters(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerPurgeDeadLettersClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerPurgeDeadLettersClientCommand.Flags())
+}
+
+var _AdminHandlerCompactClientCommand = &cobra.Command{
+	Use:  "compact",
+	Long: "Compact client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	compact -p > req.json
+
+Submit request using file:
+	compact -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | compact --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Compact(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerCompactClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerCompactClientCommand.Flags())
+}
+
+var _AdminHandlerSelfTestClientCommand = &cobra.Command{
+	Use:  "selftest",
+	Long: "SelfTest client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	selftest -p > req.json
+
+Submit request using file:
+	selftest -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | selftest --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
 		var v Empty
-		err := _OrderHandlerRoundTrip(v, func(cli OrderHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.GetAllOrders(context.Background(), &v)
+			resp, err := cli.SelfTest(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -496,13 +2640,155 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	OrderHandlerClientCommand.AddCommand(_OrderHandlerGetAllOrdersClientCommand)
-	_DefaultOrderHandlerClientCommandConfig.AddFlags(_OrderHandlerGetAllOrdersClientCommand.Flags())
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerSelfTestClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerSelfTestClientCommand.Flags())
 }
 
-var _DefaultChannelHandlerClientCommandConfig = _NewChannelHandlerClientCommandConfig()
+var _AdminHandlerReplicateClientCommand = &cobra.Command{
+	Use:  "replicate",
+	Long: "Replicate client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	replicate -p > req.json
 
-type _ChannelHandlerClientCommandConfig struct {
+Submit request using file:
+	replicate -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | replicate --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			stream, err := cli.Replicate(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerReplicateClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerReplicateClientCommand.Flags())
+}
+
+var _AdminHandlerGetReplicationStatusClientCommand = &cobra.Command{
+	Use:  "getreplicationstatus",
+	Long: "GetReplicationStatus client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	getreplicationstatus -p > req.json
+
+Submit request using file:
+	getreplicationstatus -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | getreplicationstatus --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.GetReplicationStatus(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerGetReplicationStatusClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerGetReplicationStatusClientCommand.Flags())
+}
+
+var _AdminHandlerPromoteClientCommand = &cobra.Command{
+	Use:  "promote",
+	Long: "Promote client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+	Example: `
+Save a sample request to a file (or refer to your protobuf descriptor to create one):
+	promote -p > req.json
+
+Submit request using file:
+	promote -f req.json
+
+Authenticate using the Authorization header (requires transport security):
+	export AUTH_TOKEN=your_access_token
+	export SERVER_ADDR=api.example.com:443
+	echo '{json}' | promote --tls`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var v Empty
+		err := _AdminHandlerRoundTrip(v, func(cli AdminHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+
+			err := in.Decode(&v)
+			if err != nil {
+				return err
+			}
+
+			resp, err := cli.Promote(context.Background(), &v)
+
+			if err != nil {
+				return err
+			}
+
+			return out.Encode(resp)
+
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	AdminHandlerClientCommand.AddCommand(_AdminHandlerPromoteClientCommand)
+	_DefaultAdminHandlerClientCommandConfig.AddFlags(_AdminHandlerPromoteClientCommand.Flags())
+}
+
+var _DefaultStorageHandlerClientCommandConfig = _NewStorageHandlerClientCommandConfig()
+
+type _StorageHandlerClientCommandConfig struct {
 	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
 	RequestFile        string        `envconfig:"REQUEST_FILE"`
 	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
@@ -520,13 +2806,13 @@ type _ChannelHandlerClientCommandConfig struct {
 	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
 }
 
-func _NewChannelHandlerClientCommandConfig() *_ChannelHandlerClientCommandConfig {
-	c := &_ChannelHandlerClientCommandConfig{}
+func _NewStorageHandlerClientCommandConfig() *_StorageHandlerClientCommandConfig {
+	c := &_StorageHandlerClientCommandConfig{}
 	envconfig.Process("", c)
 	return c
 }
 
-func (o *_ChannelHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+func (o *_StorageHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
 	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
 	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
@@ -544,12 +2830,12 @@ func (o *_ChannelHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
 }
 
-var ChannelHandlerClientCommand = &cobra.Command{
-	Use: "channelhandler",
+var StorageHandlerClientCommand = &cobra.Command{
+	Use: "storagehandler",
 }
 
-func _DialChannelHandler() (*grpc.ClientConn, ChannelHandlerClient, error) {
-	cfg := _DefaultChannelHandlerClientCommandConfig
+func _DialStorageHandler() (*grpc.ClientConn, StorageHandlerClient, error) {
+	cfg := _DefaultStorageHandlerClientCommandConfig
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
 		grpc.WithTimeout(cfg.Timeout),
@@ -615,13 +2901,13 @@ func _DialChannelHandler() (*grpc.ClientConn, ChannelHandlerClient, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	return conn, NewChannelHandlerClient(conn), nil
+	return conn, NewStorageHandlerClient(conn), nil
 }
 
-type _ChannelHandlerRoundTripFunc func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+type _StorageHandlerRoundTripFunc func(cli StorageHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
 
-func _ChannelHandlerRoundTrip(sample interface{}, fn _ChannelHandlerRoundTripFunc) error {
-	cfg := _DefaultChannelHandlerClientCommandConfig
+func _StorageHandlerRoundTrip(sample interface{}, fn _StorageHandlerRoundTripFunc) error {
+	cfg := _DefaultStorageHandlerClientCommandConfig
 	var em iocodec.EncoderMaker
 	var ok bool
 	if cfg.ResponseFormat == "" {
@@ -654,7 +2940,7 @@ func _ChannelHandlerRoundTrip(sample interface{}, fn _ChannelHandlerRoundTripFun
 		}
 		d = dm.NewDecoder(f)
 	}
-	conn, client, err := _DialChannelHandler()
+	conn, client, err := _DialStorageHandler()
 	if err != nil {
 		return err
 	}
@@ -662,30 +2948,30 @@ func _ChannelHandlerRoundTrip(sample interface{}, fn _ChannelHandlerRoundTripFun
 	return fn(client, d, em.NewEncoder(os.Stdout))
 }
 
-var _ChannelHandlerJoinClientCommand = &cobra.Command{
-	Use:  "join",
-	Long: "Join client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _StorageHandlerListClientCommand = &cobra.Command{
+	Use:  "list",
+	Long: "List client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	join -p > req.json
+	list -p > req.json
 
 Submit request using file:
-	join -f req.json
+	list -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | join --tls`,
+	echo '{json}' | list --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v JoinRequest
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v StorageListRequest
+		err := _StorageHandlerRoundTrip(v, func(cli StorageHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.Join(context.Background(), &v)
+			resp, err := cli.List(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -701,83 +2987,53 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerJoinClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerJoinClientCommand.Flags())
+	StorageHandlerClientCommand.AddCommand(_StorageHandlerListClientCommand)
+	_DefaultStorageHandlerClientCommandConfig.AddFlags(_StorageHandlerListClientCommand.Flags())
 }
 
-var _ChannelHandlerLeaveClientCommand = &cobra.Command{
-	Use:  "leave",
-	Long: "Leave client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _StorageHandlerDumpClientCommand = &cobra.Command{
+	Use:  "dump",
+	Long: "Dump client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	leave -p > req.json
+	dump -p > req.json
 
 Submit request using file:
-	leave -f req.json
+	dump -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | leave --tls`,
+	echo '{json}' | dump --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v ChannelSpecificRequest
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v StorageDumpRequest
+		err := _StorageHandlerRoundTrip(v, func(cli StorageHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.Leave(context.Background(), &v)
-
-			if err != nil {
-				return err
-			}
-
-			return out.Encode(resp)
-
-		})
-		if err != nil {
-			log.Fatal(err)
-		}
-	},
-}
-
-func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerLeaveClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerLeaveClientCommand.Flags())
-}
-
-var _ChannelHandlerGetChannelClientCommand = &cobra.Command{
-	Use:  "getchannel",
-	Long: "GetChannel client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
-	Example: `
-Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	getchannel -p > req.json
-
-Submit request using file:
-	getchannel -f req.json
-
-Authenticate using the Authorization header (requires transport security):
-	export AUTH_TOKEN=your_access_token
-	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | getchannel --tls`,
-	Run: func(cmd *cobra.Command, args []string) {
-		var v ChannelSpecificRequest
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+			stream, err := cli.Dump(context.Background(), &v)
 
-			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.GetChannel(context.Background(), &v)
-
-			if err != nil {
-				return err
+			for {
+				v, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				err = out.Encode(v)
+				if err != nil {
+					return err
+				}
 			}
-
-			return out.Encode(resp)
+			return nil
 
 		})
 		if err != nil {
@@ -787,34 +3043,34 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetChannelClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetChannelClientCommand.Flags())
+	StorageHandlerClientCommand.AddCommand(_StorageHandlerDumpClientCommand)
+	_DefaultStorageHandlerClientCommandConfig.AddFlags(_StorageHandlerDumpClientCommand.Flags())
 }
 
-var _ChannelHandlerGetAllChannelsClientCommand = &cobra.Command{
-	Use:  "getallchannels",
-	Long: "GetAllChannels client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _StorageHandlerStatClientCommand = &cobra.Command{
+	Use:  "stat",
+	Long: "Stat client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	getallchannels -p > req.json
+	stat -p > req.json
 
 Submit request using file:
-	getallchannels -f req.json
+	stat -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | getallchannels --tls`,
+	echo '{json}' | stat --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
 		var v Empty
-		err := _ChannelHandlerRoundTrip(v, func(cli ChannelHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		err := _StorageHandlerRoundTrip(v, func(cli StorageHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.GetAllChannels(context.Background(), &v)
+			resp, err := cli.Stat(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -830,13 +3086,13 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	ChannelHandlerClientCommand.AddCommand(_ChannelHandlerGetAllChannelsClientCommand)
-	_DefaultChannelHandlerClientCommandConfig.AddFlags(_ChannelHandlerGetAllChannelsClientCommand.Flags())
+	StorageHandlerClientCommand.AddCommand(_StorageHandlerStatClientCommand)
+	_DefaultStorageHandlerClientCommandConfig.AddFlags(_StorageHandlerStatClientCommand.Flags())
 }
 
-var _DefaultNodeHandlerClientCommandConfig = _NewNodeHandlerClientCommandConfig()
+var _DefaultMarketDataHandlerClientCommandConfig = _NewMarketDataHandlerClientCommandConfig()
 
-type _NodeHandlerClientCommandConfig struct {
+type _MarketDataHandlerClientCommandConfig struct {
 	ServerAddr         string        `envconfig:"SERVER_ADDR" default:"localhost:8080"`
 	RequestFile        string        `envconfig:"REQUEST_FILE"`
 	PrintSampleRequest bool          `envconfig:"PRINT_SAMPLE_REQUEST"`
@@ -854,13 +3110,13 @@ type _NodeHandlerClientCommandConfig struct {
 	JWTKeyFile         string        `envconfig:"JWT_KEY_FILE"`
 }
 
-func _NewNodeHandlerClientCommandConfig() *_NodeHandlerClientCommandConfig {
-	c := &_NodeHandlerClientCommandConfig{}
+func _NewMarketDataHandlerClientCommandConfig() *_MarketDataHandlerClientCommandConfig {
+	c := &_MarketDataHandlerClientCommandConfig{}
 	envconfig.Process("", c)
 	return c
 }
 
-func (o *_NodeHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
+func (o *_MarketDataHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&o.ServerAddr, "server-addr", "s", o.ServerAddr, "server address in form of host:port")
 	fs.StringVarP(&o.RequestFile, "request-file", "f", o.RequestFile, "client request file (must be json, yaml, or xml); use \"-\" for stdin + json")
 	fs.BoolVarP(&o.PrintSampleRequest, "print-sample-request", "p", o.PrintSampleRequest, "print sample request file and exit")
@@ -878,12 +3134,12 @@ func (o *_NodeHandlerClientCommandConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.JWTKeyFile, "jwt-key-file", o.JWTKeyFile, "jwt key file")
 }
 
-var NodeHandlerClientCommand = &cobra.Command{
-	Use: "nodehandler",
+var MarketDataHandlerClientCommand = &cobra.Command{
+	Use: "marketdatahandler",
 }
 
-func _DialNodeHandler() (*grpc.ClientConn, NodeHandlerClient, error) {
-	cfg := _DefaultNodeHandlerClientCommandConfig
+func _DialMarketDataHandler() (*grpc.ClientConn, MarketDataHandlerClient, error) {
+	cfg := _DefaultMarketDataHandlerClientCommandConfig
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
 		grpc.WithTimeout(cfg.Timeout),
@@ -949,13 +3205,13 @@ func _DialNodeHandler() (*grpc.ClientConn, NodeHandlerClient, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	return conn, NewNodeHandlerClient(conn), nil
+	return conn, NewMarketDataHandlerClient(conn), nil
 }
 
-type _NodeHandlerRoundTripFunc func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
+type _MarketDataHandlerRoundTripFunc func(cli MarketDataHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error
 
-func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) error {
-	cfg := _DefaultNodeHandlerClientCommandConfig
+func _MarketDataHandlerRoundTrip(sample interface{}, fn _MarketDataHandlerRoundTripFunc) error {
+	cfg := _DefaultMarketDataHandlerClientCommandConfig
 	var em iocodec.EncoderMaker
 	var ok bool
 	if cfg.ResponseFormat == "" {
@@ -988,7 +3244,7 @@ func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) err
 		}
 		d = dm.NewDecoder(f)
 	}
-	conn, client, err := _DialNodeHandler()
+	conn, client, err := _DialMarketDataHandler()
 	if err != nil {
 		return err
 	}
@@ -996,30 +3252,30 @@ func _NodeHandlerRoundTrip(sample interface{}, fn _NodeHandlerRoundTripFunc) err
 	return fn(client, d, em.NewEncoder(os.Stdout))
 }
 
-var _NodeHandlerGetAllPeersClientCommand = &cobra.Command{
-	Use:  "getallpeers",
-	Long: "GetAllPeers client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _MarketDataHandlerGetCandlesClientCommand = &cobra.Command{
+	Use:  "getcandles",
+	Long: "GetCandles client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	getallpeers -p > req.json
+	getcandles -p > req.json
 
 Submit request using file:
-	getallpeers -f req.json
+	getcandles -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | getallpeers --tls`,
+	echo '{json}' | getcandles --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v Empty
-		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v CandleRequest
+		err := _MarketDataHandlerRoundTrip(v, func(cli MarketDataHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.GetAllPeers(context.Background(), &v)
+			resp, err := cli.GetCandles(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -1035,34 +3291,34 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	NodeHandlerClientCommand.AddCommand(_NodeHandlerGetAllPeersClientCommand)
-	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerGetAllPeersClientCommand.Flags())
+	MarketDataHandlerClientCommand.AddCommand(_MarketDataHandlerGetCandlesClientCommand)
+	_DefaultMarketDataHandlerClientCommandConfig.AddFlags(_MarketDataHandlerGetCandlesClientCommand.Flags())
 }
 
-var _NodeHandlerBlacklistPeerClientCommand = &cobra.Command{
-	Use:  "blacklistpeer",
-	Long: "BlacklistPeer client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
+var _MarketDataHandlerGetTickerClientCommand = &cobra.Command{
+	Use:  "getticker",
+	Long: "GetTicker client\n\nYou can use environment variables with the same name of the command flags.\nAll caps and s/-/_, e.g. SERVER_ADDR.",
 	Example: `
 Save a sample request to a file (or refer to your protobuf descriptor to create one):
-	blacklistpeer -p > req.json
+	getticker -p > req.json
 
 Submit request using file:
-	blacklistpeer -f req.json
+	getticker -f req.json
 
 Authenticate using the Authorization header (requires transport security):
 	export AUTH_TOKEN=your_access_token
 	export SERVER_ADDR=api.example.com:443
-	echo '{json}' | blacklistpeer --tls`,
+	echo '{json}' | getticker --tls`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var v Peer
-		err := _NodeHandlerRoundTrip(v, func(cli NodeHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
+		var v ChannelSpecificRequest
+		err := _MarketDataHandlerRoundTrip(v, func(cli MarketDataHandlerClient, in iocodec.Decoder, out iocodec.Encoder) error {
 
 			err := in.Decode(&v)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cli.BlacklistPeer(context.Background(), &v)
+			resp, err := cli.GetTicker(context.Background(), &v)
 
 			if err != nil {
 				return err
@@ -1078,6 +3334,6 @@ Authenticate using the Authorization header (requires transport security):
 }
 
 func init() {
-	NodeHandlerClientCommand.AddCommand(_NodeHandlerBlacklistPeerClientCommand)
-	_DefaultNodeHandlerClientCommandConfig.AddFlags(_NodeHandlerBlacklistPeerClientCommand.Flags())
+	MarketDataHandlerClientCommand.AddCommand(_MarketDataHandlerGetTickerClientCommand)
+	_DefaultMarketDataHandlerClientCommandConfig.AddFlags(_MarketDataHandlerGetTickerClientCommand.Flags())
 }
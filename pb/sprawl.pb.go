@@ -6,6 +6,7 @@ package pb
 import (
 	context "context"
 	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
@@ -28,18 +29,30 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type State int32
 
 const (
-	State_OPEN   State = 0
-	State_LOCKED State = 1
+	State_OPEN             State = 0
+	State_LOCKED           State = 1
+	State_PARTIALLY_FILLED State = 2
+	State_FILLED           State = 3
+	State_SETTLED          State = 4
+	State_ABORTED          State = 5
 )
 
 var State_name = map[int32]string{
 	0: "OPEN",
 	1: "LOCKED",
+	2: "PARTIALLY_FILLED",
+	3: "FILLED",
+	4: "SETTLED",
+	5: "ABORTED",
 }
 
 var State_value = map[string]int32{
-	"OPEN":   0,
-	"LOCKED": 1,
+	"OPEN":             0,
+	"LOCKED":           1,
+	"PARTIALLY_FILLED": 2,
+	"FILLED":           3,
+	"SETTLED":          4,
+	"ABORTED":          5,
 }
 
 func (x State) String() string {
@@ -53,30 +66,57 @@ func (State) EnumDescriptor() ([]byte, []int) {
 type Operation int32
 
 const (
-	Operation_CREATE       Operation = 0
-	Operation_DELETE       Operation = 1
-	Operation_LOCK         Operation = 2
-	Operation_UNLOCK       Operation = 3
-	Operation_SYNC_REQUEST Operation = 4
-	Operation_SYNC_RECEIVE Operation = 5
+	Operation_CREATE              Operation = 0
+	Operation_DELETE              Operation = 1
+	Operation_LOCK                Operation = 2
+	Operation_UNLOCK              Operation = 3
+	Operation_SYNC_REQUEST        Operation = 4
+	Operation_SYNC_RECEIVE        Operation = 5
+	Operation_MEMBERSHIP          Operation = 6
+	Operation_IDENTITY_TRANSITION Operation = 7
+	Operation_FILL                Operation = 8
+	Operation_CHANNEL_CONFIG      Operation = 9
+	Operation_REMOVE              Operation = 10
+	Operation_CANDLE              Operation = 11
+	Operation_SYNC_HISTORY        Operation = 12
+	Operation_ORDER_DIGEST        Operation = 13
+	Operation_DELETE_ALL          Operation = 14
 )
 
 var Operation_name = map[int32]string{
-	0: "CREATE",
-	1: "DELETE",
-	2: "LOCK",
-	3: "UNLOCK",
-	4: "SYNC_REQUEST",
-	5: "SYNC_RECEIVE",
+	0:  "CREATE",
+	1:  "DELETE",
+	2:  "LOCK",
+	3:  "UNLOCK",
+	4:  "SYNC_REQUEST",
+	5:  "SYNC_RECEIVE",
+	6:  "MEMBERSHIP",
+	7:  "IDENTITY_TRANSITION",
+	8:  "FILL",
+	9:  "CHANNEL_CONFIG",
+	10: "REMOVE",
+	11: "CANDLE",
+	12: "SYNC_HISTORY",
+	13: "ORDER_DIGEST",
+	14: "DELETE_ALL",
 }
 
 var Operation_value = map[string]int32{
-	"CREATE":       0,
-	"DELETE":       1,
-	"LOCK":         2,
-	"UNLOCK":       3,
-	"SYNC_REQUEST": 4,
-	"SYNC_RECEIVE": 5,
+	"CREATE":              0,
+	"DELETE":              1,
+	"LOCK":                2,
+	"UNLOCK":              3,
+	"SYNC_REQUEST":        4,
+	"SYNC_RECEIVE":        5,
+	"MEMBERSHIP":          6,
+	"IDENTITY_TRANSITION": 7,
+	"FILL":                8,
+	"CHANNEL_CONFIG":      9,
+	"REMOVE":              10,
+	"CANDLE":              11,
+	"SYNC_HISTORY":        12,
+	"ORDER_DIGEST":        13,
+	"DELETE_ALL":          14,
 }
 
 func (x Operation) String() string {
@@ -87,6 +127,180 @@ func (Operation) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_b5e409e9578376a3, []int{1}
 }
 
+type NegotiationStep int32
+
+const (
+	NegotiationStep_RESUME     NegotiationStep = 0
+	NegotiationStep_LOCK_ORDER NegotiationStep = 1
+	NegotiationStep_PROPOSE    NegotiationStep = 2
+	NegotiationStep_ACCEPT     NegotiationStep = 3
+	NegotiationStep_REJECT     NegotiationStep = 4
+	NegotiationStep_FINALIZE   NegotiationStep = 5
+)
+
+var NegotiationStep_name = map[int32]string{
+	0: "RESUME",
+	1: "LOCK_ORDER",
+	2: "PROPOSE",
+	3: "ACCEPT",
+	4: "REJECT",
+	5: "FINALIZE",
+}
+
+var NegotiationStep_value = map[string]int32{
+	"RESUME":     0,
+	"LOCK_ORDER": 1,
+	"PROPOSE":    2,
+	"ACCEPT":     3,
+	"REJECT":     4,
+	"FINALIZE":   5,
+}
+
+func (x NegotiationStep) String() string {
+	return proto.EnumName(NegotiationStep_name, int32(x))
+}
+
+func (NegotiationStep) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{2}
+}
+
+type BookChange int32
+
+const (
+	BookChange_ORDER_ADDED   BookChange = 0
+	BookChange_ORDER_CHANGED BookChange = 1
+	BookChange_ORDER_REMOVED BookChange = 2
+)
+
+var BookChange_name = map[int32]string{
+	0: "ORDER_ADDED",
+	1: "ORDER_CHANGED",
+	2: "ORDER_REMOVED",
+}
+
+var BookChange_value = map[string]int32{
+	"ORDER_ADDED":   0,
+	"ORDER_CHANGED": 1,
+	"ORDER_REMOVED": 2,
+}
+
+func (x BookChange) String() string {
+	return proto.EnumName(BookChange_name, int32(x))
+}
+
+func (BookChange) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{3}
+}
+
+type Direction int32
+
+const (
+	Direction_UNKNOWN_DIRECTION Direction = 0
+	Direction_INBOUND           Direction = 1
+	Direction_OUTBOUND          Direction = 2
+)
+
+var Direction_name = map[int32]string{
+	0: "UNKNOWN_DIRECTION",
+	1: "INBOUND",
+	2: "OUTBOUND",
+}
+
+var Direction_value = map[string]int32{
+	"UNKNOWN_DIRECTION": 0,
+	"INBOUND":           1,
+	"OUTBOUND":          2,
+}
+
+func (x Direction) String() string {
+	return proto.EnumName(Direction_name, int32(x))
+}
+
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{4}
+}
+
+type Side int32
+
+const (
+	Side_ANY  Side = 0
+	Side_BUY  Side = 1
+	Side_SELL Side = 2
+)
+
+var Side_name = map[int32]string{
+	0: "ANY",
+	1: "BUY",
+	2: "SELL",
+}
+
+var Side_value = map[string]int32{
+	"ANY":  0,
+	"BUY":  1,
+	"SELL": 2,
+}
+
+func (x Side) String() string {
+	return proto.EnumName(Side_name, int32(x))
+}
+
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{5}
+}
+
+type ProfileType int32
+
+const (
+	ProfileType_HEAP ProfileType = 0
+	ProfileType_CPU  ProfileType = 1
+)
+
+var ProfileType_name = map[int32]string{
+	0: "HEAP",
+	1: "CPU",
+}
+
+var ProfileType_value = map[string]int32{
+	"HEAP": 0,
+	"CPU":  1,
+}
+
+func (x ProfileType) String() string {
+	return proto.EnumName(ProfileType_name, int32(x))
+}
+
+func (ProfileType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{6}
+}
+
+type History int32
+
+const (
+	History_OPEN_ORDERS History = 0
+	History_NONE        History = 1
+	History_FULL        History = 2
+)
+
+var History_name = map[int32]string{
+	0: "OPEN_ORDERS",
+	1: "NONE",
+	2: "FULL",
+}
+
+var History_value = map[string]int32{
+	"OPEN_ORDERS": 0,
+	"NONE":        1,
+	"FULL":        2,
+}
+
+func (x History) String() string {
+	return proto.EnumName(History_name, int32(x))
+}
+
+func (History) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{7}
+}
+
 type Peer struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -137,6 +351,14 @@ type Order struct {
 	Signature            []byte               `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
 	Nonce                uint32               `protobuf:"varint,9,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	Metadata             []byte               `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	DeletedAt            *timestamp.Timestamp `protobuf:"bytes,11,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	MakerPeerID          []byte               `protobuf:"bytes,12,opt,name=makerPeerID,proto3" json:"makerPeerID,omitempty"`
+	MakerPubKey          []byte               `protobuf:"bytes,13,opt,name=makerPubKey,proto3" json:"makerPubKey,omitempty"`
+	LockedUntil          *timestamp.Timestamp `protobuf:"bytes,14,opt,name=lockedUntil,proto3" json:"lockedUntil,omitempty"`
+	FilledAmount         uint64               `protobuf:"varint,15,opt,name=filledAmount,proto3" json:"filledAmount,omitempty"`
+	Fills                []*Fill              `protobuf:"bytes,16,rep,name=fills,proto3" json:"fills,omitempty"`
+	MakerFee             float32              `protobuf:"fixed32,17,opt,name=makerFee,proto3" json:"makerFee,omitempty"`
+	TakerFee             float32              `protobuf:"fixed32,18,opt,name=takerFee,proto3" json:"takerFee,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -237,1321 +459,7171 @@ func (m *Order) GetMetadata() []byte {
 	return nil
 }
 
-type OrderList struct {
-	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Order) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
 }
 
-func (m *OrderList) Reset()         { *m = OrderList{} }
-func (m *OrderList) String() string { return proto.CompactTextString(m) }
-func (*OrderList) ProtoMessage()    {}
-func (*OrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{2}
+func (m *Order) GetMakerPeerID() []byte {
+	if m != nil {
+		return m.MakerPeerID
+	}
+	return nil
 }
 
-func (m *OrderList) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderList.Unmarshal(m, b)
-}
-func (m *OrderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderList.Marshal(b, m, deterministic)
+func (m *Order) GetMakerPubKey() []byte {
+	if m != nil {
+		return m.MakerPubKey
+	}
+	return nil
 }
-func (m *OrderList) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderList.Merge(m, src)
+
+func (m *Order) GetLockedUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.LockedUntil
+	}
+	return nil
 }
-func (m *OrderList) XXX_Size() int {
-	return xxx_messageInfo_OrderList.Size(m)
+
+func (m *Order) GetFilledAmount() uint64 {
+	if m != nil {
+		return m.FilledAmount
+	}
+	return 0
 }
-func (m *OrderList) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderList.DiscardUnknown(m)
+
+func (m *Order) GetFills() []*Fill {
+	if m != nil {
+		return m.Fills
+	}
+	return nil
 }
 
-var xxx_messageInfo_OrderList proto.InternalMessageInfo
+func (m *Order) GetMakerFee() float32 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
 
-func (m *OrderList) GetOrders() []*Order {
+func (m *Order) GetTakerFee() float32 {
 	if m != nil {
-		return m.Orders
+		return m.TakerFee
 	}
-	return nil
+	return 0
 }
 
-type Channel struct {
-	Id                   []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Options              *ChannelOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type Fill struct {
+	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Amount               uint64   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Nonce                uint32   `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	TakerPubKey          []byte   `protobuf:"bytes,4,opt,name=takerPubKey,proto3" json:"takerPubKey,omitempty"`
+	TakerSignature       []byte   `protobuf:"bytes,5,opt,name=takerSignature,proto3" json:"takerSignature,omitempty"`
+	MakerSignature       []byte   `protobuf:"bytes,6,opt,name=makerSignature,proto3" json:"makerSignature,omitempty"`
+	MakerFee             uint64   `protobuf:"varint,7,opt,name=makerFee,proto3" json:"makerFee,omitempty"`
+	TakerFee             uint64   `protobuf:"varint,8,opt,name=takerFee,proto3" json:"takerFee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Channel) Reset()         { *m = Channel{} }
-func (m *Channel) String() string { return proto.CompactTextString(m) }
-func (*Channel) ProtoMessage()    {}
-func (*Channel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{3}
+func (m *Fill) Reset()         { *m = Fill{} }
+func (m *Fill) String() string { return proto.CompactTextString(m) }
+func (*Fill) ProtoMessage()    {}
+func (*Fill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{2}
 }
 
-func (m *Channel) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Channel.Unmarshal(m, b)
+func (m *Fill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Fill.Unmarshal(m, b)
 }
-func (m *Channel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Channel.Marshal(b, m, deterministic)
+func (m *Fill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Fill.Marshal(b, m, deterministic)
 }
-func (m *Channel) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Channel.Merge(m, src)
+func (m *Fill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Fill.Merge(m, src)
 }
-func (m *Channel) XXX_Size() int {
-	return xxx_messageInfo_Channel.Size(m)
+func (m *Fill) XXX_Size() int {
+	return xxx_messageInfo_Fill.Size(m)
 }
-func (m *Channel) XXX_DiscardUnknown() {
-	xxx_messageInfo_Channel.DiscardUnknown(m)
+func (m *Fill) XXX_DiscardUnknown() {
+	xxx_messageInfo_Fill.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Channel proto.InternalMessageInfo
+var xxx_messageInfo_Fill proto.InternalMessageInfo
 
-func (m *Channel) GetId() []byte {
+func (m *Fill) GetOrderID() []byte {
 	if m != nil {
-		return m.Id
+		return m.OrderID
 	}
 	return nil
 }
 
-func (m *Channel) GetOptions() *ChannelOptions {
+func (m *Fill) GetAmount() uint64 {
 	if m != nil {
-		return m.Options
+		return m.Amount
 	}
-	return nil
+	return 0
 }
 
-type ChannelList struct {
-	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *Fill) GetNonce() uint32 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
 }
 
-func (m *ChannelList) Reset()         { *m = ChannelList{} }
-func (m *ChannelList) String() string { return proto.CompactTextString(m) }
-func (*ChannelList) ProtoMessage()    {}
-func (*ChannelList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{4}
+func (m *Fill) GetTakerPubKey() []byte {
+	if m != nil {
+		return m.TakerPubKey
+	}
+	return nil
 }
 
-func (m *ChannelList) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelList.Unmarshal(m, b)
-}
-func (m *ChannelList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelList.Marshal(b, m, deterministic)
-}
-func (m *ChannelList) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelList.Merge(m, src)
-}
-func (m *ChannelList) XXX_Size() int {
-	return xxx_messageInfo_ChannelList.Size(m)
+func (m *Fill) GetTakerSignature() []byte {
+	if m != nil {
+		return m.TakerSignature
+	}
+	return nil
 }
-func (m *ChannelList) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelList.DiscardUnknown(m)
+
+func (m *Fill) GetMakerSignature() []byte {
+	if m != nil {
+		return m.MakerSignature
+	}
+	return nil
 }
 
-var xxx_messageInfo_ChannelList proto.InternalMessageInfo
+func (m *Fill) GetMakerFee() uint64 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
 
-func (m *ChannelList) GetChannels() []*Channel {
+func (m *Fill) GetTakerFee() uint64 {
 	if m != nil {
-		return m.Channels
+		return m.TakerFee
 	}
-	return nil
+	return 0
 }
 
-type Recipient struct {
-	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+type FillRequest struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Fill                 *Fill    `protobuf:"bytes,2,opt,name=fill,proto3" json:"fill,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Recipient) Reset()         { *m = Recipient{} }
-func (m *Recipient) String() string { return proto.CompactTextString(m) }
-func (*Recipient) ProtoMessage()    {}
-func (*Recipient) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{5}
+func (m *FillRequest) Reset()         { *m = FillRequest{} }
+func (m *FillRequest) String() string { return proto.CompactTextString(m) }
+func (*FillRequest) ProtoMessage()    {}
+func (*FillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{3}
 }
 
-func (m *Recipient) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Recipient.Unmarshal(m, b)
+func (m *FillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FillRequest.Unmarshal(m, b)
 }
-func (m *Recipient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Recipient.Marshal(b, m, deterministic)
+func (m *FillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FillRequest.Marshal(b, m, deterministic)
 }
-func (m *Recipient) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Recipient.Merge(m, src)
+func (m *FillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FillRequest.Merge(m, src)
 }
-func (m *Recipient) XXX_Size() int {
-	return xxx_messageInfo_Recipient.Size(m)
+func (m *FillRequest) XXX_Size() int {
+	return xxx_messageInfo_FillRequest.Size(m)
 }
-func (m *Recipient) XXX_DiscardUnknown() {
-	xxx_messageInfo_Recipient.DiscardUnknown(m)
+func (m *FillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FillRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Recipient proto.InternalMessageInfo
+var xxx_messageInfo_FillRequest proto.InternalMessageInfo
 
-func (m *Recipient) GetPeerID() []byte {
+func (m *FillRequest) GetChannelID() []byte {
 	if m != nil {
-		return m.PeerID
+		return m.ChannelID
 	}
 	return nil
 }
 
-type WireMessage struct {
-	ChannelID            []byte    `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	Operation            Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.Operation" json:"operation,omitempty"`
-	Data                 []byte    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *FillRequest) GetFill() *Fill {
+	if m != nil {
+		return m.Fill
+	}
+	return nil
 }
 
-func (m *WireMessage) Reset()         { *m = WireMessage{} }
-func (m *WireMessage) String() string { return proto.CompactTextString(m) }
-func (*WireMessage) ProtoMessage()    {}
-func (*WireMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{6}
+type OrderList struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WireMessage) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WireMessage.Unmarshal(m, b)
-}
-func (m *WireMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WireMessage.Marshal(b, m, deterministic)
+func (m *OrderList) Reset()         { *m = OrderList{} }
+func (m *OrderList) String() string { return proto.CompactTextString(m) }
+func (*OrderList) ProtoMessage()    {}
+func (*OrderList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{4}
 }
-func (m *WireMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WireMessage.Merge(m, src)
+
+func (m *OrderList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderList.Unmarshal(m, b)
 }
-func (m *WireMessage) XXX_Size() int {
-	return xxx_messageInfo_WireMessage.Size(m)
+func (m *OrderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderList.Marshal(b, m, deterministic)
 }
-func (m *WireMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_WireMessage.DiscardUnknown(m)
+func (m *OrderList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderList.Merge(m, src)
 }
-
-var xxx_messageInfo_WireMessage proto.InternalMessageInfo
-
-func (m *WireMessage) GetChannelID() []byte {
-	if m != nil {
-		return m.ChannelID
-	}
-	return nil
+func (m *OrderList) XXX_Size() int {
+	return xxx_messageInfo_OrderList.Size(m)
 }
-
-func (m *WireMessage) GetOperation() Operation {
-	if m != nil {
-		return m.Operation
-	}
-	return Operation_CREATE
+func (m *OrderList) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderList.DiscardUnknown(m)
 }
 
-func (m *WireMessage) GetData() []byte {
+var xxx_messageInfo_OrderList proto.InternalMessageInfo
+
+func (m *OrderList) GetOrders() []*Order {
 	if m != nil {
-		return m.Data
+		return m.Orders
 	}
 	return nil
 }
 
-type CreateRequest struct {
-	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	Asset                string   `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
-	CounterAsset         string   `protobuf:"bytes,3,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
-	Amount               uint64   `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Price                float32  `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+type OrderListRequest struct {
+	Mine                 bool     `protobuf:"varint,1,opt,name=mine,proto3" json:"mine,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
-func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateRequest) ProtoMessage()    {}
-func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{7}
+func (m *OrderListRequest) Reset()         { *m = OrderListRequest{} }
+func (m *OrderListRequest) String() string { return proto.CompactTextString(m) }
+func (*OrderListRequest) ProtoMessage()    {}
+func (*OrderListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{5}
 }
 
-func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+func (m *OrderListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderListRequest.Unmarshal(m, b)
 }
-func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+func (m *OrderListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderListRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRequest.Merge(m, src)
+func (m *OrderListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderListRequest.Merge(m, src)
 }
-func (m *CreateRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateRequest.Size(m)
+func (m *OrderListRequest) XXX_Size() int {
+	return xxx_messageInfo_OrderListRequest.Size(m)
 }
-func (m *CreateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+func (m *OrderListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderListRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+var xxx_messageInfo_OrderListRequest proto.InternalMessageInfo
 
-func (m *CreateRequest) GetChannelID() []byte {
+func (m *OrderListRequest) GetMine() bool {
+	if m != nil {
+		return m.Mine
+	}
+	return false
+}
+
+type SearchRequest struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Asset                string               `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string               `protobuf:"bytes,3,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Side                 Side                 `protobuf:"varint,4,opt,name=side,proto3,enum=pb.Side" json:"side,omitempty"`
+	MinPrice             float32              `protobuf:"fixed32,5,opt,name=minPrice,proto3" json:"minPrice,omitempty"`
+	MaxPrice             float32              `protobuf:"fixed32,6,opt,name=maxPrice,proto3" json:"maxPrice,omitempty"`
+	States               []State              `protobuf:"varint,7,rep,packed,name=states,proto3,enum=pb.State" json:"states,omitempty"`
+	MakerPeerID          []byte               `protobuf:"bytes,8,opt,name=makerPeerID,proto3" json:"makerPeerID,omitempty"`
+	CreatedSince         *timestamp.Timestamp `protobuf:"bytes,9,opt,name=createdSince,proto3" json:"createdSince,omitempty"`
+	Limit                uint32               `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{6}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetChannelID() []byte {
 	if m != nil {
 		return m.ChannelID
 	}
 	return nil
 }
 
-func (m *CreateRequest) GetAsset() string {
+func (m *SearchRequest) GetAsset() string {
 	if m != nil {
 		return m.Asset
 	}
 	return ""
 }
 
-func (m *CreateRequest) GetCounterAsset() string {
+func (m *SearchRequest) GetCounterAsset() string {
 	if m != nil {
 		return m.CounterAsset
 	}
 	return ""
 }
 
-func (m *CreateRequest) GetAmount() uint64 {
+func (m *SearchRequest) GetSide() Side {
 	if m != nil {
-		return m.Amount
+		return m.Side
 	}
-	return 0
+	return Side_ANY
 }
 
-func (m *CreateRequest) GetPrice() float32 {
+func (m *SearchRequest) GetMinPrice() float32 {
 	if m != nil {
-		return m.Price
+		return m.MinPrice
 	}
 	return 0
 }
 
-type JoinRequest struct {
-	Asset                string   `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
-	CounterAsset         string   `protobuf:"bytes,2,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SearchRequest) GetMaxPrice() float32 {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return 0
 }
 
-func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
-func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
-func (*JoinRequest) ProtoMessage()    {}
-func (*JoinRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{8}
+func (m *SearchRequest) GetStates() []State {
+	if m != nil {
+		return m.States
+	}
+	return nil
 }
 
-func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JoinRequest.Unmarshal(m, b)
-}
-func (m *JoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JoinRequest.Marshal(b, m, deterministic)
-}
-func (m *JoinRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JoinRequest.Merge(m, src)
-}
-func (m *JoinRequest) XXX_Size() int {
-	return xxx_messageInfo_JoinRequest.Size(m)
-}
-func (m *JoinRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_JoinRequest.DiscardUnknown(m)
+func (m *SearchRequest) GetMakerPeerID() []byte {
+	if m != nil {
+		return m.MakerPeerID
+	}
+	return nil
 }
 
-var xxx_messageInfo_JoinRequest proto.InternalMessageInfo
-
-func (m *JoinRequest) GetAsset() string {
+func (m *SearchRequest) GetCreatedSince() *timestamp.Timestamp {
 	if m != nil {
-		return m.Asset
+		return m.CreatedSince
 	}
-	return ""
+	return nil
 }
 
-func (m *JoinRequest) GetCounterAsset() string {
+func (m *SearchRequest) GetLimit() uint32 {
 	if m != nil {
-		return m.CounterAsset
+		return m.Limit
 	}
-	return ""
+	return 0
 }
 
-type ChannelOptions struct {
-	AssetPair            string   `protobuf:"bytes,1,opt,name=assetPair,proto3" json:"assetPair,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Channel struct {
+	Id                   []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Options              *ChannelOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	Admins               []string        `protobuf:"bytes,3,rep,name=admins,proto3" json:"admins,omitempty"`
+	Membership           *Membership     `protobuf:"bytes,4,opt,name=membership,proto3" json:"membership,omitempty"`
+	Config               *ChannelConfig  `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+	History              History         `protobuf:"varint,6,opt,name=history,proto3,enum=pb.History" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *ChannelOptions) Reset()         { *m = ChannelOptions{} }
-func (m *ChannelOptions) String() string { return proto.CompactTextString(m) }
-func (*ChannelOptions) ProtoMessage()    {}
-func (*ChannelOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{9}
+func (m *Channel) Reset()         { *m = Channel{} }
+func (m *Channel) String() string { return proto.CompactTextString(m) }
+func (*Channel) ProtoMessage()    {}
+func (*Channel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{7}
 }
 
-func (m *ChannelOptions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelOptions.Unmarshal(m, b)
+func (m *Channel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Channel.Unmarshal(m, b)
 }
-func (m *ChannelOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelOptions.Marshal(b, m, deterministic)
+func (m *Channel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Channel.Marshal(b, m, deterministic)
 }
-func (m *ChannelOptions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelOptions.Merge(m, src)
+func (m *Channel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Channel.Merge(m, src)
 }
-func (m *ChannelOptions) XXX_Size() int {
-	return xxx_messageInfo_ChannelOptions.Size(m)
+func (m *Channel) XXX_Size() int {
+	return xxx_messageInfo_Channel.Size(m)
 }
-func (m *ChannelOptions) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelOptions.DiscardUnknown(m)
+func (m *Channel) XXX_DiscardUnknown() {
+	xxx_messageInfo_Channel.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChannelOptions proto.InternalMessageInfo
+var xxx_messageInfo_Channel proto.InternalMessageInfo
 
-func (m *ChannelOptions) GetAssetPair() string {
+func (m *Channel) GetId() []byte {
 	if m != nil {
-		return m.AssetPair
+		return m.Id
 	}
-	return ""
+	return nil
 }
 
-type OrderSpecificRequest struct {
-	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
-	ChannelID            []byte   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Channel) GetOptions() *ChannelOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
 }
 
-func (m *OrderSpecificRequest) Reset()         { *m = OrderSpecificRequest{} }
-func (m *OrderSpecificRequest) String() string { return proto.CompactTextString(m) }
-func (*OrderSpecificRequest) ProtoMessage()    {}
-func (*OrderSpecificRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{10}
+func (m *Channel) GetAdmins() []string {
+	if m != nil {
+		return m.Admins
+	}
+	return nil
 }
 
-func (m *OrderSpecificRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderSpecificRequest.Unmarshal(m, b)
-}
-func (m *OrderSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderSpecificRequest.Marshal(b, m, deterministic)
-}
-func (m *OrderSpecificRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderSpecificRequest.Merge(m, src)
-}
-func (m *OrderSpecificRequest) XXX_Size() int {
-	return xxx_messageInfo_OrderSpecificRequest.Size(m)
-}
-func (m *OrderSpecificRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderSpecificRequest.DiscardUnknown(m)
+func (m *Channel) GetMembership() *Membership {
+	if m != nil {
+		return m.Membership
+	}
+	return nil
 }
 
-var xxx_messageInfo_OrderSpecificRequest proto.InternalMessageInfo
-
-func (m *OrderSpecificRequest) GetOrderID() []byte {
+func (m *Channel) GetConfig() *ChannelConfig {
 	if m != nil {
-		return m.OrderID
+		return m.Config
 	}
 	return nil
 }
 
-func (m *OrderSpecificRequest) GetChannelID() []byte {
+func (m *Channel) GetHistory() History {
 	if m != nil {
-		return m.ChannelID
+		return m.History
 	}
-	return nil
+	return History_OPEN_ORDERS
 }
 
-type ChannelSpecificRequest struct {
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type Membership struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Members              []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Version              uint32   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	CreatorPubKey        []byte   `protobuf:"bytes,4,opt,name=creatorPubKey,proto3" json:"creatorPubKey,omitempty"`
+	Signature            []byte   `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ChannelSpecificRequest) Reset()         { *m = ChannelSpecificRequest{} }
-func (m *ChannelSpecificRequest) String() string { return proto.CompactTextString(m) }
-func (*ChannelSpecificRequest) ProtoMessage()    {}
-func (*ChannelSpecificRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{11}
+func (m *Membership) Reset()         { *m = Membership{} }
+func (m *Membership) String() string { return proto.CompactTextString(m) }
+func (*Membership) ProtoMessage()    {}
+func (*Membership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{8}
 }
 
-func (m *ChannelSpecificRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelSpecificRequest.Unmarshal(m, b)
+func (m *Membership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Membership.Unmarshal(m, b)
 }
-func (m *ChannelSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelSpecificRequest.Marshal(b, m, deterministic)
+func (m *Membership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Membership.Marshal(b, m, deterministic)
 }
-func (m *ChannelSpecificRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelSpecificRequest.Merge(m, src)
+func (m *Membership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Membership.Merge(m, src)
 }
-func (m *ChannelSpecificRequest) XXX_Size() int {
-	return xxx_messageInfo_ChannelSpecificRequest.Size(m)
+func (m *Membership) XXX_Size() int {
+	return xxx_messageInfo_Membership.Size(m)
 }
-func (m *ChannelSpecificRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelSpecificRequest.DiscardUnknown(m)
+func (m *Membership) XXX_DiscardUnknown() {
+	xxx_messageInfo_Membership.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChannelSpecificRequest proto.InternalMessageInfo
+var xxx_messageInfo_Membership proto.InternalMessageInfo
 
-func (m *ChannelSpecificRequest) GetId() []byte {
+func (m *Membership) GetChannelID() []byte {
 	if m != nil {
-		return m.Id
+		return m.ChannelID
 	}
 	return nil
 }
 
-type CreateResponse struct {
-	CreatedOrder         *Order   `protobuf:"bytes,1,opt,name=createdOrder,proto3" json:"createdOrder,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
-func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateResponse) ProtoMessage()    {}
-func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{12}
+func (m *Membership) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
 }
 
-func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
-}
-func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
-}
-func (m *CreateResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateResponse.Merge(m, src)
-}
-func (m *CreateResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateResponse.Size(m)
-}
-func (m *CreateResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+func (m *Membership) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
 }
 
-var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
-
-func (m *CreateResponse) GetCreatedOrder() *Order {
+func (m *Membership) GetCreatorPubKey() []byte {
 	if m != nil {
-		return m.CreatedOrder
+		return m.CreatorPubKey
 	}
 	return nil
 }
 
-type OrderListResponse struct {
-	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Membership) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
 }
 
-func (m *OrderListResponse) Reset()         { *m = OrderListResponse{} }
-func (m *OrderListResponse) String() string { return proto.CompactTextString(m) }
-func (*OrderListResponse) ProtoMessage()    {}
-func (*OrderListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{13}
+type ChannelConfig struct {
+	ChannelID              []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Version                uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	TickSize               float32  `protobuf:"fixed32,3,opt,name=tickSize,proto3" json:"tickSize,omitempty"`
+	LotSize                uint64   `protobuf:"varint,4,opt,name=lotSize,proto3" json:"lotSize,omitempty"`
+	MakerFee               float32  `protobuf:"fixed32,5,opt,name=makerFee,proto3" json:"makerFee,omitempty"`
+	TakerFee               float32  `protobuf:"fixed32,6,opt,name=takerFee,proto3" json:"takerFee,omitempty"`
+	AllowlistHash          []byte   `protobuf:"bytes,7,opt,name=allowlistHash,proto3" json:"allowlistHash,omitempty"`
+	SettlementInstructions string   `protobuf:"bytes,8,opt,name=settlementInstructions,proto3" json:"settlementInstructions,omitempty"`
+	CreatorPubKey          []byte   `protobuf:"bytes,9,opt,name=creatorPubKey,proto3" json:"creatorPubKey,omitempty"`
+	Signature              []byte   `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`
+	MakerRateLimit         uint32   `protobuf:"varint,11,opt,name=makerRateLimit,proto3" json:"makerRateLimit,omitempty"`
+	MaxMakerOrders         uint32   `protobuf:"varint,12,opt,name=maxMakerOrders,proto3" json:"maxMakerOrders,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *ChannelConfig) Reset()         { *m = ChannelConfig{} }
+func (m *ChannelConfig) String() string { return proto.CompactTextString(m) }
+func (*ChannelConfig) ProtoMessage()    {}
+func (*ChannelConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{9}
 }
 
-func (m *OrderListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderListResponse.Unmarshal(m, b)
+func (m *ChannelConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelConfig.Unmarshal(m, b)
 }
-func (m *OrderListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderListResponse.Marshal(b, m, deterministic)
+func (m *ChannelConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelConfig.Marshal(b, m, deterministic)
 }
-func (m *OrderListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderListResponse.Merge(m, src)
+func (m *ChannelConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelConfig.Merge(m, src)
 }
-func (m *OrderListResponse) XXX_Size() int {
-	return xxx_messageInfo_OrderListResponse.Size(m)
+func (m *ChannelConfig) XXX_Size() int {
+	return xxx_messageInfo_ChannelConfig.Size(m)
 }
-func (m *OrderListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderListResponse.DiscardUnknown(m)
+func (m *ChannelConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelConfig.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderListResponse proto.InternalMessageInfo
+var xxx_messageInfo_ChannelConfig proto.InternalMessageInfo
 
-func (m *OrderListResponse) GetOrders() []*Order {
+func (m *ChannelConfig) GetChannelID() []byte {
 	if m != nil {
-		return m.Orders
+		return m.ChannelID
 	}
 	return nil
 }
 
-type ChannelListResponse struct {
-	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *ChannelConfig) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
 }
 
-func (m *ChannelListResponse) Reset()         { *m = ChannelListResponse{} }
-func (m *ChannelListResponse) String() string { return proto.CompactTextString(m) }
-func (*ChannelListResponse) ProtoMessage()    {}
-func (*ChannelListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{14}
+func (m *ChannelConfig) GetTickSize() float32 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
 }
 
-func (m *ChannelListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelListResponse.Unmarshal(m, b)
+func (m *ChannelConfig) GetLotSize() uint64 {
+	if m != nil {
+		return m.LotSize
+	}
+	return 0
 }
-func (m *ChannelListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelListResponse.Marshal(b, m, deterministic)
+
+func (m *ChannelConfig) GetMakerFee() float32 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
 }
-func (m *ChannelListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelListResponse.Merge(m, src)
+
+func (m *ChannelConfig) GetTakerFee() float32 {
+	if m != nil {
+		return m.TakerFee
+	}
+	return 0
 }
-func (m *ChannelListResponse) XXX_Size() int {
-	return xxx_messageInfo_ChannelListResponse.Size(m)
+
+func (m *ChannelConfig) GetAllowlistHash() []byte {
+	if m != nil {
+		return m.AllowlistHash
+	}
+	return nil
 }
-func (m *ChannelListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelListResponse.DiscardUnknown(m)
+
+func (m *ChannelConfig) GetSettlementInstructions() string {
+	if m != nil {
+		return m.SettlementInstructions
+	}
+	return ""
 }
 
-var xxx_messageInfo_ChannelListResponse proto.InternalMessageInfo
+func (m *ChannelConfig) GetCreatorPubKey() []byte {
+	if m != nil {
+		return m.CreatorPubKey
+	}
+	return nil
+}
 
-func (m *ChannelListResponse) GetChannels() []*Channel {
+func (m *ChannelConfig) GetSignature() []byte {
 	if m != nil {
-		return m.Channels
+		return m.Signature
 	}
 	return nil
 }
 
-type PeerListResponse struct {
-	PeerIDs              []string `protobuf:"bytes,1,rep,name=peerIDs,proto3" json:"peerIDs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ChannelConfig) GetMakerRateLimit() uint32 {
+	if m != nil {
+		return m.MakerRateLimit
+	}
+	return 0
 }
 
-func (m *PeerListResponse) Reset()         { *m = PeerListResponse{} }
-func (m *PeerListResponse) String() string { return proto.CompactTextString(m) }
-func (*PeerListResponse) ProtoMessage()    {}
-func (*PeerListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{15}
+func (m *ChannelConfig) GetMaxMakerOrders() uint32 {
+	if m != nil {
+		return m.MaxMakerOrders
+	}
+	return 0
 }
 
-func (m *PeerListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PeerListResponse.Unmarshal(m, b)
+type IdentityTransition struct {
+	OldPubKey            []byte               `protobuf:"bytes,1,opt,name=oldPubKey,proto3" json:"oldPubKey,omitempty"`
+	NewPubKey            []byte               `protobuf:"bytes,2,opt,name=newPubKey,proto3" json:"newPubKey,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	Signature            []byte               `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
-func (m *PeerListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PeerListResponse.Marshal(b, m, deterministic)
+
+func (m *IdentityTransition) Reset()         { *m = IdentityTransition{} }
+func (m *IdentityTransition) String() string { return proto.CompactTextString(m) }
+func (*IdentityTransition) ProtoMessage()    {}
+func (*IdentityTransition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{10}
 }
-func (m *PeerListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PeerListResponse.Merge(m, src)
+
+func (m *IdentityTransition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdentityTransition.Unmarshal(m, b)
 }
-func (m *PeerListResponse) XXX_Size() int {
-	return xxx_messageInfo_PeerListResponse.Size(m)
+func (m *IdentityTransition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdentityTransition.Marshal(b, m, deterministic)
 }
-func (m *PeerListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PeerListResponse.DiscardUnknown(m)
+func (m *IdentityTransition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentityTransition.Merge(m, src)
+}
+func (m *IdentityTransition) XXX_Size() int {
+	return xxx_messageInfo_IdentityTransition.Size(m)
+}
+func (m *IdentityTransition) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentityTransition.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PeerListResponse proto.InternalMessageInfo
+var xxx_messageInfo_IdentityTransition proto.InternalMessageInfo
 
-func (m *PeerListResponse) GetPeerIDs() []string {
+func (m *IdentityTransition) GetOldPubKey() []byte {
 	if m != nil {
-		return m.PeerIDs
+		return m.OldPubKey
 	}
 	return nil
 }
 
-type JoinResponse struct {
-	JoinedChannel        *Channel `protobuf:"bytes,1,opt,name=joinedChannel,proto3" json:"joinedChannel,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *IdentityTransition) GetNewPubKey() []byte {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return nil
 }
 
-func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
-func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
-func (*JoinResponse) ProtoMessage()    {}
-func (*JoinResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{16}
+func (m *IdentityTransition) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
 }
 
-func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JoinResponse.Unmarshal(m, b)
+func (m *IdentityTransition) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
 }
-func (m *JoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JoinResponse.Marshal(b, m, deterministic)
+
+type ChannelList struct {
+	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
-func (m *JoinResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JoinResponse.Merge(m, src)
+
+func (m *ChannelList) Reset()         { *m = ChannelList{} }
+func (m *ChannelList) String() string { return proto.CompactTextString(m) }
+func (*ChannelList) ProtoMessage()    {}
+func (*ChannelList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{11}
 }
-func (m *JoinResponse) XXX_Size() int {
-	return xxx_messageInfo_JoinResponse.Size(m)
+
+func (m *ChannelList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelList.Unmarshal(m, b)
 }
-func (m *JoinResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_JoinResponse.DiscardUnknown(m)
+func (m *ChannelList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelList.Marshal(b, m, deterministic)
+}
+func (m *ChannelList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelList.Merge(m, src)
+}
+func (m *ChannelList) XXX_Size() int {
+	return xxx_messageInfo_ChannelList.Size(m)
+}
+func (m *ChannelList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelList.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_JoinResponse proto.InternalMessageInfo
+var xxx_messageInfo_ChannelList proto.InternalMessageInfo
 
-func (m *JoinResponse) GetJoinedChannel() *Channel {
+func (m *ChannelList) GetChannels() []*Channel {
 	if m != nil {
-		return m.JoinedChannel
+		return m.Channels
 	}
 	return nil
 }
 
-type Empty struct {
+type Recipient struct {
+	PeerID               []byte   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Empty) Reset()         { *m = Empty{} }
-func (m *Empty) String() string { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()    {}
-func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b5e409e9578376a3, []int{17}
+func (m *Recipient) Reset()         { *m = Recipient{} }
+func (m *Recipient) String() string { return proto.CompactTextString(m) }
+func (*Recipient) ProtoMessage()    {}
+func (*Recipient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{12}
 }
 
-func (m *Empty) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Empty.Unmarshal(m, b)
+func (m *Recipient) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Recipient.Unmarshal(m, b)
 }
-func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+func (m *Recipient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Recipient.Marshal(b, m, deterministic)
 }
-func (m *Empty) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Empty.Merge(m, src)
+func (m *Recipient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Recipient.Merge(m, src)
 }
-func (m *Empty) XXX_Size() int {
-	return xxx_messageInfo_Empty.Size(m)
+func (m *Recipient) XXX_Size() int {
+	return xxx_messageInfo_Recipient.Size(m)
 }
-func (m *Empty) XXX_DiscardUnknown() {
-	xxx_messageInfo_Empty.DiscardUnknown(m)
+func (m *Recipient) XXX_DiscardUnknown() {
+	xxx_messageInfo_Recipient.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Empty proto.InternalMessageInfo
+var xxx_messageInfo_Recipient proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("pb.State", State_name, State_value)
-	proto.RegisterEnum("pb.Operation", Operation_name, Operation_value)
-	proto.RegisterType((*Peer)(nil), "pb.Peer")
-	proto.RegisterType((*Order)(nil), "pb.Order")
-	proto.RegisterType((*OrderList)(nil), "pb.OrderList")
-	proto.RegisterType((*Channel)(nil), "pb.Channel")
-	proto.RegisterType((*ChannelList)(nil), "pb.ChannelList")
+func (m *Recipient) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
+	}
+	return nil
+}
+
+type WireMessage struct {
+	ChannelID            []byte    `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Operation            Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=pb.Operation" json:"operation,omitempty"`
+	Data                 []byte    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *WireMessage) Reset()         { *m = WireMessage{} }
+func (m *WireMessage) String() string { return proto.CompactTextString(m) }
+func (*WireMessage) ProtoMessage()    {}
+func (*WireMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{13}
+}
+
+func (m *WireMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WireMessage.Unmarshal(m, b)
+}
+func (m *WireMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WireMessage.Marshal(b, m, deterministic)
+}
+func (m *WireMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WireMessage.Merge(m, src)
+}
+func (m *WireMessage) XXX_Size() int {
+	return xxx_messageInfo_WireMessage.Size(m)
+}
+func (m *WireMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_WireMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WireMessage proto.InternalMessageInfo
+
+func (m *WireMessage) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *WireMessage) GetOperation() Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return Operation_CREATE
+}
+
+func (m *WireMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type WireMessageBatch struct {
+	Messages             []*WireMessage `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *WireMessageBatch) Reset()         { *m = WireMessageBatch{} }
+func (m *WireMessageBatch) String() string { return proto.CompactTextString(m) }
+func (*WireMessageBatch) ProtoMessage()    {}
+func (*WireMessageBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{14}
+}
+
+func (m *WireMessageBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WireMessageBatch.Unmarshal(m, b)
+}
+func (m *WireMessageBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WireMessageBatch.Marshal(b, m, deterministic)
+}
+func (m *WireMessageBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WireMessageBatch.Merge(m, src)
+}
+func (m *WireMessageBatch) XXX_Size() int {
+	return xxx_messageInfo_WireMessageBatch.Size(m)
+}
+func (m *WireMessageBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_WireMessageBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WireMessageBatch proto.InternalMessageInfo
+
+func (m *WireMessageBatch) GetMessages() []*WireMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type Subscription struct {
+	Asset                string   `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string   `protobuf:"bytes,2,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	MinPrice             float32  `protobuf:"fixed32,3,opt,name=minPrice,proto3" json:"minPrice,omitempty"`
+	MaxPrice             float32  `protobuf:"fixed32,4,opt,name=maxPrice,proto3" json:"maxPrice,omitempty"`
+	Side                 Side     `protobuf:"varint,5,opt,name=side,proto3,enum=pb.Side" json:"side,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Subscription) Reset()         { *m = Subscription{} }
+func (m *Subscription) String() string { return proto.CompactTextString(m) }
+func (*Subscription) ProtoMessage()    {}
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{15}
+}
+
+func (m *Subscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Subscription.Unmarshal(m, b)
+}
+func (m *Subscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Subscription.Marshal(b, m, deterministic)
+}
+func (m *Subscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Subscription.Merge(m, src)
+}
+func (m *Subscription) XXX_Size() int {
+	return xxx_messageInfo_Subscription.Size(m)
+}
+func (m *Subscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_Subscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Subscription proto.InternalMessageInfo
+
+func (m *Subscription) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *Subscription) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
+}
+
+func (m *Subscription) GetMinPrice() float32 {
+	if m != nil {
+		return m.MinPrice
+	}
+	return 0
+}
+
+func (m *Subscription) GetMaxPrice() float32 {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return 0
+}
+
+func (m *Subscription) GetSide() Side {
+	if m != nil {
+		return m.Side
+	}
+	return Side_ANY
+}
+
+type Handshake struct {
+	PublicKey            []byte               `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Challenge            []byte               `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Signature            []byte               `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	ProtocolVersion      string               `protobuf:"bytes,4,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Channels             [][]byte             `protobuf:"bytes,5,rep,name=channels,proto3" json:"channels,omitempty"`
+	Capabilities         []string             `protobuf:"bytes,6,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Handshake) Reset()         { *m = Handshake{} }
+func (m *Handshake) String() string { return proto.CompactTextString(m) }
+func (*Handshake) ProtoMessage()    {}
+func (*Handshake) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{16}
+}
+
+func (m *Handshake) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Handshake.Unmarshal(m, b)
+}
+func (m *Handshake) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Handshake.Marshal(b, m, deterministic)
+}
+func (m *Handshake) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Handshake.Merge(m, src)
+}
+func (m *Handshake) XXX_Size() int {
+	return xxx_messageInfo_Handshake.Size(m)
+}
+func (m *Handshake) XXX_DiscardUnknown() {
+	xxx_messageInfo_Handshake.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Handshake proto.InternalMessageInfo
+
+func (m *Handshake) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *Handshake) GetChallenge() []byte {
+	if m != nil {
+		return m.Challenge
+	}
+	return nil
+}
+
+func (m *Handshake) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Handshake) GetProtocolVersion() string {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return ""
+}
+
+func (m *Handshake) GetChannels() [][]byte {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Handshake) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *Handshake) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type CreateRequest struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Asset                string   `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string   `protobuf:"bytes,3,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Amount               uint64   `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price                float32  `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{17}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *CreateRequest) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+type Negotiation struct {
+	Id                   []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChannelID            []byte               `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Order                *Order               `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	Step                 NegotiationStep      `protobuf:"varint,4,opt,name=step,proto3,enum=pb.NegotiationStep" json:"step,omitempty"`
+	Amount               uint64               `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price                float32              `protobuf:"fixed32,6,opt,name=price,proto3" json:"price,omitempty"`
+	RemainderOrderID     []byte               `protobuf:"bytes,7,opt,name=remainderOrderID,proto3" json:"remainderOrderID,omitempty"`
+	Updated              *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Negotiation) Reset()         { *m = Negotiation{} }
+func (m *Negotiation) String() string { return proto.CompactTextString(m) }
+func (*Negotiation) ProtoMessage()    {}
+func (*Negotiation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{18}
+}
+
+func (m *Negotiation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Negotiation.Unmarshal(m, b)
+}
+func (m *Negotiation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Negotiation.Marshal(b, m, deterministic)
+}
+func (m *Negotiation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Negotiation.Merge(m, src)
+}
+func (m *Negotiation) XXX_Size() int {
+	return xxx_messageInfo_Negotiation.Size(m)
+}
+func (m *Negotiation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Negotiation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Negotiation proto.InternalMessageInfo
+
+func (m *Negotiation) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Negotiation) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *Negotiation) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *Negotiation) GetStep() NegotiationStep {
+	if m != nil {
+		return m.Step
+	}
+	return NegotiationStep_RESUME
+}
+
+func (m *Negotiation) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Negotiation) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *Negotiation) GetRemainderOrderID() []byte {
+	if m != nil {
+		return m.RemainderOrderID
+	}
+	return nil
+}
+
+func (m *Negotiation) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+type NegotiationMessage struct {
+	NegotiationID        []byte          `protobuf:"bytes,1,opt,name=negotiationID,proto3" json:"negotiationID,omitempty"`
+	Step                 NegotiationStep `protobuf:"varint,2,opt,name=step,proto3,enum=pb.NegotiationStep" json:"step,omitempty"`
+	ChannelID            []byte          `protobuf:"bytes,3,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	OrderID              []byte          `protobuf:"bytes,4,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Amount               uint64          `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price                float32         `protobuf:"fixed32,6,opt,name=price,proto3" json:"price,omitempty"`
+	Negotiation          *Negotiation    `protobuf:"bytes,7,opt,name=negotiation,proto3" json:"negotiation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *NegotiationMessage) Reset()         { *m = NegotiationMessage{} }
+func (m *NegotiationMessage) String() string { return proto.CompactTextString(m) }
+func (*NegotiationMessage) ProtoMessage()    {}
+func (*NegotiationMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{19}
+}
+
+func (m *NegotiationMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NegotiationMessage.Unmarshal(m, b)
+}
+func (m *NegotiationMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NegotiationMessage.Marshal(b, m, deterministic)
+}
+func (m *NegotiationMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NegotiationMessage.Merge(m, src)
+}
+func (m *NegotiationMessage) XXX_Size() int {
+	return xxx_messageInfo_NegotiationMessage.Size(m)
+}
+func (m *NegotiationMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NegotiationMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NegotiationMessage proto.InternalMessageInfo
+
+func (m *NegotiationMessage) GetNegotiationID() []byte {
+	if m != nil {
+		return m.NegotiationID
+	}
+	return nil
+}
+
+func (m *NegotiationMessage) GetStep() NegotiationStep {
+	if m != nil {
+		return m.Step
+	}
+	return NegotiationStep_RESUME
+}
+
+func (m *NegotiationMessage) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *NegotiationMessage) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+func (m *NegotiationMessage) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *NegotiationMessage) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *NegotiationMessage) GetNegotiation() *Negotiation {
+	if m != nil {
+		return m.Negotiation
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	Asset                string          `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	CounterAsset         string          `protobuf:"bytes,2,opt,name=counterAsset,proto3" json:"counterAsset,omitempty"`
+	Admins               []string        `protobuf:"bytes,3,rep,name=admins,proto3" json:"admins,omitempty"`
+	Options              *ChannelOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	History              History         `protobuf:"varint,5,opt,name=history,proto3,enum=pb.History" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
+func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinRequest) ProtoMessage()    {}
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{20}
+}
+
+func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinRequest.Unmarshal(m, b)
+}
+func (m *JoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinRequest.Marshal(b, m, deterministic)
+}
+func (m *JoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinRequest.Merge(m, src)
+}
+func (m *JoinRequest) XXX_Size() int {
+	return xxx_messageInfo_JoinRequest.Size(m)
+}
+func (m *JoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinRequest proto.InternalMessageInfo
+
+func (m *JoinRequest) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *JoinRequest) GetCounterAsset() string {
+	if m != nil {
+		return m.CounterAsset
+	}
+	return ""
+}
+
+func (m *JoinRequest) GetAdmins() []string {
+	if m != nil {
+		return m.Admins
+	}
+	return nil
+}
+
+func (m *JoinRequest) GetOptions() *ChannelOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *JoinRequest) GetHistory() History {
+	if m != nil {
+		return m.History
+	}
+	return History_OPEN_ORDERS
+}
+
+type ChannelOptions struct {
+	AssetPair            string   `protobuf:"bytes,1,opt,name=assetPair,proto3" json:"assetPair,omitempty"`
+	TickSize             float32  `protobuf:"fixed32,2,opt,name=tickSize,proto3" json:"tickSize,omitempty"`
+	LotSize              uint64   `protobuf:"varint,3,opt,name=lotSize,proto3" json:"lotSize,omitempty"`
+	QuoteAsset           string   `protobuf:"bytes,4,opt,name=quoteAsset,proto3" json:"quoteAsset,omitempty"`
+	MembersOnly          bool     `protobuf:"varint,5,opt,name=membersOnly,proto3" json:"membersOnly,omitempty"`
+	Creator              string   `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	Assets               []*Asset `protobuf:"bytes,7,rep,name=assets,proto3" json:"assets,omitempty"`
+	MakerFee             float32  `protobuf:"fixed32,8,opt,name=makerFee,proto3" json:"makerFee,omitempty"`
+	TakerFee             float32  `protobuf:"fixed32,9,opt,name=takerFee,proto3" json:"takerFee,omitempty"`
+	Operators            []string `protobuf:"bytes,10,rep,name=operators,proto3" json:"operators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelOptions) Reset()         { *m = ChannelOptions{} }
+func (m *ChannelOptions) String() string { return proto.CompactTextString(m) }
+func (*ChannelOptions) ProtoMessage()    {}
+func (*ChannelOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{21}
+}
+
+func (m *ChannelOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelOptions.Unmarshal(m, b)
+}
+func (m *ChannelOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelOptions.Marshal(b, m, deterministic)
+}
+func (m *ChannelOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelOptions.Merge(m, src)
+}
+func (m *ChannelOptions) XXX_Size() int {
+	return xxx_messageInfo_ChannelOptions.Size(m)
+}
+func (m *ChannelOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelOptions proto.InternalMessageInfo
+
+func (m *ChannelOptions) GetAssetPair() string {
+	if m != nil {
+		return m.AssetPair
+	}
+	return ""
+}
+
+func (m *ChannelOptions) GetTickSize() float32 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+func (m *ChannelOptions) GetLotSize() uint64 {
+	if m != nil {
+		return m.LotSize
+	}
+	return 0
+}
+
+func (m *ChannelOptions) GetQuoteAsset() string {
+	if m != nil {
+		return m.QuoteAsset
+	}
+	return ""
+}
+
+func (m *ChannelOptions) GetMembersOnly() bool {
+	if m != nil {
+		return m.MembersOnly
+	}
+	return false
+}
+
+func (m *ChannelOptions) GetCreator() string {
+	if m != nil {
+		return m.Creator
+	}
+	return ""
+}
+
+func (m *ChannelOptions) GetAssets() []*Asset {
+	if m != nil {
+		return m.Assets
+	}
+	return nil
+}
+
+func (m *ChannelOptions) GetMakerFee() float32 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
+
+func (m *ChannelOptions) GetTakerFee() float32 {
+	if m != nil {
+		return m.TakerFee
+	}
+	return 0
+}
+
+func (m *ChannelOptions) GetOperators() []string {
+	if m != nil {
+		return m.Operators
+	}
+	return nil
+}
+
+type Asset struct {
+	Symbol               string   `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals             uint32   `protobuf:"varint,2,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Chain                string   `protobuf:"bytes,3,opt,name=chain,proto3" json:"chain,omitempty"`
+	ContractAddress      string   `protobuf:"bytes,4,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Asset) Reset()         { *m = Asset{} }
+func (m *Asset) String() string { return proto.CompactTextString(m) }
+func (*Asset) ProtoMessage()    {}
+func (*Asset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{22}
+}
+
+func (m *Asset) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Asset.Unmarshal(m, b)
+}
+func (m *Asset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Asset.Marshal(b, m, deterministic)
+}
+func (m *Asset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Asset.Merge(m, src)
+}
+func (m *Asset) XXX_Size() int {
+	return xxx_messageInfo_Asset.Size(m)
+}
+func (m *Asset) XXX_DiscardUnknown() {
+	xxx_messageInfo_Asset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Asset proto.InternalMessageInfo
+
+func (m *Asset) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *Asset) GetDecimals() uint32 {
+	if m != nil {
+		return m.Decimals
+	}
+	return 0
+}
+
+func (m *Asset) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *Asset) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+type AssetList struct {
+	Assets               []*Asset `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AssetList) Reset()         { *m = AssetList{} }
+func (m *AssetList) String() string { return proto.CompactTextString(m) }
+func (*AssetList) ProtoMessage()    {}
+func (*AssetList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{23}
+}
+
+func (m *AssetList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AssetList.Unmarshal(m, b)
+}
+func (m *AssetList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AssetList.Marshal(b, m, deterministic)
+}
+func (m *AssetList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssetList.Merge(m, src)
+}
+func (m *AssetList) XXX_Size() int {
+	return xxx_messageInfo_AssetList.Size(m)
+}
+func (m *AssetList) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssetList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssetList proto.InternalMessageInfo
+
+func (m *AssetList) GetAssets() []*Asset {
+	if m != nil {
+		return m.Assets
+	}
+	return nil
+}
+
+type OrderSpecificRequest struct {
+	OrderID              []byte   `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	ChannelID            []byte   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderSpecificRequest) Reset()         { *m = OrderSpecificRequest{} }
+func (m *OrderSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*OrderSpecificRequest) ProtoMessage()    {}
+func (*OrderSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{24}
+}
+
+func (m *OrderSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderSpecificRequest.Unmarshal(m, b)
+}
+func (m *OrderSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *OrderSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderSpecificRequest.Merge(m, src)
+}
+func (m *OrderSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_OrderSpecificRequest.Size(m)
+}
+func (m *OrderSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderSpecificRequest proto.InternalMessageInfo
+
+func (m *OrderSpecificRequest) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+func (m *OrderSpecificRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+type OrderHistoryRequest struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	From                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *timestamp.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Cursor               []byte               `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit                uint32               `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *OrderHistoryRequest) Reset()         { *m = OrderHistoryRequest{} }
+func (m *OrderHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*OrderHistoryRequest) ProtoMessage()    {}
+func (*OrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{25}
+}
+
+func (m *OrderHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderHistoryRequest.Unmarshal(m, b)
+}
+func (m *OrderHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *OrderHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderHistoryRequest.Merge(m, src)
+}
+func (m *OrderHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_OrderHistoryRequest.Size(m)
+}
+func (m *OrderHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderHistoryRequest proto.InternalMessageInfo
+
+func (m *OrderHistoryRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *OrderHistoryRequest) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *OrderHistoryRequest) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *OrderHistoryRequest) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+func (m *OrderHistoryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type MembershipRequest struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Members              []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MembershipRequest) Reset()         { *m = MembershipRequest{} }
+func (m *MembershipRequest) String() string { return proto.CompactTextString(m) }
+func (*MembershipRequest) ProtoMessage()    {}
+func (*MembershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{26}
+}
+
+func (m *MembershipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MembershipRequest.Unmarshal(m, b)
+}
+func (m *MembershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MembershipRequest.Marshal(b, m, deterministic)
+}
+func (m *MembershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembershipRequest.Merge(m, src)
+}
+func (m *MembershipRequest) XXX_Size() int {
+	return xxx_messageInfo_MembershipRequest.Size(m)
+}
+func (m *MembershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembershipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MembershipRequest proto.InternalMessageInfo
+
+func (m *MembershipRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *MembershipRequest) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type ChannelConfigRequest struct {
+	ChannelID              []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	TickSize               float32  `protobuf:"fixed32,2,opt,name=tickSize,proto3" json:"tickSize,omitempty"`
+	LotSize                uint64   `protobuf:"varint,3,opt,name=lotSize,proto3" json:"lotSize,omitempty"`
+	MakerFee               float32  `protobuf:"fixed32,4,opt,name=makerFee,proto3" json:"makerFee,omitempty"`
+	TakerFee               float32  `protobuf:"fixed32,5,opt,name=takerFee,proto3" json:"takerFee,omitempty"`
+	PinMembers             bool     `protobuf:"varint,6,opt,name=pinMembers,proto3" json:"pinMembers,omitempty"`
+	SettlementInstructions string   `protobuf:"bytes,7,opt,name=settlementInstructions,proto3" json:"settlementInstructions,omitempty"`
+	MakerRateLimit         uint32   `protobuf:"varint,8,opt,name=makerRateLimit,proto3" json:"makerRateLimit,omitempty"`
+	MaxMakerOrders         uint32   `protobuf:"varint,9,opt,name=maxMakerOrders,proto3" json:"maxMakerOrders,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *ChannelConfigRequest) Reset()         { *m = ChannelConfigRequest{} }
+func (m *ChannelConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*ChannelConfigRequest) ProtoMessage()    {}
+func (*ChannelConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{27}
+}
+
+func (m *ChannelConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelConfigRequest.Unmarshal(m, b)
+}
+func (m *ChannelConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *ChannelConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelConfigRequest.Merge(m, src)
+}
+func (m *ChannelConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_ChannelConfigRequest.Size(m)
+}
+func (m *ChannelConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelConfigRequest proto.InternalMessageInfo
+
+func (m *ChannelConfigRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *ChannelConfigRequest) GetTickSize() float32 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+func (m *ChannelConfigRequest) GetLotSize() uint64 {
+	if m != nil {
+		return m.LotSize
+	}
+	return 0
+}
+
+func (m *ChannelConfigRequest) GetMakerFee() float32 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
+
+func (m *ChannelConfigRequest) GetTakerFee() float32 {
+	if m != nil {
+		return m.TakerFee
+	}
+	return 0
+}
+
+func (m *ChannelConfigRequest) GetPinMembers() bool {
+	if m != nil {
+		return m.PinMembers
+	}
+	return false
+}
+
+func (m *ChannelConfigRequest) GetSettlementInstructions() string {
+	if m != nil {
+		return m.SettlementInstructions
+	}
+	return ""
+}
+
+func (m *ChannelConfigRequest) GetMakerRateLimit() uint32 {
+	if m != nil {
+		return m.MakerRateLimit
+	}
+	return 0
+}
+
+func (m *ChannelConfigRequest) GetMaxMakerOrders() uint32 {
+	if m != nil {
+		return m.MaxMakerOrders
+	}
+	return 0
+}
+
+type ChannelSpecificRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelSpecificRequest) Reset()         { *m = ChannelSpecificRequest{} }
+func (m *ChannelSpecificRequest) String() string { return proto.CompactTextString(m) }
+func (*ChannelSpecificRequest) ProtoMessage()    {}
+func (*ChannelSpecificRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{28}
+}
+
+func (m *ChannelSpecificRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelSpecificRequest.Unmarshal(m, b)
+}
+func (m *ChannelSpecificRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelSpecificRequest.Marshal(b, m, deterministic)
+}
+func (m *ChannelSpecificRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelSpecificRequest.Merge(m, src)
+}
+func (m *ChannelSpecificRequest) XXX_Size() int {
+	return xxx_messageInfo_ChannelSpecificRequest.Size(m)
+}
+func (m *ChannelSpecificRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelSpecificRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelSpecificRequest proto.InternalMessageInfo
+
+func (m *ChannelSpecificRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type CreateResponse struct {
+	CreatedOrder         *Order   `protobuf:"bytes,1,opt,name=createdOrder,proto3" json:"createdOrder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
+func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateResponse) ProtoMessage()    {}
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{29}
+}
+
+func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+}
+func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateResponse.Merge(m, src)
+}
+func (m *CreateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateResponse.Size(m)
+}
+func (m *CreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+
+func (m *CreateResponse) GetCreatedOrder() *Order {
+	if m != nil {
+		return m.CreatedOrder
+	}
+	return nil
+}
+
+type OrderListResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderListResponse) Reset()         { *m = OrderListResponse{} }
+func (m *OrderListResponse) String() string { return proto.CompactTextString(m) }
+func (*OrderListResponse) ProtoMessage()    {}
+func (*OrderListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{30}
+}
+
+func (m *OrderListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderListResponse.Unmarshal(m, b)
+}
+func (m *OrderListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderListResponse.Marshal(b, m, deterministic)
+}
+func (m *OrderListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderListResponse.Merge(m, src)
+}
+func (m *OrderListResponse) XXX_Size() int {
+	return xxx_messageInfo_OrderListResponse.Size(m)
+}
+func (m *OrderListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderListResponse proto.InternalMessageInfo
+
+func (m *OrderListResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type OrderHistoryResponse struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextCursor           []byte   `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderHistoryResponse) Reset()         { *m = OrderHistoryResponse{} }
+func (m *OrderHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*OrderHistoryResponse) ProtoMessage()    {}
+func (*OrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{31}
+}
+
+func (m *OrderHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderHistoryResponse.Unmarshal(m, b)
+}
+func (m *OrderHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *OrderHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderHistoryResponse.Merge(m, src)
+}
+func (m *OrderHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_OrderHistoryResponse.Size(m)
+}
+func (m *OrderHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderHistoryResponse proto.InternalMessageInfo
+
+func (m *OrderHistoryResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+func (m *OrderHistoryResponse) GetNextCursor() []byte {
+	if m != nil {
+		return m.NextCursor
+	}
+	return nil
+}
+
+type ChannelListResponse struct {
+	Channels             []*Channel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ChannelListResponse) Reset()         { *m = ChannelListResponse{} }
+func (m *ChannelListResponse) String() string { return proto.CompactTextString(m) }
+func (*ChannelListResponse) ProtoMessage()    {}
+func (*ChannelListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{32}
+}
+
+func (m *ChannelListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelListResponse.Unmarshal(m, b)
+}
+func (m *ChannelListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelListResponse.Marshal(b, m, deterministic)
+}
+func (m *ChannelListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelListResponse.Merge(m, src)
+}
+func (m *ChannelListResponse) XXX_Size() int {
+	return xxx_messageInfo_ChannelListResponse.Size(m)
+}
+func (m *ChannelListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelListResponse proto.InternalMessageInfo
+
+func (m *ChannelListResponse) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type PeerListResponse struct {
+	PeerIDs              []string `protobuf:"bytes,1,rep,name=peerIDs,proto3" json:"peerIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerListResponse) Reset()         { *m = PeerListResponse{} }
+func (m *PeerListResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerListResponse) ProtoMessage()    {}
+func (*PeerListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{33}
+}
+
+func (m *PeerListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerListResponse.Unmarshal(m, b)
+}
+func (m *PeerListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerListResponse.Marshal(b, m, deterministic)
+}
+func (m *PeerListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerListResponse.Merge(m, src)
+}
+func (m *PeerListResponse) XXX_Size() int {
+	return xxx_messageInfo_PeerListResponse.Size(m)
+}
+func (m *PeerListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerListResponse proto.InternalMessageInfo
+
+func (m *PeerListResponse) GetPeerIDs() []string {
+	if m != nil {
+		return m.PeerIDs
+	}
+	return nil
+}
+
+type PeerScore struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score                int32    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	InvalidSignatures    uint32   `protobuf:"varint,3,opt,name=invalidSignatures,proto3" json:"invalidSignatures,omitempty"`
+	Replays              uint32   `protobuf:"varint,4,opt,name=replays,proto3" json:"replays,omitempty"`
+	Malformed            uint32   `protobuf:"varint,5,opt,name=malformed,proto3" json:"malformed,omitempty"`
+	Spam                 uint32   `protobuf:"varint,6,opt,name=spam,proto3" json:"spam,omitempty"`
+	Oversized            uint32   `protobuf:"varint,7,opt,name=oversized,proto3" json:"oversized,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerScore) Reset()         { *m = PeerScore{} }
+func (m *PeerScore) String() string { return proto.CompactTextString(m) }
+func (*PeerScore) ProtoMessage()    {}
+func (*PeerScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{34}
+}
+
+func (m *PeerScore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerScore.Unmarshal(m, b)
+}
+func (m *PeerScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerScore.Marshal(b, m, deterministic)
+}
+func (m *PeerScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerScore.Merge(m, src)
+}
+func (m *PeerScore) XXX_Size() int {
+	return xxx_messageInfo_PeerScore.Size(m)
+}
+func (m *PeerScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerScore proto.InternalMessageInfo
+
+func (m *PeerScore) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PeerScore) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *PeerScore) GetInvalidSignatures() uint32 {
+	if m != nil {
+		return m.InvalidSignatures
+	}
+	return 0
+}
+
+func (m *PeerScore) GetReplays() uint32 {
+	if m != nil {
+		return m.Replays
+	}
+	return 0
+}
+
+func (m *PeerScore) GetMalformed() uint32 {
+	if m != nil {
+		return m.Malformed
+	}
+	return 0
+}
+
+func (m *PeerScore) GetSpam() uint32 {
+	if m != nil {
+		return m.Spam
+	}
+	return 0
+}
+
+func (m *PeerScore) GetOversized() uint32 {
+	if m != nil {
+		return m.Oversized
+	}
+	return 0
+}
+
+type PeerDetails struct {
+	Id                   string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addresses            []string  `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	AgentVersion         string    `protobuf:"bytes,3,opt,name=agentVersion,proto3" json:"agentVersion,omitempty"`
+	ProtocolVersion      string    `protobuf:"bytes,4,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	SprawlVersion        string    `protobuf:"bytes,5,opt,name=sprawlVersion,proto3" json:"sprawlVersion,omitempty"`
+	Channels             [][]byte  `protobuf:"bytes,6,rep,name=channels,proto3" json:"channels,omitempty"`
+	Direction            Direction `protobuf:"varint,7,opt,name=direction,proto3,enum=pb.Direction" json:"direction,omitempty"`
+	Latency              int64     `protobuf:"varint,8,opt,name=latency,proto3" json:"latency,omitempty"`
+	BytesIn              uint64    `protobuf:"varint,9,opt,name=bytesIn,proto3" json:"bytesIn,omitempty"`
+	BytesOut             uint64    `protobuf:"varint,10,opt,name=bytesOut,proto3" json:"bytesOut,omitempty"`
+	RateIn               float64   `protobuf:"fixed64,11,opt,name=rateIn,proto3" json:"rateIn,omitempty"`
+	RateOut              float64   `protobuf:"fixed64,12,opt,name=rateOut,proto3" json:"rateOut,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PeerDetails) Reset()         { *m = PeerDetails{} }
+func (m *PeerDetails) String() string { return proto.CompactTextString(m) }
+func (*PeerDetails) ProtoMessage()    {}
+func (*PeerDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{35}
+}
+
+func (m *PeerDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerDetails.Unmarshal(m, b)
+}
+func (m *PeerDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerDetails.Marshal(b, m, deterministic)
+}
+func (m *PeerDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerDetails.Merge(m, src)
+}
+func (m *PeerDetails) XXX_Size() int {
+	return xxx_messageInfo_PeerDetails.Size(m)
+}
+func (m *PeerDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerDetails proto.InternalMessageInfo
+
+func (m *PeerDetails) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PeerDetails) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *PeerDetails) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
+func (m *PeerDetails) GetProtocolVersion() string {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return ""
+}
+
+func (m *PeerDetails) GetSprawlVersion() string {
+	if m != nil {
+		return m.SprawlVersion
+	}
+	return ""
+}
+
+func (m *PeerDetails) GetChannels() [][]byte {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *PeerDetails) GetDirection() Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return Direction_UNKNOWN_DIRECTION
+}
+
+func (m *PeerDetails) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+func (m *PeerDetails) GetBytesIn() uint64 {
+	if m != nil {
+		return m.BytesIn
+	}
+	return 0
+}
+
+func (m *PeerDetails) GetBytesOut() uint64 {
+	if m != nil {
+		return m.BytesOut
+	}
+	return 0
+}
+
+func (m *PeerDetails) GetRateIn() float64 {
+	if m != nil {
+		return m.RateIn
+	}
+	return 0
+}
+
+func (m *PeerDetails) GetRateOut() float64 {
+	if m != nil {
+		return m.RateOut
+	}
+	return 0
+}
+
+type PeerDetailsList struct {
+	Peers                []*PeerDetails `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PeerDetailsList) Reset()         { *m = PeerDetailsList{} }
+func (m *PeerDetailsList) String() string { return proto.CompactTextString(m) }
+func (*PeerDetailsList) ProtoMessage()    {}
+func (*PeerDetailsList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{36}
+}
+
+func (m *PeerDetailsList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerDetailsList.Unmarshal(m, b)
+}
+func (m *PeerDetailsList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerDetailsList.Marshal(b, m, deterministic)
+}
+func (m *PeerDetailsList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerDetailsList.Merge(m, src)
+}
+func (m *PeerDetailsList) XXX_Size() int {
+	return xxx_messageInfo_PeerDetailsList.Size(m)
+}
+func (m *PeerDetailsList) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerDetailsList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerDetailsList proto.InternalMessageInfo
+
+func (m *PeerDetailsList) GetPeers() []*PeerDetails {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type NodeInfo struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Peers                []*PeerScore      `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+	ListenAddresses      []string          `protobuf:"bytes,3,rep,name=listenAddresses,proto3" json:"listenAddresses,omitempty"`
+	AnnouncedAddresses   []string          `protobuf:"bytes,4,rep,name=announcedAddresses,proto3" json:"announcedAddresses,omitempty"`
+	OrderCacheHits       uint64            `protobuf:"varint,5,opt,name=orderCacheHits,proto3" json:"orderCacheHits,omitempty"`
+	OrderCacheMisses     uint64            `protobuf:"varint,6,opt,name=orderCacheMisses,proto3" json:"orderCacheMisses,omitempty"`
+	ClockSkew            int64             `protobuf:"varint,7,opt,name=clockSkew,proto3" json:"clockSkew,omitempty"`
+	ClockSamples         uint32            `protobuf:"varint,8,opt,name=clockSamples,proto3" json:"clockSamples,omitempty"`
+	SkewedOrders         uint64            `protobuf:"varint,9,opt,name=skewedOrders,proto3" json:"skewedOrders,omitempty"`
+	ReadOnly             bool              `protobuf:"varint,10,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	FreeDiskSpace        uint64            `protobuf:"varint,11,opt,name=freeDiskSpace,proto3" json:"freeDiskSpace,omitempty"`
+	Counters             []*Counter        `protobuf:"bytes,12,rep,name=counters,proto3" json:"counters,omitempty"`
+	LastCompaction       *CompactionReport `protobuf:"bytes,13,opt,name=lastCompaction,proto3" json:"lastCompaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
+func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
+func (*NodeInfo) ProtoMessage()    {}
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{37}
+}
+
+func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeInfo.Unmarshal(m, b)
+}
+func (m *NodeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeInfo.Marshal(b, m, deterministic)
+}
+func (m *NodeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfo.Merge(m, src)
+}
+func (m *NodeInfo) XXX_Size() int {
+	return xxx_messageInfo_NodeInfo.Size(m)
+}
+func (m *NodeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeInfo proto.InternalMessageInfo
+
+func (m *NodeInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetPeers() []*PeerScore {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetListenAddresses() []string {
+	if m != nil {
+		return m.ListenAddresses
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetAnnouncedAddresses() []string {
+	if m != nil {
+		return m.AnnouncedAddresses
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetOrderCacheHits() uint64 {
+	if m != nil {
+		return m.OrderCacheHits
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetOrderCacheMisses() uint64 {
+	if m != nil {
+		return m.OrderCacheMisses
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetClockSkew() int64 {
+	if m != nil {
+		return m.ClockSkew
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetClockSamples() uint32 {
+	if m != nil {
+		return m.ClockSamples
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetSkewedOrders() uint64 {
+	if m != nil {
+		return m.SkewedOrders
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *NodeInfo) GetFreeDiskSpace() uint64 {
+	if m != nil {
+		return m.FreeDiskSpace
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetCounters() []*Counter {
+	if m != nil {
+		return m.Counters
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetLastCompaction() *CompactionReport {
+	if m != nil {
+		return m.LastCompaction
+	}
+	return nil
+}
+
+type JoinResponse struct {
+	JoinedChannel        *Channel `protobuf:"bytes,1,opt,name=joinedChannel,proto3" json:"joinedChannel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
+func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
+func (*JoinResponse) ProtoMessage()    {}
+func (*JoinResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{38}
+}
+
+func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinResponse.Unmarshal(m, b)
+}
+func (m *JoinResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinResponse.Marshal(b, m, deterministic)
+}
+func (m *JoinResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinResponse.Merge(m, src)
+}
+func (m *JoinResponse) XXX_Size() int {
+	return xxx_messageInfo_JoinResponse.Size(m)
+}
+func (m *JoinResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinResponse proto.InternalMessageInfo
+
+func (m *JoinResponse) GetJoinedChannel() *Channel {
+	if m != nil {
+		return m.JoinedChannel
+	}
+	return nil
+}
+
+type BackupChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupChunk) Reset()         { *m = BackupChunk{} }
+func (m *BackupChunk) String() string { return proto.CompactTextString(m) }
+func (*BackupChunk) ProtoMessage()    {}
+func (*BackupChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{39}
+}
+
+func (m *BackupChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupChunk.Unmarshal(m, b)
+}
+func (m *BackupChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupChunk.Marshal(b, m, deterministic)
+}
+func (m *BackupChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupChunk.Merge(m, src)
+}
+func (m *BackupChunk) XXX_Size() int {
+	return xxx_messageInfo_BackupChunk.Size(m)
+}
+func (m *BackupChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupChunk proto.InternalMessageInfo
+
+func (m *BackupChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ProfileRequest struct {
+	Type                 ProfileType `protobuf:"varint,1,opt,name=type,proto3,enum=pb.ProfileType" json:"type,omitempty"`
+	Seconds              uint32      `protobuf:"varint,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ProfileRequest) Reset()         { *m = ProfileRequest{} }
+func (m *ProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*ProfileRequest) ProtoMessage()    {}
+func (*ProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{40}
+}
+
+func (m *ProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileRequest.Unmarshal(m, b)
+}
+func (m *ProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *ProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileRequest.Merge(m, src)
+}
+func (m *ProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_ProfileRequest.Size(m)
+}
+func (m *ProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileRequest proto.InternalMessageInfo
+
+func (m *ProfileRequest) GetType() ProfileType {
+	if m != nil {
+		return m.Type
+	}
+	return ProfileType_HEAP
+}
+
+func (m *ProfileRequest) GetSeconds() uint32 {
+	if m != nil {
+		return m.Seconds
+	}
+	return 0
+}
+
+type ProfileResponse struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProfileResponse) Reset()         { *m = ProfileResponse{} }
+func (m *ProfileResponse) String() string { return proto.CompactTextString(m) }
+func (*ProfileResponse) ProtoMessage()    {}
+func (*ProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{41}
+}
+
+func (m *ProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfileResponse.Unmarshal(m, b)
+}
+func (m *ProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfileResponse.Marshal(b, m, deterministic)
+}
+func (m *ProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfileResponse.Merge(m, src)
+}
+func (m *ProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_ProfileResponse.Size(m)
+}
+func (m *ProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfileResponse proto.InternalMessageInfo
+
+func (m *ProfileResponse) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type StorageListRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit                uint32   `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageListRequest) Reset()         { *m = StorageListRequest{} }
+func (m *StorageListRequest) String() string { return proto.CompactTextString(m) }
+func (*StorageListRequest) ProtoMessage()    {}
+func (*StorageListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{42}
+}
+
+func (m *StorageListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageListRequest.Unmarshal(m, b)
+}
+func (m *StorageListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageListRequest.Marshal(b, m, deterministic)
+}
+func (m *StorageListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageListRequest.Merge(m, src)
+}
+func (m *StorageListRequest) XXX_Size() int {
+	return xxx_messageInfo_StorageListRequest.Size(m)
+}
+func (m *StorageListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageListRequest proto.InternalMessageInfo
+
+func (m *StorageListRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *StorageListRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type StorageKey struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Size                 uint64   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageKey) Reset()         { *m = StorageKey{} }
+func (m *StorageKey) String() string { return proto.CompactTextString(m) }
+func (*StorageKey) ProtoMessage()    {}
+func (*StorageKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{43}
+}
+
+func (m *StorageKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageKey.Unmarshal(m, b)
+}
+func (m *StorageKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageKey.Marshal(b, m, deterministic)
+}
+func (m *StorageKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageKey.Merge(m, src)
+}
+func (m *StorageKey) XXX_Size() int {
+	return xxx_messageInfo_StorageKey.Size(m)
+}
+func (m *StorageKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageKey proto.InternalMessageInfo
+
+func (m *StorageKey) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StorageKey) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type StorageKeyList struct {
+	Keys                 []*StorageKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Truncated            bool          `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *StorageKeyList) Reset()         { *m = StorageKeyList{} }
+func (m *StorageKeyList) String() string { return proto.CompactTextString(m) }
+func (*StorageKeyList) ProtoMessage()    {}
+func (*StorageKeyList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{44}
+}
+
+func (m *StorageKeyList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageKeyList.Unmarshal(m, b)
+}
+func (m *StorageKeyList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageKeyList.Marshal(b, m, deterministic)
+}
+func (m *StorageKeyList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageKeyList.Merge(m, src)
+}
+func (m *StorageKeyList) XXX_Size() int {
+	return xxx_messageInfo_StorageKeyList.Size(m)
+}
+func (m *StorageKeyList) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageKeyList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageKeyList proto.InternalMessageInfo
+
+func (m *StorageKeyList) GetKeys() []*StorageKey {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *StorageKeyList) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+type StorageDumpRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageDumpRequest) Reset()         { *m = StorageDumpRequest{} }
+func (m *StorageDumpRequest) String() string { return proto.CompactTextString(m) }
+func (*StorageDumpRequest) ProtoMessage()    {}
+func (*StorageDumpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{45}
+}
+
+func (m *StorageDumpRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageDumpRequest.Unmarshal(m, b)
+}
+func (m *StorageDumpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageDumpRequest.Marshal(b, m, deterministic)
+}
+func (m *StorageDumpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageDumpRequest.Merge(m, src)
+}
+func (m *StorageDumpRequest) XXX_Size() int {
+	return xxx_messageInfo_StorageDumpRequest.Size(m)
+}
+func (m *StorageDumpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageDumpRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageDumpRequest proto.InternalMessageInfo
+
+func (m *StorageDumpRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type StorageEntry struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageEntry) Reset()         { *m = StorageEntry{} }
+func (m *StorageEntry) String() string { return proto.CompactTextString(m) }
+func (*StorageEntry) ProtoMessage()    {}
+func (*StorageEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{46}
+}
+
+func (m *StorageEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageEntry.Unmarshal(m, b)
+}
+func (m *StorageEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageEntry.Marshal(b, m, deterministic)
+}
+func (m *StorageEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageEntry.Merge(m, src)
+}
+func (m *StorageEntry) XXX_Size() int {
+	return xxx_messageInfo_StorageEntry.Size(m)
+}
+func (m *StorageEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageEntry proto.InternalMessageInfo
+
+func (m *StorageEntry) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StorageEntry) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type StoragePrefixStat struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Keys                 uint64   `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	Size                 uint64   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoragePrefixStat) Reset()         { *m = StoragePrefixStat{} }
+func (m *StoragePrefixStat) String() string { return proto.CompactTextString(m) }
+func (*StoragePrefixStat) ProtoMessage()    {}
+func (*StoragePrefixStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{47}
+}
+
+func (m *StoragePrefixStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoragePrefixStat.Unmarshal(m, b)
+}
+func (m *StoragePrefixStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoragePrefixStat.Marshal(b, m, deterministic)
+}
+func (m *StoragePrefixStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoragePrefixStat.Merge(m, src)
+}
+func (m *StoragePrefixStat) XXX_Size() int {
+	return xxx_messageInfo_StoragePrefixStat.Size(m)
+}
+func (m *StoragePrefixStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoragePrefixStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoragePrefixStat proto.InternalMessageInfo
+
+func (m *StoragePrefixStat) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *StoragePrefixStat) GetKeys() uint64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *StoragePrefixStat) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type StorageStat struct {
+	Prefixes             []*StoragePrefixStat `protobuf:"bytes,1,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Keys                 uint64               `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	Size                 uint64               `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StorageStat) Reset()         { *m = StorageStat{} }
+func (m *StorageStat) String() string { return proto.CompactTextString(m) }
+func (*StorageStat) ProtoMessage()    {}
+func (*StorageStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{48}
+}
+
+func (m *StorageStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageStat.Unmarshal(m, b)
+}
+func (m *StorageStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageStat.Marshal(b, m, deterministic)
+}
+func (m *StorageStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageStat.Merge(m, src)
+}
+func (m *StorageStat) XXX_Size() int {
+	return xxx_messageInfo_StorageStat.Size(m)
+}
+func (m *StorageStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageStat proto.InternalMessageInfo
+
+func (m *StorageStat) GetPrefixes() []*StoragePrefixStat {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *StorageStat) GetKeys() uint64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *StorageStat) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type AuditEntry struct {
+	Method               string               `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Namespace            string               `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Address              string               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	RequestHash          []byte               `protobuf:"bytes,4,opt,name=requestHash,proto3" json:"requestHash,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Result               string               `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	Error                string               `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	RequestID            string               `protobuf:"bytes,8,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{49}
+}
+
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEntry.Unmarshal(m, b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+}
+func (m *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(m, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return xxx_messageInfo_AuditEntry.Size(m)
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetRequestHash() []byte {
+	if m != nil {
+		return m.RequestHash
+	}
+	return nil
+}
+
+func (m *AuditEntry) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *AuditEntry) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetRequestID() string {
+	if m != nil {
+		return m.RequestID
+	}
+	return ""
+}
+
+type OrderBookUpdate struct {
+	ChannelID            []byte     `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Sequence             uint64     `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Snapshot             bool       `protobuf:"varint,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Orders               []*Order   `protobuf:"bytes,4,rep,name=orders,proto3" json:"orders,omitempty"`
+	Change               BookChange `protobuf:"varint,5,opt,name=change,proto3,enum=pb.BookChange" json:"change,omitempty"`
+	Order                *Order     `protobuf:"bytes,6,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *OrderBookUpdate) Reset()         { *m = OrderBookUpdate{} }
+func (m *OrderBookUpdate) String() string { return proto.CompactTextString(m) }
+func (*OrderBookUpdate) ProtoMessage()    {}
+func (*OrderBookUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{50}
+}
+
+func (m *OrderBookUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderBookUpdate.Unmarshal(m, b)
+}
+func (m *OrderBookUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderBookUpdate.Marshal(b, m, deterministic)
+}
+func (m *OrderBookUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBookUpdate.Merge(m, src)
+}
+func (m *OrderBookUpdate) XXX_Size() int {
+	return xxx_messageInfo_OrderBookUpdate.Size(m)
+}
+func (m *OrderBookUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBookUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderBookUpdate proto.InternalMessageInfo
+
+func (m *OrderBookUpdate) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *OrderBookUpdate) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *OrderBookUpdate) GetSnapshot() bool {
+	if m != nil {
+		return m.Snapshot
+	}
+	return false
+}
+
+func (m *OrderBookUpdate) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+func (m *OrderBookUpdate) GetChange() BookChange {
+	if m != nil {
+		return m.Change
+	}
+	return BookChange_ORDER_ADDED
+}
+
+func (m *OrderBookUpdate) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+type DeadLetter struct {
+	Id                   []byte               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data                 []byte               `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	From                 string               `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	Reason               string               `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Received             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=received,proto3" json:"received,omitempty"`
+	RequestID            string               `protobuf:"bytes,6,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{51}
+}
+
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetter.Unmarshal(m, b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+}
+func (m *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(m, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return xxx_messageInfo_DeadLetter.Size(m)
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
+func (m *DeadLetter) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetReceived() *timestamp.Timestamp {
+	if m != nil {
+		return m.Received
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetRequestID() string {
+	if m != nil {
+		return m.RequestID
+	}
+	return ""
+}
+
+type DeadLetterList struct {
+	DeadLetters          []*DeadLetter `protobuf:"bytes,1,rep,name=deadLetters,proto3" json:"deadLetters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DeadLetterList) Reset()         { *m = DeadLetterList{} }
+func (m *DeadLetterList) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterList) ProtoMessage()    {}
+func (*DeadLetterList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{52}
+}
+
+func (m *DeadLetterList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterList.Unmarshal(m, b)
+}
+func (m *DeadLetterList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterList.Marshal(b, m, deterministic)
+}
+func (m *DeadLetterList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterList.Merge(m, src)
+}
+func (m *DeadLetterList) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterList.Size(m)
+}
+func (m *DeadLetterList) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetterList proto.InternalMessageInfo
+
+func (m *DeadLetterList) GetDeadLetters() []*DeadLetter {
+	if m != nil {
+		return m.DeadLetters
+	}
+	return nil
+}
+
+type DeadLetterRequest struct {
+	Ids                  [][]byte `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLetterRequest) Reset()         { *m = DeadLetterRequest{} }
+func (m *DeadLetterRequest) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterRequest) ProtoMessage()    {}
+func (*DeadLetterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{53}
+}
+
+func (m *DeadLetterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterRequest.Unmarshal(m, b)
+}
+func (m *DeadLetterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterRequest.Marshal(b, m, deterministic)
+}
+func (m *DeadLetterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterRequest.Merge(m, src)
+}
+func (m *DeadLetterRequest) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterRequest.Size(m)
+}
+func (m *DeadLetterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetterRequest proto.InternalMessageInfo
+
+func (m *DeadLetterRequest) GetIds() [][]byte {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type AuditLogRequest struct {
+	From                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AuditLogRequest) Reset()         { *m = AuditLogRequest{} }
+func (m *AuditLogRequest) String() string { return proto.CompactTextString(m) }
+func (*AuditLogRequest) ProtoMessage()    {}
+func (*AuditLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{54}
+}
+
+func (m *AuditLogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditLogRequest.Unmarshal(m, b)
+}
+func (m *AuditLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditLogRequest.Marshal(b, m, deterministic)
+}
+func (m *AuditLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditLogRequest.Merge(m, src)
+}
+func (m *AuditLogRequest) XXX_Size() int {
+	return xxx_messageInfo_AuditLogRequest.Size(m)
+}
+func (m *AuditLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditLogRequest proto.InternalMessageInfo
+
+func (m *AuditLogRequest) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *AuditLogRequest) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{55}
+}
+
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+type Counter struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Session              uint64   `protobuf:"varint,2,opt,name=session,proto3" json:"session,omitempty"`
+	AllTime              uint64   `protobuf:"varint,3,opt,name=allTime,proto3" json:"allTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Counter) Reset()         { *m = Counter{} }
+func (m *Counter) String() string { return proto.CompactTextString(m) }
+func (*Counter) ProtoMessage()    {}
+func (*Counter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{56}
+}
+
+func (m *Counter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Counter.Unmarshal(m, b)
+}
+func (m *Counter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Counter.Marshal(b, m, deterministic)
+}
+func (m *Counter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Counter.Merge(m, src)
+}
+func (m *Counter) XXX_Size() int {
+	return xxx_messageInfo_Counter.Size(m)
+}
+func (m *Counter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Counter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Counter proto.InternalMessageInfo
+
+func (m *Counter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Counter) GetSession() uint64 {
+	if m != nil {
+		return m.Session
+	}
+	return 0
+}
+
+func (m *Counter) GetAllTime() uint64 {
+	if m != nil {
+		return m.AllTime
+	}
+	return 0
+}
+
+type RelayedMessage struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	PublicKey            []byte   `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Hops                 uint32   `protobuf:"varint,4,opt,name=hops,proto3" json:"hops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RelayedMessage) Reset()         { *m = RelayedMessage{} }
+func (m *RelayedMessage) String() string { return proto.CompactTextString(m) }
+func (*RelayedMessage) ProtoMessage()    {}
+func (*RelayedMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{57}
+}
+
+func (m *RelayedMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RelayedMessage.Unmarshal(m, b)
+}
+func (m *RelayedMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RelayedMessage.Marshal(b, m, deterministic)
+}
+func (m *RelayedMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelayedMessage.Merge(m, src)
+}
+func (m *RelayedMessage) XXX_Size() int {
+	return xxx_messageInfo_RelayedMessage.Size(m)
+}
+func (m *RelayedMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelayedMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RelayedMessage proto.InternalMessageInfo
+
+func (m *RelayedMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *RelayedMessage) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *RelayedMessage) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *RelayedMessage) GetHops() uint32 {
+	if m != nil {
+		return m.Hops
+	}
+	return 0
+}
+
+type CompactionReport struct {
+	Started              *timestamp.Timestamp `protobuf:"bytes,1,opt,name=started,proto3" json:"started,omitempty"`
+	Duration             uint64               `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	SizeBefore           uint64               `protobuf:"varint,3,opt,name=sizeBefore,proto3" json:"sizeBefore,omitempty"`
+	SizeAfter            uint64               `protobuf:"varint,4,opt,name=sizeAfter,proto3" json:"sizeAfter,omitempty"`
+	Reclaimed            uint64               `protobuf:"varint,5,opt,name=reclaimed,proto3" json:"reclaimed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CompactionReport) Reset()         { *m = CompactionReport{} }
+func (m *CompactionReport) String() string { return proto.CompactTextString(m) }
+func (*CompactionReport) ProtoMessage()    {}
+func (*CompactionReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{58}
+}
+
+func (m *CompactionReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactionReport.Unmarshal(m, b)
+}
+func (m *CompactionReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactionReport.Marshal(b, m, deterministic)
+}
+func (m *CompactionReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactionReport.Merge(m, src)
+}
+func (m *CompactionReport) XXX_Size() int {
+	return xxx_messageInfo_CompactionReport.Size(m)
+}
+func (m *CompactionReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactionReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactionReport proto.InternalMessageInfo
+
+func (m *CompactionReport) GetStarted() *timestamp.Timestamp {
+	if m != nil {
+		return m.Started
+	}
+	return nil
+}
+
+func (m *CompactionReport) GetDuration() uint64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *CompactionReport) GetSizeBefore() uint64 {
+	if m != nil {
+		return m.SizeBefore
+	}
+	return 0
+}
+
+func (m *CompactionReport) GetSizeAfter() uint64 {
+	if m != nil {
+		return m.SizeAfter
+	}
+	return 0
+}
+
+func (m *CompactionReport) GetReclaimed() uint64 {
+	if m != nil {
+		return m.Reclaimed
+	}
+	return 0
+}
+
+type RemovalRequest struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	OrderID              []byte   `protobuf:"bytes,2,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Maker                string   `protobuf:"bytes,3,opt,name=maker,proto3" json:"maker,omitempty"`
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemovalRequest) Reset()         { *m = RemovalRequest{} }
+func (m *RemovalRequest) String() string { return proto.CompactTextString(m) }
+func (*RemovalRequest) ProtoMessage()    {}
+func (*RemovalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{59}
+}
+
+func (m *RemovalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovalRequest.Unmarshal(m, b)
+}
+func (m *RemovalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovalRequest.Marshal(b, m, deterministic)
+}
+func (m *RemovalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovalRequest.Merge(m, src)
+}
+func (m *RemovalRequest) XXX_Size() int {
+	return xxx_messageInfo_RemovalRequest.Size(m)
+}
+func (m *RemovalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemovalRequest proto.InternalMessageInfo
+
+func (m *RemovalRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *RemovalRequest) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+func (m *RemovalRequest) GetMaker() string {
+	if m != nil {
+		return m.Maker
+	}
+	return ""
+}
+
+func (m *RemovalRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type Removal struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	OrderID              []byte               `protobuf:"bytes,2,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Maker                string               `protobuf:"bytes,3,opt,name=maker,proto3" json:"maker,omitempty"`
+	Reason               string               `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	OperatorPubKey       []byte               `protobuf:"bytes,6,opt,name=operatorPubKey,proto3" json:"operatorPubKey,omitempty"`
+	Signature            []byte               `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Removal) Reset()         { *m = Removal{} }
+func (m *Removal) String() string { return proto.CompactTextString(m) }
+func (*Removal) ProtoMessage()    {}
+func (*Removal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{60}
+}
+
+func (m *Removal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Removal.Unmarshal(m, b)
+}
+func (m *Removal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Removal.Marshal(b, m, deterministic)
+}
+func (m *Removal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Removal.Merge(m, src)
+}
+func (m *Removal) XXX_Size() int {
+	return xxx_messageInfo_Removal.Size(m)
+}
+func (m *Removal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Removal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Removal proto.InternalMessageInfo
+
+func (m *Removal) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *Removal) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+func (m *Removal) GetMaker() string {
+	if m != nil {
+		return m.Maker
+	}
+	return ""
+}
+
+func (m *Removal) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Removal) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Removal) GetOperatorPubKey() []byte {
+	if m != nil {
+		return m.OperatorPubKey
+	}
+	return nil
+}
+
+func (m *Removal) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type Candle struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Interval             uint32               `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Start                *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	Open                 float32              `protobuf:"fixed32,4,opt,name=open,proto3" json:"open,omitempty"`
+	High                 float32              `protobuf:"fixed32,5,opt,name=high,proto3" json:"high,omitempty"`
+	Low                  float32              `protobuf:"fixed32,6,opt,name=low,proto3" json:"low,omitempty"`
+	Close                float32              `protobuf:"fixed32,7,opt,name=close,proto3" json:"close,omitempty"`
+	Volume               uint64               `protobuf:"varint,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	Trades               uint32               `protobuf:"varint,9,opt,name=trades,proto3" json:"trades,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Candle) Reset()         { *m = Candle{} }
+func (m *Candle) String() string { return proto.CompactTextString(m) }
+func (*Candle) ProtoMessage()    {}
+func (*Candle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{61}
+}
+
+func (m *Candle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Candle.Unmarshal(m, b)
+}
+func (m *Candle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Candle.Marshal(b, m, deterministic)
+}
+func (m *Candle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Candle.Merge(m, src)
+}
+func (m *Candle) XXX_Size() int {
+	return xxx_messageInfo_Candle.Size(m)
+}
+func (m *Candle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Candle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Candle proto.InternalMessageInfo
+
+func (m *Candle) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *Candle) GetInterval() uint32 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *Candle) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *Candle) GetOpen() float32 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Candle) GetHigh() float32 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Candle) GetLow() float32 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Candle) GetClose() float32 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *Candle) GetVolume() uint64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Candle) GetTrades() uint32 {
+	if m != nil {
+		return m.Trades
+	}
+	return 0
+}
+
+type CandleRequest struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Interval             uint32               `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	From                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CandleRequest) Reset()         { *m = CandleRequest{} }
+func (m *CandleRequest) String() string { return proto.CompactTextString(m) }
+func (*CandleRequest) ProtoMessage()    {}
+func (*CandleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{62}
+}
+
+func (m *CandleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CandleRequest.Unmarshal(m, b)
+}
+func (m *CandleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CandleRequest.Marshal(b, m, deterministic)
+}
+func (m *CandleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandleRequest.Merge(m, src)
+}
+func (m *CandleRequest) XXX_Size() int {
+	return xxx_messageInfo_CandleRequest.Size(m)
+}
+func (m *CandleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandleRequest proto.InternalMessageInfo
+
+func (m *CandleRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *CandleRequest) GetInterval() uint32 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *CandleRequest) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *CandleRequest) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+type CandleList struct {
+	Candles              []*Candle `protobuf:"bytes,1,rep,name=candles,proto3" json:"candles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CandleList) Reset()         { *m = CandleList{} }
+func (m *CandleList) String() string { return proto.CompactTextString(m) }
+func (*CandleList) ProtoMessage()    {}
+func (*CandleList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{63}
+}
+
+func (m *CandleList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CandleList.Unmarshal(m, b)
+}
+func (m *CandleList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CandleList.Marshal(b, m, deterministic)
+}
+func (m *CandleList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandleList.Merge(m, src)
+}
+func (m *CandleList) XXX_Size() int {
+	return xxx_messageInfo_CandleList.Size(m)
+}
+func (m *CandleList) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandleList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandleList proto.InternalMessageInfo
+
+func (m *CandleList) GetCandles() []*Candle {
+	if m != nil {
+		return m.Candles
+	}
+	return nil
+}
+
+type Ticker struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Last                 float32              `protobuf:"fixed32,2,opt,name=last,proto3" json:"last,omitempty"`
+	Open                 float32              `protobuf:"fixed32,3,opt,name=open,proto3" json:"open,omitempty"`
+	High                 float32              `protobuf:"fixed32,4,opt,name=high,proto3" json:"high,omitempty"`
+	Low                  float32              `protobuf:"fixed32,5,opt,name=low,proto3" json:"low,omitempty"`
+	Change               float32              `protobuf:"fixed32,6,opt,name=change,proto3" json:"change,omitempty"`
+	Volume               uint64               `protobuf:"varint,7,opt,name=volume,proto3" json:"volume,omitempty"`
+	Trades               uint32               `protobuf:"varint,8,opt,name=trades,proto3" json:"trades,omitempty"`
+	Updated              *timestamp.Timestamp `protobuf:"bytes,9,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Ticker) Reset()         { *m = Ticker{} }
+func (m *Ticker) String() string { return proto.CompactTextString(m) }
+func (*Ticker) ProtoMessage()    {}
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{64}
+}
+
+func (m *Ticker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ticker.Unmarshal(m, b)
+}
+func (m *Ticker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ticker.Marshal(b, m, deterministic)
+}
+func (m *Ticker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ticker.Merge(m, src)
+}
+func (m *Ticker) XXX_Size() int {
+	return xxx_messageInfo_Ticker.Size(m)
+}
+func (m *Ticker) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ticker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ticker proto.InternalMessageInfo
+
+func (m *Ticker) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *Ticker) GetLast() float32 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *Ticker) GetOpen() float32 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Ticker) GetHigh() float32 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Ticker) GetLow() float32 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Ticker) GetChange() float32 {
+	if m != nil {
+		return m.Change
+	}
+	return 0
+}
+
+func (m *Ticker) GetVolume() uint64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Ticker) GetTrades() uint32 {
+	if m != nil {
+		return m.Trades
+	}
+	return 0
+}
+
+func (m *Ticker) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+type SelfTestResult struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Passed               bool     `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	Warning              bool     `protobuf:"varint,3,opt,name=warning,proto3" json:"warning,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Hint                 string   `protobuf:"bytes,5,opt,name=hint,proto3" json:"hint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SelfTestResult) Reset()         { *m = SelfTestResult{} }
+func (m *SelfTestResult) String() string { return proto.CompactTextString(m) }
+func (*SelfTestResult) ProtoMessage()    {}
+func (*SelfTestResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{65}
+}
+
+func (m *SelfTestResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelfTestResult.Unmarshal(m, b)
+}
+func (m *SelfTestResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelfTestResult.Marshal(b, m, deterministic)
+}
+func (m *SelfTestResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelfTestResult.Merge(m, src)
+}
+func (m *SelfTestResult) XXX_Size() int {
+	return xxx_messageInfo_SelfTestResult.Size(m)
+}
+func (m *SelfTestResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelfTestResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelfTestResult proto.InternalMessageInfo
+
+func (m *SelfTestResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SelfTestResult) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+func (m *SelfTestResult) GetWarning() bool {
+	if m != nil {
+		return m.Warning
+	}
+	return false
+}
+
+func (m *SelfTestResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *SelfTestResult) GetHint() string {
+	if m != nil {
+		return m.Hint
+	}
+	return ""
+}
+
+type SelfTestReport struct {
+	Results              []*SelfTestResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Passed               bool              `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SelfTestReport) Reset()         { *m = SelfTestReport{} }
+func (m *SelfTestReport) String() string { return proto.CompactTextString(m) }
+func (*SelfTestReport) ProtoMessage()    {}
+func (*SelfTestReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{66}
+}
+
+func (m *SelfTestReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelfTestReport.Unmarshal(m, b)
+}
+func (m *SelfTestReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelfTestReport.Marshal(b, m, deterministic)
+}
+func (m *SelfTestReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelfTestReport.Merge(m, src)
+}
+func (m *SelfTestReport) XXX_Size() int {
+	return xxx_messageInfo_SelfTestReport.Size(m)
+}
+func (m *SelfTestReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelfTestReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelfTestReport proto.InternalMessageInfo
+
+func (m *SelfTestReport) GetResults() []*SelfTestResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *SelfTestReport) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+type IdleChannel struct {
+	Channel              *Channel             `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	LastActive           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=lastActive,proto3" json:"lastActive,omitempty"`
+	Left                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=left,proto3" json:"left,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *IdleChannel) Reset()         { *m = IdleChannel{} }
+func (m *IdleChannel) String() string { return proto.CompactTextString(m) }
+func (*IdleChannel) ProtoMessage()    {}
+func (*IdleChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{67}
+}
+
+func (m *IdleChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdleChannel.Unmarshal(m, b)
+}
+func (m *IdleChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdleChannel.Marshal(b, m, deterministic)
+}
+func (m *IdleChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdleChannel.Merge(m, src)
+}
+func (m *IdleChannel) XXX_Size() int {
+	return xxx_messageInfo_IdleChannel.Size(m)
+}
+func (m *IdleChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdleChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdleChannel proto.InternalMessageInfo
+
+func (m *IdleChannel) GetChannel() *Channel {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *IdleChannel) GetLastActive() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastActive
+	}
+	return nil
+}
+
+func (m *IdleChannel) GetLeft() *timestamp.Timestamp {
+	if m != nil {
+		return m.Left
+	}
+	return nil
+}
+
+type IdleChannelList struct {
+	Channels             []*IdleChannel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *IdleChannelList) Reset()         { *m = IdleChannelList{} }
+func (m *IdleChannelList) String() string { return proto.CompactTextString(m) }
+func (*IdleChannelList) ProtoMessage()    {}
+func (*IdleChannelList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{68}
+}
+
+func (m *IdleChannelList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdleChannelList.Unmarshal(m, b)
+}
+func (m *IdleChannelList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdleChannelList.Marshal(b, m, deterministic)
+}
+func (m *IdleChannelList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdleChannelList.Merge(m, src)
+}
+func (m *IdleChannelList) XXX_Size() int {
+	return xxx_messageInfo_IdleChannelList.Size(m)
+}
+func (m *IdleChannelList) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdleChannelList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdleChannelList proto.InternalMessageInfo
+
+func (m *IdleChannelList) GetChannels() []*IdleChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type ReplicationEntry struct {
+	Sequence             uint64               `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Key                  []byte               `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte               `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Delete               bool                 `protobuf:"varint,4,opt,name=delete,proto3" json:"delete,omitempty"`
+	Prefix               bool                 `protobuf:"varint,5,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	SnapshotDone         bool                 `protobuf:"varint,6,opt,name=snapshotDone,proto3" json:"snapshotDone,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,7,opt,name=expires,proto3" json:"expires,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ReplicationEntry) Reset()         { *m = ReplicationEntry{} }
+func (m *ReplicationEntry) String() string { return proto.CompactTextString(m) }
+func (*ReplicationEntry) ProtoMessage()    {}
+func (*ReplicationEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{69}
+}
+
+func (m *ReplicationEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicationEntry.Unmarshal(m, b)
+}
+func (m *ReplicationEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicationEntry.Marshal(b, m, deterministic)
+}
+func (m *ReplicationEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicationEntry.Merge(m, src)
+}
+func (m *ReplicationEntry) XXX_Size() int {
+	return xxx_messageInfo_ReplicationEntry.Size(m)
+}
+func (m *ReplicationEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicationEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicationEntry proto.InternalMessageInfo
+
+func (m *ReplicationEntry) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ReplicationEntry) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ReplicationEntry) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ReplicationEntry) GetDelete() bool {
+	if m != nil {
+		return m.Delete
+	}
+	return false
+}
+
+func (m *ReplicationEntry) GetPrefix() bool {
+	if m != nil {
+		return m.Prefix
+	}
+	return false
+}
+
+func (m *ReplicationEntry) GetSnapshotDone() bool {
+	if m != nil {
+		return m.SnapshotDone
+	}
+	return false
+}
+
+func (m *ReplicationEntry) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+type ReplicationStatus struct {
+	Role                 string               `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Primary              string               `protobuf:"bytes,2,opt,name=primary,proto3" json:"primary,omitempty"`
+	Connected            bool                 `protobuf:"varint,3,opt,name=connected,proto3" json:"connected,omitempty"`
+	Sequence             uint64               `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	LastApplied          *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastApplied,proto3" json:"lastApplied,omitempty"`
+	Followers            uint32               `protobuf:"varint,6,opt,name=followers,proto3" json:"followers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ReplicationStatus) Reset()         { *m = ReplicationStatus{} }
+func (m *ReplicationStatus) String() string { return proto.CompactTextString(m) }
+func (*ReplicationStatus) ProtoMessage()    {}
+func (*ReplicationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{70}
+}
+
+func (m *ReplicationStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicationStatus.Unmarshal(m, b)
+}
+func (m *ReplicationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicationStatus.Marshal(b, m, deterministic)
+}
+func (m *ReplicationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicationStatus.Merge(m, src)
+}
+func (m *ReplicationStatus) XXX_Size() int {
+	return xxx_messageInfo_ReplicationStatus.Size(m)
+}
+func (m *ReplicationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicationStatus proto.InternalMessageInfo
+
+func (m *ReplicationStatus) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *ReplicationStatus) GetPrimary() string {
+	if m != nil {
+		return m.Primary
+	}
+	return ""
+}
+
+func (m *ReplicationStatus) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *ReplicationStatus) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ReplicationStatus) GetLastApplied() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastApplied
+	}
+	return nil
+}
+
+func (m *ReplicationStatus) GetFollowers() uint32 {
+	if m != nil {
+		return m.Followers
+	}
+	return 0
+}
+
+type Trade struct {
+	ChannelID            []byte               `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	OrderID              []byte               `protobuf:"bytes,2,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Price                float32              `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
+	Amount               uint64               `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Executed             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=executed,proto3" json:"executed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Trade) Reset()         { *m = Trade{} }
+func (m *Trade) String() string { return proto.CompactTextString(m) }
+func (*Trade) ProtoMessage()    {}
+func (*Trade) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{71}
+}
+
+func (m *Trade) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Trade.Unmarshal(m, b)
+}
+func (m *Trade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Trade.Marshal(b, m, deterministic)
+}
+func (m *Trade) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Trade.Merge(m, src)
+}
+func (m *Trade) XXX_Size() int {
+	return xxx_messageInfo_Trade.Size(m)
+}
+func (m *Trade) XXX_DiscardUnknown() {
+	xxx_messageInfo_Trade.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Trade proto.InternalMessageInfo
+
+func (m *Trade) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func (m *Trade) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+func (m *Trade) GetPrice() float32 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *Trade) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Trade) GetExecuted() *timestamp.Timestamp {
+	if m != nil {
+		return m.Executed
+	}
+	return nil
+}
+
+type SyncRequest struct {
+	History              History  `protobuf:"varint,1,opt,name=history,proto3,enum=pb.History" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncRequest) Reset()         { *m = SyncRequest{} }
+func (m *SyncRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncRequest) ProtoMessage()    {}
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{72}
+}
+
+func (m *SyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncRequest.Unmarshal(m, b)
+}
+func (m *SyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncRequest.Marshal(b, m, deterministic)
+}
+func (m *SyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncRequest.Merge(m, src)
+}
+func (m *SyncRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncRequest.Size(m)
+}
+func (m *SyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncRequest proto.InternalMessageInfo
+
+func (m *SyncRequest) GetHistory() History {
+	if m != nil {
+		return m.History
+	}
+	return History_OPEN_ORDERS
+}
+
+type OrderDigest struct {
+	Root                 []byte   `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Orders               uint32   `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderDigest) Reset()         { *m = OrderDigest{} }
+func (m *OrderDigest) String() string { return proto.CompactTextString(m) }
+func (*OrderDigest) ProtoMessage()    {}
+func (*OrderDigest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{73}
+}
+
+func (m *OrderDigest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderDigest.Unmarshal(m, b)
+}
+func (m *OrderDigest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderDigest.Marshal(b, m, deterministic)
+}
+func (m *OrderDigest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderDigest.Merge(m, src)
+}
+func (m *OrderDigest) XXX_Size() int {
+	return xxx_messageInfo_OrderDigest.Size(m)
+}
+func (m *OrderDigest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderDigest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderDigest proto.InternalMessageInfo
+
+func (m *OrderDigest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *OrderDigest) GetOrders() uint32 {
+	if m != nil {
+		return m.Orders
+	}
+	return 0
+}
+
+type KillSwitchRequest struct {
+	ChannelID            []byte   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KillSwitchRequest) Reset()         { *m = KillSwitchRequest{} }
+func (m *KillSwitchRequest) String() string { return proto.CompactTextString(m) }
+func (*KillSwitchRequest) ProtoMessage()    {}
+func (*KillSwitchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b5e409e9578376a3, []int{74}
+}
+
+func (m *KillSwitchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KillSwitchRequest.Unmarshal(m, b)
+}
+func (m *KillSwitchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KillSwitchRequest.Marshal(b, m, deterministic)
+}
+func (m *KillSwitchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KillSwitchRequest.Merge(m, src)
+}
+func (m *KillSwitchRequest) XXX_Size() int {
+	return xxx_messageInfo_KillSwitchRequest.Size(m)
+}
+func (m *KillSwitchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KillSwitchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KillSwitchRequest proto.InternalMessageInfo
+
+func (m *KillSwitchRequest) GetChannelID() []byte {
+	if m != nil {
+		return m.ChannelID
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("pb.State", State_name, State_value)
+	proto.RegisterEnum("pb.Operation", Operation_name, Operation_value)
+	proto.RegisterEnum("pb.NegotiationStep", NegotiationStep_name, NegotiationStep_value)
+	proto.RegisterEnum("pb.BookChange", BookChange_name, BookChange_value)
+	proto.RegisterEnum("pb.Direction", Direction_name, Direction_value)
+	proto.RegisterEnum("pb.Side", Side_name, Side_value)
+	proto.RegisterEnum("pb.ProfileType", ProfileType_name, ProfileType_value)
+	proto.RegisterEnum("pb.History", History_name, History_value)
+	proto.RegisterType((*Peer)(nil), "pb.Peer")
+	proto.RegisterType((*Order)(nil), "pb.Order")
+	proto.RegisterType((*Fill)(nil), "pb.Fill")
+	proto.RegisterType((*FillRequest)(nil), "pb.FillRequest")
+	proto.RegisterType((*OrderList)(nil), "pb.OrderList")
+	proto.RegisterType((*OrderListRequest)(nil), "pb.OrderListRequest")
+	proto.RegisterType((*SearchRequest)(nil), "pb.SearchRequest")
+	proto.RegisterType((*Channel)(nil), "pb.Channel")
+	proto.RegisterType((*Membership)(nil), "pb.Membership")
+	proto.RegisterType((*ChannelConfig)(nil), "pb.ChannelConfig")
+	proto.RegisterType((*IdentityTransition)(nil), "pb.IdentityTransition")
+	proto.RegisterType((*ChannelList)(nil), "pb.ChannelList")
 	proto.RegisterType((*Recipient)(nil), "pb.Recipient")
 	proto.RegisterType((*WireMessage)(nil), "pb.WireMessage")
+	proto.RegisterType((*WireMessageBatch)(nil), "pb.WireMessageBatch")
+	proto.RegisterType((*Subscription)(nil), "pb.Subscription")
+	proto.RegisterType((*Handshake)(nil), "pb.Handshake")
 	proto.RegisterType((*CreateRequest)(nil), "pb.CreateRequest")
+	proto.RegisterType((*Negotiation)(nil), "pb.Negotiation")
+	proto.RegisterType((*NegotiationMessage)(nil), "pb.NegotiationMessage")
 	proto.RegisterType((*JoinRequest)(nil), "pb.JoinRequest")
 	proto.RegisterType((*ChannelOptions)(nil), "pb.ChannelOptions")
+	proto.RegisterType((*Asset)(nil), "pb.Asset")
+	proto.RegisterType((*AssetList)(nil), "pb.AssetList")
 	proto.RegisterType((*OrderSpecificRequest)(nil), "pb.OrderSpecificRequest")
+	proto.RegisterType((*OrderHistoryRequest)(nil), "pb.OrderHistoryRequest")
+	proto.RegisterType((*MembershipRequest)(nil), "pb.MembershipRequest")
+	proto.RegisterType((*ChannelConfigRequest)(nil), "pb.ChannelConfigRequest")
 	proto.RegisterType((*ChannelSpecificRequest)(nil), "pb.ChannelSpecificRequest")
 	proto.RegisterType((*CreateResponse)(nil), "pb.CreateResponse")
 	proto.RegisterType((*OrderListResponse)(nil), "pb.OrderListResponse")
+	proto.RegisterType((*OrderHistoryResponse)(nil), "pb.OrderHistoryResponse")
 	proto.RegisterType((*ChannelListResponse)(nil), "pb.ChannelListResponse")
 	proto.RegisterType((*PeerListResponse)(nil), "pb.PeerListResponse")
+	proto.RegisterType((*PeerScore)(nil), "pb.PeerScore")
+	proto.RegisterType((*PeerDetails)(nil), "pb.PeerDetails")
+	proto.RegisterType((*PeerDetailsList)(nil), "pb.PeerDetailsList")
+	proto.RegisterType((*NodeInfo)(nil), "pb.NodeInfo")
 	proto.RegisterType((*JoinResponse)(nil), "pb.JoinResponse")
+	proto.RegisterType((*BackupChunk)(nil), "pb.BackupChunk")
+	proto.RegisterType((*ProfileRequest)(nil), "pb.ProfileRequest")
+	proto.RegisterType((*ProfileResponse)(nil), "pb.ProfileResponse")
+	proto.RegisterType((*StorageListRequest)(nil), "pb.StorageListRequest")
+	proto.RegisterType((*StorageKey)(nil), "pb.StorageKey")
+	proto.RegisterType((*StorageKeyList)(nil), "pb.StorageKeyList")
+	proto.RegisterType((*StorageDumpRequest)(nil), "pb.StorageDumpRequest")
+	proto.RegisterType((*StorageEntry)(nil), "pb.StorageEntry")
+	proto.RegisterType((*StoragePrefixStat)(nil), "pb.StoragePrefixStat")
+	proto.RegisterType((*StorageStat)(nil), "pb.StorageStat")
+	proto.RegisterType((*AuditEntry)(nil), "pb.AuditEntry")
+	proto.RegisterType((*OrderBookUpdate)(nil), "pb.OrderBookUpdate")
+	proto.RegisterType((*DeadLetter)(nil), "pb.DeadLetter")
+	proto.RegisterType((*DeadLetterList)(nil), "pb.DeadLetterList")
+	proto.RegisterType((*DeadLetterRequest)(nil), "pb.DeadLetterRequest")
+	proto.RegisterType((*AuditLogRequest)(nil), "pb.AuditLogRequest")
 	proto.RegisterType((*Empty)(nil), "pb.Empty")
+	proto.RegisterType((*Counter)(nil), "pb.Counter")
+	proto.RegisterType((*RelayedMessage)(nil), "pb.RelayedMessage")
+	proto.RegisterType((*CompactionReport)(nil), "pb.CompactionReport")
+	proto.RegisterType((*RemovalRequest)(nil), "pb.RemovalRequest")
+	proto.RegisterType((*Removal)(nil), "pb.Removal")
+	proto.RegisterType((*Candle)(nil), "pb.Candle")
+	proto.RegisterType((*CandleRequest)(nil), "pb.CandleRequest")
+	proto.RegisterType((*CandleList)(nil), "pb.CandleList")
+	proto.RegisterType((*Ticker)(nil), "pb.Ticker")
+	proto.RegisterType((*SelfTestResult)(nil), "pb.SelfTestResult")
+	proto.RegisterType((*SelfTestReport)(nil), "pb.SelfTestReport")
+	proto.RegisterType((*IdleChannel)(nil), "pb.IdleChannel")
+	proto.RegisterType((*IdleChannelList)(nil), "pb.IdleChannelList")
+	proto.RegisterType((*ReplicationEntry)(nil), "pb.ReplicationEntry")
+	proto.RegisterType((*ReplicationStatus)(nil), "pb.ReplicationStatus")
+	proto.RegisterType((*Trade)(nil), "pb.Trade")
+	proto.RegisterType((*SyncRequest)(nil), "pb.SyncRequest")
+	proto.RegisterType((*OrderDigest)(nil), "pb.OrderDigest")
+	proto.RegisterType((*KillSwitchRequest)(nil), "pb.KillSwitchRequest")
+}
+
+func init() { proto.RegisterFile("sprawl.proto", fileDescriptor_b5e409e9578376a3) }
+
+var fileDescriptor_b5e409e9578376a3 = []byte{
+	// 4791 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xad, 0x3b, 0x4d, 0x6f, 0x23, 0x47,
+	0x76, 0xc3, 0x2f, 0x91, 0x2c, 0x4a, 0x14, 0xd5, 0xf3, 0x61, 0x42, 0xf0, 0xae, 0xc7, 0x6d, 0xcf,
+	0xec, 0x58, 0xf6, 0x6a, 0xd6, 0xb2, 0x33, 0xf1, 0x26, 0x1b, 0x3b, 0x14, 0xc5, 0x99, 0xd1, 0x5a,
+	0x22, 0xb5, 0x4d, 0xca, 0xc6, 0xec, 0x65, 0xd2, 0x22, 0x4b, 0x52, 0x47, 0xcd, 0x6e, 0x6e, 0x77,
+	0x73, 0x66, 0x34, 0xb9, 0x2c, 0x82, 0xfd, 0x0b, 0xb9, 0x05, 0x41, 0x10, 0x64, 0x91, 0x7b, 0x2e,
+	0x41, 0x0e, 0x01, 0x72, 0x5a, 0x6c, 0xce, 0x39, 0x24, 0x97, 0x04, 0xc9, 0x2d, 0x40, 0x72, 0x08,
+	0x82, 0x1c, 0x02, 0x63, 0x81, 0xe4, 0xbd, 0x57, 0x55, 0xdd, 0xd5, 0x4d, 0x8a, 0xe2, 0x24, 0xd1,
+	0x85, 0xfd, 0x5e, 0xbd, 0xfa, 0x7a, 0xf5, 0xbe, 0xab, 0xc4, 0x56, 0xc3, 0x49, 0x60, 0xbf, 0x74,
+	0xb7, 0x27, 0x81, 0x1f, 0xf9, 0x46, 0x7e, 0x72, 0xb2, 0xf9, 0xce, 0x99, 0xef, 0x9f, 0xb9, 0xfc,
+	0x21, 0x61, 0x4e, 0xa6, 0xa7, 0x0f, 0x23, 0x67, 0xcc, 0xc3, 0xc8, 0x1e, 0x4f, 0x04, 0xd1, 0xe6,
+	0x5b, 0x2f, 0x6c, 0xd7, 0x19, 0xd9, 0x11, 0x7f, 0xa8, 0x3e, 0x44, 0x83, 0x79, 0x87, 0x15, 0x8f,
+	0x38, 0x0f, 0x8c, 0x3a, 0xcb, 0x3b, 0xa3, 0x66, 0xee, 0x6e, 0xee, 0x41, 0xd5, 0x82, 0x2f, 0xf3,
+	0x1f, 0x8b, 0xac, 0xd4, 0x0b, 0x46, 0xa9, 0x96, 0x55, 0x6c, 0x31, 0x3e, 0x65, 0xe5, 0x61, 0xc0,
+	0x61, 0x84, 0x51, 0x33, 0x0f, 0xc8, 0xda, 0xce, 0xe6, 0xb6, 0x98, 0x7d, 0x5b, 0xcd, 0xbe, 0x3d,
+	0x50, 0xb3, 0x5b, 0x8a, 0xd4, 0xb8, 0xc5, 0x4a, 0x76, 0x18, 0xf2, 0xa8, 0x59, 0xa0, 0x29, 0x04,
+	0x60, 0x98, 0x6c, 0x75, 0xe8, 0x4f, 0xbd, 0x88, 0x07, 0x2d, 0x6a, 0x2c, 0x52, 0x63, 0x0a, 0x67,
+	0xdc, 0x61, 0x2b, 0xf6, 0x18, 0x11, 0xcd, 0x12, 0xb4, 0x16, 0x2d, 0x09, 0xe1, 0x88, 0x93, 0xc0,
+	0x19, 0xf2, 0xe6, 0x0a, 0xa0, 0xf3, 0x96, 0x00, 0x8c, 0x77, 0x58, 0x09, 0x66, 0x8e, 0x78, 0xb3,
+	0x0c, 0xd8, 0xfa, 0x4e, 0x75, 0x7b, 0x72, 0xb2, 0xdd, 0x47, 0x84, 0x25, 0xf0, 0xc6, 0xdb, 0xac,
+	0x1a, 0x3a, 0x67, 0x9e, 0x1d, 0x4d, 0x03, 0xde, 0xac, 0xd0, 0xae, 0x12, 0x04, 0x0e, 0xea, 0xf9,
+	0x1e, 0x0c, 0x5a, 0x85, 0x96, 0x35, 0x4b, 0x00, 0xc6, 0x26, 0xab, 0x8c, 0x79, 0x64, 0x03, 0xdb,
+	0xec, 0x26, 0xa3, 0x2e, 0x31, 0x6c, 0x7c, 0xc6, 0xaa, 0x23, 0xee, 0x72, 0xd8, 0x63, 0x2b, 0x6a,
+	0xd6, 0xae, 0x65, 0x48, 0x42, 0x6c, 0xdc, 0x65, 0xb5, 0xb1, 0x7d, 0xc1, 0x03, 0xe4, 0xff, 0xfe,
+	0x5e, 0x73, 0x95, 0x06, 0xd6, 0x51, 0x09, 0xc5, 0xf4, 0xe4, 0x4b, 0x7e, 0xd9, 0x5c, 0xd3, 0x29,
+	0x08, 0x65, 0xfc, 0x80, 0xd5, 0x5c, 0x7f, 0x78, 0xc1, 0x47, 0xc7, 0x5e, 0xe4, 0xb8, 0xcd, 0xfa,
+	0xb5, 0xf3, 0xeb, 0xe4, 0xc8, 0xfe, 0x53, 0xc7, 0x75, 0x61, 0x35, 0x82, 0xc1, 0xeb, 0xc4, 0xe0,
+	0x14, 0xce, 0xf8, 0x36, 0x2b, 0x21, 0x1c, 0x36, 0x1b, 0x77, 0x0b, 0x30, 0x76, 0x05, 0x19, 0xfa,
+	0x18, 0x10, 0x96, 0x40, 0x13, 0x6f, 0x70, 0x41, 0x8f, 0x39, 0x6f, 0x6e, 0xd0, 0x49, 0xc4, 0x30,
+	0xb6, 0x45, 0xaa, 0xcd, 0x10, 0x6d, 0x0a, 0x36, 0xff, 0x2b, 0xc7, 0x8a, 0x38, 0x8e, 0xd1, 0x64,
+	0x65, 0x1f, 0x05, 0x0d, 0x58, 0x20, 0x84, 0x4c, 0x81, 0xda, 0xc9, 0xe7, 0xb3, 0x27, 0x2f, 0x0e,
+	0xa9, 0xa0, 0x1f, 0x12, 0x30, 0x2b, 0xd2, 0x98, 0x55, 0x14, 0xcc, 0xd2, 0x50, 0xc6, 0x7d, 0x56,
+	0x27, 0xb0, 0x1f, 0x9f, 0x7f, 0x89, 0x88, 0x32, 0x58, 0xa4, 0x1b, 0xa7, 0xe9, 0x56, 0x04, 0x5d,
+	0x1a, 0x9b, 0xda, 0x7a, 0x99, 0x56, 0x38, 0x7f, 0xeb, 0x15, 0xd1, 0x16, 0x6f, 0xfd, 0x98, 0xd5,
+	0x88, 0x83, 0xfc, 0x27, 0x53, 0x38, 0x15, 0xe3, 0x01, 0xab, 0x0e, 0xcf, 0x6d, 0xcf, 0xe3, 0xae,
+	0x62, 0xc1, 0x2e, 0xfb, 0x66, 0xb7, 0xfc, 0xba, 0xd4, 0xc8, 0x35, 0x7f, 0x9a, 0xb7, 0x92, 0x46,
+	0x90, 0xdd, 0x22, 0x32, 0x5d, 0xea, 0x5d, 0x72, 0x14, 0x84, 0x35, 0xb7, 0x59, 0x95, 0x34, 0xf6,
+	0xc0, 0x81, 0x41, 0xdf, 0x65, 0x2b, 0xc4, 0xc6, 0x10, 0x46, 0xc4, 0x73, 0x23, 0x45, 0xa0, 0x66,
+	0x4b, 0x36, 0x98, 0xf7, 0x59, 0x23, 0xa6, 0x57, 0x6b, 0x31, 0x58, 0x71, 0xec, 0x78, 0x9c, 0x96,
+	0x51, 0xb1, 0xe8, 0xdb, 0xfc, 0xfb, 0x3c, 0x5b, 0xeb, 0x73, 0x3b, 0x18, 0x9e, 0x2b, 0xaa, 0xb7,
+	0x67, 0x56, 0xac, 0xaf, 0x32, 0x56, 0xf5, 0xfc, 0x22, 0x55, 0x2f, 0xcc, 0x51, 0x75, 0xd8, 0x5f,
+	0xe8, 0x8c, 0x38, 0x9d, 0x5d, 0x5d, 0xec, 0xaf, 0x0f, 0xb0, 0x45, 0x58, 0x62, 0xb7, 0xe3, 0x1d,
+	0x91, 0xce, 0x97, 0xa4, 0xa4, 0x49, 0x58, 0x1c, 0xc5, 0xab, 0x23, 0xcd, 0x1e, 0xc4, 0x30, 0xb2,
+	0x82, 0x54, 0x3f, 0x84, 0x43, 0x2a, 0xa4, 0x6d, 0x82, 0x6c, 0xc8, 0xaa, 0x62, 0x65, 0x56, 0x15,
+	0x3f, 0x87, 0xe5, 0x0b, 0x53, 0xd6, 0x77, 0x94, 0x7d, 0x58, 0xac, 0x69, 0x29, 0x7a, 0x64, 0x8a,
+	0xeb, 0x8c, 0x9d, 0x88, 0xec, 0x07, 0xc8, 0x2c, 0x01, 0xe6, 0x3f, 0xe7, 0x58, 0xb9, 0x2d, 0x18,
+	0x37, 0x63, 0x67, 0x3f, 0x02, 0xbd, 0x98, 0x44, 0x8e, 0xef, 0x85, 0xf2, 0xbc, 0x0d, 0x5c, 0xb7,
+	0xa4, 0xee, 0x89, 0x16, 0x4b, 0x91, 0x90, 0xae, 0x8c, 0x80, 0x1d, 0x21, 0x30, 0xb6, 0x00, 0x8c,
+	0x95, 0x90, 0xb1, 0xcd, 0xd8, 0x98, 0x8f, 0x4f, 0xe0, 0xbc, 0xcf, 0x9d, 0x09, 0x31, 0xb6, 0xb6,
+	0x53, 0xc7, 0x81, 0x0e, 0x63, 0xac, 0xa5, 0x51, 0x18, 0x1f, 0xb0, 0x95, 0xa1, 0xef, 0x9d, 0x3a,
+	0x67, 0xc4, 0xe2, 0xda, 0xce, 0x86, 0x36, 0x69, 0x9b, 0x1a, 0x2c, 0x49, 0x60, 0xdc, 0x63, 0xe5,
+	0x73, 0x10, 0x1d, 0x3f, 0xb8, 0x24, 0x96, 0xd7, 0x77, 0x6a, 0x48, 0xfb, 0x54, 0xa0, 0x2c, 0xd5,
+	0x66, 0xfe, 0x49, 0x8e, 0xb1, 0x64, 0xb2, 0x6b, 0x64, 0x07, 0x8c, 0x81, 0x5c, 0x0c, 0x6c, 0x1a,
+	0xf7, 0xa1, 0x40, 0x6c, 0x79, 0x01, 0xbf, 0xb0, 0x59, 0xa9, 0xf6, 0x0a, 0x34, 0xde, 0x67, 0x6b,
+	0xc4, 0x6a, 0x3f, 0xad, 0xfa, 0x69, 0x64, 0xda, 0xee, 0x97, 0x32, 0x76, 0xdf, 0xfc, 0xb3, 0x02,
+	0x5b, 0x4b, 0xed, 0xf2, 0xfa, 0x75, 0xaa, 0xd5, 0xe4, 0xd3, 0xab, 0x41, 0xc5, 0x77, 0x86, 0x17,
+	0x7d, 0xe7, 0xb5, 0xb0, 0x4f, 0x68, 0xf3, 0x24, 0x8c, 0xbd, 0x5c, 0x3f, 0xa2, 0xa6, 0x22, 0xd9,
+	0x04, 0x05, 0xa6, 0x4c, 0x49, 0x69, 0x81, 0x15, 0x5d, 0x49, 0x5b, 0x51, 0xdc, 0xbb, 0xed, 0xba,
+	0xfe, 0x4b, 0x17, 0x98, 0xfd, 0xd4, 0x0e, 0xcf, 0xc9, 0x0e, 0xc1, 0xde, 0x53, 0x48, 0xe3, 0x11,
+	0xbb, 0x03, 0xea, 0x15, 0xb9, 0x7c, 0xcc, 0xbd, 0x68, 0xdf, 0x0b, 0xa3, 0x60, 0x3a, 0x14, 0x92,
+	0x55, 0x21, 0x2d, 0xbc, 0xa2, 0x75, 0x96, 0xb3, 0xd5, 0x6b, 0x39, 0xcb, 0xb2, 0x1e, 0x55, 0x19,
+	0x53, 0x0b, 0x74, 0xe1, 0x80, 0x34, 0xa0, 0x46, 0x0c, 0xcb, 0x60, 0x05, 0xdd, 0xab, 0x43, 0x44,
+	0xf6, 0x84, 0xe1, 0x5a, 0x55, 0x74, 0x3a, 0xd6, 0xfc, 0xd3, 0x1c, 0x33, 0xf6, 0x47, 0xb0, 0x52,
+	0x27, 0xba, 0x1c, 0x04, 0xb6, 0x17, 0x3a, 0xb8, 0x56, 0x5c, 0x84, 0xef, 0x8e, 0xe4, 0x32, 0xe5,
+	0x71, 0xc5, 0x08, 0x6c, 0xf5, 0xf8, 0x4b, 0xd9, 0x9a, 0x17, 0xad, 0x31, 0x42, 0x8f, 0x68, 0x0a,
+	0xcb, 0x47, 0x34, 0xa9, 0x6d, 0x17, 0xb3, 0x02, 0xf5, 0x88, 0xd5, 0xa4, 0x3c, 0x91, 0x39, 0xfe,
+	0x0e, 0xab, 0x48, 0xe1, 0x51, 0x06, 0xb9, 0xa6, 0x29, 0x96, 0x15, 0x37, 0x9a, 0xef, 0xb1, 0xaa,
+	0xc5, 0x87, 0xce, 0xc4, 0x81, 0x1d, 0xa2, 0x52, 0x4f, 0xb8, 0xe6, 0x19, 0x25, 0x64, 0xba, 0xac,
+	0xf6, 0xb5, 0x13, 0xf0, 0x43, 0x1e, 0x86, 0xf6, 0x19, 0xbf, 0x46, 0x54, 0x3f, 0x04, 0xce, 0x4c,
+	0x78, 0x60, 0x47, 0x4a, 0x58, 0xeb, 0x3b, 0x6b, 0xe4, 0x0c, 0x14, 0xd2, 0x4a, 0xda, 0xd1, 0xfe,
+	0x53, 0x94, 0x53, 0xa0, 0x51, 0xe8, 0xdb, 0xfc, 0x82, 0x35, 0xb4, 0xd9, 0x76, 0xed, 0x68, 0x78,
+	0x0e, 0x83, 0x42, 0x04, 0x44, 0x70, 0x08, 0x7b, 0xc7, 0xfd, 0xac, 0xe3, 0x98, 0x1a, 0x9d, 0x15,
+	0x13, 0x98, 0x7f, 0x94, 0x63, 0xab, 0xfd, 0xe9, 0x49, 0x38, 0x0c, 0x1c, 0xb2, 0x56, 0x89, 0x87,
+	0xc8, 0x2d, 0xf2, 0x10, 0xf9, 0x39, 0x1e, 0x42, 0xf7, 0x01, 0x85, 0x05, 0x3e, 0xa0, 0x98, 0xf1,
+	0x01, 0xca, 0xb3, 0x94, 0xe6, 0x79, 0x16, 0xf3, 0xbf, 0x73, 0xac, 0xfa, 0xd4, 0xf6, 0x46, 0xe1,
+	0x39, 0x08, 0x1a, 0xb2, 0x73, 0x32, 0x3d, 0x71, 0x9d, 0xa1, 0x26, 0x4a, 0x31, 0x42, 0x32, 0x1b,
+	0x02, 0x24, 0xef, 0x8c, 0x2b, 0x51, 0x8a, 0x11, 0x69, 0xa1, 0x28, 0x64, 0x75, 0xe1, 0x01, 0x5b,
+	0x27, 0x89, 0x1a, 0xfa, 0xee, 0x57, 0xd2, 0x7a, 0x88, 0x88, 0x37, 0x8b, 0xc6, 0xbd, 0xc4, 0xf2,
+	0x52, 0x02, 0xfe, 0xae, 0x26, 0x22, 0x42, 0x7c, 0xb2, 0x27, 0xf6, 0x89, 0xe3, 0x82, 0xe8, 0x03,
+	0xff, 0x57, 0xc8, 0x50, 0xa6, 0x70, 0x60, 0xf6, 0x8b, 0x98, 0x02, 0x90, 0x39, 0x58, 0x2c, 0xcf,
+	0x44, 0x67, 0xfe, 0x32, 0x07, 0xf6, 0x8f, 0x04, 0xfb, 0xcd, 0xa3, 0x92, 0x6f, 0xa5, 0xfc, 0xfd,
+	0x6e, 0xf9, 0x9b, 0xdd, 0x62, 0x90, 0x6f, 0xe4, 0xd4, 0xb1, 0x7e, 0x38, 0xcf, 0xf1, 0x27, 0x54,
+	0xe9, 0xf3, 0x7d, 0x27, 0x0e, 0xf9, 0xc8, 0x40, 0x12, 0xd9, 0x4e, 0xfe, 0xee, 0x8d, 0x38, 0xf6,
+	0xbb, 0xab, 0xa2, 0x7e, 0xb2, 0x92, 0xb4, 0x24, 0x56, 0xba, 0x77, 0x03, 0xfe, 0x64, 0x06, 0x60,
+	0xfe, 0x41, 0x9e, 0xd5, 0xba, 0xfc, 0xcc, 0x8f, 0x1c, 0x21, 0xd2, 0x59, 0xbf, 0x9a, 0xd2, 0x96,
+	0x7c, 0x56, 0x5b, 0x20, 0x7f, 0xa0, 0xf0, 0x48, 0x5a, 0x02, 0x2d, 0x6c, 0x12, 0x78, 0xd0, 0xe4,
+	0x62, 0x18, 0xf1, 0x89, 0x8c, 0x51, 0x6e, 0x62, 0xbb, 0x36, 0x5b, 0x1f, 0x9a, 0x2c, 0x22, 0x78,
+	0xc3, 0xbc, 0x65, 0x8b, 0x35, 0x02, 0x3e, 0xb6, 0x1d, 0x6f, 0x24, 0x2d, 0x1d, 0x2c, 0x4e, 0xd8,
+	0xf2, 0x19, 0x3c, 0xda, 0xab, 0xe9, 0x64, 0x44, 0xf6, 0xaa, 0x72, 0xbd, 0xbd, 0x92, 0xa4, 0xe6,
+	0xaf, 0xc0, 0x70, 0x6a, 0x2b, 0x55, 0xc6, 0x03, 0x6c, 0xbc, 0x97, 0x60, 0x63, 0x03, 0x92, 0x46,
+	0xc6, 0xbb, 0xce, 0x5f, 0xb7, 0xeb, 0x14, 0x77, 0x0b, 0x73, 0xdc, 0xa6, 0x8a, 0xf5, 0x8b, 0x57,
+	0xc5, 0xfa, 0xcb, 0x70, 0xeb, 0x63, 0x56, 0xd3, 0xd6, 0x27, 0xa5, 0x7c, 0x3d, 0xb3, 0x2a, 0x4b,
+	0xa7, 0x31, 0x7f, 0x91, 0x63, 0xb5, 0x1f, 0xfa, 0x8e, 0xa7, 0xe4, 0xfb, 0x5b, 0x29, 0x1b, 0x74,
+	0xad, 0xd4, 0xe6, 0x17, 0x49, 0xed, 0x55, 0xc1, 0x97, 0x16, 0xc2, 0x15, 0xaf, 0x0f, 0xe1, 0xb4,
+	0x78, 0xaa, 0xb4, 0x20, 0x9e, 0xfa, 0xcb, 0x3c, 0xab, 0xa7, 0x87, 0x40, 0xa6, 0xd3, 0xaa, 0x8f,
+	0x6c, 0x27, 0x90, 0x36, 0x35, 0x41, 0xa4, 0x22, 0x92, 0xfc, 0xd5, 0x11, 0x49, 0x21, 0x1d, 0x91,
+	0x7c, 0x9b, 0xb1, 0x9f, 0x4c, 0xfd, 0x88, 0xeb, 0x89, 0xb9, 0x86, 0xa1, 0x90, 0x59, 0x84, 0x66,
+	0x3d, 0xcf, 0x15, 0x2b, 0xae, 0x58, 0x3a, 0x0a, 0xc7, 0x96, 0x81, 0x02, 0x1d, 0x5e, 0xd5, 0x52,
+	0x20, 0x46, 0xe4, 0xb4, 0x3c, 0x11, 0x91, 0x4b, 0x2d, 0xa3, 0x61, 0x2d, 0xd9, 0x90, 0x0a, 0x88,
+	0x2a, 0x0b, 0x02, 0xa2, 0x6a, 0x26, 0x20, 0x7a, 0x5b, 0x79, 0x3b, 0x1f, 0x22, 0x08, 0x46, 0xa7,
+	0x91, 0x20, 0xcc, 0xdf, 0x63, 0xa5, 0xf8, 0xc4, 0xc2, 0xcb, 0xf1, 0x89, 0xef, 0x4a, 0x76, 0x49,
+	0x08, 0x87, 0x1e, 0x81, 0xfb, 0x1d, 0xdb, 0x6e, 0x28, 0x03, 0xbb, 0x18, 0x46, 0x51, 0x04, 0x49,
+	0x76, 0x3c, 0x55, 0xc2, 0x20, 0x00, 0x6d, 0x3a, 0xc4, 0xc3, 0x51, 0x60, 0x0f, 0xa3, 0xd6, 0x68,
+	0x14, 0x80, 0x56, 0x29, 0x9b, 0x9e, 0x41, 0x63, 0x7e, 0x46, 0x93, 0xab, 0xfc, 0x4c, 0xb2, 0x20,
+	0x77, 0x05, 0x0b, 0xcc, 0x21, 0xbb, 0x45, 0x1a, 0xdf, 0x9f, 0xc0, 0x0a, 0x4e, 0x9d, 0xa1, 0x92,
+	0xdc, 0x77, 0x33, 0x09, 0x33, 0x49, 0xe5, 0x6b, 0x94, 0xca, 0x58, 0x9b, 0x1e, 0xcc, 0xd8, 0xb8,
+	0x2b, 0x8c, 0xb7, 0xf9, 0x57, 0x39, 0x76, 0x93, 0x66, 0x51, 0x72, 0xb6, 0x54, 0x8a, 0x07, 0xee,
+	0xe5, 0x34, 0xf0, 0xc7, 0x4b, 0x14, 0x80, 0x88, 0x0e, 0xac, 0x5b, 0x3e, 0xf2, 0x97, 0x08, 0xae,
+	0x80, 0x0a, 0x8f, 0x66, 0x38, 0x0d, 0x42, 0x90, 0x1a, 0x61, 0x22, 0x24, 0x94, 0x64, 0x50, 0x25,
+	0x3d, 0x83, 0xfa, 0x9a, 0x6d, 0x68, 0x99, 0xcc, 0x1b, 0xfb, 0xae, 0x2b, 0xf3, 0x0d, 0xf3, 0xdf,
+	0xf2, 0xec, 0x56, 0x3a, 0xef, 0x79, 0xe3, 0xc1, 0xef, 0x67, 0x15, 0x4f, 0xba, 0xab, 0xef, 0x92,
+	0xbb, 0x5a, 0x46, 0x09, 0x75, 0x2d, 0x28, 0x2e, 0xd0, 0x82, 0x52, 0x46, 0x0b, 0x40, 0x79, 0x27,
+	0x8e, 0x27, 0x19, 0x43, 0xda, 0x57, 0xb1, 0x34, 0x8c, 0xf1, 0xdb, 0x57, 0x26, 0x04, 0x65, 0xb2,
+	0x73, 0x95, 0x6f, 0x76, 0x4b, 0x41, 0xe1, 0xc1, 0x4f, 0xef, 0x5e, 0x99, 0x1a, 0xcc, 0x86, 0xf5,
+	0x95, 0x25, 0xc3, 0xfa, 0xea, 0xdc, 0xb0, 0xfe, 0x53, 0x76, 0x47, 0x72, 0x3b, 0x2b, 0xee, 0x9b,
+	0x89, 0xff, 0x4e, 0x31, 0x1a, 0xab, 0x94, 0x5f, 0x80, 0x29, 0x94, 0x51, 0x4b, 0x38, 0x81, 0x65,
+	0x71, 0xe3, 0xbb, 0x71, 0x9e, 0x4e, 0x03, 0x53, 0xbf, 0x94, 0x1b, 0x4f, 0x35, 0x43, 0x98, 0xbe,
+	0xa1, 0xd5, 0x40, 0xe4, 0x18, 0x4b, 0xd4, 0x4e, 0x9e, 0x49, 0xdd, 0x8c, 0xb5, 0x66, 0xe9, 0xae,
+	0x78, 0x36, 0x1e, 0x7f, 0x15, 0xb5, 0x85, 0x8c, 0x8b, 0x00, 0x44, 0xc3, 0x98, 0x9f, 0xb3, 0x9b,
+	0x5a, 0xe6, 0x10, 0x8f, 0xbc, 0x74, 0x06, 0xf1, 0x11, 0x6b, 0x60, 0xcd, 0x22, 0xd5, 0x19, 0x24,
+	0x4c, 0xa4, 0x0e, 0xa2, 0x2f, 0x88, 0xb9, 0x04, 0xcd, 0xbf, 0x81, 0xd0, 0x17, 0xc9, 0xfb, 0x43,
+	0x1f, 0x02, 0xd4, 0x4c, 0x15, 0x18, 0x75, 0x2e, 0xc4, 0x06, 0x5a, 0x66, 0xc9, 0x12, 0x00, 0xb8,
+	0xb5, 0x0d, 0xc7, 0xa3, 0x3a, 0x72, 0x5c, 0x0b, 0x0b, 0x65, 0x52, 0x3e, 0xdb, 0x80, 0x73, 0x07,
+	0x7c, 0xe2, 0xda, 0x97, 0xc2, 0x30, 0x42, 0xaa, 0x2c, 0x41, 0xb4, 0x31, 0x60, 0x58, 0x4f, 0xfd,
+	0x60, 0x0c, 0x91, 0x8c, 0xd0, 0xea, 0x04, 0x81, 0xa9, 0x48, 0x38, 0xb1, 0xc7, 0x24, 0xbd, 0x6b,
+	0x16, 0x7d, 0x93, 0x75, 0xa7, 0x44, 0xfb, 0x35, 0xf4, 0x28, 0x8b, 0x1e, 0x31, 0xc2, 0xfc, 0x06,
+	0x22, 0x3f, 0xdc, 0xcb, 0x1e, 0x8f, 0x6c, 0x07, 0x0c, 0x76, 0x76, 0x37, 0xe8, 0x26, 0x85, 0x2d,
+	0xe6, 0x4a, 0xdd, 0x13, 0x04, 0x86, 0xd5, 0x10, 0x0f, 0x79, 0xd1, 0x57, 0x5a, 0x95, 0x01, 0xc2,
+	0x6a, 0x1d, 0xf7, 0x06, 0x01, 0x3c, 0x84, 0x55, 0xa2, 0x4a, 0xaf, 0xe8, 0x4a, 0x44, 0x97, 0x46,
+	0xa6, 0xc2, 0xfc, 0x95, 0x4c, 0x98, 0x0f, 0x79, 0xdb, 0x08, 0xd2, 0xa9, 0x61, 0x1c, 0xe1, 0xc8,
+	0xbc, 0x6d, 0x4f, 0x21, 0xad, 0xa4, 0x9d, 0x4c, 0x08, 0x48, 0xb5, 0x37, 0xbc, 0x24, 0x3d, 0x2c,
+	0x58, 0x0a, 0xc4, 0x96, 0x93, 0xcb, 0x88, 0x87, 0xfb, 0x1e, 0x69, 0x1e, 0x18, 0x17, 0x09, 0xe2,
+	0xe4, 0xf4, 0xd9, 0x9b, 0x8a, 0xaa, 0x54, 0xd1, 0x8a, 0x61, 0x34, 0xc2, 0xe0, 0x32, 0x39, 0x74,
+	0xc2, 0x6c, 0x3d, 0x67, 0x49, 0x88, 0x0e, 0x13, 0xbe, 0xb0, 0xcb, 0x2a, 0x35, 0x28, 0xd0, 0xfc,
+	0x8c, 0xad, 0x6b, 0xbc, 0x27, 0x1f, 0x77, 0x0f, 0x62, 0x37, 0x9e, 0xe8, 0x02, 0xc5, 0x67, 0x1a,
+	0x8d, 0x25, 0x5a, 0xcd, 0x5f, 0x15, 0x58, 0xa5, 0xeb, 0x8f, 0x60, 0xf8, 0x53, 0x7f, 0xe6, 0xcc,
+	0xde, 0x53, 0x63, 0xe4, 0x69, 0x8c, 0x35, 0x35, 0x06, 0xc9, 0xab, 0x1c, 0x01, 0x8f, 0x05, 0x6b,
+	0x1d, 0xdc, 0x6b, 0xc5, 0xc7, 0x2b, 0x02, 0xb1, 0x2c, 0x1a, 0x1c, 0x97, 0x01, 0xec, 0x85, 0xd8,
+	0x6d, 0xc8, 0x47, 0x09, 0x71, 0x91, 0x88, 0xe7, 0xb4, 0xa0, 0xf9, 0x22, 0xb5, 0x6d, 0xdb, 0xc3,
+	0x73, 0xfe, 0xd4, 0x89, 0x42, 0x19, 0x9e, 0x66, 0xb0, 0x18, 0xbe, 0x27, 0x98, 0x43, 0x87, 0x46,
+	0x5d, 0x21, 0xca, 0x19, 0x3c, 0xb9, 0x56, 0xac, 0xc2, 0xf7, 0x2f, 0xf8, 0x4b, 0x3a, 0xd8, 0x82,
+	0x95, 0x20, 0x28, 0xbb, 0x23, 0x00, 0xfc, 0xa1, 0xcb, 0x43, 0x69, 0x56, 0x53, 0x38, 0xa4, 0x09,
+	0x81, 0x56, 0x1a, 0xb1, 0x50, 0x1e, 0x6c, 0x0a, 0x87, 0xa7, 0x0b, 0x86, 0x6e, 0x44, 0xc1, 0x19,
+	0x23, 0x07, 0x10, 0xc3, 0x28, 0x9c, 0xa7, 0x01, 0xe7, 0x7b, 0x4e, 0x78, 0xd1, 0x9f, 0xd8, 0x10,
+	0x5c, 0xd7, 0x68, 0x80, 0x34, 0x92, 0x2c, 0x8e, 0x88, 0x72, 0xb1, 0x16, 0x93, 0x58, 0x1c, 0x81,
+	0xb3, 0xe2, 0x46, 0xe3, 0x07, 0xac, 0xee, 0xda, 0x61, 0xd4, 0xf6, 0xc7, 0xd0, 0x8f, 0xc4, 0x75,
+	0x8d, 0xac, 0xee, 0x2d, 0x41, 0xae, 0xb0, 0x16, 0x9f, 0xf8, 0x41, 0x64, 0x65, 0x68, 0xcd, 0x16,
+	0x5b, 0x15, 0x71, 0xb9, 0xb4, 0x55, 0x1f, 0xb3, 0xb5, 0xdf, 0x05, 0x98, 0x8f, 0xa4, 0x69, 0x93,
+	0x26, 0x3c, 0x65, 0xed, 0xd2, 0x14, 0xe6, 0xbb, 0xac, 0xb6, 0x6b, 0x0f, 0x2f, 0xa6, 0x93, 0xf6,
+	0xf9, 0xd4, 0xbb, 0x88, 0x8b, 0x18, 0x39, 0xad, 0x88, 0xd1, 0x63, 0xf5, 0xa3, 0xc0, 0x3f, 0x75,
+	0xdc, 0x38, 0xc1, 0x7d, 0x0f, 0x52, 0xe4, 0xcb, 0x89, 0x28, 0x75, 0xd7, 0xa5, 0x70, 0x0a, 0x8a,
+	0x01, 0xa0, 0x2d, 0x6a, 0x44, 0x79, 0x0f, 0x39, 0x04, 0x72, 0x23, 0x15, 0x0e, 0x2a, 0xd0, 0xbc,
+	0x07, 0xf2, 0xae, 0x06, 0x94, 0x2b, 0x87, 0x79, 0x27, 0x76, 0x74, 0x2e, 0xa5, 0x97, 0xbe, 0xcd,
+	0x5d, 0x66, 0xf4, 0xc1, 0x43, 0x80, 0x15, 0xd1, 0xcb, 0xec, 0x58, 0xd8, 0x09, 0xf8, 0xa9, 0xf3,
+	0x4a, 0x85, 0x9f, 0x02, 0x4a, 0x62, 0x9c, 0xbc, 0x1e, 0xe3, 0xec, 0x30, 0x26, 0xc7, 0xc0, 0x02,
+	0x44, 0x83, 0x15, 0x2e, 0xe2, 0xc2, 0x04, 0x7e, 0x92, 0xa5, 0x54, 0x31, 0x46, 0xd1, 0xa2, 0x6f,
+	0xd3, 0x62, 0xf5, 0xa4, 0x0f, 0x69, 0xa3, 0xc9, 0x8a, 0x40, 0xac, 0x94, 0xb1, 0x2e, 0x8a, 0xe0,
+	0x8a, 0xc2, 0xa2, 0x36, 0x14, 0x4d, 0x70, 0xf1, 0xde, 0x30, 0xbe, 0xdd, 0xab, 0x58, 0x09, 0x02,
+	0x3c, 0x8b, 0xda, 0xcb, 0xde, 0x74, 0x3c, 0xb9, 0x66, 0x2f, 0xe0, 0x5a, 0x57, 0x25, 0x75, 0x07,
+	0xe2, 0xe0, 0x79, 0xeb, 0x86, 0xdd, 0x82, 0xb3, 0x98, 0xaa, 0x32, 0x8a, 0x00, 0xcc, 0x3e, 0xdb,
+	0x90, 0xfd, 0x8e, 0x68, 0x20, 0xac, 0xd4, 0x5f, 0xc9, 0x30, 0x43, 0x6e, 0x4a, 0x6e, 0x9d, 0x36,
+	0xa1, 0xd8, 0x51, 0xd0, 0xd8, 0x71, 0xce, 0x6a, 0x72, 0x50, 0x1a, 0xee, 0x63, 0x56, 0x11, 0x03,
+	0x70, 0xc5, 0x8f, 0xdb, 0x1a, 0x3f, 0x92, 0x79, 0xad, 0x98, 0x6c, 0xe9, 0x99, 0x7e, 0x96, 0x67,
+	0xac, 0x35, 0x1d, 0x39, 0x91, 0xd8, 0x35, 0x2c, 0x7c, 0xcc, 0xa3, 0x73, 0x5f, 0xd9, 0x34, 0x09,
+	0x51, 0x45, 0xd2, 0x86, 0xb0, 0x97, 0xd4, 0x4f, 0x54, 0xba, 0x12, 0x04, 0x8a, 0x9d, 0x74, 0x4c,
+	0xd2, 0x0d, 0x29, 0x10, 0xd3, 0xae, 0x40, 0x30, 0x9e, 0xca, 0xbd, 0xf2, 0x96, 0x4b, 0x43, 0xe1,
+	0x85, 0x64, 0x7c, 0xfb, 0x2b, 0x8b, 0xf8, 0x0b, 0x2f, 0x24, 0x63, 0x62, 0x32, 0xfa, 0x3c, 0x9c,
+	0xba, 0x91, 0xcc, 0xd7, 0x24, 0x84, 0xe7, 0xc4, 0x83, 0x00, 0x82, 0x95, 0xb2, 0x48, 0x7c, 0x08,
+	0xc0, 0x1d, 0xc8, 0x69, 0xe5, 0x8d, 0x09, 0xec, 0x20, 0x46, 0x98, 0x7f, 0x9b, 0x63, 0xeb, 0x64,
+	0x89, 0x76, 0x7d, 0xff, 0xe2, 0x98, 0x4a, 0x10, 0xd7, 0xe4, 0x14, 0x60, 0xb0, 0x42, 0xec, 0xee,
+	0x0d, 0x95, 0x24, 0xc7, 0x30, 0xb5, 0x79, 0xf6, 0x24, 0x3c, 0xf7, 0x45, 0xfd, 0x08, 0x8c, 0x99,
+	0x82, 0xb5, 0x90, 0xab, 0x78, 0x55, 0xc8, 0x75, 0x1f, 0x52, 0x0a, 0x98, 0xe7, 0x4c, 0xd5, 0xff,
+	0x48, 0xf8, 0x71, 0x61, 0x6d, 0xc2, 0x5a, 0xb2, 0x35, 0x29, 0xfe, 0xac, 0xcc, 0x2f, 0xfe, 0x98,
+	0x7f, 0x9e, 0x63, 0x6c, 0x0f, 0xac, 0xe8, 0x01, 0x04, 0xc5, 0x73, 0xae, 0xc6, 0x95, 0xe1, 0xc9,
+	0x27, 0x86, 0x07, 0x71, 0x94, 0x2a, 0x89, 0x73, 0x14, 0xe9, 0x10, 0x31, 0xda, 0x0e, 0xe3, 0xe8,
+	0x41, 0x42, 0xc6, 0x23, 0xb4, 0xd9, 0x43, 0xee, 0xbc, 0x90, 0xf1, 0xd0, 0xe2, 0x93, 0x8b, 0x69,
+	0xd3, 0x47, 0xb1, 0x92, 0x3d, 0x8a, 0x5d, 0x56, 0x4f, 0xd6, 0x4c, 0xa6, 0xe0, 0x7b, 0xac, 0x36,
+	0x8a, 0x31, 0x29, 0x8b, 0x90, 0x10, 0x5a, 0x3a, 0x09, 0x58, 0xbb, 0x0d, 0xad, 0x49, 0x6a, 0x3e,
+	0x68, 0xb4, 0x33, 0x12, 0xdd, 0x41, 0xa3, 0xe1, 0xd3, 0x1c, 0xb3, 0x75, 0x92, 0xfd, 0x03, 0x3f,
+	0x4e, 0x97, 0x54, 0xaa, 0x98, 0x7b, 0xa3, 0x54, 0x31, 0xbf, 0x4c, 0xaa, 0x68, 0x42, 0x2e, 0xd5,
+	0x19, 0x4f, 0xa2, 0x4b, 0xf3, 0x47, 0xac, 0x2c, 0xdd, 0x12, 0xf2, 0x1b, 0xf5, 0x48, 0x19, 0x61,
+	0xfc, 0x16, 0x56, 0x3c, 0x8c, 0x6f, 0x6b, 0x8a, 0x96, 0x02, 0x49, 0xd1, 0x5c, 0x17, 0x47, 0x55,
+	0xa9, 0x97, 0x04, 0xcd, 0x88, 0xd5, 0x2d, 0x0e, 0x61, 0x2a, 0x1f, 0xa9, 0x4a, 0xd9, 0x1c, 0xb7,
+	0x92, 0xae, 0x15, 0xe7, 0xe7, 0xd4, 0x8a, 0x17, 0x54, 0x83, 0x61, 0xbc, 0x73, 0x7f, 0xa2, 0xa2,
+	0x62, 0xfa, 0x36, 0xff, 0x3a, 0xc7, 0x1a, 0x59, 0x8f, 0x89, 0xf5, 0x3e, 0xd8, 0x73, 0x80, 0x36,
+	0xf9, 0x7a, 0x2e, 0x2a, 0x52, 0x2a, 0x65, 0x4c, 0xb5, 0xb2, 0x3f, 0xe8, 0x93, 0x82, 0x31, 0x07,
+	0x41, 0x63, 0xb5, 0xcb, 0x4f, 0xfd, 0x40, 0xed, 0x5c, 0xc3, 0x88, 0x85, 0xbf, 0xe6, 0xad, 0x53,
+	0xe0, 0xa8, 0xbc, 0xaa, 0x4a, 0x10, 0x42, 0xdc, 0x86, 0xae, 0xed, 0xa8, 0xb8, 0xbd, 0x68, 0x25,
+	0x08, 0xf3, 0xf7, 0x73, 0xc8, 0xb9, 0xb1, 0x0f, 0xd6, 0xfc, 0x7f, 0x95, 0x8f, 0xab, 0xda, 0x46,
+	0x3e, 0x5d, 0x20, 0x04, 0x23, 0x44, 0xa9, 0xa5, 0xaa, 0xbe, 0x10, 0x70, 0x95, 0x26, 0x99, 0xff,
+	0x9a, 0x63, 0x65, 0xb9, 0x88, 0xeb, 0x6f, 0xf2, 0xfe, 0x3f, 0x66, 0xd4, 0x2f, 0x91, 0x4a, 0xcb,
+	0x5f, 0x22, 0x61, 0x7c, 0x29, 0xab, 0x53, 0xf2, 0x76, 0x4a, 0x3e, 0x35, 0x48, 0x63, 0xd3, 0x92,
+	0x54, 0xce, 0x5e, 0x36, 0xfd, 0x67, 0x8e, 0xad, 0xb4, 0x6d, 0x6f, 0xe4, 0x2e, 0x61, 0x63, 0x1d,
+	0xd4, 0x12, 0x60, 0x8b, 0x2a, 0x6f, 0x29, 0x18, 0x8c, 0x42, 0x89, 0x44, 0x67, 0x89, 0x32, 0x8d,
+	0x20, 0x44, 0x01, 0x86, 0x65, 0x7a, 0xb2, 0x32, 0x41, 0xdf, 0x24, 0xd4, 0xce, 0xd9, 0xb9, 0xac,
+	0x48, 0xd0, 0x37, 0xda, 0x09, 0xd7, 0x7f, 0x29, 0x2b, 0xb8, 0xf8, 0x49, 0xa5, 0x34, 0xd7, 0x0f,
+	0xc5, 0x56, 0xf2, 0x96, 0x00, 0x90, 0xb5, 0x2f, 0x7c, 0x77, 0x3a, 0x56, 0x2f, 0x26, 0x24, 0x84,
+	0xf8, 0x28, 0xb0, 0x47, 0x5c, 0xd5, 0x0e, 0x24, 0x64, 0xfe, 0x1c, 0x2f, 0x2d, 0x68, 0xdb, 0xcb,
+	0x55, 0xad, 0x16, 0xed, 0x7e, 0x5b, 0x33, 0xd3, 0xcb, 0x9b, 0xa9, 0xe2, 0x52, 0x66, 0x0a, 0xe2,
+	0x37, 0xb1, 0x4c, 0x32, 0xbe, 0xef, 0x83, 0xa0, 0x10, 0xa4, 0x0c, 0x2f, 0xa3, 0xc8, 0x56, 0xec,
+	0x43, 0x35, 0x99, 0xff, 0x01, 0x47, 0x3a, 0x70, 0x86, 0x17, 0x42, 0xdd, 0x16, 0x6c, 0x0a, 0x18,
+	0x8e, 0x01, 0xb5, 0xac, 0xec, 0xd2, 0x77, 0x7c, 0x30, 0x85, 0x39, 0x07, 0x53, 0x9c, 0x3d, 0x98,
+	0x52, 0x72, 0x30, 0x77, 0x62, 0x4f, 0x29, 0x4e, 0x4b, 0x79, 0xc6, 0xe4, 0x68, 0xca, 0x57, 0x1c,
+	0x4d, 0x45, 0x3f, 0x1a, 0xfd, 0x8a, 0xa2, 0xba, 0xfc, 0x15, 0xc5, 0xcf, 0xc0, 0x74, 0xf4, 0xb9,
+	0x7b, 0x3a, 0xe0, 0x54, 0xbb, 0xc0, 0xd8, 0x63, 0x9e, 0x39, 0xc7, 0x60, 0x10, 0x6b, 0xa4, 0x2a,
+	0x44, 0x95, 0x10, 0xaa, 0xf2, 0x4b, 0x3b, 0xf0, 0x1c, 0xef, 0x4c, 0x06, 0x09, 0x0a, 0x14, 0x65,
+	0x3e, 0xb2, 0xe2, 0x52, 0x6b, 0x15, 0x28, 0xd8, 0x22, 0x6f, 0x1d, 0xaa, 0x16, 0x7d, 0x9b, 0x5f,
+	0xe9, 0xab, 0x20, 0x0b, 0xfc, 0x11, 0xd6, 0x30, 0x70, 0x3d, 0xea, 0xcc, 0xa8, 0x90, 0x9f, 0x5e,
+	0xaa, 0xa5, 0x48, 0xae, 0x5a, 0x9f, 0xf9, 0xc7, 0x39, 0x56, 0xdb, 0x87, 0xd3, 0x55, 0x2f, 0x3e,
+	0xee, 0x81, 0x24, 0x5c, 0x9d, 0xe3, 0xa8, 0x36, 0xe3, 0x37, 0x18, 0xc3, 0x53, 0x6d, 0x81, 0x4b,
+	0x78, 0xc1, 0x97, 0xf0, 0x8c, 0x1a, 0x35, 0x8a, 0xb5, 0xcb, 0x4f, 0x97, 0xd1, 0x69, 0xa2, 0x33,
+	0x3f, 0x67, 0xeb, 0xda, 0x0a, 0x49, 0x5e, 0x3f, 0x9c, 0x29, 0x3c, 0x51, 0xae, 0xa4, 0x91, 0x69,
+	0xc5, 0xa7, 0x7f, 0x02, 0xff, 0x05, 0x3c, 0x03, 0xff, 0x47, 0x8e, 0x46, 0xc4, 0xc0, 0x7a, 0x64,
+	0x97, 0xcb, 0x44, 0x76, 0x32, 0x2b, 0xc8, 0xcf, 0xc9, 0x0a, 0x0a, 0x5a, 0x56, 0x80, 0x3c, 0x15,
+	0x2f, 0xe7, 0xe8, 0x00, 0x81, 0xa7, 0x02, 0xd2, 0x12, 0x83, 0x92, 0xe4, 0xb5, 0x48, 0x0c, 0x30,
+	0x45, 0x96, 0x11, 0xe2, 0x9e, 0xef, 0x71, 0x59, 0x03, 0x4d, 0xe1, 0x50, 0x48, 0xf9, 0xab, 0x89,
+	0x13, 0xf0, 0x70, 0x89, 0x7b, 0x52, 0x45, 0x6a, 0xfe, 0x5d, 0x8e, 0x6d, 0x68, 0x5b, 0xc4, 0x34,
+	0x61, 0x4a, 0xa9, 0x40, 0xe0, 0xbb, 0xb1, 0x9c, 0xe2, 0x37, 0x55, 0xdd, 0x02, 0x67, 0x6c, 0x07,
+	0x97, 0x32, 0xc2, 0x57, 0x20, 0xa9, 0xb4, 0x0f, 0x1c, 0x1b, 0xaa, 0x37, 0x07, 0x90, 0x67, 0xc5,
+	0x88, 0x14, 0xbf, 0x8a, 0x19, 0x7e, 0xe1, 0x83, 0x3f, 0x3c, 0xde, 0x09, 0x2c, 0x60, 0x29, 0x57,
+	0xa3, 0x93, 0xe3, 0xbc, 0xa7, 0x3e, 0x3e, 0x0d, 0x51, 0x65, 0xe1, 0x35, 0x2b, 0x41, 0xa0, 0x3d,
+	0x2d, 0x0d, 0x50, 0x7f, 0xff, 0x2f, 0x2e, 0x73, 0xa2, 0xdd, 0xcd, 0xcb, 0xdb, 0xba, 0x3b, 0xe9,
+	0x4b, 0xdd, 0xf8, 0x6e, 0x0f, 0xc2, 0x5d, 0xfe, 0x8a, 0x0f, 0xa7, 0xcb, 0xf9, 0xcc, 0x98, 0xd6,
+	0xfc, 0x14, 0x92, 0xb9, 0x4b, 0x2f, 0x2e, 0x10, 0x6b, 0xf7, 0x66, 0xb9, 0x05, 0xf7, 0x66, 0xdf,
+	0x67, 0x35, 0x8a, 0xe5, 0xf7, 0x9c, 0x33, 0xf9, 0xd2, 0x2d, 0xf0, 0xfd, 0x48, 0x45, 0x73, 0xf8,
+	0x8d, 0x0b, 0x95, 0xa9, 0x84, 0x70, 0x0f, 0xaa, 0xda, 0xfb, 0x9b, 0x6c, 0xe3, 0x4b, 0xc7, 0x75,
+	0xfb, 0x2f, 0x9d, 0x28, 0x79, 0x04, 0x77, 0x7f, 0x36, 0xa8, 0xc1, 0xb2, 0xf9, 0xeb, 0x42, 0x3a,
+	0xa4, 0xd9, 0x7a, 0xc6, 0x4a, 0xf4, 0xd8, 0xcc, 0xa8, 0xb0, 0x62, 0xef, 0xa8, 0xd3, 0x6d, 0xdc,
+	0x30, 0x18, 0x5b, 0x39, 0xe8, 0xb5, 0xbf, 0xec, 0xec, 0x35, 0x72, 0xc0, 0xb2, 0xc6, 0x51, 0xcb,
+	0x1a, 0xec, 0xb7, 0x0e, 0x0e, 0x9e, 0x3d, 0x7f, 0xbc, 0x7f, 0x70, 0x00, 0xd8, 0x3c, 0x52, 0xc8,
+	0xef, 0x82, 0x51, 0x63, 0xe5, 0x7e, 0x67, 0x30, 0x40, 0xa0, 0x88, 0x40, 0x6b, 0xb7, 0x67, 0x0d,
+	0x00, 0x28, 0x6d, 0xfd, 0x7b, 0x8e, 0x55, 0xe3, 0x67, 0x1c, 0xd8, 0xa7, 0x6d, 0x75, 0x5a, 0x83,
+	0x8e, 0x98, 0x61, 0xaf, 0x73, 0xd0, 0x81, 0xef, 0x1c, 0xce, 0x8b, 0xb3, 0x89, 0x51, 0x8f, 0xbb,
+	0xf4, 0x5d, 0x00, 0xc5, 0x5b, 0xed, 0x3f, 0xeb, 0xb6, 0x9f, 0x5b, 0x9d, 0x1f, 0x1d, 0x77, 0xfa,
+	0x03, 0x18, 0x3a, 0xc1, 0xb4, 0x3b, 0xfb, 0x5f, 0x75, 0x1a, 0x25, 0xc8, 0x6f, 0xd8, 0x61, 0xe7,
+	0x70, 0xb7, 0x63, 0xf5, 0x9f, 0xee, 0x1f, 0x35, 0x56, 0x8c, 0xb7, 0xd8, 0xcd, 0xfd, 0xbd, 0x4e,
+	0x77, 0xb0, 0x3f, 0x78, 0xf6, 0x7c, 0x60, 0xb5, 0xba, 0xfd, 0xfd, 0xc1, 0x7e, 0xaf, 0xdb, 0x28,
+	0xe3, 0x14, 0xb8, 0xdc, 0x46, 0x05, 0xd8, 0x5a, 0x6f, 0x3f, 0x6d, 0x75, 0xbb, 0x9d, 0x83, 0xe7,
+	0xed, 0x5e, 0xf7, 0xf1, 0xfe, 0x93, 0x46, 0x15, 0xa7, 0xb5, 0x3a, 0x87, 0x3d, 0x18, 0x92, 0xd1,
+	0x22, 0x5b, 0xdd, 0xbd, 0x83, 0x4e, 0xa3, 0x16, 0x4f, 0xf8, 0x74, 0xbf, 0x3f, 0xe8, 0x59, 0xcf,
+	0x1a, 0xab, 0x88, 0xe9, 0x59, 0x7b, 0x1d, 0xeb, 0xf9, 0xde, 0xfe, 0x13, 0x5c, 0xd4, 0x1a, 0x2e,
+	0x41, 0x6c, 0xe4, 0x39, 0x30, 0xa8, 0x51, 0xdf, 0xfa, 0x1d, 0xb6, 0x9e, 0xb9, 0x78, 0x16, 0xc3,
+	0xf7, 0x8f, 0x0f, 0x71, 0xdf, 0x40, 0x8e, 0xfb, 0x7b, 0x4e, 0xa3, 0xc0, 0xde, 0x81, 0x5d, 0x47,
+	0x56, 0xef, 0xa8, 0xd7, 0xef, 0x88, 0xed, 0xb7, 0xda, 0xed, 0xce, 0xd1, 0x00, 0xb6, 0x4f, 0x9d,
+	0x7e, 0xd8, 0x69, 0xe3, 0xc6, 0x57, 0x59, 0xe5, 0xf1, 0x7e, 0xb7, 0x75, 0xb0, 0xff, 0x63, 0xd8,
+	0xf4, 0x56, 0x9b, 0xb1, 0x24, 0x35, 0x34, 0xd6, 0x41, 0x6a, 0x68, 0x45, 0xad, 0xbd, 0x3d, 0xe0,
+	0xf9, 0x0d, 0x63, 0x83, 0xad, 0x09, 0x04, 0x6e, 0xf3, 0x09, 0x1d, 0x61, 0x8c, 0x12, 0xbb, 0x84,
+	0xf3, 0xdb, 0xfa, 0x2d, 0x56, 0x8d, 0xeb, 0xb4, 0xc6, 0x6d, 0xb6, 0x71, 0xdc, 0xfd, 0xb2, 0xdb,
+	0xfb, 0xba, 0x0b, 0xfb, 0x02, 0xee, 0x12, 0xd3, 0x6e, 0xe0, 0xda, 0xf6, 0xbb, 0xbb, 0xbd, 0xe3,
+	0x2e, 0x8e, 0x01, 0x6b, 0xe8, 0x1d, 0x0f, 0x04, 0x94, 0xdf, 0x32, 0x59, 0x11, 0x9f, 0xa7, 0x18,
+	0x65, 0x56, 0x68, 0x75, 0x9f, 0x01, 0x2d, 0x7c, 0xec, 0x1e, 0x3f, 0x13, 0x87, 0xd9, 0xef, 0x00,
+	0x27, 0xf2, 0x5b, 0x77, 0x59, 0x4d, 0xab, 0x57, 0x61, 0xc3, 0xd3, 0x4e, 0xeb, 0x48, 0xd0, 0xb6,
+	0x8f, 0x8e, 0x1b, 0xb9, 0xad, 0x6d, 0x56, 0x96, 0x5a, 0x40, 0xdb, 0x00, 0xd9, 0x13, 0x7c, 0xe9,
+	0x03, 0x11, 0x90, 0x77, 0x7b, 0x5d, 0x29, 0x1e, 0x8f, 0x8f, 0x71, 0xc4, 0x9d, 0x7f, 0x58, 0x01,
+	0xf6, 0xd3, 0xad, 0x06, 0x05, 0x21, 0x81, 0xf1, 0x10, 0x0e, 0x8b, 0x02, 0x55, 0x43, 0x3c, 0x03,
+	0xd4, 0x1f, 0x88, 0x6c, 0x1a, 0x3a, 0x2a, 0xbe, 0x7d, 0x59, 0xd9, 0x13, 0x76, 0xb9, 0x19, 0xe7,
+	0xce, 0x99, 0xfb, 0x9c, 0x4d, 0xca, 0xaa, 0x29, 0x6d, 0x03, 0xd7, 0x52, 0x3c, 0xf0, 0x87, 0x17,
+	0xcb, 0x11, 0xc3, 0xd8, 0xc7, 0x9e, 0xbb, 0x34, 0xf9, 0x43, 0x56, 0x79, 0xc2, 0x23, 0xf1, 0x84,
+	0xfd, 0x9a, 0x0e, 0x82, 0xe8, 0x13, 0xb6, 0x0a, 0x1d, 0x5a, 0xae, 0x2b, 0x0b, 0xa8, 0xb7, 0xe2,
+	0x26, 0xad, 0x72, 0xb7, 0xb9, 0x96, 0xc2, 0x1a, 0xdf, 0xa7, 0x4e, 0x71, 0xa1, 0xc3, 0xd8, 0xd4,
+	0x3c, 0x78, 0x76, 0xae, 0x4c, 0xd7, 0x3d, 0xb6, 0xae, 0xba, 0xaa, 0x53, 0x7a, 0x2b, 0xa6, 0x48,
+	0xdf, 0xc6, 0x6e, 0x36, 0x67, 0x1b, 0x24, 0xc7, 0xbf, 0x60, 0x55, 0xa5, 0x0f, 0x60, 0x68, 0x33,
+	0x2f, 0x20, 0x64, 0xe6, 0xba, 0x79, 0x05, 0xfe, 0x41, 0xee, 0x7b, 0x39, 0xd8, 0x76, 0xdd, 0xf2,
+	0xd1, 0x3e, 0xa9, 0x47, 0x75, 0x46, 0xc2, 0x44, 0xd1, 0x71, 0xce, 0x6b, 0xbb, 0x07, 0x8c, 0x89,
+	0xc8, 0x88, 0x5e, 0x70, 0xaf, 0xc7, 0x0f, 0x91, 0x67, 0xb9, 0xba, 0xc5, 0x56, 0xc4, 0xdb, 0x61,
+	0x21, 0x42, 0xa9, 0x77, 0xc4, 0x59, 0x8e, 0x3c, 0x61, 0x86, 0x7c, 0x26, 0x76, 0xc2, 0x97, 0x63,
+	0xe9, 0xcd, 0x78, 0x80, 0xa4, 0xcc, 0x04, 0x7b, 0xfa, 0x00, 0x94, 0x1b, 0x93, 0x3f, 0x08, 0xef,
+	0x90, 0x20, 0x9d, 0x8d, 0x6e, 0xd6, 0x34, 0x1c, 0xf8, 0xa0, 0xaa, 0x90, 0x58, 0x38, 0xf8, 0x37,
+	0x39, 0x3d, 0x88, 0xe9, 0x13, 0x97, 0x60, 0x50, 0xf5, 0x70, 0xc6, 0x45, 0x64, 0xfa, 0xec, 0xfc,
+	0xbc, 0x10, 0xbf, 0xdc, 0x50, 0x1a, 0xf6, 0x01, 0x2b, 0x62, 0xf1, 0x5b, 0xb0, 0x50, 0x7b, 0x9e,
+	0xb2, 0xd9, 0x48, 0x10, 0xf2, 0xa4, 0xb7, 0x59, 0xe9, 0x80, 0xdb, 0xb0, 0xa7, 0x45, 0xab, 0xd4,
+	0x14, 0xe0, 0xd7, 0x18, 0x03, 0xf9, 0x52, 0xb1, 0xe6, 0xa2, 0x4e, 0x7a, 0xd8, 0x09, 0xa1, 0x6e,
+	0x5d, 0xa8, 0x41, 0x5b, 0x5d, 0x44, 0x69, 0xf2, 0xb0, 0xae, 0x51, 0xca, 0x4a, 0x12, 0xeb, 0xf3,
+	0x48, 0x5d, 0x2b, 0xdf, 0xce, 0x3c, 0x2c, 0x9e, 0x37, 0xfe, 0x23, 0xb6, 0x76, 0x84, 0x05, 0x92,
+	0xf0, 0x5c, 0x3e, 0xb4, 0x6d, 0xce, 0xbe, 0x30, 0x9e, 0xd7, 0xef, 0x63, 0x52, 0x17, 0x2d, 0xea,
+	0x4c, 0x2d, 0xec, 0x66, 0x26, 0x24, 0xa5, 0xc5, 0x3d, 0x42, 0x31, 0xc0, 0x9b, 0x82, 0x85, 0xbb,
+	0x9f, 0xe1, 0xf4, 0xce, 0x5f, 0x40, 0x9c, 0x8e, 0x17, 0x52, 0xea, 0x90, 0xb6, 0x59, 0x4d, 0xb0,
+	0xe4, 0x88, 0x6e, 0x9b, 0xb4, 0x69, 0x6f, 0xa9, 0xeb, 0xa8, 0xd4, 0x6d, 0xeb, 0xfb, 0x6c, 0x6d,
+	0xd7, 0xb5, 0x87, 0x17, 0x78, 0xf9, 0x44, 0xff, 0x5c, 0x53, 0x51, 0x64, 0xfa, 0xf9, 0xdc, 0xa7,
+	0x51, 0xe3, 0x8b, 0x2f, 0x6d, 0xd4, 0x55, 0x52, 0x57, 0xd5, 0xb0, 0x45, 0x86, 0x6c, 0x66, 0xea,
+	0x9b, 0x99, 0xdb, 0x34, 0x92, 0xb0, 0x1f, 0xb3, 0x55, 0x7a, 0x43, 0xa2, 0x56, 0x7e, 0x97, 0x55,
+	0x2c, 0x7e, 0x86, 0x77, 0x60, 0x81, 0x91, 0xbc, 0x30, 0xd9, 0x4c, 0x3e, 0x41, 0x93, 0xa5, 0xd5,
+	0x6b, 0x89, 0x77, 0x37, 0xda, 0x0c, 0x6b, 0x31, 0x15, 0x8d, 0xfd, 0x2f, 0x45, 0x18, 0x1c, 0xdf,
+	0x35, 0xa9, 0xc1, 0xef, 0xb3, 0x15, 0x71, 0xeb, 0x32, 0x23, 0x21, 0xda, 0x65, 0x0c, 0x68, 0xe3,
+	0x77, 0xb0, 0x14, 0x83, 0x56, 0x8b, 0x1b, 0xd9, 0x56, 0x8d, 0x1f, 0x0f, 0x72, 0x60, 0x4c, 0xeb,
+	0x6d, 0x7b, 0x82, 0x15, 0x0d, 0xe9, 0xd8, 0x84, 0xfa, 0xa6, 0xef, 0x6d, 0xe4, 0xc6, 0x33, 0x57,
+	0x2f, 0xbf, 0xce, 0xea, 0x9d, 0x57, 0x68, 0x90, 0x54, 0xf9, 0xd1, 0x20, 0xb2, 0x4c, 0x31, 0x72,
+	0xb3, 0x1e, 0x23, 0x29, 0x33, 0x81, 0xc5, 0x3d, 0x24, 0x71, 0x4f, 0x6a, 0x9b, 0x29, 0x0e, 0x18,
+	0xe9, 0x92, 0x28, 0x09, 0xd5, 0xe7, 0x22, 0xfa, 0xb7, 0x2f, 0xf5, 0x3e, 0xb7, 0x33, 0xb5, 0x53,
+	0xdd, 0x45, 0x66, 0xfa, 0x7f, 0x0a, 0xf1, 0xde, 0x34, 0x38, 0xe3, 0x4b, 0x74, 0xd7, 0x84, 0x65,
+	0x0b, 0x0b, 0x9c, 0x54, 0x16, 0x9c, 0x11, 0xbf, 0x99, 0x72, 0xe1, 0x07, 0xac, 0xa2, 0x32, 0xd3,
+	0x99, 0xcd, 0x64, 0xf2, 0xda, 0x6d, 0x7c, 0x6d, 0x2c, 0x52, 0x19, 0x3e, 0x33, 0x70, 0x36, 0x8f,
+	0x03, 0x6e, 0x7d, 0xc6, 0x6e, 0x01, 0xb7, 0x66, 0xb3, 0x1f, 0xad, 0xeb, 0xed, 0x4c, 0x57, 0x49,
+	0xf1, 0x21, 0x04, 0x62, 0x81, 0x3f, 0xf6, 0xd3, 0xf3, 0xcc, 0x27, 0xde, 0xf9, 0xc3, 0x5c, 0x7c,
+	0x7b, 0xa5, 0x84, 0x6d, 0x07, 0x42, 0x05, 0x64, 0xdf, 0x1d, 0xed, 0x9e, 0x46, 0xf7, 0xcb, 0x46,
+	0xfa, 0x3e, 0x4b, 0xda, 0xe8, 0x22, 0x5e, 0x54, 0xa5, 0xfa, 0x68, 0x37, 0x57, 0x42, 0xf3, 0xf5,
+	0x3b, 0x2a, 0xd8, 0x21, 0x46, 0x5e, 0x78, 0x43, 0x94, 0x15, 0x69, 0xed, 0xf6, 0x68, 0xe7, 0x92,
+	0x6d, 0x1c, 0xda, 0xc1, 0x05, 0x88, 0x8d, 0x1d, 0xd9, 0x49, 0xac, 0x44, 0xe6, 0x56, 0x94, 0x6f,
+	0x64, 0xbc, 0xa4, 0xd7, 0xa6, 0x84, 0xec, 0x69, 0x75, 0xa0, 0x4f, 0x58, 0x15, 0x3a, 0xc8, 0x1a,
+	0xcf, 0x22, 0x03, 0x45, 0xf5, 0x21, 0x41, 0x77, 0xb2, 0x42, 0x89, 0xd1, 0x27, 0xff, 0x03, 0x58,
+	0x00, 0x59, 0x0a, 0x03, 0x38, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OrderHandlerClient is the client API for OrderHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OrderHandlerClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error)
+	GetAllOrders(ctx context.Context, in *OrderListRequest, opts ...grpc.CallOption) (*OrderList, error)
+	GetOrderBook(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*OrderList, error)
+	GetOrderHistory(ctx context.Context, in *OrderHistoryRequest, opts ...grpc.CallOption) (*OrderHistoryResponse, error)
+	Negotiate(ctx context.Context, opts ...grpc.CallOption) (OrderHandler_NegotiateClient, error)
+	RotateIdentity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*IdentityTransition, error)
+	ReportFill(ctx context.Context, in *FillRequest, opts ...grpc.CallOption) (*Order, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*OrderList, error)
+	SubscribeOrderBook(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (OrderHandler_SubscribeOrderBookClient, error)
+	Remove(ctx context.Context, in *RemovalRequest, opts ...grpc.CallOption) (*Removal, error)
+	DeleteAll(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*OrderList, error)
+	KillSwitch(ctx context.Context, in *KillSwitchRequest, opts ...grpc.CallOption) (*OrderList, error)
+}
+
+type orderHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOrderHandlerClient(cc *grpc.ClientConn) OrderHandlerClient {
+	return &orderHandlerClient{cc}
+}
+
+func (c *orderHandlerClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Lock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetAllOrders(ctx context.Context, in *OrderListRequest, opts ...grpc.CallOption) (*OrderList, error) {
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetAllOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetOrderBook(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*OrderList, error) {
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrderBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) GetOrderHistory(ctx context.Context, in *OrderHistoryRequest, opts ...grpc.CallOption) (*OrderHistoryResponse, error) {
+	out := new(OrderHistoryResponse)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrderHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Negotiate(ctx context.Context, opts ...grpc.CallOption) (OrderHandler_NegotiateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OrderHandler_serviceDesc.Streams[0], "/pb.OrderHandler/Negotiate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderHandlerNegotiateClient{stream}
+	return x, nil
+}
+
+type OrderHandler_NegotiateClient interface {
+	Send(*NegotiationMessage) error
+	Recv() (*NegotiationMessage, error)
+	grpc.ClientStream
+}
+
+type orderHandlerNegotiateClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderHandlerNegotiateClient) Send(m *NegotiationMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *orderHandlerNegotiateClient) Recv() (*NegotiationMessage, error) {
+	m := new(NegotiationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *orderHandlerClient) RotateIdentity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*IdentityTransition, error) {
+	out := new(IdentityTransition)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/RotateIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) ReportFill(ctx context.Context, in *FillRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/ReportFill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*OrderList, error) {
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) SubscribeOrderBook(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (OrderHandler_SubscribeOrderBookClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OrderHandler_serviceDesc.Streams[1], "/pb.OrderHandler/SubscribeOrderBook", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderHandlerSubscribeOrderBookClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderHandler_SubscribeOrderBookClient interface {
+	Recv() (*OrderBookUpdate, error)
+	grpc.ClientStream
+}
+
+type orderHandlerSubscribeOrderBookClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderHandlerSubscribeOrderBookClient) Recv() (*OrderBookUpdate, error) {
+	m := new(OrderBookUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *orderHandlerClient) Remove(ctx context.Context, in *RemovalRequest, opts ...grpc.CallOption) (*Removal, error) {
+	out := new(Removal)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) DeleteAll(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*OrderList, error) {
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/DeleteAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderHandlerClient) KillSwitch(ctx context.Context, in *KillSwitchRequest, opts ...grpc.CallOption) (*OrderList, error) {
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, "/pb.OrderHandler/KillSwitch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderHandlerServer is the server API for OrderHandler service.
+type OrderHandlerServer interface {
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Delete(context.Context, *OrderSpecificRequest) (*Empty, error)
+	Lock(context.Context, *OrderSpecificRequest) (*Empty, error)
+	Unlock(context.Context, *OrderSpecificRequest) (*Empty, error)
+	GetOrder(context.Context, *OrderSpecificRequest) (*Order, error)
+	GetAllOrders(context.Context, *OrderListRequest) (*OrderList, error)
+	GetOrderBook(context.Context, *ChannelSpecificRequest) (*OrderList, error)
+	GetOrderHistory(context.Context, *OrderHistoryRequest) (*OrderHistoryResponse, error)
+	Negotiate(OrderHandler_NegotiateServer) error
+	RotateIdentity(context.Context, *Empty) (*IdentityTransition, error)
+	ReportFill(context.Context, *FillRequest) (*Order, error)
+	Search(context.Context, *SearchRequest) (*OrderList, error)
+	SubscribeOrderBook(*ChannelSpecificRequest, OrderHandler_SubscribeOrderBookServer) error
+	Remove(context.Context, *RemovalRequest) (*Removal, error)
+	DeleteAll(context.Context, *ChannelSpecificRequest) (*OrderList, error)
+	KillSwitch(context.Context, *KillSwitchRequest) (*OrderList, error)
+}
+
+// UnimplementedOrderHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedOrderHandlerServer struct {
+}
+
+func (*UnimplementedOrderHandlerServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Delete(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Lock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Unlock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetOrder(ctx context.Context, req *OrderSpecificRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetAllOrders(ctx context.Context, req *OrderListRequest) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllOrders not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetOrderBook(ctx context.Context, req *ChannelSpecificRequest) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
+}
+func (*UnimplementedOrderHandlerServer) GetOrderHistory(ctx context.Context, req *OrderHistoryRequest) (*OrderHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderHistory not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Negotiate(srv OrderHandler_NegotiateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Negotiate not implemented")
+}
+func (*UnimplementedOrderHandlerServer) RotateIdentity(ctx context.Context, req *Empty) (*IdentityTransition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateIdentity not implemented")
+}
+func (*UnimplementedOrderHandlerServer) ReportFill(ctx context.Context, req *FillRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportFill not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Search(ctx context.Context, req *SearchRequest) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedOrderHandlerServer) SubscribeOrderBook(req *ChannelSpecificRequest, srv OrderHandler_SubscribeOrderBookServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOrderBook not implemented")
+}
+func (*UnimplementedOrderHandlerServer) Remove(ctx context.Context, req *RemovalRequest) (*Removal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (*UnimplementedOrderHandlerServer) DeleteAll(ctx context.Context, req *ChannelSpecificRequest) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAll not implemented")
+}
+func (*UnimplementedOrderHandlerServer) KillSwitch(ctx context.Context, req *KillSwitchRequest) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillSwitch not implemented")
+}
+
+func RegisterOrderHandlerServer(s *grpc.Server, srv OrderHandlerServer) {
+	s.RegisterService(&_OrderHandler_serviceDesc, srv)
+}
+
+func _OrderHandler_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Delete(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Lock(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Unlock(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetOrder(ctx, req.(*OrderSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetAllOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetAllOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetAllOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetAllOrders(ctx, req.(*OrderListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetOrderBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetOrderBook(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).GetOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/GetOrderHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).GetOrderHistory(ctx, req.(*OrderHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Negotiate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OrderHandlerServer).Negotiate(&orderHandlerNegotiateServer{stream})
+}
+
+type OrderHandler_NegotiateServer interface {
+	Send(*NegotiationMessage) error
+	Recv() (*NegotiationMessage, error)
+	grpc.ServerStream
+}
+
+type orderHandlerNegotiateServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderHandlerNegotiateServer) Send(m *NegotiationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *orderHandlerNegotiateServer) Recv() (*NegotiationMessage, error) {
+	m := new(NegotiationMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _OrderHandler_RotateIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).RotateIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/RotateIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).RotateIdentity(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_ReportFill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).ReportFill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/ReportFill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).ReportFill(ctx, req.(*FillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_SubscribeOrderBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChannelSpecificRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderHandlerServer).SubscribeOrderBook(m, &orderHandlerSubscribeOrderBookServer{stream})
+}
+
+type OrderHandler_SubscribeOrderBookServer interface {
+	Send(*OrderBookUpdate) error
+	grpc.ServerStream
+}
+
+type orderHandlerSubscribeOrderBookServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderHandlerSubscribeOrderBookServer) Send(m *OrderBookUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _OrderHandler_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).Remove(ctx, req.(*RemovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_DeleteAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).DeleteAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/DeleteAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).DeleteAll(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderHandler_KillSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillSwitchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderHandlerServer).KillSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.OrderHandler/KillSwitch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderHandlerServer).KillSwitch(ctx, req.(*KillSwitchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OrderHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.OrderHandler",
+	HandlerType: (*OrderHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _OrderHandler_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _OrderHandler_Delete_Handler,
+		},
+		{
+			MethodName: "Lock",
+			Handler:    _OrderHandler_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _OrderHandler_Unlock_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderHandler_GetOrder_Handler,
+		},
+		{
+			MethodName: "GetAllOrders",
+			Handler:    _OrderHandler_GetAllOrders_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _OrderHandler_GetOrderBook_Handler,
+		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _OrderHandler_GetOrderHistory_Handler,
+		},
+		{
+			MethodName: "RotateIdentity",
+			Handler:    _OrderHandler_RotateIdentity_Handler,
+		},
+		{
+			MethodName: "ReportFill",
+			Handler:    _OrderHandler_ReportFill_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _OrderHandler_Search_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _OrderHandler_Remove_Handler,
+		},
+		{
+			MethodName: "DeleteAll",
+			Handler:    _OrderHandler_DeleteAll_Handler,
+		},
+		{
+			MethodName: "KillSwitch",
+			Handler:    _OrderHandler_KillSwitch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Negotiate",
+			Handler:       _OrderHandler_Negotiate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeOrderBook",
+			Handler:       _OrderHandler_SubscribeOrderBook_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sprawl.proto",
+}
+
+// ChannelHandlerClient is the client API for ChannelHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChannelHandlerClient interface {
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error)
+	GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error)
+	SetMembers(ctx context.Context, in *MembershipRequest, opts ...grpc.CallOption) (*Channel, error)
+	PublishConfig(ctx context.Context, in *ChannelConfigRequest, opts ...grpc.CallOption) (*Channel, error)
+	GetIdleChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*IdleChannelList, error)
+	Rejoin(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+}
+
+type channelHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChannelHandlerClient(cc *grpc.ClientConn) ChannelHandlerClient {
+	return &channelHandlerClient{cc}
+}
+
+func (c *channelHandlerClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Join", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error) {
+	out := new(Channel)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error) {
+	out := new(ChannelList)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetAllChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) SetMembers(ctx context.Context, in *MembershipRequest, opts ...grpc.CallOption) (*Channel, error) {
+	out := new(Channel)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/SetMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) PublishConfig(ctx context.Context, in *ChannelConfigRequest, opts ...grpc.CallOption) (*Channel, error) {
+	out := new(Channel)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/PublishConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) GetIdleChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*IdleChannelList, error) {
+	out := new(IdleChannelList)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetIdleChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelHandlerClient) Rejoin(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Rejoin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChannelHandlerServer is the server API for ChannelHandler service.
+type ChannelHandlerServer interface {
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	Leave(context.Context, *ChannelSpecificRequest) (*Empty, error)
+	GetChannel(context.Context, *ChannelSpecificRequest) (*Channel, error)
+	GetAllChannels(context.Context, *Empty) (*ChannelList, error)
+	SetMembers(context.Context, *MembershipRequest) (*Channel, error)
+	PublishConfig(context.Context, *ChannelConfigRequest) (*Channel, error)
+	GetIdleChannels(context.Context, *Empty) (*IdleChannelList, error)
+	Rejoin(context.Context, *ChannelSpecificRequest) (*JoinResponse, error)
+}
+
+// UnimplementedChannelHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedChannelHandlerServer struct {
+}
+
+func (*UnimplementedChannelHandlerServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedChannelHandlerServer) Leave(ctx context.Context, req *ChannelSpecificRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetChannel(ctx context.Context, req *ChannelSpecificRequest) (*Channel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannel not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetAllChannels(ctx context.Context, req *Empty) (*ChannelList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllChannels not implemented")
+}
+func (*UnimplementedChannelHandlerServer) SetMembers(ctx context.Context, req *MembershipRequest) (*Channel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMembers not implemented")
+}
+func (*UnimplementedChannelHandlerServer) PublishConfig(ctx context.Context, req *ChannelConfigRequest) (*Channel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishConfig not implemented")
+}
+func (*UnimplementedChannelHandlerServer) GetIdleChannels(ctx context.Context, req *Empty) (*IdleChannelList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdleChannels not implemented")
+}
+func (*UnimplementedChannelHandlerServer) Rejoin(ctx context.Context, req *ChannelSpecificRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rejoin not implemented")
+}
+
+func RegisterChannelHandlerServer(s *grpc.Server, srv ChannelHandlerServer) {
+	s.RegisterService(&_ChannelHandler_serviceDesc, srv)
+}
+
+func _ChannelHandler_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Join",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Join(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Leave(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_GetChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetChannel(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_GetAllChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetAllChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetAllChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetAllChannels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_SetMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).SetMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/SetMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).SetMembers(ctx, req.(*MembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_PublishConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).PublishConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/PublishConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).PublishConfig(ctx, req.(*ChannelConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_GetIdleChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).GetIdleChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/GetIdleChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).GetIdleChannels(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelHandler_Rejoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelHandlerServer).Rejoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChannelHandler/Rejoin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelHandlerServer).Rejoin(ctx, req.(*ChannelSpecificRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ChannelHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ChannelHandler",
+	HandlerType: (*ChannelHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    _ChannelHandler_Join_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _ChannelHandler_Leave_Handler,
+		},
+		{
+			MethodName: "GetChannel",
+			Handler:    _ChannelHandler_GetChannel_Handler,
+		},
+		{
+			MethodName: "GetAllChannels",
+			Handler:    _ChannelHandler_GetAllChannels_Handler,
+		},
+		{
+			MethodName: "SetMembers",
+			Handler:    _ChannelHandler_SetMembers_Handler,
+		},
+		{
+			MethodName: "PublishConfig",
+			Handler:    _ChannelHandler_PublishConfig_Handler,
+		},
+		{
+			MethodName: "GetIdleChannels",
+			Handler:    _ChannelHandler_GetIdleChannels_Handler,
+		},
+		{
+			MethodName: "Rejoin",
+			Handler:    _ChannelHandler_Rejoin_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sprawl.proto",
+}
+
+// NodeHandlerClient is the client API for NodeHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NodeHandlerClient interface {
+	GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error)
+	BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error)
+	GetNodeInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeInfo, error)
+	GetPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerDetailsList, error)
+}
+
+type nodeHandlerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNodeHandlerClient(cc *grpc.ClientConn) NodeHandlerClient {
+	return &nodeHandlerClient{cc}
+}
+
+func (c *nodeHandlerClient) GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error) {
+	out := new(PeerListResponse)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetAllPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/BlacklistPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) GetNodeInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeInfo, error) {
+	out := new(NodeInfo)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetNodeInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeHandlerClient) GetPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerDetailsList, error) {
+	out := new(PeerDetailsList)
+	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NodeHandlerServer is the server API for NodeHandler service.
+type NodeHandlerServer interface {
+	GetAllPeers(context.Context, *Empty) (*PeerListResponse, error)
+	BlacklistPeer(context.Context, *Peer) (*Empty, error)
+	GetNodeInfo(context.Context, *Empty) (*NodeInfo, error)
+	GetPeers(context.Context, *Empty) (*PeerDetailsList, error)
+}
+
+// UnimplementedNodeHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedNodeHandlerServer struct {
+}
+
+func (*UnimplementedNodeHandlerServer) GetAllPeers(ctx context.Context, req *Empty) (*PeerListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllPeers not implemented")
+}
+func (*UnimplementedNodeHandlerServer) BlacklistPeer(ctx context.Context, req *Peer) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlacklistPeer not implemented")
+}
+func (*UnimplementedNodeHandlerServer) GetNodeInfo(ctx context.Context, req *Empty) (*NodeInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
+}
+func (*UnimplementedNodeHandlerServer) GetPeers(ctx context.Context, req *Empty) (*PeerDetailsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeers not implemented")
+}
+
+func RegisterNodeHandlerServer(s *grpc.Server, srv NodeHandlerServer) {
+	s.RegisterService(&_NodeHandler_serviceDesc, srv)
+}
+
+func _NodeHandler_GetAllPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeHandlerServer).GetAllPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeHandler/GetAllPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeHandlerServer).GetAllPeers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeHandler_BlacklistPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Peer)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeHandlerServer).BlacklistPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeHandler/BlacklistPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeHandlerServer).BlacklistPeer(ctx, req.(*Peer))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeHandler_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeHandlerServer).GetNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeHandler/GetNodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeHandlerServer).GetNodeInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeHandler_GetPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeHandlerServer).GetPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NodeHandler/GetPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeHandlerServer).GetPeers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func init() { proto.RegisterFile("sprawl.proto", fileDescriptor_b5e409e9578376a3) }
+var _NodeHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.NodeHandler",
+	HandlerType: (*NodeHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAllPeers",
+			Handler:    _NodeHandler_GetAllPeers_Handler,
+		},
+		{
+			MethodName: "BlacklistPeer",
+			Handler:    _NodeHandler_BlacklistPeer_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _NodeHandler_GetNodeInfo_Handler,
+		},
+		{
+			MethodName: "GetPeers",
+			Handler:    _NodeHandler_GetPeers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sprawl.proto",
+}
 
-var fileDescriptor_b5e409e9578376a3 = []byte{
-	// 886 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xef, 0x6e, 0xe3, 0x44,
-	0x10, 0xc7, 0x8e, 0x9d, 0xc4, 0x93, 0x3f, 0xe7, 0x5b, 0xaa, 0xca, 0x8a, 0x40, 0x17, 0x16, 0x24,
-	0xcc, 0x5d, 0xcf, 0x15, 0x01, 0xee, 0x23, 0xa8, 0xa4, 0x56, 0x38, 0x08, 0x69, 0x71, 0x5b, 0x10,
-	0x1f, 0x10, 0xda, 0xda, 0x73, 0xc5, 0x9c, 0x63, 0x1b, 0x7b, 0x0b, 0xe2, 0x41, 0x78, 0x04, 0x78,
-	0x1e, 0x1e, 0x09, 0xed, 0xae, 0xed, 0xd8, 0x2d, 0xca, 0xf5, 0x9b, 0x67, 0xe6, 0xf7, 0x9b, 0xd9,
-	0x99, 0xfd, 0xcd, 0x26, 0x30, 0x2e, 0xf3, 0x82, 0xfd, 0x91, 0x78, 0x79, 0x91, 0xf1, 0x8c, 0xe8,
-	0xf9, 0xf5, 0xec, 0xc9, 0x4d, 0x96, 0xdd, 0x24, 0x78, 0x2c, 0x3d, 0xd7, 0xb7, 0xaf, 0x8e, 0x79,
-	0xbc, 0xc5, 0x92, 0xb3, 0x6d, 0xae, 0x40, 0xf4, 0x10, 0x8c, 0x73, 0xc4, 0x82, 0x4c, 0x41, 0x8f,
-	0x23, 0x47, 0x9b, 0x6b, 0xae, 0x15, 0xe8, 0x71, 0x44, 0xff, 0xd1, 0xc1, 0x3c, 0x2b, 0xa2, 0x4e,
-	0x64, 0x2c, 0x22, 0xe4, 0x53, 0x18, 0x84, 0x05, 0x32, 0x8e, 0x91, 0xa3, 0xcf, 0x35, 0x77, 0xb4,
-	0x98, 0x79, 0xaa, 0x88, 0x57, 0x17, 0xf1, 0x2e, 0xeb, 0x22, 0x41, 0x0d, 0x25, 0x07, 0x60, 0xb2,
-	0xb2, 0x44, 0xee, 0xf4, 0x64, 0x09, 0x65, 0x10, 0x0a, 0xe3, 0x30, 0xbb, 0x4d, 0x39, 0x16, 0x27,
-	0x32, 0x68, 0xc8, 0x60, 0xc7, 0x47, 0x0e, 0xa1, 0xcf, 0xb6, 0xc2, 0xe1, 0x98, 0x73, 0xcd, 0x35,
-	0x82, 0xca, 0x12, 0x19, 0xf3, 0x22, 0x0e, 0xd1, 0xe9, 0xcf, 0x35, 0x57, 0x0f, 0x94, 0x41, 0x9e,
-	0x80, 0x59, 0x72, 0xc6, 0xd1, 0x19, 0xcc, 0x35, 0x77, 0xba, 0xb0, 0xbc, 0xfc, 0xda, 0xbb, 0x10,
-	0x8e, 0x40, 0xf9, 0xc9, 0x3b, 0x60, 0x95, 0xf1, 0x4d, 0xca, 0xf8, 0x6d, 0x81, 0xce, 0x50, 0x76,
-	0xb5, 0x73, 0x88, 0xa4, 0x69, 0x96, 0x86, 0xe8, 0x58, 0x73, 0xcd, 0x9d, 0x04, 0xca, 0x20, 0x33,
-	0x18, 0x6e, 0x91, 0xb3, 0x88, 0x71, 0xe6, 0x80, 0xa4, 0x34, 0x36, 0xf5, 0xc0, 0x92, 0x73, 0x5a,
-	0xc7, 0x25, 0x27, 0xef, 0x41, 0x3f, 0x13, 0x46, 0xe9, 0x68, 0xf3, 0x9e, 0x3b, 0x52, 0xe5, 0x65,
-	0x38, 0xa8, 0x02, 0x74, 0x05, 0x83, 0xe5, 0x2f, 0x2c, 0x4d, 0x31, 0xb9, 0x37, 0xd9, 0x23, 0x18,
-	0x64, 0x39, 0x8f, 0xb3, 0xb4, 0xac, 0x26, 0x4b, 0x04, 0xbd, 0x42, 0x9f, 0xa9, 0x48, 0x50, 0x43,
-	0xe8, 0x0b, 0x18, 0x55, 0x21, 0x59, 0xfa, 0x43, 0x18, 0x86, 0xca, 0xac, 0x8b, 0x8f, 0x5a, 0xec,
-	0xa0, 0x09, 0xd2, 0xf7, 0xc1, 0x0a, 0x30, 0x8c, 0xf3, 0x18, 0x53, 0x39, 0xdc, 0x1c, 0xb1, 0x78,
-	0x79, 0x5a, 0x1d, 0xa3, 0xb2, 0x68, 0x02, 0xa3, 0x1f, 0xe2, 0x02, 0xbf, 0xc5, 0xb2, 0x64, 0x37,
-	0x72, 0x68, 0x15, 0xbf, 0x41, 0xee, 0x1c, 0xe4, 0x19, 0x58, 0x59, 0x8e, 0x05, 0x13, 0xe7, 0x92,
-	0x27, 0x9f, 0x2e, 0x26, 0xb2, 0xf1, 0xda, 0x19, 0xec, 0xe2, 0x84, 0x80, 0x21, 0xe7, 0xd8, 0x93,
-	0x59, 0xe4, 0x37, 0xfd, 0x4b, 0x83, 0xc9, 0x52, 0x0a, 0x25, 0xc0, 0xdf, 0x6e, 0xb1, 0xe4, 0x6f,
-	0x28, 0xd8, 0x88, 0x49, 0xdf, 0x27, 0xa6, 0xde, 0x5e, 0x31, 0x19, 0xff, 0x2f, 0x26, 0xb3, 0x25,
-	0x26, 0xba, 0x82, 0xd1, 0xd7, 0x59, 0x9c, 0xd6, 0x87, 0x6a, 0xca, 0x6a, 0xfb, 0xca, 0xea, 0xf7,
-	0xcb, 0x52, 0x0f, 0xa6, 0xdd, 0x6b, 0x14, 0x0d, 0x4a, 0xfa, 0x39, 0x8b, 0x8b, 0x2a, 0xdf, 0xce,
-	0x41, 0x37, 0x70, 0x20, 0x55, 0x73, 0x91, 0x63, 0x18, 0xbf, 0x8a, 0xc3, 0xfa, 0x04, 0x0e, 0x0c,
-	0xa4, 0x8c, 0x9a, 0xa1, 0xd4, 0x66, 0x77, 0x60, 0xfa, 0x9d, 0x81, 0x51, 0x17, 0x0e, 0xab, 0xfa,
-	0x77, 0x33, 0xde, 0xd1, 0x20, 0xfd, 0x02, 0xa6, 0xf5, 0x4d, 0x94, 0x79, 0x96, 0x96, 0x48, 0x9e,
-	0xc3, 0xb8, 0x5a, 0x62, 0x79, 0x24, 0x89, 0xed, 0x28, 0xbb, 0x13, 0xa6, 0x2f, 0xe0, 0x71, 0xb3,
-	0x0f, 0x4d, 0x8e, 0x07, 0xec, 0xc5, 0xe7, 0xf0, 0x76, 0x4b, 0xce, 0x0d, 0xf3, 0xc1, 0xb2, 0x3e,
-	0x02, 0x5b, 0x3c, 0x64, 0x1d, 0xb2, 0x03, 0x03, 0xa5, 0x67, 0xc5, 0xb5, 0x82, 0xda, 0xa4, 0x27,
-	0x30, 0x56, 0x37, 0x5b, 0x21, 0x3f, 0x86, 0xc9, 0xaf, 0x59, 0x9c, 0x62, 0x54, 0x25, 0xae, 0xba,
-	0xec, 0xd4, 0xea, 0x22, 0xe8, 0x00, 0x4c, 0x7f, 0x9b, 0xf3, 0x3f, 0x9f, 0xbe, 0x0b, 0xa6, 0x7c,
-	0x61, 0xc8, 0x10, 0x8c, 0xb3, 0x73, 0x7f, 0x63, 0xbf, 0x45, 0x00, 0xfa, 0xeb, 0xb3, 0xe5, 0x37,
-	0xfe, 0xa9, 0xad, 0x3d, 0xfd, 0x09, 0xac, 0x66, 0x11, 0x44, 0x60, 0x19, 0xf8, 0x27, 0x97, 0xbe,
-	0x02, 0x9d, 0xfa, 0x6b, 0xff, 0xd2, 0xb7, 0x35, 0x41, 0x15, 0x04, 0x5b, 0x17, 0xde, 0xab, 0x8d,
-	0xfc, 0xee, 0x11, 0x1b, 0xc6, 0x17, 0x3f, 0x6e, 0x96, 0x3f, 0x07, 0xfe, 0x77, 0x57, 0xfe, 0xc5,
-	0xa5, 0x6d, 0xb4, 0x3c, 0x4b, 0xff, 0xe5, 0xf7, 0xbe, 0x6d, 0x2e, 0xfe, 0xd6, 0x61, 0x2c, 0x27,
-	0xf9, 0x15, 0x4b, 0xa3, 0x04, 0x0b, 0x72, 0x0c, 0x7d, 0x75, 0x83, 0xe4, 0xb1, 0x3c, 0x7d, 0x7b,
-	0xaf, 0x66, 0xa4, 0xed, 0x6a, 0x2e, 0xb8, 0x7f, 0x8a, 0x09, 0x72, 0x24, 0x4e, 0x73, 0x2d, 0x77,
-	0x64, 0x32, 0x93, 0x17, 0x26, 0xdb, 0x25, 0xcf, 0xc0, 0x58, 0x67, 0xe1, 0xeb, 0x87, 0x81, 0x9f,
-	0x43, 0xff, 0x2a, 0x4d, 0x1e, 0x0c, 0x3f, 0x86, 0xe1, 0x0a, 0xb9, 0xfa, 0xdd, 0x79, 0x03, 0x41,
-	0x81, 0x5c, 0x18, 0xaf, 0x90, 0x9f, 0x24, 0x89, 0x34, 0x4b, 0xb2, 0xcb, 0x35, 0x9b, 0x34, 0x28,
-	0xa1, 0x89, 0xc5, 0xbf, 0x5a, 0xb3, 0x83, 0xf5, 0xa4, 0x3e, 0x02, 0x43, 0x88, 0x80, 0x3c, 0x12,
-	0xc8, 0xd6, 0xa2, 0xcf, 0xec, 0x9d, 0xa3, 0x9a, 0x91, 0x07, 0xe6, 0x1a, 0xd9, 0xef, 0x48, 0x66,
-	0x2d, 0x45, 0xec, 0x69, 0xe4, 0x33, 0x80, 0x15, 0xf2, 0xfa, 0xa1, 0xdf, 0x47, 0x6a, 0x4b, 0x8c,
-	0x1c, 0xc1, 0x54, 0xb5, 0x53, 0x39, 0x3a, 0x0d, 0x3d, 0x6a, 0x21, 0x65, 0x4b, 0x21, 0x8c, 0x36,
-	0x59, 0x84, 0x75, 0x3b, 0x1e, 0x8c, 0x14, 0x59, 0xec, 0x41, 0x87, 0x79, 0x20, 0x3e, 0xef, 0x6d,
-	0xc7, 0x07, 0x30, 0xf9, 0x32, 0x61, 0xe1, 0xeb, 0x24, 0x2e, 0xb9, 0xfc, 0x0f, 0x30, 0xac, 0x61,
-	0xad, 0x4e, 0xae, 0xfb, 0xf2, 0x57, 0xfd, 0x93, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xed, 0x2f,
-	0x3b, 0x90, 0x5c, 0x08, 0x00, 0x00,
+// AssetHandlerClient is the client API for AssetHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AssetHandlerClient interface {
+	Register(ctx context.Context, in *Asset, opts ...grpc.CallOption) (*Asset, error)
+	GetAllAssets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AssetList, error)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type assetHandlerClient struct {
+	cc *grpc.ClientConn
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func NewAssetHandlerClient(cc *grpc.ClientConn) AssetHandlerClient {
+	return &assetHandlerClient{cc}
+}
 
-// OrderHandlerClient is the client API for OrderHandler service.
+func (c *assetHandlerClient) Register(ctx context.Context, in *Asset, opts ...grpc.CallOption) (*Asset, error) {
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, "/pb.AssetHandler/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetHandlerClient) GetAllAssets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AssetList, error) {
+	out := new(AssetList)
+	err := c.cc.Invoke(ctx, "/pb.AssetHandler/GetAllAssets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetHandlerServer is the server API for AssetHandler service.
+type AssetHandlerServer interface {
+	Register(context.Context, *Asset) (*Asset, error)
+	GetAllAssets(context.Context, *Empty) (*AssetList, error)
+}
+
+// UnimplementedAssetHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedAssetHandlerServer struct {
+}
+
+func (*UnimplementedAssetHandlerServer) Register(ctx context.Context, req *Asset) (*Asset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (*UnimplementedAssetHandlerServer) GetAllAssets(ctx context.Context, req *Empty) (*AssetList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllAssets not implemented")
+}
+
+func RegisterAssetHandlerServer(s *grpc.Server, srv AssetHandlerServer) {
+	s.RegisterService(&_AssetHandler_serviceDesc, srv)
+}
+
+func _AssetHandler_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Asset)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetHandlerServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.AssetHandler/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetHandlerServer).Register(ctx, req.(*Asset))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetHandler_GetAllAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetHandlerServer).GetAllAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.AssetHandler/GetAllAssets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetHandlerServer).GetAllAssets(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AssetHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.AssetHandler",
+	HandlerType: (*AssetHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _AssetHandler_Register_Handler,
+		},
+		{
+			MethodName: "GetAllAssets",
+			Handler:    _AssetHandler_GetAllAssets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sprawl.proto",
+}
+
+// AdminHandlerClient is the client API for AdminHandler service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type OrderHandlerClient interface {
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
-	Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error)
-	GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error)
+type AdminHandlerClient interface {
+	Backup(ctx context.Context, in *Empty, opts ...grpc.CallOption) (AdminHandler_BackupClient, error)
+	Restore(ctx context.Context, opts ...grpc.CallOption) (AdminHandler_RestoreClient, error)
+	CaptureProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
+	ExportAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (AdminHandler_ExportAuditLogClient, error)
+	GetDeadLetters(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DeadLetterList, error)
+	ReplayDeadLetters(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*DeadLetterList, error)
+	PurgeDeadLetters(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*Empty, error)
+	Compact(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CompactionReport, error)
+	SelfTest(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SelfTestReport, error)
+	Replicate(ctx context.Context, in *Empty, opts ...grpc.CallOption) (AdminHandler_ReplicateClient, error)
+	GetReplicationStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplicationStatus, error)
+	Promote(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplicationStatus, error)
+}
+
+type adminHandlerClient struct {
+	cc *grpc.ClientConn
 }
 
-type orderHandlerClient struct {
-	cc *grpc.ClientConn
+func NewAdminHandlerClient(cc *grpc.ClientConn) AdminHandlerClient {
+	return &adminHandlerClient{cc}
 }
 
-func NewOrderHandlerClient(cc *grpc.ClientConn) OrderHandlerClient {
-	return &orderHandlerClient{cc}
+func (c *adminHandlerClient) Backup(ctx context.Context, in *Empty, opts ...grpc.CallOption) (AdminHandler_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminHandler_serviceDesc.Streams[0], "/pb.AdminHandler/Backup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminHandlerBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *orderHandlerClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
-	out := new(CreateResponse)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Create", in, out, opts...)
+type AdminHandler_BackupClient interface {
+	Recv() (*BackupChunk, error)
+	grpc.ClientStream
+}
+
+type adminHandlerBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminHandlerBackupClient) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminHandlerClient) Restore(ctx context.Context, opts ...grpc.CallOption) (AdminHandler_RestoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminHandler_serviceDesc.Streams[1], "/pb.AdminHandler/Restore", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminHandlerRestoreClient{stream}
+	return x, nil
+}
+
+type AdminHandler_RestoreClient interface {
+	Send(*BackupChunk) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type adminHandlerRestoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminHandlerRestoreClient) Send(m *BackupChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminHandlerRestoreClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminHandlerClient) CaptureProfile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error) {
+	out := new(ProfileResponse)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/CaptureProfile", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) Delete(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Delete", in, out, opts...)
+func (c *adminHandlerClient) ExportAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (AdminHandler_ExportAuditLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminHandler_serviceDesc.Streams[2], "/pb.AdminHandler/ExportAuditLog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminHandlerExportAuditLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminHandler_ExportAuditLogClient interface {
+	Recv() (*AuditEntry, error)
+	grpc.ClientStream
+}
+
+type adminHandlerExportAuditLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminHandlerExportAuditLogClient) Recv() (*AuditEntry, error) {
+	m := new(AuditEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminHandlerClient) GetDeadLetters(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DeadLetterList, error) {
+	out := new(DeadLetterList)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/GetDeadLetters", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) Lock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Lock", in, out, opts...)
+func (c *adminHandlerClient) ReplayDeadLetters(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*DeadLetterList, error) {
+	out := new(DeadLetterList)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/ReplayDeadLetters", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) Unlock(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
+func (c *adminHandlerClient) PurgeDeadLetters(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/Unlock", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/PurgeDeadLetters", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) GetOrder(ctx context.Context, in *OrderSpecificRequest, opts ...grpc.CallOption) (*Order, error) {
-	out := new(Order)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetOrder", in, out, opts...)
+func (c *adminHandlerClient) Compact(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CompactionReport, error) {
+	out := new(CompactionReport)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/Compact", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *orderHandlerClient) GetAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error) {
-	out := new(OrderList)
-	err := c.cc.Invoke(ctx, "/pb.OrderHandler/GetAllOrders", in, out, opts...)
+func (c *adminHandlerClient) SelfTest(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SelfTestReport, error) {
+	out := new(SelfTestReport)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/SelfTest", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// OrderHandlerServer is the server API for OrderHandler service.
-type OrderHandlerServer interface {
-	Create(context.Context, *CreateRequest) (*CreateResponse, error)
-	Delete(context.Context, *OrderSpecificRequest) (*Empty, error)
-	Lock(context.Context, *OrderSpecificRequest) (*Empty, error)
-	Unlock(context.Context, *OrderSpecificRequest) (*Empty, error)
-	GetOrder(context.Context, *OrderSpecificRequest) (*Order, error)
-	GetAllOrders(context.Context, *Empty) (*OrderList, error)
+func (c *adminHandlerClient) Replicate(ctx context.Context, in *Empty, opts ...grpc.CallOption) (AdminHandler_ReplicateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminHandler_serviceDesc.Streams[3], "/pb.AdminHandler/Replicate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminHandlerReplicateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminHandler_ReplicateClient interface {
+	Recv() (*ReplicationEntry, error)
+	grpc.ClientStream
+}
+
+type adminHandlerReplicateClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminHandlerReplicateClient) Recv() (*ReplicationEntry, error) {
+	m := new(ReplicationEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminHandlerClient) GetReplicationStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplicationStatus, error) {
+	out := new(ReplicationStatus)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/GetReplicationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminHandlerClient) Promote(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplicationStatus, error) {
+	out := new(ReplicationStatus)
+	err := c.cc.Invoke(ctx, "/pb.AdminHandler/Promote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminHandlerServer is the server API for AdminHandler service.
+type AdminHandlerServer interface {
+	Backup(*Empty, AdminHandler_BackupServer) error
+	Restore(AdminHandler_RestoreServer) error
+	CaptureProfile(context.Context, *ProfileRequest) (*ProfileResponse, error)
+	ExportAuditLog(*AuditLogRequest, AdminHandler_ExportAuditLogServer) error
+	GetDeadLetters(context.Context, *Empty) (*DeadLetterList, error)
+	ReplayDeadLetters(context.Context, *DeadLetterRequest) (*DeadLetterList, error)
+	PurgeDeadLetters(context.Context, *DeadLetterRequest) (*Empty, error)
+	Compact(context.Context, *Empty) (*CompactionReport, error)
+	SelfTest(context.Context, *Empty) (*SelfTestReport, error)
+	Replicate(*Empty, AdminHandler_ReplicateServer) error
+	GetReplicationStatus(context.Context, *Empty) (*ReplicationStatus, error)
+	Promote(context.Context, *Empty) (*ReplicationStatus, error)
+}
+
+// UnimplementedAdminHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminHandlerServer struct {
+}
+
+func (*UnimplementedAdminHandlerServer) Backup(req *Empty, srv AdminHandler_BackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (*UnimplementedAdminHandlerServer) Restore(srv AdminHandler_RestoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (*UnimplementedAdminHandlerServer) CaptureProfile(ctx context.Context, req *ProfileRequest) (*ProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureProfile not implemented")
+}
+func (*UnimplementedAdminHandlerServer) ExportAuditLog(req *AuditLogRequest, srv AdminHandler_ExportAuditLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditLog not implemented")
+}
+func (*UnimplementedAdminHandlerServer) GetDeadLetters(ctx context.Context, req *Empty) (*DeadLetterList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetters not implemented")
+}
+func (*UnimplementedAdminHandlerServer) ReplayDeadLetters(ctx context.Context, req *DeadLetterRequest) (*DeadLetterList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetters not implemented")
+}
+func (*UnimplementedAdminHandlerServer) PurgeDeadLetters(ctx context.Context, req *DeadLetterRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeadLetters not implemented")
+}
+func (*UnimplementedAdminHandlerServer) Compact(ctx context.Context, req *Empty) (*CompactionReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
+func (*UnimplementedAdminHandlerServer) SelfTest(ctx context.Context, req *Empty) (*SelfTestReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelfTest not implemented")
+}
+func (*UnimplementedAdminHandlerServer) Replicate(req *Empty, srv AdminHandler_ReplicateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+func (*UnimplementedAdminHandlerServer) GetReplicationStatus(ctx context.Context, req *Empty) (*ReplicationStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationStatus not implemented")
+}
+func (*UnimplementedAdminHandlerServer) Promote(ctx context.Context, req *Empty) (*ReplicationStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Promote not implemented")
+}
+
+func RegisterAdminHandlerServer(s *grpc.Server, srv AdminHandlerServer) {
+	s.RegisterService(&_AdminHandler_serviceDesc, srv)
+}
+
+func _AdminHandler_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminHandlerServer).Backup(m, &adminHandlerBackupServer{stream})
+}
+
+type AdminHandler_BackupServer interface {
+	Send(*BackupChunk) error
+	grpc.ServerStream
+}
+
+type adminHandlerBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminHandlerBackupServer) Send(m *BackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminHandler_Restore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminHandlerServer).Restore(&adminHandlerRestoreServer{stream})
+}
+
+type AdminHandler_RestoreServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*BackupChunk, error)
+	grpc.ServerStream
+}
+
+type adminHandlerRestoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminHandlerRestoreServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminHandlerRestoreServer) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AdminHandler_CaptureProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminHandlerServer).CaptureProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.AdminHandler/CaptureProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminHandlerServer).CaptureProfile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedOrderHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedOrderHandlerServer struct {
+func _AdminHandler_ExportAuditLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuditLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminHandlerServer).ExportAuditLog(m, &adminHandlerExportAuditLogServer{stream})
 }
 
-func (*UnimplementedOrderHandlerServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
-}
-func (*UnimplementedOrderHandlerServer) Delete(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
-}
-func (*UnimplementedOrderHandlerServer) Lock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+type AdminHandler_ExportAuditLogServer interface {
+	Send(*AuditEntry) error
+	grpc.ServerStream
 }
-func (*UnimplementedOrderHandlerServer) Unlock(ctx context.Context, req *OrderSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
-}
-func (*UnimplementedOrderHandlerServer) GetOrder(ctx context.Context, req *OrderSpecificRequest) (*Order, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+
+type adminHandlerExportAuditLogServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedOrderHandlerServer) GetAllOrders(ctx context.Context, req *Empty) (*OrderList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllOrders not implemented")
+
+func (x *adminHandlerExportAuditLogServer) Send(m *AuditEntry) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func RegisterOrderHandlerServer(s *grpc.Server, srv OrderHandlerServer) {
-	s.RegisterService(&_OrderHandler_serviceDesc, srv)
+func _AdminHandler_GetDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminHandlerServer).GetDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.AdminHandler/GetDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminHandlerServer).GetDeadLetters(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _AdminHandler_ReplayDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLetterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Create(ctx, in)
+		return srv.(AdminHandlerServer).ReplayDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Create",
+		FullMethod: "/pb.AdminHandler/ReplayDeadLetters",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Create(ctx, req.(*CreateRequest))
+		return srv.(AdminHandlerServer).ReplayDeadLetters(ctx, req.(*DeadLetterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _AdminHandler_PurgeDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLetterRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Delete(ctx, in)
+		return srv.(AdminHandlerServer).PurgeDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Delete",
+		FullMethod: "/pb.AdminHandler/PurgeDeadLetters",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Delete(ctx, req.(*OrderSpecificRequest))
+		return srv.(AdminHandlerServer).PurgeDeadLetters(ctx, req.(*DeadLetterRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _AdminHandler_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Lock(ctx, in)
+		return srv.(AdminHandlerServer).Compact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Lock",
+		FullMethod: "/pb.AdminHandler/Compact",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Lock(ctx, req.(*OrderSpecificRequest))
+		return srv.(AdminHandlerServer).Compact(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _AdminHandler_SelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).Unlock(ctx, in)
+		return srv.(AdminHandlerServer).SelfTest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/Unlock",
+		FullMethod: "/pb.AdminHandler/SelfTest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).Unlock(ctx, req.(*OrderSpecificRequest))
+		return srv.(AdminHandlerServer).SelfTest(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OrderSpecificRequest)
+func _AdminHandler_Replicate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminHandlerServer).Replicate(m, &adminHandlerReplicateServer{stream})
+}
+
+type AdminHandler_ReplicateServer interface {
+	Send(*ReplicationEntry) error
+	grpc.ServerStream
+}
+
+type adminHandlerReplicateServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminHandlerReplicateServer) Send(m *ReplicationEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminHandler_GetReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).GetOrder(ctx, in)
+		return srv.(AdminHandlerServer).GetReplicationStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/GetOrder",
+		FullMethod: "/pb.AdminHandler/GetReplicationStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).GetOrder(ctx, req.(*OrderSpecificRequest))
+		return srv.(AdminHandlerServer).GetReplicationStatus(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _OrderHandler_GetAllOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _AdminHandler_Promote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderHandlerServer).GetAllOrders(ctx, in)
+		return srv.(AdminHandlerServer).Promote(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.OrderHandler/GetAllOrders",
+		FullMethod: "/pb.AdminHandler/Promote",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderHandlerServer).GetAllOrders(ctx, req.(*Empty))
+		return srv.(AdminHandlerServer).Promote(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _OrderHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.OrderHandler",
-	HandlerType: (*OrderHandlerServer)(nil),
+var _AdminHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.AdminHandler",
+	HandlerType: (*AdminHandlerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Create",
-			Handler:    _OrderHandler_Create_Handler,
+			MethodName: "CaptureProfile",
+			Handler:    _AdminHandler_CaptureProfile_Handler,
 		},
 		{
-			MethodName: "Delete",
-			Handler:    _OrderHandler_Delete_Handler,
+			MethodName: "GetDeadLetters",
+			Handler:    _AdminHandler_GetDeadLetters_Handler,
 		},
 		{
-			MethodName: "Lock",
-			Handler:    _OrderHandler_Lock_Handler,
+			MethodName: "ReplayDeadLetters",
+			Handler:    _AdminHandler_ReplayDeadLetters_Handler,
 		},
 		{
-			MethodName: "Unlock",
-			Handler:    _OrderHandler_Unlock_Handler,
+			MethodName: "PurgeDeadLetters",
+			Handler:    _AdminHandler_PurgeDeadLetters_Handler,
 		},
 		{
-			MethodName: "GetOrder",
-			Handler:    _OrderHandler_GetOrder_Handler,
+			MethodName: "Compact",
+			Handler:    _AdminHandler_Compact_Handler,
 		},
 		{
-			MethodName: "GetAllOrders",
-			Handler:    _OrderHandler_GetAllOrders_Handler,
+			MethodName: "SelfTest",
+			Handler:    _AdminHandler_SelfTest_Handler,
+		},
+		{
+			MethodName: "GetReplicationStatus",
+			Handler:    _AdminHandler_GetReplicationStatus_Handler,
+		},
+		{
+			MethodName: "Promote",
+			Handler:    _AdminHandler_Promote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Backup",
+			Handler:       _AdminHandler_Backup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Restore",
+			Handler:       _AdminHandler_Restore_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportAuditLog",
+			Handler:       _AdminHandler_ExportAuditLog_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Replicate",
+			Handler:       _AdminHandler_Replicate_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "sprawl.proto",
 }
 
-// ChannelHandlerClient is the client API for ChannelHandler service.
+// StorageHandlerClient is the client API for StorageHandler service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ChannelHandlerClient interface {
-	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
-	Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error)
-	GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error)
-	GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error)
+type StorageHandlerClient interface {
+	List(ctx context.Context, in *StorageListRequest, opts ...grpc.CallOption) (*StorageKeyList, error)
+	Dump(ctx context.Context, in *StorageDumpRequest, opts ...grpc.CallOption) (StorageHandler_DumpClient, error)
+	Stat(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StorageStat, error)
 }
 
-type channelHandlerClient struct {
+type storageHandlerClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewChannelHandlerClient(cc *grpc.ClientConn) ChannelHandlerClient {
-	return &channelHandlerClient{cc}
+func NewStorageHandlerClient(cc *grpc.ClientConn) StorageHandlerClient {
+	return &storageHandlerClient{cc}
 }
 
-func (c *channelHandlerClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
-	out := new(JoinResponse)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Join", in, out, opts...)
+func (c *storageHandlerClient) List(ctx context.Context, in *StorageListRequest, opts ...grpc.CallOption) (*StorageKeyList, error) {
+	out := new(StorageKeyList)
+	err := c.cc.Invoke(ctx, "/pb.StorageHandler/List", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *channelHandlerClient) Leave(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/Leave", in, out, opts...)
+func (c *storageHandlerClient) Dump(ctx context.Context, in *StorageDumpRequest, opts ...grpc.CallOption) (StorageHandler_DumpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StorageHandler_serviceDesc.Streams[0], "/pb.StorageHandler/Dump", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &storageHandlerDumpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *channelHandlerClient) GetChannel(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Channel, error) {
-	out := new(Channel)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetChannel", in, out, opts...)
-	if err != nil {
+type StorageHandler_DumpClient interface {
+	Recv() (*StorageEntry, error)
+	grpc.ClientStream
+}
+
+type storageHandlerDumpClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageHandlerDumpClient) Recv() (*StorageEntry, error) {
+	m := new(StorageEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *channelHandlerClient) GetAllChannels(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChannelList, error) {
-	out := new(ChannelList)
-	err := c.cc.Invoke(ctx, "/pb.ChannelHandler/GetAllChannels", in, out, opts...)
+func (c *storageHandlerClient) Stat(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StorageStat, error) {
+	out := new(StorageStat)
+	err := c.cc.Invoke(ctx, "/pb.StorageHandler/Stat", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ChannelHandlerServer is the server API for ChannelHandler service.
-type ChannelHandlerServer interface {
-	Join(context.Context, *JoinRequest) (*JoinResponse, error)
-	Leave(context.Context, *ChannelSpecificRequest) (*Empty, error)
-	GetChannel(context.Context, *ChannelSpecificRequest) (*Channel, error)
-	GetAllChannels(context.Context, *Empty) (*ChannelList, error)
+// StorageHandlerServer is the server API for StorageHandler service.
+type StorageHandlerServer interface {
+	List(context.Context, *StorageListRequest) (*StorageKeyList, error)
+	Dump(*StorageDumpRequest, StorageHandler_DumpServer) error
+	Stat(context.Context, *Empty) (*StorageStat, error)
 }
 
-// UnimplementedChannelHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedChannelHandlerServer struct {
+// UnimplementedStorageHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedStorageHandlerServer struct {
 }
 
-func (*UnimplementedChannelHandlerServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
-}
-func (*UnimplementedChannelHandlerServer) Leave(ctx context.Context, req *ChannelSpecificRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+func (*UnimplementedStorageHandlerServer) List(ctx context.Context, req *StorageListRequest) (*StorageKeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
-func (*UnimplementedChannelHandlerServer) GetChannel(ctx context.Context, req *ChannelSpecificRequest) (*Channel, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChannel not implemented")
+func (*UnimplementedStorageHandlerServer) Dump(req *StorageDumpRequest, srv StorageHandler_DumpServer) error {
+	return status.Errorf(codes.Unimplemented, "method Dump not implemented")
 }
-func (*UnimplementedChannelHandlerServer) GetAllChannels(ctx context.Context, req *Empty) (*ChannelList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllChannels not implemented")
+func (*UnimplementedStorageHandlerServer) Stat(ctx context.Context, req *Empty) (*StorageStat, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
 }
 
-func RegisterChannelHandlerServer(s *grpc.Server, srv ChannelHandlerServer) {
-	s.RegisterService(&_ChannelHandler_serviceDesc, srv)
+func RegisterStorageHandlerServer(s *grpc.Server, srv StorageHandlerServer) {
+	s.RegisterService(&_StorageHandler_serviceDesc, srv)
 }
 
-func _ChannelHandler_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinRequest)
+func _StorageHandler_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).Join(ctx, in)
+		return srv.(StorageHandlerServer).List(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/Join",
+		FullMethod: "/pb.StorageHandler/List",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).Join(ctx, req.(*JoinRequest))
+		return srv.(StorageHandlerServer).List(ctx, req.(*StorageListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChannelHandler_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelSpecificRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ChannelHandlerServer).Leave(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/Leave",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).Leave(ctx, req.(*ChannelSpecificRequest))
+func _StorageHandler_Dump_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StorageDumpRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(StorageHandlerServer).Dump(m, &storageHandlerDumpServer{stream})
 }
 
-func _ChannelHandler_GetChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelSpecificRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ChannelHandlerServer).GetChannel(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/GetChannel",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).GetChannel(ctx, req.(*ChannelSpecificRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type StorageHandler_DumpServer interface {
+	Send(*StorageEntry) error
+	grpc.ServerStream
 }
 
-func _ChannelHandler_GetAllChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+type storageHandlerDumpServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageHandlerDumpServer) Send(m *StorageEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageHandler_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChannelHandlerServer).GetAllChannels(ctx, in)
+		return srv.(StorageHandlerServer).Stat(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ChannelHandler/GetAllChannels",
+		FullMethod: "/pb.StorageHandler/Stat",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChannelHandlerServer).GetAllChannels(ctx, req.(*Empty))
+		return srv.(StorageHandlerServer).Stat(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ChannelHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.ChannelHandler",
-	HandlerType: (*ChannelHandlerServer)(nil),
+var _StorageHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.StorageHandler",
+	HandlerType: (*StorageHandlerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Join",
-			Handler:    _ChannelHandler_Join_Handler,
-		},
-		{
-			MethodName: "Leave",
-			Handler:    _ChannelHandler_Leave_Handler,
+			MethodName: "List",
+			Handler:    _StorageHandler_List_Handler,
 		},
 		{
-			MethodName: "GetChannel",
-			Handler:    _ChannelHandler_GetChannel_Handler,
+			MethodName: "Stat",
+			Handler:    _StorageHandler_Stat_Handler,
 		},
+	},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "GetAllChannels",
-			Handler:    _ChannelHandler_GetAllChannels_Handler,
+			StreamName:    "Dump",
+			Handler:       _StorageHandler_Dump_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "sprawl.proto",
 }
 
-// NodeHandlerClient is the client API for NodeHandler service.
+// MarketDataHandlerClient is the client API for MarketDataHandler service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NodeHandlerClient interface {
-	GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error)
-	BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error)
+type MarketDataHandlerClient interface {
+	GetCandles(ctx context.Context, in *CandleRequest, opts ...grpc.CallOption) (*CandleList, error)
+	GetTicker(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Ticker, error)
 }
 
-type nodeHandlerClient struct {
+type marketDataHandlerClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewNodeHandlerClient(cc *grpc.ClientConn) NodeHandlerClient {
-	return &nodeHandlerClient{cc}
+func NewMarketDataHandlerClient(cc *grpc.ClientConn) MarketDataHandlerClient {
+	return &marketDataHandlerClient{cc}
 }
 
-func (c *nodeHandlerClient) GetAllPeers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PeerListResponse, error) {
-	out := new(PeerListResponse)
-	err := c.cc.Invoke(ctx, "/pb.NodeHandler/GetAllPeers", in, out, opts...)
+func (c *marketDataHandlerClient) GetCandles(ctx context.Context, in *CandleRequest, opts ...grpc.CallOption) (*CandleList, error) {
+	out := new(CandleList)
+	err := c.cc.Invoke(ctx, "/pb.MarketDataHandler/GetCandles", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *nodeHandlerClient) BlacklistPeer(ctx context.Context, in *Peer, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/pb.NodeHandler/BlacklistPeer", in, out, opts...)
+func (c *marketDataHandlerClient) GetTicker(ctx context.Context, in *ChannelSpecificRequest, opts ...grpc.CallOption) (*Ticker, error) {
+	out := new(Ticker)
+	err := c.cc.Invoke(ctx, "/pb.MarketDataHandler/GetTicker", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// NodeHandlerServer is the server API for NodeHandler service.
-type NodeHandlerServer interface {
-	GetAllPeers(context.Context, *Empty) (*PeerListResponse, error)
-	BlacklistPeer(context.Context, *Peer) (*Empty, error)
+// MarketDataHandlerServer is the server API for MarketDataHandler service.
+type MarketDataHandlerServer interface {
+	GetCandles(context.Context, *CandleRequest) (*CandleList, error)
+	GetTicker(context.Context, *ChannelSpecificRequest) (*Ticker, error)
 }
 
-// UnimplementedNodeHandlerServer can be embedded to have forward compatible implementations.
-type UnimplementedNodeHandlerServer struct {
+// UnimplementedMarketDataHandlerServer can be embedded to have forward compatible implementations.
+type UnimplementedMarketDataHandlerServer struct {
 }
 
-func (*UnimplementedNodeHandlerServer) GetAllPeers(ctx context.Context, req *Empty) (*PeerListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllPeers not implemented")
+func (*UnimplementedMarketDataHandlerServer) GetCandles(ctx context.Context, req *CandleRequest) (*CandleList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandles not implemented")
 }
-func (*UnimplementedNodeHandlerServer) BlacklistPeer(ctx context.Context, req *Peer) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BlacklistPeer not implemented")
+func (*UnimplementedMarketDataHandlerServer) GetTicker(ctx context.Context, req *ChannelSpecificRequest) (*Ticker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTicker not implemented")
 }
 
-func RegisterNodeHandlerServer(s *grpc.Server, srv NodeHandlerServer) {
-	s.RegisterService(&_NodeHandler_serviceDesc, srv)
+func RegisterMarketDataHandlerServer(s *grpc.Server, srv MarketDataHandlerServer) {
+	s.RegisterService(&_MarketDataHandler_serviceDesc, srv)
 }
 
-func _NodeHandler_GetAllPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _MarketDataHandler_GetCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CandleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeHandlerServer).GetAllPeers(ctx, in)
+		return srv.(MarketDataHandlerServer).GetCandles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.NodeHandler/GetAllPeers",
+		FullMethod: "/pb.MarketDataHandler/GetCandles",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeHandlerServer).GetAllPeers(ctx, req.(*Empty))
+		return srv.(MarketDataHandlerServer).GetCandles(ctx, req.(*CandleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NodeHandler_BlacklistPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Peer)
+func _MarketDataHandler_GetTicker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelSpecificRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NodeHandlerServer).BlacklistPeer(ctx, in)
+		return srv.(MarketDataHandlerServer).GetTicker(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.NodeHandler/BlacklistPeer",
+		FullMethod: "/pb.MarketDataHandler/GetTicker",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeHandlerServer).BlacklistPeer(ctx, req.(*Peer))
+		return srv.(MarketDataHandlerServer).GetTicker(ctx, req.(*ChannelSpecificRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _NodeHandler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.NodeHandler",
-	HandlerType: (*NodeHandlerServer)(nil),
+var _MarketDataHandler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.MarketDataHandler",
+	HandlerType: (*MarketDataHandlerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetAllPeers",
-			Handler:    _NodeHandler_GetAllPeers_Handler,
+			MethodName: "GetCandles",
+			Handler:    _MarketDataHandler_GetCandles_Handler,
 		},
 		{
-			MethodName: "BlacklistPeer",
-			Handler:    _NodeHandler_BlacklistPeer_Handler,
+			MethodName: "GetTicker",
+			Handler:    _MarketDataHandler_GetTicker_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
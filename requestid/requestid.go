@@ -0,0 +1,123 @@
+// Package requestid tags gRPC calls and received messages with an ID that follows them through the node's logs
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/sprawl/sprawl/interfaces"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the gRPC metadata key that carries the request ID, both in calls and in their response headers
+const Header string = "x-request-id"
+
+// maxLength bounds the request IDs accepted from clients, so they can't flood the logs
+const maxLength int = 64
+
+// key is the context key of the request ID
+type key struct{}
+
+// New returns a random request ID
+func New() string {
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// WithID returns a context that carries the request ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// FromContext returns the request ID of the context, or an empty string if it has none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(key{}).(string)
+	return id
+}
+
+// FromIncoming returns the request ID the client sent in the call's metadata, or a new one if it didn't send one
+func FromIncoming(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, id := range md.Get(Header) {
+		if id != "" && len(id) <= maxLength {
+			return id
+		}
+	}
+	return New()
+}
+
+// Logger prefixes every line with the request ID of the context, so the lines of one request can be grepped from the logs
+func Logger(ctx context.Context, logger interfaces.Logger) interfaces.Logger {
+	id := FromContext(ctx)
+	if id == "" {
+		return logger
+	}
+	return &prefixedLogger{prefix: "[request " + id + "] ", logger: logger}
+}
+
+// prefixedLogger writes to another logger with the request ID in front of every line
+type prefixedLogger struct {
+	prefix string
+	logger interfaces.Logger
+}
+
+// line joins the arguments like the underlying logger would, after the request ID
+func (l *prefixedLogger) line(args []interface{}) string {
+	return l.prefix + fmt.Sprint(args...)
+}
+
+// Debug logs the arguments after the request ID
+func (l *prefixedLogger) Debug(args ...interface{}) {
+	l.logger.Debug(l.line(args))
+}
+
+// Debugf logs the formatted line after the request ID
+func (l *prefixedLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Debugf(l.prefix+format, args...)
+}
+
+// Info logs the arguments after the request ID
+func (l *prefixedLogger) Info(args ...interface{}) {
+	l.logger.Info(l.line(args))
+}
+
+// Infof logs the formatted line after the request ID
+func (l *prefixedLogger) Infof(format string, args ...interface{}) {
+	l.logger.Infof(l.prefix+format, args...)
+}
+
+// Warn logs the arguments after the request ID
+func (l *prefixedLogger) Warn(args ...interface{}) {
+	l.logger.Warn(l.line(args))
+}
+
+// Warnf logs the formatted line after the request ID
+func (l *prefixedLogger) Warnf(format string, args ...interface{}) {
+	l.logger.Warnf(l.prefix+format, args...)
+}
+
+// Error logs the arguments after the request ID
+func (l *prefixedLogger) Error(args ...interface{}) {
+	l.logger.Error(l.line(args))
+}
+
+// Errorf logs the formatted line after the request ID
+func (l *prefixedLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Errorf(l.prefix+format, args...)
+}
+
+// Fatal logs the arguments after the request ID
+func (l *prefixedLogger) Fatal(args ...interface{}) {
+	l.logger.Fatal(l.line(args))
+}
+
+// Fatalf logs the formatted line after the request ID
+func (l *prefixedLogger) Fatalf(format string, args ...interface{}) {
+	l.logger.Fatalf(l.prefix+format, args...)
+}
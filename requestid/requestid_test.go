@@ -0,0 +1,48 @@
+package requestid
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+// recordingLogger keeps the lines logged with Debug and Debugf
+type recordingLogger struct {
+	util.PlaceholderLogger
+	lines []string
+}
+
+func (l *recordingLogger) Debug(args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprint(args...))
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestFromIncoming(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, "trace-1"))
+	assert.Equal(t, "trace-1", FromIncoming(ctx))
+
+	// Calls without a request ID or with an overlong one get a new one
+	assert.Len(t, FromIncoming(context.Background()), 16)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, strings.Repeat("a", maxLength+1)))
+	assert.Len(t, FromIncoming(ctx), 16)
+	assert.NotEqual(t, New(), New())
+}
+
+func TestLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	assert.Equal(t, logger, Logger(context.Background(), logger))
+
+	ctx := WithID(context.Background(), "trace-1")
+	assert.Equal(t, "trace-1", FromContext(ctx))
+	Logger(ctx, logger).Debug("created ", 2, " orders")
+	Logger(ctx, logger).Debugf("created %d orders", 2)
+	assert.Equal(t, []string{"[request trace-1] created 2 orders", "[request trace-1] created 2 orders"}, logger.lines)
+}
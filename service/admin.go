@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// AdminService implements the AdminHandlerServer service.proto
+type AdminService struct {
+	Storage interfaces.Storage
+	Logger  interfaces.Logger
+	// OnRestore is called after the storage has been restored, to drop anything cached from it
+	OnRestore func()
+	// ProfileDir is where CaptureProfile writes profiles, the system's temporary directory if empty
+	ProfileDir string
+	// Receiver processes dead letters again when they're replayed
+	Receiver interfaces.Receiver
+	// Doctor runs the node's self-test checks for SelfTest
+	Doctor func(ctx context.Context) *pb.SelfTestReport
+	// ReplicationStatus reports how far the node has replicated its primary, and returns nil once it isn't following one
+	ReplicationStatus func() *pb.ReplicationStatus
+	// OnPromote makes a following node take over as primary. It's nil unless the node follows one.
+	OnPromote func(ctx context.Context) error
+	// auditSequence numbers the audit entries recorded by this node
+	auditSequence uint64
+	// compacting lets one compaction run at a time, and lastCompaction holds the report of the latest one
+	compacting     sync.Mutex
+	lastCompaction atomic.Value
+}
+
+// backupChunkWriter sends everything written to it as BackupChunks
+type backupChunkWriter struct {
+	stream pb.AdminHandler_BackupServer
+}
+
+func (w *backupChunkWriter) Write(data []byte) (int, error) {
+	chunk := make([]byte, len(data))
+	copy(chunk, data)
+	err := w.stream.Send(&pb.BackupChunk{Data: chunk})
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// backupChunkReader reads the data of received BackupChunks until the client closes the stream
+type backupChunkReader struct {
+	stream pb.AdminHandler_RestoreServer
+	buffer []byte
+}
+
+func (r *backupChunkReader) Read(data []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buffer = chunk.GetData()
+	}
+	n := copy(data, r.buffer)
+	r.buffer = r.buffer[n:]
+	return n, nil
+}
+
+// RegisterStorage registers the storage service that is backed up and restored
+func (s *AdminService) RegisterStorage(storage interfaces.Storage) {
+	s.Storage = storage
+}
+
+// Backup streams a checksummed snapshot of the whole storage to the client
+func (s *AdminService) Backup(in *pb.Empty, stream pb.AdminHandler_BackupServer) error {
+	err := s.Storage.Backup(stream.Context(), &backupChunkWriter{stream: stream})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Backup storage"), err)
+	}
+	return nil
+}
+
+// Restore replaces the storage contents with a snapshot streamed by the client
+func (s *AdminService) Restore(stream pb.AdminHandler_RestoreServer) error {
+	err := s.Storage.Restore(stream.Context(), &backupChunkReader{stream: stream})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Restore storage"), err)
+	}
+	if s.OnRestore != nil {
+		s.OnRestore()
+	}
+	if s.Logger != nil {
+		s.Logger.Info("Storage restored from backup")
+	}
+	return stream.SendAndClose(&pb.Empty{})
+}
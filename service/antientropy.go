@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// digestSyncs remembers when this node last asked a peer of each channel for its order book because their digests differed
+type digestSyncs struct {
+	requested map[string]time.Time
+	lock      sync.Mutex
+}
+
+// allow tells if the channel's order book may be requested again, and counts the request if so.
+// Every peer whose order book differs publishes its own digest, so one request per interval is enough.
+func (d *digestSyncs) allow(channelID []byte, now time.Time, interval time.Duration) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.requested == nil {
+		d.requested = make(map[string]time.Time)
+	}
+	if requested, ok := d.requested[string(channelID)]; ok && now.Sub(requested) < interval {
+		return false
+	}
+	d.requested[string(channelID)] = now
+	return true
+}
+
+// getOrderDigest hashes the sorted IDs of a channel's orders, so nodes can tell whether their order books differ
+// without exchanging them
+func (s *OrderService) getOrderDigest(ctx context.Context, channelID []byte) (*pb.OrderDigest, error) {
+	prefix := getOrderQueryPrefix(channelID)
+	orders, err := s.Storage.GetAllWithPrefix(ctx, string(prefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get orders for digest"), err)
+	}
+	orderIDs := make([]string, 0, len(orders))
+	for key := range orders {
+		orderIDs = append(orderIDs, key[len(prefix):])
+	}
+	sort.Strings(orderIDs)
+
+	hash := sha256.New()
+	length := make([]byte, 4)
+	for _, orderID := range orderIDs {
+		binary.BigEndian.PutUint32(length, uint32(len(orderID)))
+		hash.Write(length)
+		hash.Write([]byte(orderID))
+	}
+	return &pb.OrderDigest{Root: hash.Sum(nil), Orders: uint32(len(orderIDs))}, nil
+}
+
+// PublishDigests publishes the order digest of every joined channel. Peers whose order book differs ask this node
+// for its order book, and this node asks them for theirs when it gets their digests, so orders missed because of
+// dropped messages or partitions are eventually exchanged both ways.
+func (s *OrderService) PublishDigests(ctx context.Context) error {
+	if s.P2p == nil {
+		return nil
+	}
+	channels, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get joined channels for digests"), err)
+	}
+	for _, value := range channels {
+		channel := &pb.Channel{}
+		if err := proto.Unmarshal([]byte(value), channel); !errors.IsEmpty(err) {
+			continue
+		}
+		digest, err := s.getOrderDigest(ctx, channel.GetId())
+		if !errors.IsEmpty(err) {
+			return err
+		}
+		digestInBytes, err := proto.Marshal(digest)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Marshal order digest"), err)
+		}
+		err = s.P2p.Send(ctx, &pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_ORDER_DIGEST, Data: digestInBytes})
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Send order digest"), err)
+		}
+	}
+	return nil
+}
+
+// receiveDigest compares a peer's order digest with this node's, and asks the peer for its order book if they differ
+func (s *OrderService) receiveDigest(ctx context.Context, channelID []byte, data []byte, from peer.ID) error {
+	digest := &pb.OrderDigest{}
+	err := proto.Unmarshal(data, digest)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal order digest"), errors.Malformed, err)
+	}
+	ownDigest, err := s.getOrderDigest(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if bytes.Equal(digest.GetRoot(), ownDigest.GetRoot()) {
+		return nil
+	}
+	if s.AntiEntropyInterval > 0 && !s.digestSyncs.allow(channelID, time.Now(), s.AntiEntropyInterval) {
+		return nil
+	}
+
+	s.Logger.Infof("Order book of channel %s differs from %s's, with %d orders here and %d there. Requesting its orders.", channelID, from, ownDigest.GetOrders(), digest.GetOrders())
+	err = s.P2p.SendToPeer(from, &pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_SYNC_REQUEST})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Request orders of a differing order book"), err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/interfaces/mocks"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDigestTestService(p2p *mocks.P2p, orderIDs ...string) *OrderService {
+	digestService := &OrderService{Logger: new(util.PlaceholderLogger), AntiEntropyInterval: time.Minute}
+	digestService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	digestService.RegisterP2p(p2p)
+	for _, orderID := range orderIDs {
+		digestService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), []byte(orderID)), []byte(orderID))
+	}
+	return digestService
+}
+
+func TestAntiEntropy(t *testing.T) {
+	ctx := context.Background()
+	p2p := new(mocks.P2p)
+	p2p.On("Send", mock.Anything, mock.Anything).Return(nil)
+	p2p.On("SendToPeer", mock.Anything, mock.Anything).Return(nil)
+	local := newDigestTestService(p2p, "a", "b")
+	remote := newDigestTestService(p2p, "b", "a", "c")
+
+	// The digest doesn't depend on the order the orders are stored in
+	digest, err := local.getOrderDigest(ctx, []byte(assetPair))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), digest.GetOrders())
+	sameDigest, err := newDigestTestService(p2p, "b", "a").getOrderDigest(ctx, []byte(assetPair))
+	assert.NoError(t, err)
+	assert.Equal(t, digest.GetRoot(), sameDigest.GetRoot())
+	remoteDigest, err := remote.getOrderDigest(ctx, []byte(assetPair))
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest.GetRoot(), remoteDigest.GetRoot())
+
+	// Equal digests need nothing
+	digestInBytes, err := proto.Marshal(digest)
+	assert.NoError(t, err)
+	assert.NoError(t, local.receiveDigest(ctx, []byte(assetPair), digestInBytes, peer.ID("remote")))
+	p2p.AssertNotCalled(t, "SendToPeer", mock.Anything, mock.Anything)
+
+	// A differing order book is requested from the peer, once per interval
+	remoteDigestInBytes, err := proto.Marshal(remoteDigest)
+	assert.NoError(t, err)
+	assert.NoError(t, local.receiveDigest(ctx, []byte(assetPair), remoteDigestInBytes, peer.ID("remote")))
+	assert.NoError(t, local.receiveDigest(ctx, []byte(assetPair), remoteDigestInBytes, peer.ID("other")))
+	p2p.AssertNumberOfCalls(t, "SendToPeer", 1)
+	p2p.AssertCalled(t, "SendToPeer", peer.ID("remote"), mock.MatchedBy(func(message *pb.WireMessage) bool {
+		return message.GetOperation() == pb.Operation_SYNC_REQUEST && string(message.GetChannelID()) == assetPair
+	}))
+
+	// Digests are published on every joined channel
+	channelInBytes, err := proto.Marshal(&pb.Channel{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.NoError(t, local.Storage.Put(ctx, getChannelStorageKey([]byte(assetPair)), channelInBytes))
+	assert.NoError(t, local.PublishDigests(ctx))
+	p2p.AssertCalled(t, "Send", mock.Anything, mock.MatchedBy(func(message *pb.WireMessage) bool {
+		return message.GetOperation() == pb.Operation_ORDER_DIGEST && string(message.GetData()) == string(digestInBytes)
+	}))
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/assets"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AssetService is a gRPC service for the asset registry
+type AssetService struct {
+	Storage interfaces.Storage
+}
+
+// RegisterStorage registers a storage service to store the assets in
+func (s *AssetService) RegisterStorage(storage interfaces.Storage) {
+	s.Storage = storage
+}
+
+// Register registers an asset with its metadata, so that channels and orders can use its symbol
+func (s *AssetService) Register(ctx context.Context, in *pb.Asset) (*pb.Asset, error) {
+	asset, err := assets.NewRegistry(s.Storage).Register(ctx, in)
+	if errors.Is(errors.Invalid, err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Register asset"), err))
+	}
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Register asset"), err))
+	}
+	return asset, nil
+}
+
+// GetAllAssets lists all registered assets
+func (s *AssetService) GetAllAssets(ctx context.Context, in *pb.Empty) (*pb.AssetList, error) {
+	registered, err := assets.NewRegistry(s.Storage).GetAll(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get all assets"), err))
+	}
+	return &pb.AssetList{Assets: registered}, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+const registeredAsset string = "NOTACOIN"
+
+func TestAssetService(t *testing.T) {
+	assetService := &AssetService{}
+	assetService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+
+	asset, err := assetService.Register(context.Background(), &pb.Asset{Symbol: "notacoin", Decimals: 2, Chain: "ethereum"})
+	assert.NoError(t, err)
+	assert.Equal(t, registeredAsset, asset.GetSymbol())
+	_, err = assetService.Register(context.Background(), &pb.Asset{Symbol: "NOT,A,COIN"})
+	assert.Error(t, err)
+
+	list, err := assetService.GetAllAssets(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Asset{asset}, list.GetAssets())
+}
+
+func TestCreateWithRegisteredAsset(t *testing.T) {
+	assetOrderService := newOwnershipTestService()
+	request := &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: registeredAsset, CounterAsset: asset2, Amount: testAmount, Price: testPrice}
+	_, err := assetOrderService.Create(context.Background(), request)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	assetService := &AssetService{Storage: assetOrderService.Storage}
+	_, err = assetService.Register(context.Background(), &pb.Asset{Symbol: registeredAsset, Decimals: 2})
+	assert.NoError(t, err)
+	_, err = assetOrderService.Create(context.Background(), request)
+	assert.NoError(t, err)
+}
+
+func TestValidateOrderDecimals(t *testing.T) {
+	validationService := newOwnershipTestService()
+	joinChannelWithOptions(t, validationService, &pb.ChannelOptions{Assets: []*pb.Asset{{Symbol: asset2, Decimals: 8}}})
+
+	// BTC can't be divided finer than 8 decimals
+	order := &pb.Order{Asset: asset2, CounterAsset: asset1, Amount: 2 * 10000000000, Price: testPrice}
+	assert.NoError(t, validationService.validateOrder(context.Background(), []byte(assetPair), order))
+	order.Amount++
+	assert.True(t, errors.Is(errors.Invalid, validationService.validateOrder(context.Background(), []byte(assetPair), order)))
+
+	// ETH wasn't registered when the channel was joined, so any amount goes
+	order = &pb.Order{Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice}
+	assert.NoError(t, validationService.validateOrder(context.Background(), []byte(assetPair), order))
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/assets"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// optionsSeparator separates the asset pair of a channel ID from the hash of its options
+const optionsSeparator string = "#"
+const optionsHashLength int = 8
+
+// getChannelAssets looks up the registered metadata of a channel's assets, in the order of the asset pair.
+// Assets that haven't been registered are left out.
+func getChannelAssets(ctx context.Context, registry *assets.Registry, assetPair []string) ([]*pb.Asset, error) {
+	channelAssets := []*pb.Asset{}
+	for _, symbol := range assetPair {
+		registered, err := registry.IsRegistered(ctx, symbol)
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		if !registered {
+			continue
+		}
+		asset, err := registry.Get(ctx, symbol)
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		channelAssets = append(channelAssets, asset)
+	}
+	return channelAssets, nil
+}
+
+// getChannelID derives a channel ID from the canonical asset pair, like BTC,ETH.
+// Channels with any options get a hash of them appended, so that differently configured markets don't mix.
+func getChannelID(asset string, counterAsset string, options *pb.ChannelOptions) ([]byte, error) {
+	assetPair := []string{assets.Canonical(asset), assets.Canonical(counterAsset)}
+	sort.Strings(assetPair)
+	channelID := strings.Join(assetPair, ",")
+
+	// The asset pair is already part of the ID
+	hashed := proto.Clone(options).(*pb.ChannelOptions)
+	hashed.AssetPair = ""
+	if proto.Equal(hashed, &pb.ChannelOptions{}) {
+		return []byte(channelID), nil
+	}
+	marshaledOptions, err := proto.Marshal(hashed)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	hash := sha256.Sum256(marshaledOptions)
+	return []byte(channelID + optionsSeparator + hex.EncodeToString(hash[:optionsHashLength])), nil
+}
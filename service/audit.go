@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// auditedMethods are the API calls that change orders, which are recorded in the audit log
+var auditedMethods = map[string]bool{
+	"/pb.OrderHandler/Create":     true,
+	"/pb.OrderHandler/Delete":     true,
+	"/pb.OrderHandler/Lock":       true,
+	"/pb.OrderHandler/Unlock":     true,
+	"/pb.OrderHandler/ReportFill": true,
+	"/pb.OrderHandler/DeleteAll":  true,
+	"/pb.OrderHandler/KillSwitch": true,
+}
+
+// getAuditStorageKey orders the audit log by time. The sequence number keeps calls made at the same time apart.
+func getAuditStorageKey(timestamp time.Time, sequence uint64) []byte {
+	suffix := make([]byte, 16)
+	binary.BigEndian.PutUint64(suffix, uint64(timestamp.UnixNano()))
+	binary.BigEndian.PutUint64(suffix[8:], sequence)
+	return []byte(strings.Join([]string{string(interfaces.AuditPrefix), string(suffix)}, ""))
+}
+
+// hashRequest returns the SHA-256 hash of a marshaled request, so the audit log can be matched with the calls of a client
+func hashRequest(req interface{}) []byte {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	data, err := proto.Marshal(message)
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
+
+// audit appends a call that changes orders to the audit log with its caller and result.
+// Calls that fail authentication are recorded too, without a namespace.
+func (s *AdminService) audit(ctx context.Context, method string, req interface{}, err error) {
+	if s == nil || s.Storage == nil || !auditedMethods[method] {
+		return
+	}
+	now := time.Now()
+	entry := &pb.AuditEntry{
+		Method:      method,
+		RequestHash: hashRequest(req),
+		Result:      status.Code(err).String(),
+	}
+	entry.Timestamp, _ = ptypes.TimestampProto(now)
+	entry.Namespace, _ = getNamespace(ctx)
+	entry.RequestID = requestid.FromContext(ctx)
+	if client, ok := peer.FromContext(ctx); ok && client.Addr != nil {
+		entry.Address = client.Addr.String()
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	entryInBytes, marshalErr := proto.Marshal(entry)
+	if !errors.IsEmpty(marshalErr) {
+		s.logAuditError(errors.E(errors.Op("Marshal audit entry"), marshalErr))
+		return
+	}
+	sequence := atomic.AddUint64(&s.auditSequence, 1)
+	putErr := s.Storage.Put(context.Background(), getAuditStorageKey(now, sequence), entryInBytes)
+	if !errors.IsEmpty(putErr) {
+		s.logAuditError(errors.E(errors.Op("Put audit entry"), putErr))
+	}
+}
+
+// logAuditError logs a call that couldn't be added to the audit log, since the call itself has already been made
+func (s *AdminService) logAuditError(err error) {
+	if s.Logger != nil {
+		s.Logger.Error(err)
+	}
+}
+
+// ExportAuditLog streams the recorded calls that changed orders between the given times to the client, oldest first.
+// Missing limits aren't checked.
+func (s *AdminService) ExportAuditLog(in *pb.AuditLogRequest, stream pb.AdminHandler_ExportAuditLogServer) error {
+	var from, to time.Time
+	var err error
+	if in.GetFrom() != nil {
+		from, err = ptypes.Timestamp(in.GetFrom())
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Parse audit log start time"), err)
+		}
+	}
+	if in.GetTo() != nil {
+		to, err = ptypes.Timestamp(in.GetTo())
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Parse audit log end time"), err)
+		}
+	}
+
+	entries, err := s.Storage.GetAllWithPrefix(stream.Context(), string(interfaces.AuditPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get audit log"), err)
+	}
+	for _, key := range sortedKeys(entries) {
+		entry := &pb.AuditEntry{}
+		err = proto.Unmarshal([]byte(entries[key]), entry)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		timestamp, err := ptypes.Timestamp(entry.GetTimestamp())
+		if !errors.IsEmpty(err) || (!from.IsZero() && timestamp.Before(from)) || (!to.IsZero() && timestamp.After(to)) {
+			continue
+		}
+		err = stream.Send(entry)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Send audit entry"), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// auditLogStream collects the entries sent by ExportAuditLog
+type auditLogStream struct {
+	grpc.ServerStream
+	entries []*pb.AuditEntry
+}
+
+func (stream *auditLogStream) Context() context.Context {
+	return context.Background()
+}
+
+func (stream *auditLogStream) Send(entry *pb.AuditEntry) error {
+	stream.entries = append(stream.entries, entry)
+	return nil
+}
+
+func TestAuditLog(t *testing.T) {
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	server := &Server{Admin: &AdminService{}, APIKeys: map[string]string{"key1": "desk1"}}
+	server.Admin.RegisterStorage(storage)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return &pb.Empty{}, nil }
+	client := &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5000}}
+	ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer key1", requestid.Header, "trace-1")), client)
+	request := &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: []byte("order")}
+
+	_, err := server.authenticateUnary(ctx, request, &grpc.UnaryServerInfo{FullMethod: "/pb.OrderHandler/Lock"}, handler)
+	assert.NoError(t, err)
+	_, err = server.authenticateUnary(peer.NewContext(context.Background(), client), request, &grpc.UnaryServerInfo{FullMethod: "/pb.OrderHandler/Delete"}, handler)
+	assert.Error(t, err)
+	// Calls that don't change orders aren't recorded
+	_, err = server.authenticateUnary(ctx, request, &grpc.UnaryServerInfo{FullMethod: "/pb.OrderHandler/GetOrder"}, handler)
+	assert.NoError(t, err)
+
+	stream := &auditLogStream{}
+	err = server.Admin.ExportAuditLog(&pb.AuditLogRequest{}, stream)
+	assert.NoError(t, err)
+	assert.Len(t, stream.entries, 2)
+	assert.Equal(t, "/pb.OrderHandler/Lock", stream.entries[0].GetMethod())
+	assert.Equal(t, "desk1", stream.entries[0].GetNamespace())
+	assert.Equal(t, "127.0.0.1:5000", stream.entries[0].GetAddress())
+	assert.Equal(t, hashRequest(request), stream.entries[0].GetRequestHash())
+	assert.Equal(t, "OK", stream.entries[0].GetResult())
+	assert.Equal(t, "trace-1", stream.entries[0].GetRequestID())
+	assert.Equal(t, "/pb.OrderHandler/Delete", stream.entries[1].GetMethod())
+	assert.Empty(t, stream.entries[1].GetNamespace())
+	assert.Equal(t, "Unauthenticated", stream.entries[1].GetResult())
+	assert.NotEmpty(t, stream.entries[1].GetError())
+	// Calls without a request ID get a new one
+	assert.NotEmpty(t, stream.entries[1].GetRequestID())
+
+	// Entries are filtered by time
+	future, _ := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	stream = &auditLogStream{}
+	err = server.Admin.ExportAuditLog(&pb.AuditLogRequest{From: future}, stream)
+	assert.NoError(t, err)
+	assert.Empty(t, stream.entries)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/assets"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
@@ -18,6 +21,11 @@ import (
 type ChannelService struct {
 	Storage interfaces.Storage
 	P2p     interfaces.P2p
+	// AllowCustomAssets allows joining channels with asset symbols that aren't known
+	AllowCustomAssets bool
+	// IdleTimeout is how long a channel may go without orders, messages or peers before LeaveIdle leaves it. 0 never leaves channels.
+	IdleTimeout time.Duration
+	activity    *channelActivity
 }
 
 func getChannelStorageKey(channelOptBlob []byte) []byte {
@@ -37,14 +45,80 @@ func (s *ChannelService) RegisterP2p(p2p interfaces.P2p) {
 // Join joins a channel, subscribing to new topic in libp2p
 func (s *ChannelService) Join(ctx context.Context, in *pb.JoinRequest) (*pb.JoinResponse, error) {
 	// Get all channel options, sort
-	assetPair := []string{string(in.GetAsset()), string(in.GetCounterAsset())}
+	asset := assets.Canonical(in.GetAsset())
+	counterAsset := assets.Canonical(in.GetCounterAsset())
+	registry := assets.NewRegistry(s.Storage)
+	err := registry.Check(ctx, s.AllowCustomAssets, asset, counterAsset)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Join"), err))
+	}
+	assetPair := []string{asset, counterAsset}
 	sort.Strings(assetPair)
 
-	// Join the channel options together
-	channelOptBlob := []byte(strings.Join(assetPair[:], ","))
+	// Channels inherit the metadata of registered assets, so that order amounts can be checked against their decimals
+	channelAssets, err := getChannelAssets(ctx, registry, assetPair)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Join"), err))
+	}
+
+	// The quote currency has to be one of the channel's assets
+	quoteAsset := assets.Canonical(in.GetOptions().GetQuoteAsset())
+	if quoteAsset != "" && quoteAsset != asset && quoteAsset != counterAsset {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Join"), "quote asset "+quoteAsset+" isn't traded on the channel"))
+	}
+
+	// Fees are fractions of the traded amount
+	err = checkFeeRates(in.GetOptions().GetMakerFee(), in.GetOptions().GetTakerFee())
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Join"), err))
+	}
+
+	// Members only channels trust the allowlist of their creator, which is this node unless told otherwise.
+	// Other channels only have a creator if one is given, who may then publish the channel's config.
+	creator := in.GetOptions().GetCreator()
+	if in.GetOptions().GetMembersOnly() && creator == "" {
+		ownID, _, err := getOwnPeerID(s.Storage)
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Join"), err))
+		}
+		creator = ownID.String()
+	}
+	if creator != "" {
+		if _, err := peer.IDB58Decode(creator); !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Join"), "invalid channel creator "+creator))
+		}
+	}
+
+	// Operators may remove orders and ban makers on the channel. They're sorted so that their order doesn't change the channel.
+	operators := append([]string{}, in.GetOptions().GetOperators()...)
+	for _, operator := range operators {
+		if _, err := peer.IDB58Decode(operator); !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Join"), "invalid channel operator "+operator))
+		}
+	}
+	sort.Strings(operators)
 
 	// Create a Channel protobuf message to return to the user
-	joinedChannel := &pb.Channel{Id: channelOptBlob, Options: &pb.ChannelOptions{AssetPair: strings.Join(assetPair, "")}}
+	options := &pb.ChannelOptions{
+		AssetPair:   strings.Join(assetPair, ""),
+		TickSize:    in.GetOptions().GetTickSize(),
+		LotSize:     in.GetOptions().GetLotSize(),
+		QuoteAsset:  quoteAsset,
+		MembersOnly: in.GetOptions().GetMembersOnly(),
+		Creator:     creator,
+		Assets:      channelAssets,
+		MakerFee:    in.GetOptions().GetMakerFee(),
+		TakerFee:    in.GetOptions().GetTakerFee(),
+		Operators:   operators,
+	}
+
+	// Nodes joining the same pair with the same options end up on the same channel
+	channelOptBlob, err := getChannelID(asset, counterAsset, options)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Join"), err))
+	}
+	// The history to sync is this node's own choice, so it's kept with the channel but doesn't change its ID
+	joinedChannel := &pb.Channel{Id: channelOptBlob, Options: options, Admins: in.GetAdmins(), History: in.GetHistory()}
 	marshaledChannel, err := proto.Marshal(joinedChannel)
 	if !errors.IsEmpty(err) {
 		return nil, status.Errorf(codes.AlreadyExists, "%s", errors.E(errors.Op("Join"), err))
@@ -57,11 +131,17 @@ func (s *ChannelService) Join(ctx context.Context, in *pb.JoinRequest) (*pb.Join
 	}
 
 	// Store the joined channel in LevelDB
-	err = s.Storage.Put(getChannelStorageKey(channelOptBlob), marshaledChannel)
+	err = s.Storage.Put(ctx, getChannelStorageKey(channelOptBlob), marshaledChannel)
 	if !errors.IsEmpty(err) {
 		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Saving channel to database in Join"), err))
 	}
 
+	// Joining a channel that was left for being idle makes its record obsolete
+	err = s.Storage.Delete(ctx, getIdleChannelStorageKey(channelOptBlob))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Delete idle channel record in Join"), err))
+	}
+
 	return &pb.JoinResponse{
 		JoinedChannel: joinedChannel,
 	}, nil
@@ -73,9 +153,12 @@ func (s *ChannelService) Leave(ctx context.Context, in *pb.ChannelSpecificReques
 
 	// Leave the channel in p2p
 	s.P2p.Unsubscribe(&pb.Channel{Id: channelID})
+	if s.activity != nil {
+		s.activity.forget(channelID)
+	}
 
 	// Remove the channel from LevelDB
-	err := s.Storage.Delete(getChannelStorageKey(channelID))
+	err := s.Storage.Delete(ctx, getChannelStorageKey(channelID))
 	if !errors.IsEmpty(err) {
 		return nil, status.Errorf(codes.NotFound, "%s", errors.E(errors.Op("Leave"), err))
 	}
@@ -85,7 +168,7 @@ func (s *ChannelService) Leave(ctx context.Context, in *pb.ChannelSpecificReques
 
 // GetChannel fetches a single channel from the database
 func (s *ChannelService) GetChannel(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Channel, error) {
-	data, err := s.Storage.Get(getChannelStorageKey(in.GetId()))
+	data, err := s.Storage.Get(ctx, getChannelStorageKey(in.GetId()))
 	if !errors.IsEmpty(err) {
 		return nil, status.Errorf(codes.NotFound, "%s", errors.E(errors.Op("Get channel"), err))
 	}
@@ -101,7 +184,7 @@ func (s *ChannelService) GetChannel(ctx context.Context, in *pb.ChannelSpecificR
 
 // GetAllChannels fetches all channels from the database
 func (s *ChannelService) GetAllChannels(ctx context.Context, in *pb.Empty) (*pb.ChannelList, error) {
-	data, err := s.Storage.GetAllWithPrefix(string(interfaces.ChannelPrefix))
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
 	if !errors.IsEmpty(err) {
 		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get all channels "), err))
 	}
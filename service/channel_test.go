@@ -14,6 +14,29 @@ func TestChannelStorageKeyPrefixer(t *testing.T) {
 	assert.Equal(t, string(prefixedBytes), string(interfaces.ChannelPrefix)+asset1)
 }
 
+func TestChannelIDs(t *testing.T) {
+	channelID, err := getChannelID(asset1, asset2, &pb.ChannelOptions{AssetPair: "BTCETH"})
+	assert.NoError(t, err)
+	assert.Equal(t, assetPair, string(channelID))
+
+	// Case and order don't matter
+	reversedID, err := getChannelID(" btc", "Eth", &pb.ChannelOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, channelID, reversedID)
+
+	// Options make a channel of their own, the same for every node
+	tickID, err := getChannelID(asset1, asset2, &pb.ChannelOptions{TickSize: 0.01})
+	assert.NoError(t, err)
+	assert.NotEqual(t, channelID, tickID)
+	assert.Equal(t, assetPair, string(NormalizeAssetPair(tickID)))
+	sameTickID, err := getChannelID(asset2, asset1, &pb.ChannelOptions{TickSize: 0.01})
+	assert.NoError(t, err)
+	assert.Equal(t, tickID, sameTickID)
+	lotID, err := getChannelID(asset1, asset2, &pb.ChannelOptions{LotSize: 100})
+	assert.NoError(t, err)
+	assert.NotEqual(t, tickID, lotID)
+}
+
 func TestChannelJoining(t *testing.T) {
 	createNewServerInstance()
 	defer p2pInstance.Close()
@@ -47,6 +70,16 @@ func TestChannelJoining(t *testing.T) {
 	_, err = channelClient.Join(ctx, &pb.JoinRequest{Asset: asset2, CounterAsset: asset1})
 	assert.Error(t, err)
 
+	// The same channel, only written differently
+	_, err = channelClient.Join(ctx, &pb.JoinRequest{Asset: "btc", CounterAsset: "eth"})
+	assert.Error(t, err)
+
+	_, err = channelClient.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: "NOTACOIN"})
+	assert.Error(t, err)
+
+	_, err = channelClient.Join(ctx, &pb.JoinRequest{Asset: asset1, CounterAsset: "DAI", Options: &pb.ChannelOptions{QuoteAsset: asset2}})
+	assert.Error(t, err)
+
 	lastChannel = resp.GetJoinedChannel()
 	storedChannel, err := channelClient.GetChannel(ctx, &pb.ChannelSpecificRequest{Id: lastChannel.GetId()})
 	assert.NoError(t, err)
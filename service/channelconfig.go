@@ -0,0 +1,198 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getAllowlistHash returns the SHA-256 hash of the comma separated, sorted peer IDs of the members
+func getAllowlistHash(members []string) []byte {
+	sorted := append([]string{}, members...)
+	sort.Strings(sorted)
+	hash := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return hash[:]
+}
+
+// getChannelConfigSigningBytes returns the bytes of the channel config that its creator signs
+func getChannelConfigSigningBytes(config *pb.ChannelConfig) ([]byte, error) {
+	configCopy := *config
+	configCopy.Signature = nil
+	return proto.Marshal(&configCopy)
+}
+
+// verifyChannelConfig checks that the config is signed by the channel's creator and newer than the one already stored
+func verifyChannelConfig(channel *pb.Channel, config *pb.ChannelConfig) error {
+	if !bytes.Equal(config.GetChannelID(), channel.GetId()) {
+		return errors.E(errors.Op("Check channel config channel"), errors.Invalid, "channel config is for another channel")
+	}
+	signingBytes, err := getChannelConfigSigningBytes(config)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal channel config"), err)
+	}
+	err = verifyCreatorSignature(channel, config.GetCreatorPubKey(), signingBytes, config.GetSignature())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify channel config"), err)
+	}
+
+	if proto.Equal(channel.GetConfig(), config) {
+		return errors.E(errors.Op("Check for duplicate channel config"), errors.Duplicate, "channel config has already been received")
+	}
+	if config.GetVersion() <= channel.GetConfig().GetVersion() {
+		return errors.E(errors.Op("Compare channel config versions"), errors.Replay, "received channel config is older than the current one")
+	}
+	return nil
+}
+
+// validateChannelConfig checks the order against the rules of the channel's signed config
+func validateChannelConfig(channel *pb.Channel, order *pb.Order) error {
+	config := channel.GetConfig()
+	if config == nil {
+		return nil
+	}
+	err := validateTickAndLot(config.GetTickSize(), config.GetLotSize(), order)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	// A pinned allowlist only admits makers of the membership it was made from
+	makerID := peer.ID(order.GetMakerPeerID()).String()
+	if len(config.GetAllowlistHash()) > 0 && makerID != channel.GetOptions().GetCreator() {
+		members := channel.GetMembership().GetMembers()
+		if !bytes.Equal(getAllowlistHash(members), config.GetAllowlistHash()) {
+			return errors.E(errors.Op("Validate order maker"), errors.Unauthorized, "the channel's members don't match the allowlist of its config")
+		}
+		for _, member := range members {
+			if member == makerID {
+				return nil
+			}
+		}
+		return errors.E(errors.Op("Validate order maker"), errors.Unauthorized, "order maker isn't on the channel's allowlist")
+	}
+	return nil
+}
+
+// receiveChannelConfig stores a channel config received from the network, if it's a newer one by the channel's creator.
+// Configs can be relayed by anyone, since they're signed.
+func (s *OrderService) receiveChannelConfig(ctx context.Context, channelID []byte, data []byte) error {
+	config := &pb.ChannelConfig{}
+	err := proto.Unmarshal(data, config)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal channel config proto in Receive"), errors.Malformed, err)
+	}
+
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) || channel.GetOptions().GetCreator() == "" {
+		return nil
+	}
+	err = verifyChannelConfig(channel, config)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	channel.Config = config
+	marshaledChannel, err := proto.Marshal(channel)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal channel with config"), err)
+	}
+	return s.Storage.Put(ctx, getChannelStorageKey(channelID), marshaledChannel)
+}
+
+// getChannelConfigMessage returns the channel's config as a WireMessage, or nil if there isn't any
+func (s *OrderService) getChannelConfigMessage(ctx context.Context, channelID []byte) ([]byte, error) {
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) || channel.GetConfig() == nil {
+		return nil, nil
+	}
+	data, err := proto.Marshal(channel.GetConfig())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal channel config"), err)
+	}
+	return proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CHANNEL_CONFIG, Data: data})
+}
+
+// PublishConfig signs the rules of a channel created by this node and broadcasts them to the channel.
+// Nodes that receive the config refuse orders that break its rules.
+func (s *ChannelService) PublishConfig(ctx context.Context, in *pb.ChannelConfigRequest) (*pb.Channel, error) {
+	channel, err := s.GetChannel(ctx, &pb.ChannelSpecificRequest{Id: in.GetChannelID()})
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	if channel.GetOptions().GetCreator() == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Publish config"), "channel has no creator"))
+	}
+	if err := checkFeeRates(in.GetMakerFee(), in.GetTakerFee()); !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Publish config"), err))
+	}
+
+	ownID, publicKey, err := getOwnPeerID(s.Storage)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Publish config"), err))
+	}
+	if ownID.String() != channel.GetOptions().GetCreator() {
+		return nil, status.Errorf(codes.PermissionDenied, "%s", errors.E(errors.Op("Publish config"), "only the channel's creator may publish its config"))
+	}
+
+	creatorPubKey, err := crypto.MarshalPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal creator public key"), err))
+	}
+	config := &pb.ChannelConfig{
+		ChannelID:              channel.GetId(),
+		Version:                channel.GetConfig().GetVersion() + 1,
+		TickSize:               in.GetTickSize(),
+		LotSize:                in.GetLotSize(),
+		MakerFee:               in.GetMakerFee(),
+		TakerFee:               in.GetTakerFee(),
+		SettlementInstructions: in.GetSettlementInstructions(),
+		MakerRateLimit:         in.GetMakerRateLimit(),
+		MaxMakerOrders:         in.GetMaxMakerOrders(),
+		CreatorPubKey:          creatorPubKey,
+	}
+	if in.GetPinMembers() {
+		config.AllowlistHash = getAllowlistHash(channel.GetMembership().GetMembers())
+	}
+	signingBytes, err := getChannelConfigSigningBytes(config)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal channel config"), err))
+	}
+	config.Signature, err = identity.Sign(s.Storage, signingBytes)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Sign channel config"), err))
+	}
+
+	channel.Config = config
+	marshaledChannel, err := proto.Marshal(channel)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal channel"), err))
+	}
+	err = s.Storage.Put(ctx, getChannelStorageKey(channel.GetId()), marshaledChannel)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Saving channel to database in PublishConfig"), err))
+	}
+
+	data, err := proto.Marshal(config)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal channel config"), err))
+	}
+	if s.P2p != nil {
+		err = s.P2p.Send(ctx, &pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_CHANNEL_CONFIG, Data: data})
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Broadcast channel config"), err))
+		}
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishConfig(t *testing.T) {
+	creatorService := newOwnershipTestService()
+	creatorID, _, err := creatorService.getMaker()
+	assert.NoError(t, err)
+	channelService := &ChannelService{Storage: creatorService.Storage}
+	request := &pb.ChannelConfigRequest{ChannelID: []byte(assetPair), TickSize: 0.5, MakerFee: 0.001, SettlementInstructions: "Settle on chain within an hour"}
+
+	// Someone else's channel
+	strangerID, _ := newStranger(t)
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{Creator: strangerID.String()})
+	_, err = channelService.PublishConfig(context.Background(), request)
+	assert.Error(t, err)
+
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{Creator: creatorID.String()})
+	_, err = channelService.PublishConfig(context.Background(), &pb.ChannelConfigRequest{ChannelID: []byte(assetPair), TakerFee: -1})
+	assert.Error(t, err)
+
+	channel, err := channelService.PublishConfig(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), channel.GetConfig().GetVersion())
+	assert.Equal(t, float32(0.5), channel.GetConfig().GetTickSize())
+	assert.NoError(t, verifyChannelConfig(&pb.Channel{Id: channel.GetId(), Options: channel.GetOptions()}, channel.GetConfig()))
+
+	channel, err = channelService.PublishConfig(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), channel.GetConfig().GetVersion())
+}
+
+func TestReceiveChannelConfig(t *testing.T) {
+	creatorService := newOwnershipTestService()
+	creatorID, _, err := creatorService.getMaker()
+	assert.NoError(t, err)
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{Creator: creatorID.String()})
+	channel, err := (&ChannelService{Storage: creatorService.Storage}).PublishConfig(context.Background(), &pb.ChannelConfigRequest{ChannelID: []byte(assetPair), TickSize: 0.5})
+	assert.NoError(t, err)
+	configInBytes, err := proto.Marshal(channel.GetConfig())
+	assert.NoError(t, err)
+
+	receiverService := newOwnershipTestService()
+	joinChannelWithOptions(t, receiverService, &pb.ChannelOptions{Creator: creatorID.String()})
+	makerService := newOwnershipTestService()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	// Before the config arrives, any price goes
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 1.25), makerID))
+
+	// Configs are signed, so anyone may relay them
+	relayID, _ := newStranger(t)
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CHANNEL_CONFIG, configInBytes, relayID))
+	assert.True(t, errors.Is(errors.Duplicate, receiveData(t, receiverService, pb.Operation_CHANNEL_CONFIG, configInBytes, relayID)))
+
+	err = receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 1.75), makerID)
+	assert.True(t, errors.Is(errors.Invalid, err))
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 1.5), makerID))
+
+	// A config tampered with on the way is rejected
+	tampered := *channel.GetConfig()
+	tampered.Version = 5
+	tampered.TickSize = 0
+	tamperedInBytes, err := proto.Marshal(&tampered)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(errors.InvalidSignature, receiveData(t, receiverService, pb.Operation_CHANNEL_CONFIG, tamperedInBytes, relayID)))
+}
+
+func TestPinnedAllowlist(t *testing.T) {
+	creatorService := newOwnershipTestService()
+	creatorID, _, err := creatorService.getMaker()
+	assert.NoError(t, err)
+	memberService := newOwnershipTestService()
+	memberID, _, err := memberService.getMaker()
+	assert.NoError(t, err)
+	strangerService := newOwnershipTestService()
+
+	channelService := &ChannelService{Storage: creatorService.Storage}
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{MembersOnly: true, Creator: creatorID.String()})
+	_, err = channelService.SetMembers(context.Background(), &pb.MembershipRequest{ChannelID: []byte(assetPair), Members: []string{memberID.String()}})
+	assert.NoError(t, err)
+	channel, err := channelService.PublishConfig(context.Background(), &pb.ChannelConfigRequest{ChannelID: []byte(assetPair), PinMembers: true})
+	assert.NoError(t, err)
+	assert.Equal(t, getAllowlistHash([]string{memberID.String()}), channel.GetConfig().GetAllowlistHash())
+
+	memberOrder := &pb.Order{}
+	assert.NoError(t, proto.Unmarshal(createTestOrder(t, memberService, testPrice), memberOrder))
+	assert.NoError(t, validateChannelConfig(channel, memberOrder))
+	strangerOrder := &pb.Order{}
+	assert.NoError(t, proto.Unmarshal(createTestOrder(t, strangerService, testPrice), strangerOrder))
+	assert.True(t, errors.Is(errors.Unauthorized, validateChannelConfig(channel, strangerOrder)))
+
+	// Members that don't match the pinned hash admit nobody but the creator
+	channel.Membership.Members = append(channel.Membership.Members, "another")
+	assert.True(t, errors.Is(errors.Unauthorized, validateChannelConfig(channel, memberOrder)))
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// checkClock flags a received order that was created further in the future than MaxClockSkew, meaning the maker's clock is ahead.
+// The order is still accepted, but it won't expire on time. The local clock is corrected by the median offset of peers' clocks, if any have been measured.
+func (s *OrderService) checkClock(order *pb.Order, makerID peer.ID) {
+	if s.MaxClockSkew == 0 {
+		return
+	}
+	created, err := ptypes.Timestamp(order.GetCreated())
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	if s.P2p != nil {
+		skew, _ := s.P2p.GetClockSkew()
+		now = now.Add(skew)
+	}
+	if ahead := created.Sub(now); ahead > s.MaxClockSkew {
+		s.count(skewedOrdersCounter)
+		s.Logger.Warnf("Order %x by %s was created %s in the future, the maker's clock may be off", order.GetId(), makerID, ahead)
+	}
+}
+
+// SkewedOrders returns how many received orders have been flagged for being created in the future
+func (s *OrderService) SkewedOrders() uint64 {
+	return s.sessionCount(skewedOrdersCounter)
+}
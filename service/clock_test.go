@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckClock(t *testing.T) {
+	clockService := newOwnershipTestService()
+	strangerID, _ := newStranger(t)
+
+	future, err := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	futureOrder := &pb.Order{Id: []byte("future"), Created: future}
+
+	// Nothing is flagged while the check is disabled
+	clockService.checkClock(futureOrder, strangerID)
+	assert.Equal(t, uint64(0), clockService.SkewedOrders())
+
+	clockService.MaxClockSkew = time.Minute
+	clockService.checkClock(&pb.Order{Id: []byte("now"), Created: ptypes.TimestampNow()}, strangerID)
+	assert.Equal(t, uint64(0), clockService.SkewedOrders())
+	clockService.checkClock(futureOrder, strangerID)
+	assert.Equal(t, uint64(1), clockService.SkewedOrders())
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Compact compacts the storage on disk, dropping the tombstones left behind by deleted and overwritten orders,
+// and reports how long it took and how much space it reclaimed. Only one compaction runs at a time.
+func (s *AdminService) Compact(ctx context.Context, in *pb.Empty) (*pb.CompactionReport, error) {
+	compactor, ok := s.Storage.(interfaces.Compactor)
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Compact storage"), errors.Invalid, "storage can't be compacted"))
+	}
+
+	s.compacting.Lock()
+	defer s.compacting.Unlock()
+
+	sizeBefore, err := compactor.Size()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Compact storage"), err)
+	}
+	started := time.Now()
+	err = compactor.Compact(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Compact storage"), err)
+	}
+	duration := time.Since(started)
+	sizeAfter, err := compactor.Size()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Compact storage"), err)
+	}
+
+	report := &pb.CompactionReport{
+		Duration:   uint64(duration / time.Millisecond),
+		SizeBefore: sizeBefore,
+		SizeAfter:  sizeAfter,
+	}
+	if sizeAfter < sizeBefore {
+		report.Reclaimed = sizeBefore - sizeAfter
+	}
+	report.Started, _ = ptypes.TimestampProto(started)
+	s.lastCompaction.Store(report)
+
+	if s.Logger != nil {
+		s.Logger.Infof("Compacted storage in %s, reclaiming %d MB (%d MB left)", duration, report.Reclaimed/bytesPerMegabyte, sizeAfter/bytesPerMegabyte)
+	}
+	return report, nil
+}
+
+// LastCompaction returns the report of the latest compaction since the node started, or nil if there hasn't been one
+func (s *AdminService) LastCompaction() *pb.CompactionReport {
+	report, _ := s.lastCompaction.Load().(*pb.CompactionReport)
+	if report == nil {
+		return nil
+	}
+	return proto.Clone(report).(*pb.CompactionReport)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// compactingStorage shrinks by half whenever it's compacted
+type compactingStorage struct {
+	interfaces.Storage
+	size      uint64
+	compacted int
+}
+
+func (storage *compactingStorage) Compact(ctx context.Context) error {
+	storage.compacted++
+	storage.size /= 2
+	return nil
+}
+
+func (storage *compactingStorage) Size() (uint64, error) {
+	return storage.size, nil
+}
+
+func TestCompact(t *testing.T) {
+	storage := &compactingStorage{Storage: &inmemory.Storage{Db: make(map[string]string)}, size: 4 * bytesPerMegabyte}
+	adminService := &AdminService{Logger: new(util.PlaceholderLogger)}
+	adminService.RegisterStorage(storage)
+	assert.Nil(t, adminService.LastCompaction())
+
+	report, err := adminService.Compact(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, storage.compacted)
+	assert.Equal(t, 4*bytesPerMegabyte, report.GetSizeBefore())
+	assert.Equal(t, 2*bytesPerMegabyte, report.GetSizeAfter())
+	assert.Equal(t, 2*bytesPerMegabyte, report.GetReclaimed())
+	assert.NotNil(t, report.GetStarted())
+	assert.Equal(t, report.GetReclaimed(), adminService.LastCompaction().GetReclaimed())
+
+	// Storages kept in memory can't be compacted
+	adminService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	_, err = adminService.Compact(context.Background(), &pb.Empty{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Names of the counters that are kept across restarts
+const ordersCreatedCounter string = "ordersCreated"
+const fillsReportedCounter string = "fillsReported"
+const messagesProcessedCounter string = "messagesProcessed"
+const messagesRejectedCounter string = "messagesRejected"
+const skewedOrdersCounter string = "skewedOrders"
+const orderIDMismatchesCounter string = "orderIDMismatches"
+const orderIDCollisionsCounter string = "orderIDCollisions"
+
+// counterNames lists the counters in the order they're shown in
+var counterNames = []string{
+	ordersCreatedCounter,
+	fillsReportedCounter,
+	messagesProcessedCounter,
+	messagesRejectedCounter,
+	skewedOrdersCounter,
+	orderIDMismatchesCounter,
+	orderIDCollisionsCounter,
+}
+
+func getCounterStorageKey(name string) []byte {
+	return []byte(strings.Join([]string{string(interfaces.CounterPrefix), name}, ""))
+}
+
+// counters counts events since the process started, on top of the all-time totals that were stored when it started
+type counters struct {
+	session map[string]uint64
+	stored  map[string]uint64
+	loaded  bool
+	lock    sync.Mutex
+}
+
+// count adds one to the named counter
+func (s *OrderService) count(name string) {
+	s.counters.lock.Lock()
+	defer s.counters.lock.Unlock()
+	if s.counters.session == nil {
+		s.counters.session = make(map[string]uint64)
+	}
+	s.counters.session[name]++
+}
+
+// sessionCount returns the value of the named counter since the process started
+func (s *OrderService) sessionCount(name string) uint64 {
+	s.counters.lock.Lock()
+	defer s.counters.lock.Unlock()
+	return s.counters.session[name]
+}
+
+// LoadCounters restores the all-time totals of the counters from storage. It's called once at startup.
+func (s *OrderService) LoadCounters(ctx context.Context) error {
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.CounterPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get stored counters"), err)
+	}
+	stored := make(map[string]uint64)
+	for key, value := range data {
+		if len(value) == 8 {
+			stored[strings.TrimPrefix(key, string(interfaces.CounterPrefix))] = binary.BigEndian.Uint64([]byte(value))
+		}
+	}
+
+	s.counters.lock.Lock()
+	s.counters.stored = stored
+	s.counters.loaded = true
+	s.counters.lock.Unlock()
+	return nil
+}
+
+// SaveCounters stores the all-time totals of the counters, so they carry on from there after a restart.
+// Nothing is stored before LoadCounters, which would overwrite the totals with the counts of this process.
+func (s *OrderService) SaveCounters(ctx context.Context) error {
+	s.counters.lock.Lock()
+	loaded := s.counters.loaded
+	s.counters.lock.Unlock()
+	if !loaded {
+		return nil
+	}
+	for _, counter := range s.Counters() {
+		data := make([]byte, 8)
+		binary.BigEndian.PutUint64(data, counter.GetAllTime())
+		err := s.Storage.Put(ctx, getCounterStorageKey(counter.GetName()), data)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put counter "+counter.GetName()), err)
+		}
+	}
+	return nil
+}
+
+// Counters returns the value of each counter since the process started and of all time
+func (s *OrderService) Counters() []*pb.Counter {
+	s.counters.lock.Lock()
+	defer s.counters.lock.Unlock()
+	result := make([]*pb.Counter, 0, len(counterNames))
+	for _, name := range counterNames {
+		session := s.counters.session[name]
+		result = append(result, &pb.Counter{Name: name, Session: session, AllTime: s.counters.stored[name] + session})
+	}
+	return result
+}
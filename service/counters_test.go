@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func getCounter(counters []*pb.Counter, name string) *pb.Counter {
+	for _, counter := range counters {
+		if counter.GetName() == name {
+			return counter
+		}
+	}
+	return nil
+}
+
+func TestCounters(t *testing.T) {
+	counterService := newOwnershipTestService()
+
+	// Nothing is stored before the stored totals have been loaded
+	counterService.count(ordersCreatedCounter)
+	assert.NoError(t, counterService.SaveCounters(context.Background()))
+	stored, err := counterService.Storage.GetAllWithPrefix(context.Background(), string(getCounterStorageKey("")))
+	assert.NoError(t, err)
+	assert.Empty(t, stored)
+
+	assert.NoError(t, counterService.LoadCounters(context.Background()))
+	_, err = counterService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	created := getCounter(counterService.Counters(), ordersCreatedCounter)
+	assert.Equal(t, uint64(2), created.GetSession())
+	assert.Equal(t, uint64(2), created.GetAllTime())
+	assert.NoError(t, counterService.SaveCounters(context.Background()))
+
+	// A restarted node carries on from the stored totals
+	restartedService := &OrderService{Logger: counterService.Logger}
+	restartedService.RegisterStorage(counterService.Storage)
+	assert.NoError(t, restartedService.LoadCounters(context.Background()))
+	restartedService.count(ordersCreatedCounter)
+	created = getCounter(restartedService.Counters(), ordersCreatedCounter)
+	assert.Equal(t, uint64(1), created.GetSession())
+	assert.Equal(t, uint64(3), created.GetAllTime())
+	assert.Equal(t, uint64(0), getCounter(restartedService.Counters(), skewedOrdersCounter).GetAllTime())
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// dashboardAPIPath is where the dashboard page fetches its data from
+const dashboardAPIPath string = "/api"
+
+// dashboardErrors is how many of the latest dead letters the dashboard shows
+const dashboardErrors int = 20
+
+// dashboardCounter is one of the node's counters on the dashboard
+type dashboardCounter struct {
+	Name    string `json:"name"`
+	Session uint64 `json:"session"`
+	AllTime uint64 `json:"allTime"`
+}
+
+// dashboardPeer is a connected peer on the dashboard
+type dashboardPeer struct {
+	ID           string   `json:"id"`
+	Addresses    []string `json:"addresses"`
+	AgentVersion string   `json:"agentVersion"`
+	Direction    string   `json:"direction"`
+	Channels     []string `json:"channels"`
+	Latency      int64    `json:"latency"`
+	RateIn       float64  `json:"rateIn"`
+	RateOut      float64  `json:"rateOut"`
+}
+
+// dashboardChannel is a joined channel on the dashboard. Its ticker is only there with market data enabled.
+type dashboardChannel struct {
+	ID     string     `json:"id"`
+	Pair   string     `json:"pair"`
+	Orders int        `json:"orders"`
+	Ticker *apiTicker `json:"ticker,omitempty"`
+}
+
+// dashboardError is a received message that failed processing
+type dashboardError struct {
+	From      string    `json:"from"`
+	Reason    string    `json:"reason"`
+	RequestID string    `json:"requestID"`
+	Received  time.Time `json:"received"`
+}
+
+// dashboardStatus is everything the dashboard page shows, fetched again every few seconds
+type dashboardStatus struct {
+	ID                 string             `json:"id"`
+	Standby            bool               `json:"standby"`
+	ListenAddresses    []string           `json:"listenAddresses"`
+	AnnouncedAddresses []string           `json:"announcedAddresses"`
+	ReadOnly           bool               `json:"readOnly"`
+	FreeDiskSpace      uint64             `json:"freeDiskSpace"`
+	ClockSkew          int64              `json:"clockSkew"`
+	OrderCacheHits     uint64             `json:"orderCacheHits"`
+	OrderCacheMisses   uint64             `json:"orderCacheMisses"`
+	Counters           []dashboardCounter `json:"counters"`
+	Peers              []dashboardPeer    `json:"peers"`
+	Channels           []dashboardChannel `json:"channels"`
+	Errors             []dashboardError   `json:"errors"`
+	Intervals          []uint32           `json:"intervals"`
+}
+
+// dashboardCandle is a candle in a dashboard graph
+type dashboardCandle struct {
+	Start  time.Time `json:"start"`
+	Close  float32   `json:"close"`
+	Volume uint64    `json:"volume"`
+}
+
+// newDashboard serves the operator dashboard on DashboardPort: a page that polls the node's status and draws graphs of it
+func (server *Server) newDashboard() *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.serveDashboardAsset)
+	mux.HandleFunc(dashboardAPIPath+"/status", server.serveDashboardStatus)
+	mux.HandleFunc(dashboardAPIPath+"/candles", server.serveDashboardCandles)
+	return &http.Server{Addr: fmt.Sprintf(":%d", server.DashboardPort), Handler: mux}
+}
+
+func (server *Server) runDashboard() {
+	server.Logger.Infof("Serving the dashboard on port %d", server.DashboardPort)
+	err := server.dashboard.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		server.Logger.Error(errors.E(errors.Op("Serve dashboard"), err))
+	}
+}
+
+// closeDashboard stops the dashboard, if it's running
+func (server *Server) closeDashboard() {
+	if server.dashboard != nil {
+		server.dashboard.Shutdown(context.Background())
+	}
+}
+
+// authorizeDashboard tells if the request may see the dashboard. With API keys configured, it needs the key of
+// a namespace with the admin role, as the password of HTTP basic authentication so that browsers ask for it.
+func (server *Server) authorizeDashboard(w http.ResponseWriter, r *http.Request) bool {
+	if len(server.APIKeys) == 0 {
+		return true
+	}
+	key := strings.TrimPrefix(r.Header.Get("Authorization"), bearerPrefix)
+	if _, password, ok := r.BasicAuth(); ok {
+		key = password
+	}
+	if namespace, ok := server.APIKeys[key]; ok && server.getRole(namespace) == RoleAdmin {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", `Basic realm="Sprawl dashboard"`)
+	http.Error(w, "the dashboard needs an API key with the admin role", http.StatusUnauthorized)
+	return false
+}
+
+// checkDashboardRequest answers the requests that can't be served, and tells if the request can be
+func (server *Server) checkDashboardRequest(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "the dashboard is read-only", http.StatusMethodNotAllowed)
+		return false
+	}
+	return server.authorizeDashboard(w, r)
+}
+
+// writeDashboardJSON answers with the value as JSON, which is never cached since the dashboard polls for changes
+func writeDashboardJSON(w http.ResponseWriter, value interface{}) {
+	body, err := json.Marshal(value)
+	if !errors.IsEmpty(err) {
+		http.Error(w, errors.E(errors.Op("Marshal dashboard data"), err).Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// serveDashboardAsset serves the static files of the dashboard page
+func (server *Server) serveDashboardAsset(w http.ResponseWriter, r *http.Request) {
+	if !server.checkDashboardRequest(w, r) {
+		return
+	}
+	path := r.URL.Path
+	if path == "/" {
+		path = "/index.html"
+	}
+	asset, ok := dashboardAssets[path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", asset.contentType)
+	w.Write([]byte(asset.content))
+}
+
+// serveDashboardStatus serves the node's info, its peers, the joined channels and the latest errors
+func (server *Server) serveDashboardStatus(w http.ResponseWriter, r *http.Request) {
+	if !server.checkDashboardRequest(w, r) {
+		return
+	}
+	// A standby's p2p host isn't running and its storage is still being replicated, so there's nothing else to show
+	if atomic.LoadInt32(&server.standby) == 1 {
+		writeDashboardJSON(w, dashboardStatus{Standby: true})
+		return
+	}
+	ctx := r.Context()
+	status, err := server.getDashboardStatus(ctx)
+	if !errors.IsEmpty(err) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeDashboardJSON(w, status)
+}
+
+func (server *Server) getDashboardStatus(ctx context.Context) (*dashboardStatus, error) {
+	info, err := server.Node.GetNodeInfo(ctx, &pb.Empty{})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get node info"), err)
+	}
+	status := &dashboardStatus{
+		ID:                 info.GetId(),
+		ListenAddresses:    info.GetListenAddresses(),
+		AnnouncedAddresses: info.GetAnnouncedAddresses(),
+		ReadOnly:           info.GetReadOnly(),
+		FreeDiskSpace:      info.GetFreeDiskSpace(),
+		ClockSkew:          info.GetClockSkew(),
+		OrderCacheHits:     info.GetOrderCacheHits(),
+		OrderCacheMisses:   info.GetOrderCacheMisses(),
+		Counters:           []dashboardCounter{},
+		Peers:              []dashboardPeer{},
+		Channels:           []dashboardChannel{},
+		Errors:             []dashboardError{},
+		Intervals:          []uint32{},
+	}
+	for _, counter := range info.GetCounters() {
+		status.Counters = append(status.Counters, dashboardCounter{Name: counter.GetName(), Session: counter.GetSession(), AllTime: counter.GetAllTime()})
+	}
+
+	peers, err := server.Node.GetPeers(ctx, &pb.Empty{})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get peers"), err)
+	}
+	for _, details := range peers.GetPeers() {
+		peer := dashboardPeer{
+			ID:           details.GetId(),
+			Addresses:    details.GetAddresses(),
+			AgentVersion: details.GetAgentVersion(),
+			Direction:    details.GetDirection().String(),
+			Channels:     []string{},
+			Latency:      details.GetLatency(),
+			RateIn:       details.GetRateIn(),
+			RateOut:      details.GetRateOut(),
+		}
+		for _, channel := range details.GetChannels() {
+			peer.Channels = append(peer.Channels, string(channel))
+		}
+		status.Peers = append(status.Peers, peer)
+	}
+
+	channels, err := server.Channels.GetAllChannels(ctx, &pb.Empty{})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channels"), err)
+	}
+	for _, joined := range channels.GetChannels() {
+		request := &pb.ChannelSpecificRequest{Id: joined.GetId()}
+		orders, err := server.Orders.GetOrderBook(ctx, request)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Get order book"), err)
+		}
+		channel := dashboardChannel{ID: string(joined.GetId()), Pair: marketPair(joined.GetId()), Orders: len(orders.GetOrders())}
+		if len(server.MarketData.Intervals) > 0 {
+			ticker, err := server.MarketData.GetTicker(ctx, request)
+			if !errors.IsEmpty(err) {
+				return nil, errors.E(errors.Op("Get ticker"), err)
+			}
+			channel.Ticker = &apiTicker{
+				Last:   ticker.GetLast(),
+				Open:   ticker.GetOpen(),
+				High:   ticker.GetHigh(),
+				Low:    ticker.GetLow(),
+				Change: ticker.GetChange(),
+				Volume: ticker.GetVolume(),
+				Trades: ticker.GetTrades(),
+			}
+		}
+		status.Channels = append(status.Channels, channel)
+	}
+	sort.Slice(status.Channels, func(i, j int) bool { return status.Channels[i].ID < status.Channels[j].ID })
+	for _, interval := range server.MarketData.Intervals {
+		status.Intervals = append(status.Intervals, uint32(interval/time.Second))
+	}
+
+	// Dead letters are kept oldest first, and the dashboard shows the newest first
+	deadLetters, err := server.Admin.GetDeadLetters(ctx, &pb.Empty{})
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get dead letters"), err)
+	}
+	letters := deadLetters.GetDeadLetters()
+	for i := len(letters) - 1; i >= 0 && len(status.Errors) < dashboardErrors; i-- {
+		received, _ := ptypes.Timestamp(letters[i].GetReceived())
+		status.Errors = append(status.Errors, dashboardError{
+			From:      letters[i].GetFrom(),
+			Reason:    letters[i].GetReason(),
+			RequestID: letters[i].GetRequestID(),
+			Received:  received.UTC(),
+		})
+	}
+	return status, nil
+}
+
+// serveDashboardCandles serves the candles of a channel for the price and volume graphs.
+// The channel and interval query parameters pick the channel and the interval in seconds.
+func (server *Server) serveDashboardCandles(w http.ResponseWriter, r *http.Request) {
+	if !server.checkDashboardRequest(w, r) {
+		return
+	}
+	if len(server.MarketData.Intervals) == 0 {
+		http.Error(w, "market data is disabled", http.StatusServiceUnavailable)
+		return
+	}
+	request := &pb.CandleRequest{ChannelID: []byte(r.URL.Query().Get("channel"))}
+	if value := r.URL.Query().Get("interval"); value != "" {
+		interval, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			http.Error(w, "interval should be a number of seconds", http.StatusBadRequest)
+			return
+		}
+		request.Interval = uint32(interval)
+	}
+	candles, err := server.MarketData.GetCandles(r.Context(), request)
+	if !errors.IsEmpty(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	response := make([]dashboardCandle, 0, len(candles.GetCandles()))
+	for _, candle := range candles.GetCandles() {
+		start, _ := ptypes.Timestamp(candle.GetStart())
+		response = append(response, dashboardCandle{Start: start.UTC(), Close: candle.GetClose(), Volume: candle.GetVolume()})
+	}
+	writeDashboardJSON(w, map[string][]dashboardCandle{"candles": response})
+}
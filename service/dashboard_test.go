@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/interfaces/mocks"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func getDashboard(handler http.Handler, path string, key string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	if key != "" {
+		request.SetBasicAuth("", key)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestDashboard(t *testing.T) {
+	ctx := context.Background()
+	p2p := new(mocks.P2p)
+	p2p.On("GetClockSkew").Return(time.Duration(0), 0)
+	p2p.On("GetHostIDString").Return("node")
+	p2p.On("GetPeerScores").Return([]*pb.PeerScore{})
+	p2p.On("GetListenAddresses").Return([]string{"/ip4/127.0.0.1/tcp/4001"})
+	p2p.On("GetAnnouncedAddresses").Return([]string{})
+	p2p.On("GetPeerDetails").Return([]*pb.PeerDetails{{Id: "peer", Channels: [][]byte{[]byte(assetPair)}, Latency: 20}})
+
+	server := NewServer(log, &inmemory.Storage{Db: make(map[string]string)}, p2p, nil)
+	server.MarketData.Intervals = []time.Duration{time.Minute}
+	server.APIKeys = map[string]string{"admin-key": AdminNamespace, "bot-key": "bot"}
+	handler := server.newDashboard().Handler
+
+	channelInBytes, err := proto.Marshal(&pb.Channel{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.NoError(t, server.Channels.Storage.Put(ctx, getChannelStorageKey([]byte(assetPair)), channelInBytes))
+	order := &pb.Order{Id: []byte("order"), Asset: asset1, CounterAsset: asset2, Amount: 100, Price: testPrice, Created: ptypes.TimestampNow()}
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Orders.putOrder(ctx, []byte(assetPair), order, orderInBytes))
+	assert.NoError(t, server.MarketData.recordTrade(ctx, []byte(assetPair), order.GetId(), testPrice, 40, time.Now()))
+
+	// Only admins get to see the dashboard
+	assert.Equal(t, http.StatusUnauthorized, getDashboard(handler, "/", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, getDashboard(handler, "/", "bot-key").Code)
+	response := getDashboard(handler, "/", "admin-key")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.True(t, strings.HasPrefix(response.Header().Get("Content-Type"), "text/html"))
+	assert.Equal(t, http.StatusOK, getDashboard(handler, "/dashboard.js", "admin-key").Code)
+	assert.Equal(t, http.StatusNotFound, getDashboard(handler, "/unknown.js", "admin-key").Code)
+
+	response = getDashboard(handler, dashboardAPIPath+"/status", "admin-key")
+	assert.Equal(t, http.StatusOK, response.Code)
+	status := dashboardStatus{}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &status))
+	assert.Equal(t, "node", status.ID)
+	assert.Equal(t, 1, len(status.Peers))
+	assert.Equal(t, []string{assetPair}, status.Peers[0].Channels)
+	assert.Equal(t, 1, len(status.Channels))
+	assert.Equal(t, 1, status.Channels[0].Orders)
+	assert.Equal(t, uint64(40), status.Channels[0].Ticker.Volume)
+	assert.Equal(t, []uint32{60}, status.Intervals)
+	assert.Empty(t, status.Errors)
+
+	response = getDashboard(handler, dashboardAPIPath+"/candles?channel="+assetPair+"&interval=60", "admin-key")
+	assert.Equal(t, http.StatusOK, response.Code)
+	candles := map[string][]dashboardCandle{}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &candles))
+	assert.Equal(t, 1, len(candles["candles"]))
+	assert.Equal(t, http.StatusBadRequest, getDashboard(handler, dashboardAPIPath+"/candles?channel="+assetPair+"&interval=5", "admin-key").Code)
+
+	// A standby only tells that it's standing by
+	server.SetStandby(true)
+	response = getDashboard(handler, dashboardAPIPath+"/status", "admin-key")
+	assert.Equal(t, http.StatusOK, response.Code)
+	status = dashboardStatus{}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &status))
+	assert.True(t, status.Standby)
+	assert.Empty(t, status.ID)
+}
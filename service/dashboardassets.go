@@ -0,0 +1,393 @@
+package service
+
+// dashboardAsset is a static file of the dashboard page
+type dashboardAsset struct {
+	contentType string
+	content     string
+}
+
+// dashboardAssets are the files of the dashboard page by path. They're kept in the binary,
+// so the dashboard works without anything installed next to the node.
+var dashboardAssets = map[string]dashboardAsset{
+	"/index.html":    {"text/html; charset=utf-8", dashboardHTML},
+	"/dashboard.css": {"text/css; charset=utf-8", dashboardCSS},
+	"/dashboard.js":  {"application/javascript; charset=utf-8", dashboardJS},
+}
+
+const dashboardHTML string = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sprawl node</title>
+<link rel="stylesheet" href="dashboard.css">
+</head>
+<body>
+<header>
+	<h1>Sprawl node</h1>
+	<span id="node-id"></span>
+	<span id="node-state" class="badge"></span>
+	<span id="updated"></span>
+</header>
+<main>
+	<section id="overview" class="cards"></section>
+	<section>
+		<h2>Activity</h2>
+		<p class="hint">Per second, over the last minutes this page has been open</p>
+		<div id="graphs" class="graphs"></div>
+	</section>
+	<section>
+		<h2>Channels</h2>
+		<table>
+			<thead><tr><th>Pair</th><th>ID</th><th>Open orders</th><th>Last</th><th>24h change</th><th>24h volume</th></tr></thead>
+			<tbody id="channels"></tbody>
+		</table>
+		<div id="market" hidden>
+			<label>Channel <select id="market-channel"></select></label>
+			<label>Interval <select id="market-interval"></select></label>
+			<div class="graphs">
+				<figure><figcaption>Close</figcaption><svg id="market-price" viewBox="0 0 300 80" preserveAspectRatio="none"></svg></figure>
+				<figure><figcaption>Volume</figcaption><svg id="market-volume" viewBox="0 0 300 80" preserveAspectRatio="none"></svg></figure>
+			</div>
+		</div>
+	</section>
+	<section>
+		<h2>Peers</h2>
+		<table>
+			<thead><tr><th>ID</th><th>Agent</th><th>Direction</th><th>Latency</th><th>In</th><th>Out</th><th>Shared channels</th></tr></thead>
+			<tbody id="peers"></tbody>
+		</table>
+	</section>
+	<section>
+		<h2>Recent errors</h2>
+		<p class="hint">Received messages that failed processing, newest first</p>
+		<table>
+			<thead><tr><th>Received</th><th>From</th><th>Reason</th><th>Request</th></tr></thead>
+			<tbody id="errors"></tbody>
+		</table>
+	</section>
+</main>
+<script src="dashboard.js"></script>
+</body>
+</html>
+`
+
+const dashboardCSS string = `body {
+	margin: 0;
+	font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif;
+	font-size: 14px;
+	color: #1f2328;
+	background: #f6f8fa;
+}
+header {
+	display: flex;
+	align-items: baseline;
+	gap: 1em;
+	padding: 0.75em 1.5em;
+	background: #24292f;
+	color: #fff;
+}
+header h1 {
+	margin: 0;
+	font-size: 1.25em;
+}
+#node-id, #updated {
+	font-family: monospace;
+	opacity: 0.8;
+}
+#updated {
+	margin-left: auto;
+}
+main {
+	padding: 0 1.5em 1.5em;
+}
+h2 {
+	margin: 1.5em 0 0.25em;
+	font-size: 1.1em;
+}
+.hint {
+	margin: 0 0 0.5em;
+	color: #656d76;
+}
+.badge {
+	padding: 0.1em 0.6em;
+	border-radius: 1em;
+	background: #1a7f37;
+	font-size: 0.85em;
+}
+.badge.warn {
+	background: #bf8700;
+}
+.badge.error {
+	background: #cf222e;
+}
+.cards, .graphs {
+	display: grid;
+	grid-template-columns: repeat(auto-fill, minmax(200px, 1fr));
+	gap: 0.75em;
+	margin-top: 1em;
+}
+.card, figure {
+	margin: 0;
+	padding: 0.75em;
+	background: #fff;
+	border: 1px solid #d0d7de;
+	border-radius: 6px;
+}
+.card .value {
+	font-size: 1.5em;
+	font-weight: 600;
+}
+.card .label, figcaption {
+	color: #656d76;
+}
+figcaption span {
+	float: right;
+	color: #1f2328;
+}
+svg {
+	width: 100%;
+	height: 80px;
+}
+svg polyline {
+	fill: none;
+	stroke: #0969da;
+	stroke-width: 1.5;
+	vector-effect: non-scaling-stroke;
+}
+svg rect {
+	fill: #54aeff;
+}
+table {
+	width: 100%;
+	border-collapse: collapse;
+	background: #fff;
+	border: 1px solid #d0d7de;
+}
+th, td {
+	padding: 0.4em 0.6em;
+	border-bottom: 1px solid #d0d7de;
+	text-align: left;
+	vertical-align: top;
+}
+th {
+	background: #f6f8fa;
+	font-weight: 600;
+}
+td.id {
+	font-family: monospace;
+	max-width: 16em;
+	overflow: hidden;
+	text-overflow: ellipsis;
+	white-space: nowrap;
+}
+label {
+	display: inline-block;
+	margin: 1em 1em 0 0;
+}
+`
+
+const dashboardJS string = `"use strict";
+
+// How often the status is fetched, and how many samples the activity graphs keep
+const pollInterval = 5000;
+const maxSamples = 120;
+
+const samples = [];
+let previous = null;
+
+function element(id) {
+	return document.getElementById(id);
+}
+
+function text(value) {
+	const span = document.createElement("span");
+	span.textContent = value;
+	return span.innerHTML;
+}
+
+function bytes(value) {
+	const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+	let unit = 0;
+	while (value >= 1024 && unit < units.length - 1) {
+		value /= 1024;
+		unit++;
+	}
+	return value.toFixed(unit === 0 ? 0 : 1) + " " + units[unit];
+}
+
+function rows(id, items, columns, empty) {
+	const body = element(id);
+	if (items.length === 0) {
+		body.innerHTML = "<tr><td colspan=\"" + columns + "\">" + empty + "</td></tr>";
+		return null;
+	}
+	return body;
+}
+
+function drawLine(svg, values) {
+	const max = Math.max(1, ...values);
+	const step = values.length > 1 ? 300 / (values.length - 1) : 300;
+	const points = values.map((value, i) => (i * step).toFixed(1) + "," + (78 - value / max * 76).toFixed(1));
+	svg.innerHTML = "<polyline points=\"" + points.join(" ") + "\"></polyline>";
+}
+
+function drawBars(svg, values) {
+	const max = Math.max(1, ...values);
+	const width = 300 / Math.max(1, values.length);
+	svg.innerHTML = values.map((value, i) => {
+		const height = value / max * 78;
+		return "<rect x=\"" + (i * width).toFixed(1) + "\" y=\"" + (80 - height).toFixed(1) + "\" width=\"" + Math.max(1, width - 1).toFixed(1) + "\" height=\"" + height.toFixed(1) + "\"></rect>";
+	}).join("");
+}
+
+// record turns the counters, which only grow, into rates since the previous status
+function record(status) {
+	const now = Date.now();
+	const counters = {};
+	status.counters.forEach(counter => { counters[counter.name] = counter.session; });
+	if (previous !== null) {
+		const seconds = (now - previous.time) / 1000;
+		const sample = { peers: status.peers.length, rateIn: 0, rateOut: 0 };
+		Object.keys(counters).forEach(name => {
+			sample[name] = Math.max(0, counters[name] - (previous.counters[name] || 0)) / seconds;
+		});
+		status.peers.forEach(peer => {
+			sample.rateIn += peer.rateIn;
+			sample.rateOut += peer.rateOut;
+		});
+		samples.push(sample);
+		if (samples.length > maxSamples) {
+			samples.shift();
+		}
+	}
+	previous = { time: now, counters: counters };
+}
+
+function renderGraphs(status) {
+	const graphs = [{ key: "peers", label: "Peers", format: value => value.toFixed(0) }];
+	status.counters.forEach(counter => graphs.push({ key: counter.name, label: counter.name, format: value => value.toFixed(2) }));
+	graphs.push({ key: "rateIn", label: "Bytes in", format: bytes }, { key: "rateOut", label: "Bytes out", format: bytes });
+
+	const container = element("graphs");
+	graphs.forEach(graph => {
+		let figure = element("graph-" + graph.key);
+		if (figure === null) {
+			figure = document.createElement("figure");
+			figure.id = "graph-" + graph.key;
+			figure.innerHTML = "<figcaption>" + text(graph.label) + " <span></span></figcaption><svg viewBox=\"0 0 300 80\" preserveAspectRatio=\"none\"></svg>";
+			container.appendChild(figure);
+		}
+		const values = samples.map(sample => sample[graph.key] || 0);
+		figure.querySelector("figcaption span").textContent = values.length > 0 ? graph.format(values[values.length - 1]) : "";
+		drawLine(figure.querySelector("svg"), values);
+	});
+}
+
+function renderOverview(status) {
+	const cards = [
+		["Peers", status.peers.length],
+		["Channels", status.channels.length],
+		["Open orders", status.channels.reduce((total, channel) => total + channel.orders, 0)],
+		["Free disk space", status.freeDiskSpace > 0 ? bytes(status.freeDiskSpace) : "unknown"],
+		["Clock skew", status.clockSkew + " ms"],
+		["Order cache hits", status.orderCacheHits + " / " + (status.orderCacheHits + status.orderCacheMisses)],
+	];
+	status.counters.forEach(counter => cards.push([counter.name, counter.session + " (" + counter.allTime + " all time)"]));
+	element("overview").innerHTML = cards.map(card =>
+		"<div class=\"card\"><div class=\"value\">" + text(String(card[1])) + "</div><div class=\"label\">" + text(card[0]) + "</div></div>").join("");
+}
+
+function renderChannels(status) {
+	const body = rows("channels", status.channels, 6, "No joined channels");
+	if (body !== null) {
+		body.innerHTML = status.channels.map(channel => {
+			const ticker = channel.ticker || {};
+			return "<tr><td>" + text(channel.pair) + "</td><td class=\"id\">" + text(channel.id) + "</td><td>" + channel.orders +
+				"</td><td>" + (channel.ticker ? ticker.last : "") + "</td><td>" + (channel.ticker ? (ticker.change * 100).toFixed(2) + " %" : "") +
+				"</td><td>" + (channel.ticker ? ticker.volume : "") + "</td></tr>";
+		}).join("");
+	}
+
+	// The market graphs need market data, which lists the intervals candles are aggregated over
+	const market = element("market");
+	market.hidden = status.intervals.length === 0 || status.channels.length === 0;
+	if (market.hidden) {
+		return;
+	}
+	const channelSelect = element("market-channel");
+	const selectedChannel = channelSelect.value;
+	channelSelect.innerHTML = status.channels.map(channel => "<option value=\"" + text(channel.id) + "\">" + text(channel.pair) + "</option>").join("");
+	if (status.channels.some(channel => channel.id === selectedChannel)) {
+		channelSelect.value = selectedChannel;
+	}
+	const intervalSelect = element("market-interval");
+	if (intervalSelect.options.length !== status.intervals.length) {
+		intervalSelect.innerHTML = status.intervals.map(interval => "<option value=\"" + interval + "\">" + interval + " s</option>").join("");
+	}
+	renderMarket();
+}
+
+function renderMarket() {
+	const channel = element("market-channel").value;
+	const interval = element("market-interval").value;
+	fetch("api/candles?channel=" + encodeURIComponent(channel) + "&interval=" + encodeURIComponent(interval))
+		.then(response => response.ok ? response.json() : Promise.reject(response.statusText))
+		.then(data => {
+			drawLine(element("market-price"), data.candles.map(candle => candle.close));
+			drawBars(element("market-volume"), data.candles.map(candle => candle.volume));
+		})
+		.catch(() => {});
+}
+
+function renderPeers(status) {
+	const body = rows("peers", status.peers, 7, "No connected peers");
+	if (body !== null) {
+		body.innerHTML = status.peers.map(peer =>
+			"<tr><td class=\"id\" title=\"" + text(peer.addresses.join(" ")) + "\">" + text(peer.id) + "</td><td>" + text(peer.agentVersion) +
+			"</td><td>" + text(peer.direction) + "</td><td>" + peer.latency + " ms</td><td>" + bytes(peer.rateIn) + "/s</td><td>" + bytes(peer.rateOut) +
+			"/s</td><td>" + text(peer.channels.join(", ")) + "</td></tr>").join("");
+	}
+}
+
+function renderErrors(status) {
+	const body = rows("errors", status.errors, 4, "No failed messages");
+	if (body !== null) {
+		body.innerHTML = status.errors.map(error =>
+			"<tr><td>" + text(new Date(error.received).toLocaleString()) + "</td><td class=\"id\">" + text(error.from) + "</td><td>" + text(error.reason) +
+			"</td><td class=\"id\">" + text(error.requestID) + "</td></tr>").join("");
+	}
+}
+
+function setState(label, level) {
+	const state = element("node-state");
+	state.textContent = label;
+	state.className = "badge " + level;
+}
+
+function refresh() {
+	fetch("api/status")
+		.then(response => response.ok ? response.json() : Promise.reject(response.status + " " + response.statusText))
+		.then(status => {
+			element("updated").textContent = "Updated " + new Date().toLocaleTimeString();
+			if (status.standby) {
+				setState("standby", "warn");
+				return;
+			}
+			element("node-id").textContent = status.id;
+			setState(status.readOnly ? "read-only" : "running", status.readOnly ? "error" : "");
+			record(status);
+			renderOverview(status);
+			renderGraphs(status);
+			renderChannels(status);
+			renderPeers(status);
+			renderErrors(status);
+		})
+		.catch(error => setState("unreachable: " + error, "error"));
+}
+
+element("market-channel").addEventListener("change", renderMarket);
+element("market-interval").addEventListener("change", renderMarket);
+refresh();
+setInterval(refresh, pollInterval);
+`
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
+)
+
+// deadLetterIDLength is the length of the time and sequence number that identify a dead letter
+const deadLetterIDLength int = 16
+
+// getDeadLetterID orders dead letters by time. The sequence number keeps messages received at the same time apart.
+func getDeadLetterID(timestamp time.Time, sequence uint64) []byte {
+	id := make([]byte, deadLetterIDLength)
+	binary.BigEndian.PutUint64(id, uint64(timestamp.UnixNano()))
+	binary.BigEndian.PutUint64(id[8:], sequence)
+	return id
+}
+
+func getDeadLetterStorageKey(id []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.DeadLetterPrefix), string(id)}, ""))
+}
+
+// deadLetter keeps a received message that failed processing, with the sender, when it was received and the reason,
+// dropping the oldest dead letters once there are more than MaxDeadLetters
+func (s *OrderService) deadLetter(ctx context.Context, msg interfaces.IncomingMessage, err error) {
+	if s.MaxDeadLetters == 0 || s.Storage == nil {
+		return
+	}
+	now := msg.ReceivedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	deadLetter := &pb.DeadLetter{Data: msg.Data, From: msg.From.String(), Reason: err.Error(), RequestID: requestid.FromContext(ctx)}
+	deadLetter.Received, _ = ptypes.TimestampProto(now)
+	data, marshalErr := proto.Marshal(deadLetter)
+	if !errors.IsEmpty(marshalErr) {
+		s.Logger.Error(errors.E(errors.Op("Marshal dead letter"), marshalErr))
+		return
+	}
+	id := getDeadLetterID(now, atomic.AddUint64(&s.deadLetterSequence, 1))
+	putErr := s.Storage.Put(ctx, getDeadLetterStorageKey(id), data)
+	if !errors.IsEmpty(putErr) {
+		requestid.Logger(ctx, s.Logger).Error(errors.E(errors.Op("Put dead letter"), putErr))
+		return
+	}
+
+	count, countErr := s.Storage.Count(ctx, string(interfaces.DeadLetterPrefix))
+	if !errors.IsEmpty(countErr) || uint(count) <= s.MaxDeadLetters {
+		return
+	}
+	entries, getErr := s.Storage.GetAllWithPrefix(ctx, string(interfaces.DeadLetterPrefix))
+	if !errors.IsEmpty(getErr) {
+		s.Logger.Error(errors.E(errors.Op("Get dead letters"), getErr))
+		return
+	}
+	keys := sortedKeys(entries)
+	for _, key := range keys[:uint(len(keys))-s.MaxDeadLetters] {
+		deleteErr := s.Storage.Delete(ctx, []byte(key))
+		if !errors.IsEmpty(deleteErr) {
+			s.Logger.Error(errors.E(errors.Op("Drop oldest dead letter"), deleteErr))
+			return
+		}
+	}
+}
+
+// getDeadLetters returns the dead letters with the given IDs, or all of them if no IDs are given, oldest first
+func getDeadLetters(ctx context.Context, storage interfaces.Storage, ids [][]byte) ([]*pb.DeadLetter, error) {
+	entries := make(map[string]string)
+	if len(ids) == 0 {
+		var err error
+		entries, err = storage.GetAllWithPrefix(ctx, string(interfaces.DeadLetterPrefix))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Get dead letters"), err)
+		}
+	}
+	for _, id := range ids {
+		key := getDeadLetterStorageKey(id)
+		data, err := storage.Get(ctx, key)
+		if !errors.IsEmpty(err) || len(data) == 0 {
+			continue
+		}
+		entries[string(key)] = string(data)
+	}
+
+	deadLetters := make([]*pb.DeadLetter, 0, len(entries))
+	for _, key := range sortedKeys(entries) {
+		deadLetter := &pb.DeadLetter{}
+		err := proto.Unmarshal([]byte(entries[key]), deadLetter)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		deadLetter.Id = []byte(strings.TrimPrefix(key, string(interfaces.DeadLetterPrefix)))
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	return deadLetters, nil
+}
+
+// GetDeadLetters lists the received messages that failed processing, oldest first
+func (s *AdminService) GetDeadLetters(ctx context.Context, in *pb.Empty) (*pb.DeadLetterList, error) {
+	deadLetters, err := getDeadLetters(ctx, s.Storage, nil)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return &pb.DeadLetterList{DeadLetters: deadLetters}, nil
+}
+
+// ReplayDeadLetters passes the given dead letters, or all of them, to the Receiver again and removes them.
+// The ones that fail again are returned with their new reason, and kept as new dead letters.
+func (s *AdminService) ReplayDeadLetters(ctx context.Context, in *pb.DeadLetterRequest) (*pb.DeadLetterList, error) {
+	if s.Receiver == nil {
+		return nil, errors.E(errors.Op("Replay dead letters"), "no receiver registered with AdminService")
+	}
+	deadLetters, err := getDeadLetters(ctx, s.Storage, in.GetIds())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+
+	failed := []*pb.DeadLetter{}
+	for _, deadLetter := range deadLetters {
+		err = s.Storage.Delete(ctx, getDeadLetterStorageKey(deadLetter.GetId()))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Delete replayed dead letter"), err)
+		}
+		from, err := peer.IDB58Decode(deadLetter.GetFrom())
+		if !errors.IsEmpty(err) {
+			deadLetter.Reason = errors.E(errors.Op("Decode dead letter sender"), err).Error()
+			failed = append(failed, deadLetter)
+			continue
+		}
+		receivedAt, _ := ptypes.Timestamp(deadLetter.GetReceived())
+		msg := interfaces.IncomingMessage{From: from, Transport: interfaces.TransportReplay, ReceivedAt: receivedAt, Data: deadLetter.GetData()}
+		err = s.Receiver.Receive(requestid.WithID(ctx, deadLetter.GetRequestID()), msg)
+		if !errors.IsEmpty(err) && !errors.Is(errors.Duplicate, err) {
+			deadLetter.Reason = err.Error()
+			failed = append(failed, deadLetter)
+		}
+	}
+	return &pb.DeadLetterList{DeadLetters: failed}, nil
+}
+
+// PurgeDeadLetters removes the given dead letters, or all of them if no IDs are given
+func (s *AdminService) PurgeDeadLetters(ctx context.Context, in *pb.DeadLetterRequest) (*pb.Empty, error) {
+	if len(in.GetIds()) == 0 {
+		err := s.Storage.DeleteAllWithPrefix(ctx, string(interfaces.DeadLetterPrefix))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Purge dead letters"), err)
+		}
+		return &pb.Empty{}, nil
+	}
+	for _, id := range in.GetIds() {
+		err := s.Storage.Delete(ctx, getDeadLetterStorageKey(id))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Purge dead letter"), err)
+		}
+	}
+	return &pb.Empty{}, nil
+}
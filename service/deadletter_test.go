@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetters(t *testing.T) {
+	receiverService := newOwnershipTestService()
+	receiverService.MaxDeadLetters = 2
+	adminService := &AdminService{Storage: receiverService.Storage, Receiver: receiverService}
+	senderID, _ := newStranger(t)
+
+	for _, data := range []string{"first", "second", "third"} {
+		assert.Error(t, receive(receiverService, []byte{0xff, 0xff, byte(len(data))}, senderID))
+	}
+
+	// Only the newest dead letters are kept
+	list, err := adminService.GetDeadLetters(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetDeadLetters(), 2)
+	assert.Equal(t, senderID.String(), list.GetDeadLetters()[0].GetFrom())
+	assert.NotEmpty(t, list.GetDeadLetters()[0].GetReason())
+	assert.NotEmpty(t, list.GetDeadLetters()[0].GetRequestID())
+	assert.Equal(t, []byte{0xff, 0xff, byte(len("second"))}, list.GetDeadLetters()[0].GetData())
+
+	_, err = adminService.PurgeDeadLetters(context.Background(), &pb.DeadLetterRequest{Ids: [][]byte{list.GetDeadLetters()[0].GetId()}})
+	assert.NoError(t, err)
+
+	// Replaying a message that still fails keeps it as a new dead letter
+	failed, err := adminService.ReplayDeadLetters(context.Background(), &pb.DeadLetterRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, failed.GetDeadLetters(), 1)
+	list, err = adminService.GetDeadLetters(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetDeadLetters(), 1)
+	assert.NotEqual(t, failed.GetDeadLetters()[0].GetId(), list.GetDeadLetters()[0].GetId())
+
+	_, err = adminService.PurgeDeadLetters(context.Background(), &pb.DeadLetterRequest{})
+	assert.NoError(t, err)
+	list, err = adminService.GetDeadLetters(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Empty(t, list.GetDeadLetters())
+
+	// Nothing is kept once dead letters are disabled
+	receiverService.MaxDeadLetters = 0
+	assert.Error(t, receive(receiverService, []byte{0xff}, senderID))
+	list, err = adminService.GetDeadLetters(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Empty(t, list.GetDeadLetters())
+}
+
+func TestReceiveOnOtherChannel(t *testing.T) {
+	receiverService := newOwnershipTestService()
+	receiverService.MaxDeadLetters = 1
+	adminService := &AdminService{Storage: receiverService.Storage, Receiver: receiverService}
+	senderID, _ := newStranger(t)
+	data, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_DELETE})
+	assert.NoError(t, err)
+
+	// A message can't change another channel than the one whose topic it was published on
+	receivedAt := time.Now().Add(-time.Minute)
+	err = receiverService.Receive(context.Background(), interfaces.IncomingMessage{From: senderID, ChannelID: []byte("other"), Transport: interfaces.TransportGossip, ReceivedAt: receivedAt, Data: data})
+	assert.True(t, errors.Is(errors.Malformed, err))
+
+	list, err := adminService.GetDeadLetters(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetDeadLetters(), 1)
+	received, err := ptypes.Timestamp(list.GetDeadLetters()[0].GetReceived())
+	assert.NoError(t, err)
+	assert.True(t, receivedAt.Equal(received))
+}
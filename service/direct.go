@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// sendToPeer sends a message to a single peer instead of the whole channel.
+// The peer is dialed if it isn't connected, so it runs in the background and only logs failures.
+func (s *OrderService) sendToPeer(peerID peer.ID, message *pb.WireMessage) {
+	if s.P2p == nil {
+		s.Logger.Warn("P2p service not registered with OrderService, not sending direct messages!")
+		return
+	}
+	go func() {
+		err := s.P2p.SendToPeer(peerID, message)
+		if !errors.IsEmpty(err) {
+			s.Logger.Debug(errors.E(errors.Op("Send to peer"), err))
+		}
+	}()
+}
+
+// confirmFill sends a fill signed by both parties directly to the node of its taker,
+// so the taker doesn't have to wait for the channel broadcast to know the fill went through
+func (s *OrderService) confirmFill(fill *pb.Fill, message *pb.WireMessage) {
+	takerKey, err := crypto.UnmarshalPublicKey(fill.GetTakerPubKey())
+	if !errors.IsEmpty(err) {
+		return
+	}
+	takerID, err := peer.IDFromPublicKey(takerKey)
+	if !errors.IsEmpty(err) || (s.P2p != nil && takerID == s.P2p.GetHostID()) {
+		return
+	}
+	s.sendToPeer(takerID, message)
+}
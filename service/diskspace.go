@@ -0,0 +1,34 @@
+package service
+
+import "sync/atomic"
+
+// bytesPerMegabyte converts the free disk space to megabytes for the logs
+const bytesPerMegabyte uint64 = 1 << 20
+
+// SetFreeDiskSpace records how many bytes are free on the storage's disk. Below minFree the node turns read-only,
+// refusing to create orders while reads and gossip go on, until enough space frees up again. 0 never turns it read-only.
+func (s *OrderService) SetFreeDiskSpace(free uint64, minFree uint64) {
+	atomic.StoreUint64(&s.freeDiskSpace, free)
+
+	var readOnly uint32
+	if minFree > 0 && free < minFree {
+		readOnly = 1
+	}
+	previous := atomic.SwapUint32(&s.readOnly, readOnly)
+	switch {
+	case readOnly == 1 && previous == 0:
+		s.Logger.Errorf("Only %d MB of disk space is left, under the minimum of %d MB. The node is read-only and won't create orders until space frees up.", free/bytesPerMegabyte, minFree/bytesPerMegabyte)
+	case readOnly == 0 && previous == 1:
+		s.Logger.Infof("%d MB of disk space is free again, the node creates orders again", free/bytesPerMegabyte)
+	}
+}
+
+// FreeDiskSpace returns how many bytes were free on the storage's disk when it was last checked
+func (s *OrderService) FreeDiskSpace() uint64 {
+	return atomic.LoadUint64(&s.freeDiskSpace)
+}
+
+// IsReadOnly tells if the node refuses to create orders because its disk is running out of space
+func (s *OrderService) IsReadOnly() bool {
+	return atomic.LoadUint32(&s.readOnly) == 1
+}
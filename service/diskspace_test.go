@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnly(t *testing.T) {
+	orderService := newOwnershipTestService()
+	request := &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice}
+
+	orderService.SetFreeDiskSpace(100*bytesPerMegabyte, 512*bytesPerMegabyte)
+	assert.True(t, orderService.IsReadOnly())
+	assert.Equal(t, 100*bytesPerMegabyte, orderService.FreeDiskSpace())
+	_, err := orderService.Create(context.Background(), request)
+	assert.Error(t, err)
+
+	// Reads still work
+	_, err = orderService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+
+	// The node recovers once space frees up
+	orderService.SetFreeDiskSpace(1024*bytesPerMegabyte, 512*bytesPerMegabyte)
+	assert.False(t, orderService.IsReadOnly())
+	_, err = orderService.Create(context.Background(), request)
+	assert.NoError(t, err)
+
+	// Without a minimum the node never turns read-only
+	orderService.SetFreeDiskSpace(0, 0)
+	assert.False(t, orderService.IsReadOnly())
+}
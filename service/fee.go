@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// checkFeeRates checks that maker and taker fees are fractions of the traded amount, from 0 up to but not including 1
+func checkFeeRates(makerFee float32, takerFee float32) error {
+	if makerFee < 0 || makerFee >= 1 || takerFee < 0 || takerFee >= 1 {
+		return errors.E(errors.Op("Check fee rates"), errors.Invalid, fmt.Sprintf("fees %v and %v have to be at least 0 and below 1", makerFee, takerFee))
+	}
+	return nil
+}
+
+// getChannelFees returns the maker and taker fees of a channel. The fees of the creator's signed config replace the ones the channel was joined with.
+func getChannelFees(channel *pb.Channel) (float32, float32) {
+	if config := channel.GetConfig(); config != nil {
+		return config.GetMakerFee(), config.GetTakerFee()
+	}
+	return channel.GetOptions().GetMakerFee(), channel.GetOptions().GetTakerFee()
+}
+
+// getFees returns the maker and taker fees of the channel, or no fees if it hasn't been joined
+func (s *OrderService) getFees(ctx context.Context, channelID []byte) (float32, float32) {
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return 0, 0
+	}
+	return getChannelFees(channel)
+}
+
+// validateFees checks that the order carries the fees of the channel it's made on
+func validateFees(channel *pb.Channel, order *pb.Order) error {
+	makerFee, takerFee := getChannelFees(channel)
+	if order.GetMakerFee() != makerFee || order.GetTakerFee() != takerFee {
+		return errors.E(errors.Op("Validate order fees"), errors.Invalid, fmt.Sprintf("order fees %v and %v aren't the channel's fees %v and %v", order.GetMakerFee(), order.GetTakerFee(), makerFee, takerFee))
+	}
+	return nil
+}
+
+// getFillFees returns the maker and taker fees of a fill in units of the order's asset, rounded to the nearest unit
+func getFillFees(order *pb.Order, fill *pb.Fill) (uint64, uint64) {
+	amount := float64(fill.GetAmount())
+	return uint64(math.Round(amount * float64(order.GetMakerFee()))), uint64(math.Round(amount * float64(order.GetTakerFee())))
+}
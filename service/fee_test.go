@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFeeRates(t *testing.T) {
+	assert.NoError(t, checkFeeRates(0, 0))
+	assert.NoError(t, checkFeeRates(0.001, 0.002))
+	assert.True(t, errors.Is(errors.Invalid, checkFeeRates(-0.001, 0)))
+	assert.True(t, errors.Is(errors.Invalid, checkFeeRates(0, 1)))
+}
+
+func TestChannelFees(t *testing.T) {
+	channel := &pb.Channel{Options: &pb.ChannelOptions{MakerFee: 0.001, TakerFee: 0.002}}
+	makerFee, takerFee := getChannelFees(channel)
+	assert.Equal(t, float32(0.001), makerFee)
+	assert.Equal(t, float32(0.002), takerFee)
+
+	// The creator's config replaces the fees of the options
+	channel.Config = &pb.ChannelConfig{TakerFee: 0.003}
+	makerFee, takerFee = getChannelFees(channel)
+	assert.Equal(t, float32(0), makerFee)
+	assert.Equal(t, float32(0.003), takerFee)
+}
+
+func TestFillFees(t *testing.T) {
+	options := &pb.ChannelOptions{MakerFee: 0.01, TakerFee: 0.025}
+	makerService := newOwnershipTestService()
+	joinChannelWithOptions(t, makerService, options)
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 1000, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	assert.Equal(t, float32(0.01), order.GetMakerFee())
+	assert.Equal(t, float32(0.025), order.GetTakerFee())
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	otherService := newOwnershipTestService()
+	joinChannelWithOptions(t, otherService, options)
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	err = otherService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), orderInBytes)
+	assert.NoError(t, err)
+
+	takerKey, _, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	fill := &pb.Fill{OrderID: order.GetId(), Amount: 400, Nonce: order.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, fill))
+	partial, err := makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: fill})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), partial.GetFills()[0].GetMakerFee())
+	assert.Equal(t, uint64(10), partial.GetFills()[0].GetTakerFee())
+
+	// Fees that don't follow from the order are refused
+	tampered := proto.Clone(partial).(*pb.Order)
+	tampered.Fills[0].TakerFee = 0
+	tamperedInBytes, err := proto.Marshal(tampered)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(errors.Invalid, receiveData(t, otherService, pb.Operation_FILL, tamperedInBytes, makerID)))
+
+	partialInBytes, err := proto.Marshal(partial)
+	assert.NoError(t, err)
+	assert.NoError(t, receiveData(t, otherService, pb.Operation_FILL, partialInBytes, makerID))
+}
+
+func TestValidateFees(t *testing.T) {
+	validationService := newOwnershipTestService()
+	joinChannelWithOptions(t, validationService, &pb.ChannelOptions{MakerFee: 0.001})
+	order := &pb.Order{Asset: asset1, CounterAsset: asset2, Amount: 300, Price: testPrice, MakerFee: 0.001}
+	assert.NoError(t, validationService.validateOrder(context.Background(), []byte(assetPair), order))
+
+	noFees := *order
+	noFees.MakerFee = 0
+	assert.True(t, errors.Is(errors.Invalid, validationService.validateOrder(context.Background(), []byte(assetPair), &noFees)))
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// feedBufferSize is how many updates a subscriber may fall behind before it's dropped
+const feedBufferSize int = 256
+
+func getSequenceStorageKey(channelID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.SequencePrefix), string(channelID)}, ""))
+}
+
+// OrderFeed numbers the changes to each channel's order book and sends them to the channel's subscribers.
+// The numbers are stored, so they keep increasing across restarts.
+type OrderFeed struct {
+	storage     interfaces.Storage
+	sequences   map[string]uint64
+	subscribers map[string]map[chan *pb.OrderBookUpdate]bool
+	lock        sync.Mutex
+}
+
+// NewOrderFeed returns an OrderFeed that stores its sequence numbers in storage
+func NewOrderFeed(storage interfaces.Storage) *OrderFeed {
+	return &OrderFeed{
+		storage:     storage,
+		sequences:   make(map[string]uint64),
+		subscribers: make(map[string]map[chan *pb.OrderBookUpdate]bool),
+	}
+}
+
+// sequence returns the sequence number of the channel's latest change. The caller must hold the lock.
+func (feed *OrderFeed) sequence(ctx context.Context, channelID []byte) (uint64, error) {
+	if sequence, ok := feed.sequences[string(channelID)]; ok {
+		return sequence, nil
+	}
+	exists, err := feed.storage.Has(ctx, getSequenceStorageKey(channelID))
+	if !errors.IsEmpty(err) {
+		return 0, errors.E(errors.Op("Check sequence number"), err)
+	}
+	var sequence uint64
+	if exists {
+		data, err := feed.storage.Get(ctx, getSequenceStorageKey(channelID))
+		if !errors.IsEmpty(err) {
+			return 0, errors.E(errors.Op("Get sequence number"), err)
+		}
+		if len(data) == 8 {
+			sequence = binary.BigEndian.Uint64(data)
+		}
+	}
+	feed.sequences[string(channelID)] = sequence
+	return sequence, nil
+}
+
+// publish numbers a change to the channel's order book and sends it to the channel's subscribers.
+// Subscribers that have fallen too far behind are dropped, since they'd miss updates otherwise.
+func (feed *OrderFeed) publish(ctx context.Context, channelID []byte, change pb.BookChange, order *pb.Order) error {
+	feed.lock.Lock()
+	defer feed.lock.Unlock()
+	sequence, err := feed.sequence(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	sequence++
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, sequence)
+	err = feed.storage.Put(ctx, getSequenceStorageKey(channelID), data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put sequence number"), err)
+	}
+	feed.sequences[string(channelID)] = sequence
+
+	update := &pb.OrderBookUpdate{ChannelID: channelID, Sequence: sequence, Change: change, Order: proto.Clone(order).(*pb.Order)}
+	for updates := range feed.subscribers[string(channelID)] {
+		select {
+		case updates <- update:
+		default:
+			delete(feed.subscribers[string(channelID)], updates)
+			close(updates)
+		}
+	}
+	return nil
+}
+
+// subscribe registers a subscriber to the channel and returns its updates along with a snapshot
+// of the order book at the sequence number the updates continue from
+func (feed *OrderFeed) subscribe(ctx context.Context, channelID []byte, snapshot func() ([]*pb.Order, error)) (chan *pb.OrderBookUpdate, *pb.OrderBookUpdate, error) {
+	feed.lock.Lock()
+	defer feed.lock.Unlock()
+	sequence, err := feed.sequence(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return nil, nil, err
+	}
+	orders, err := snapshot()
+	if !errors.IsEmpty(err) {
+		return nil, nil, errors.E(errors.Op("Get order book snapshot"), err)
+	}
+
+	updates := make(chan *pb.OrderBookUpdate, feedBufferSize)
+	if feed.subscribers[string(channelID)] == nil {
+		feed.subscribers[string(channelID)] = make(map[chan *pb.OrderBookUpdate]bool)
+	}
+	feed.subscribers[string(channelID)][updates] = true
+	return updates, &pb.OrderBookUpdate{ChannelID: channelID, Sequence: sequence, Snapshot: true, Orders: orders}, nil
+}
+
+// unsubscribe removes a subscriber, unless it has already been dropped
+func (feed *OrderFeed) unsubscribe(channelID []byte, updates chan *pb.OrderBookUpdate) {
+	feed.lock.Lock()
+	defer feed.lock.Unlock()
+	if feed.subscribers[string(channelID)][updates] {
+		delete(feed.subscribers[string(channelID)], updates)
+		close(updates)
+	}
+	if len(feed.subscribers[string(channelID)]) == 0 {
+		delete(feed.subscribers, string(channelID))
+	}
+}
+
+// SubscribeOrderBook streams a snapshot of a channel's order book followed by every change to it.
+// Each change has the next sequence number of the channel. A client that sees a gap in the numbers,
+// or whose stream ends because it fell behind, resyncs by subscribing again.
+// Changes are whole orders, so one that was already in the snapshot can be applied again safely.
+func (s *OrderService) SubscribeOrderBook(in *pb.ChannelSpecificRequest, stream pb.OrderHandler_SubscribeOrderBookServer) error {
+	if s.feed == nil {
+		return errors.E(errors.Op("Subscribe order book"), "storage not registered with OrderService")
+	}
+	ctx := stream.Context()
+	updates, snapshot, err := s.feed.subscribe(ctx, in.GetId(), func() ([]*pb.Order, error) {
+		return s.book.get(ctx, in.GetId())
+	})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Subscribe order book"), err)
+	}
+	defer s.feed.unsubscribe(in.GetId(), updates)
+
+	err = stream.Send(snapshot)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send order book snapshot"), err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "%s", errors.E(errors.Op("Subscribe order book"), "subscriber fell behind, subscribe again to resync"))
+			}
+			err = stream.Send(update)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Send order book update"), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderFeed(t *testing.T) {
+	feedService := newOwnershipTestService()
+	ctx := context.Background()
+	channelID := []byte(assetPair)
+	resp, err := feedService.Create(ctx, &pb.CreateRequest{ChannelID: channelID, Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+
+	updates, snapshot, err := feedService.feed.subscribe(ctx, channelID, func() ([]*pb.Order, error) {
+		return feedService.book.get(ctx, channelID)
+	})
+	assert.NoError(t, err)
+	defer feedService.feed.unsubscribe(channelID, updates)
+	assert.True(t, snapshot.GetSnapshot())
+	assert.Equal(t, uint64(1), snapshot.GetSequence())
+	assert.Len(t, snapshot.GetOrders(), 1)
+
+	_, err = feedService.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: order.GetId()})
+	assert.NoError(t, err)
+	_, err = feedService.Delete(ctx, &pb.OrderSpecificRequest{ChannelID: channelID, OrderID: order.GetId()})
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, uint64(2), update.GetSequence())
+	assert.Equal(t, pb.BookChange_ORDER_CHANGED, update.GetChange())
+	assert.Equal(t, pb.State_LOCKED, update.GetOrder().GetState())
+	update = <-updates
+	assert.Equal(t, uint64(3), update.GetSequence())
+	assert.Equal(t, pb.BookChange_ORDER_REMOVED, update.GetChange())
+	assert.Equal(t, order.GetId(), update.GetOrder().GetId())
+
+	// The sequence numbers carry on after a restart
+	restartedFeed := NewOrderFeed(feedService.Storage)
+	restartedFeed.lock.Lock()
+	sequence, err := restartedFeed.sequence(ctx, channelID)
+	restartedFeed.lock.Unlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), sequence)
+}
+
+func TestOrderFeedDropsSlowSubscribers(t *testing.T) {
+	feed := NewOrderFeed(newOwnershipTestService().Storage)
+	ctx := context.Background()
+	channelID := []byte(assetPair)
+	updates, _, err := feed.subscribe(ctx, channelID, func() ([]*pb.Order, error) { return nil, nil })
+	assert.NoError(t, err)
+
+	for i := 0; i <= feedBufferSize; i++ {
+		assert.NoError(t, feed.publish(ctx, channelID, pb.BookChange_ORDER_ADDED, &pb.Order{}))
+	}
+	received := 0
+	for range updates {
+		received++
+	}
+	assert.Equal(t, feedBufferSize, received)
+
+	// Unsubscribing after being dropped is safe
+	feed.unsubscribe(channelID, updates)
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/settlement"
+)
+
+// getFillSigningBytes returns the part of a fill that the taker and the maker sign.
+// The fees aren't signed, as they follow from the amount and the fees of the signed order.
+func getFillSigningBytes(fill *pb.Fill) ([]byte, error) {
+	fillCopy := *fill
+	fillCopy.TakerSignature = nil
+	fillCopy.MakerSignature = nil
+	fillCopy.MakerFee = 0
+	fillCopy.TakerFee = 0
+	return proto.Marshal(&fillCopy)
+}
+
+// SignFill signs a fill as its taker, so that the maker can report it with ReportFill.
+// The fill's nonce has to be the current nonce of the order.
+func SignFill(privateKey crypto.PrivKey, fill *pb.Fill) error {
+	publicKey, err := crypto.MarshalPublicKey(privateKey.GetPublic())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal taker public key"), err)
+	}
+	fill.TakerPubKey = publicKey
+	data, err := getFillSigningBytes(fill)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal fill"), err)
+	}
+	fill.TakerSignature, err = privateKey.Sign(data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Sign fill"), err)
+	}
+	return nil
+}
+
+// verifyFillSignature checks one of the signatures of a fill
+func verifyFillSignature(publicKey crypto.PubKey, fill *pb.Fill, signature []byte) error {
+	data, err := getFillSigningBytes(fill)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal fill"), err)
+	}
+	valid, err := identity.Verify(publicKey, data, signature)
+	if !errors.IsEmpty(err) || !valid {
+		return errors.E(errors.Op("Verify fill signature"), errors.InvalidSignature, "fill isn't signed by both the taker and the maker")
+	}
+	return nil
+}
+
+// verifyTaker checks that the fill is signed by the taker whose public key it has
+func verifyTaker(fill *pb.Fill) error {
+	takerKey, err := crypto.UnmarshalPublicKey(fill.GetTakerPubKey())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal taker public key"), errors.Malformed, err)
+	}
+	return verifyFillSignature(takerKey, fill, fill.GetTakerSignature())
+}
+
+// applyFill adds a fill to the order, moving it to PARTIALLY_FILLED or FILLED, and charges the order's fees on it.
+// A fill ends any lock on the order, since locks are taken to settle fills.
+func applyFill(order *pb.Order, fill *pb.Fill) error {
+	if fill.GetAmount() == 0 || fill.GetAmount() > order.GetAmount()-order.GetFilledAmount() {
+		return errors.E(errors.Op("Apply fill"), errors.Invalid, "fill amount has to be between 0 and the unfilled amount of the order")
+	}
+	fill.MakerFee, fill.TakerFee = getFillFees(order, fill)
+	order.Fills = append(order.Fills, fill)
+	order.FilledAmount += fill.GetAmount()
+	order.Nonce++
+	order.LockedUntil = nil
+	if order.GetFilledAmount() == order.GetAmount() {
+		order.State = pb.State_FILLED
+	} else {
+		order.State = pb.State_PARTIALLY_FILLED
+	}
+	return nil
+}
+
+// storeFill keeps a partially filled order on the order book and moves a filled one into the order history
+func (s *OrderService) storeFill(ctx context.Context, channelID []byte, order *pb.Order, orderInBytes []byte) error {
+	if order.GetState() == pb.State_FILLED {
+		return s.deleteOrder(ctx, channelID, order)
+	}
+	return s.putOrder(ctx, channelID, order, orderInBytes)
+}
+
+// ReportFill records a fill of one of this node's orders and broadcasts the order with its remaining amount.
+// The fill has to be signed by the taker with SignFill, and is signed by this node as the maker.
+// The registered settlement engine settles the fill before it's broadcast.
+func (s *OrderService) ReportFill(ctx context.Context, in *pb.FillRequest) (*pb.Order, error) {
+	fill := in.GetFill()
+	orderInBytes, err := s.Storage.Get(ctx, getOrderStorageKey(in.GetChannelID(), fill.GetOrderID()))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get order in ReportFill"), err)
+	}
+
+	order := &pb.Order{}
+	err = proto.Unmarshal(orderInBytes, order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal order proto in ReportFill"), err)
+	}
+
+	err = s.authorizeSelf(ctx, in.GetChannelID(), order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Authorize fill"), err)
+	}
+	if fill.GetNonce() != order.GetNonce() {
+		return nil, errors.E(errors.Op("Compare nonces"), errors.Replay, "fill was signed for another state of the order")
+	}
+	err = verifyTaker(fill)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Verify taker"), err)
+	}
+
+	trade := &settlement.Trade{ChannelID: in.GetChannelID(), Order: order, Fill: fill}
+	err = s.getSettlement().Prepare(ctx, trade)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Prepare settlement"), err)
+	}
+	order, err = s.settleFill(ctx, in.GetChannelID(), trade)
+	if !errors.IsEmpty(err) {
+		s.abortSettlement(ctx, trade, err)
+		return nil, err
+	}
+
+	s.count(fillsReportedCounter)
+
+	err = s.getSettlement().Confirm(ctx, trade)
+	if !errors.IsEmpty(err) {
+		s.Logger.Error(errors.E(errors.Op("Confirm settlement"), err))
+	}
+	return order, nil
+}
+
+// settleFill signs and applies a prepared fill, executes its settlement, and broadcasts and stores the filled order
+func (s *OrderService) settleFill(ctx context.Context, channelID []byte, trade *settlement.Trade) (*pb.Order, error) {
+	order, fill := trade.Order, trade.Fill
+	data, err := getFillSigningBytes(fill)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal fill"), err)
+	}
+	fill.MakerSignature, err = identity.Sign(s.Storage, data)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Sign fill"), err)
+	}
+	err = applyFill(order, fill)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+
+	err = s.getSettlement().Execute(ctx, trade)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Execute settlement"), err)
+	}
+
+	// Get order as bytes
+	orderInBytes, err := proto.Marshal(order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal order"), err)
+	}
+
+	// Construct the message to send to other peers
+	wireMessage := &pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_FILL, Data: orderInBytes}
+
+	if s.P2p != nil {
+		// Send the fill by wire
+		err = s.P2p.Send(ctx, wireMessage)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Send fill"), err)
+		}
+		s.confirmFill(fill, wireMessage)
+	} else {
+		s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
+	}
+
+	err = s.storeFill(ctx, channelID, order, orderInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Store filled order"), err)
+	}
+	s.recordTrade(ctx, channelID, order, fill)
+
+	s.notify(wireMessage)
+	s.mirrorOrder(ctx, channelID, pb.Operation_FILL, order, orderInBytes, true)
+
+	return order, nil
+}
+
+// receiveFill checks a fill broadcast by the maker of an order, which has to add exactly one fill
+// signed by its taker and maker to the stored order
+func (s *OrderService) receiveFill(ctx context.Context, channelID []byte, data []byte, from peer.ID) error {
+	order := &pb.Order{}
+	err := proto.Unmarshal(data, order)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal order proto in Receive"), errors.Malformed, err)
+	}
+
+	previousOrderData, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, order.GetId()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get previous order"), err)
+	}
+	previousOrder := &pb.Order{}
+	proto.Unmarshal(previousOrderData, previousOrder)
+	if proto.Equal(previousOrder, order) {
+		return errors.E(errors.Op("Check for duplicate fill"), errors.Duplicate, "fill has already been recorded")
+	}
+	if previousOrder.GetNonce() >= order.GetNonce() {
+		return errors.E(errors.Op("Compare nonces"), errors.Replay, "received order state is behind current status")
+	}
+
+	err = s.authorize(ctx, channelID, order, from)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Authorize fill in Receive"), err)
+	}
+
+	if len(order.GetFills()) != len(previousOrder.GetFills())+1 {
+		return errors.E(errors.Op("Check fills"), errors.Invalid, "fill has to add exactly one fill to the order")
+	}
+	fill := order.GetFills()[len(order.GetFills())-1]
+	if fill.GetNonce() != previousOrder.GetNonce() {
+		return errors.E(errors.Op("Compare nonces"), errors.Replay, "fill was signed for another state of the order")
+	}
+	makerFee, takerFee := fill.GetMakerFee(), fill.GetTakerFee()
+	expected := proto.Clone(previousOrder).(*pb.Order)
+	err = applyFill(expected, fill)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if fill.GetMakerFee() != makerFee || fill.GetTakerFee() != takerFee {
+		return errors.E(errors.Op("Check fill fees"), errors.Invalid, "fees of the fill don't match the fees of the order")
+	}
+	if order.GetFilledAmount() != expected.GetFilledAmount() || order.GetState() != expected.GetState() {
+		return errors.E(errors.Op("Check fills"), errors.Invalid, "filled amount or state doesn't match the fills")
+	}
+
+	err = verifyTaker(fill)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify taker"), err)
+	}
+	makerKey, err := crypto.UnmarshalPublicKey(order.GetMakerPubKey())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal maker public key"), errors.Malformed, err)
+	}
+	err = verifyFillSignature(makerKey, fill, fill.GetMakerSignature())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify maker"), err)
+	}
+
+	err = s.storeFill(ctx, channelID, order, data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Store filled order"), err)
+	}
+	s.recordTrade(ctx, channelID, order, fill)
+	s.mirrorOrder(ctx, channelID, pb.Operation_FILL, order, data, false)
+	return nil
+}
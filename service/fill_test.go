@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportFill(t *testing.T) {
+	makerService := newOwnershipTestService()
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 100, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	otherService := newOwnershipTestService()
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	err = otherService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), orderInBytes)
+	assert.NoError(t, err)
+
+	takerKey, _, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	fill := &pb.Fill{OrderID: order.GetId(), Amount: 40, Nonce: order.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, fill))
+
+	// The maker doesn't sign fills the taker hasn't signed
+	tamperedFill := *fill
+	tamperedFill.Amount = 41
+	_, err = makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: &tamperedFill})
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+
+	partial, err := makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: fill})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_PARTIALLY_FILLED, partial.GetState())
+	assert.Equal(t, uint64(40), partial.GetFilledAmount())
+	assert.Len(t, partial.GetFills(), 1)
+	_, err = makerService.verifyMaker(partial)
+	assert.NoError(t, err)
+
+	// Other nodes show the remaining size
+	partialInBytes, err := proto.Marshal(partial)
+	assert.NoError(t, err)
+	fillMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_FILL, Data: partialInBytes})
+	assert.NoError(t, err)
+	strangerID, _ := newStranger(t)
+	assert.True(t, errors.Is(errors.Unauthorized, receive(otherService, fillMessage, strangerID)))
+	assert.NoError(t, receive(otherService, fillMessage, makerID))
+	assert.True(t, errors.Is(errors.Duplicate, receive(otherService, fillMessage, makerID)))
+	request := &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: order.GetId()}
+	stored, err := otherService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(40), stored.GetFilledAmount())
+
+	// A fill can't be reported twice or be bigger than the rest of the order
+	_, err = makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: fill})
+	assert.True(t, errors.Is(errors.Replay, err))
+	overFill := &pb.Fill{OrderID: order.GetId(), Amount: 61, Nonce: partial.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, overFill))
+	_, err = makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: overFill})
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	lastFill := &pb.Fill{OrderID: order.GetId(), Amount: 60, Nonce: partial.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, lastFill))
+	filled, err := makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: lastFill})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_FILLED, filled.GetState())
+	_, err = makerService.GetOrder(context.Background(), request)
+	assert.Error(t, err)
+
+	filledInBytes, err := proto.Marshal(filled)
+	assert.NoError(t, err)
+	fillMessage, err = proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_FILL, Data: filledInBytes})
+	assert.NoError(t, err)
+	assert.NoError(t, receive(otherService, fillMessage, makerID))
+	_, err = otherService.GetOrder(context.Background(), request)
+	assert.Error(t, err)
+}
+
+func TestReceiveForgedFill(t *testing.T) {
+	makerService := newOwnershipTestService()
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 100, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	otherService := newOwnershipTestService()
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	err = otherService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), orderInBytes)
+	assert.NoError(t, err)
+
+	// A fill only signed by the taker isn't accepted, even from the maker
+	takerKey, _, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	fill := &pb.Fill{OrderID: order.GetId(), Amount: 40, Nonce: order.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, fill))
+	forged := proto.Clone(order).(*pb.Order)
+	assert.NoError(t, applyFill(forged, fill))
+	forgedInBytes, err := proto.Marshal(forged)
+	assert.NoError(t, err)
+	fillMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_FILL, Data: forgedInBytes})
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(errors.InvalidSignature, receive(otherService, fillMessage, makerID)))
+}
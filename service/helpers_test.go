@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+// joinChannelWithOptions stores the test channel with the given options, as if the service had joined it
+func joinChannelWithOptions(t *testing.T, service *OrderService, options *pb.ChannelOptions) {
+	marshaledChannel, err := proto.Marshal(&pb.Channel{Id: []byte(assetPair), Options: options})
+	assert.NoError(t, err)
+	err = service.Storage.Put(context.Background(), getChannelStorageKey([]byte(assetPair)), marshaledChannel)
+	assert.NoError(t, err)
+}
+
+// createTestOrder creates an order on the test channel with the given price and returns it marshaled
+func createTestOrder(t *testing.T, service *OrderService, price float32) []byte {
+	resp, err := service.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: price})
+	assert.NoError(t, err)
+	orderInBytes, err := proto.Marshal(resp.GetCreatedOrder())
+	assert.NoError(t, err)
+	return orderInBytes
+}
+
+// receiveData receives data as if it came from a peer in a wire message of the test channel
+func receiveData(t *testing.T, receiver *OrderService, op pb.Operation, data []byte, from peer.ID) error {
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: op, Data: data})
+	assert.NoError(t, err)
+	return receive(receiver, wireMessage, from)
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const defaultHistoryLimit uint32 = 100
+
+// getHistoryStorageKey orders a channel's history by creation time, so the keys can be used as cursors
+func getHistoryStorageKey(channelID []byte, created time.Time, orderID []byte) []byte {
+	timestamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(timestamp, uint64(created.UnixNano()))
+	return []byte(strings.Join([]string{string(getHistoryQueryPrefix(channelID)), string(timestamp), string(orderID)}, ""))
+}
+
+func getHistoryQueryPrefix(channelID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.HistoryPrefix), string(channelID)}, ""))
+}
+
+// historyTTL is how long an order deleted at deletedAt has left in the order history
+func (s *OrderService) historyTTL(deletedAt *timestamp.Timestamp) time.Duration {
+	if s.HistoryRetention == 0 {
+		return 0
+	}
+	deleted, err := ptypes.Timestamp(deletedAt)
+	if !errors.IsEmpty(err) {
+		return s.HistoryRetention
+	}
+	ttl := time.Until(deleted.Add(s.HistoryRetention))
+	// A TTL of 0 keeps the value forever, so an order that's already due expires on the next sweep instead
+	if ttl <= 0 {
+		return time.Nanosecond
+	}
+	return ttl
+}
+
+// deleteOrder soft deletes an order, moving it from the channel's open orders to its order history
+func (s *OrderService) deleteOrder(ctx context.Context, channelID []byte, order *pb.Order) error {
+	deletedOrder := *order
+	deletedOrder.DeletedAt = ptypes.TimestampNow()
+	created, err := ptypes.Timestamp(deletedOrder.GetCreated())
+	if !errors.IsEmpty(err) {
+		created = time.Unix(0, 0)
+	}
+
+	orderInBytes, err := proto.Marshal(&deletedOrder)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal deleted order"), err)
+	}
+	err = s.Storage.PutWithTTL(ctx, getHistoryStorageKey(channelID, created, order.GetId()), orderInBytes, s.historyTTL(deletedOrder.GetDeletedAt()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put order to history"), err)
+	}
+	err = s.Storage.Delete(ctx, getOrderStorageKey(channelID, order.GetId()))
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = s.unindexOrder(ctx, channelID, order)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = s.Storage.Delete(ctx, getNamespaceStorageKey(channelID, order.GetId()))
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if s.book != nil {
+		s.book.remove(channelID, order.GetId())
+	}
+	s.invalidate(channelID, order.GetId())
+	if s.feed != nil {
+		return s.feed.publish(ctx, channelID, pb.BookChange_ORDER_REMOVED, order)
+	}
+	return nil
+}
+
+// deleteOrders soft deletes several orders of a channel, removing them from its open orders in one batch.
+// Batches can't expire, so the orders are put to the history first; a crash in between leaves them open to be deleted again.
+// Messages of the orders that are still waiting in the outbox are dropped in the same batch, so they're never broadcast.
+func (s *OrderService) deleteOrders(ctx context.Context, channelID []byte, orders []*pb.Order) error {
+	deletedAt := ptypes.TimestampNow()
+	entries := make([]interfaces.Entry, 0, len(orders)*7)
+	for _, order := range orders {
+		deletedOrder := *order
+		deletedOrder.DeletedAt = deletedAt
+		created, err := ptypes.Timestamp(deletedOrder.GetCreated())
+		if !errors.IsEmpty(err) {
+			created = time.Unix(0, 0)
+		}
+		orderInBytes, err := proto.Marshal(&deletedOrder)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Marshal deleted order"), err)
+		}
+		err = s.Storage.PutWithTTL(ctx, getHistoryStorageKey(channelID, created, order.GetId()), orderInBytes, s.historyTTL(deletedAt))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put order to history"), err)
+		}
+
+		entries = append(entries,
+			interfaces.Entry{Key: getOrderStorageKey(channelID, order.GetId()), Delete: true},
+			interfaces.Entry{Key: getNamespaceStorageKey(channelID, order.GetId()), Delete: true},
+		)
+		for _, key := range getIndexStorageKeys(channelID, order) {
+			entries = append(entries, interfaces.Entry{Key: key, Delete: true})
+		}
+	}
+	outboxKeys, err := s.getOutboxStorageKeys(ctx, orders)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	for _, key := range outboxKeys {
+		entries = append(entries, interfaces.Entry{Key: key, Delete: true})
+	}
+
+	if batcher, ok := s.Storage.(interfaces.Batcher); ok {
+		err = batcher.PutBatch(ctx, entries)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete orders"), err)
+		}
+	} else {
+		for _, entry := range entries {
+			err = s.Storage.Delete(ctx, entry.Key)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Delete orders"), err)
+			}
+		}
+	}
+
+	for _, order := range orders {
+		if s.book != nil {
+			s.book.remove(channelID, order.GetId())
+		}
+		s.invalidate(channelID, order.GetId())
+		if s.feed != nil {
+			err = s.feed.publish(ctx, channelID, pb.BookChange_ORDER_REMOVED, order)
+			if !errors.IsEmpty(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getHistorySyncMessage marshals the order history of a channel into a SYNC_HISTORY message, for a peer that joined it with the full history
+func (s *OrderService) getHistorySyncMessage(ctx context.Context, channelID []byte) ([]byte, error) {
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(getHistoryQueryPrefix(channelID)))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get order history"), err)
+	}
+	orderList := &pb.OrderList{}
+	for _, value := range data {
+		order := &pb.Order{}
+		if err := proto.Unmarshal([]byte(value), order); !errors.IsEmpty(err) {
+			continue
+		}
+		orderList.Orders = append(orderList.Orders, order)
+	}
+	marshaledOrderList, err := proto.Marshal(orderList)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal order history"), err)
+	}
+	return proto.Marshal(&pb.WireMessage{Operation: pb.Operation_SYNC_HISTORY, ChannelID: channelID, Data: marshaledOrderList})
+}
+
+// receiveHistory stores the order history a peer synced, on channels joined with the full history. Orders that are
+// already in the history, that the retention has passed or that their maker didn't sign are skipped.
+func (s *OrderService) receiveHistory(ctx context.Context, channelID []byte, data []byte) error {
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) || channel.GetHistory() != pb.History_FULL {
+		return errors.E(errors.Op("Check channel history"), errors.Unauthorized, "the channel wasn't joined with the full history")
+	}
+	orderList := &pb.OrderList{}
+	err = proto.Unmarshal(data, orderList)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal order history"), errors.Malformed, err)
+	}
+
+	duplicates := 0
+	deletedOrders := make([]*pb.Order, 0, len(orderList.GetOrders()))
+	for _, order := range orderList.GetOrders() {
+		deleted, err := ptypes.Timestamp(order.GetDeletedAt())
+		if !errors.IsEmpty(err) || (s.HistoryRetention > 0 && deleted.Before(time.Now().Add(-s.HistoryRetention))) {
+			continue
+		}
+		created, err := ptypes.Timestamp(order.GetCreated())
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		exists, err := s.Storage.Has(ctx, getHistoryStorageKey(channelID, created, order.GetId()))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check order history"), err)
+		}
+		if exists {
+			duplicates++
+			continue
+		}
+		deletedOrders = append(deletedOrders, order)
+	}
+
+	// Like the open orders, the history is verified as one batch
+	_, verifyErrs := s.verifyMakers(deletedOrders)
+	for i, order := range deletedOrders {
+		if !errors.IsEmpty(verifyErrs[i]) {
+			s.Logger.Warn(errors.E(errors.Op("Verify synced history order maker"), verifyErrs[i]))
+			continue
+		}
+		created, _ := ptypes.Timestamp(order.GetCreated())
+		orderInBytes, err := proto.Marshal(order)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Marshal synced history order"), err)
+		}
+		err = s.Storage.PutWithTTL(ctx, getHistoryStorageKey(channelID, created, order.GetId()), orderInBytes, s.historyTTL(order.GetDeletedAt()))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put synced order to history"), err)
+		}
+	}
+	s.Logger.Debugf("Received %d orders of history on channel %s", len(deletedOrders), string(channelID))
+	if duplicates > 0 && duplicates == len(orderList.GetOrders()) {
+		return errors.E(errors.Op("Check for duplicate history"), errors.Duplicate, "all synced history is already stored")
+	}
+	return nil
+}
+
+// inTimeRange tells if the order was created between from and to. Missing limits aren't checked.
+func inTimeRange(order *pb.Order, from time.Time, to time.Time) bool {
+	created, err := ptypes.Timestamp(order.GetCreated())
+	if !errors.IsEmpty(err) {
+		return false
+	}
+	if !from.IsZero() && created.Before(from) {
+		return false
+	}
+	if !to.IsZero() && created.After(to) {
+		return false
+	}
+	return true
+}
+
+// GetOrderHistory pages through the deleted orders of a channel, oldest first.
+// The returned cursor is passed to the next request to continue from the last order.
+func (s *OrderService) GetOrderHistory(ctx context.Context, in *pb.OrderHistoryRequest) (*pb.OrderHistoryResponse, error) {
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(getHistoryQueryPrefix(in.GetChannelID())))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get order history"), err)
+	}
+
+	var from, to time.Time
+	if in.GetFrom() != nil {
+		from, err = ptypes.Timestamp(in.GetFrom())
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Parse history start time"), err)
+		}
+	}
+	if in.GetTo() != nil {
+		to, err = ptypes.Timestamp(in.GetTo())
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Parse history end time"), err)
+		}
+	}
+
+	limit := in.GetLimit()
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if bytes.Compare([]byte(key), in.GetCursor()) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	history := &pb.OrderHistoryResponse{Orders: make([]*pb.Order, 0)}
+	var lastKey string
+	for _, key := range keys {
+		order := &pb.Order{}
+		proto.Unmarshal([]byte(data[key]), order)
+		if !inTimeRange(order, from, to) {
+			continue
+		}
+		if uint32(len(history.Orders)) == limit {
+			history.NextCursor = []byte(lastKey)
+			break
+		}
+		history.Orders = append(history.Orders, order)
+		lastKey = key
+	}
+	return history, nil
+}
+
+// PruneHistory removes the orders deleted before the given time from the order history of all channels.
+// Orders expire from the history by themselves, so this only catches the ones stored without a TTL.
+func (s *OrderService) PruneHistory(before time.Time) error {
+	ctx := context.Background()
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.HistoryPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get order history for pruning"), err)
+	}
+
+	for key, value := range data {
+		order := &pb.Order{}
+		proto.Unmarshal([]byte(value), order)
+		deletedAt, err := ptypes.Timestamp(order.GetDeletedAt())
+		if errors.IsEmpty(err) && !deletedAt.Before(before) {
+			continue
+		}
+		err = s.Storage.Delete(ctx, []byte(key))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Prune order from history"), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const historyTestOrders int = 5
+
+func createHistoryTestOrders(t *testing.T, historyService *OrderService) [][]byte {
+	orderIDs := make([][]byte, 0, historyTestOrders)
+	for i := 0; i < historyTestOrders; i++ {
+		resp, err := historyService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: uint64(i), Price: testPrice})
+		assert.NoError(t, err)
+		orderIDs = append(orderIDs, resp.GetCreatedOrder().GetId())
+		// Keep the creation times apart so the history order is deterministic
+		time.Sleep(time.Millisecond)
+	}
+	for _, orderID := range orderIDs {
+		_, err := historyService.Delete(context.Background(), &pb.OrderSpecificRequest{OrderID: orderID, ChannelID: []byte(assetPair)})
+		assert.NoError(t, err)
+	}
+	return orderIDs
+}
+
+func TestHistoryStorageKeyOrdering(t *testing.T) {
+	earlier := getHistoryStorageKey([]byte(assetPair), time.Unix(1, 0), []byte("b"))
+	later := getHistoryStorageKey([]byte(assetPair), time.Unix(2, 0), []byte("a"))
+	assert.True(t, string(earlier) < string(later))
+}
+
+func TestOrderHistory(t *testing.T) {
+	historyStorage := &inmemory.Storage{Db: make(map[string]string)}
+	historyService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	historyService.RegisterStorage(historyStorage)
+	orderIDs := createHistoryTestOrders(t, historyService)
+
+	// Deleted orders are gone from the order book but kept in the history
+	_, err := historyService.GetOrder(context.Background(), &pb.OrderSpecificRequest{OrderID: orderIDs[0], ChannelID: []byte(assetPair)})
+	assert.Error(t, err)
+
+	history, err := historyService.GetOrderHistory(context.Background(), &pb.OrderHistoryRequest{ChannelID: []byte(assetPair), Limit: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(history.GetOrders()))
+	assert.NotEmpty(t, history.GetNextCursor())
+	assert.Equal(t, orderIDs[0], history.GetOrders()[0].GetId())
+	assert.NotNil(t, history.GetOrders()[0].GetDeletedAt())
+
+	history, err = historyService.GetOrderHistory(context.Background(), &pb.OrderHistoryRequest{ChannelID: []byte(assetPair), Limit: 3, Cursor: history.GetNextCursor()})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(history.GetOrders()))
+	assert.Empty(t, history.GetNextCursor())
+	assert.Equal(t, orderIDs[3], history.GetOrders()[0].GetId())
+
+	// Filter by creation time
+	history, err = historyService.GetOrderHistory(context.Background(), &pb.OrderHistoryRequest{ChannelID: []byte(assetPair), From: history.GetOrders()[1].GetCreated()})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history.GetOrders()))
+	assert.Equal(t, orderIDs[4], history.GetOrders()[0].GetId())
+}
+
+func TestPruneHistory(t *testing.T) {
+	historyStorage := &inmemory.Storage{Db: make(map[string]string)}
+	historyService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	historyService.RegisterStorage(historyStorage)
+	createHistoryTestOrders(t, historyService)
+
+	err := historyService.PruneHistory(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	history, err := historyService.GetOrderHistory(context.Background(), &pb.OrderHistoryRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, historyTestOrders, len(history.GetOrders()))
+
+	err = historyService.PruneHistory(time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	history, err = historyService.GetOrderHistory(context.Background(), &pb.OrderHistoryRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Empty(t, history.GetOrders())
+}
+
+func TestHistoryTTL(t *testing.T) {
+	historyStorage := &inmemory.Storage{Db: make(map[string]string)}
+	historyService := &OrderService{Logger: new(util.PlaceholderLogger), HistoryRetention: time.Hour}
+	historyService.RegisterStorage(historyStorage)
+	createHistoryTestOrders(t, historyService)
+
+	// Deleted orders expire from the history by themselves
+	count, err := historyStorage.Count(context.Background(), string(interfaces.TTLPrefix)+string(interfaces.HistoryPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, historyTestOrders, count)
+
+	deletedAt, err := ptypes.TimestampProto(time.Now().Add(-2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Nanosecond, historyService.historyTTL(deletedAt))
+	assert.True(t, historyService.historyTTL(ptypes.TimestampNow()) > 59*time.Minute)
+
+	historyService.HistoryRetention = 0
+	assert.Equal(t, time.Duration(0), historyService.historyTTL(ptypes.TimestampNow()))
+}
+
+func TestDeletedAtIsNotSigned(t *testing.T) {
+	historyStorage := &inmemory.Storage{Db: make(map[string]string)}
+	historyService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	historyService.RegisterStorage(historyStorage)
+
+	resp, err := historyService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	order.DeletedAt = ptypes.TimestampNow()
+
+	signature, err := historyService.GetSignature(order)
+	assert.NoError(t, err)
+	assert.Equal(t, order.GetSignature(), signature)
+}
+
+func TestSyncHistory(t *testing.T) {
+	ctx := context.Background()
+	historyService := newOwnershipTestService()
+	makerID, _, err := historyService.getMaker()
+	assert.NoError(t, err)
+	createHistoryTestOrders(t, historyService)
+	message, err := historyService.getHistorySyncMessage(ctx, []byte(assetPair))
+	assert.NoError(t, err)
+
+	// Nodes that didn't join the channel with the full history don't take it
+	receiverService := newOwnershipTestService()
+	assert.True(t, errors.Is(errors.Unauthorized, receive(receiverService, message, makerID)))
+
+	channel, err := proto.Marshal(&pb.Channel{Id: []byte(assetPair), History: pb.History_FULL})
+	assert.NoError(t, err)
+	assert.NoError(t, receiverService.Storage.Put(ctx, getChannelStorageKey([]byte(assetPair)), channel))
+	assert.NoError(t, receive(receiverService, message, makerID))
+	history, err := receiverService.GetOrderHistory(ctx, &pb.OrderHistoryRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, historyTestOrders, len(history.GetOrders()))
+	assert.True(t, errors.Is(errors.Duplicate, receive(receiverService, message, makerID)))
+}
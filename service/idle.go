@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getIdleChannelStorageKey(channelID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.IdlePrefix), string(channelID)}, ""))
+}
+
+// channelActivity remembers when each joined channel last had orders, messages or peers
+type channelActivity struct {
+	lock sync.Mutex
+	seen map[string]time.Time
+}
+
+func newChannelActivity() *channelActivity {
+	return &channelActivity{seen: make(map[string]time.Time)}
+}
+
+// touch marks the channel active at the given time
+func (a *channelActivity) touch(channelID []byte, at time.Time) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if at.After(a.seen[string(channelID)]) {
+		a.seen[string(channelID)] = at
+	}
+}
+
+// lastSeen returns when the channel was last active, and false if it hasn't been seen since the node started
+func (a *channelActivity) lastSeen(channelID []byte) (time.Time, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	seen, ok := a.seen[string(channelID)]
+	return seen, ok
+}
+
+func (a *channelActivity) forget(channelID []byte) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.seen, string(channelID))
+}
+
+// touchChannel marks a channel active, if activity is tracked
+func (s *OrderService) touchChannel(channelID []byte) {
+	if s.activity != nil {
+		s.activity.touch(channelID, time.Now())
+	}
+}
+
+// getPeerChannels returns the IDs of the joined channels that connected peers are on too
+func (s *ChannelService) getPeerChannels() map[string]bool {
+	channels := make(map[string]bool)
+	if s.P2p == nil {
+		return channels
+	}
+	for _, details := range s.P2p.GetPeerDetails() {
+		for _, channelID := range details.GetChannels() {
+			channels[string(channelID)] = true
+		}
+	}
+	return channels
+}
+
+// LeaveIdle leaves the joined channels that have had no open orders, received messages or peers for IdleTimeout,
+// freeing their gossip mesh and memory. Channels not seen since the node started are given the whole timeout from now.
+// A record of each channel left is kept, so it can be rejoined with the same options. It returns the IDs of the channels left.
+func (s *ChannelService) LeaveIdle(ctx context.Context, now time.Time) ([][]byte, error) {
+	left := [][]byte{}
+	if s.IdleTimeout == 0 || s.activity == nil {
+		return left, nil
+	}
+
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return left, errors.E(errors.Op("Get channels for idle check"), err)
+	}
+	peerChannels := s.getPeerChannels()
+	for _, value := range data {
+		channel := &pb.Channel{}
+		err = proto.Unmarshal([]byte(value), channel)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		channelID := channel.GetId()
+
+		orders, err := s.Storage.GetAllWithPrefix(ctx, string(getOrderQueryPrefix(channelID)))
+		if !errors.IsEmpty(err) {
+			return left, errors.E(errors.Op("Get orders for idle check"), err)
+		}
+		if len(orders) > 0 || peerChannels[string(channelID)] {
+			s.activity.touch(channelID, now)
+			continue
+		}
+		lastActive, seen := s.activity.lastSeen(channelID)
+		if !seen {
+			s.activity.touch(channelID, now)
+			continue
+		}
+		if now.Sub(lastActive) < s.IdleTimeout {
+			continue
+		}
+
+		err = s.leaveIdle(ctx, channel, lastActive, now)
+		if !errors.IsEmpty(err) {
+			return left, err
+		}
+		left = append(left, channelID)
+	}
+	return left, nil
+}
+
+// leaveIdle unsubscribes from an idle channel and moves it from the joined channels into the idle ones
+func (s *ChannelService) leaveIdle(ctx context.Context, channel *pb.Channel, lastActive time.Time, now time.Time) error {
+	idle := &pb.IdleChannel{Channel: channel}
+	idle.LastActive, _ = ptypes.TimestampProto(lastActive)
+	idle.Left, _ = ptypes.TimestampProto(now)
+	data, err := proto.Marshal(idle)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal idle channel"), err)
+	}
+	err = s.Storage.Put(ctx, getIdleChannelStorageKey(channel.GetId()), data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put idle channel"), err)
+	}
+
+	if s.P2p != nil {
+		s.P2p.Unsubscribe(channel)
+	}
+	err = s.Storage.Delete(ctx, getChannelStorageKey(channel.GetId()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Delete idle channel"), err)
+	}
+	s.activity.forget(channel.GetId())
+	return nil
+}
+
+// GetIdleChannels lists the channels that were left for being idle, with when they were last active and when they were left
+func (s *ChannelService) GetIdleChannels(ctx context.Context, in *pb.Empty) (*pb.IdleChannelList, error) {
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.IdlePrefix))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get idle channels"), err))
+	}
+
+	channels := make([]*pb.IdleChannel, 0, len(data))
+	for _, value := range data {
+		idle := &pb.IdleChannel{}
+		err = proto.Unmarshal([]byte(value), idle)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		channels = append(channels, idle)
+	}
+	return &pb.IdleChannelList{Channels: channels}, nil
+}
+
+// Rejoin joins a channel that was left for being idle again, with the options, admins, membership and config it had
+func (s *ChannelService) Rejoin(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.JoinResponse, error) {
+	data, err := s.Storage.Get(ctx, getIdleChannelStorageKey(in.GetId()))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.NotFound, "%s", errors.E(errors.Op("Rejoin"), "channel hasn't been left for being idle"))
+	}
+	idle := &pb.IdleChannel{}
+	err = proto.Unmarshal(data, idle)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Unmarshal idle channel"), err))
+	}
+	channel := idle.GetChannel()
+	marshaledChannel, err := proto.Marshal(channel)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Rejoin"), err))
+	}
+
+	_, err = s.P2p.Subscribe(channel)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "%s", errors.E(errors.Op("Subscribe"), err))
+	}
+	err = s.Storage.Put(ctx, getChannelStorageKey(channel.GetId()), marshaledChannel)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Saving channel to database in Rejoin"), err))
+	}
+	err = s.Storage.Delete(ctx, getIdleChannelStorageKey(channel.GetId()))
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Delete idle channel record"), err))
+	}
+
+	return &pb.JoinResponse{JoinedChannel: channel}, nil
+}
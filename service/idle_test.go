@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/interfaces/mocks"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaveIdle(t *testing.T) {
+	ctx := context.Background()
+	idleChannel := &pb.Channel{Id: []byte("idle"), Options: &pb.ChannelOptions{TickSize: 0.01}}
+	peerChannel := &pb.Channel{Id: []byte("peers")}
+	orderChannel := &pb.Channel{Id: []byte("orders")}
+
+	p2p := new(mocks.P2p)
+	p2p.On("GetPeerDetails").Return([]*pb.PeerDetails{{Id: "peer", Channels: [][]byte{peerChannel.GetId()}}})
+	p2p.On("Unsubscribe", mock.Anything).Return()
+	p2p.On("Subscribe", mock.Anything).Return(ctx, nil)
+
+	channelService := &ChannelService{IdleTimeout: time.Hour, activity: newChannelActivity()}
+	channelService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	channelService.RegisterP2p(p2p)
+	for _, channel := range []*pb.Channel{idleChannel, peerChannel, orderChannel} {
+		data, err := proto.Marshal(channel)
+		assert.NoError(t, err)
+		assert.NoError(t, channelService.Storage.Put(ctx, getChannelStorageKey(channel.GetId()), data))
+	}
+	assert.NoError(t, channelService.Storage.Put(ctx, getOrderStorageKey(orderChannel.GetId(), []byte("order")), []byte("order")))
+
+	// Channels get the whole timeout from when they're first seen
+	now := time.Now()
+	left, err := channelService.LeaveIdle(ctx, now)
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	left, err = channelService.LeaveIdle(ctx, now.Add(30*time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+
+	// Only the channel without orders or peers is left
+	left, err = channelService.LeaveIdle(ctx, now.Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{idleChannel.GetId()}, left)
+	p2p.AssertCalled(t, "Unsubscribe", mock.MatchedBy(func(channel *pb.Channel) bool { return proto.Equal(idleChannel, channel) }))
+	channels, err := channelService.GetAllChannels(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(channels.GetChannels()))
+
+	idleChannels, err := channelService.GetIdleChannels(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(idleChannels.GetChannels()))
+	assert.True(t, proto.Equal(idleChannel, idleChannels.GetChannels()[0].GetChannel()))
+
+	// Rejoining restores the channel with its options
+	joined, err := channelService.Rejoin(ctx, &pb.ChannelSpecificRequest{Id: idleChannel.GetId()})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(idleChannel, joined.GetJoinedChannel()))
+	stored, err := channelService.GetChannel(ctx, &pb.ChannelSpecificRequest{Id: idleChannel.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, float32(0.01), stored.GetOptions().GetTickSize())
+	idleChannels, err = channelService.GetIdleChannels(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Empty(t, idleChannels.GetChannels())
+
+	_, err = channelService.Rejoin(ctx, &pb.ChannelSpecificRequest{Id: idleChannel.GetId()})
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
+)
+
+// maxDeleteAllOrders is how many orders one DELETE_ALL message cancels at most, which keeps it under the default maximum message size
+const maxDeleteAllOrders int = 1000
+
+// isFillable tells if an order can still be taken, in full or in what's left of it
+func isFillable(order *pb.Order) bool {
+	return order.GetState() == pb.State_OPEN || order.GetState() == pb.State_PARTIALLY_FILLED
+}
+
+// getOwnOpenOrders returns the orders of the channel that this node made, that can still be filled and that the calling client may delete.
+// Locked orders are left alone, since a counterparty may already be settling them.
+func (s *OrderService) getOwnOpenOrders(ctx context.Context, channelID []byte) ([]*pb.Order, error) {
+	ownID, _, err := s.getMaker()
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(getOrderQueryPrefix(channelID)))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get open orders"), err)
+	}
+	orders := make([]*pb.Order, 0)
+	for _, value := range data {
+		order := &pb.Order{}
+		if err := proto.Unmarshal([]byte(value), order); !errors.IsEmpty(err) {
+			continue
+		}
+		if !isFillable(order) || !s.isSameIdentity(ctx, peer.ID(order.GetMakerPeerID()), ownID) {
+			continue
+		}
+		if err := s.authorizeNamespace(ctx, channelID, order.GetId()); !errors.IsEmpty(err) {
+			continue
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// cancelOwnOrders deletes this node's open orders on the channel in one batch and broadcasts them in a DELETE_ALL message,
+// split in several if there are more than maxDeleteAllOrders
+func (s *OrderService) cancelOwnOrders(ctx context.Context, channelID []byte) ([]*pb.Order, error) {
+	orders, err := s.getOwnOpenOrders(ctx, channelID)
+	if !errors.IsEmpty(err) || len(orders) == 0 {
+		return orders, err
+	}
+
+	if s.P2p != nil {
+		for start := 0; start < len(orders); start += maxDeleteAllOrders {
+			end := start + maxDeleteAllOrders
+			if end > len(orders) {
+				end = len(orders)
+			}
+			orderListInBytes, err := proto.Marshal(&pb.OrderList{Orders: orders[start:end]})
+			if !errors.IsEmpty(err) {
+				return nil, errors.E(errors.Op("Marshal cancelled orders"), err)
+			}
+			err = s.P2p.Send(ctx, &pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_DELETE_ALL, Data: orderListInBytes})
+			if !errors.IsEmpty(err) {
+				return nil, errors.E(errors.Op("Send cancelled orders"), err)
+			}
+		}
+	} else {
+		s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
+	}
+
+	err = s.deleteOrders(ctx, channelID, orders)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Delete orders"), err)
+	}
+	for _, order := range orders {
+		orderInBytes, err := proto.Marshal(order)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		s.notify(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_DELETE, Data: orderInBytes})
+		s.mirrorOrder(ctx, channelID, pb.Operation_DELETE, order, orderInBytes, true)
+	}
+	return orders, nil
+}
+
+// DeleteAll moves all open orders this node made on the channel into the order history at once, and broadcasts their deletion to
+// the other nodes on the channel. Calls made with an API key only delete the orders created with it. The deleted orders are returned.
+func (s *OrderService) DeleteAll(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.OrderList, error) {
+	orders, err := s.cancelOwnOrders(ctx, in.GetId())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Delete all orders"), err)
+	}
+	return &pb.OrderList{Orders: orders}, nil
+}
+
+// KillSwitch deletes all open orders this node made on every joined channel, or on the requested channel only,
+// for pulling out of the market in a hurry. The deleted orders are returned.
+func (s *OrderService) KillSwitch(ctx context.Context, in *pb.KillSwitchRequest) (*pb.OrderList, error) {
+	channelIDs := [][]byte{in.GetChannelID()}
+	if len(in.GetChannelID()) == 0 {
+		channels, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Get joined channels for kill switch"), err)
+		}
+		channelIDs = make([][]byte, 0, len(channels))
+		for _, value := range channels {
+			channel := &pb.Channel{}
+			if err := proto.Unmarshal([]byte(value), channel); !errors.IsEmpty(err) {
+				continue
+			}
+			channelIDs = append(channelIDs, channel.GetId())
+		}
+	}
+
+	cancelled := &pb.OrderList{Orders: make([]*pb.Order, 0)}
+	for _, channelID := range channelIDs {
+		orders, err := s.cancelOwnOrders(ctx, channelID)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Kill switch on "+string(channelID)), err)
+		}
+		cancelled.Orders = append(cancelled.Orders, orders...)
+	}
+	requestid.Logger(ctx, s.Logger).Infof("Kill switch cancelled %d orders on %d channels", len(cancelled.GetOrders()), len(channelIDs))
+	return cancelled, nil
+}
+
+// receiveDeleteAll deletes the orders a peer cancelled at once. The peer must be allowed to delete every one of them, or none
+// is deleted. Each deleted order is pushed to websockets and webhooks as a delete of its own.
+func (s *OrderService) receiveDeleteAll(ctx context.Context, channelID []byte, data []byte, from peer.ID) error {
+	orderList := &pb.OrderList{}
+	err := proto.Unmarshal(data, orderList)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal cancelled orders"), errors.Malformed, err)
+	}
+
+	// The stored orders are authorized and deleted, since their state and so their index entries may differ from the peer's copies
+	orders := make([]*pb.Order, 0, len(orderList.GetOrders()))
+	for _, order := range orderList.GetOrders() {
+		storedInBytes, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, order.GetId()))
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		stored := &pb.Order{}
+		if err := proto.Unmarshal(storedInBytes, stored); !errors.IsEmpty(err) {
+			continue
+		}
+		err = s.authorize(ctx, channelID, stored, from)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Authorize delete all in Receive"), err)
+		}
+		orders = append(orders, stored)
+	}
+	if len(orders) == 0 {
+		return errors.E(errors.Op("Check for duplicate delete all"), errors.Duplicate, "none of the orders are in the order book")
+	}
+
+	err = s.deleteOrders(ctx, channelID, orders)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Delete orders"), err)
+	}
+	for _, order := range orders {
+		orderInBytes, err := proto.Marshal(order)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		wireMessage := &pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_DELETE, Data: orderInBytes}
+		s.notify(wireMessage)
+		if s.websocket != nil {
+			s.websocket.PushToWebsockets(ctx, wireMessage, nil)
+		}
+		s.mirrorOrder(ctx, channelID, pb.Operation_DELETE, order, orderInBytes, false)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces/mocks"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteAll(t *testing.T) {
+	ctx := context.Background()
+	var sent *pb.WireMessage
+	p2p := new(mocks.P2p)
+	p2p.On("Send", mock.Anything, mock.MatchedBy(func(message *pb.WireMessage) bool {
+		if message.GetOperation() == pb.Operation_DELETE_ALL {
+			sent = message
+		}
+		return true
+	})).Return(nil)
+	maker := newOwnershipTestService()
+	maker.RegisterP2p(p2p)
+	receiver := newOwnershipTestService()
+
+	orders := []*pb.Order{}
+	for i := 0; i < 2; i++ {
+		resp, err := maker.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount + uint64(i), Price: testPrice})
+		assert.NoError(t, err)
+		orderInBytes, err := proto.Marshal(resp.GetCreatedOrder())
+		assert.NoError(t, err)
+		assert.NoError(t, receiveCreate(receiver, orderInBytes, peer.ID(resp.GetCreatedOrder().GetMakerPeerID())))
+		orders = append(orders, resp.GetCreatedOrder())
+	}
+	makerID := peer.ID(orders[0].GetMakerPeerID())
+	_, err := maker.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: orders[1].GetId()})
+	assert.NoError(t, err)
+
+	// Only the open orders are deleted, in one message
+	deleted, err := maker.DeleteAll(ctx, &pb.ChannelSpecificRequest{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deleted.GetOrders()))
+	assert.Equal(t, orders[0].GetId(), deleted.GetOrders()[0].GetId())
+	exists, err := maker.Storage.Has(ctx, getOrderStorageKey([]byte(assetPair), orders[0].GetId()))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = maker.Storage.Has(ctx, getOrderStorageKey([]byte(assetPair), orders[1].GetId()))
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NotNil(t, sent)
+
+	// Peers can't cancel the orders of others
+	strangerID, _ := newStranger(t)
+	assert.True(t, errors.Is(errors.Unauthorized, receiver.process(ctx, sent, strangerID)))
+	exists, err = receiver.Storage.Has(ctx, getOrderStorageKey([]byte(assetPair), orders[0].GetId()))
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	// The maker's cancel moves the orders to the history, and webhooks get a delete of each order
+	server, received := newWebhookTestServer(t, 0)
+	defer server.Close()
+	receiver.RegisterWebhooks(NewWebhooks(new(util.PlaceholderLogger), []string{server.URL}, nil, testWebhookSecret, 0))
+	sentInBytes, err := proto.Marshal(sent)
+	assert.NoError(t, err)
+	assert.NoError(t, receive(receiver, sentInBytes, makerID))
+	requests := waitForWebhooks(received, 2)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "deleted", requests[0].event)
+	exists, err = receiver.Storage.Has(ctx, getOrderStorageKey([]byte(assetPair), orders[0].GetId()))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	history, err := receiver.GetOrderHistory(ctx, &pb.OrderHistoryRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history.GetOrders()))
+	assert.True(t, errors.Is(errors.Duplicate, receiver.process(ctx, sent, makerID)))
+
+	// The kill switch cancels the rest once they're open
+	_, err = maker.Unlock(ctx, &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: orders[1].GetId()})
+	assert.NoError(t, err)
+	deleted, err = maker.KillSwitch(ctx, &pb.KillSwitchRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deleted.GetOrders()))
+	assert.Equal(t, orders[1].GetId(), deleted.GetOrders()[0].GetId())
+}
+
+func TestKillSwitchCancelsPartiallyFilledOrders(t *testing.T) {
+	ctx := context.Background()
+	maker := newOwnershipTestService()
+	states := []pb.State{pb.State_OPEN, pb.State_PARTIALLY_FILLED, pb.State_FILLED, pb.State_SETTLED}
+	orders := []*pb.Order{}
+	for i, state := range states {
+		resp, err := maker.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount + uint64(i), Price: testPrice})
+		assert.NoError(t, err)
+		order := resp.GetCreatedOrder()
+		if state != pb.State_OPEN {
+			order.State = state
+			order.FilledAmount = 1
+			orderInBytes, err := proto.Marshal(order)
+			assert.NoError(t, err)
+			assert.NoError(t, maker.Storage.Put(ctx, getOrderStorageKey([]byte(assetPair), order.GetId()), orderInBytes))
+		}
+		orders = append(orders, order)
+	}
+
+	// Orders that can still be filled are cancelled, and the rest are left alone
+	deleted, err := maker.KillSwitch(ctx, &pb.KillSwitchRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	deletedIDs := [][]byte{}
+	for _, order := range deleted.GetOrders() {
+		deletedIDs = append(deletedIDs, order.GetId())
+	}
+	assert.ElementsMatch(t, [][]byte{orders[0].GetId(), orders[1].GetId()}, deletedIDs)
+	for i, order := range orders {
+		exists, err := maker.Storage.Has(ctx, getOrderStorageKey([]byte(assetPair), order.GetId()))
+		assert.NoError(t, err)
+		assert.Equal(t, i > 1, exists)
+	}
+}
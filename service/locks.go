@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// isLockExpired tells if the order is locked and its lock had timed out by now
+func isLockExpired(order *pb.Order, now time.Time) bool {
+	if order.GetState() != pb.State_LOCKED || order.GetLockedUntil() == nil {
+		return false
+	}
+	lockedUntil, err := ptypes.Timestamp(order.GetLockedUntil())
+	if !errors.IsEmpty(err) {
+		return false
+	}
+	return !now.Before(lockedUntil)
+}
+
+// UnlockExpired opens the orders whose locks have timed out and broadcasts the unlocks to their channels.
+// Only orders this node may unlock are handled, other nodes unlock their own orders.
+func (s *OrderService) UnlockExpired(now time.Time) error {
+	ctx := context.Background()
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get orders for unlocking"), err)
+	}
+
+	for key, value := range data {
+		order := &pb.Order{}
+		proto.Unmarshal([]byte(value), order)
+		if !isLockExpired(order, now) {
+			continue
+		}
+
+		channelID := []byte(key[len(interfaces.OrderPrefix) : len(key)-len(order.GetId())])
+		if !errors.IsEmpty(s.authorizeSelf(ctx, channelID, order)) {
+			continue
+		}
+
+		// Unlocking a routed order also unlocks its mirrors, so the state is read again
+		request := &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: channelID}
+		current, err := s.GetOrder(ctx, request)
+		if !errors.IsEmpty(err) || !isLockExpired(current, now) {
+			continue
+		}
+
+		s.Logger.Infof("Lock of order %x on channel %s timed out, unlocking", order.GetId(), channelID)
+		_, err = s.Unlock(ctx, request)
+		if !errors.IsEmpty(err) {
+			s.Logger.Warn(errors.E(errors.Op("Unlock timed out order"), err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockTimeout(t *testing.T) {
+	lockService := newOwnershipTestService()
+	lockService.LockTimeout = time.Minute
+	resp, err := lockService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	request := &pb.OrderSpecificRequest{OrderID: resp.GetCreatedOrder().GetId(), ChannelID: []byte(assetPair)}
+
+	_, err = lockService.Lock(context.Background(), request)
+	assert.NoError(t, err)
+	lockedOrder, err := lockService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.NotNil(t, lockedOrder.GetLockedUntil())
+	// The lock timeout isn't signed, so the order still verifies
+	_, err = lockService.verifyMaker(lockedOrder)
+	assert.NoError(t, err)
+
+	// Another node has the locked order, but only the maker unlocks it
+	strangerService := newOwnershipTestService()
+	orderInBytes, err := proto.Marshal(lockedOrder)
+	assert.NoError(t, err)
+	err = strangerService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), lockedOrder.GetId()), orderInBytes)
+	assert.NoError(t, err)
+	assert.NoError(t, strangerService.UnlockExpired(time.Now().Add(time.Hour)))
+	strangerOrder, err := strangerService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, strangerOrder.GetState())
+
+	assert.NoError(t, lockService.UnlockExpired(time.Now()))
+	order, err := lockService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, order.GetState())
+
+	assert.NoError(t, lockService.UnlockExpired(time.Now().Add(2*time.Minute)))
+	order, err = lockService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_OPEN, order.GetState())
+	assert.Nil(t, order.GetLockedUntil())
+	assert.Equal(t, lockedOrder.GetNonce()+1, order.GetNonce())
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	fmt "fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/assets"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// marketAPIPath is where the markets are listed, and each market's order book and trades are under it
+const marketAPIPath string = "/v1/markets"
+
+// defaultMarketAPIMaxAge is how long responses may be cached if MarketAPIMaxAge isn't set
+const defaultMarketAPIMaxAge time.Duration = 5 * time.Second
+
+// defaultTradeLimit and maxTradeLimit bound how many trades are returned at once
+const defaultTradeLimit int = 100
+const maxTradeLimit int = 1000
+
+// apiTicker is the 24 hour ticker of a market in the market data API
+type apiTicker struct {
+	Last   float32 `json:"last"`
+	Open   float32 `json:"open"`
+	High   float32 `json:"high"`
+	Low    float32 `json:"low"`
+	Change float32 `json:"change"`
+	Volume uint64  `json:"volume"`
+	Trades uint32  `json:"trades"`
+}
+
+// apiMarket is a joined channel in the market data API
+type apiMarket struct {
+	ID     string     `json:"id"`
+	Pair   string     `json:"pair"`
+	Orders int        `json:"orders"`
+	Ticker *apiTicker `json:"ticker,omitempty"`
+}
+
+// apiOrder is an open order in the market data API, without the signatures and keys of its maker.
+// Its side is relative to the first asset of the market's pair, and its amount is what's left to fill.
+type apiOrder struct {
+	ID           string    `json:"id"`
+	Side         string    `json:"side"`
+	Asset        string    `json:"asset"`
+	CounterAsset string    `json:"counterAsset"`
+	Price        float32   `json:"price"`
+	Amount       uint64    `json:"amount"`
+	State        string    `json:"state"`
+	Created      time.Time `json:"created"`
+}
+
+// apiTrade is a fill of an order in the market data API
+type apiTrade struct {
+	OrderID  string    `json:"orderID"`
+	Price    float32   `json:"price"`
+	Amount   uint64    `json:"amount"`
+	Executed time.Time `json:"executed"`
+}
+
+type apiOrderBook struct {
+	ID     string     `json:"id"`
+	Pair   string     `json:"pair"`
+	Orders []apiOrder `json:"orders"`
+}
+
+type apiTrades struct {
+	ID     string     `json:"id"`
+	Pair   string     `json:"pair"`
+	Trades []apiTrade `json:"trades"`
+}
+
+// marketPair writes the asset pair of a channel like BTC/ETH
+func marketPair(channelID []byte) string {
+	return strings.Replace(string(NormalizeAssetPair(channelID)), ",", "/", -1)
+}
+
+// orderSide tells if an order sells or buys the first asset of the market's pair
+func orderSide(channelID []byte, order *pb.Order) string {
+	base := strings.Split(string(NormalizeAssetPair(channelID)), ",")[0]
+	if assets.Canonical(order.GetAsset()) == base {
+		return "sell"
+	}
+	return "buy"
+}
+
+// newMarketAPI serves the read-only market data API on MarketAPIPort, so websites can show the markets without gRPC
+func (server *Server) newMarketAPI() *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(marketAPIPath, server.serveMarkets)
+	mux.HandleFunc(marketAPIPath+"/", server.serveMarket)
+	return &http.Server{Addr: fmt.Sprintf(":%d", server.MarketAPIPort), Handler: mux}
+}
+
+func (server *Server) runMarketAPI() {
+	server.Logger.Infof("Serving the market data API on port %d", server.MarketAPIPort)
+	err := server.marketAPI.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		server.Logger.Error(errors.E(errors.Op("Serve market data API"), err))
+	}
+}
+
+// closeMarketAPI stops the market data API, if it's running
+func (server *Server) closeMarketAPI() {
+	if server.marketAPI != nil {
+		server.marketAPI.Shutdown(context.Background())
+	}
+}
+
+func (server *Server) marketAPIMaxAge() time.Duration {
+	if server.MarketAPIMaxAge == 0 {
+		return defaultMarketAPIMaxAge
+	}
+	return server.MarketAPIMaxAge
+}
+
+// checkMarketAPIRequest answers the requests that can't be served, and tells if the request can be
+func (server *Server) checkMarketAPIRequest(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeAPIError(w, http.StatusMethodNotAllowed, "the market data API is read-only")
+		return false
+	}
+	if atomic.LoadInt32(&server.standby) == 1 {
+		writeAPIError(w, http.StatusServiceUnavailable, "the node is a standby following a primary")
+		return false
+	}
+	return true
+}
+
+// writeAPIError answers with an error that isn't cached
+func writeAPIError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// writeGRPCError answers with the HTTP status matching the gRPC status of err
+func writeGRPCError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.FailedPrecondition:
+		code = http.StatusServiceUnavailable
+	}
+	writeAPIError(w, code, err.Error())
+}
+
+// etagMatches tells if the client already has the response, by the ETags in its If-None-Match header
+func etagMatches(r *http.Request, etag string) bool {
+	for _, match := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		match = strings.TrimPrefix(strings.TrimSpace(match), "W/")
+		if match == etag || match == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeCached answers with the value as JSON, tagged with the hash of the body so that clients and CDNs can revalidate it
+func (server *Server) writeCached(w http.ResponseWriter, r *http.Request, value interface{}) {
+	body, err := json.Marshal(value)
+	if !errors.IsEmpty(err) {
+		writeAPIError(w, http.StatusInternalServerError, errors.E(errors.Op("Marshal market data"), err).Error())
+		return
+	}
+	hash := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(server.marketAPIMaxAge()/time.Second)))
+	w.Header().Set("ETag", etag)
+	if etagMatches(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// serveMarkets lists the joined channels with their number of open orders and, if market data is enabled, their tickers
+func (server *Server) serveMarkets(w http.ResponseWriter, r *http.Request) {
+	if !server.checkMarketAPIRequest(w, r) {
+		return
+	}
+	channels, err := server.Channels.GetAllChannels(r.Context(), &pb.Empty{})
+	if !errors.IsEmpty(err) {
+		writeGRPCError(w, err)
+		return
+	}
+
+	markets := make([]apiMarket, 0, len(channels.GetChannels()))
+	for _, channel := range channels.GetChannels() {
+		request := &pb.ChannelSpecificRequest{Id: channel.GetId()}
+		orders, err := server.Orders.GetOrderBook(r.Context(), request)
+		if !errors.IsEmpty(err) {
+			writeGRPCError(w, err)
+			return
+		}
+		market := apiMarket{ID: string(channel.GetId()), Pair: marketPair(channel.GetId()), Orders: len(orders.GetOrders())}
+		if len(server.MarketData.Intervals) > 0 {
+			ticker, err := server.MarketData.GetTicker(r.Context(), request)
+			if !errors.IsEmpty(err) {
+				writeGRPCError(w, err)
+				return
+			}
+			market.Ticker = &apiTicker{
+				Last:   ticker.GetLast(),
+				Open:   ticker.GetOpen(),
+				High:   ticker.GetHigh(),
+				Low:    ticker.GetLow(),
+				Change: ticker.GetChange(),
+				Volume: ticker.GetVolume(),
+				Trades: ticker.GetTrades(),
+			}
+		}
+		markets = append(markets, market)
+	}
+	sort.Slice(markets, func(i, j int) bool { return markets[i].ID < markets[j].ID })
+	server.writeCached(w, r, map[string][]apiMarket{"markets": markets})
+}
+
+// serveMarket serves the order book or the trades of a joined channel, whose ID is escaped in the path
+func (server *Server) serveMarket(w http.ResponseWriter, r *http.Request) {
+	if !server.checkMarketAPIRequest(w, r) {
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, marketAPIPath+"/")
+	separator := strings.LastIndex(path, "/")
+	if separator <= 0 {
+		writeAPIError(w, http.StatusNotFound, "use "+marketAPIPath+"/{id}/orderbook or "+marketAPIPath+"/{id}/trades")
+		return
+	}
+	channelID := []byte(path[:separator])
+	_, err := server.Channels.GetChannel(r.Context(), &pb.ChannelSpecificRequest{Id: channelID})
+	if !errors.IsEmpty(err) {
+		writeGRPCError(w, err)
+		return
+	}
+
+	switch path[separator+1:] {
+	case "orderbook":
+		server.serveOrderBook(w, r, channelID)
+	case "trades":
+		server.serveTrades(w, r, channelID)
+	default:
+		writeAPIError(w, http.StatusNotFound, "unknown market resource "+path[separator+1:])
+	}
+}
+
+// serveOrderBook serves the open orders of a channel, sorted by price and then creation time
+func (server *Server) serveOrderBook(w http.ResponseWriter, r *http.Request, channelID []byte) {
+	orders, err := server.Orders.GetOrderBook(r.Context(), &pb.ChannelSpecificRequest{Id: channelID})
+	if !errors.IsEmpty(err) {
+		writeGRPCError(w, err)
+		return
+	}
+	book := apiOrderBook{ID: string(channelID), Pair: marketPair(channelID), Orders: make([]apiOrder, 0, len(orders.GetOrders()))}
+	for _, order := range orders.GetOrders() {
+		created, _ := ptypes.Timestamp(order.GetCreated())
+		book.Orders = append(book.Orders, apiOrder{
+			ID:           hex.EncodeToString(order.GetId()),
+			Side:         orderSide(channelID, order),
+			Asset:        order.GetAsset(),
+			CounterAsset: order.GetCounterAsset(),
+			Price:        order.GetPrice(),
+			Amount:       order.GetAmount() - order.GetFilledAmount(),
+			State:        order.GetState().String(),
+			Created:      created.UTC(),
+		})
+	}
+	server.writeCached(w, r, book)
+}
+
+// serveTrades serves the latest trades of a channel, newest first. The limit query parameter sets how many.
+func (server *Server) serveTrades(w http.ResponseWriter, r *http.Request, channelID []byte) {
+	if len(server.MarketData.Intervals) == 0 {
+		writeAPIError(w, http.StatusServiceUnavailable, "market data is disabled")
+		return
+	}
+	limit := defaultTradeLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxTradeLimit {
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("limit should be between 1 and %d", maxTradeLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	trades, err := server.MarketData.getTrades(r.Context(), channelID, limit)
+	if !errors.IsEmpty(err) {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response := apiTrades{ID: string(channelID), Pair: marketPair(channelID), Trades: make([]apiTrade, 0, len(trades))}
+	for _, trade := range trades {
+		executed, _ := ptypes.Timestamp(trade.GetExecuted())
+		response.Trades = append(response.Trades, apiTrade{
+			OrderID:  hex.EncodeToString(trade.GetOrderID()),
+			Price:    trade.GetPrice(),
+			Amount:   trade.GetAmount(),
+			Executed: executed.UTC(),
+		})
+	}
+	server.writeCached(w, r, response)
+}
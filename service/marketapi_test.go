@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func getMarketAPI(handler http.Handler, path string, etag string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestMarketAPI(t *testing.T) {
+	ctx := context.Background()
+	server := NewServer(log, &inmemory.Storage{Db: make(map[string]string)}, nil, nil)
+	server.MarketData.Intervals = []time.Duration{time.Minute}
+	handler := server.newMarketAPI().Handler
+
+	channelInBytes, err := proto.Marshal(&pb.Channel{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.NoError(t, server.Channels.Storage.Put(ctx, getChannelStorageKey([]byte(assetPair)), channelInBytes))
+	order := &pb.Order{Id: []byte("order"), Asset: asset1, CounterAsset: asset2, Amount: 100, FilledAmount: 40, Price: testPrice, Created: ptypes.TimestampNow()}
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Orders.putOrder(ctx, []byte(assetPair), order, orderInBytes))
+	assert.NoError(t, server.MarketData.recordTrade(ctx, []byte(assetPair), order.GetId(), testPrice, 40, time.Now()))
+
+	response := getMarketAPI(handler, marketAPIPath, "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "public, max-age=5", response.Header().Get("Cache-Control"))
+	assert.Equal(t, "*", response.Header().Get("Access-Control-Allow-Origin"))
+	markets := map[string][]apiMarket{}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &markets))
+	assert.Equal(t, 1, len(markets["markets"]))
+	assert.Equal(t, assetPair, markets["markets"][0].ID)
+	assert.Equal(t, 1, markets["markets"][0].Orders)
+	assert.Equal(t, uint64(40), markets["markets"][0].Ticker.Volume)
+
+	// A client that has the response gets it revalidated without the body
+	etag := response.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	response = getMarketAPI(handler, marketAPIPath, etag)
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Empty(t, response.Body.Bytes())
+
+	path := marketAPIPath + "/" + url.PathEscape(assetPair)
+	response = getMarketAPI(handler, path+"/orderbook", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	book := apiOrderBook{}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &book))
+	assert.Equal(t, 1, len(book.Orders))
+	assert.Equal(t, uint64(60), book.Orders[0].Amount)
+
+	response = getMarketAPI(handler, path+"/trades?limit=10", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	trades := apiTrades{}
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &trades))
+	assert.Equal(t, 1, len(trades.Trades))
+	assert.Equal(t, uint64(40), trades.Trades[0].Amount)
+
+	assert.Equal(t, http.StatusBadRequest, getMarketAPI(handler, path+"/trades?limit=0", "").Code)
+	assert.Equal(t, http.StatusNotFound, getMarketAPI(handler, marketAPIPath+"/unknown/orderbook", "").Code)
+	assert.Equal(t, http.StatusNotFound, getMarketAPI(handler, path+"/candles", "").Code)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, marketAPIPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	server.SetStandby(true)
+	assert.Equal(t, http.StatusServiceUnavailable, getMarketAPI(handler, marketAPIPath, "").Code)
+}
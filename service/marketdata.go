@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tickerPeriod is how far back the ticker's statistics reach
+const tickerPeriod time.Duration = 24 * time.Hour
+
+func getCandleQueryPrefix(channelID []byte, interval time.Duration) []byte {
+	seconds := make([]byte, 4)
+	binary.BigEndian.PutUint32(seconds, uint32(interval/time.Second))
+	return []byte(strings.Join([]string{string(interfaces.CandlePrefix), string(channelID), string(seconds)}, ""))
+}
+
+// getCandleStorageKey orders a channel's candles of an interval by their start time
+func getCandleStorageKey(channelID []byte, interval time.Duration, start time.Time) []byte {
+	timestamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(timestamp, uint64(start.Unix()))
+	return []byte(string(getCandleQueryPrefix(channelID, interval)) + string(timestamp))
+}
+
+// getTradeStorageKey orders a channel's trades by when they were executed
+func getTradeStorageKey(channelID []byte, executed time.Time, orderID []byte) []byte {
+	timestamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(timestamp, uint64(executed.UnixNano()))
+	return []byte(strings.Join([]string{string(interfaces.TradePrefix), string(channelID), string(timestamp), string(orderID)}, ""))
+}
+
+// ParseIntervals parses comma separated durations, like 1m or 24h, into candle intervals sorted from the shortest.
+// Intervals are whole seconds.
+func ParseIntervals(intervals string) ([]time.Duration, error) {
+	parsed := []time.Duration{}
+	if intervals == "" {
+		return parsed, nil
+	}
+	for _, value := range strings.Split(intervals, ",") {
+		interval, err := time.ParseDuration(strings.TrimSpace(value))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Parse candle intervals"), errors.Invalid, err)
+		}
+		if interval < time.Second || interval%time.Second != 0 {
+			return nil, errors.E(errors.Op("Parse candle intervals"), errors.Invalid, "candle intervals should be whole seconds")
+		}
+		for _, other := range parsed {
+			if other == interval {
+				return nil, errors.E(errors.Op("Parse candle intervals"), errors.Invalid, "candle interval "+value+" is given twice")
+			}
+		}
+		parsed = append(parsed, interval)
+	}
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i] < parsed[j] })
+	return parsed, nil
+}
+
+// MarketDataService aggregates the trades of each channel into OHLCV candles and serves them with a 24 hour ticker
+type MarketDataService struct {
+	Storage   interfaces.Storage
+	websocket interfaces.WebsocketService
+	// Intervals are the durations candles are aggregated over, from the shortest. Empty disables market data.
+	Intervals []time.Duration
+	lock      sync.Mutex
+}
+
+// RegisterStorage registers a storage service to store the candles in
+func (s *MarketDataService) RegisterStorage(storage interfaces.Storage) {
+	s.Storage = storage
+}
+
+// RegisterWebsocket registers a websocket service that updated candles are pushed to
+func (s *MarketDataService) RegisterWebsocket(websocket interfaces.WebsocketService) {
+	s.websocket = websocket
+}
+
+// addTrade updates a candle with a trade. An empty candle opens at the trade's price.
+func addTrade(candle *pb.Candle, price float32, amount uint64) {
+	if candle.GetTrades() == 0 {
+		candle.Open, candle.High, candle.Low = price, price, price
+	}
+	if price > candle.GetHigh() {
+		candle.High = price
+	}
+	if price < candle.GetLow() {
+		candle.Low = price
+	}
+	candle.Close = price
+	candle.Volume += amount
+	candle.Trades++
+}
+
+// recordTrade stores a trade and adds it to the channel's candle of every interval, pushing the updated candles to websocket clients
+func (s *MarketDataService) recordTrade(ctx context.Context, channelID []byte, orderID []byte, price float32, amount uint64, at time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	trade := &pb.Trade{ChannelID: channelID, OrderID: orderID, Price: price, Amount: amount}
+	trade.Executed, _ = ptypes.TimestampProto(at)
+	data, err := proto.Marshal(trade)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal trade"), err)
+	}
+	err = s.Storage.Put(ctx, getTradeStorageKey(channelID, at, orderID), data)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put trade"), err)
+	}
+
+	for _, interval := range s.Intervals {
+		start := at.Truncate(interval)
+		key := getCandleStorageKey(channelID, interval, start)
+		candle := &pb.Candle{ChannelID: channelID, Interval: uint32(interval / time.Second)}
+		candle.Start, _ = ptypes.TimestampProto(start)
+
+		exists, err := s.Storage.Has(ctx, key)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check candle"), err)
+		}
+		if exists {
+			data, err := s.Storage.Get(ctx, key)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Get candle"), err)
+			}
+			err = proto.Unmarshal(data, candle)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Unmarshal candle"), err)
+			}
+		}
+
+		addTrade(candle, price, amount)
+		data, err := proto.Marshal(candle)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Marshal candle"), err)
+		}
+		err = s.Storage.Put(ctx, key, data)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put candle"), err)
+		}
+		if s.websocket != nil {
+			s.websocket.PushToWebsockets(ctx, &pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CANDLE, Data: data}, nil)
+		}
+	}
+	return nil
+}
+
+// getCandles returns the channel's candles of an interval that start between from and to, oldest first. Zero limits aren't checked.
+func (s *MarketDataService) getCandles(ctx context.Context, channelID []byte, interval time.Duration, from time.Time, to time.Time) ([]*pb.Candle, error) {
+	stored, err := s.Storage.GetAllWithPrefix(ctx, string(getCandleQueryPrefix(channelID, interval)))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get candles"), err)
+	}
+	keys := make([]string, 0, len(stored))
+	for key := range stored {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	candles := make([]*pb.Candle, 0, len(keys))
+	for _, key := range keys {
+		candle := &pb.Candle{}
+		err = proto.Unmarshal([]byte(stored[key]), candle)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		start, err := ptypes.Timestamp(candle.GetStart())
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		if (!from.IsZero() && start.Before(from)) || (!to.IsZero() && !start.Before(to)) {
+			continue
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// getTrades returns the channel's latest trades, newest first. A limit of 0 returns all of them.
+func (s *MarketDataService) getTrades(ctx context.Context, channelID []byte, limit int) ([]*pb.Trade, error) {
+	stored, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.TradePrefix)+string(channelID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get trades"), err)
+	}
+	keys := make([]string, 0, len(stored))
+	for key := range stored {
+		keys = append(keys, key)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	trades := make([]*pb.Trade, 0, len(keys))
+	for _, key := range keys {
+		if limit > 0 && len(trades) == limit {
+			break
+		}
+		trade := &pb.Trade{}
+		err = proto.Unmarshal([]byte(stored[key]), trade)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// GetCandles returns the channel's candles of the requested interval in seconds, or of the shortest interval if it's 0.
+// The from and to times limit the candles by their start, and are optional.
+func (s *MarketDataService) GetCandles(ctx context.Context, in *pb.CandleRequest) (*pb.CandleList, error) {
+	if len(s.Intervals) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Get candles"), "market data is disabled"))
+	}
+	interval := s.Intervals[0]
+	if in.GetInterval() != 0 {
+		interval = time.Duration(in.GetInterval()) * time.Second
+		found := false
+		for _, configured := range s.Intervals {
+			found = found || configured == interval
+		}
+		if !found {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Get candles"), "candles aren't aggregated over "+interval.String()))
+		}
+	}
+
+	var from, to time.Time
+	if in.GetFrom() != nil {
+		from, _ = ptypes.Timestamp(in.GetFrom())
+	}
+	if in.GetTo() != nil {
+		to, _ = ptypes.Timestamp(in.GetTo())
+	}
+	candles, err := s.getCandles(ctx, in.GetChannelID(), interval, from, to)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get candles"), err))
+	}
+	return &pb.CandleList{Candles: candles}, nil
+}
+
+// GetTicker returns the channel's last price, its change and range, and the traded volume over the last 24 hours.
+// They're computed from the candles of the shortest interval, so the period is only as exact as that interval.
+func (s *MarketDataService) GetTicker(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.Ticker, error) {
+	if len(s.Intervals) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Get ticker"), "market data is disabled"))
+	}
+	now := time.Now()
+	candles, err := s.getCandles(ctx, in.GetId(), s.Intervals[0], now.Add(-tickerPeriod), time.Time{})
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Get ticker"), err))
+	}
+
+	ticker := &pb.Ticker{ChannelID: in.GetId(), Updated: ptypes.TimestampNow()}
+	for i, candle := range candles {
+		if i == 0 {
+			ticker.Open, ticker.High, ticker.Low = candle.GetOpen(), candle.GetHigh(), candle.GetLow()
+		}
+		if candle.GetHigh() > ticker.GetHigh() {
+			ticker.High = candle.GetHigh()
+		}
+		if candle.GetLow() < ticker.GetLow() {
+			ticker.Low = candle.GetLow()
+		}
+		ticker.Last = candle.GetClose()
+		ticker.Volume += candle.GetVolume()
+		ticker.Trades += candle.GetTrades()
+	}
+	if ticker.GetOpen() != 0 {
+		ticker.Change = (ticker.GetLast() - ticker.GetOpen()) / ticker.GetOpen()
+	}
+	return ticker, nil
+}
+
+// recordTrade hands a fill of an order to the market data, if it's registered.
+// Failures are only logged, since the fill has already been stored.
+func (s *OrderService) recordTrade(ctx context.Context, channelID []byte, order *pb.Order, fill *pb.Fill) {
+	if s.marketData == nil || len(s.marketData.Intervals) == 0 {
+		return
+	}
+	err := s.marketData.recordTrade(ctx, channelID, order.GetId(), order.GetPrice(), fill.GetAmount(), time.Now())
+	if !errors.IsEmpty(err) {
+		requestid.Logger(ctx, s.Logger).Warn(errors.E(errors.Op("Record trade"), err))
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMarketDataTestService(intervals ...time.Duration) *MarketDataService {
+	marketDataService := &MarketDataService{Intervals: intervals}
+	marketDataService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	return marketDataService
+}
+
+func TestParseIntervals(t *testing.T) {
+	intervals, err := ParseIntervals("1h, 1m,5m")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Minute, 5 * time.Minute, time.Hour}, intervals)
+
+	intervals, err = ParseIntervals("")
+	assert.NoError(t, err)
+	assert.Empty(t, intervals)
+
+	_, err = ParseIntervals("1m,1m")
+	assert.Error(t, err)
+	_, err = ParseIntervals("500ms")
+	assert.Error(t, err)
+	_, err = ParseIntervals("daily")
+	assert.Error(t, err)
+}
+
+func TestRecordTrade(t *testing.T) {
+	marketDataService := newMarketDataTestService(time.Minute, time.Hour)
+	ctx := context.Background()
+	start := time.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 1.5, 10, start))
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 2, 20, start.Add(10*time.Second)))
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 1, 30, start.Add(30*time.Second)))
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 1.25, 40, start.Add(90*time.Second)))
+
+	minutes, err := marketDataService.GetCandles(ctx, &pb.CandleRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(minutes.GetCandles()))
+	candle := minutes.GetCandles()[0]
+	assert.Equal(t, uint32(60), candle.GetInterval())
+	assert.Equal(t, []float32{1.5, 2, 1, 1}, []float32{candle.GetOpen(), candle.GetHigh(), candle.GetLow(), candle.GetClose()})
+	assert.Equal(t, uint64(60), candle.GetVolume())
+	assert.Equal(t, uint32(3), candle.GetTrades())
+
+	hours, err := marketDataService.GetCandles(ctx, &pb.CandleRequest{ChannelID: []byte(assetPair), Interval: 3600})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(hours.GetCandles()))
+	assert.Equal(t, uint64(100), hours.GetCandles()[0].GetVolume())
+	assert.Equal(t, float32(1.25), hours.GetCandles()[0].GetClose())
+
+	// Candles are limited by their start
+	from, err := ptypes.TimestampProto(start.Add(time.Minute))
+	assert.NoError(t, err)
+	minutes, err = marketDataService.GetCandles(ctx, &pb.CandleRequest{ChannelID: []byte(assetPair), From: from})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(minutes.GetCandles()))
+
+	_, err = marketDataService.GetCandles(ctx, &pb.CandleRequest{ChannelID: []byte(assetPair), Interval: 300})
+	assert.Error(t, err)
+
+	// Trades are kept newest first
+	trades, err := marketDataService.getTrades(ctx, []byte(assetPair), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(trades))
+	assert.Equal(t, float32(1.25), trades[0].GetPrice())
+	assert.Equal(t, uint64(30), trades[1].GetAmount())
+}
+
+func TestGetTicker(t *testing.T) {
+	marketDataService := newMarketDataTestService(time.Minute)
+	ctx := context.Background()
+	now := time.Now()
+
+	// Trades older than a day aren't counted
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 5, 10, now.Add(-25*time.Hour)))
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 2, 10, now.Add(-2*time.Hour)))
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 3, 20, now.Add(-time.Hour)))
+	assert.NoError(t, marketDataService.recordTrade(ctx, []byte(assetPair), []byte("order"), 2.5, 30, now))
+
+	ticker, err := marketDataService.GetTicker(ctx, &pb.ChannelSpecificRequest{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, float32(2.5), ticker.GetLast())
+	assert.Equal(t, float32(2), ticker.GetOpen())
+	assert.Equal(t, float32(3), ticker.GetHigh())
+	assert.Equal(t, float32(2), ticker.GetLow())
+	assert.Equal(t, float32(0.25), ticker.GetChange())
+	assert.Equal(t, uint64(60), ticker.GetVolume())
+	assert.Equal(t, uint32(3), ticker.GetTrades())
+
+	_, err = newMarketDataTestService().GetTicker(ctx, &pb.ChannelSpecificRequest{Id: []byte(assetPair)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getOwnPeerID returns the peer ID of this node
+func getOwnPeerID(storage interfaces.Storage) (peer.ID, crypto.PubKey, error) {
+	_, publicKey, err := identity.GetIdentity(storage)
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Get identity"), err)
+	}
+	peerID, err := peer.IDFromPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Get own peer ID"), err)
+	}
+	return peerID, publicKey, nil
+}
+
+// getMembershipSigningBytes returns the bytes of the membership that its creator signs
+func getMembershipSigningBytes(membership *pb.Membership) ([]byte, error) {
+	membershipCopy := *membership
+	membershipCopy.Signature = nil
+	return proto.Marshal(&membershipCopy)
+}
+
+// verifyCreatorSignature checks that signingBytes are signed by the channel's creator with the given public key
+func verifyCreatorSignature(channel *pb.Channel, creatorPubKey []byte, signingBytes []byte, signature []byte) error {
+	publicKey, err := crypto.UnmarshalPublicKey(creatorPubKey)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal creator public key"), errors.Malformed, err)
+	}
+	creatorID, err := peer.IDFromPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get creator peer ID"), errors.Malformed, err)
+	}
+	if creatorID.String() != channel.GetOptions().GetCreator() {
+		return errors.E(errors.Op("Check creator"), errors.Unauthorized, "not made by the channel's creator")
+	}
+
+	valid, err := identity.Verify(publicKey, signingBytes, signature)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify creator signature"), errors.InvalidSignature, err)
+	}
+	if !valid {
+		return errors.E(errors.Op("Verify creator signature"), errors.InvalidSignature, "not signed by the channel's creator")
+	}
+	return nil
+}
+
+// verifyMembership checks that the membership is signed by the channel's creator and newer than the one already stored
+func verifyMembership(channel *pb.Channel, membership *pb.Membership) error {
+	signingBytes, err := getMembershipSigningBytes(membership)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal membership"), err)
+	}
+	err = verifyCreatorSignature(channel, membership.GetCreatorPubKey(), signingBytes, membership.GetSignature())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify membership"), err)
+	}
+
+	if proto.Equal(channel.GetMembership(), membership) {
+		return errors.E(errors.Op("Check for duplicate membership"), errors.Duplicate, "membership has already been received")
+	}
+	if membership.GetVersion() <= channel.GetMembership().GetVersion() {
+		return errors.E(errors.Op("Compare membership versions"), errors.Replay, "received membership is older than the current one")
+	}
+	return nil
+}
+
+// isMember tells if the peer may take part in the channel.
+// Channels that haven't been joined or aren't members only are open to everyone, and the creator is always a member.
+func (s *OrderService) isMember(ctx context.Context, channelID []byte, peerID peer.ID) bool {
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) || !channel.GetOptions().GetMembersOnly() {
+		return true
+	}
+	if peerID.String() == channel.GetOptions().GetCreator() {
+		return true
+	}
+	for _, member := range channel.GetMembership().GetMembers() {
+		if member == peerID.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// receiveMembership stores a membership received from the network, if it's a newer one by the channel's creator.
+// Memberships can be relayed by anyone, since they're signed.
+func (s *OrderService) receiveMembership(ctx context.Context, channelID []byte, data []byte) error {
+	membership := &pb.Membership{}
+	err := proto.Unmarshal(data, membership)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal membership proto in Receive"), errors.Malformed, err)
+	}
+
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) || !channel.GetOptions().GetMembersOnly() {
+		return nil
+	}
+	err = verifyMembership(channel, membership)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	channel.Membership = membership
+	marshaledChannel, err := proto.Marshal(channel)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal channel with membership"), err)
+	}
+	return s.Storage.Put(ctx, getChannelStorageKey(channelID), marshaledChannel)
+}
+
+// getMembershipMessage returns the channel's membership as a WireMessage, or nil if there isn't any
+func (s *OrderService) getMembershipMessage(ctx context.Context, channelID []byte) ([]byte, error) {
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) || channel.GetMembership() == nil {
+		return nil, nil
+	}
+	data, err := proto.Marshal(channel.GetMembership())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal membership"), err)
+	}
+	return proto.Marshal(&pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_MEMBERSHIP, Data: data})
+}
+
+// SetMembers replaces the allowlist of a members only channel created by this node.
+// The list is signed and broadcast to the channel, where nodes drop orders from anyone not on it.
+func (s *ChannelService) SetMembers(ctx context.Context, in *pb.MembershipRequest) (*pb.Channel, error) {
+	channel, err := s.GetChannel(ctx, &pb.ChannelSpecificRequest{Id: in.GetChannelID()})
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	if !channel.GetOptions().GetMembersOnly() {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Set members"), "channel isn't members only"))
+	}
+
+	ownID, publicKey, err := getOwnPeerID(s.Storage)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Set members"), err))
+	}
+	if ownID.String() != channel.GetOptions().GetCreator() {
+		return nil, status.Errorf(codes.PermissionDenied, "%s", errors.E(errors.Op("Set members"), "only the channel's creator may set its members"))
+	}
+	for _, member := range in.GetMembers() {
+		if _, err := peer.IDB58Decode(member); !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Set members"), "invalid member peer ID "+member))
+		}
+	}
+
+	creatorPubKey, err := crypto.MarshalPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal creator public key"), err))
+	}
+	membership := &pb.Membership{
+		ChannelID:     channel.GetId(),
+		Members:       in.GetMembers(),
+		Version:       channel.GetMembership().GetVersion() + 1,
+		CreatorPubKey: creatorPubKey,
+	}
+	signingBytes, err := getMembershipSigningBytes(membership)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal membership"), err))
+	}
+	membership.Signature, err = identity.Sign(s.Storage, signingBytes)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Sign membership"), err))
+	}
+
+	channel.Membership = membership
+	marshaledChannel, err := proto.Marshal(channel)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal channel"), err))
+	}
+	err = s.Storage.Put(ctx, getChannelStorageKey(channel.GetId()), marshaledChannel)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Saving channel to database in SetMembers"), err))
+	}
+
+	data, err := proto.Marshal(membership)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal membership"), err))
+	}
+	if s.P2p != nil {
+		err = s.P2p.Send(ctx, &pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_MEMBERSHIP, Data: data})
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Broadcast membership"), err))
+		}
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMembers(t *testing.T) {
+	creatorService := newOwnershipTestService()
+	creatorID, _, err := creatorService.getMaker()
+	assert.NoError(t, err)
+	channelService := &ChannelService{Storage: creatorService.Storage}
+	memberID, _ := newStranger(t)
+	request := &pb.MembershipRequest{ChannelID: []byte(assetPair), Members: []string{memberID.String()}}
+
+	// Someone else's channel
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{MembersOnly: true, Creator: memberID.String()})
+	_, err = channelService.SetMembers(context.Background(), request)
+	assert.Error(t, err)
+
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{MembersOnly: true, Creator: creatorID.String()})
+	_, err = channelService.SetMembers(context.Background(), &pb.MembershipRequest{ChannelID: []byte(assetPair), Members: []string{"not-a-peer"}})
+	assert.Error(t, err)
+
+	channel, err := channelService.SetMembers(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), channel.GetMembership().GetVersion())
+	assert.Equal(t, []string{memberID.String()}, channel.GetMembership().GetMembers())
+	assert.True(t, creatorService.isMember(context.Background(), []byte(assetPair), memberID))
+	assert.True(t, creatorService.isMember(context.Background(), []byte(assetPair), creatorID))
+
+	channel, err = channelService.SetMembers(context.Background(), &pb.MembershipRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), channel.GetMembership().GetVersion())
+	assert.False(t, creatorService.isMember(context.Background(), []byte(assetPair), memberID))
+}
+
+func TestMembersOnlyChannel(t *testing.T) {
+	creatorService := newOwnershipTestService()
+	creatorID, _, err := creatorService.getMaker()
+	assert.NoError(t, err)
+	memberService := newOwnershipTestService()
+	memberID, _, err := memberService.getMaker()
+	assert.NoError(t, err)
+	strangerService := newOwnershipTestService()
+	strangerID, _, err := strangerService.getMaker()
+	assert.NoError(t, err)
+
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{MembersOnly: true, Creator: creatorID.String()})
+	channel, err := (&ChannelService{Storage: creatorService.Storage}).SetMembers(context.Background(), &pb.MembershipRequest{ChannelID: []byte(assetPair), Members: []string{memberID.String()}})
+	assert.NoError(t, err)
+	membershipInBytes, err := proto.Marshal(channel.GetMembership())
+	assert.NoError(t, err)
+
+	receiverService := newOwnershipTestService()
+	joinChannelWithOptions(t, receiverService, &pb.ChannelOptions{MembersOnly: true, Creator: creatorID.String()})
+
+	// Before the membership arrives, only the creator is a member
+	memberOrder := createTestOrder(t, memberService, testPrice)
+	err = receiveData(t, receiverService, pb.Operation_CREATE, memberOrder, memberID)
+	assert.True(t, errors.Is(errors.Unauthorized, err))
+
+	// Tampered memberships are rejected
+	tamperedMembership := proto.Clone(channel.GetMembership()).(*pb.Membership)
+	tamperedMembership.Members = append(tamperedMembership.Members, strangerID.String())
+	tamperedInBytes, err := proto.Marshal(tamperedMembership)
+	assert.NoError(t, err)
+	err = receiveData(t, receiverService, pb.Operation_MEMBERSHIP, tamperedInBytes, strangerID)
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+
+	// Anyone can relay the membership
+	err = receiveData(t, receiverService, pb.Operation_MEMBERSHIP, membershipInBytes, strangerID)
+	assert.NoError(t, err)
+	err = receiveData(t, receiverService, pb.Operation_MEMBERSHIP, membershipInBytes, strangerID)
+	assert.True(t, errors.Is(errors.Duplicate, err))
+
+	err = receiveData(t, receiverService, pb.Operation_CREATE, memberOrder, memberID)
+	assert.NoError(t, err)
+	err = receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, strangerService, testPrice), strangerID)
+	assert.True(t, errors.Is(errors.Unauthorized, err))
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getBanStorageKey(channelID []byte, maker string) []byte {
+	return []byte(strings.Join([]string{string(interfaces.BanPrefix), string(channelID), maker}, ""))
+}
+
+// getRemovalSigningBytes returns the bytes of the removal that its operator signs
+func getRemovalSigningBytes(removal *pb.Removal) ([]byte, error) {
+	removalCopy := *removal
+	removalCopy.Signature = nil
+	return proto.Marshal(&removalCopy)
+}
+
+// isOperator tells if the peer is one of the channel's operators
+func isOperator(channel *pb.Channel, peerID peer.ID) bool {
+	for _, operator := range channel.GetOptions().GetOperators() {
+		if operator == peerID.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyRemoval checks that the removal is meant for the channel and signed by one of its operators
+func verifyRemoval(channel *pb.Channel, removal *pb.Removal) error {
+	if string(removal.GetChannelID()) != string(channel.GetId()) {
+		return errors.E(errors.Op("Check removal channel"), errors.Invalid, "removal is for another channel")
+	}
+	publicKey, err := crypto.UnmarshalPublicKey(removal.GetOperatorPubKey())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal operator public key"), errors.Malformed, err)
+	}
+	operatorID, err := peer.IDFromPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get operator peer ID"), errors.Malformed, err)
+	}
+	if !isOperator(channel, operatorID) {
+		return errors.E(errors.Op("Check operator"), errors.Unauthorized, "not made by an operator of the channel")
+	}
+
+	signingBytes, err := getRemovalSigningBytes(removal)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal removal"), err)
+	}
+	valid, err := identity.Verify(publicKey, signingBytes, removal.GetSignature())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Verify removal signature"), errors.InvalidSignature, err)
+	}
+	if !valid {
+		return errors.E(errors.Op("Verify removal signature"), errors.InvalidSignature, "not signed by an operator of the channel")
+	}
+	return nil
+}
+
+// isBanned tells if the channel's operators have banned the maker from it
+func (s *OrderService) isBanned(ctx context.Context, channelID []byte, makerID peer.ID) bool {
+	banned, err := s.Storage.Has(ctx, getBanStorageKey(channelID, makerID.String()))
+	return errors.IsEmpty(err) && banned
+}
+
+// applyRemoval deletes the order of the removal and bans its maker, deleting the maker's open orders on the channel.
+// Removals that don't change anything are duplicates.
+func (s *OrderService) applyRemoval(ctx context.Context, channelID []byte, removal *pb.Removal) error {
+	removed := 0
+	if len(removal.GetOrderID()) > 0 {
+		order, err := s.getOrder(ctx, channelID, removal.GetOrderID())
+		if errors.IsEmpty(err) {
+			err = s.deleteOrder(ctx, channelID, order)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Delete removed order"), err)
+			}
+			removed++
+		}
+	}
+
+	if removal.GetMaker() != "" {
+		banKey := getBanStorageKey(channelID, removal.GetMaker())
+		banned, err := s.Storage.Has(ctx, banKey)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check ban"), err)
+		}
+		if !banned {
+			err = s.Storage.Put(ctx, banKey, []byte(removal.GetReason()))
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Put ban"), err)
+			}
+			removed++
+		}
+
+		orders, err := s.Storage.GetAllWithPrefix(ctx, string(getOrderQueryPrefix(channelID)))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Get orders of banned maker"), err)
+		}
+		for _, data := range orders {
+			order := &pb.Order{}
+			err = proto.Unmarshal([]byte(data), order)
+			if !errors.IsEmpty(err) || peer.ID(order.GetMakerPeerID()).String() != removal.GetMaker() {
+				continue
+			}
+			err = s.deleteOrder(ctx, channelID, order)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Delete order of banned maker"), err)
+			}
+			removed++
+		}
+	}
+
+	if removed == 0 {
+		return errors.E(errors.Op("Apply removal"), errors.Duplicate, "removal has already been applied")
+	}
+	return nil
+}
+
+// receiveRemoval applies a removal received from the network, if it's signed by an operator of the channel.
+// Removals can be relayed by anyone, since they're signed.
+func (s *OrderService) receiveRemoval(ctx context.Context, channelID []byte, data []byte) error {
+	removal := &pb.Removal{}
+	err := proto.Unmarshal(data, removal)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal removal proto in Receive"), errors.Malformed, err)
+	}
+
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	err = verifyRemoval(channel, removal)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return s.applyRemoval(ctx, channelID, removal)
+}
+
+// Remove deletes an order from a channel this node operates, or bans a maker from it along with their open orders.
+// The removal is signed and broadcast to the channel, where every node applies it.
+func (s *OrderService) Remove(ctx context.Context, in *pb.RemovalRequest) (*pb.Removal, error) {
+	if len(in.GetOrderID()) == 0 && in.GetMaker() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Remove"), "removal needs an order or a maker"))
+	}
+	if in.GetMaker() != "" {
+		if _, err := peer.IDB58Decode(in.GetMaker()); !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Remove"), "invalid maker peer ID "+in.GetMaker()))
+		}
+	}
+
+	channel, err := s.getChannel(ctx, in.GetChannelID())
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.NotFound, "%s", errors.E(errors.Op("Remove"), err))
+	}
+	ownID, publicKey, err := getOwnPeerID(s.Storage)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Remove"), err))
+	}
+	if !isOperator(channel, ownID) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s", errors.E(errors.Op("Remove"), "only the channel's operators may remove orders"))
+	}
+
+	operatorPubKey, err := crypto.MarshalPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal operator public key"), err))
+	}
+	removal := &pb.Removal{
+		ChannelID:      channel.GetId(),
+		OrderID:        in.GetOrderID(),
+		Maker:          in.GetMaker(),
+		Reason:         in.GetReason(),
+		Created:        ptypes.TimestampNow(),
+		OperatorPubKey: operatorPubKey,
+	}
+	signingBytes, err := getRemovalSigningBytes(removal)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal removal"), err))
+	}
+	removal.Signature, err = identity.Sign(s.Storage, signingBytes)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Sign removal"), err))
+	}
+
+	err = s.applyRemoval(ctx, channel.GetId(), removal)
+	if !errors.IsEmpty(err) && !errors.Is(errors.Duplicate, err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Apply removal"), err))
+	}
+
+	data, err := proto.Marshal(removal)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Marshal removal"), err))
+	}
+	if s.P2p != nil {
+		err = s.P2p.Send(ctx, &pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_REMOVE, Data: data})
+		if !errors.IsEmpty(err) {
+			return nil, status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Broadcast removal"), err))
+		}
+	}
+
+	return removal, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemove(t *testing.T) {
+	operatorService := newOwnershipTestService()
+	operatorID, _, err := operatorService.getMaker()
+	assert.NoError(t, err)
+	makerService := newOwnershipTestService()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+	joinChannelWithOptions(t, operatorService, &pb.ChannelOptions{Operators: []string{operatorID.String()}})
+
+	_, err = operatorService.Remove(context.Background(), &pb.RemovalRequest{ChannelID: []byte(assetPair)})
+	assert.Error(t, err)
+	_, err = operatorService.Remove(context.Background(), &pb.RemovalRequest{ChannelID: []byte(assetPair), Maker: "invalid"})
+	assert.Error(t, err)
+
+	// Only operators may remove orders
+	strangerService := newOwnershipTestService()
+	joinChannelWithOptions(t, strangerService, &pb.ChannelOptions{Operators: []string{makerID.String()}})
+	_, err = strangerService.Remove(context.Background(), &pb.RemovalRequest{ChannelID: []byte(assetPair), Maker: makerID.String()})
+	assert.Error(t, err)
+
+	orderInBytes := createTestOrder(t, makerService, testPrice)
+	order := &pb.Order{}
+	assert.NoError(t, proto.Unmarshal(orderInBytes, order))
+	assert.NoError(t, receiveData(t, operatorService, pb.Operation_CREATE, orderInBytes, makerID))
+
+	removal, err := operatorService.Remove(context.Background(), &pb.RemovalRequest{ChannelID: []byte(assetPair), OrderID: order.GetId(), Reason: "spam"})
+	assert.NoError(t, err)
+	assert.NoError(t, verifyRemoval(&pb.Channel{Id: []byte(assetPair), Options: &pb.ChannelOptions{Operators: []string{operatorID.String()}}}, removal))
+	_, err = operatorService.GetOrder(context.Background(), &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: order.GetId()})
+	assert.Error(t, err)
+}
+
+func TestReceiveRemoval(t *testing.T) {
+	operatorService := newOwnershipTestService()
+	operatorID, _, err := operatorService.getMaker()
+	assert.NoError(t, err)
+	joinChannelWithOptions(t, operatorService, &pb.ChannelOptions{Operators: []string{operatorID.String()}})
+	makerService := newOwnershipTestService()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	receiverService := newOwnershipTestService()
+	joinChannelWithOptions(t, receiverService, &pb.ChannelOptions{Operators: []string{operatorID.String()}})
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, testPrice), makerID))
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, testPrice), makerID))
+
+	removal, err := operatorService.Remove(context.Background(), &pb.RemovalRequest{ChannelID: []byte(assetPair), Maker: makerID.String(), Reason: "wash trading"})
+	assert.NoError(t, err)
+	removalInBytes, err := proto.Marshal(removal)
+	assert.NoError(t, err)
+
+	// A removal tampered with on the way is rejected
+	tampered := *removal
+	tampered.Maker = operatorID.String()
+	tamperedInBytes, err := proto.Marshal(&tampered)
+	assert.NoError(t, err)
+	relayID, _ := newStranger(t)
+	assert.True(t, errors.Is(errors.InvalidSignature, receiveData(t, receiverService, pb.Operation_REMOVE, tamperedInBytes, relayID)))
+
+	// Removals are signed, so anyone may relay them
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_REMOVE, removalInBytes, relayID))
+	assert.True(t, errors.Is(errors.Duplicate, receiveData(t, receiverService, pb.Operation_REMOVE, removalInBytes, relayID)))
+	orders, err := receiverService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, orders.GetOrders())
+
+	// The banned maker's new orders are dropped
+	err = receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, testPrice), makerID)
+	assert.True(t, errors.Is(errors.Unauthorized, err))
+
+	// Removals by someone who isn't an operator are rejected
+	strangerService := newOwnershipTestService()
+	strangerID, _, err := strangerService.getMaker()
+	assert.NoError(t, err)
+	joinChannelWithOptions(t, strangerService, &pb.ChannelOptions{Operators: []string{strangerID.String()}})
+	strangerRemoval, err := strangerService.Remove(context.Background(), &pb.RemovalRequest{ChannelID: []byte(assetPair), Maker: operatorID.String()})
+	assert.NoError(t, err)
+	strangerInBytes, err := proto.Marshal(strangerRemoval)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(errors.Unauthorized, receiveData(t, receiverService, pb.Operation_REMOVE, strangerInBytes, strangerID)))
+}
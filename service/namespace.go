@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const bearerPrefix string = "Bearer "
+
+// AdminNamespace is the namespace of API keys that has the admin role unless rpc.apiRoles gives it another
+const AdminNamespace string = "admin"
+
+// adminServices are the services that only the admin role may call when API keys are configured
+var adminServices = []string{"/pb.AdminHandler/", "/pb.StorageHandler/"}
+
+// isAdminMethod tells if a full gRPC method name belongs to one of the admin services
+func isAdminMethod(method string) bool {
+	for _, service := range adminServices {
+		if strings.HasPrefix(method, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceKey is the context key of the namespace of the client making a call
+type namespaceKey struct{}
+
+// WithNamespace returns a context for calls made by the client with the given namespace.
+// Calls in a namespace can only delete, lock and unlock orders created in the same namespace.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// getNamespace returns the namespace of the client making a call, if the call was made with an API key
+func getNamespace(ctx context.Context) (string, bool) {
+	namespace, ok := ctx.Value(namespaceKey{}).(string)
+	return namespace, ok
+}
+
+// ParseAPIKeys parses comma separated namespace:key pairs into a map from API keys to namespaces
+func ParseAPIKeys(apiKeys string) (map[string]string, error) {
+	namespaces := make(map[string]string)
+	if apiKeys == "" {
+		return namespaces, nil
+	}
+	for _, pair := range strings.Split(apiKeys, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.E(errors.Op("Parse API keys"), errors.Invalid, "API keys should be namespace:key pairs")
+		}
+		if _, ok := namespaces[parts[1]]; ok {
+			return nil, errors.E(errors.Op("Parse API keys"), errors.Invalid, "API key of "+parts[0]+" is already used")
+		}
+		namespaces[parts[1]] = parts[0]
+	}
+	return namespaces, nil
+}
+
+// authenticate puts the namespace of the API key in the authorization header into the context.
+// Calls are only authenticated if API keys are configured, and then the role of the key's namespace has to allow the method.
+func (server *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if len(server.APIKeys) == 0 {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, authorization := range md.Get("authorization") {
+		if !strings.HasPrefix(authorization, bearerPrefix) {
+			continue
+		}
+		namespace, ok := server.APIKeys[strings.TrimPrefix(authorization, bearerPrefix)]
+		if !ok {
+			continue
+		}
+		if role := server.getRole(namespace); !role.allows(method) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s", errors.E(errors.Op("Authenticate"), errors.Unauthorized, method+" can't be called by the "+string(role)+" role of "+namespace))
+		}
+		return WithNamespace(ctx, namespace), nil
+	}
+	return nil, status.Errorf(codes.Unauthenticated, "%s", errors.E(errors.Op("Authenticate"), errors.Unauthorized, "missing or unknown API key"))
+}
+
+func (server *Server) authenticateUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = tagRequest(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(requestid.Header, requestid.FromContext(ctx)))
+	authenticated, err := server.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		server.Admin.audit(ctx, info.FullMethod, req, err)
+		return nil, err
+	}
+	err = server.checkStandby(info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	err = validateRequest(req)
+	if err != nil {
+		server.Admin.audit(authenticated, info.FullMethod, req, err)
+		return nil, err
+	}
+	resp, err := handler(authenticated, req)
+	if err != nil && server.Logger != nil {
+		requestid.Logger(ctx, server.Logger).Debugf("%s failed: %s", info.FullMethod, err)
+	}
+	server.Admin.audit(authenticated, info.FullMethod, req, err)
+	return resp, err
+}
+
+// namespacedStream replaces the context of a server stream with one that has the client's namespace
+type namespacedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *namespacedStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (server *Server) authenticateStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := tagRequest(stream.Context())
+	stream.SetHeader(metadata.Pairs(requestid.Header, requestid.FromContext(ctx)))
+	ctx, err := server.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return err
+	}
+	err = server.checkStandby(info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &namespacedStream{ServerStream: stream, ctx: ctx})
+}
+
+// tagRequest gives the call the request ID sent by the client, or a new one, so it can be traced through the node's logs
+func tagRequest(ctx context.Context) context.Context {
+	return requestid.WithID(ctx, requestid.FromIncoming(ctx))
+}
+
+func getNamespaceStorageKey(channelID []byte, orderID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.NamespacePrefix), string(channelID), string(orderID)}, ""))
+}
+
+// tagOrder stores the namespace of the client that created an order, if it was created with an API key
+func (s *OrderService) tagOrder(ctx context.Context, channelID []byte, orderID []byte) error {
+	namespace, ok := getNamespace(ctx)
+	if !ok {
+		return nil
+	}
+	return s.Storage.Put(ctx, getNamespaceStorageKey(channelID, orderID), []byte(namespace))
+}
+
+// authorizeNamespace checks that a client calling with an API key created the order in its own namespace.
+// Calls made without an API key may change any order of this node.
+func (s *OrderService) authorizeNamespace(ctx context.Context, channelID []byte, orderID []byte) error {
+	namespace, ok := getNamespace(ctx)
+	if !ok {
+		return nil
+	}
+	owner, err := s.Storage.Get(ctx, getNamespaceStorageKey(channelID, orderID))
+	if !errors.IsEmpty(err) || string(owner) != namespace {
+		return errors.E(errors.Op("Authorize namespace"), errors.Unauthorized, "order wasn't created by the calling client")
+	}
+	return nil
+}
+
+// getNamespaceOrders returns the open orders created in the namespace of the calling client, sorted like the order book
+func (s *OrderService) getNamespaceOrders(ctx context.Context) ([]*pb.Order, error) {
+	namespace, ok := getNamespace(ctx)
+	if !ok {
+		return nil, errors.E(errors.Op("Get namespace"), errors.Invalid, "only calls made with an API key have their own orders")
+	}
+	tags, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.NamespacePrefix))
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	orders := make([]*pb.Order, 0)
+	for key, owner := range tags {
+		if owner != namespace {
+			continue
+		}
+		// The tag has the same channel and order ID as the order itself
+		data, err := s.Storage.Get(ctx, []byte(string(interfaces.OrderPrefix)+key[len(interfaces.NamespacePrefix):]))
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		order := &pb.Order{}
+		err = proto.Unmarshal(data, order)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		orders = append(orders, order)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orderLess(orders[i], orders[j]) })
+	return orders, nil
+}
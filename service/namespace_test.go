@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	apiKeys, err := ParseAPIKeys("")
+	assert.NoError(t, err)
+	assert.Empty(t, apiKeys)
+
+	apiKeys, err = ParseAPIKeys("desk1:key1, desk2:key:2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"key1": "desk1", "key:2": "desk2"}, apiKeys)
+
+	_, err = ParseAPIKeys("desk1")
+	assert.Error(t, err)
+	_, err = ParseAPIKeys("desk1:key1,desk2:key1")
+	assert.Error(t, err)
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := &Server{}
+	ctx, err := server.authenticate(context.Background(), "/pb.OrderHandler/GetAllOrders")
+	assert.NoError(t, err)
+	_, ok := getNamespace(ctx)
+	assert.False(t, ok)
+
+	server.APIKeys = map[string]string{"key1": "desk1"}
+	_, err = server.authenticate(context.Background(), "/pb.OrderHandler/GetAllOrders")
+	assert.Error(t, err)
+	_, err = server.authenticate(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer key2")), "/pb.OrderHandler/GetAllOrders")
+	assert.Error(t, err)
+
+	ctx, err = server.authenticate(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer key1")), "/pb.OrderHandler/GetAllOrders")
+	assert.NoError(t, err)
+	namespace, ok := getNamespace(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "desk1", namespace)
+
+	// Only the admin namespace may inspect the storage
+	_, err = server.authenticate(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer key1")), "/pb.StorageHandler/Dump")
+	assert.Error(t, err)
+	server.APIKeys["key3"] = AdminNamespace
+	_, err = server.authenticate(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer key3")), "/pb.StorageHandler/Dump")
+	assert.NoError(t, err)
+}
+
+func TestNamespacedOrders(t *testing.T) {
+	namespaceService := newOwnershipTestService()
+	desk1 := WithNamespace(context.Background(), "desk1")
+	desk2 := WithNamespace(context.Background(), "desk2")
+
+	created, err := namespaceService.Create(desk1, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	_, err = namespaceService.Create(desk2, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice + 1})
+	assert.NoError(t, err)
+
+	mine, err := namespaceService.GetAllOrders(desk1, &pb.OrderListRequest{Mine: true})
+	assert.NoError(t, err)
+	assert.Len(t, mine.GetOrders(), 1)
+	assert.Equal(t, created.GetCreatedOrder().GetId(), mine.GetOrders()[0].GetId())
+	all, err := namespaceService.GetAllOrders(desk1, &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, all.GetOrders(), 2)
+	_, err = namespaceService.GetAllOrders(context.Background(), &pb.OrderListRequest{Mine: true})
+	assert.Error(t, err)
+
+	// Only the namespace that created the order can change it
+	request := &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: created.GetCreatedOrder().GetId()}
+	_, err = namespaceService.Lock(desk2, request)
+	assert.True(t, errors.Is(errors.Unauthorized, err))
+	_, err = namespaceService.Delete(desk2, request)
+	assert.True(t, errors.Is(errors.Unauthorized, err))
+	_, err = namespaceService.Lock(desk1, request)
+	assert.NoError(t, err)
+
+	// Calls without an API key may change any order
+	_, err = namespaceService.Delete(context.Background(), request)
+	assert.NoError(t, err)
+	mine, err = namespaceService.GetAllOrders(desk1, &pb.OrderListRequest{Mine: true})
+	assert.NoError(t, err)
+	assert.Empty(t, mine.GetOrders())
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const negotiationIDLength int = 16
+
+// allowedNegotiationSteps lists the steps each step may be followed by
+var allowedNegotiationSteps = map[pb.NegotiationStep][]pb.NegotiationStep{
+	pb.NegotiationStep_LOCK_ORDER: {pb.NegotiationStep_PROPOSE, pb.NegotiationStep_REJECT},
+	pb.NegotiationStep_PROPOSE:    {pb.NegotiationStep_PROPOSE, pb.NegotiationStep_ACCEPT, pb.NegotiationStep_REJECT},
+	pb.NegotiationStep_ACCEPT:     {pb.NegotiationStep_FINALIZE},
+}
+
+func getNegotiationStorageKey(negotiationID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.NegotiationPrefix), string(negotiationID)}, ""))
+}
+
+func isAllowedNegotiationStep(from pb.NegotiationStep, to pb.NegotiationStep) bool {
+	for _, step := range allowedNegotiationSteps[from] {
+		if step == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *OrderService) putNegotiation(ctx context.Context, negotiation *pb.Negotiation) error {
+	negotiation.Updated = ptypes.TimestampNow()
+	data, err := proto.Marshal(negotiation)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal negotiation"), err)
+	}
+	return s.Storage.Put(ctx, getNegotiationStorageKey(negotiation.GetId()), data)
+}
+
+func (s *OrderService) getNegotiation(ctx context.Context, negotiationID []byte) (*pb.Negotiation, error) {
+	data, err := s.Storage.Get(ctx, getNegotiationStorageKey(negotiationID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get negotiation"), err)
+	}
+	negotiation := &pb.Negotiation{}
+	err = proto.Unmarshal(data, negotiation)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal negotiation"), errors.Malformed, err)
+	}
+	return negotiation, nil
+}
+
+// startNegotiation locks the order and persists a snapshot of it, so the negotiation can be finalized even if the order changes
+func (s *OrderService) startNegotiation(ctx context.Context, in *pb.NegotiationMessage) (*pb.Negotiation, error) {
+	orderRequest := &pb.OrderSpecificRequest{OrderID: in.GetOrderID(), ChannelID: in.GetChannelID()}
+	_, err := s.Lock(ctx, orderRequest)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Lock order for negotiation"), err)
+	}
+	order, err := s.GetOrder(ctx, orderRequest)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get negotiated order"), err)
+	}
+
+	id := make([]byte, negotiationIDLength)
+	_, err = rand.Read(id)
+	if err != nil {
+		return nil, errors.E(errors.Op("Generate negotiation ID"), err)
+	}
+
+	negotiation := &pb.Negotiation{
+		Id:        id,
+		ChannelID: in.GetChannelID(),
+		Order:     order,
+		Step:      pb.NegotiationStep_LOCK_ORDER,
+	}
+	err = s.putNegotiation(ctx, negotiation)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put negotiation"), err)
+	}
+	return negotiation, nil
+}
+
+// releaseNegotiatedOrder unlocks a rejected order, unless its lock has already timed out
+func (s *OrderService) releaseNegotiatedOrder(ctx context.Context, negotiation *pb.Negotiation) error {
+	orderRequest := &pb.OrderSpecificRequest{OrderID: negotiation.GetOrder().GetId(), ChannelID: negotiation.GetChannelID()}
+	order, err := s.GetOrder(ctx, orderRequest)
+	if !errors.IsEmpty(err) || order.GetState() != pb.State_LOCKED {
+		return nil
+	}
+	_, err = s.Unlock(ctx, orderRequest)
+	return err
+}
+
+// finalizeNegotiation takes the order off the book and puts any unfilled amount back as a new order.
+// Each part is skipped if it's already done, so a finalize interrupted by a crash can be retried.
+func (s *OrderService) finalizeNegotiation(ctx context.Context, negotiation *pb.Negotiation) error {
+	order := negotiation.GetOrder()
+	stored, err := s.Storage.Has(ctx, getOrderStorageKey(negotiation.GetChannelID(), order.GetId()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Check filled order"), err)
+	}
+	if stored {
+		_, err = s.Delete(ctx, &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: negotiation.GetChannelID()})
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete filled order"), err)
+		}
+	}
+
+	if negotiation.GetAmount() < order.GetAmount() && len(negotiation.GetRemainderOrderID()) == 0 {
+		remainder, err := s.Create(ctx, &pb.CreateRequest{
+			ChannelID:    negotiation.GetChannelID(),
+			Asset:        order.GetAsset(),
+			CounterAsset: order.GetCounterAsset(),
+			Amount:       order.GetAmount() - negotiation.GetAmount(),
+			Price:        order.GetPrice(),
+		})
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Create remainder order"), err)
+		}
+		negotiation.RemainderOrderID = remainder.GetCreatedOrder().GetId()
+	}
+	return nil
+}
+
+// negotiate applies one step to a negotiation and persists the result
+func (s *OrderService) negotiate(ctx context.Context, in *pb.NegotiationMessage) (*pb.Negotiation, error) {
+	if in.GetStep() == pb.NegotiationStep_LOCK_ORDER {
+		return s.startNegotiation(ctx, in)
+	}
+
+	negotiation, err := s.getNegotiation(ctx, in.GetNegotiationID())
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	if in.GetStep() == pb.NegotiationStep_RESUME {
+		return negotiation, nil
+	}
+	if !isAllowedNegotiationStep(negotiation.GetStep(), in.GetStep()) {
+		return nil, errors.E(errors.Op("Check negotiation step"), errors.Invalid, in.GetStep().String()+" can't follow "+negotiation.GetStep().String())
+	}
+
+	switch in.GetStep() {
+	case pb.NegotiationStep_PROPOSE:
+		if in.GetAmount() == 0 || in.GetAmount() > negotiation.GetOrder().GetAmount() {
+			return nil, errors.E(errors.Op("Check proposed amount"), errors.Invalid, "proposed amount must be between 1 and the order's amount")
+		}
+		if in.GetPrice() <= 0 {
+			return nil, errors.E(errors.Op("Check proposed price"), errors.Invalid, "proposed price must be positive")
+		}
+		negotiation.Amount = in.GetAmount()
+		negotiation.Price = in.GetPrice()
+	case pb.NegotiationStep_REJECT:
+		err = s.releaseNegotiatedOrder(ctx, negotiation)
+	case pb.NegotiationStep_FINALIZE:
+		err = s.finalizeNegotiation(ctx, negotiation)
+	}
+	if !errors.IsEmpty(err) {
+		// Keep whatever was done so far, so the step can be retried
+		s.putNegotiation(ctx, negotiation)
+		return nil, err
+	}
+
+	negotiation.Step = in.GetStep()
+	err = s.putNegotiation(ctx, negotiation)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put negotiation"), err)
+	}
+	return negotiation, nil
+}
+
+// Negotiate takes an order through locking, proposing fill terms, accepting or rejecting them and finalizing the fill.
+// Every step is persisted, and a negotiation interrupted by a crash continues by sending RESUME with its ID.
+func (s *OrderService) Negotiate(stream pb.OrderHandler_NegotiateServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		negotiation, err := s.negotiate(stream.Context(), in)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Negotiate"), err)
+		}
+
+		err = stream.Send(&pb.NegotiationMessage{
+			NegotiationID: negotiation.GetId(),
+			Step:          negotiation.GetStep(),
+			ChannelID:     negotiation.GetChannelID(),
+			OrderID:       negotiation.GetOrder().GetId(),
+			Amount:        negotiation.GetAmount(),
+			Price:         negotiation.GetPrice(),
+			Negotiation:   negotiation,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
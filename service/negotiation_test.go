@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiation(t *testing.T) {
+	negotiationService := newOwnershipTestService()
+	ctx := context.Background()
+	resp, err := negotiationService.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	request := &pb.OrderSpecificRequest{OrderID: resp.GetCreatedOrder().GetId(), ChannelID: []byte(assetPair)}
+
+	negotiation, err := negotiationService.negotiate(ctx, &pb.NegotiationMessage{Step: pb.NegotiationStep_LOCK_ORDER, OrderID: request.GetOrderID(), ChannelID: request.GetChannelID()})
+	assert.NoError(t, err)
+	order, err := negotiationService.GetOrder(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, order.GetState())
+
+	// Terms have to be proposed and accepted before finalizing
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_FINALIZE})
+	assert.True(t, errors.Is(errors.Invalid, err))
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_PROPOSE, Amount: testAmount + 1, Price: testPrice})
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_PROPOSE, Amount: testAmount - 100, Price: testPrice})
+	assert.NoError(t, err)
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_ACCEPT})
+	assert.NoError(t, err)
+
+	// A new stream picks up where the last one left off
+	resumed, err := negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_RESUME})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.NegotiationStep_ACCEPT, resumed.GetStep())
+	assert.Equal(t, uint64(testAmount-100), resumed.GetAmount())
+
+	finalized, err := negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_FINALIZE})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.NegotiationStep_FINALIZE, finalized.GetStep())
+	_, err = negotiationService.GetOrder(ctx, request)
+	assert.Error(t, err)
+
+	// The unfilled amount is put back on the book
+	remainder, err := negotiationService.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: finalized.GetRemainderOrderID(), ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), remainder.GetAmount())
+	assert.Equal(t, pb.State_OPEN, remainder.GetState())
+
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_PROPOSE, Amount: 1, Price: testPrice})
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestRejectNegotiation(t *testing.T) {
+	negotiationService := newOwnershipTestService()
+	ctx := context.Background()
+	resp, err := negotiationService.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	request := &pb.OrderSpecificRequest{OrderID: resp.GetCreatedOrder().GetId(), ChannelID: []byte(assetPair)}
+
+	negotiation, err := negotiationService.negotiate(ctx, &pb.NegotiationMessage{Step: pb.NegotiationStep_LOCK_ORDER, OrderID: request.GetOrderID(), ChannelID: request.GetChannelID()})
+	assert.NoError(t, err)
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_PROPOSE, Amount: testAmount, Price: testPrice / 2})
+	assert.NoError(t, err)
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_REJECT})
+	assert.NoError(t, err)
+
+	order, err := negotiationService.GetOrder(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_OPEN, order.GetState())
+
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: negotiation.GetId(), Step: pb.NegotiationStep_ACCEPT})
+	assert.True(t, errors.Is(errors.Invalid, err))
+	_, err = negotiationService.negotiate(ctx, &pb.NegotiationMessage{NegotiationID: []byte("unknown"), Step: pb.NegotiationStep_RESUME})
+	assert.Error(t, err)
+}
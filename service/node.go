@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
@@ -9,7 +10,11 @@ import (
 
 // NodeService is a gRPC service for p2p operations.
 type NodeService struct {
-	P2p interfaces.P2p
+	P2p        interfaces.P2p
+	Orders     *OrderService
+	OrderCache *OrderCache
+	// Admin reports the latest storage compaction
+	Admin *AdminService
 }
 
 // RegisterP2p registers a p2p interface with NodeService
@@ -33,3 +38,37 @@ func (s *NodeService) BlacklistPeer(ctx context.Context, in *pb.Peer) (*pb.Empty
 	s.P2p.BlacklistPeer(in)
 	return &pb.Empty{}, nil
 }
+
+// GetNodeInfo returns this node's ID, its bound and announced addresses, the reputation scores of its peers,
+// the hits and misses of the order cache, how far off the local clock is from peers' clocks, the node's counters
+// and the report of the latest storage compaction
+func (s *NodeService) GetNodeInfo(ctx context.Context, in *pb.Empty) (*pb.NodeInfo, error) {
+	skew, samples := s.P2p.GetClockSkew()
+	info := &pb.NodeInfo{
+		Id:                 s.P2p.GetHostIDString(),
+		Peers:              s.P2p.GetPeerScores(),
+		ListenAddresses:    s.P2p.GetListenAddresses(),
+		AnnouncedAddresses: s.P2p.GetAnnouncedAddresses(),
+		ClockSkew:          int64(skew / time.Millisecond),
+		ClockSamples:       uint32(samples),
+	}
+	if s.Orders != nil {
+		info.SkewedOrders = s.Orders.SkewedOrders()
+		info.ReadOnly = s.Orders.IsReadOnly()
+		info.FreeDiskSpace = s.Orders.FreeDiskSpace()
+		info.Counters = s.Orders.Counters()
+	}
+	if s.OrderCache != nil {
+		info.OrderCacheHits, info.OrderCacheMisses = s.OrderCache.Stats()
+	}
+	if s.Admin != nil {
+		info.LastCompaction = s.Admin.LastCompaction()
+	}
+	return info, nil
+}
+
+// GetPeers returns the connected peers with their addresses, agent and protocol versions, the channels they share
+// with this node, the direction of the connection, their latency and bandwidth usage
+func (s *NodeService) GetPeers(ctx context.Context, in *pb.Empty) (*pb.PeerDetailsList, error) {
+	return &pb.PeerDetailsList{Peers: s.P2p.GetPeerDetails()}, nil
+}
@@ -3,10 +3,14 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/interfaces/mocks"
 	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNodeService(t *testing.T) {
@@ -33,4 +37,40 @@ func TestNodeService(t *testing.T) {
 	} else {
 		nodeClient.BlacklistPeer(context.Background(), &pb.Peer{Id: "Testi"})
 	}
+
+	info, err := nodeClient.GetNodeInfo(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, p2pInstance.GetHostIDString(), info.GetId())
+
+	peerList, err := nodeClient.GetPeers(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	for _, peer := range peerList.GetPeers() {
+		assert.NotEmpty(t, peer.GetId())
+	}
+}
+
+func TestGetNodeInfo(t *testing.T) {
+	p2p := new(mocks.P2p)
+	p2p.On("GetClockSkew").Return(250*time.Millisecond, 3)
+	p2p.On("GetHostIDString").Return("node")
+	p2p.On("GetPeerScores").Return([]*pb.PeerScore{{Id: "peer", Score: 5}})
+	p2p.On("GetListenAddresses").Return([]string{"/ip4/127.0.0.1/tcp/4001"})
+	p2p.On("GetAnnouncedAddresses").Return([]string(nil))
+
+	storage := &compactingStorage{Storage: new(mocks.Storage), size: 2 * bytesPerMegabyte}
+	adminService := &AdminService{Logger: new(util.PlaceholderLogger)}
+	adminService.RegisterStorage(storage)
+	_, err := adminService.Compact(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+
+	nodeService := &NodeService{Admin: adminService}
+	nodeService.RegisterP2p(p2p)
+	info, err := nodeService.GetNodeInfo(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "node", info.GetId())
+	assert.Equal(t, int64(250), info.GetClockSkew())
+	assert.Equal(t, uint32(3), info.GetClockSamples())
+	assert.Equal(t, "peer", info.GetPeers()[0].GetId())
+	assert.Equal(t, bytesPerMegabyte, info.GetLastCompaction().GetReclaimed())
+	p2p.AssertExpectations(t)
 }
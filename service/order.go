@@ -1,27 +1,76 @@
 package service
 
 import (
+	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	ptypes "github.com/golang/protobuf/ptypes"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/assets"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/identity"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
+	"github.com/sprawl/sprawl/settlement"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // OrderService implements the OrderService Server service.proto
 type OrderService struct {
-	Logger    interfaces.Logger
-	Storage   interfaces.Storage
-	P2p       interfaces.P2p
-	websocket interfaces.WebsocketService
+	Logger     interfaces.Logger
+	Storage    interfaces.Storage
+	P2p        interfaces.P2p
+	websocket  interfaces.WebsocketService
+	router     *Router
+	book       *OrderBook
+	feed       *OrderFeed
+	cache      *OrderCache
+	webhooks   *Webhooks
+	marketData *MarketDataService
+	activity   *channelActivity
+	settlement settlement.Engine
+	workers    *Workers
+	outbox     *Outbox
+	// LockTimeout is how long locks made by this node last before UnlockExpired opens the order again. 0 never times out.
+	LockTimeout time.Duration
+	// HistoryRetention is how long deleted orders are kept in the order history. 0 keeps them forever.
+	HistoryRetention time.Duration
+	// MaxOrderAge is how old open orders may get before PruneChannels moves them into the history. 0 keeps them forever.
+	MaxOrderAge time.Duration
+	// MaxOrdersPerChannel is how many open orders PruneChannels keeps on each channel. 0 doesn't limit them.
+	MaxOrdersPerChannel uint
+	// AllowCustomAssets allows creating orders with asset symbols that are neither built in nor registered
+	AllowCustomAssets bool
+	// MaxClockSkew is how far in the future received orders may have been created before they're flagged. 0 doesn't check.
+	MaxClockSkew time.Duration
+	// MaxDeadLetters is how many received messages that failed processing are kept for inspection. 0 doesn't keep them.
+	MaxDeadLetters uint
+	// AntiEntropyInterval is how often the order digests of joined channels are published. A differing order book
+	// is requested from each channel at most this often. 0 doesn't limit the requests.
+	AntiEntropyInterval time.Duration
+	// OrderIDMismatch is what's done with received orders whose ID doesn't match their content: OrderIDAccept,
+	// OrderIDReject or OrderIDQuarantine. Empty accepts them.
+	OrderIDMismatch string
+	// OrderIDCollision is what's done when a received order claims the ID of a stored order that was created differently:
+	// CollisionReject or CollisionLastWriteWins. Empty replaces the stored order.
+	OrderIDCollision string
+	// MakerRateLimit is how many orders per second a maker may create on a channel without a limit of its own. 0 doesn't limit them.
+	MakerRateLimit uint
+	// MaxMakerOrders is how many open orders a maker may have on a channel without a limit of its own. 0 doesn't limit them.
+	MaxMakerOrders     uint
+	throttle           makerThrottle
+	digestSyncs        digestSyncs
+	predecessors       predecessorKeys
+	counters           counters
+	deadLetterSequence uint64
+	freeDiskSpace      uint64
+	readOnly           uint32
 }
 
 func getOrderStorageKey(channelID []byte, orderID []byte) []byte {
@@ -37,9 +86,34 @@ func (s *OrderService) RegisterWebsocket(websocket interfaces.WebsocketService)
 	s.websocket = websocket
 }
 
+// RegisterRouter registers a router that mirrors orders between equivalent channels
+func (s *OrderService) RegisterRouter(router *Router) {
+	s.router = router
+}
+
+// RegisterWebhooks registers webhooks that are notified of order events
+func (s *OrderService) RegisterWebhooks(webhooks *Webhooks) {
+	s.webhooks = webhooks
+}
+
+// RegisterMarketData registers the market data service that aggregates the fills of orders into candles
+func (s *OrderService) RegisterMarketData(marketData *MarketDataService) {
+	s.marketData = marketData
+}
+
+// notify sends a successful order operation to webhooks, if there are any
+func (s *OrderService) notify(message *pb.WireMessage) {
+	if s.webhooks != nil {
+		s.webhooks.Notify(message)
+	}
+}
+
 // RegisterStorage registers a storage service to store the Orders in
 func (s *OrderService) RegisterStorage(storage interfaces.Storage) {
 	s.Storage = storage
+	s.book = NewOrderBook(storage)
+	s.feed = NewOrderFeed(storage)
+	s.predecessors.reset()
 }
 
 // RegisterP2p registers a p2p service
@@ -47,13 +121,51 @@ func (s *OrderService) RegisterP2p(p2p interfaces.P2p) {
 	s.P2p = p2p
 }
 
+// mirrorOrder applies an order operation to all joined channels equivalent to channelID.
+// Only the order's creator can broadcast the mirrored operation, since other peers verify it against the sender.
+func (s *OrderService) mirrorOrder(ctx context.Context, channelID []byte, op pb.Operation, order *pb.Order, orderInBytes []byte, broadcast bool) {
+	if s.router == nil || !s.router.Routes(channelID) {
+		return
+	}
+
+	logger := requestid.Logger(ctx, s.Logger)
+	channels, err := s.router.GetEquivalentChannels(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		logger.Warn(errors.E(errors.Op("Get equivalent channels"), err))
+		return
+	}
+
+	for _, mirrorID := range channels {
+		if op == pb.Operation_CREATE {
+			if err := s.validateOrder(ctx, mirrorID, order); !errors.IsEmpty(err) {
+				logger.Debugf("Not mirroring order to %s: %s", mirrorID, err)
+				continue
+			}
+		}
+		if op == pb.Operation_DELETE {
+			err = s.deleteOrder(ctx, mirrorID, order)
+		} else if op == pb.Operation_FILL {
+			err = s.storeFill(ctx, mirrorID, order, orderInBytes)
+		} else {
+			err = s.putOrder(ctx, mirrorID, order, orderInBytes)
+		}
+		if !errors.IsEmpty(err) {
+			logger.Warn(errors.E(errors.Op("Mirror order to "+string(mirrorID)), err))
+			continue
+		}
+
+		if broadcast && s.P2p != nil {
+			err = s.P2p.Send(ctx, &pb.WireMessage{ChannelID: mirrorID, Operation: op, Data: orderInBytes})
+			if !errors.IsEmpty(err) {
+				logger.Warn(errors.E(errors.Op("Broadcast mirrored order to "+string(mirrorID)), err))
+			}
+		}
+	}
+}
+
 // GetSignature generates signature from order and returns it
 func (s *OrderService) GetSignature(order *pb.Order) ([]byte, error) {
-	orderCopy := *order
-	orderCopy.State = pb.State_OPEN
-	orderCopy.Signature = nil
-	orderCopy.Nonce = 0
-	orderInBytes, err := proto.Marshal(&orderCopy)
+	orderInBytes, err := identity.GetOrderSigningBytes(order)
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Marshal order in GetSignature"), err)
 	}
@@ -63,42 +175,38 @@ func (s *OrderService) GetSignature(order *pb.Order) ([]byte, error) {
 
 // VerifyOrder verifies order
 func (s *OrderService) VerifyOrder(publicKey crypto.PubKey, order *pb.Order) (bool, error) {
-	orderCopy := *order
-	sig := orderCopy.Signature
-	orderCopy.Signature = nil
-	orderCopy.State = pb.State_OPEN
-	orderCopy.Nonce = 0
-	orderInBytes, err := proto.Marshal(&orderCopy)
-	if !errors.IsEmpty(err) {
-		return false, errors.E(errors.Op("Marshal order in VerifyOrder"), err)
-	}
-	return identity.Verify(publicKey, orderInBytes, sig)
+	return identity.VerifyOrderSignature(publicKey, order)
 }
 
 // Create creates an Order, storing it locally and broadcasts the Order to all other nodes on the channel
 func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.CreateResponse, error) {
+	if s.IsReadOnly() {
+		return nil, errors.E(errors.Op("Create order"), "the node is read-only until more disk space is free")
+	}
+	// Clients are told to back off rather than queued, so a burst doesn't stall the node
+	if !s.workers.tryAcquire() {
+		return nil, status.Errorf(codes.ResourceExhausted, "%s", errors.E(errors.Op("Create order"), errors.Throttled, "the node is busy processing orders, retry later"))
+	}
+	defer s.workers.release()
 
-	_, publicKey, err := identity.GetIdentity(s.Storage)
+	err := assets.NewRegistry(s.Storage).Check(ctx, s.AllowCustomAssets, in.GetAsset(), in.GetCounterAsset())
 	if !errors.IsEmpty(err) {
-		errors.E(errors.Op("Get public key in create order"), err)
+		return nil, errors.E(errors.Op("Check assets in create order"), err)
 	}
 
-	// Get current timestamp as protobuf type
-	now := ptypes.TimestampNow()
-
-	secret, err := publicKey.Bytes()
+	makerID, makerPubKey, err := s.getMaker()
 	if !errors.IsEmpty(err) {
-		errors.E(errors.Op("Turn public key into bytes"), err)
+		return nil, errors.E(errors.Op("Get maker in create order"), err)
 	}
 
-	// Create a new HMAC by defining the hash type and the key (as byte array)
-	h := hmac.New(sha256.New, secret)
+	// Get current timestamp as protobuf type
+	now := ptypes.TimestampNow()
 
-	// Write Data to it
-	h.Write(append([]byte(in.String()), []byte(now.String())...))
+	// The ID is derived from the request, so receivers can check that the order matches it
+	id := getOrderID(makerPubKey, in, now)
 
-	// Get result and encode as hexadecimal string
-	id := h.Sum(nil)
+	// Orders carry the channel's fees, so clients can show the price with fees
+	makerFee, takerFee := s.getFees(ctx, in.GetChannelID())
 
 	// Construct the order
 	order := &pb.Order{
@@ -110,6 +218,15 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 		Price:        in.Price,
 		State:        pb.State_OPEN, //Mutable
 		Nonce:        0,             //Mutable
+		MakerPeerID:  []byte(makerID),
+		MakerPubKey:  makerPubKey,
+		MakerFee:     makerFee,
+		TakerFee:     takerFee,
+	}
+
+	err = s.validateOrder(ctx, in.GetChannelID(), order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Validate order in create order"), err)
 	}
 
 	sig, err := s.GetSignature(order)
@@ -124,104 +241,271 @@ func (s *OrderService) Create(ctx context.Context, in *pb.CreateRequest) (*pb.Cr
 	// Get order as bytes
 	orderInBytes, err := proto.Marshal(order)
 	if !errors.IsEmpty(err) {
-		s.Logger.Warn(errors.E(errors.Op("Marshal order"), err))
-	}
-
-	// Save order to LevelDB locally
-	err = s.Storage.Put(getOrderStorageKey(in.GetChannelID(), id), orderInBytes)
-	if !errors.IsEmpty(err) {
-		err = errors.E(errors.Op("Put order"), err)
+		requestid.Logger(ctx, s.Logger).Warn(errors.E(errors.Op("Marshal order"), err))
 	}
 
 	// Construct the message to send to other peers
 	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_CREATE, Data: orderInBytes}
 
-	if s.P2p != nil {
-		// Send the order creation by wire
-		s.P2p.Send(wireMessage)
+	if s.outbox != nil {
+		// Store the order together with the message broadcasting it, which the outbox sends even if the node crashes first
+		err = s.putWithOutbox(ctx, in.GetChannelID(), order, orderInBytes, wireMessage)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Put order with outbox"), err)
+		}
+		s.outbox.notify()
+		err = s.addOrder(ctx, in.GetChannelID(), pb.BookChange_ORDER_ADDED, order)
+		if !errors.IsEmpty(err) {
+			err = errors.E(errors.Op("Add order"), err)
+		} else {
+			s.count(ordersCreatedCounter)
+		}
 	} else {
-		s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
+		// Send the order creation by wire before saving it, so a cancelled request doesn't leave an order only this node knows about
+		if s.P2p != nil {
+			err = s.P2p.Send(ctx, wireMessage)
+			if !errors.IsEmpty(err) {
+				return nil, errors.E(errors.Op("Send order"), err)
+			}
+		} else {
+			s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
+		}
+
+		// Save order to LevelDB locally
+		err = s.putOrder(ctx, in.GetChannelID(), order, orderInBytes)
+		if !errors.IsEmpty(err) {
+			err = errors.E(errors.Op("Put order"), err)
+		} else if tagErr := s.tagOrder(ctx, in.GetChannelID(), order.GetId()); !errors.IsEmpty(tagErr) {
+			err = errors.E(errors.Op("Tag order with namespace"), tagErr)
+		} else {
+			s.count(ordersCreatedCounter)
+		}
 	}
 
+	s.notify(wireMessage)
+	s.mirrorOrder(ctx, in.GetChannelID(), pb.Operation_CREATE, order, orderInBytes, true)
+
 	return &pb.CreateResponse{
 		CreatedOrder: order,
 	}, err
 }
 
-// Receive receives a buffer from p2p and tries to unmarshal it into a struct
-func (s *OrderService) Receive(buf []byte, from peer.ID) error {
-	wireMessage := &pb.WireMessage{}
-	err := proto.Unmarshal(buf, wireMessage)
+// Receive receives a message from p2p and tries to unmarshal it into a struct.
+// Messages that don't change anything return a Duplicate error and aren't pushed to websockets again,
+// and neither are messages from peers that aren't allowed to send them or orders over their maker's limits.
+// Other messages that fail are kept as dead letters.
+// The message isn't copied out of msg.Data, which is pushed to websockets as it was received.
+func (s *OrderService) Receive(ctx context.Context, msg interfaces.IncomingMessage) error {
+	// Every received message gets its own request ID, which its log lines and dead letter carry
+	if requestid.FromContext(ctx) == "" {
+		ctx = requestid.WithID(ctx, requestid.New())
+	}
+	// Received messages wait for a worker, which holds up reading from the channel until the node catches up
+	if !s.workers.acquire(ctx) {
+		return errors.E(errors.Op("Wait for worker in Receive"), ctx.Err())
+	}
+	defer s.workers.release()
+
+	buf, from := msg.Data, msg.From
+	wireMessage, err := decodeWireMessage(buf)
 	if !errors.IsEmpty(err) {
-		return errors.E(errors.Op("Unmarshal wiremessage proto in Receive"), err)
+		err = errors.E(errors.Op("Unmarshal wiremessage proto in Receive"), errors.Malformed, err)
+		s.deadLetter(ctx, msg, err)
+		return err
+	}
+
+	// A message published on one channel's topic can't change another channel
+	if len(msg.ChannelID) > 0 && !bytes.Equal(msg.ChannelID, wireMessage.GetChannelID()) {
+		err = errors.E(errors.Op("Check channel in Receive"), errors.Malformed, "message for another channel received on the topic of "+string(msg.ChannelID))
+		s.count(messagesRejectedCounter)
+		s.deadLetter(ctx, msg, err)
+		return err
+	}
+
+	err = s.process(ctx, wireMessage, from)
+	// Orders cancelled all at once are pushed and notified one by one by receiveDeleteAll
+	if errors.IsEmpty(err) {
+		s.count(messagesProcessedCounter)
+		if wireMessage.GetOperation() != pb.Operation_DELETE_ALL {
+			s.notify(wireMessage)
+			if s.websocket != nil {
+				s.websocket.PushToWebsockets(ctx, wireMessage, buf)
+			}
+		}
 	}
-	if s.websocket != nil {
-		s.websocket.PushToWebsockets(wireMessage)
+
+	if !errors.IsEmpty(err) && !errors.Is(errors.Duplicate, err) && !errors.Is(errors.Throttled, err) {
+		s.count(messagesRejectedCounter)
+		requestid.Logger(ctx, s.Logger).Debugf("Rejected %s message from %s over %s: %s", wireMessage.GetOperation(), from, msg.Transport, err)
+		s.deadLetter(ctx, msg, err)
 	}
+	return err
+}
+
+// ReceiveSnapshot stores the open orders of a channel downloaded from a trusted peer with fast-sync.
+// The peer is trusted, so the orders are stored without verifying their makers one by one.
+func (s *OrderService) ReceiveSnapshot(channelID []byte, orders [][]byte, from peer.ID) error {
+	ctx := context.Background()
+	stored := 0
+	for _, data := range orders {
+		if s.isStoredBytes(ctx, channelID, data) {
+			continue
+		}
+		order := &pb.Order{}
+		if err := proto.Unmarshal(data, order); !errors.IsEmpty(err) || len(order.GetId()) == 0 {
+			s.Logger.Warnf("Skipping a malformed snapshot order from %s", from)
+			continue
+		}
+		if s.isBanned(ctx, channelID, peer.ID(order.GetMakerPeerID())) {
+			continue
+		}
+		err := s.putOrder(ctx, channelID, order, data)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put snapshot order"), err)
+		}
+		stored++
+	}
+	s.Logger.Debugf("Stored %d of %d snapshot orders on channel %s", stored, len(orders), string(channelID))
+	return nil
+}
+
+// isStoredBytes tells if the marshaled order is already stored on the channel byte for byte.
+// It catches most duplicates without unmarshaling them.
+func (s *OrderService) isStoredBytes(ctx context.Context, channelID []byte, data []byte) bool {
+	orderID, err := peekOrderID(data)
+	if !errors.IsEmpty(err) || len(orderID) == 0 {
+		return false
+	}
+	storedData, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, orderID))
+	return errors.IsEmpty(err) && bytes.Equal(storedData, data)
+}
+
+// isStored tells if the exact same order is already stored on the channel
+func (s *OrderService) isStored(ctx context.Context, channelID []byte, order *pb.Order) bool {
+	data, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, order.GetId()))
+	if !errors.IsEmpty(err) {
+		return false
+	}
+	storedOrder := &pb.Order{}
+	err = proto.Unmarshal(data, storedOrder)
+	if !errors.IsEmpty(err) {
+		return false
+	}
+	return proto.Equal(storedOrder, order)
+}
+
+// process applies the operation of a received WireMessage to the local order book
+func (s *OrderService) process(ctx context.Context, wireMessage *pb.WireMessage, from peer.ID) error {
+	var err error
 
 	// Read operation and data from the WireMessage
 	op := wireMessage.GetOperation()
 	data := wireMessage.GetData()
 	channelID := wireMessage.GetChannelID()
 
-	s.Logger.Debugf("%s: %s.%s", from.String(), channelID, op)
+	requestid.Logger(ctx, s.Logger).Debugf("%s: %s.%s", from.String(), channelID, op)
 
 	if s.Storage != nil {
+		// Members only channels drop everything but signed memberships, configs and removals from non-members
+		if op != pb.Operation_MEMBERSHIP && op != pb.Operation_CHANNEL_CONFIG && op != pb.Operation_REMOVE && !s.isMember(ctx, channelID, from) {
+			return errors.E(errors.Op("Check channel membership"), errors.Unauthorized, "peer isn't a member of the channel")
+		}
+		s.touchChannel(channelID)
+
 		switch op {
 
 		case pb.Operation_CREATE:
+			if s.isStoredBytes(ctx, channelID, data) {
+				return errors.E(errors.Op("Check for duplicate order"), errors.Duplicate, "order has already been created")
+			}
+
 			// Validate order
 			order := &pb.Order{}
 			err = proto.Unmarshal(data, order)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Unmarshal order proto in Receive"), err)
+				return errors.E(errors.Op("Unmarshal order proto in Receive"), errors.Malformed, err)
+			}
+			if s.isStored(ctx, channelID, order) {
+				return errors.E(errors.Op("Check for duplicate order"), errors.Duplicate, "order has already been created")
 			}
 
-			publickey, err := from.ExtractPublicKey()
+			makerID, err := s.verifyMaker(order)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Extract public key in Receive"), err)
+				return errors.E(errors.Op("Verify order maker in Receive"), err)
 			}
-			isCreator, err := s.VerifyOrder(publickey, order)
+			if !s.isSameIdentity(ctx, makerID, from) {
+				return errors.E(errors.Op("Verify order maker in Receive"), errors.Unauthorized, "received create request from someone that isn't the order's maker")
+			}
+			err = s.checkOrderID(ctx, channelID, order, data, makerID)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Verify order creator in Receive"), err)
+				return errors.E(errors.Op("Check order ID in Receive"), err)
 			}
-			if isCreator {
-				// Save order to LevelDB locally
-				err = s.Storage.Put(getOrderStorageKey(channelID, order.GetId()), data)
-				if !errors.IsEmpty(err) {
-					err = errors.E(errors.Op("Put order"), err)
-				}
-			} else {
-				s.Logger.Debug("Received create request from someone that doesn't own the order")
+			err = s.resolveCollision(ctx, channelID, order)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Check order ID in Receive"), err)
+			}
+			err = s.validateOrder(ctx, channelID, order)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Validate order in Receive"), err)
+			}
+			s.checkClock(order, makerID)
+			err = s.throttleMaker(ctx, channelID, order)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Throttle order maker in Receive"), err)
 			}
 
+			// Save order to LevelDB locally
+			err = s.putOrder(ctx, channelID, order, data)
+			if !errors.IsEmpty(err) {
+				err = errors.E(errors.Op("Put order"), err)
+			}
+			s.mirrorOrder(ctx, channelID, op, order, data, false)
+
 		case pb.Operation_DELETE:
 			// Unmarshal order to get its key, validate
 			order := &pb.Order{}
 			err = proto.Unmarshal(data, order)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Unmarshal order proto in Receive"), err)
+				return errors.E(errors.Op("Unmarshal order proto in Receive"), errors.Malformed, err)
 			}
-			publickey, err := from.ExtractPublicKey()
+			exists, err := s.Storage.Has(ctx, getOrderStorageKey(channelID, order.GetId()))
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Extract public key in Receive"), err)
+				return errors.E(errors.Op("Check order before delete"), err)
+			}
+			if !exists {
+				return errors.E(errors.Op("Check for duplicate delete"), errors.Duplicate, "order isn't in the order book")
 			}
 
-			isCreator, err := s.VerifyOrder(publickey, order)
+			// The stored order is authorized and deleted, since the maker fields of the received copy are the sender's to choose
+			storedData, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, order.GetId()))
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Verify order creator in Receive"), err)
+				return errors.E(errors.Op("Get order before delete"), err)
 			}
-			if isCreator {
-				err = s.Storage.Delete(getOrderStorageKey(channelID, order.GetId()))
-				if !errors.IsEmpty(err) {
-					return errors.E(errors.Op("Delete order"), err)
-				}
-			} else {
-				s.Logger.Debug("Received delete request from someone that doesn't own the order")
+			stored := &pb.Order{}
+			err = proto.Unmarshal(storedData, stored)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Unmarshal stored order in Receive"), err)
+			}
+			err = s.authorize(ctx, channelID, stored, from)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Authorize delete in Receive"), err)
 			}
 
+			err = s.deleteOrder(ctx, channelID, stored)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Delete order"), err)
+			}
+			s.mirrorOrder(ctx, channelID, op, stored, storedData, false)
+
 		case pb.Operation_SYNC_REQUEST:
-			orders, err := s.Storage.GetAllWithPrefix(string(getOrderQueryPrefix(channelID)))
+			// Requests without data are for the open orders, like those of nodes that can't ask for more
+			syncRequest := &pb.SyncRequest{}
+			err = proto.Unmarshal(data, syncRequest)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Unmarshal sync request"), errors.Malformed, err)
+			}
+
+			orders, err := s.Storage.GetAllWithPrefix(ctx, string(getOrderQueryPrefix(channelID)))
 			if !errors.IsEmpty(err) {
 				return errors.E(errors.Op("Fetch orders for sync"), err)
 			}
@@ -245,15 +529,50 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 				return errors.E(errors.Op("Marshal wireMessage in sync request"), err)
 			}
 
+			// The membership is sent first, so new members know who else's orders to accept
+			membershipMessage, err := s.getMembershipMessage(ctx, channelID)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Get membership for sync"), err)
+			}
+
+			// So is the config, so they know the rules orders are checked against
+			configMessage, err := s.getChannelConfigMessage(ctx, channelID)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Get channel config for sync"), err)
+			}
+
 			stream, err := s.P2p.OpenStream(from)
 			if !errors.IsEmpty(err) {
 				return errors.E(errors.Op("Open a sync request stream"), err)
 			}
 
+			if membershipMessage != nil && stream.HasCapability(interfaces.CapabilityMembership) {
+				err = stream.WriteToStream(membershipMessage)
+				if !errors.IsEmpty(err) {
+					return errors.E(errors.Op("Write membership to stream"), err)
+				}
+			}
+			if configMessage != nil && stream.HasCapability(interfaces.CapabilityChannelConfig) {
+				err = stream.WriteToStream(configMessage)
+				if !errors.IsEmpty(err) {
+					return errors.E(errors.Op("Write channel config to stream"), err)
+				}
+			}
+
 			err = stream.WriteToStream(marshaledData)
 			if !errors.IsEmpty(err) {
 				return errors.E(errors.Op("Write to stream"), err)
 			}
+			if syncRequest.GetHistory() == pb.History_FULL {
+				historyMessage, err := s.getHistorySyncMessage(ctx, channelID)
+				if !errors.IsEmpty(err) {
+					return errors.E(errors.Op("Get order history for sync"), err)
+				}
+				err = stream.WriteToStream(historyMessage)
+				if !errors.IsEmpty(err) {
+					return errors.E(errors.Op("Write order history to stream"), err)
+				}
+			}
 			err = s.P2p.CloseStream(from)
 			if !errors.IsEmpty(err) {
 				return errors.E(errors.Op("Close the stream"), err)
@@ -263,56 +582,128 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 			orderList := &pb.OrderList{}
 			err = proto.Unmarshal(data, orderList)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Unmarshal order proto in Receive"), err)
+				return errors.E(errors.Op("Unmarshal order proto in Receive"), errors.Malformed, err)
 			}
-			s.Logger.Info(orderList)
+			s.Logger.Debugf("Received %d synced orders on channel %s", len(orderList.GetOrders()), string(channelID))
+			duplicates := 0
+			newOrders := make([]*pb.Order, 0, len(orderList.GetOrders()))
 			for _, order := range orderList.GetOrders() {
+				if s.isStored(ctx, channelID, order) {
+					duplicates++
+					continue
+				}
+				newOrders = append(newOrders, order)
+			}
+			// A synced channel can have thousands of orders, so their signatures are verified as one batch
+			makerIDs, verifyErrs := s.verifyMakers(newOrders)
+			for i, order := range newOrders {
+				makerID := makerIDs[i]
+				if !errors.IsEmpty(verifyErrs[i]) {
+					s.Logger.Warn(errors.E(errors.Op("Verify synced order maker"), verifyErrs[i]))
+					continue
+				}
+				if !s.isMember(ctx, channelID, makerID) {
+					s.Logger.Debugf("Skipping synced order by %s, who isn't a member of the channel", makerID)
+					continue
+				}
+				if err := s.validateOrder(ctx, channelID, order); !errors.IsEmpty(err) {
+					s.Logger.Warn(errors.E(errors.Op("Validate synced order"), err))
+					continue
+				}
+				s.checkClock(order, makerID)
 				orderBytes, err := proto.Marshal(order)
 				if !errors.IsEmpty(err) {
 					err = errors.E(errors.Op("Marshal order from received orderList"), err)
 				}
-				err = s.Storage.Put(getOrderStorageKey(channelID, order.GetId()), orderBytes)
+				if err := s.checkOrderID(ctx, channelID, order, orderBytes, makerID); !errors.IsEmpty(err) {
+					s.Logger.Warn(errors.E(errors.Op("Check synced order ID"), err))
+					continue
+				}
+				if err := s.resolveCollision(ctx, channelID, order); !errors.IsEmpty(err) {
+					if !errors.Is(errors.Duplicate, err) {
+						s.Logger.Warn(errors.E(errors.Op("Check synced order ID"), err))
+					}
+					continue
+				}
+				err = s.putOrder(ctx, channelID, order, orderBytes)
 				if !errors.IsEmpty(err) {
 					err = errors.E(errors.Op("Put order"), err)
 				}
 			}
+			if duplicates > 0 && duplicates == len(orderList.GetOrders()) {
+				return errors.E(errors.Op("Check for duplicate orders"), errors.Duplicate, "all synced orders are already stored")
+			}
+		case pb.Operation_SYNC_HISTORY:
+			err = s.receiveHistory(ctx, channelID, data)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Receive synced order history"), err)
+			}
 		case pb.Operation_LOCK, pb.Operation_UNLOCK:
 			// Unmarshal order to get its key, validate
 			order := &pb.Order{}
 			err = proto.Unmarshal(data, order)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Unmarshal order proto in Receive"), err)
+				return errors.E(errors.Op("Unmarshal order proto in Receive"), errors.Malformed, err)
 			}
 
-			previousOrderData, err := s.Storage.Get(getOrderStorageKey(channelID, order.GetId()))
+			previousOrderData, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, order.GetId()))
 			if !errors.IsEmpty(err) {
 				return errors.E(errors.Op("Get previous order"), err)
 			}
+			if bytes.Equal(previousOrderData, data) {
+				return errors.E(errors.Op("Check for duplicate lock/unlock"), errors.Duplicate, "order state has already been updated")
+			}
 			previousOrder := &pb.Order{}
 			proto.Unmarshal(previousOrderData, previousOrder)
+			if proto.Equal(previousOrder, order) {
+				return errors.E(errors.Op("Check for duplicate lock/unlock"), errors.Duplicate, "order state has already been updated")
+			}
 			if previousOrder.Nonce >= order.Nonce {
-				return errors.E(errors.Op("Compare nonces"), "received order state is behind current status")
+				return errors.E(errors.Op("Compare nonces"), errors.Replay, "received order state is behind current status")
 			}
 
-			publickey, err := from.ExtractPublicKey()
+			// Authorize against the stored order and take only its lock state from the received copy,
+			// so the sender can't swap in maker fields or terms of its own
+			err = s.authorize(ctx, channelID, previousOrder, from)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Extract public key in Receive"), err)
+				return errors.E(errors.Op("Authorize lock/unlock in Receive"), err)
 			}
-
-			isCreator, err := s.VerifyOrder(publickey, order)
+			updated := proto.Clone(previousOrder).(*pb.Order)
+			updated.State = order.GetState()
+			updated.Nonce = order.GetNonce()
+			updated.LockedUntil = order.GetLockedUntil()
+			updatedData, err := proto.Marshal(updated)
 			if !errors.IsEmpty(err) {
-				return errors.E(errors.Op("Verify order creator in Receive"), err)
+				return errors.E(errors.Op("Marshal lock/unlock order"), err)
 			}
 
-			if isCreator {
-				// Save order to LevelDB locally
-				err = s.Storage.Put(getOrderStorageKey(channelID, order.GetId()), data)
-				if !errors.IsEmpty(err) {
-					return errors.E(errors.Op("Store lock/unlock order"), err)
-				}
-			} else {
-				s.Logger.Debug("Received delete request from someone that doesn't own the order")
+			// Save order to LevelDB locally
+			err = s.putOrder(ctx, channelID, updated, updatedData)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Store lock/unlock order"), err)
 			}
+			s.mirrorOrder(ctx, channelID, op, updated, updatedData, false)
+
+		case pb.Operation_FILL:
+			return s.receiveFill(ctx, channelID, data, from)
+
+		case pb.Operation_MEMBERSHIP:
+			return s.receiveMembership(ctx, channelID, data)
+
+		case pb.Operation_CHANNEL_CONFIG:
+			return s.receiveChannelConfig(ctx, channelID, data)
+
+		case pb.Operation_IDENTITY_TRANSITION:
+			return s.receiveTransition(ctx, data, from)
+
+		case pb.Operation_REMOVE:
+			return s.receiveRemoval(ctx, channelID, data)
+
+		case pb.Operation_ORDER_DIGEST:
+			return s.receiveDigest(ctx, channelID, data, from)
+
+		case pb.Operation_DELETE_ALL:
+			return s.receiveDeleteAll(ctx, channelID, data, from)
 
 		}
 	} else {
@@ -322,40 +713,38 @@ func (s *OrderService) Receive(buf []byte, from peer.ID) error {
 	return err
 }
 
-// GetOrder fetches a single order from the database
+// GetOrder fetches a single order from the cache, or from the database if it isn't cached
 func (s *OrderService) GetOrder(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Order, error) {
-	data, err := s.Storage.Get(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
+	order, err := s.getOrder(ctx, in.GetChannelID(), in.GetOrderID())
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Get order"), err)
 	}
-	order := &pb.Order{}
-	proto.Unmarshal(data, order)
 	return order, nil
 }
 
-// GetAllOrders fetches all orders from the database
-func (s *OrderService) GetAllOrders(ctx context.Context, in *pb.Empty) (*pb.OrderList, error) {
-	data, err := s.Storage.GetAllWithPrefix(string(interfaces.OrderPrefix))
-	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Get all orders"), err)
+// GetAllOrders fetches all orders from the order book, sorted by price and then creation time.
+// With mine set, only the orders created with the caller's API key are returned.
+func (s *OrderService) GetAllOrders(ctx context.Context, in *pb.OrderListRequest) (*pb.OrderList, error) {
+	if in.GetMine() {
+		orders, err := s.getNamespaceOrders(ctx)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Get own orders"), err)
+		}
+		return &pb.OrderList{Orders: orders}, nil
 	}
 
-	orders := make([]*pb.Order, 0)
-	i := 0
-	for _, value := range data {
-		order := &pb.Order{}
-		proto.Unmarshal([]byte(value), order)
-		orders = append(orders, order)
-		i++
+	orders, err := s.book.getAll(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get all orders"), err)
 	}
 
 	OrderList := &pb.OrderList{Orders: orders}
 	return OrderList, nil
 }
 
-// Delete removes the Order with the specified ID locally, and broadcasts the same request to all other nodes on the channel
+// Delete moves the Order with the specified ID into the order history locally, and broadcasts the same request to all other nodes on the channel
 func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error) {
-	orderInBytes, err := s.Storage.Get(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
+	orderInBytes, err := s.Storage.Get(ctx, getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Delete order"), err)
 	}
@@ -366,41 +755,40 @@ func (s *OrderService) Delete(ctx context.Context, in *pb.OrderSpecificRequest)
 		return nil, errors.E(errors.Op("Unmarshal order proto in Delete"), err)
 	}
 
-	_, publickey, err := identity.GetIdentity(s.Storage)
+	err = s.authorizeSelf(ctx, in.GetChannelID(), order)
 	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Get public key in Delete"), err)
-	}
-
-	isCreator, err := s.VerifyOrder(publickey, order)
-	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Verify the order"), err)
+		return nil, errors.E(errors.Op("Authorize delete"), err)
 	}
 
 	// Construct the message to send to other peers
 	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_DELETE, Data: orderInBytes}
 
 	if s.P2p != nil {
-		if isCreator {
-			// Send the order creation by wire
-			s.P2p.Send(wireMessage)
+		// Send the order modification by wire
+		err = s.P2p.Send(ctx, wireMessage)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Send order modification"), err)
 		}
 	} else {
 		s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
 	}
 
-	// Try to delete the Order from LevelDB with specified ID
-	err = s.Storage.Delete(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
+	// Move the Order from the open orders into the channel's order history
+	err = s.deleteOrder(ctx, in.GetChannelID(), order)
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Delete order"), err)
 	}
 
+	s.notify(wireMessage)
+	s.mirrorOrder(ctx, in.GetChannelID(), pb.Operation_DELETE, order, orderInBytes, true)
+
 	return &pb.Empty{}, nil
 }
 
 // Lock locks the given Order if the Order is created by this node, broadcasts the lock to other nodes on the channel.
 func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error) {
 
-	orderInBytes, err := s.Storage.Get(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
+	orderInBytes, err := s.Storage.Get(ctx, getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Get order in Lock"), err)
 	}
@@ -415,18 +803,19 @@ func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*
 		return nil, errors.E(errors.Op("Check state"), "Trying to lock something that is already locked")
 	}
 
-	_, publickey, err := identity.GetIdentity(s.Storage)
+	err = s.authorizeSelf(ctx, in.GetChannelID(), order)
 	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Get public key in Lock"), err)
-	}
-
-	isCreator, err := s.VerifyOrder(publickey, order)
-	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Verify the order in Lock"), err)
+		return nil, errors.E(errors.Op("Authorize lock"), err)
 	}
 
 	order.State = pb.State_LOCKED
 	order.Nonce++
+	if s.LockTimeout > 0 {
+		order.LockedUntil, err = ptypes.TimestampProto(time.Now().Add(s.LockTimeout))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Set lock timeout"), err)
+		}
+	}
 
 	// Get order as bytes
 	orderInBytes, err = proto.Marshal(order)
@@ -438,27 +827,31 @@ func (s *OrderService) Lock(ctx context.Context, in *pb.OrderSpecificRequest) (*
 	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_LOCK, Data: orderInBytes}
 
 	if s.P2p != nil {
-		if isCreator {
-			// Send the order creation by wire
-			s.P2p.Send(wireMessage)
+		// Send the order modification by wire
+		err = s.P2p.Send(ctx, wireMessage)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Send order modification"), err)
 		}
 	} else {
 		s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
 	}
 
 	// Save order to LevelDB locally
-	err = s.Storage.Put(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()), orderInBytes)
+	err = s.putOrder(ctx, in.GetChannelID(), order, orderInBytes)
 	if !errors.IsEmpty(err) {
 		err = errors.E(errors.Op("Put order"), err)
 	}
 
+	s.notify(wireMessage)
+	s.mirrorOrder(ctx, in.GetChannelID(), pb.Operation_LOCK, order, orderInBytes, true)
+
 	return &pb.Empty{}, nil
 }
 
 // Unlock unlocks the given Order if it's created by this node, broadcasts the unlocking operation to other nodes on the channel.
 func (s *OrderService) Unlock(ctx context.Context, in *pb.OrderSpecificRequest) (*pb.Empty, error) {
 
-	orderInBytes, err := s.Storage.Get(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
+	orderInBytes, err := s.Storage.Get(ctx, getOrderStorageKey(in.GetChannelID(), in.GetOrderID()))
 	if !errors.IsEmpty(err) {
 		return nil, errors.E(errors.Op("Get order in Unlock"), err)
 	}
@@ -470,22 +863,21 @@ func (s *OrderService) Unlock(ctx context.Context, in *pb.OrderSpecificRequest)
 	}
 
 	//Might cause problem
-	if order.State == pb.State_OPEN {
+	if order.State == pb.State_OPEN || order.State == pb.State_PARTIALLY_FILLED {
 		return nil, errors.E(errors.Op("Check state"), "Trying to unlock something that is already open")
 	}
 
-	_, publickey, err := identity.GetIdentity(s.Storage)
+	err = s.authorizeSelf(ctx, in.GetChannelID(), order)
 	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Get public key in Unlock"), err)
-	}
-
-	isCreator, err := s.VerifyOrder(publickey, order)
-	if !errors.IsEmpty(err) {
-		return nil, errors.E(errors.Op("Verify the order in Unlock"), err)
+		return nil, errors.E(errors.Op("Authorize unlock"), err)
 	}
 
 	order.State = pb.State_OPEN
+	if order.GetFilledAmount() > 0 {
+		order.State = pb.State_PARTIALLY_FILLED
+	}
 	order.Nonce++
+	order.LockedUntil = nil
 
 	// Get order as bytes
 	orderInBytes, err = proto.Marshal(order)
@@ -497,19 +889,23 @@ func (s *OrderService) Unlock(ctx context.Context, in *pb.OrderSpecificRequest)
 	wireMessage := &pb.WireMessage{ChannelID: in.GetChannelID(), Operation: pb.Operation_UNLOCK, Data: orderInBytes}
 
 	if s.P2p != nil {
-		if isCreator {
-			// Send the order creation by wire
-			s.P2p.Send(wireMessage)
+		// Send the order modification by wire
+		err = s.P2p.Send(ctx, wireMessage)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Send order modification"), err)
 		}
 	} else {
 		s.Logger.Warn("P2p service not registered with OrderService, not publishing or receiving orders from the network!")
 	}
 
 	// Save order to LevelDB locally
-	err = s.Storage.Put(getOrderStorageKey(in.GetChannelID(), in.GetOrderID()), orderInBytes)
+	err = s.putOrder(ctx, in.GetChannelID(), order, orderInBytes)
 	if !errors.IsEmpty(err) {
 		err = errors.E(errors.Op("Put order"), err)
 	}
 
+	s.notify(wireMessage)
+	s.mirrorOrder(ctx, in.GetChannelID(), pb.Operation_UNLOCK, order, orderInBytes, true)
+
 	return &pb.Empty{}, nil
 }
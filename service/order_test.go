@@ -5,12 +5,14 @@ import (
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"fmt"
 	"net"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	ptypes "github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/sprawl/sprawl/config"
 	"github.com/sprawl/sprawl/database/leveldb"
 	"github.com/sprawl/sprawl/errors"
@@ -92,7 +94,7 @@ func joinTestChannel(t *testing.T) {
 }
 
 func removeAllOrders() {
-	storage.DeleteAllWithPrefix(string(interfaces.OrderPrefix))
+	storage.DeleteAllWithPrefix(context.Background(), string(interfaces.OrderPrefix))
 }
 
 func BufDialer(string, time.Duration) (net.Conn, error) {
@@ -193,19 +195,13 @@ func TestOrderReceive(t *testing.T) {
 	order, err := orderService.Create(ctx, &testOrder)
 	marshaledOrder, err := proto.Marshal(order)
 
-	err = orderService.Receive(marshaledOrder, p2pInstance.GetHostID())
+	err = receive(orderService, marshaledOrder, p2pInstance.GetHostID())
 
-	wireMessage := &pb.WireMessage{}
-
-	assert.NoError(t, err)
-	err = proto.Unmarshal(marshaledOrder, wireMessage)
-	assert.NoError(t, err)
-
-	_, p, err := ws.ReadMessage()
-	assert.NoError(t, err)
-	testWireMessage2 := &pb.WireMessage{}
-	proto.Unmarshal(p, testWireMessage2)
-	assert.Equal(t, wireMessage, testWireMessage2)
+	// The message doesn't carry an order with a maker identity, so it's rejected and not pushed to websockets
+	assert.True(t, errors.Is(errors.Malformed, err))
+	ws.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err = ws.ReadMessage()
+	assert.Error(t, err)
 
 	storedOrder, err := orderClient.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: order.GetCreatedOrder().GetId(), ChannelID: channel.GetId()})
 	assert.NoError(t, err)
@@ -286,12 +282,52 @@ func TestOrderGetAll(t *testing.T) {
 		assert.True(t, errors.IsEmpty(err))
 	}
 
-	resp, err := orderClient.GetAllOrders(ctx, &pb.Empty{})
+	resp, err := orderClient.GetAllOrders(ctx, &pb.OrderListRequest{})
 	assert.True(t, errors.IsEmpty(err))
 	orders := resp.GetOrders()
 	assert.Equal(t, len(orders), testIterations)
 }
 
+// receive passes data to the receiver as if the peer had sent it over gossip
+func receive(receiver interfaces.Receiver, data []byte, from peer.ID) error {
+	return receiver.Receive(context.Background(), interfaces.IncomingMessage{From: from, Transport: interfaces.TransportGossip, ReceivedAt: time.Now(), Data: data})
+}
+
+func receiveOperation(t *testing.T, receiver *OrderService, op pb.Operation, order *pb.Order, from peer.ID) error {
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	wireMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: op, Data: orderInBytes})
+	assert.NoError(t, err)
+	return receive(receiver, wireMessage, from)
+}
+
+func TestOrderReceiveDuplicates(t *testing.T) {
+	makerService := newOwnershipTestService()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+	receiverService := newOwnershipTestService()
+
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+
+	assert.NoError(t, receiveOperation(t, receiverService, pb.Operation_CREATE, order, makerID))
+	assert.True(t, errors.Is(errors.Duplicate, receiveOperation(t, receiverService, pb.Operation_CREATE, order, makerID)))
+
+	request := &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: []byte(assetPair)}
+	_, err = makerService.Lock(context.Background(), request)
+	assert.NoError(t, err)
+	lockedOrder, err := makerService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+
+	assert.NoError(t, receiveOperation(t, receiverService, pb.Operation_LOCK, lockedOrder, makerID))
+	assert.True(t, errors.Is(errors.Duplicate, receiveOperation(t, receiverService, pb.Operation_LOCK, lockedOrder, makerID)))
+	assert.True(t, errors.Is(errors.Replay, receiveOperation(t, receiverService, pb.Operation_UNLOCK, order, makerID)))
+
+	assert.NoError(t, receiveOperation(t, receiverService, pb.Operation_DELETE, lockedOrder, makerID))
+	assert.True(t, errors.Is(errors.Duplicate, receiveOperation(t, receiverService, pb.Operation_DELETE, lockedOrder, makerID)))
+}
+
 func BenchmarkOrderReceive(b *testing.B) {
 	createNewServerInstance()
 	orderService.RegisterStorage(storage)
@@ -317,7 +353,38 @@ func BenchmarkOrderReceive(b *testing.B) {
 	for i := 1; i < b.N; i++ {
 		order, _ := orderService.Create(ctx, &testOrder)
 		marshaledOrder, _ := proto.Marshal(order)
-		orderService.Receive(marshaledOrder, p2pInstance.GetHostID())
+		receive(orderService, marshaledOrder, p2pInstance.GetHostID())
 		orderClient.GetOrder(ctx, &pb.OrderSpecificRequest{OrderID: order.GetCreatedOrder().GetId()})
 	}
 }
+
+// BenchmarkSyncReceive receives the open orders of a channel with 100k orders from a peer, as a node does when it joins
+func BenchmarkSyncReceive(b *testing.B) {
+	makerService := newOwnershipTestService()
+	makerID, _, _ := makerService.getMaker()
+	created, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	if !errors.IsEmpty(err) {
+		b.Fatal(err)
+	}
+
+	orderList := &pb.OrderList{Orders: make([]*pb.Order, 100000)}
+	for i := range orderList.Orders {
+		order := proto.Clone(created.GetCreatedOrder()).(*pb.Order)
+		order.Id = []byte(fmt.Sprint(i))
+		order.Signature, err = makerService.GetSignature(order)
+		if !errors.IsEmpty(err) {
+			b.Fatal(err)
+		}
+		orderList.Orders[i] = order
+	}
+	data, _ := proto.Marshal(orderList)
+	message, _ := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_SYNC_RECEIVE, Data: data})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		receiverService := newOwnershipTestService()
+		b.StartTimer()
+		receive(receiverService, message, makerID)
+	}
+}
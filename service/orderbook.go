@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// indexedOrder is an order in an orderIndex, identified by its storage key
+type indexedOrder struct {
+	key   string
+	order *pb.Order
+}
+
+// orderIndex keeps orders sorted by price, then creation time
+type orderIndex struct {
+	loaded bool
+	orders []indexedOrder
+}
+
+func orderLess(a *pb.Order, b *pb.Order) bool {
+	if a.GetPrice() != b.GetPrice() {
+		return a.GetPrice() < b.GetPrice()
+	}
+	if a.GetCreated().GetSeconds() != b.GetCreated().GetSeconds() {
+		return a.GetCreated().GetSeconds() < b.GetCreated().GetSeconds()
+	}
+	if a.GetCreated().GetNanos() != b.GetCreated().GetNanos() {
+		return a.GetCreated().GetNanos() < b.GetCreated().GetNanos()
+	}
+	return bytes.Compare(a.GetId(), b.GetId()) < 0
+}
+
+func (index *orderIndex) remove(key string) {
+	for i, entry := range index.orders {
+		if entry.key == key {
+			index.orders = append(index.orders[:i], index.orders[i+1:]...)
+			return
+		}
+	}
+}
+
+func (index *orderIndex) put(key string, order *pb.Order) {
+	index.remove(key)
+	i := sort.Search(len(index.orders), func(i int) bool {
+		return !orderLess(index.orders[i].order, order)
+	})
+	index.orders = append(index.orders, indexedOrder{})
+	copy(index.orders[i+1:], index.orders[i:])
+	index.orders[i] = indexedOrder{key: key, order: order}
+}
+
+func (index *orderIndex) list() []*pb.Order {
+	orders := make([]*pb.Order, 0, len(index.orders))
+	for _, entry := range index.orders {
+		orders = append(orders, entry.order)
+	}
+	return orders
+}
+
+// OrderBook is an in-memory view of the open orders in storage, indexed per channel.
+// Indexes are loaded from storage when they're first read and kept up to date on every order write after that.
+type OrderBook struct {
+	storage  interfaces.Storage
+	all      *orderIndex
+	channels map[string]*orderIndex
+	lock     sync.Mutex
+}
+
+// NewOrderBook returns an empty OrderBook on top of storage
+func NewOrderBook(storage interfaces.Storage) *OrderBook {
+	return &OrderBook{
+		storage:  storage,
+		all:      &orderIndex{},
+		channels: make(map[string]*orderIndex),
+	}
+}
+
+// Reset drops all indexes, so they're loaded from storage again
+func (book *OrderBook) Reset() {
+	book.lock.Lock()
+	defer book.lock.Unlock()
+	book.all = &orderIndex{}
+	book.channels = make(map[string]*orderIndex)
+}
+
+func (book *OrderBook) put(channelID []byte, order *pb.Order) {
+	book.lock.Lock()
+	defer book.lock.Unlock()
+	key := string(getOrderStorageKey(channelID, order.GetId()))
+	order = proto.Clone(order).(*pb.Order)
+
+	// Indexes that aren't loaded yet will read the order from storage
+	if book.all.loaded {
+		book.all.put(key, order)
+	}
+	if index, ok := book.channels[string(channelID)]; ok {
+		index.put(key, order)
+	}
+}
+
+func (book *OrderBook) remove(channelID []byte, orderID []byte) {
+	book.lock.Lock()
+	defer book.lock.Unlock()
+	key := string(getOrderStorageKey(channelID, orderID))
+	book.all.remove(key)
+	if index, ok := book.channels[string(channelID)]; ok {
+		index.remove(key)
+	}
+}
+
+// getAll returns the orders of all channels
+func (book *OrderBook) getAll(ctx context.Context) ([]*pb.Order, error) {
+	book.lock.Lock()
+	defer book.lock.Unlock()
+	if !book.all.loaded {
+		data, err := book.storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Load order book"), err)
+		}
+		for key, value := range data {
+			order := &pb.Order{}
+			proto.Unmarshal([]byte(value), order)
+			book.all.put(key, order)
+		}
+		book.all.loaded = true
+	}
+	return book.all.list(), nil
+}
+
+// get returns the orders of a single channel
+func (book *OrderBook) get(ctx context.Context, channelID []byte) ([]*pb.Order, error) {
+	book.lock.Lock()
+	defer book.lock.Unlock()
+	index, ok := book.channels[string(channelID)]
+	if !ok {
+		data, err := book.storage.GetAllWithPrefix(ctx, string(getOrderQueryPrefix(channelID)))
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Load channel order book"), err)
+		}
+		index = &orderIndex{loaded: true}
+		for key, value := range data {
+			order := &pb.Order{}
+			proto.Unmarshal([]byte(value), order)
+			// Channels whose IDs start with this channel's ID share the prefix
+			if key != string(getOrderStorageKey(channelID, order.GetId())) {
+				continue
+			}
+			index.put(key, order)
+		}
+		book.channels[string(channelID)] = index
+	}
+	return index.list(), nil
+}
+
+// putOrder stores the order and updates the order book and the search indexes
+func (s *OrderService) putOrder(ctx context.Context, channelID []byte, order *pb.Order, orderInBytes []byte) error {
+	// The indexed fields of a stored order may change, so its old index entries are replaced
+	change := pb.BookChange_ORDER_ADDED
+	previous := &pb.Order{}
+	if data, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, order.GetId())); errors.IsEmpty(err) && proto.Unmarshal(data, previous) == nil && bytes.Equal(previous.GetId(), order.GetId()) {
+		change = pb.BookChange_ORDER_CHANGED
+		err = s.unindexOrder(ctx, channelID, previous)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	err := s.Storage.Put(ctx, getOrderStorageKey(channelID, order.GetId()), orderInBytes)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return s.addOrder(ctx, channelID, change, order)
+}
+
+// addOrder indexes a stored order, puts it in the order book and publishes the change to the order feed
+func (s *OrderService) addOrder(ctx context.Context, channelID []byte, change pb.BookChange, order *pb.Order) error {
+	err := s.indexOrder(ctx, channelID, order)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if s.book != nil {
+		s.book.put(channelID, order)
+	}
+	s.invalidate(channelID, order.GetId())
+	if s.feed != nil {
+		return s.feed.publish(ctx, channelID, change, order)
+	}
+	return nil
+}
+
+// ResetOrderBook reloads the order book, the order cache and the predecessor keys from storage on next read, for when storage has been changed directly
+func (s *OrderService) ResetOrderBook() {
+	if s.book != nil {
+		s.book.Reset()
+	}
+	if s.cache != nil {
+		s.cache.Reset()
+	}
+	s.predecessors.reset()
+}
+
+// GetOrderBook fetches the open orders of a channel, sorted by price and then creation time
+func (s *OrderService) GetOrderBook(ctx context.Context, in *pb.ChannelSpecificRequest) (*pb.OrderList, error) {
+	if s.book == nil {
+		return nil, errors.E(errors.Op("Get order book"), "storage not registered with OrderService")
+	}
+	orders, err := s.book.get(ctx, in.GetId())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get order book"), err)
+	}
+	return &pb.OrderList{Orders: orders}, nil
+}
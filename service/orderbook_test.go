@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOrderBookTestService() *OrderService {
+	bookService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	bookService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	return bookService
+}
+
+func createOrderBookTestOrder(t testing.TB, bookService *OrderService, channelID string, price float32) *pb.Order {
+	resp, err := bookService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(channelID), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: price})
+	assert.NoError(t, err)
+	return resp.GetCreatedOrder()
+}
+
+func TestOrderIndexSorting(t *testing.T) {
+	index := &orderIndex{}
+	index.put("c", &pb.Order{Id: []byte("c"), Price: 2, Created: &timestamp.Timestamp{Seconds: 1}})
+	index.put("b", &pb.Order{Id: []byte("b"), Price: 1, Created: &timestamp.Timestamp{Seconds: 2}})
+	index.put("a", &pb.Order{Id: []byte("a"), Price: 1, Created: &timestamp.Timestamp{Seconds: 1}})
+
+	orders := index.list()
+	assert.Equal(t, []byte("a"), orders[0].GetId())
+	assert.Equal(t, []byte("b"), orders[1].GetId())
+	assert.Equal(t, []byte("c"), orders[2].GetId())
+
+	// Putting an order again replaces it
+	index.put("c", &pb.Order{Id: []byte("c"), Price: 0})
+	orders = index.list()
+	assert.Len(t, orders, 3)
+	assert.Equal(t, []byte("c"), orders[0].GetId())
+
+	index.remove("b")
+	assert.Len(t, index.list(), 2)
+}
+
+func TestOrderBookColdStart(t *testing.T) {
+	bookService := newOrderBookTestService()
+	expensiveOrder := createOrderBookTestOrder(t, bookService, assetPair, 2)
+	cheapOrder := createOrderBookTestOrder(t, bookService, assetPair, 1)
+	otherOrder := createOrderBookTestOrder(t, bookService, assetPair+"X", 1)
+
+	// A new service has to read the existing orders from storage
+	coldService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	coldService.RegisterStorage(bookService.Storage)
+
+	book, err := coldService.GetOrderBook(context.Background(), &pb.ChannelSpecificRequest{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Len(t, book.GetOrders(), 2)
+	assert.Equal(t, cheapOrder.GetId(), book.GetOrders()[0].GetId())
+	assert.Equal(t, expensiveOrder.GetId(), book.GetOrders()[1].GetId())
+
+	all, err := coldService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, all.GetOrders(), 3)
+	assert.Contains(t, [][]byte{all.GetOrders()[0].GetId(), all.GetOrders()[1].GetId()}, otherOrder.GetId())
+}
+
+func TestOrderBookUpdates(t *testing.T) {
+	bookService := newOrderBookTestService()
+	order := createOrderBookTestOrder(t, bookService, assetPair, 1)
+
+	// Load both indexes before changing anything
+	_, err := bookService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	_, err = bookService.GetOrderBook(context.Background(), &pb.ChannelSpecificRequest{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+
+	newOrder := createOrderBookTestOrder(t, bookService, assetPair, 0.5)
+	request := &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: []byte(assetPair)}
+	_, err = bookService.Lock(context.Background(), request)
+	assert.NoError(t, err)
+
+	book, err := bookService.GetOrderBook(context.Background(), &pb.ChannelSpecificRequest{Id: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Len(t, book.GetOrders(), 2)
+	assert.Equal(t, newOrder.GetId(), book.GetOrders()[0].GetId())
+	assert.Equal(t, pb.State_LOCKED, book.GetOrders()[1].GetState())
+
+	_, err = bookService.Delete(context.Background(), request)
+	assert.NoError(t, err)
+	all, err := bookService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, all.GetOrders(), 1)
+	assert.Equal(t, newOrder.GetId(), all.GetOrders()[0].GetId())
+
+	// Writing storage directly needs a reset to be seen
+	marshaledOrder, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	err = bookService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), marshaledOrder)
+	assert.NoError(t, err)
+	bookService.ResetOrderBook()
+	all, err = bookService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, all.GetOrders(), 2)
+}
+
+func BenchmarkGetAllOrders(b *testing.B) {
+	bookService := newOrderBookTestService()
+	for i := 0; i < 1000; i++ {
+		createOrderBookTestOrder(b, bookService, assetPair, float32(i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bookService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	}
+}
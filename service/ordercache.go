@@ -0,0 +1,142 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// cachedOrder is an entry of an OrderCache, identified by its storage key
+type cachedOrder struct {
+	key   string
+	order *pb.Order
+}
+
+// OrderCache keeps the most recently read single orders in memory, so that GetOrder doesn't hit storage for hot orders.
+// Entries are dropped whenever their order is written or deleted.
+type OrderCache struct {
+	size    int
+	entries map[string]*list.Element
+	recent  *list.List
+	hits    uint64
+	misses  uint64
+	// invalidations counts removals, so that an order read from storage isn't cached if it was written meanwhile
+	invalidations uint64
+	lock          sync.Mutex
+}
+
+// NewOrderCache returns an empty OrderCache that holds up to size orders
+func NewOrderCache(size uint) *OrderCache {
+	return &OrderCache{
+		size:    int(size),
+		entries: make(map[string]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// RegisterCache registers a cache for GetOrder
+func (s *OrderService) RegisterCache(cache *OrderCache) {
+	s.cache = cache
+}
+
+// Stats returns how many reads were served from the cache and how many went to storage
+func (cache *OrderCache) Stats() (hits uint64, misses uint64) {
+	return atomic.LoadUint64(&cache.hits), atomic.LoadUint64(&cache.misses)
+}
+
+// Reset drops all cached orders, for when storage has been changed directly
+func (cache *OrderCache) Reset() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.entries = make(map[string]*list.Element)
+	cache.recent.Init()
+	cache.invalidations++
+}
+
+// get returns a copy of a cached order, counting the read as a hit or a miss
+func (cache *OrderCache) get(key string) (*pb.Order, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	element, ok := cache.entries[key]
+	if !ok {
+		atomic.AddUint64(&cache.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&cache.hits, 1)
+	cache.recent.MoveToFront(element)
+	return proto.Clone(element.Value.(*cachedOrder).order).(*pb.Order), true
+}
+
+// generation returns the current count of invalidations, to pass to fill after reading from storage
+func (cache *OrderCache) generation() uint64 {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.invalidations
+}
+
+// fill caches a copy of an order read from storage, unless any order has been invalidated since the read started.
+// The least recently used order is evicted if the cache is full.
+func (cache *OrderCache) fill(key string, order *pb.Order, generation uint64) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if cache.size <= 0 || cache.invalidations != generation {
+		return
+	}
+	order = proto.Clone(order).(*pb.Order)
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*cachedOrder).order = order
+		cache.recent.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.recent.PushFront(&cachedOrder{key: key, order: order})
+	if cache.recent.Len() > cache.size {
+		oldest := cache.recent.Back()
+		cache.recent.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cachedOrder).key)
+	}
+}
+
+// remove drops an order from the cache
+func (cache *OrderCache) remove(key string) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.invalidations++
+	if element, ok := cache.entries[key]; ok {
+		cache.recent.Remove(element)
+		delete(cache.entries, key)
+	}
+}
+
+// invalidate drops a written or deleted order from the cache, if there is one
+func (s *OrderService) invalidate(channelID []byte, orderID []byte) {
+	if s.cache != nil {
+		s.cache.remove(string(getOrderStorageKey(channelID, orderID)))
+	}
+}
+
+// getOrder reads an order through the cache, if there is one
+func (s *OrderService) getOrder(ctx context.Context, channelID []byte, orderID []byte) (*pb.Order, error) {
+	key := getOrderStorageKey(channelID, orderID)
+	var generation uint64
+	if s.cache != nil {
+		if order, ok := s.cache.get(string(key)); ok {
+			return order, nil
+		}
+		generation = s.cache.generation()
+	}
+	data, err := s.Storage.Get(ctx, key)
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	order := &pb.Order{}
+	proto.Unmarshal(data, order)
+	if s.cache != nil {
+		s.cache.fill(string(key), order, generation)
+	}
+	return order, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderCacheEviction(t *testing.T) {
+	cache := NewOrderCache(2)
+	cache.fill("a", &pb.Order{Id: []byte("a")}, cache.generation())
+	cache.fill("b", &pb.Order{Id: []byte("b")}, cache.generation())
+
+	// Reading a makes b the least recently used
+	_, ok := cache.get("a")
+	assert.True(t, ok)
+	cache.fill("c", &pb.Order{Id: []byte("c")}, cache.generation())
+
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+	order, ok := cache.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), order.GetId())
+	_, ok = cache.get("c")
+	assert.True(t, ok)
+
+	hits, misses := cache.Stats()
+	assert.Equal(t, uint64(3), hits)
+	assert.Equal(t, uint64(1), misses)
+
+	// An order read before an invalidation isn't cached
+	generation := cache.generation()
+	cache.remove("c")
+	cache.fill("d", &pb.Order{Id: []byte("d")}, generation)
+	_, ok = cache.get("d")
+	assert.False(t, ok)
+}
+
+func TestOrderCacheInvalidation(t *testing.T) {
+	cacheService := newOrderBookTestService()
+	cache := NewOrderCache(16)
+	cacheService.RegisterCache(cache)
+	order := createOrderBookTestOrder(t, cacheService, assetPair, 1)
+	request := &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: []byte(assetPair)}
+
+	cached, err := cacheService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_OPEN, cached.GetState())
+	_, err = cacheService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	hits, misses := cache.Stats()
+	assert.Equal(t, uint64(1), hits)
+	assert.Equal(t, uint64(1), misses)
+
+	// Changing a returned order doesn't change the cached one
+	cached.Price = 100
+	cached, err = cacheService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(1), cached.GetPrice())
+
+	_, err = cacheService.Lock(context.Background(), request)
+	assert.NoError(t, err)
+	cached, err = cacheService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, cached.GetState())
+
+	_, err = cacheService.Delete(context.Background(), request)
+	assert.NoError(t, err)
+	_, err = cacheService.GetOrder(context.Background(), request)
+	assert.Error(t, err)
+
+	// Writing storage directly needs a reset to be seen
+	marshaledOrder, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	err = cacheService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), marshaledOrder)
+	assert.NoError(t, err)
+	_, err = cacheService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	order.State = pb.State_LOCKED
+	marshaledOrder, err = proto.Marshal(order)
+	assert.NoError(t, err)
+	err = cacheService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), marshaledOrder)
+	assert.NoError(t, err)
+	cacheService.ResetOrderBook()
+	cached, err = cacheService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, cached.GetState())
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// OrderIDAccept accepts received orders whose ID doesn't match their content
+const OrderIDAccept string = "accept"
+
+// OrderIDReject rejects received orders whose ID doesn't match their content
+const OrderIDReject string = "reject"
+
+// OrderIDQuarantine keeps received orders whose ID doesn't match their content out of the order book, under QuarantinePrefix
+const OrderIDQuarantine string = "quarantine"
+
+// CollisionReject keeps the stored order when a received order with different content claims its ID
+const CollisionReject string = "reject"
+
+// CollisionLastWriteWins keeps whichever of two orders claiming the same ID was created last
+const CollisionLastWriteWins string = "lastWriteWins"
+
+// quarantineRetention is how long quarantined orders are kept for inspection
+const quarantineRetention time.Duration = 24 * time.Hour
+
+// CheckOrderIDPolicies tells if the policies for mismatching and colliding order IDs are known
+func CheckOrderIDPolicies(mismatch string, collision string) error {
+	op := errors.Op("Check order ID policies")
+	if mismatch != OrderIDAccept && mismatch != OrderIDReject && mismatch != OrderIDQuarantine {
+		return errors.E(op, errors.Invalid, fmt.Sprintf("order ID mismatch policy %q isn't %s, %s or %s", mismatch, OrderIDAccept, OrderIDReject, OrderIDQuarantine))
+	}
+	if collision != CollisionReject && collision != CollisionLastWriteWins {
+		return errors.E(op, errors.Invalid, fmt.Sprintf("order ID collision policy %q isn't %s or %s", collision, CollisionReject, CollisionLastWriteWins))
+	}
+	return nil
+}
+
+func getQuarantineStorageKey(channelID []byte, orderID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.QuarantinePrefix), string(channelID), string(orderID)}, ""))
+}
+
+// getOrderID derives the ID of an order from the request it was created with and its creation time, keyed with its maker's public key.
+// Only the fields an order carries are hashed, each length-prefixed in the deterministic wire encoding, so every node derives the same ID
+// whatever version of the protobuf library it's built with.
+func getOrderID(makerPubKey []byte, request *pb.CreateRequest, created *timestamp.Timestamp) []byte {
+	buffer := proto.NewBuffer(nil)
+	buffer.SetDeterministic(true)
+	// Marshaling these messages of plain fields can't fail
+	buffer.EncodeMessage(&pb.CreateRequest{
+		ChannelID:    request.GetChannelID(),
+		Asset:        request.GetAsset(),
+		CounterAsset: request.GetCounterAsset(),
+		Amount:       request.GetAmount(),
+		Price:        request.GetPrice(),
+	})
+	buffer.EncodeMessage(&timestamp.Timestamp{Seconds: created.GetSeconds(), Nanos: created.GetNanos()})
+	h := hmac.New(sha256.New, makerPubKey)
+	h.Write(buffer.Bytes())
+	return h.Sum(nil)
+}
+
+// getExpectedOrderID recomputes the ID a received order should have with the given maker key
+func getExpectedOrderID(channelID []byte, order *pb.Order, makerPubKey []byte) []byte {
+	request := &pb.CreateRequest{
+		ChannelID:    channelID,
+		Asset:        order.GetAsset(),
+		CounterAsset: order.GetCounterAsset(),
+		Amount:       order.GetAmount(),
+		Price:        order.GetPrice(),
+	}
+	return getOrderID(makerPubKey, request, order.GetCreated())
+}
+
+// getCandidateChannels returns the channels a received order may have been created on: the one it was received on,
+// and the joined channels that trade the same asset pair, which routing nodes mirror their orders to
+func (s *OrderService) getCandidateChannels(ctx context.Context, channelID []byte) [][]byte {
+	candidates := [][]byte{channelID}
+	channels, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return candidates
+	}
+	normalized := NormalizeAssetPair(channelID)
+	for _, value := range channels {
+		channel := &pb.Channel{}
+		if err := proto.Unmarshal([]byte(value), channel); !errors.IsEmpty(err) {
+			continue
+		}
+		if !bytes.Equal(channel.GetId(), channelID) && bytes.Equal(NormalizeAssetPair(channel.GetId()), normalized) {
+			candidates = append(candidates, channel.GetId())
+		}
+	}
+	return candidates
+}
+
+// predecessorKeys caches the public keys each maker has rotated away from, keyed by the maker's new peer ID,
+// so checking the ID of an order doesn't verify every stored transition. It's loaded from storage on first use.
+type predecessorKeys struct {
+	keys       map[peer.ID]map[peer.ID][]byte
+	successors map[peer.ID]peer.ID
+	loaded     bool
+	lock       sync.Mutex
+}
+
+// add caches the key of a stored transition, replacing the one stored before for the same old peer ID
+func (p *predecessorKeys) add(oldID peer.ID, newID peer.ID, oldPubKey []byte) {
+	if previous, ok := p.successors[oldID]; ok {
+		delete(p.keys[previous], oldID)
+	}
+	if p.keys[newID] == nil {
+		p.keys[newID] = make(map[peer.ID][]byte)
+	}
+	p.keys[newID][oldID] = oldPubKey
+	p.successors[oldID] = newID
+}
+
+// stored caches a transition that has just been stored. A cache that isn't loaded yet gets it from storage.
+func (p *predecessorKeys) stored(transition *pb.IdentityTransition, oldID peer.ID, newID peer.ID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.loaded {
+		p.add(oldID, newID, transition.GetOldPubKey())
+	}
+}
+
+// reset drops the cached keys, for when storage has been changed directly
+func (p *predecessorKeys) reset() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.loaded = false
+}
+
+// get returns the public keys the maker has rotated away from, verifying the stored transitions once
+func (p *predecessorKeys) get(ctx context.Context, storage interfaces.Storage, makerID peer.ID) [][]byte {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !p.loaded {
+		transitions, err := storage.GetAllWithPrefix(ctx, string(interfaces.TransitionPrefix))
+		if !errors.IsEmpty(err) {
+			return nil
+		}
+		p.keys = make(map[peer.ID]map[peer.ID][]byte)
+		p.successors = make(map[peer.ID]peer.ID)
+		for _, data := range transitions {
+			transition := &pb.IdentityTransition{}
+			if err := proto.Unmarshal([]byte(data), transition); !errors.IsEmpty(err) {
+				continue
+			}
+			if oldID, newID, err := identity.VerifyTransition(transition); errors.IsEmpty(err) {
+				p.add(oldID, newID, transition.GetOldPubKey())
+			}
+		}
+		p.loaded = true
+	}
+	keys := make([][]byte, 0, len(p.keys[makerID]))
+	for _, key := range p.keys[makerID] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// getPredecessorKeys returns the public keys the maker has rotated away from. Orders signed again after a rotation
+// keep the ID they were created with under the old key.
+func (s *OrderService) getPredecessorKeys(ctx context.Context, makerID peer.ID) [][]byte {
+	return s.predecessors.get(ctx, s.Storage, makerID)
+}
+
+// hasExpectedID tells if the order's ID is the one derived from its content on one of the candidate channels,
+// with its maker's key or a key the maker rotated away from
+func (s *OrderService) hasExpectedID(ctx context.Context, channelID []byte, order *pb.Order, makerID peer.ID) bool {
+	keys := append([][]byte{order.GetMakerPubKey()}, s.getPredecessorKeys(ctx, makerID)...)
+	for _, candidate := range s.getCandidateChannels(ctx, channelID) {
+		for _, key := range keys {
+			if hmac.Equal(order.GetId(), getExpectedOrderID(candidate, order, key)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkOrderID applies OrderIDMismatch to a received order whose ID doesn't match its content
+func (s *OrderService) checkOrderID(ctx context.Context, channelID []byte, order *pb.Order, data []byte, makerID peer.ID) error {
+	if s.OrderIDMismatch == "" || s.OrderIDMismatch == OrderIDAccept || s.hasExpectedID(ctx, channelID, order, makerID) {
+		return nil
+	}
+	op := errors.Op("Check order ID")
+	s.count(orderIDMismatchesCounter)
+	if s.OrderIDMismatch == OrderIDQuarantine {
+		err := s.Storage.PutWithTTL(ctx, getQuarantineStorageKey(channelID, order.GetId()), data, quarantineRetention)
+		if !errors.IsEmpty(err) {
+			return errors.E(op, err)
+		}
+		return errors.E(op, errors.Invalid, fmt.Sprintf("order %x by %s doesn't match its ID and is quarantined", order.GetId(), makerID))
+	}
+	return errors.E(op, errors.Invalid, fmt.Sprintf("order %x by %s doesn't match its ID", order.GetId(), makerID))
+}
+
+// isSameOrder tells if two orders with the same ID were created as the same order. Their state may differ,
+// and so may their maker if it has rotated its identity since.
+func (s *OrderService) isSameOrder(ctx context.Context, stored *pb.Order, received *pb.Order) bool {
+	return proto.Equal(stored.GetCreated(), received.GetCreated()) &&
+		stored.GetAsset() == received.GetAsset() &&
+		stored.GetCounterAsset() == received.GetCounterAsset() &&
+		stored.GetAmount() == received.GetAmount() &&
+		stored.GetPrice() == received.GetPrice() &&
+		s.isSameIdentity(ctx, peer.ID(stored.GetMakerPeerID()), peer.ID(received.GetMakerPeerID()))
+}
+
+// isCreatedLater tells if order a wins over order b under CollisionLastWriteWins. Equal creation times are decided
+// by the signatures, so every node keeps the same order whichever of them it receives first.
+func isCreatedLater(a *pb.Order, b *pb.Order) bool {
+	aCreated, bCreated := a.GetCreated(), b.GetCreated()
+	if aCreated.GetSeconds() != bCreated.GetSeconds() {
+		return aCreated.GetSeconds() > bCreated.GetSeconds()
+	}
+	if aCreated.GetNanos() != bCreated.GetNanos() {
+		return aCreated.GetNanos() > bCreated.GetNanos()
+	}
+	return bytes.Compare(a.GetSignature(), b.GetSignature()) > 0
+}
+
+// resolveCollision applies OrderIDCollision when a received order claims the ID of a stored order that was created differently
+func (s *OrderService) resolveCollision(ctx context.Context, channelID []byte, order *pb.Order) error {
+	if s.OrderIDCollision == "" {
+		return nil
+	}
+	data, err := s.Storage.Get(ctx, getOrderStorageKey(channelID, order.GetId()))
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	stored := &pb.Order{}
+	if err := proto.Unmarshal(data, stored); !errors.IsEmpty(err) || s.isSameOrder(ctx, stored, order) {
+		return nil
+	}
+
+	op := errors.Op("Resolve order ID collision")
+	s.count(orderIDCollisionsCounter)
+	s.Logger.Warnf("Order %x by %s claims the ID of an order by %s", order.GetId(), peer.ID(order.GetMakerPeerID()), peer.ID(stored.GetMakerPeerID()))
+	if s.OrderIDCollision == CollisionLastWriteWins {
+		if isCreatedLater(order, stored) {
+			return nil
+		}
+		return errors.E(op, errors.Duplicate, "an order created later with the same ID is already stored")
+	}
+	return errors.E(op, errors.Invalid, "the order ID is already taken by a different order")
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+// signOrder signs the order again as the maker and returns it marshaled
+func signOrder(t *testing.T, maker *OrderService, order *pb.Order) []byte {
+	signature, err := maker.GetSignature(order)
+	assert.NoError(t, err)
+	order.Signature = signature
+	data, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	return data
+}
+
+func receiveCreate(s *OrderService, data []byte, from peer.ID) error {
+	return s.process(context.Background(), &pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_CREATE, Data: data}, from)
+}
+
+func TestOrderIDMismatch(t *testing.T) {
+	ctx := context.Background()
+	maker := newOwnershipTestService()
+	resp, err := maker.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	makerID := peer.ID(order.GetMakerPeerID())
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+
+	// The ID of a created order matches its content
+	receiver := newOwnershipTestService()
+	receiver.OrderIDMismatch = OrderIDReject
+	assert.NoError(t, receiveCreate(receiver, orderInBytes, makerID))
+
+	// A signed order whose content was changed after its ID was derived is rejected
+	tampered := *order
+	tampered.Price = testPrice * 2
+	tamperedInBytes := signOrder(t, maker, &tampered)
+	receiver = newOwnershipTestService()
+	receiver.OrderIDMismatch = OrderIDReject
+	assert.True(t, errors.Is(errors.Invalid, receiveCreate(receiver, tamperedInBytes, makerID)))
+	assert.Equal(t, uint64(1), receiver.sessionCount(orderIDMismatchesCounter))
+
+	// Or kept out of the order book in quarantine
+	receiver = newOwnershipTestService()
+	receiver.OrderIDMismatch = OrderIDQuarantine
+	assert.True(t, errors.Is(errors.Invalid, receiveCreate(receiver, tamperedInBytes, makerID)))
+	quarantined, err := receiver.Storage.Get(ctx, getQuarantineStorageKey([]byte(assetPair), order.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, tamperedInBytes, quarantined)
+	exists, err := receiver.Storage.Has(ctx, getOrderStorageKey([]byte(assetPair), order.GetId()))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Or accepted as it is
+	receiver = newOwnershipTestService()
+	receiver.OrderIDMismatch = OrderIDAccept
+	assert.NoError(t, receiveCreate(receiver, tamperedInBytes, makerID))
+}
+
+func TestOrderIDCollision(t *testing.T) {
+	ctx := context.Background()
+	maker := newOwnershipTestService()
+	resp, err := maker.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	makerID := peer.ID(order.GetMakerPeerID())
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+
+	later := *order
+	later.Price = testPrice * 2
+	later.Created = &timestamp.Timestamp{Seconds: order.GetCreated().GetSeconds() + 1}
+	laterInBytes := signOrder(t, maker, &later)
+
+	// Changes to the state of the same order aren't collisions
+	locked := *order
+	locked.State = pb.State_LOCKED
+	locked.Nonce = 1
+	signOrder(t, maker, &locked)
+
+	// The stored order is kept
+	receiver := newOwnershipTestService()
+	receiver.OrderIDCollision = CollisionReject
+	assert.NoError(t, receiveCreate(receiver, orderInBytes, makerID))
+	assert.NoError(t, receiver.resolveCollision(ctx, []byte(assetPair), &locked))
+	assert.True(t, errors.Is(errors.Invalid, receiveCreate(receiver, laterInBytes, makerID)))
+	assert.Equal(t, uint64(1), receiver.sessionCount(orderIDCollisionsCounter))
+	stored, err := receiver.Storage.Get(ctx, getOrderStorageKey([]byte(assetPair), order.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, orderInBytes, stored)
+
+	// The order created last is kept, whichever arrives first
+	for _, arrivals := range [][][]byte{{orderInBytes, laterInBytes}, {laterInBytes, orderInBytes}} {
+		receiver = newOwnershipTestService()
+		receiver.OrderIDCollision = CollisionLastWriteWins
+		assert.NoError(t, receiveCreate(receiver, arrivals[0], makerID))
+		err = receiveCreate(receiver, arrivals[1], makerID)
+		assert.True(t, errors.IsEmpty(err) || errors.Is(errors.Duplicate, err))
+		stored, err = receiver.Storage.Get(ctx, getOrderStorageKey([]byte(assetPair), order.GetId()))
+		assert.NoError(t, err)
+		assert.Equal(t, laterInBytes, stored)
+	}
+}
+
+func TestOrderIDIsDeterministic(t *testing.T) {
+	makerPubKey := []byte("maker")
+	created := &timestamp.Timestamp{Seconds: 1500000000, Nanos: 42}
+	request := &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 100, Price: 2.5}
+	id := getOrderID(makerPubKey, request, created)
+
+	// Fields the order doesn't carry don't change its ID
+	withUnknownFields := proto.Clone(request).(*pb.CreateRequest)
+	withUnknownFields.XXX_unrecognized = []byte{0x78, 0x01}
+	assert.Equal(t, id, getOrderID(makerPubKey, withUnknownFields, created))
+	assert.Equal(t, id, getExpectedOrderID([]byte(assetPair), &pb.Order{Asset: asset1, CounterAsset: asset2, Amount: 100, Price: 2.5, Created: created}, makerPubKey))
+
+	changedPrice := proto.Clone(request).(*pb.CreateRequest)
+	changedPrice.Price = 2.6
+	assert.NotEqual(t, id, getOrderID(makerPubKey, changedPrice, created))
+	assert.NotEqual(t, id, getOrderID(makerPubKey, request, &timestamp.Timestamp{Seconds: 1500000000, Nanos: 43}))
+	assert.NotEqual(t, id, getOrderID([]byte("taker"), request, created))
+}
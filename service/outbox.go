@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Outbox keeps the messages of created orders in storage until they've been broadcast, so that an order stored
+// by a node that crashes before broadcasting it still reaches the network once the node is back
+type Outbox struct {
+	wake chan struct{}
+}
+
+// NewOutbox returns an Outbox for RunOutbox to dispatch
+func NewOutbox() *Outbox {
+	return &Outbox{wake: make(chan struct{}, 1)}
+}
+
+// RegisterOutbox registers the outbox that created orders are broadcast through.
+// Without one, Create broadcasts orders before storing them.
+func (s *OrderService) RegisterOutbox(outbox *Outbox) {
+	s.outbox = outbox
+}
+
+// notify wakes up RunOutbox, unless it's already been woken up
+func (outbox *Outbox) notify() {
+	select {
+	case outbox.wake <- struct{}{}:
+	default:
+	}
+}
+
+// getOutboxStorageKey returns the key of an outbox entry. Entries are keyed by when they were made, so they're broadcast in order.
+func getOutboxStorageKey(created time.Time, orderID []byte) []byte {
+	return []byte(fmt.Sprintf("%s%020d%s", interfaces.OutboxPrefix, created.UnixNano(), orderID))
+}
+
+// getOutboxStorageKeys returns the keys of the outbox entries of the given orders
+func (s *OrderService) getOutboxStorageKeys(ctx context.Context, orders []*pb.Order) ([][]byte, error) {
+	entries, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.OutboxPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get outbox"), err)
+	}
+	orderIDs := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		orderIDs[string(order.GetId())] = true
+	}
+	// The order ID follows the prefix and the 20 digits of the time the entry was made
+	offset := len(interfaces.OutboxPrefix) + 20
+	keys := [][]byte{}
+	for key := range entries {
+		if len(key) > offset && orderIDs[key[offset:]] {
+			keys = append(keys, []byte(key))
+		}
+	}
+	return keys, nil
+}
+
+// putWithOutbox stores a created order, the message broadcasting it and the namespace it was created in.
+// Storages that can't put them in one batch get the message first, so a crash can't leave an order that's never broadcast.
+func (s *OrderService) putWithOutbox(ctx context.Context, channelID []byte, order *pb.Order, orderInBytes []byte, wireMessage *pb.WireMessage) error {
+	messageInBytes, err := proto.Marshal(wireMessage)
+	if err != nil {
+		return errors.E(errors.Op("Marshal wire message"), err)
+	}
+	entries := []interfaces.Entry{
+		{Key: getOutboxStorageKey(time.Now(), order.GetId()), Value: messageInBytes},
+		{Key: getOrderStorageKey(channelID, order.GetId()), Value: orderInBytes},
+	}
+	if namespace, ok := getNamespace(ctx); ok {
+		entries = append(entries, interfaces.Entry{Key: getNamespaceStorageKey(channelID, order.GetId()), Value: []byte(namespace)})
+	}
+	if batcher, ok := s.Storage.(interfaces.Batcher); ok {
+		return batcher.PutBatch(ctx, entries)
+	}
+	for _, entry := range entries {
+		err = s.Storage.Put(ctx, entry.Key, entry.Value)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// DispatchOutbox broadcasts the messages in the outbox, oldest first, and removes each once it's been published.
+// It stops at the first message that can't be broadcast, which is tried again on the next dispatch.
+func (s *OrderService) DispatchOutbox(ctx context.Context) error {
+	if s.P2p == nil {
+		return errors.E(errors.Op("Dispatch outbox"), "P2p service not registered with OrderService")
+	}
+	entries, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.OutboxPrefix))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get outbox"), err)
+	}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		wireMessage := &pb.WireMessage{}
+		if err := proto.Unmarshal([]byte(entries[key]), wireMessage); err != nil {
+			// A message that can't be read would never be sent, so it isn't allowed to hold up the rest
+			s.Logger.Warn(errors.E(errors.Op("Unmarshal outbox message"), err))
+		} else {
+			err = s.P2p.Broadcast(ctx, wireMessage)
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Broadcast outbox message"), err)
+			}
+		}
+		err = s.Storage.Delete(ctx, []byte(key))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete outbox message"), err)
+		}
+	}
+	return nil
+}
+
+// RunOutbox dispatches the outbox whenever an order is created, and every retryInterval to retry messages that
+// couldn't be broadcast, starting with the ones left over from before a restart. It returns once ctx is done.
+func (s *OrderService) RunOutbox(ctx context.Context, retryInterval time.Duration) {
+	if s.outbox == nil {
+		return
+	}
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		err := s.DispatchOutbox(ctx)
+		if !errors.IsEmpty(err) && ctx.Err() == nil {
+			s.Logger.Warn(errors.E(errors.Op("Dispatch outbox"), err))
+		}
+		select {
+		case <-s.outbox.wake:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/interfaces/mocks"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	p2p := new(mocks.P2p)
+	p2p.On("Broadcast", mock.Anything, mock.Anything).Return(errors.E(errors.Op("Broadcast message"), "no peers")).Once()
+	p2p.On("Broadcast", mock.Anything, mock.Anything).Return(nil)
+
+	outboxService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	outboxService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	outboxService.RegisterP2p(p2p)
+	outboxService.RegisterOutbox(NewOutbox())
+
+	// The order is stored along with its broadcast, instead of being sent right away
+	created, err := outboxService.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	p2p.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	order, err := outboxService.GetOrder(ctx, &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: created.GetCreatedOrder().GetId()})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(created.GetCreatedOrder(), order))
+	outbox, err := outboxService.Storage.GetAllWithPrefix(ctx, string(interfaces.OutboxPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(outbox))
+
+	// A failed broadcast stays in the outbox for the next dispatch
+	assert.Error(t, outboxService.DispatchOutbox(ctx))
+	outbox, err = outboxService.Storage.GetAllWithPrefix(ctx, string(interfaces.OutboxPrefix))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(outbox))
+
+	assert.NoError(t, outboxService.DispatchOutbox(ctx))
+	outbox, err = outboxService.Storage.GetAllWithPrefix(ctx, string(interfaces.OutboxPrefix))
+	assert.NoError(t, err)
+	assert.Empty(t, outbox)
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	p2p.AssertCalled(t, "Broadcast", mock.Anything, mock.MatchedBy(func(message *pb.WireMessage) bool {
+		return message.GetOperation() == pb.Operation_CREATE && string(message.GetData()) == string(orderInBytes)
+	}))
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// getMaker returns this node's peer ID and marshaled public key, used as the maker of created orders
+func (s *OrderService) getMaker() (peer.ID, []byte, error) {
+	peerID, publicKey, err := getOwnPeerID(s.Storage)
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Get maker"), err)
+	}
+	publicKeyBytes, err := crypto.MarshalPublicKey(publicKey)
+	if !errors.IsEmpty(err) {
+		return "", nil, errors.E(errors.Op("Marshal maker public key"), err)
+	}
+	return peerID, publicKeyBytes, nil
+}
+
+// verifyMaker checks that the order's maker fields belong together and that the maker has signed the order
+func (s *OrderService) verifyMaker(order *pb.Order) (peer.ID, error) {
+	return identity.VerifyOrder(order)
+}
+
+// verifyMakers verifies a batch of orders at once, returning the maker or the error of each order at its index
+func (s *OrderService) verifyMakers(orders []*pb.Order) ([]peer.ID, []error) {
+	return identity.VerifyOrders(orders)
+}
+
+// getChannel reads a joined channel from storage
+func (s *OrderService) getChannel(ctx context.Context, channelID []byte) (*pb.Channel, error) {
+	data, err := s.Storage.Get(ctx, getChannelStorageKey(channelID))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channel"), err)
+	}
+	channel := &pb.Channel{}
+	err = proto.Unmarshal(data, channel)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Unmarshal channel"), err)
+	}
+	return channel, nil
+}
+
+// isChannelAdmin tells if the peer is listed as an admin of the joined channel
+func (s *OrderService) isChannelAdmin(ctx context.Context, channelID []byte, peerID peer.ID) bool {
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return false
+	}
+	for _, admin := range channel.GetAdmins() {
+		if admin == peerID.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize checks that the peer may delete, lock or unlock the order.
+// Only the order's maker, also across an identity rotation, or an admin of the channel may modify it.
+func (s *OrderService) authorize(ctx context.Context, channelID []byte, order *pb.Order, peerID peer.ID) error {
+	makerID, err := s.verifyMaker(order)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if s.isSameIdentity(ctx, makerID, peerID) || s.isChannelAdmin(ctx, channelID, peerID) {
+		return nil
+	}
+	return errors.E(errors.Op("Authorize order modification"), errors.Unauthorized, "only the maker or a channel admin may modify the order")
+}
+
+// authorizeSelf checks that this node, and the calling client if it has an API key, may delete, lock or unlock the order
+func (s *OrderService) authorizeSelf(ctx context.Context, channelID []byte, order *pb.Order) error {
+	ownID, _, err := s.getMaker()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	err = s.authorize(ctx, channelID, order, ownID)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	return s.authorizeNamespace(ctx, channelID, order.GetId())
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOwnershipTestService() *OrderService {
+	ownershipService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	ownershipService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	return ownershipService
+}
+
+func newStranger(t *testing.T) (peer.ID, crypto.PubKey) {
+	_, publicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	strangerID, err := peer.IDFromPublicKey(publicKey)
+	assert.NoError(t, err)
+	return strangerID, publicKey
+}
+
+func TestOrderMaker(t *testing.T) {
+	ownershipService := newOwnershipTestService()
+	resp, err := ownershipService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+
+	ownID, _, err := ownershipService.getMaker()
+	assert.NoError(t, err)
+	makerID, err := ownershipService.verifyMaker(order)
+	assert.NoError(t, err)
+	assert.Equal(t, ownID, makerID)
+
+	strangerID, strangerPublicKey := newStranger(t)
+
+	tamperedOrder := *order
+	tamperedOrder.MakerPeerID = []byte(strangerID)
+	_, err = ownershipService.verifyMaker(&tamperedOrder)
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+
+	tamperedOrder.MakerPubKey, err = crypto.MarshalPublicKey(strangerPublicKey)
+	assert.NoError(t, err)
+	_, err = ownershipService.verifyMaker(&tamperedOrder)
+	assert.True(t, errors.Is(errors.InvalidSignature, err))
+
+	tamperedOrder.MakerPeerID = nil
+	_, err = ownershipService.verifyMaker(&tamperedOrder)
+	assert.True(t, errors.Is(errors.Malformed, err))
+}
+
+func TestOrderAuthorization(t *testing.T) {
+	ownershipService := newOwnershipTestService()
+	resp, err := ownershipService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+
+	strangerID, _ := newStranger(t)
+	assert.NoError(t, ownershipService.authorizeSelf(context.Background(), []byte(assetPair), order))
+	assert.True(t, errors.Is(errors.Unauthorized, ownershipService.authorize(context.Background(), []byte(assetPair), order, strangerID)))
+
+	marshaledChannel, err := proto.Marshal(&pb.Channel{Id: []byte(assetPair), Admins: []string{strangerID.String()}})
+	assert.NoError(t, err)
+	err = ownershipService.Storage.Put(context.Background(), getChannelStorageKey([]byte(assetPair)), marshaledChannel)
+	assert.NoError(t, err)
+	assert.NoError(t, ownershipService.authorize(context.Background(), []byte(assetPair), order, strangerID))
+}
+
+func TestOrderModificationByStranger(t *testing.T) {
+	makerService := newOwnershipTestService()
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+
+	// Another node has received the order, but isn't allowed to modify it
+	strangerService := newOwnershipTestService()
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	err = strangerService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), orderInBytes)
+	assert.NoError(t, err)
+
+	request := &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: []byte(assetPair)}
+	_, err = strangerService.Lock(context.Background(), request)
+	assert.True(t, errors.Is(errors.Unauthorized, err))
+	_, err = strangerService.Delete(context.Background(), request)
+	assert.True(t, errors.Is(errors.Unauthorized, err))
+
+	_, err = makerService.Lock(context.Background(), request)
+	assert.NoError(t, err)
+	_, err = makerService.Delete(context.Background(), request)
+	assert.NoError(t, err)
+}
+
+func TestOrderModificationForgedByStranger(t *testing.T) {
+	makerService := newOwnershipTestService()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+	receiverService := newOwnershipTestService()
+
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	assert.NoError(t, receiveOperation(t, receiverService, pb.Operation_CREATE, order, makerID))
+	storedBefore, err := receiverService.Storage.Get(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()))
+	assert.NoError(t, err)
+
+	// The stranger claims to be the maker of the order by signing a copy of it with its own key
+	strangerPrivateKey, strangerPublicKey, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	strangerID, err := peer.IDFromPublicKey(strangerPublicKey)
+	assert.NoError(t, err)
+	forgedOrder := *order
+	forgedOrder.MakerPeerID = []byte(strangerID)
+	forgedOrder.MakerPubKey, err = crypto.MarshalPublicKey(strangerPublicKey)
+	assert.NoError(t, err)
+	forgedOrder.Price = testPrice * 2
+	signingBytes, err := identity.GetOrderSigningBytes(&forgedOrder)
+	assert.NoError(t, err)
+	forgedOrder.Signature, err = strangerPrivateKey.Sign(signingBytes)
+	assert.NoError(t, err)
+
+	assert.True(t, errors.Is(errors.Unauthorized, receiveOperation(t, receiverService, pb.Operation_DELETE, &forgedOrder, strangerID)))
+
+	forgedOrder.State = pb.State_LOCKED
+	forgedOrder.Nonce = order.GetNonce() + 1
+	assert.True(t, errors.Is(errors.Unauthorized, receiveOperation(t, receiverService, pb.Operation_LOCK, &forgedOrder, strangerID)))
+
+	storedAfter, err := receiverService.Storage.Get(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, storedBefore, storedAfter)
+
+	// The maker's own lock keeps the stored terms and takes only the new lock state
+	request := &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: []byte(assetPair)}
+	_, err = makerService.Lock(context.Background(), request)
+	assert.NoError(t, err)
+	lockedOrder, err := makerService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.NoError(t, receiveOperation(t, receiverService, pb.Operation_LOCK, lockedOrder, makerID))
+	receivedOrder, err := receiverService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_LOCKED, receivedOrder.GetState())
+	assert.Equal(t, order.GetPrice(), receivedOrder.GetPrice())
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// defaultProfileDuration is how long a CPU profile is sampled for when the request doesn't say
+const defaultProfileDuration time.Duration = 30 * time.Second
+
+// CaptureProfile writes a heap or CPU profile to a new file in ProfileDir and returns its path
+func (s *AdminService) CaptureProfile(ctx context.Context, in *pb.ProfileRequest) (*pb.ProfileResponse, error) {
+	dir := s.ProfileDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	pattern := fmt.Sprintf("sprawl-%s-%s-*.pprof", in.GetType().String(), time.Now().UTC().Format("20060102T150405"))
+	file, err := ioutil.TempFile(dir, pattern)
+	if err != nil {
+		return nil, errors.E(errors.Op("Create profile file"), err)
+	}
+	defer file.Close()
+
+	switch in.GetType() {
+	case pb.ProfileType_CPU:
+		duration := time.Duration(in.GetSeconds()) * time.Second
+		if duration == 0 {
+			duration = defaultProfileDuration
+		}
+		err = captureCPUProfile(ctx, file, duration)
+	default:
+		// Collect garbage first so the profile shows what's actually still in use
+		runtime.GC()
+		err = pprof.WriteHeapProfile(file)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return nil, errors.E(errors.Op("Capture profile"), err)
+	}
+
+	if s.Logger != nil {
+		s.Logger.Infof("Wrote %s profile to %s", in.GetType().String(), file.Name())
+	}
+	return &pb.ProfileResponse{Path: file.Name()}, nil
+}
+
+// captureCPUProfile samples the CPU for the given duration, or until the request is cancelled
+func captureCPUProfile(ctx context.Context, file *os.File, duration time.Duration) error {
+	err := pprof.StartCPUProfile(file)
+	if err != nil {
+		return err
+	}
+	defer pprof.StopCPUProfile()
+
+	select {
+	case <-time.After(duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
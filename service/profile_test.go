@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sprawl-profile-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	adminService := &AdminService{Logger: new(util.PlaceholderLogger), ProfileDir: dir}
+
+	heap, err := adminService.CaptureProfile(context.Background(), &pb.ProfileRequest{Type: pb.ProfileType_HEAP})
+	assert.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(heap.GetPath()))
+	info, err := os.Stat(heap.GetPath())
+	assert.NoError(t, err)
+	assert.NotZero(t, info.Size())
+
+	cpu, err := adminService.CaptureProfile(context.Background(), &pb.ProfileRequest{Type: pb.ProfileType_CPU, Seconds: 1})
+	assert.NoError(t, err)
+	assert.NotEqual(t, heap.GetPath(), cpu.GetPath())
+	info, err = os.Stat(cpu.GetPath())
+	assert.NoError(t, err)
+	assert.NotZero(t, info.Size())
+
+	// A cancelled capture leaves no file behind
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = adminService.CaptureProfile(ctx, &pb.ProfileRequest{Type: pb.ProfileType_CPU})
+	assert.Error(t, err)
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The roles a node reports in its replication status
+const (
+	RolePrimary    string = "primary"
+	RoleFollower   string = "follower"
+	RoleStandalone string = "standalone"
+)
+
+// replicator is implemented by storages that followers can replicate, like replicated.Storage
+type replicator interface {
+	Follow(ctx context.Context) ([]*pb.ReplicationEntry, uint64, <-chan *pb.ReplicationEntry, func(), error)
+	Followers() int
+	Sequence() uint64
+}
+
+// Replicate streams a snapshot of the whole storage to a follower, and then every change made to it in order.
+// The stream starts by telling the follower to empty its storage, and marks the end of the snapshot with snapshotDone.
+// It ends with Aborted if the follower falls behind, so that it can start over from a new snapshot.
+func (s *AdminService) Replicate(in *pb.Empty, stream pb.AdminHandler_ReplicateServer) error {
+	storage, ok := s.Storage.(replicator)
+	if !ok {
+		return status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Replicate"), errors.Invalid, "replication isn't enabled, set replication.enable on the primary"))
+	}
+	snapshot, sequence, changes, stop, err := storage.Follow(stream.Context())
+	if !errors.IsEmpty(err) {
+		return status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Replicate"), err))
+	}
+	defer stop()
+
+	err = stream.Send(&pb.ReplicationEntry{Sequence: sequence, Delete: true, Prefix: true})
+	if err != nil {
+		return err
+	}
+	for _, entry := range snapshot {
+		err = stream.Send(entry)
+		if err != nil {
+			return err
+		}
+	}
+	err = stream.Send(&pb.ReplicationEntry{Sequence: sequence, SnapshotDone: true})
+	if err != nil {
+		return err
+	}
+	if s.Logger != nil {
+		s.Logger.Infof("A follower replicated a snapshot of %d entries at sequence %d", len(snapshot), sequence)
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case entry, ok := <-changes:
+			if !ok {
+				return status.Errorf(codes.Aborted, "%s", errors.E(errors.Op("Replicate"), "the follower fell behind or the storage was restored, replicate again from a new snapshot"))
+			}
+			err = stream.Send(entry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetReplicationStatus tells if the node is a primary that others replicate, a follower of one, or neither,
+// and how far replication has got
+func (s *AdminService) GetReplicationStatus(ctx context.Context, in *pb.Empty) (*pb.ReplicationStatus, error) {
+	if s.ReplicationStatus != nil {
+		if replicationStatus := s.ReplicationStatus(); replicationStatus != nil {
+			return replicationStatus, nil
+		}
+	}
+	storage, ok := s.Storage.(replicator)
+	if !ok {
+		return &pb.ReplicationStatus{Role: RoleStandalone}, nil
+	}
+	return &pb.ReplicationStatus{Role: RolePrimary, Sequence: storage.Sequence(), Followers: uint32(storage.Followers())}, nil
+}
+
+// Promote makes a follower stop replicating and take over as a primary, with the identity and the channels it replicated.
+// It should only be called once the primary is down, or the two nodes would publish under the same peer ID.
+func (s *AdminService) Promote(ctx context.Context, in *pb.Empty) (*pb.ReplicationStatus, error) {
+	if s.OnPromote == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", errors.E(errors.Op("Promote"), errors.Invalid, "the node isn't following a primary"))
+	}
+	err := s.OnPromote(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, status.Errorf(codes.Internal, "%s", errors.E(errors.Op("Promote"), err))
+	}
+	if s.Logger != nil {
+		s.Logger.Info("Promoted to primary")
+	}
+	return s.GetReplicationStatus(ctx, in)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/database/replicated"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replicationStream hands the entries sent by Replicate over to the test
+type replicationStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	entries chan *pb.ReplicationEntry
+}
+
+func (stream *replicationStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *replicationStream) Send(entry *pb.ReplicationEntry) error {
+	stream.entries <- entry
+	return nil
+}
+
+func TestReplicate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	storage := &replicated.Storage{Storage: &inmemory.Storage{Db: make(map[string]string)}}
+	assert.NoError(t, storage.Put(ctx, []byte("order-1"), []byte("first")))
+	admin := &AdminService{}
+	admin.RegisterStorage(storage)
+
+	stream := &replicationStream{ctx: ctx, entries: make(chan *pb.ReplicationEntry, 8)}
+	done := make(chan error)
+	go func() { done <- admin.Replicate(&pb.Empty{}, stream) }()
+
+	// The follower gets its storage emptied, the snapshot and then the changes
+	follower := &inmemory.Storage{Db: map[string]string{"order-stale": "stale"}}
+	for entry := range stream.entries {
+		assert.Equal(t, uint64(1), entry.GetSequence())
+		if entry.GetSnapshotDone() {
+			break
+		}
+		assert.NoError(t, replicated.Apply(ctx, follower, entry))
+	}
+	assert.NoError(t, storage.Put(ctx, []byte("order-2"), []byte("second")))
+	assert.NoError(t, storage.Delete(ctx, []byte("order-1")))
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, replicated.Apply(ctx, follower, <-stream.entries))
+	}
+	assert.Equal(t, map[string]string{"order-2": "second"}, follower.Db)
+
+	replicationStatus, err := admin.GetReplicationStatus(ctx, &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, RolePrimary, replicationStatus.GetRole())
+	assert.Equal(t, uint64(3), replicationStatus.GetSequence())
+	assert.Equal(t, uint32(1), replicationStatus.GetFollowers())
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, 0, storage.Followers())
+}
+
+func TestReplicateWithoutReplication(t *testing.T) {
+	admin := &AdminService{}
+	admin.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	err := admin.Replicate(&pb.Empty{}, &replicationStream{ctx: context.Background()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	replicationStatus, err := admin.GetReplicationStatus(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, RoleStandalone, replicationStatus.GetRole())
+	_, err = admin.Promote(context.Background(), &pb.Empty{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestStandby(t *testing.T) {
+	server := &Server{Admin: &AdminService{}}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return &pb.Empty{}, nil }
+	server.SetStandby(true)
+	_, err := server.authenticateUnary(context.Background(), &pb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/pb.OrderHandler/GetAllOrders"}, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = server.authenticateUnary(context.Background(), &pb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/pb.AdminHandler/Promote"}, handler)
+	assert.NoError(t, err)
+
+	server.SetStandby(false)
+	_, err = server.authenticateUnary(context.Background(), &pb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/pb.OrderHandler/GetAllOrders"}, handler)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// channelOrder is an open order with its creation time parsed for sorting
+type channelOrder struct {
+	order   *pb.Order
+	created time.Time
+}
+
+// getOrdersByChannel reads all open orders, grouped by channel and sorted oldest first
+func (s *OrderService) getOrdersByChannel(ctx context.Context) (map[string][]channelOrder, error) {
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+
+	channels := make(map[string][]channelOrder)
+	for key, value := range data {
+		order := &pb.Order{}
+		err = proto.Unmarshal([]byte(value), order)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		created, err := ptypes.Timestamp(order.GetCreated())
+		if !errors.IsEmpty(err) {
+			created = time.Unix(0, 0)
+		}
+		channelID := key[len(interfaces.OrderPrefix) : len(key)-len(order.GetId())]
+		channels[channelID] = append(channels[channelID], channelOrder{order: order, created: created})
+	}
+
+	for _, orders := range channels {
+		sort.Slice(orders, func(i, j int) bool {
+			return orders[i].created.Before(orders[j].created)
+		})
+	}
+	return channels, nil
+}
+
+// PruneChannels moves open orders that are older than MaxOrderAge, or beyond the newest MaxOrdersPerChannel of their channel,
+// into the order history. Pruning is local to this node and isn't broadcast. Locked orders are kept, as they may be being filled.
+// It returns how many orders were pruned on each channel.
+func (s *OrderService) PruneChannels(now time.Time) (map[string]int, error) {
+	pruned := make(map[string]int)
+	if s.MaxOrderAge == 0 && s.MaxOrdersPerChannel == 0 {
+		return pruned, nil
+	}
+
+	ctx := context.Background()
+	channels, err := s.getOrdersByChannel(ctx)
+	if !errors.IsEmpty(err) {
+		return pruned, errors.E(errors.Op("Get orders for pruning"), err)
+	}
+
+	for channelID, orders := range channels {
+		remaining := uint(len(orders))
+		for _, entry := range orders {
+			tooMany := s.MaxOrdersPerChannel > 0 && remaining > s.MaxOrdersPerChannel
+			tooOld := s.MaxOrderAge > 0 && entry.created.Before(now.Add(-s.MaxOrderAge))
+			if !tooMany && !tooOld {
+				// Orders are sorted oldest first, so the rest are within the policy too
+				break
+			}
+			if entry.order.GetState() == pb.State_LOCKED {
+				continue
+			}
+
+			err = s.deleteOrder(ctx, []byte(channelID), entry.order)
+			if !errors.IsEmpty(err) {
+				return pruned, errors.E(errors.Op("Prune order"), err)
+			}
+			remaining--
+			pruned[channelID]++
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRetentionTestOrders(t *testing.T, retentionService *OrderService, count int) []*pb.OrderSpecificRequest {
+	requests := make([]*pb.OrderSpecificRequest, 0, count)
+	for i := 0; i < count; i++ {
+		resp, err := retentionService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: uint64(i + 1), Price: testPrice})
+		assert.NoError(t, err)
+		requests = append(requests, &pb.OrderSpecificRequest{OrderID: resp.GetCreatedOrder().GetId(), ChannelID: []byte(assetPair)})
+		// Keep the creation times apart so the pruning order is deterministic
+		time.Sleep(time.Millisecond)
+	}
+	return requests
+}
+
+func TestPruneChannelsByCount(t *testing.T) {
+	retentionService := newOwnershipTestService()
+	requests := createRetentionTestOrders(t, retentionService, 4)
+
+	// Without a policy nothing is pruned
+	pruned, err := retentionService.PruneChannels(time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, pruned)
+
+	// The oldest order is locked, so the next oldest ones go instead
+	_, err = retentionService.Lock(context.Background(), requests[0])
+	assert.NoError(t, err)
+	retentionService.MaxOrdersPerChannel = 2
+	pruned, err = retentionService.PruneChannels(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{assetPair: 2}, pruned)
+
+	for i, kept := range []bool{true, false, false, true} {
+		_, err = retentionService.GetOrder(context.Background(), requests[i])
+		assert.Equal(t, kept, err == nil)
+	}
+
+	history, err := retentionService.GetOrderHistory(context.Background(), &pb.OrderHistoryRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Len(t, history.GetOrders(), 2)
+}
+
+func TestPruneChannelsByAge(t *testing.T) {
+	retentionService := newOwnershipTestService()
+	requests := createRetentionTestOrders(t, retentionService, 3)
+	retentionService.MaxOrderAge = time.Hour
+
+	pruned, err := retentionService.PruneChannels(time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, pruned)
+
+	pruned, err = retentionService.PruneChannels(time.Now().Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{assetPair: 3}, pruned)
+	for _, request := range requests {
+		_, err = retentionService.GetOrder(context.Background(), request)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/sprawl/sprawl/errors"
+)
+
+// Role is what the clients of a namespace may do with the API when API keys are configured
+type Role string
+
+const (
+	// RoleReadOnly may only call the Get, Search and Subscribe methods outside of the admin services
+	RoleReadOnly Role = "read-only"
+	// RoleTrader may also create, delete, lock and unlock its own orders, join channels and register assets
+	RoleTrader Role = "trader"
+	// RoleAdmin may call every method, including those of AdminHandler and StorageHandler
+	RoleAdmin Role = "admin"
+)
+
+// readPrefixes are the prefixes of the method names that only read from the node
+var readPrefixes = []string{"Get", "Search", "Subscribe"}
+
+// isReadMethod tells if a full gRPC method name only reads from the node
+func isReadMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// allows tells if the role may call a full gRPC method name
+func (role Role) allows(method string) bool {
+	switch role {
+	case RoleAdmin:
+		return true
+	case RoleTrader:
+		return !isAdminMethod(method)
+	case RoleReadOnly:
+		return !isAdminMethod(method) && isReadMethod(method)
+	}
+	return false
+}
+
+// ParseAPIRoles parses comma separated namespace:role pairs into a map from namespaces to roles
+func ParseAPIRoles(apiRoles string) (map[string]Role, error) {
+	roles := make(map[string]Role)
+	if apiRoles == "" {
+		return roles, nil
+	}
+	for _, pair := range strings.Split(apiRoles, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.E(errors.Op("Parse API roles"), errors.Invalid, "API roles should be namespace:role pairs")
+		}
+		role := Role(parts[1])
+		if role != RoleReadOnly && role != RoleTrader && role != RoleAdmin {
+			return nil, errors.E(errors.Op("Parse API roles"), errors.Invalid, "unknown role "+parts[1]+" of "+parts[0]+", it should be read-only, trader or admin")
+		}
+		if _, ok := roles[parts[0]]; ok {
+			return nil, errors.E(errors.Op("Parse API roles"), errors.Invalid, "role of "+parts[0]+" is given twice")
+		}
+		roles[parts[0]] = role
+	}
+	return roles, nil
+}
+
+// getRole returns the role of a namespace. Namespaces without one are traders, except the admin namespace.
+func (server *Server) getRole(namespace string) Role {
+	if role, ok := server.APIRoles[namespace]; ok {
+		return role
+	}
+	if namespace == AdminNamespace {
+		return RoleAdmin
+	}
+	return RoleTrader
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseAPIRoles(t *testing.T) {
+	roles, err := ParseAPIRoles("")
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+
+	roles, err = ParseAPIRoles("desk1:trader, dashboard:read-only")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Role{"desk1": RoleTrader, "dashboard": RoleReadOnly}, roles)
+
+	_, err = ParseAPIRoles("desk1")
+	assert.Error(t, err)
+	_, err = ParseAPIRoles("desk1:owner")
+	assert.Error(t, err)
+	_, err = ParseAPIRoles("desk1:trader,desk1:admin")
+	assert.Error(t, err)
+}
+
+func TestRoles(t *testing.T) {
+	server := &Server{
+		APIKeys:  map[string]string{"key1": "dashboard", "key2": "desk1", "key3": "ops", "key4": AdminNamespace},
+		APIRoles: map[string]Role{"dashboard": RoleReadOnly, "ops": RoleAdmin},
+	}
+	call := func(key string, method string) codes.Code {
+		_, err := server.authenticate(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+key)), method)
+		return status.Code(err)
+	}
+
+	// Read-only keys can look but not trade
+	assert.Equal(t, codes.OK, call("key1", "/pb.OrderHandler/GetOrderBook"))
+	assert.Equal(t, codes.OK, call("key1", "/pb.OrderHandler/SubscribeOrderBook"))
+	assert.Equal(t, codes.PermissionDenied, call("key1", "/pb.OrderHandler/Create"))
+	assert.Equal(t, codes.PermissionDenied, call("key1", "/pb.AdminHandler/GetDeadLetters"))
+
+	// Namespaces without a role are traders
+	assert.Equal(t, codes.OK, call("key2", "/pb.OrderHandler/Create"))
+	assert.Equal(t, codes.PermissionDenied, call("key2", "/pb.AdminHandler/Backup"))
+
+	// Any namespace can be an admin, and the admin namespace is one by default
+	assert.Equal(t, codes.OK, call("key3", "/pb.AdminHandler/Backup"))
+	assert.Equal(t, codes.OK, call("key4", "/pb.StorageHandler/Dump"))
+}
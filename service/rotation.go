@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+func getTransitionStorageKey(oldID peer.ID) []byte {
+	return []byte(strings.Join([]string{string(interfaces.TransitionPrefix), oldID.String()}, ""))
+}
+
+// getSuccessor returns the peer ID that the peer has rotated its identity to, if it has
+func (s *OrderService) getSuccessor(ctx context.Context, peerID peer.ID) (peer.ID, bool) {
+	data, err := s.Storage.Get(ctx, getTransitionStorageKey(peerID))
+	if !errors.IsEmpty(err) {
+		return "", false
+	}
+	transition := &pb.IdentityTransition{}
+	err = proto.Unmarshal(data, transition)
+	if !errors.IsEmpty(err) {
+		return "", false
+	}
+	_, newID, err := identity.VerifyTransition(transition)
+	if !errors.IsEmpty(err) {
+		return "", false
+	}
+	return newID, true
+}
+
+// isSameIdentity tells if the peer IDs are the same node, either as they are or across a single identity rotation.
+// A rotated node keeps connecting with its old ID until it's restarted, while its orders are already made with the new one.
+func (s *OrderService) isSameIdentity(ctx context.Context, makerID peer.ID, peerID peer.ID) bool {
+	if makerID == peerID {
+		return true
+	}
+	if successor, ok := s.getSuccessor(ctx, peerID); ok && successor == makerID {
+		return true
+	}
+	if successor, ok := s.getSuccessor(ctx, makerID); ok && successor == peerID {
+		return true
+	}
+	return false
+}
+
+// receiveTransition stores an identity transition received from the network.
+// Only the rotating node itself may announce it, with either its old or its new ID.
+func (s *OrderService) receiveTransition(ctx context.Context, data []byte, from peer.ID) error {
+	transition := &pb.IdentityTransition{}
+	err := proto.Unmarshal(data, transition)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal transition proto in Receive"), errors.Malformed, err)
+	}
+	oldID, newID, err := identity.VerifyTransition(transition)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	if from != oldID && from != newID {
+		return errors.E(errors.Op("Check transition sender"), errors.Unauthorized, "transition isn't sent by the rotating peer")
+	}
+	if successor, ok := s.getSuccessor(ctx, oldID); ok && successor == newID {
+		return errors.E(errors.Op("Check for duplicate transition"), errors.Duplicate, "transition has already been received")
+	}
+	err = s.Storage.Put(ctx, getTransitionStorageKey(oldID), data)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	s.predecessors.stored(transition, oldID, newID)
+	return nil
+}
+
+// RotateIdentity replaces this node's key pair and announces the transition, signed with the old key, on all joined channels.
+// The node's open orders are then signed again with the new key and broadcast, so they stay in the order books of other nodes.
+// The p2p host keeps its current ID until the node is restarted.
+func (s *OrderService) RotateIdentity(ctx context.Context, in *pb.Empty) (*pb.IdentityTransition, error) {
+	oldID, _, err := s.getMaker()
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get maker in RotateIdentity"), err)
+	}
+	transition, err := identity.Rotate(s.Storage)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Rotate identity"), err)
+	}
+	transitionInBytes, err := proto.Marshal(transition)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal transition"), err)
+	}
+	err = s.Storage.Put(ctx, getTransitionStorageKey(oldID), transitionInBytes)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Put transition"), err)
+	}
+	if _, newID, err := identity.VerifyTransition(transition); errors.IsEmpty(err) {
+		s.predecessors.stored(transition, oldID, newID)
+	}
+
+	// Other nodes need the transition before they accept the orders signed with the new key
+	channels, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get joined channels for transition"), err)
+	}
+	if s.P2p != nil {
+		for _, value := range channels {
+			channel := &pb.Channel{}
+			if err := proto.Unmarshal([]byte(value), channel); !errors.IsEmpty(err) {
+				continue
+			}
+			err = s.P2p.Send(ctx, &pb.WireMessage{ChannelID: channel.GetId(), Operation: pb.Operation_IDENTITY_TRANSITION, Data: transitionInBytes})
+			if !errors.IsEmpty(err) {
+				return nil, errors.E(errors.Op("Send transition"), err)
+			}
+		}
+	}
+
+	err = s.resignOrders(ctx, oldID)
+	if !errors.IsEmpty(err) {
+		return transition, errors.E(errors.Op("Sign orders with the new identity"), err)
+	}
+	return transition, nil
+}
+
+// resignOrders makes this node's new identity the maker of the open orders made with the old one, and broadcasts them again.
+// Locked and partially filled orders are left as they are, since their fills are signed by the old identity.
+func (s *OrderService) resignOrders(ctx context.Context, oldID peer.ID) error {
+	makerID, makerPubKey, err := s.getMaker()
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.OrderPrefix))
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	for key, value := range data {
+		order := &pb.Order{}
+		err = proto.Unmarshal([]byte(value), order)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		if peer.ID(order.GetMakerPeerID()) != oldID || order.GetState() != pb.State_OPEN {
+			continue
+		}
+
+		order.MakerPeerID = []byte(makerID)
+		order.MakerPubKey = makerPubKey
+		order.Signature, err = s.GetSignature(order)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+		orderInBytes, err := proto.Marshal(order)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+
+		channelID := []byte(key[len(interfaces.OrderPrefix) : len(key)-len(order.GetId())])
+		err = s.putOrder(ctx, channelID, order, orderInBytes)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+		wireMessage := &pb.WireMessage{ChannelID: channelID, Operation: pb.Operation_CREATE, Data: orderInBytes}
+		if s.P2p != nil {
+			err = s.P2p.Send(ctx, wireMessage)
+			if !errors.IsEmpty(err) {
+				return err
+			}
+		}
+		s.notify(wireMessage)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateIdentity(t *testing.T) {
+	makerService := newOwnershipTestService()
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	oldID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	transition, err := makerService.RotateIdentity(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	transitionOldID, newID, err := identity.VerifyTransition(transition)
+	assert.NoError(t, err)
+	assert.Equal(t, oldID, transitionOldID)
+	ownID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+	assert.Equal(t, newID, ownID)
+
+	// The open order is now made and signed by the new identity
+	request := &pb.OrderSpecificRequest{OrderID: order.GetId(), ChannelID: []byte(assetPair)}
+	rotated, err := makerService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	makerID, err := makerService.verifyMaker(rotated)
+	assert.NoError(t, err)
+	assert.Equal(t, newID, makerID)
+
+	// Another node only accepts the re-signed order from the old ID once it has the transition
+	otherService := newOwnershipTestService()
+	orderInBytes, err := proto.Marshal(order)
+	assert.NoError(t, err)
+	err = otherService.Storage.Put(context.Background(), getOrderStorageKey([]byte(assetPair), order.GetId()), orderInBytes)
+	assert.NoError(t, err)
+
+	rotatedInBytes, err := proto.Marshal(rotated)
+	assert.NoError(t, err)
+	createMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_CREATE, Data: rotatedInBytes})
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(errors.Unauthorized, receive(otherService, createMessage, oldID)))
+
+	transitionInBytes, err := proto.Marshal(transition)
+	assert.NoError(t, err)
+	transitionMessage, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_IDENTITY_TRANSITION, Data: transitionInBytes})
+	assert.NoError(t, err)
+	assert.Empty(t, otherService.getPredecessorKeys(context.Background(), newID))
+	strangerID, _ := newStranger(t)
+	assert.True(t, errors.Is(errors.Unauthorized, receive(otherService, transitionMessage, strangerID)))
+	assert.NoError(t, receive(otherService, transitionMessage, oldID))
+	assert.True(t, errors.Is(errors.Duplicate, receive(otherService, transitionMessage, oldID)))
+
+	// The old key is cached as the new identity's predecessor once the transition is stored, and reloaded after a reset
+	assert.Equal(t, [][]byte{transition.GetOldPubKey()}, otherService.getPredecessorKeys(context.Background(), newID))
+	otherService.ResetOrderBook()
+	assert.Equal(t, [][]byte{transition.GetOldPubKey()}, otherService.getPredecessorKeys(context.Background(), newID))
+
+	assert.NoError(t, receive(otherService, createMessage, oldID))
+	stored, err := otherService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(newID), stored.GetMakerPeerID())
+
+	// After a restart the node connects with its new ID, and may still modify orders made with the old one
+	assert.NoError(t, otherService.authorize(context.Background(), []byte(assetPair), order, newID))
+	assert.True(t, errors.Is(errors.Unauthorized, otherService.authorize(context.Background(), []byte(assetPair), order, strangerID)))
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/assets"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const allPairs string = "*"
+
+// Router mirrors orders between joined channels that trade the same asset pair under a different name,
+// like BTC,ETH and ETH,BTC, so that liquidity isn't fragmented by channel naming
+type Router struct {
+	Storage interfaces.Storage
+	pairs   map[string]bool
+}
+
+// NormalizeAssetPair returns the asset pair of a channel ID with its assets in canonical case and sorted order,
+// leaving out the hash of any channel options
+func NormalizeAssetPair(channelID []byte) []byte {
+	assetPair := strings.Split(strings.SplitN(string(channelID), optionsSeparator, 2)[0], ",")
+	for i, asset := range assetPair {
+		assetPair[i] = assets.Canonical(asset)
+	}
+	sort.Strings(assetPair)
+	return []byte(strings.Join(assetPair, ","))
+}
+
+// NewRouter returns a Router that mirrors the given asset pairs, written as BTC/ETH.
+// A pair of "*" mirrors all asset pairs.
+func NewRouter(storage interfaces.Storage, pairs []string) *Router {
+	router := &Router{Storage: storage, pairs: make(map[string]bool)}
+	for _, pair := range pairs {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		if pair != allPairs {
+			pair = string(NormalizeAssetPair([]byte(strings.Replace(pair, "/", ",", -1))))
+		}
+		router.pairs[pair] = true
+	}
+	return router
+}
+
+// Routes tells if orders on the channel are mirrored to its equivalent channels
+func (r *Router) Routes(channelID []byte) bool {
+	return r.pairs[allPairs] || r.pairs[string(NormalizeAssetPair(channelID))]
+}
+
+// GetEquivalentChannels returns the IDs of all other joined channels that trade the same asset pair
+func (r *Router) GetEquivalentChannels(ctx context.Context, channelID []byte) ([][]byte, error) {
+	data, err := r.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get joined channels for routing"), err)
+	}
+
+	normalized := NormalizeAssetPair(channelID)
+	channels := make([][]byte, 0)
+	for _, value := range data {
+		channel := &pb.Channel{}
+		err = proto.Unmarshal([]byte(value), channel)
+		if !errors.IsEmpty(err) {
+			continue
+		}
+		if bytes.Equal(channel.GetId(), channelID) {
+			continue
+		}
+		if bytes.Equal(NormalizeAssetPair(channel.GetId()), normalized) {
+			channels = append(channels, channel.GetId())
+		}
+	}
+	return channels, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const reversedAssetPair string = "ETH,BTC"
+
+func joinRoutedChannels(t *testing.T, routerStorage interfaces.Storage, channelIDs ...string) {
+	for _, channelID := range channelIDs {
+		marshaledChannel, err := proto.Marshal(&pb.Channel{Id: []byte(channelID)})
+		assert.NoError(t, err)
+		err = routerStorage.Put(context.Background(), getChannelStorageKey([]byte(channelID)), marshaledChannel)
+		assert.NoError(t, err)
+	}
+}
+
+func TestNormalizeAssetPair(t *testing.T) {
+	assert.Equal(t, assetPair, string(NormalizeAssetPair([]byte(reversedAssetPair))))
+	assert.Equal(t, assetPair, string(NormalizeAssetPair([]byte(assetPair))))
+	assert.Equal(t, assetPair, string(NormalizeAssetPair([]byte("eth, btc#0123456789abcdef"))))
+}
+
+func TestRouterRoutes(t *testing.T) {
+	router := NewRouter(nil, []string{"ETH/BTC", " DAI/ETH"})
+	assert.True(t, router.Routes([]byte(assetPair)))
+	assert.True(t, router.Routes([]byte(reversedAssetPair)))
+	assert.True(t, router.Routes([]byte("ETH,DAI")))
+	assert.False(t, router.Routes([]byte("BTC,DAI")))
+
+	router = NewRouter(nil, []string{"*"})
+	assert.True(t, router.Routes([]byte("BTC,DAI")))
+
+	router = NewRouter(nil, []string{""})
+	assert.False(t, router.Routes([]byte(assetPair)))
+}
+
+func TestRouterEquivalentChannels(t *testing.T) {
+	routerStorage := &inmemory.Storage{Db: make(map[string]string)}
+	joinRoutedChannels(t, routerStorage, assetPair, reversedAssetPair, "BTC,DAI")
+
+	router := NewRouter(routerStorage, []string{"BTC/ETH"})
+	channels, err := router.GetEquivalentChannels(context.Background(), []byte(assetPair))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(reversedAssetPair)}, channels)
+}
+
+func TestRouterMirrorsOrders(t *testing.T) {
+	routerStorage := &inmemory.Storage{Db: make(map[string]string)}
+	joinRoutedChannels(t, routerStorage, assetPair, reversedAssetPair)
+
+	routedOrderService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	routedOrderService.RegisterStorage(routerStorage)
+	routedOrderService.RegisterRouter(NewRouter(routerStorage, []string{"BTC/ETH"}))
+
+	resp, err := routedOrderService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	orderID := resp.GetCreatedOrder().GetId()
+
+	mirroredOrder, err := routedOrderService.GetOrder(context.Background(), &pb.OrderSpecificRequest{OrderID: orderID, ChannelID: []byte(reversedAssetPair)})
+	assert.NoError(t, err)
+	assert.Equal(t, orderID, mirroredOrder.GetId())
+
+	_, err = routedOrderService.Delete(context.Background(), &pb.OrderSpecificRequest{OrderID: orderID, ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	exists, err := routerStorage.Has(context.Background(), getOrderStorageKey([]byte(reversedAssetPair), orderID))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const defaultSearchLimit uint32 = 100
+
+// Secondary indexes of open orders. Each index entry's key sorts orders of a channel by one field
+// and its value is the ID of the order.
+const priceIndex string = "price-"
+const createdIndex string = "created-"
+const stateIndex string = "state-"
+const makerIndex string = "maker-"
+
+func getIndexQueryPrefix(index string, channelID []byte) []byte {
+	return []byte(strings.Join([]string{string(interfaces.IndexPrefix), index, string(channelID)}, ""))
+}
+
+// getIndexStorageKey returns the key of an order's entry in an index, where value is the indexed field in sortable form
+func getIndexStorageKey(index string, channelID []byte, value []byte, orderID []byte) []byte {
+	return []byte(strings.Join([]string{string(getIndexQueryPrefix(index, channelID)), string(value), string(orderID)}, ""))
+}
+
+// sortablePrice encodes a price so that its bytes sort like the prices themselves
+func sortablePrice(price float32) []byte {
+	bits := math.Float32bits(price)
+	if bits&(1<<31) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 31
+	}
+	encoded := make([]byte, 4)
+	binary.BigEndian.PutUint32(encoded, bits)
+	return encoded
+}
+
+// sortableTime encodes a time so that its bytes sort like the times themselves
+func sortableTime(t time.Time) []byte {
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, uint64(t.UnixNano()))
+	return encoded
+}
+
+// prefixEnd returns the first key after all the keys that start with prefix
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// getIndexStorageKeys returns the keys of all the index entries of an order
+func getIndexStorageKeys(channelID []byte, order *pb.Order) [][]byte {
+	created, err := ptypes.Timestamp(order.GetCreated())
+	if !errors.IsEmpty(err) {
+		created = time.Unix(0, 0)
+	}
+	return [][]byte{
+		getIndexStorageKey(priceIndex, channelID, sortablePrice(order.GetPrice()), order.GetId()),
+		getIndexStorageKey(createdIndex, channelID, sortableTime(created), order.GetId()),
+		getIndexStorageKey(stateIndex, channelID, []byte{byte(order.GetState())}, order.GetId()),
+		getIndexStorageKey(makerIndex, channelID, order.GetMakerPeerID(), order.GetId()),
+	}
+}
+
+// indexOrder adds an order to the secondary indexes of its channel
+func (s *OrderService) indexOrder(ctx context.Context, channelID []byte, order *pb.Order) error {
+	for _, key := range getIndexStorageKeys(channelID, order) {
+		err := s.Storage.Put(ctx, key, order.GetId())
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Put index entry"), err)
+		}
+	}
+	return nil
+}
+
+// unindexOrder removes an order from the secondary indexes of its channel
+func (s *OrderService) unindexOrder(ctx context.Context, channelID []byte, order *pb.Order) error {
+	for _, key := range getIndexStorageKeys(channelID, order) {
+		err := s.Storage.Delete(ctx, key)
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Delete index entry"), err)
+		}
+	}
+	return nil
+}
+
+// searchChannels returns the IDs of the channels to search, which are all joined channels of the
+// requested asset pair, or all joined channels if the request doesn't name a channel or a pair
+func (s *OrderService) searchChannels(ctx context.Context, in *pb.SearchRequest) ([][]byte, error) {
+	if len(in.GetChannelID()) > 0 {
+		return [][]byte{in.GetChannelID()}, nil
+	}
+	data, err := s.Storage.GetAllWithPrefix(ctx, string(interfaces.ChannelPrefix))
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Get channels"), err)
+	}
+	var pair []byte
+	if in.GetAsset() != "" && in.GetCounterAsset() != "" {
+		pair = NormalizeAssetPair([]byte(in.GetAsset() + "," + in.GetCounterAsset()))
+	}
+	channelIDs := make([][]byte, 0, len(data))
+	for _, value := range data {
+		channel := &pb.Channel{}
+		if err := proto.Unmarshal([]byte(value), channel); !errors.IsEmpty(err) {
+			continue
+		}
+		if pair != nil && !bytes.Equal(NormalizeAssetPair(channel.GetId()), pair) {
+			continue
+		}
+		channelIDs = append(channelIDs, channel.GetId())
+	}
+	return channelIDs, nil
+}
+
+// searchCandidates returns the IDs of a channel's orders that may match the search, read from the
+// most selective index the request can use: the maker, the states, the price range and then the creation time.
+// Without any of them every open order of the channel is a candidate.
+func (s *OrderService) searchCandidates(ctx context.Context, channelID []byte, in *pb.SearchRequest) ([][]byte, error) {
+	var entries map[string]string
+	var err error
+	switch {
+	case len(in.GetMakerPeerID()) > 0:
+		entries, err = s.Storage.GetAllWithPrefix(ctx, string(getIndexQueryPrefix(makerIndex, channelID))+string(in.GetMakerPeerID()))
+	case len(in.GetStates()) > 0:
+		entries = make(map[string]string)
+		for _, state := range in.GetStates() {
+			stateEntries, err := s.Storage.GetAllWithPrefix(ctx, string(getIndexQueryPrefix(stateIndex, channelID))+string([]byte{byte(state)}))
+			if !errors.IsEmpty(err) {
+				return nil, err
+			}
+			for key, value := range stateEntries {
+				entries[key] = value
+			}
+		}
+	case in.GetMinPrice() != 0 || in.GetMaxPrice() != 0:
+		prefix := getIndexQueryPrefix(priceIndex, channelID)
+		start, end := prefix, prefixEnd(prefix)
+		if in.GetMinPrice() != 0 {
+			start = append(append([]byte{}, prefix...), sortablePrice(in.GetMinPrice())...)
+		}
+		if in.GetMaxPrice() != 0 {
+			end = prefixEnd(append(append([]byte{}, prefix...), sortablePrice(in.GetMaxPrice())...))
+		}
+		entries, err = s.Storage.GetRange(ctx, start, end)
+	case in.GetCreatedSince() != nil:
+		since, err := ptypes.Timestamp(in.GetCreatedSince())
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Parse created since"), err)
+		}
+		prefix := getIndexQueryPrefix(createdIndex, channelID)
+		entries, err = s.Storage.GetRange(ctx, append(append([]byte{}, prefix...), sortableTime(since)...), prefixEnd(prefix))
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+	default:
+		orders, err := s.book.get(ctx, channelID)
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		orderIDs := make([][]byte, 0, len(orders))
+		for _, order := range orders {
+			orderIDs = append(orderIDs, order.GetId())
+		}
+		return orderIDs, nil
+	}
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	orderIDs := make([][]byte, 0, len(entries))
+	for _, value := range entries {
+		orderIDs = append(orderIDs, []byte(value))
+	}
+	return orderIDs, nil
+}
+
+// searchMatches tells if an order passes every filter of the search
+func searchMatches(in *pb.SearchRequest, order *pb.Order, since time.Time) bool {
+	filters := &pb.Subscription{Asset: in.GetAsset(), CounterAsset: in.GetCounterAsset(), MinPrice: in.GetMinPrice(), MaxPrice: in.GetMaxPrice(), Side: in.GetSide()}
+	if !orderMatches(filters, order) {
+		return false
+	}
+	if len(in.GetMakerPeerID()) > 0 && !bytes.Equal(order.GetMakerPeerID(), in.GetMakerPeerID()) {
+		return false
+	}
+	if len(in.GetStates()) > 0 {
+		found := false
+		for _, state := range in.GetStates() {
+			found = found || order.GetState() == state
+		}
+		if !found {
+			return false
+		}
+	}
+	return since.IsZero() || inTimeRange(order, since, time.Time{})
+}
+
+// Search finds the open orders that match all the given filters, sorted by price and then creation time.
+// Orders are looked up through secondary indexes, so only the orders that may match are read.
+func (s *OrderService) Search(ctx context.Context, in *pb.SearchRequest) (*pb.OrderList, error) {
+	if s.book == nil {
+		return nil, errors.E(errors.Op("Search orders"), "storage not registered with OrderService")
+	}
+	var since time.Time
+	if in.GetCreatedSince() != nil {
+		var err error
+		since, err = ptypes.Timestamp(in.GetCreatedSince())
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Parse created since"), err)
+		}
+	}
+	limit := in.GetLimit()
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	channelIDs, err := s.searchChannels(ctx, in)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Search orders"), err)
+	}
+	found := make(map[string]bool)
+	orders := make([]*pb.Order, 0)
+	for _, channelID := range channelIDs {
+		orderIDs, err := s.searchCandidates(ctx, channelID, in)
+		if !errors.IsEmpty(err) {
+			return nil, errors.E(errors.Op("Search orders"), err)
+		}
+		for _, orderID := range orderIDs {
+			// Orders mirrored to equivalent channels are only listed once
+			if found[string(orderID)] {
+				continue
+			}
+			order, err := s.getOrder(ctx, channelID, orderID)
+			if !errors.IsEmpty(err) || !bytes.Equal(order.GetId(), orderID) || !searchMatches(in, order, since) {
+				continue
+			}
+			found[string(orderID)] = true
+			orders = append(orders, order)
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool { return orderLess(orders[i], orders[j]) })
+	if uint32(len(orders)) > limit {
+		orders = orders[:limit]
+	}
+	return &pb.OrderList{Orders: orders}, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	searchService := newOwnershipTestService()
+	ctx := context.Background()
+	prices := []float32{1, 2, 3, 4}
+	orders := make([]*pb.Order, 0, len(prices))
+	for _, price := range prices {
+		resp, err := searchService.Create(ctx, &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: price})
+		assert.NoError(t, err)
+		orders = append(orders, resp.GetCreatedOrder())
+	}
+
+	result, err := searchService.Search(ctx, &pb.SearchRequest{ChannelID: []byte(assetPair), MinPrice: 2, MaxPrice: 3})
+	assert.NoError(t, err)
+	assert.Len(t, result.GetOrders(), 2)
+	assert.Equal(t, orders[1].GetId(), result.GetOrders()[0].GetId())
+	assert.Equal(t, orders[2].GetId(), result.GetOrders()[1].GetId())
+
+	makerID, _, err := searchService.getMaker()
+	assert.NoError(t, err)
+	result, err = searchService.Search(ctx, &pb.SearchRequest{ChannelID: []byte(assetPair), MakerPeerID: []byte(makerID), Limit: 3})
+	assert.NoError(t, err)
+	assert.Len(t, result.GetOrders(), 3)
+
+	result, err = searchService.Search(ctx, &pb.SearchRequest{ChannelID: []byte(assetPair), MakerPeerID: []byte("stranger")})
+	assert.NoError(t, err)
+	assert.Empty(t, result.GetOrders())
+
+	_, err = searchService.Lock(ctx, &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: orders[0].GetId()})
+	assert.NoError(t, err)
+	result, err = searchService.Search(ctx, &pb.SearchRequest{ChannelID: []byte(assetPair), States: []pb.State{pb.State_LOCKED}})
+	assert.NoError(t, err)
+	assert.Len(t, result.GetOrders(), 1)
+	assert.Equal(t, orders[0].GetId(), result.GetOrders()[0].GetId())
+
+	_, err = searchService.Delete(ctx, &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: orders[3].GetId()})
+	assert.NoError(t, err)
+	result, err = searchService.Search(ctx, &pb.SearchRequest{ChannelID: []byte(assetPair), MinPrice: 4})
+	assert.NoError(t, err)
+	assert.Empty(t, result.GetOrders())
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SelfTest checks the node's config, storage, identity and network, and reports what fails with a hint on how to fix it.
+// The network checks wait for peers, so it can take up to a minute.
+func (s *AdminService) SelfTest(ctx context.Context, in *pb.Empty) (*pb.SelfTestReport, error) {
+	if s.Doctor == nil {
+		return nil, status.Errorf(codes.Unimplemented, "%s", errors.E(errors.Op("Self-test"), "the node has no self-test"))
+	}
+	return s.Doctor(ctx), nil
+}
@@ -3,24 +3,68 @@ package service
 import (
 	fmt "fmt"
 	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
 	"github.com/sprawl/sprawl/util"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
-// Server contains services for both Orders and Channels
+// Server contains services for Orders, Channels and administration
 type Server struct {
 	Orders   *OrderService
 	Channels *ChannelService
-	Logger   interfaces.Logger
-	grpc     *grpc.Server
+	Node     *NodeService
+	Admin    *AdminService
+	Assets   *AssetService
+	Storage  *StorageService
+	// MarketData aggregates trades into candles. Its Intervals are empty until they're set.
+	MarketData *MarketDataService
+	Logger     interfaces.Logger
+	// EnableReflection registers the gRPC server reflection service on Run
+	EnableReflection bool
+	// APIKeys maps the keys clients authenticate with to their namespaces. Calls aren't authenticated if it's empty.
+	APIKeys map[string]string
+	// APIRoles maps namespaces to their roles. Namespaces without a role are traders, except AdminNamespace.
+	APIRoles map[string]Role
+	// WebPort serves the API over gRPC-Web for browsers on Run. 0 disables it.
+	WebPort uint
+	// WebOrigins are the origins of the pages that may call the gRPC-Web API. Cross-origin calls are denied if it's empty.
+	WebOrigins []string
+	// MarketAPIPort serves the read-only market data API over HTTP, without authentication, on Run. 0 disables it.
+	MarketAPIPort uint
+	// MarketAPIMaxAge is how long clients and CDNs may cache the market data API's responses. 0 caches them for 5 seconds.
+	MarketAPIMaxAge time.Duration
+	// DashboardPort serves the operator dashboard over HTTP on Run. 0 disables it.
+	DashboardPort uint
+	// Keepalive sets when the server pings idle clients and how long connections are kept. Zero fields keep gRPC's defaults.
+	Keepalive keepalive.ServerParameters
+	// KeepalivePolicy sets how often clients may ping the server without being disconnected
+	KeepalivePolicy keepalive.EnforcementPolicy
+	// MaxRecvMessageSize is the largest message in bytes that clients may send. 0 keeps gRPC's default.
+	MaxRecvMessageSize int
+	// MaxSendMessageSize is the largest message in bytes that the server sends. 0 keeps gRPC's default.
+	MaxSendMessageSize int
+	// MaxConcurrentStreams is how many calls each client connection may have open at once. 0 keeps gRPC's default.
+	MaxConcurrentStreams uint32
+	// standby is 1 while the node follows a primary, and only the admin services are served
+	standby   int32
+	grpc      *grpc.Server
+	web       *http.Server
+	marketAPI *http.Server
+	dashboard *http.Server
 }
 
 // NewServer returns a server that has connections to p2p and storage
-func NewServer(log interfaces.Logger, storage interfaces.Storage, p2p interfaces.P2p,  websocket interfaces.WebsocketService) *Server {
+func NewServer(log interfaces.Logger, storage interfaces.Storage, p2p interfaces.P2p, websocket interfaces.WebsocketService) *Server {
 	server := &Server{}
 	if log != nil {
 		server.Logger = log
@@ -39,6 +83,36 @@ func NewServer(log interfaces.Logger, storage interfaces.Storage, p2p interfaces
 	server.Channels.RegisterStorage(storage)
 	server.Channels.RegisterP2p(p2p)
 
+	// Channels are active while orders or messages arrive on them
+	activity := newChannelActivity()
+	server.Channels.activity = activity
+	server.Orders.activity = activity
+
+	// Create a NodeService for peer operations
+	server.Node = &NodeService{Orders: server.Orders}
+	server.Node.RegisterP2p(p2p)
+
+	// Create an AdminService for storage backups
+	server.Admin = &AdminService{Logger: log}
+	server.Admin.RegisterStorage(storage)
+	server.Admin.OnRestore = server.Orders.ResetOrderBook
+	server.Admin.Receiver = server.Orders
+	server.Node.Admin = server.Admin
+
+	// Create an AssetService for the asset registry
+	server.Assets = &AssetService{}
+	server.Assets.RegisterStorage(storage)
+
+	// Create a StorageService for inspecting the raw storage
+	server.Storage = &StorageService{}
+	server.Storage.RegisterStorage(storage)
+
+	// Create a MarketDataService that aggregates the fills of orders into candles
+	server.MarketData = &MarketDataService{}
+	server.MarketData.RegisterStorage(storage)
+	server.MarketData.RegisterWebsocket(websocket)
+	server.Orders.RegisterMarketData(server.MarketData)
+
 	return server
 }
 
@@ -49,19 +123,78 @@ func (server *Server) Run(port uint) {
 		server.Logger.Fatal(errors.E(errors.Op("Listen"), err))
 	}
 
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(server.authenticateUnary),
+		grpc.StreamInterceptor(server.authenticateStream),
+		grpc.KeepaliveParams(server.Keepalive),
+		grpc.KeepaliveEnforcementPolicy(server.KeepalivePolicy),
+	}
+	if server.MaxRecvMessageSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(server.MaxRecvMessageSize))
+	}
+	if server.MaxSendMessageSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(server.MaxSendMessageSize))
+	}
+	if server.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(server.MaxConcurrentStreams))
+	}
 	server.grpc = grpc.NewServer(opts...)
 
 	// Register the Services with the RPC server
 	pb.RegisterOrderHandlerServer(server.grpc, server.Orders)
 	pb.RegisterChannelHandlerServer(server.grpc, server.Channels)
+	pb.RegisterNodeHandlerServer(server.grpc, server.Node)
+	pb.RegisterAdminHandlerServer(server.grpc, server.Admin)
+	pb.RegisterAssetHandlerServer(server.grpc, server.Assets)
+	pb.RegisterStorageHandlerServer(server.grpc, server.Storage)
+	pb.RegisterMarketDataHandlerServer(server.grpc, server.MarketData)
+
+	if server.EnableReflection {
+		reflection.Register(server.grpc)
+	}
+
+	if server.WebPort > 0 {
+		server.web = server.newWeb()
+		go server.runWeb()
+	}
+	if server.MarketAPIPort > 0 {
+		server.marketAPI = server.newMarketAPI()
+		go server.runMarketAPI()
+	}
+	if server.DashboardPort > 0 {
+		server.dashboard = server.newDashboard()
+		go server.runDashboard()
+	}
 
 	// Run the server
 	server.grpc.Serve(lis)
 }
 
+// SetStandby makes the server refuse all but the admin services while the node follows a primary, or serve everything again
+func (server *Server) SetStandby(standby bool) {
+	var value int32
+	if standby {
+		value = 1
+	}
+	atomic.StoreInt32(&server.standby, value)
+}
+
+// checkStandby refuses the calls to non-admin methods while the node is on standby
+func (server *Server) checkStandby(method string) error {
+	if atomic.LoadInt32(&server.standby) == 1 && !isAdminMethod(method) {
+		return status.Errorf(codes.Unavailable, "%s", errors.E(errors.Op("Serve "+method), "the node is a standby following a primary, promote it with AdminHandler.Promote to serve the API"))
+	}
+	return nil
+}
+
 // Close gracefully shuts down the gRPC server
 func (server *Server) Close() {
 	server.Logger.Debug("gRPC API shutting down")
-	server.grpc.GracefulStop()
+	// The gRPC server only exists once Run has been called
+	server.closeWeb()
+	server.closeMarketAPI()
+	server.closeDashboard()
+	if server.grpc != nil {
+		server.grpc.GracefulStop()
+	}
 }
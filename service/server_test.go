@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"strconv"
 	"testing"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/sprawl/sprawl/util"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
 )
 
 const serverTestKey string = "serverTestKey"
@@ -33,13 +37,13 @@ func TestServerCreation(t *testing.T) {
 
 	var err error
 
-	err = server.Orders.Storage.Put([]byte(serverTestKey), []byte(serverTestEntry))
+	err = server.Orders.Storage.Put(context.Background(), []byte(serverTestKey), []byte(serverTestEntry))
 	assert.NoError(t, err)
-	server.Orders.Storage.DeleteAll()
+	server.Orders.Storage.DeleteAll(context.Background())
 
-	err = server.Channels.Storage.Put([]byte(serverTestKey), []byte(serverTestEntry))
+	err = server.Channels.Storage.Put(context.Background(), []byte(serverTestKey), []byte(serverTestEntry))
 	assert.NoError(t, err)
-	server.Channels.Storage.DeleteAll()
+	server.Channels.Storage.DeleteAll(context.Background())
 }
 func TestServerRun(t *testing.T) {
 	p2pInstance.Run()
@@ -58,7 +62,109 @@ func TestServerRun(t *testing.T) {
 	defer conn.Close()
 
 	client := pb.NewOrderHandlerClient(conn)
-	resp, err := client.GetAllOrders(context.Background(), &pb.Empty{})
+	resp, err := client.GetAllOrders(context.Background(), &pb.OrderListRequest{})
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 }
+
+func TestServerMaxRecvMessageSize(t *testing.T) {
+	p2pInstance.Run()
+	storage.Run()
+	defer storage.Close()
+	defer p2pInstance.Close()
+
+	server := NewServer(log, storage, p2pInstance, nil)
+	server.MaxRecvMessageSize = 64
+	port, err := strconv.ParseUint(apiPort, 10, 64)
+	assert.NoError(t, err)
+	go server.Run(uint(port))
+	defer server.Close()
+
+	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewOrderHandlerClient(conn)
+	_, err = client.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	_, err = client.Create(context.Background(), &pb.CreateRequest{ChannelID: make([]byte, 128), Asset: asset1, CounterAsset: asset2})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestServerBackupRestore(t *testing.T) {
+	p2pInstance.Run()
+	storage.Run()
+	defer storage.Close()
+	defer p2pInstance.Close()
+	storage.DeleteAll(context.Background())
+
+	server := NewServer(log, storage, p2pInstance, nil)
+	port, err := strconv.ParseUint(apiPort, 10, 64)
+	assert.NoError(t, err)
+	go server.Run(uint(port))
+	defer server.Close()
+
+	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	err = storage.Put(context.Background(), []byte(serverTestKey), []byte(serverTestEntry))
+	assert.NoError(t, err)
+
+	client := pb.NewAdminHandlerClient(conn)
+	backupStream, err := client.Backup(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	var chunks []*pb.BackupChunk
+	for {
+		chunk, err := backupStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	assert.NotEmpty(t, chunks)
+
+	storage.DeleteAll(context.Background())
+
+	restoreStream, err := client.Restore(context.Background())
+	assert.NoError(t, err)
+	for _, chunk := range chunks {
+		assert.NoError(t, restoreStream.Send(chunk))
+	}
+	_, err = restoreStream.CloseAndRecv()
+	assert.NoError(t, err)
+
+	restored, err := storage.Get(context.Background(), []byte(serverTestKey))
+	assert.NoError(t, err)
+	assert.Equal(t, serverTestEntry, string(restored))
+	storage.DeleteAll(context.Background())
+}
+
+func TestServerReflection(t *testing.T) {
+	storage.Run()
+	defer storage.Close()
+
+	server := NewServer(log, storage, nil, nil)
+	server.EnableReflection = true
+	go server.Run(uint(1339))
+	defer server.Close()
+
+	conn, err := grpc.Dial("localhost:1339", grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	client := rpb.NewServerReflectionClient(conn)
+	stream, err := client.ServerReflectionInfo(context.Background())
+	assert.NoError(t, err)
+	err = stream.Send(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_ListServices{}})
+	assert.NoError(t, err)
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+
+	services := make([]string, 0)
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, "pb.OrderHandler")
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ptypes "github.com/golang/protobuf/ptypes"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/settlement"
+)
+
+// RegisterSettlement registers the engine that settles the fills reported with ReportFill.
+// Engines that keep their trades in storage get the service's storage, and engines that learn the outcome
+// later report it back through recordSettlement.
+func (s *OrderService) RegisterSettlement(engine settlement.Engine) {
+	s.settlement = engine
+	if storer, ok := engine.(settlement.Storer); ok && s.Storage != nil {
+		storer.RegisterStorage(s.Storage)
+	}
+	if watcher, ok := engine.(settlement.Watcher); ok {
+		watcher.Watch(s.recordSettlement)
+	}
+}
+
+// getSettlement returns the registered settlement engine, or the no-op engine if none is registered
+func (s *OrderService) getSettlement() settlement.Engine {
+	if s.settlement == nil {
+		return settlement.Noop{}
+	}
+	return s.settlement
+}
+
+// abortSettlement tells the settlement engine that a prepared trade won't go through
+func (s *OrderService) abortSettlement(ctx context.Context, trade *settlement.Trade, reason error) {
+	err := s.getSettlement().Abort(ctx, trade, reason)
+	if !errors.IsEmpty(err) {
+		s.Logger.Error(errors.E(errors.Op("Abort settlement"), err))
+	}
+}
+
+// recordSettlement moves a filled order in the order history to SETTLED or ABORTED once its settlement is known.
+// Orders that are only partially filled stay open, so the outcome of their fills is only logged.
+func (s *OrderService) recordSettlement(ctx context.Context, trade *settlement.Trade, state pb.State) {
+	err := s.setSettlementState(ctx, trade, state)
+	if !errors.IsEmpty(err) {
+		s.Logger.Error(errors.E(errors.Op("Record settlement"), err))
+	}
+}
+
+func (s *OrderService) setSettlementState(ctx context.Context, trade *settlement.Trade, state pb.State) error {
+	if state != pb.State_SETTLED && state != pb.State_ABORTED {
+		return errors.E(errors.Op("Check settlement state"), errors.Invalid, "settlement can only end as SETTLED or ABORTED")
+	}
+	if trade.Order.GetState() != pb.State_FILLED {
+		s.Logger.Infof("Fill of order %x on channel %s is %s", trade.Order.GetId(), trade.ChannelID, state)
+		return nil
+	}
+
+	created, err := ptypes.Timestamp(trade.Order.GetCreated())
+	if !errors.IsEmpty(err) {
+		created = time.Unix(0, 0)
+	}
+	key := getHistoryStorageKey(trade.ChannelID, created, trade.Order.GetId())
+	data, err := s.Storage.Get(ctx, key)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Get filled order from history"), err)
+	}
+	order := &pb.Order{}
+	err = proto.Unmarshal(data, order)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Unmarshal filled order"), err)
+	}
+	if order.GetState() != pb.State_FILLED {
+		return errors.E(errors.Op("Check filled order"), errors.Invalid, "settlement of the order has already been recorded")
+	}
+
+	order.State = state
+	data, err = proto.Marshal(order)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Marshal settled order"), err)
+	}
+	err = s.Storage.PutWithTTL(ctx, key, data, s.historyTTL(order.GetDeletedAt()))
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Put settled order to history"), err)
+	}
+	s.Logger.Infof("Order %x on channel %s is %s", order.GetId(), trade.ChannelID, state)
+	return nil
+}
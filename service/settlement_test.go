@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/identity"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/settlement"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingEngine records the settlement steps it's called with and fails Execute on demand
+type recordingEngine struct {
+	steps       []string
+	failExecute bool
+}
+
+func (e *recordingEngine) Prepare(ctx context.Context, trade *settlement.Trade) error {
+	e.steps = append(e.steps, "prepare")
+	return nil
+}
+
+func (e *recordingEngine) Execute(ctx context.Context, trade *settlement.Trade) error {
+	e.steps = append(e.steps, "execute")
+	if e.failExecute {
+		return errors.E(errors.Op("Execute"), "payment rail is down")
+	}
+	return nil
+}
+
+func (e *recordingEngine) Confirm(ctx context.Context, trade *settlement.Trade) error {
+	e.steps = append(e.steps, "confirm")
+	return nil
+}
+
+func (e *recordingEngine) Abort(ctx context.Context, trade *settlement.Trade, reason error) error {
+	e.steps = append(e.steps, "abort")
+	return nil
+}
+
+func TestSettlement(t *testing.T) {
+	makerService := newOwnershipTestService()
+	engine := &recordingEngine{failExecute: true}
+	makerService.RegisterSettlement(engine)
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 100, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+	request := &pb.OrderSpecificRequest{ChannelID: []byte(assetPair), OrderID: order.GetId()}
+
+	takerKey, _, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	fill := &pb.Fill{OrderID: order.GetId(), Amount: 40, Nonce: order.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, fill))
+
+	// A fill that can't be settled isn't recorded
+	_, err = makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: fill})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"prepare", "execute", "abort"}, engine.steps)
+	stored, err := makerService.GetOrder(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), stored.GetFilledAmount())
+
+	engine.steps = nil
+	engine.failExecute = false
+	fill = &pb.Fill{OrderID: order.GetId(), Amount: 40, Nonce: order.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, fill))
+	partial, err := makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: fill})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(40), partial.GetFilledAmount())
+	assert.Equal(t, []string{"prepare", "execute", "confirm"}, engine.steps)
+}
+
+// watchingEngine is a recordingEngine that reports the outcome of trades later, like a chain would
+type watchingEngine struct {
+	recordingEngine
+	report settlement.Report
+}
+
+func (e *watchingEngine) Watch(report settlement.Report) {
+	e.report = report
+}
+
+func TestRecordSettlement(t *testing.T) {
+	makerService := newOwnershipTestService()
+	engine := &watchingEngine{}
+	makerService.RegisterSettlement(engine)
+	assert.NotNil(t, engine.report)
+	resp, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 100, Price: testPrice})
+	assert.NoError(t, err)
+	order := resp.GetCreatedOrder()
+
+	takerKey, _, err := identity.GenerateKeyPair(rand.Reader)
+	assert.NoError(t, err)
+	fill := &pb.Fill{OrderID: order.GetId(), Amount: 100, Nonce: order.GetNonce()}
+	assert.NoError(t, SignFill(takerKey, fill))
+	filled, err := makerService.ReportFill(context.Background(), &pb.FillRequest{ChannelID: []byte(assetPair), Fill: fill})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.State_FILLED, filled.GetState())
+
+	trade := &settlement.Trade{ChannelID: []byte(assetPair), Order: filled, Fill: fill}
+	engine.report(context.Background(), trade, pb.State_SETTLED)
+	history, err := makerService.GetOrderHistory(context.Background(), &pb.OrderHistoryRequest{ChannelID: []byte(assetPair)})
+	assert.NoError(t, err)
+	assert.Len(t, history.GetOrders(), 1)
+	assert.Equal(t, pb.State_SETTLED, history.GetOrders()[0].GetState())
+
+	// The outcome of a trade is only recorded once
+	err = makerService.setSettlementState(context.Background(), trade, pb.State_ABORTED)
+	assert.True(t, errors.Is(errors.Invalid, err))
+	err = makerService.setSettlementState(context.Background(), trade, pb.State_OPEN)
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// StorageService is a gRPC service for inspecting the raw contents of the storage while the node is running
+type StorageService struct {
+	Storage interfaces.Storage
+}
+
+// RegisterStorage registers the storage service that is inspected
+func (s *StorageService) RegisterStorage(storage interfaces.Storage) {
+	s.Storage = storage
+}
+
+// sortedKeys returns the keys of storage entries in order
+func sortedKeys(entries map[string]string) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getKeyPrefix returns the prefix of a key up to and including its first dash, which is how all prefixes in Storage end
+func getKeyPrefix(key string) string {
+	if i := strings.Index(key, "-"); i >= 0 {
+		return key[:i+1]
+	}
+	return ""
+}
+
+// List returns the keys with the given prefix and the sizes of their values, up to limit keys if it isn't 0
+func (s *StorageService) List(ctx context.Context, in *pb.StorageListRequest) (*pb.StorageKeyList, error) {
+	entries, err := s.Storage.GetAllWithPrefix(ctx, in.GetPrefix())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("List storage"), err)
+	}
+	list := &pb.StorageKeyList{}
+	for _, key := range sortedKeys(entries) {
+		if in.GetLimit() > 0 && len(list.Keys) == int(in.GetLimit()) {
+			list.Truncated = true
+			break
+		}
+		list.Keys = append(list.Keys, &pb.StorageKey{Key: []byte(key), Size: uint64(len(entries[key]))})
+	}
+	return list, nil
+}
+
+// Dump streams the keys and values with the given prefix to the client in order
+func (s *StorageService) Dump(in *pb.StorageDumpRequest, stream pb.StorageHandler_DumpServer) error {
+	entries, err := s.Storage.GetAllWithPrefix(stream.Context(), in.GetPrefix())
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Dump storage"), err)
+	}
+	for _, key := range sortedKeys(entries) {
+		err = stream.Send(&pb.StorageEntry{Key: []byte(key), Value: []byte(entries[key])})
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Send storage entry"), err)
+		}
+	}
+	return nil
+}
+
+// Stat counts the keys and the approximate size of the keys and values under each prefix in Storage
+func (s *StorageService) Stat(ctx context.Context, in *pb.Empty) (*pb.StorageStat, error) {
+	entries, err := s.Storage.GetAll(ctx)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Stat storage"), err)
+	}
+	stat := &pb.StorageStat{}
+	prefixes := make(map[string]*pb.StoragePrefixStat)
+	for key, value := range entries {
+		prefix := getKeyPrefix(key)
+		prefixStat, ok := prefixes[prefix]
+		if !ok {
+			prefixStat = &pb.StoragePrefixStat{Prefix: prefix}
+			prefixes[prefix] = prefixStat
+			stat.Prefixes = append(stat.Prefixes, prefixStat)
+		}
+		size := uint64(len(key) + len(value))
+		prefixStat.Keys++
+		prefixStat.Size += size
+		stat.Keys++
+		stat.Size += size
+	}
+	sort.Slice(stat.Prefixes, func(i, j int) bool { return stat.Prefixes[i].GetPrefix() < stat.Prefixes[j].GetPrefix() })
+	return stat, nil
+}
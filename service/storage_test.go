@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// dumpStream collects the entries sent by Dump
+type dumpStream struct {
+	grpc.ServerStream
+	entries []*pb.StorageEntry
+}
+
+func (stream *dumpStream) Context() context.Context {
+	return context.Background()
+}
+
+func (stream *dumpStream) Send(entry *pb.StorageEntry) error {
+	stream.entries = append(stream.entries, entry)
+	return nil
+}
+
+func newStorageTestService() *StorageService {
+	storageService := &StorageService{}
+	storageService.RegisterStorage(&inmemory.Storage{Db: map[string]string{
+		"order-b":   "22",
+		"order-a":   "1",
+		"channel-a": "333",
+		"identity":  "4444",
+	}})
+	return storageService
+}
+
+func TestStorageList(t *testing.T) {
+	storageService := newStorageTestService()
+
+	list, err := storageService.List(context.Background(), &pb.StorageListRequest{Prefix: "order-"})
+	assert.NoError(t, err)
+	assert.False(t, list.GetTruncated())
+	assert.Len(t, list.GetKeys(), 2)
+	assert.Equal(t, []byte("order-a"), list.GetKeys()[0].GetKey())
+	assert.Equal(t, uint64(1), list.GetKeys()[0].GetSize())
+
+	list, err = storageService.List(context.Background(), &pb.StorageListRequest{Limit: 3})
+	assert.NoError(t, err)
+	assert.True(t, list.GetTruncated())
+	assert.Len(t, list.GetKeys(), 3)
+}
+
+func TestStorageDump(t *testing.T) {
+	storageService := newStorageTestService()
+	stream := &dumpStream{}
+
+	err := storageService.Dump(&pb.StorageDumpRequest{Prefix: "order-"}, stream)
+	assert.NoError(t, err)
+	assert.Len(t, stream.entries, 2)
+	assert.Equal(t, []byte("order-b"), stream.entries[1].GetKey())
+	assert.Equal(t, []byte("22"), stream.entries[1].GetValue())
+}
+
+func TestStorageStat(t *testing.T) {
+	storageService := newStorageTestService()
+
+	stat, err := storageService.Stat(context.Background(), &pb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), stat.GetKeys())
+	assert.Equal(t, uint64(7+2+7+1+9+3+8+4), stat.GetSize())
+	assert.Len(t, stat.GetPrefixes(), 3)
+	assert.Equal(t, "", stat.GetPrefixes()[0].GetPrefix())
+	assert.Equal(t, "channel-", stat.GetPrefixes()[1].GetPrefix())
+	assert.Equal(t, "order-", stat.GetPrefixes()[2].GetPrefix())
+	assert.Equal(t, uint64(2), stat.GetPrefixes()[2].GetKeys())
+	assert.Equal(t, uint64(7+2+7+1), stat.GetPrefixes()[2].GetSize())
+}
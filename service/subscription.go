@@ -0,0 +1,91 @@
+package service
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// subscribe replaces the connection's filters with the pb.Subscription the client sent.
+// An empty subscription removes all filters.
+func (ws *WebsocketService) subscribe(conn *websocket.Conn, data []byte) {
+	subscription := &pb.Subscription{}
+	err := proto.Unmarshal(data, subscription)
+	if !errors.IsEmpty(err) {
+		if ws.Logger != nil {
+			ws.Logger.Debugf("Invalid websocket subscription from %s: %s", conn.RemoteAddr(), err)
+		}
+		return
+	}
+
+	ws.connLock.Lock()
+	defer ws.connLock.Unlock()
+	if ws.subscriptions == nil {
+		ws.subscriptions = make(map[*websocket.Conn]*pb.Subscription)
+	}
+	if proto.Equal(subscription, &pb.Subscription{}) {
+		delete(ws.subscriptions, conn)
+	} else {
+		ws.subscriptions[conn] = subscription
+	}
+}
+
+// subscribedOrder returns the order of an order operation, which subscriptions filter on.
+// Other messages have no order.
+func subscribedOrder(message *pb.WireMessage) *pb.Order {
+	switch message.GetOperation() {
+	case pb.Operation_CREATE, pb.Operation_DELETE, pb.Operation_LOCK, pb.Operation_UNLOCK, pb.Operation_FILL:
+	default:
+		return nil
+	}
+
+	order := &pb.Order{}
+	err := proto.Unmarshal(message.GetData(), order)
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	return order
+}
+
+// subscriptionMatches tells if the message with the given order should be pushed to a client with the given subscription.
+// Clients without a subscription get everything, others only get order operations that pass the filters.
+func subscriptionMatches(subscription *pb.Subscription, order *pb.Order) bool {
+	if subscription == nil {
+		return true
+	}
+	if order == nil {
+		return false
+	}
+	return orderMatches(subscription, order)
+}
+
+// orderMatches checks the order against the asset pair, price range and side of the subscription.
+// Zero values aren't checked. The side is relative to the subscription's asset, so it's only checked when the asset is set.
+func orderMatches(subscription *pb.Subscription, order *pb.Order) bool {
+	asset := subscription.GetAsset()
+	counterAsset := subscription.GetCounterAsset()
+	if asset != "" && order.GetAsset() != asset && order.GetCounterAsset() != asset {
+		return false
+	}
+	if counterAsset != "" && order.GetAsset() != counterAsset && order.GetCounterAsset() != counterAsset {
+		return false
+	}
+
+	if subscription.GetMinPrice() != 0 && order.GetPrice() < subscription.GetMinPrice() {
+		return false
+	}
+	if subscription.GetMaxPrice() != 0 && order.GetPrice() > subscription.GetMaxPrice() {
+		return false
+	}
+
+	if asset != "" {
+		switch subscription.GetSide() {
+		case pb.Side_SELL:
+			return order.GetAsset() == asset
+		case pb.Side_BUY:
+			return order.GetCounterAsset() == asset
+		}
+	}
+	return true
+}
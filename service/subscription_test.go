@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderMatches(t *testing.T) {
+	sellOrder := &pb.Order{Asset: asset1, CounterAsset: asset2, Price: 0.2}
+	buyOrder := &pb.Order{Asset: asset2, CounterAsset: asset1, Price: 5}
+
+	assert.True(t, orderMatches(&pb.Subscription{}, sellOrder))
+	assert.True(t, orderMatches(&pb.Subscription{Asset: asset1, CounterAsset: asset2}, buyOrder))
+	assert.False(t, orderMatches(&pb.Subscription{Asset: asset1, CounterAsset: "DAI"}, sellOrder))
+
+	assert.True(t, orderMatches(&pb.Subscription{MinPrice: 0.1, MaxPrice: 0.3}, sellOrder))
+	assert.False(t, orderMatches(&pb.Subscription{MinPrice: 0.3}, sellOrder))
+	assert.False(t, orderMatches(&pb.Subscription{MaxPrice: 0.1}, sellOrder))
+
+	assert.True(t, orderMatches(&pb.Subscription{Asset: asset1, Side: pb.Side_SELL}, sellOrder))
+	assert.False(t, orderMatches(&pb.Subscription{Asset: asset1, Side: pb.Side_SELL}, buyOrder))
+	assert.True(t, orderMatches(&pb.Subscription{Asset: asset1, Side: pb.Side_BUY}, buyOrder))
+	assert.True(t, orderMatches(&pb.Subscription{Side: pb.Side_BUY}, sellOrder))
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	orderInBytes, err := proto.Marshal(&pb.Order{Asset: asset1, CounterAsset: asset2, Price: 0.2})
+	assert.NoError(t, err)
+	createMessage := &pb.WireMessage{Operation: pb.Operation_CREATE, Data: orderInBytes}
+	syncMessage := &pb.WireMessage{Operation: pb.Operation_SYNC_REQUEST}
+
+	assert.True(t, subscriptionMatches(nil, subscribedOrder(syncMessage)))
+	assert.False(t, subscriptionMatches(&pb.Subscription{Asset: asset1}, subscribedOrder(syncMessage)))
+	assert.True(t, subscriptionMatches(&pb.Subscription{Asset: asset1}, subscribedOrder(createMessage)))
+	assert.False(t, subscriptionMatches(&pb.Subscription{Asset: "DAI"}, subscribedOrder(createMessage)))
+}
+
+func TestWebsocketSubscription(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port}
+	ws, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+
+	subscription, err := proto.Marshal(&pb.Subscription{Asset: asset1, MinPrice: 1})
+	assert.NoError(t, err)
+	err = ws.WriteMessage(websocket.BinaryMessage, subscription)
+	assert.NoError(t, err)
+
+	// Wait for the server to read the subscription
+	for i := 0; i < 100; i++ {
+		wss.connLock.RLock()
+		subscribed := len(wss.subscriptions) == 1
+		wss.connLock.RUnlock()
+		if subscribed {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cheapOrder, err := proto.Marshal(&pb.Order{Id: []byte("cheap"), Asset: asset1, CounterAsset: asset2, Price: 0.5})
+	assert.NoError(t, err)
+	expensiveOrder, err := proto.Marshal(&pb.Order{Id: []byte("expensive"), Asset: asset1, CounterAsset: asset2, Price: 2})
+	assert.NoError(t, err)
+	wss.PushToWebsockets(context.Background(), &pb.WireMessage{Operation: pb.Operation_CREATE, Data: cheapOrder}, nil)
+	wss.PushToWebsockets(context.Background(), &pb.WireMessage{Operation: pb.Operation_CREATE, Data: expensiveOrder}, nil)
+
+	_, p, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	receivedMessage := &pb.WireMessage{}
+	proto.Unmarshal(p, receivedMessage)
+	assert.Equal(t, expensiveOrder, receivedMessage.GetData())
+	ws.Close()
+}
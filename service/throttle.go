@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// makerRateWindow is the window the orders of a maker are counted in for its rate limit
+const makerRateWindow time.Duration = time.Second
+
+// maxMakerWindows is how many makers' windows are kept before the expired ones are dropped
+const maxMakerWindows int = 10000
+
+// makerWindow counts the orders a maker has created on a channel within the current window
+type makerWindow struct {
+	start  time.Time
+	orders uint
+}
+
+// makerThrottle keeps the rate windows of every maker on every channel
+type makerThrottle struct {
+	windows map[string]*makerWindow
+	lock    sync.Mutex
+}
+
+// allow counts an order by the maker on the channel and tells if it's within the rate limit
+func (t *makerThrottle) allow(channelID []byte, makerPeerID []byte, now time.Time, limit uint) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.windows == nil {
+		t.windows = make(map[string]*makerWindow)
+	}
+
+	key := string(channelID) + string(makerPeerID)
+	window, ok := t.windows[key]
+	if !ok {
+		if len(t.windows) >= maxMakerWindows {
+			t.dropExpired(now)
+		}
+		window = &makerWindow{}
+		t.windows[key] = window
+	}
+	if now.Sub(window.start) >= makerRateWindow {
+		window.start = now
+		window.orders = 0
+	}
+	window.orders++
+	return window.orders <= limit
+}
+
+// dropExpired forgets the windows that have ended, since their makers start from zero anyway
+func (t *makerThrottle) dropExpired(now time.Time) {
+	for key, window := range t.windows {
+		if now.Sub(window.start) >= makerRateWindow {
+			delete(t.windows, key)
+		}
+	}
+}
+
+// getMakerLimits returns the rate and open order limits of makers on the channel.
+// The limits of the channel's signed config take precedence over the node's own.
+func (s *OrderService) getMakerLimits(ctx context.Context, channelID []byte) (uint, uint) {
+	rateLimit, maxOrders := s.MakerRateLimit, s.MaxMakerOrders
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return rateLimit, maxOrders
+	}
+	if limit := channel.GetConfig().GetMakerRateLimit(); limit > 0 {
+		rateLimit = uint(limit)
+	}
+	if limit := channel.GetConfig().GetMaxMakerOrders(); limit > 0 {
+		maxOrders = uint(limit)
+	}
+	return rateLimit, maxOrders
+}
+
+// throttleMaker checks that a received order keeps its maker within the channel's limits, so a single maker
+// can't flood the channel. Orders over the limits are Throttled, which lowers the score of the peer that sent them.
+func (s *OrderService) throttleMaker(ctx context.Context, channelID []byte, order *pb.Order) error {
+	rateLimit, maxOrders := s.getMakerLimits(ctx, channelID)
+
+	// Changes to an order the maker already has don't add to its open orders
+	if maxOrders > 0 {
+		exists, err := s.Storage.Has(ctx, getOrderStorageKey(channelID, order.GetId()))
+		if !errors.IsEmpty(err) {
+			return errors.E(errors.Op("Check for existing order"), err)
+		}
+		if !exists {
+			openOrders, err := s.Storage.GetAllWithPrefix(ctx, string(getIndexQueryPrefix(makerIndex, channelID))+string(order.GetMakerPeerID()))
+			if !errors.IsEmpty(err) {
+				return errors.E(errors.Op("Count open orders of maker"), err)
+			}
+			if uint(len(openOrders)) >= maxOrders {
+				return errors.E(errors.Op("Check open orders of maker"), errors.Throttled, fmt.Sprintf("maker already has %d open orders on the channel", len(openOrders)))
+			}
+		}
+	}
+
+	if rateLimit > 0 && !s.throttle.allow(channelID, order.GetMakerPeerID(), time.Now(), rateLimit) {
+		return errors.E(errors.Op("Check order rate of maker"), errors.Throttled, fmt.Sprintf("maker created more than %d orders per second on the channel", rateLimit))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakerThrottle(t *testing.T) {
+	throttle := &makerThrottle{}
+	now := time.Now()
+	assert.True(t, throttle.allow([]byte(assetPair), []byte("maker"), now, 2))
+	assert.True(t, throttle.allow([]byte(assetPair), []byte("maker"), now, 2))
+	assert.False(t, throttle.allow([]byte(assetPair), []byte("maker"), now, 2))
+
+	// Other makers and channels have windows of their own
+	assert.True(t, throttle.allow([]byte(assetPair), []byte("other"), now, 2))
+	assert.True(t, throttle.allow([]byte("other"), []byte("maker"), now, 2))
+
+	// The count starts over in the next window
+	assert.True(t, throttle.allow([]byte(assetPair), []byte("maker"), now.Add(makerRateWindow), 2))
+}
+
+func TestThrottleMakerRate(t *testing.T) {
+	receiverService := newOwnershipTestService()
+	receiverService.MakerRateLimit = 2
+	receiverService.MaxDeadLetters = 10
+	makerService := newOwnershipTestService()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 1), makerID))
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 2), makerID))
+	err = receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 3), makerID)
+	assert.True(t, errors.Is(errors.Throttled, err))
+
+	// Surplus orders are neither stored nor kept as dead letters
+	orders, err := receiverService.GetAllOrders(context.Background(), &pb.OrderListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, orders.GetOrders(), 2)
+	deadLetters, err := getDeadLetters(context.Background(), receiverService.Storage, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
+
+func TestThrottleMakerOpenOrders(t *testing.T) {
+	receiverService := newOwnershipTestService()
+	receiverService.MaxMakerOrders = 5
+	makerService := newOwnershipTestService()
+	makerID, _, err := makerService.getMaker()
+	assert.NoError(t, err)
+
+	// A signed channel config overrides the node's limit
+	creatorService := newOwnershipTestService()
+	creatorID, _, err := creatorService.getMaker()
+	assert.NoError(t, err)
+	joinChannelWithOptions(t, creatorService, &pb.ChannelOptions{Creator: creatorID.String()})
+	joinChannelWithOptions(t, receiverService, &pb.ChannelOptions{Creator: creatorID.String()})
+	channel, err := (&ChannelService{Storage: creatorService.Storage}).PublishConfig(context.Background(), &pb.ChannelConfigRequest{ChannelID: []byte(assetPair), MaxMakerOrders: 1})
+	assert.NoError(t, err)
+	channelConfig, err := proto.Marshal(channel.GetConfig())
+	assert.NoError(t, err)
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CHANNEL_CONFIG, channelConfig, creatorID))
+
+	firstOrder := createTestOrder(t, makerService, 1)
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, firstOrder, makerID))
+	err = receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 2), makerID)
+	assert.True(t, errors.Is(errors.Throttled, err))
+
+	// Deleting an order makes room for another
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_DELETE, firstOrder, makerID))
+	assert.NoError(t, receiveData(t, receiverService, pb.Operation_CREATE, createTestOrder(t, makerService, 3), makerID))
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/sprawl/sprawl/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validator is implemented by the requests generated with protoc-gen-validate from the rules in sprawl.proto
+type validator interface {
+	Validate() error
+}
+
+// validateRequest checks a request against the rules of its message, and tells the client which field broke which rule
+func validateRequest(req interface{}) error {
+	v, ok := req.(validator)
+	if !ok {
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s", errors.E(errors.Op("Validate request"), errors.Invalid, err))
+	}
+	return nil
+}
+
+// RecvMsg validates the messages clients stream to the server, like the unary requests are
+func (stream *namespacedStream) RecvMsg(m interface{}) error {
+	err := stream.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	return validateRequest(m)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateRequest(t *testing.T) {
+	valid := &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 1, Price: 1}
+	assert.NoError(t, validateRequest(valid))
+	// Messages without rules pass too
+	assert.NoError(t, validateRequest(&pb.Empty{}))
+
+	invalid := &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 1, Price: -1}
+	err := validateRequest(invalid)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "Price")
+
+	// Invalid requests don't reach the handler
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return &pb.CreateResponse{}, nil
+	}
+	server := &Server{}
+	_, err = server.authenticateUnary(context.Background(), &pb.CreateRequest{Asset: asset1, CounterAsset: asset2, Amount: 1, Price: 1}, &grpc.UnaryServerInfo{FullMethod: "/pb.OrderHandler/Create"}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "ChannelID")
+	assert.False(t, called)
+	_, err = server.authenticateUnary(context.Background(), valid, &grpc.UnaryServerInfo{FullMethod: "/pb.OrderHandler/Create"}, handler)
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/sprawl/sprawl/assets"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// tickTolerance allows for float rounding when checking that a price is a multiple of the tick size
+const tickTolerance float64 = 1e-6
+
+// validateOrder checks the order against the market parameters of the joined channel.
+// Orders on channels that haven't been joined, or have no market parameters, are always valid.
+func (s *OrderService) validateOrder(ctx context.Context, channelID []byte, order *pb.Order) error {
+	channel, err := s.getChannel(ctx, channelID)
+	if !errors.IsEmpty(err) {
+		return nil
+	}
+	options := channel.GetOptions()
+
+	if s.isBanned(ctx, channelID, peer.ID(order.GetMakerPeerID())) {
+		return errors.E(errors.Op("Validate order maker"), errors.Unauthorized, "maker is banned from the channel")
+	}
+
+	// Prices are quoted in the quote asset, so the order has to trade the channel's pair
+	orderPair := NormalizeAssetPair([]byte(order.GetAsset() + "," + order.GetCounterAsset()))
+	if options.GetQuoteAsset() != "" && string(orderPair) != string(NormalizeAssetPair(channel.GetId())) {
+		return errors.E(errors.Op("Validate order assets"), errors.Invalid, fmt.Sprintf("order trades %s for %s, which isn't the channel's pair", order.GetAsset(), order.GetCounterAsset()))
+	}
+
+	// Amounts have a fixed precision, which may be finer than the asset can be divided
+	for _, asset := range options.GetAssets() {
+		if asset.GetSymbol() != assets.Canonical(order.GetAsset()) {
+			continue
+		}
+		if unit := assets.AmountUnit(asset.GetDecimals()); order.GetAmount()%unit != 0 {
+			return errors.E(errors.Op("Validate order amount"), errors.Invalid, fmt.Sprintf("amount %d is more precise than the %d decimals of %s", order.GetAmount(), asset.GetDecimals(), asset.GetSymbol()))
+		}
+	}
+
+	err = validateTickAndLot(options.GetTickSize(), options.GetLotSize(), order)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	err = validateFees(channel, order)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+
+	// The creator's signed config can tighten the rules further
+	return validateChannelConfig(channel, order)
+}
+
+// validateTickAndLot checks that the order's price is a multiple of the tick size and its amount a whole number of lots
+func validateTickAndLot(tickSize float32, lotSize uint64, order *pb.Order) error {
+	if tick := float64(tickSize); tick > 0 {
+		ticks := float64(order.GetPrice()) / tick
+		if math.Abs(ticks-math.Round(ticks)) > tickTolerance*math.Max(1, ticks) {
+			return errors.E(errors.Op("Validate order price"), errors.Invalid, fmt.Sprintf("price %v isn't a multiple of the tick size %v", order.GetPrice(), tickSize))
+		}
+	}
+
+	if lotSize > 0 {
+		if order.GetAmount() < lotSize || order.GetAmount()%lotSize != 0 {
+			return errors.E(errors.Op("Validate order amount"), errors.Invalid, fmt.Sprintf("amount %d isn't a whole number of lots of %d", order.GetAmount(), lotSize))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOrder(t *testing.T) {
+	validationService := newOwnershipTestService()
+	order := &pb.Order{Asset: asset1, CounterAsset: asset2, Amount: 300, Price: 0.15}
+
+	// Without a joined channel there's nothing to check against
+	assert.NoError(t, validationService.validateOrder(context.Background(), []byte(assetPair), order))
+
+	joinChannelWithOptions(t, validationService, &pb.ChannelOptions{TickSize: 0.05, LotSize: 100, QuoteAsset: asset2})
+	assert.NoError(t, validationService.validateOrder(context.Background(), []byte(assetPair), order))
+
+	offTick := *order
+	offTick.Price = 0.17
+	assert.True(t, errors.Is(errors.Invalid, validationService.validateOrder(context.Background(), []byte(assetPair), &offTick)))
+
+	partialLot := *order
+	partialLot.Amount = 250
+	assert.True(t, errors.Is(errors.Invalid, validationService.validateOrder(context.Background(), []byte(assetPair), &partialLot)))
+
+	smallLot := *order
+	smallLot.Amount = 50
+	assert.True(t, errors.Is(errors.Invalid, validationService.validateOrder(context.Background(), []byte(assetPair), &smallLot)))
+
+	otherPair := *order
+	otherPair.CounterAsset = "DAI"
+	assert.True(t, errors.Is(errors.Invalid, validationService.validateOrder(context.Background(), []byte(assetPair), &otherPair)))
+}
+
+func TestCreateInvalidOrder(t *testing.T) {
+	validationService := newOwnershipTestService()
+	joinChannelWithOptions(t, validationService, &pb.ChannelOptions{LotSize: 100})
+
+	_, err := validationService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 150, Price: testPrice})
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	_, err = validationService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: 200, Price: testPrice})
+	assert.NoError(t, err)
+}
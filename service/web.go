@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/sprawl/sprawl/errors"
+)
+
+// webMethods are the calls served over gRPC-Web. Browsers only get to read orders and channels.
+var webMethods = map[string]bool{
+	"/pb.OrderHandler/GetOrder":           true,
+	"/pb.OrderHandler/GetAllOrders":       true,
+	"/pb.OrderHandler/GetOrderBook":       true,
+	"/pb.OrderHandler/GetOrderHistory":    true,
+	"/pb.OrderHandler/Search":             true,
+	"/pb.OrderHandler/SubscribeOrderBook": true,
+	"/pb.ChannelHandler/GetChannel":       true,
+	"/pb.ChannelHandler/GetAllChannels":   true,
+}
+
+// allowsOrigin tells if a browser page served from origin may call the API over gRPC-Web.
+// Cross-origin calls are denied unless their origin is listed in WebOrigins.
+func (server *Server) allowsOrigin(origin string) bool {
+	for _, allowed := range server.WebOrigins {
+		if allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// filterWebMethods answers the calls that aren't in webMethods with 404 before they reach the gRPC server
+func filterWebMethods(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !webMethods[r.URL.Path] {
+			http.NotFound(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// newWeb wraps the gRPC server for gRPC-Web on WebPort, so browser dashboards can call it without a proxy
+func (server *Server) newWeb() *http.Server {
+	wrapped := grpcweb.WrapServer(server.grpc, grpcweb.WithOriginFunc(server.allowsOrigin))
+	return &http.Server{Addr: fmt.Sprintf(":%d", server.WebPort), Handler: filterWebMethods(wrapped)}
+}
+
+func (server *Server) runWeb() {
+	server.Logger.Infof("Serving gRPC-Web on port %d", server.WebPort)
+	err := server.web.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		server.Logger.Error(errors.E(errors.Op("Serve gRPC-Web"), err))
+	}
+}
+
+// closeWeb stops the gRPC-Web listener, if it's running
+func (server *Server) closeWeb() {
+	if server.web != nil {
+		server.web.Shutdown(context.Background())
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowsOrigin(t *testing.T) {
+	server := &Server{}
+	assert.False(t, server.allowsOrigin("https://dashboard.example.com"))
+
+	server.WebOrigins = []string{"https://dashboard.example.com"}
+	assert.True(t, server.allowsOrigin("https://dashboard.example.com"))
+	assert.False(t, server.allowsOrigin("https://evil.example.com"))
+}
+
+func TestFilterWebMethods(t *testing.T) {
+	handler := filterWebMethods(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for path, status := range map[string]int{
+		"/pb.OrderHandler/GetAllOrders": http.StatusOK,
+		"/pb.ChannelHandler/GetChannel": http.StatusOK,
+		"/pb.OrderHandler/Create":       http.StatusNotFound,
+		"/pb.ChannelHandler/Join":       http.StatusNotFound,
+		"/pb.AdminHandler/Backup":       http.StatusNotFound,
+		"/pb.StorageHandler/List":       http.StatusNotFound,
+	} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, nil))
+		assert.Equal(t, status, recorder.Code, path)
+	}
+}
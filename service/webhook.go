@@ -0,0 +1,167 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+const webhookSignatureHeader string = "X-Sprawl-Signature"
+const webhookEventHeader string = "X-Sprawl-Event"
+const webhookTimeout time.Duration = 10 * time.Second
+const defaultWebhookBackoff time.Duration = time.Second
+
+// webhookEvents names the order operations webhooks can be sent for
+var webhookEvents = map[string]pb.Operation{
+	"created":  pb.Operation_CREATE,
+	"deleted":  pb.Operation_DELETE,
+	"locked":   pb.Operation_LOCK,
+	"unlocked": pb.Operation_UNLOCK,
+	"filled":   pb.Operation_FILL,
+}
+
+// webhookPayload is the JSON body POSTed to webhook URLs
+type webhookPayload struct {
+	Event     string          `json:"event"`
+	ChannelID string          `json:"channelID"`
+	Order     json.RawMessage `json:"order"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Webhooks POSTs order events to external URLs, so other systems can follow the order book without a websocket.
+// Each request is signed with an HMAC-SHA256 of the body and retried with exponential backoff if it fails.
+type Webhooks struct {
+	Logger  interfaces.Logger
+	urls    []string
+	events  map[pb.Operation]string
+	secret  []byte
+	retries uint
+	backoff time.Duration
+	client  *http.Client
+}
+
+// NewWebhooks returns Webhooks that send the given events, like "created" or "locked", to all urls.
+// No events sends all of them.
+func NewWebhooks(logger interfaces.Logger, urls []string, events []string, secret string, retries uint) *Webhooks {
+	webhooks := &Webhooks{
+		Logger:  logger,
+		events:  make(map[pb.Operation]string),
+		secret:  []byte(secret),
+		retries: retries,
+		backoff: defaultWebhookBackoff,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}
+	for _, url := range urls {
+		url = strings.TrimSpace(url)
+		if url != "" {
+			webhooks.urls = append(webhooks.urls, url)
+		}
+	}
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if event == "" {
+			continue
+		}
+		op, ok := webhookEvents[event]
+		if !ok {
+			logger.Warnf("Unknown webhook event %s", event)
+			continue
+		}
+		webhooks.events[op] = event
+	}
+	if len(webhooks.events) == 0 {
+		for event, op := range webhookEvents {
+			webhooks.events[op] = event
+		}
+	}
+	return webhooks
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the body
+func (w *Webhooks) sign(body []byte) string {
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify sends the order operation in the message to all webhook URLs in the background, if its event is enabled
+func (w *Webhooks) Notify(message *pb.WireMessage) {
+	event, ok := w.events[message.GetOperation()]
+	if !ok {
+		return
+	}
+
+	order := &pb.Order{}
+	err := proto.Unmarshal(message.GetData(), order)
+	if !errors.IsEmpty(err) {
+		w.Logger.Warn(errors.E(errors.Op("Unmarshal order for webhook"), err))
+		return
+	}
+	orderJSON, err := (&jsonpb.Marshaler{}).MarshalToString(order)
+	if !errors.IsEmpty(err) {
+		w.Logger.Warn(errors.E(errors.Op("Marshal order for webhook"), err))
+		return
+	}
+	body, err := json.Marshal(&webhookPayload{
+		Event:     event,
+		ChannelID: string(message.GetChannelID()),
+		Order:     json.RawMessage(orderJSON),
+		Timestamp: time.Now().UTC(),
+	})
+	if !errors.IsEmpty(err) {
+		w.Logger.Warn(errors.E(errors.Op("Marshal webhook payload"), err))
+		return
+	}
+
+	for _, url := range w.urls {
+		go w.deliver(url, event, body)
+	}
+}
+
+// deliver POSTs the body to the url, retrying with a doubling backoff until it gets a 2xx response
+func (w *Webhooks) deliver(url string, event string, body []byte) {
+	backoff := w.backoff
+	for attempt := uint(0); ; attempt++ {
+		err := w.post(url, event, body)
+		if errors.IsEmpty(err) {
+			return
+		}
+		if attempt >= w.retries {
+			w.Logger.Warn(errors.E(errors.Op("Send "+event+" webhook to "+url), err))
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (w *Webhooks) post(url string, event string, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.E(errors.Op("Create webhook request"), err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhookEventHeader, event)
+	request.Header.Set(webhookSignatureHeader, "sha256="+w.sign(body))
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return errors.E(errors.Op("Post webhook"), err)
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.E(errors.Op("Post webhook"), "webhook responded with "+response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWebhookSecret string = "secret"
+
+type webhookRequest struct {
+	event     string
+	signature string
+	payload   webhookPayload
+}
+
+// newWebhookTestServer returns a server that fails the first failures requests and records the rest
+func newWebhookTestServer(t *testing.T, failures int) (*httptest.Server, func() []webhookRequest) {
+	var lock sync.Mutex
+	var requests []webhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		request := webhookRequest{event: r.Header.Get(webhookEventHeader), signature: r.Header.Get(webhookSignatureHeader)}
+		assert.NoError(t, json.Unmarshal(body, &request.payload))
+		assert.Equal(t, "sha256="+(&Webhooks{secret: []byte(testWebhookSecret)}).sign(body), request.signature)
+		requests = append(requests, request)
+	}))
+	received := func() []webhookRequest {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]webhookRequest{}, requests...)
+	}
+	return server, received
+}
+
+func waitForWebhooks(received func() []webhookRequest, count int) []webhookRequest {
+	for i := 0; i < 100 && len(received()) < count; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return received()
+}
+
+func TestWebhooks(t *testing.T) {
+	server, received := newWebhookTestServer(t, 0)
+	defer server.Close()
+
+	webhookService := newOwnershipTestService()
+	webhookService.RegisterWebhooks(NewWebhooks(new(util.PlaceholderLogger), []string{server.URL}, []string{"created", " locked", "unknown"}, testWebhookSecret, 0))
+
+	resp, err := webhookService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	requests := waitForWebhooks(received, 1)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "created", requests[0].event)
+	assert.Equal(t, "created", requests[0].payload.Event)
+	assert.Equal(t, assetPair, requests[0].payload.ChannelID)
+	assert.Contains(t, string(requests[0].payload.Order), asset1)
+
+	// Deletes aren't sent, since only created and locked orders are
+	request := &pb.OrderSpecificRequest{OrderID: resp.GetCreatedOrder().GetId(), ChannelID: []byte(assetPair)}
+	_, err = webhookService.Lock(context.Background(), request)
+	assert.NoError(t, err)
+	_, err = webhookService.Delete(context.Background(), request)
+	assert.NoError(t, err)
+	requests = waitForWebhooks(received, 3)
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "locked", requests[1].event)
+}
+
+func TestWebhookRetries(t *testing.T) {
+	server, received := newWebhookTestServer(t, 2)
+	defer server.Close()
+
+	webhooks := NewWebhooks(new(util.PlaceholderLogger), []string{server.URL}, nil, testWebhookSecret, 2)
+	webhooks.backoff = time.Millisecond
+	webhookService := newOwnershipTestService()
+	webhookService.RegisterWebhooks(webhooks)
+
+	_, err := webhookService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	assert.Len(t, waitForWebhooks(received, 1), 1)
+}
@@ -1,45 +1,142 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/websocket"
 	"github.com/sprawl/sprawl/errors"
 	"github.com/sprawl/sprawl/interfaces"
 	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/requestid"
 )
 
+const defaultPingInterval time.Duration = 30 * time.Second
+const defaultPongTimeout time.Duration = 10 * time.Second
+const defaultWriteTimeout time.Duration = 10 * time.Second
+
 type WebsocketService struct {
-	Connections []*websocket.Conn
-	Logger      interfaces.Logger
-	Port        uint
-	httpServer  http.Server
+	Connections  []*websocket.Conn
+	Logger       interfaces.Logger
+	Port         uint
+	PingInterval time.Duration
+	PongTimeout  time.Duration
+	// WriteTimeout is how long a client may take to accept a frame before it's dropped
+	WriteTimeout time.Duration
+	// FlushInterval coalesces the messages pushed within it into one WireMessageBatch frame per client. 0 sends every message at once.
+	FlushInterval time.Duration
+	// Listeners are the endpoints clients connect to. Without any, clients connect to localhost at Port.
+	Listeners []WebsocketListener
+	// APIKeys are the keys that clients of listeners with WebsocketAuthAPIKey authenticate with
+	APIKeys       map[string]string
+	httpServers   []*http.Server
+	serverLock    sync.Mutex
+	subscriptions map[*websocket.Conn]*pb.Subscription
+	batches       map[*websocket.Conn][]byte
+	writeLocks    map[*websocket.Conn]*sync.Mutex
+	flushing      bool
+	connLock      sync.RWMutex
 }
 
+// Start serves websocket clients on every listener, and returns once all of them are closed
 func (ws *WebsocketService) Start() {
-	mux := http.NewServeMux()
+	listeners := ws.Listeners
+	if len(listeners) == 0 {
+		listeners = []WebsocketListener{{Network: "tcp", Address: "localhost:" + fmt.Sprint(ws.Port), Auth: WebsocketAuthNone}}
+	}
+
+	var wg sync.WaitGroup
+	for _, listener := range listeners {
+		wg.Add(1)
+		go func(listener WebsocketListener) {
+			defer wg.Done()
+			ws.serve(listener)
+		}(listener)
+	}
+	wg.Wait()
+}
 
+// serve accepts websocket clients on a single listener until it's closed
+func (ws *WebsocketService) serve(listener WebsocketListener) {
+	mux := http.NewServeMux()
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if !ws.authorize(listener, r) {
+			http.Error(w, "the websocket needs an API key", http.StatusUnauthorized)
+			return
+		}
 		ws.connect(w, r)
 	})
-	ws.httpServer = http.Server{Addr: "localhost:" + fmt.Sprint(ws.Port), Handler: mux}
-	err := ws.httpServer.ListenAndServe()
+	server := &http.Server{Handler: mux}
+
+	socket, err := listener.listen()
 	if !errors.IsEmpty(err) {
 		if ws.Logger != nil {
-			ws.Logger.Error(errors.E(errors.Op("Listen and serve port :"+fmt.Sprint(ws.Port))), err)
+			ws.Logger.Error(errors.E(errors.Op("Listen on "+listener.String()), err))
+		}
+		return
+	}
+	ws.serverLock.Lock()
+	ws.httpServers = append(ws.httpServers, server)
+	ws.serverLock.Unlock()
+
+	if listener.CertFile != "" {
+		err = server.ServeTLS(socket, listener.CertFile, listener.KeyFile)
+	} else {
+		err = server.Serve(socket)
+	}
+	if !errors.IsEmpty(err) && err != http.ErrServerClosed {
+		if ws.Logger != nil {
+			ws.Logger.Error(errors.E(errors.Op("Serve websockets on "+listener.String()), err))
 		}
 	}
 }
 
 func (ws *WebsocketService) Close() {
-	err := ws.httpServer.Close()
-	if !errors.IsEmpty(err) {
-		if ws.Logger != nil {
-			ws.Logger.Error(errors.E(errors.Op("Close http server")), err)
+	ws.serverLock.Lock()
+	for _, server := range ws.httpServers {
+		err := server.Close()
+		if !errors.IsEmpty(err) {
+			if ws.Logger != nil {
+				ws.Logger.Error(errors.E(errors.Op("Close http server")), err)
+			}
 		}
 	}
+	ws.httpServers = nil
+	ws.serverLock.Unlock()
+	ws.connLock.Lock()
+	for _, conn := range ws.Connections {
+		conn.Close()
+	}
+	ws.Connections = nil
+	ws.subscriptions = nil
+	ws.batches = nil
+	ws.writeLocks = nil
+	ws.connLock.Unlock()
+}
+
+func (ws *WebsocketService) pingInterval() time.Duration {
+	if ws.PingInterval == 0 {
+		return defaultPingInterval
+	}
+	return ws.PingInterval
+}
+
+func (ws *WebsocketService) pongTimeout() time.Duration {
+	if ws.PongTimeout == 0 {
+		return defaultPongTimeout
+	}
+	return ws.PongTimeout
+}
+
+func (ws *WebsocketService) writeTimeout() time.Duration {
+	if ws.WriteTimeout == 0 {
+		return defaultWriteTimeout
+	}
+	return ws.WriteTimeout
 }
 
 func (ws *WebsocketService) connect(w http.ResponseWriter, r *http.Request) {
@@ -58,26 +155,166 @@ func (ws *WebsocketService) connect(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	ws.connLock.Lock()
 	ws.Connections = append(ws.Connections, conn)
+	if ws.writeLocks == nil {
+		ws.writeLocks = make(map[*websocket.Conn]*sync.Mutex)
+	}
+	ws.writeLocks[conn] = new(sync.Mutex)
+	ws.connLock.Unlock()
+
+	go ws.keepAlive(conn)
 }
 
-func (ws *WebsocketService) PushToWebsockets(message *pb.WireMessage) {
-	if len(ws.Connections) == 0 {
-		return
+// keepAlive pings the client periodically and reads from the connection so that pongs get processed.
+// A client that doesn't answer within the read deadline is dropped.
+func (ws *WebsocketService) keepAlive(conn *websocket.Conn) {
+	deadline := ws.pingInterval() + ws.pongTimeout()
+	conn.SetReadDeadline(time.Now().Add(deadline))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(deadline))
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(ws.pingInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(ws.pongTimeout()))
+				if !errors.IsEmpty(err) {
+					conn.Close()
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// Clients only send subscriptions, but reading is required for control frames to be handled anyway
+	for {
+		_, data, err := conn.ReadMessage()
+		if !errors.IsEmpty(err) {
+			if ws.Logger != nil {
+				ws.Logger.Debugf("Dropping websocket connection %s: %s", conn.RemoteAddr(), err)
+			}
+			break
+		}
+		ws.subscribe(conn, data)
 	}
-	buf, err := proto.Marshal(message)
-	if !errors.IsEmpty(err) {
-		if ws.Logger != nil {
-			ws.Logger.Warn(errors.E(errors.Op("Marshal wiremessage"), err))
+	close(done)
+	ws.removeConnection(conn)
+}
+
+// removeConnection closes the connection and removes it from ws.Connections
+func (ws *WebsocketService) removeConnection(conn *websocket.Conn) {
+	ws.connLock.Lock()
+	defer ws.connLock.Unlock()
+	for i, c := range ws.Connections {
+		if c == conn {
+			ws.Connections = append(ws.Connections[:i], ws.Connections[i+1:]...)
+			break
 		}
+	}
+	delete(ws.subscriptions, conn)
+	delete(ws.batches, conn)
+	delete(ws.writeLocks, conn)
+	conn.Close()
+}
+
+// PushToWebsockets sends the message to every connected client whose subscription it matches.
+// buf is the message as it was received, which is sent as is. A nil buf marshals the message.
+// Failures are logged with the request ID of ctx.
+func (ws *WebsocketService) PushToWebsockets(ctx context.Context, message *pb.WireMessage, buf []byte) {
+	ws.connLock.RLock()
+	if len(ws.Connections) == 0 {
+		ws.connLock.RUnlock()
 		return
 	}
-	for _, conn := range ws.Connections {
-		err := conn.WriteMessage(1, buf)
+	ws.connLock.RUnlock()
+
+	if buf == nil {
+		var err error
+		buf, err = proto.Marshal(message)
 		if !errors.IsEmpty(err) {
 			if ws.Logger != nil {
-				ws.Logger.Warn(errors.E(errors.Op("Send message with ws"), err))
+				requestid.Logger(ctx, ws.Logger).Warn(errors.E(errors.Op("Marshal wiremessage"), err))
 			}
+			return
+		}
+	}
+
+	ws.connLock.Lock()
+	// The order is only unmarshaled if a client has filters to match it against
+	var order *pb.Order
+	if len(ws.subscriptions) > 0 {
+		order = subscribedOrder(message)
+	}
+	recipients := make([]*websocket.Conn, 0, len(ws.Connections))
+	for _, conn := range ws.Connections {
+		if !subscriptionMatches(ws.subscriptions[conn], order) {
+			continue
+		}
+		if ws.FlushInterval > 0 {
+			ws.batch(conn, buf)
+			continue
+		}
+		recipients = append(recipients, conn)
+	}
+	ws.connLock.Unlock()
+
+	// The clients are written to without holding ws.connLock, so a slow one doesn't hold up connecting and dropping others
+	for _, conn := range recipients {
+		ws.write(conn, buf)
+	}
+}
+
+// batch adds the message to the client's next WireMessageBatch and schedules a flush if there isn't one yet.
+// The caller must hold ws.connLock.
+func (ws *WebsocketService) batch(conn *websocket.Conn, buf []byte) {
+	if ws.batches == nil {
+		ws.batches = make(map[*websocket.Conn][]byte)
+	}
+	ws.batches[conn] = appendToBatch(ws.batches[conn], buf)
+	if !ws.flushing {
+		ws.flushing = true
+		time.AfterFunc(ws.FlushInterval, ws.flush)
+	}
+}
+
+// flush sends every client the messages batched for it since the last flush in a single frame
+func (ws *WebsocketService) flush() {
+	ws.connLock.Lock()
+	batches := ws.batches
+	ws.batches = nil
+	ws.flushing = false
+	ws.connLock.Unlock()
+	for conn, batch := range batches {
+		ws.write(conn, batch)
+	}
+}
+
+// write sends a frame to the client, dropping the client if it fails or doesn't accept the frame within the write timeout.
+// Writes aren't safe to do concurrently on a single connection, so each connection has a lock of its own.
+func (ws *WebsocketService) write(conn *websocket.Conn, buf []byte) {
+	ws.connLock.RLock()
+	writeLock := ws.writeLocks[conn]
+	ws.connLock.RUnlock()
+	// The client has been dropped since the frame was addressed to it
+	if writeLock == nil {
+		return
+	}
+
+	writeLock.Lock()
+	conn.SetWriteDeadline(time.Now().Add(ws.writeTimeout()))
+	err := conn.WriteMessage(websocket.TextMessage, buf)
+	writeLock.Unlock()
+	if !errors.IsEmpty(err) {
+		if ws.Logger != nil {
+			ws.Logger.Warn(errors.E(errors.Op("Send message with ws"), err))
 		}
+		ws.removeConnection(conn)
 	}
 }
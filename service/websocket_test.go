@@ -1,9 +1,16 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sprawl/sprawl/errors"
@@ -40,7 +47,7 @@ func TestConnectAndRelay(t *testing.T) {
 	testOrderInBytes, err := proto.Marshal(testOrder)
 	assert.NoError(t, err)
 	testWireMessage = &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
-	wss.PushToWebsockets(testWireMessage)
+	wss.PushToWebsockets(context.Background(), testWireMessage, nil)
 	_, p, err := ws.ReadMessage()
 	assert.NoError(t, err)
 	testWireMessage2 := &pb.WireMessage{}
@@ -51,3 +58,141 @@ func TestConnectAndRelay(t *testing.T) {
 	assert.Equal(t, testOrder.GetId(), testOrder2.GetId())
 
 }
+
+func TestDeadConnectionReaping(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port, PingInterval: time.Second / 10, PongTimeout: time.Second / 10}
+	ws, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+
+	// A client that never reads doesn't answer pings and gets dropped
+	time.Sleep(time.Second)
+	wss.connLock.RLock()
+	assert.Len(t, wss.Connections, 0)
+	wss.connLock.RUnlock()
+	ws.Close()
+}
+
+func TestKeepAlive(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port, PingInterval: time.Second / 10, PongTimeout: time.Second / 10}
+	ws, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+
+	// Reading makes the client answer pings automatically
+	go func() {
+		for {
+			if _, _, err := ws.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	time.Sleep(time.Second)
+	wss.connLock.RLock()
+	assert.Len(t, wss.Connections, 1)
+	wss.connLock.RUnlock()
+	ws.Close()
+}
+
+func TestWriteTimeout(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port, WriteTimeout: time.Nanosecond}
+	ws, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+	defer ws.Close()
+	time.Sleep(time.Second / 10)
+
+	// A client that can't take a frame before its write deadline is dropped
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	wss.PushToWebsockets(context.Background(), &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}, nil)
+	wss.connLock.RLock()
+	assert.Len(t, wss.Connections, 0)
+	wss.connLock.RUnlock()
+}
+
+func TestBatchedPush(t *testing.T) {
+	wss := WebsocketService{Logger: log, Port: port, FlushInterval: time.Second / 20}
+	ws, err := StartServer(&wss)
+	defer wss.Close()
+	assert.NoError(t, err)
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	created := &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}
+	locked := &pb.WireMessage{ChannelID: []byte("otherChannel"), Operation: pb.Operation_LOCK, Data: testOrderInBytes}
+	wss.PushToWebsockets(context.Background(), created, nil)
+	wss.PushToWebsockets(context.Background(), locked, nil)
+
+	// Both messages arrive in a single frame
+	_, p, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	expected, err := proto.Marshal(&pb.WireMessageBatch{Messages: []*pb.WireMessage{created, locked}})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, p)
+	batch := &pb.WireMessageBatch{}
+	assert.NoError(t, proto.Unmarshal(p, batch))
+	assert.Len(t, batch.GetMessages(), 2)
+	assert.Equal(t, []byte("otherChannel"), batch.GetMessages()[1].GetChannelID())
+}
+
+func TestParseWebsocketListeners(t *testing.T) {
+	listeners, err := ParseWebsocketListeners("unix:///run/sprawl/ws.sock, tcp://0.0.0.0:3443?cert=ws.crt&key=ws.key&auth=apiKey")
+	assert.NoError(t, err)
+	assert.Equal(t, []WebsocketListener{
+		{Network: "unix", Address: "/run/sprawl/ws.sock", Auth: WebsocketAuthNone},
+		{Network: "tcp", Address: "0.0.0.0:3443", CertFile: "ws.crt", KeyFile: "ws.key", Auth: WebsocketAuthAPIKey},
+	}, listeners)
+
+	listeners, err = ParseWebsocketListeners("")
+	assert.NoError(t, err)
+	assert.Empty(t, listeners)
+
+	for _, invalid := range []string{"udp://0.0.0.0:3000", "tcp://", "tcp://0.0.0.0:3443?cert=ws.crt", "unix:///ws.sock?auth=password"} {
+		_, err = ParseWebsocketListeners(invalid)
+		assert.True(t, errors.Is(errors.Invalid, err), invalid)
+	}
+}
+
+func TestUnixSocketListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sprawl-websocket")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "ws.sock")
+
+	wss := WebsocketService{
+		Logger:    log,
+		Listeners: []WebsocketListener{{Network: "unix", Address: socketPath, Auth: WebsocketAuthAPIKey}},
+		APIKeys:   map[string]string{"bot-key": "bot"},
+	}
+	go wss.Start()
+	defer wss.Close()
+	for i := 0; i < 100; i++ {
+		if _, err = os.Stat(socketPath); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+
+	dialer := websocket.Dialer{NetDial: func(network, addr string) (net.Conn, error) {
+		return net.Dial("unix", socketPath)
+	}}
+
+	// Clients without an API key are turned away
+	_, response, err := dialer.Dial("ws://sprawl/", nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	ws, _, err := dialer.Dial("ws://sprawl/?apiKey=bot-key", nil)
+	assert.NoError(t, err)
+	defer ws.Close()
+	testOrderInBytes, err := proto.Marshal(testOrder)
+	assert.NoError(t, err)
+	wss.PushToWebsockets(context.Background(), &pb.WireMessage{ChannelID: testChannel.GetId(), Operation: pb.Operation_CREATE, Data: testOrderInBytes}, nil)
+	_, data, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	received := &pb.WireMessage{}
+	assert.NoError(t, proto.Unmarshal(data, received))
+	assert.Equal(t, testOrderInBytes, received.GetData())
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/sprawl/sprawl/errors"
+)
+
+// WebsocketAuthNone lets anyone that can reach a websocket listener connect to it
+const WebsocketAuthNone string = "none"
+
+// WebsocketAuthAPIKey makes websocket clients authenticate with one of the configured API keys
+const WebsocketAuthAPIKey string = "apiKey"
+
+// apiKeyParameter is the query parameter that browsers, which can't set headers on websockets, send their API key in
+const apiKeyParameter string = "apiKey"
+
+// WebsocketListener is an endpoint that websocket clients connect to
+type WebsocketListener struct {
+	// Network is "tcp" or "unix"
+	Network string
+	// Address is the host and port of a TCP listener, or the path of a Unix socket
+	Address string
+	// CertFile and KeyFile serve the listener over TLS
+	CertFile string
+	KeyFile  string
+	// Auth is WebsocketAuthNone or WebsocketAuthAPIKey
+	Auth string
+}
+
+func (listener WebsocketListener) String() string {
+	return listener.Network + "://" + listener.Address
+}
+
+// ParseWebsocketListeners parses comma separated listener URLs, like unix:///run/sprawl/ws.sock or
+// tcp://0.0.0.0:3443?cert=ws.crt&key=ws.key&auth=apiKey
+func ParseWebsocketListeners(listeners string) ([]WebsocketListener, error) {
+	parsed := []WebsocketListener{}
+	if strings.TrimSpace(listeners) == "" {
+		return parsed, nil
+	}
+	for _, spec := range strings.Split(listeners, ",") {
+		listener, err := parseWebsocketListener(strings.TrimSpace(spec))
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		parsed = append(parsed, listener)
+	}
+	return parsed, nil
+}
+
+func parseWebsocketListener(spec string) (WebsocketListener, error) {
+	op := errors.Op("Parse websocket listener " + spec)
+	listenerURL, err := url.Parse(spec)
+	if !errors.IsEmpty(err) {
+		return WebsocketListener{}, errors.E(op, errors.Invalid, err)
+	}
+	query := listenerURL.Query()
+	listener := WebsocketListener{
+		Network:  listenerURL.Scheme,
+		CertFile: query.Get("cert"),
+		KeyFile:  query.Get("key"),
+		Auth:     query.Get("auth"),
+	}
+	switch listener.Network {
+	case "tcp":
+		listener.Address = listenerURL.Host
+	case "unix":
+		listener.Address = listenerURL.Path
+	default:
+		return listener, errors.E(op, errors.Invalid, fmt.Sprintf("network %q isn't tcp or unix", listener.Network))
+	}
+	if listener.Address == "" {
+		return listener, errors.E(op, errors.Invalid, "the listener has no address")
+	}
+	if (listener.CertFile == "") != (listener.KeyFile == "") {
+		return listener, errors.E(op, errors.Invalid, "TLS needs both a cert and a key")
+	}
+	if listener.Auth == "" {
+		listener.Auth = WebsocketAuthNone
+	}
+	if listener.Auth != WebsocketAuthNone && listener.Auth != WebsocketAuthAPIKey {
+		return listener, errors.E(op, errors.Invalid, fmt.Sprintf("auth %q isn't %s or %s", listener.Auth, WebsocketAuthNone, WebsocketAuthAPIKey))
+	}
+	return listener, nil
+}
+
+// listen opens the listener's socket. A Unix socket left behind by an earlier run is replaced.
+func (listener WebsocketListener) listen() (net.Listener, error) {
+	if listener.Network == "unix" {
+		if info, err := os.Stat(listener.Address); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(listener.Address)
+		}
+	}
+	return net.Listen(listener.Network, listener.Address)
+}
+
+// authorize tells if the request may connect to the listener. With WebsocketAuthAPIKey, the request needs one of
+// the API keys as a bearer token, as the password of HTTP basic authentication or in the apiKey query parameter.
+func (ws *WebsocketService) authorize(listener WebsocketListener, r *http.Request) bool {
+	if listener.Auth != WebsocketAuthAPIKey {
+		return true
+	}
+	key := r.URL.Query().Get(apiKeyParameter)
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, bearerPrefix) {
+		key = strings.TrimPrefix(authorization, bearerPrefix)
+	}
+	if _, password, ok := r.BasicAuth(); ok {
+		key = password
+	}
+	_, ok := ws.APIKeys[key]
+	return ok && key != ""
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Field numbers of pb.WireMessage and pb.Order read without unmarshaling
+const wireChannelIDField uint64 = 1
+const wireOperationField uint64 = 2
+const wireDataField uint64 = 3
+const orderIDField uint64 = 1
+const batchMessagesField uint64 = 4
+
+// rangeFields calls field for every field of a marshaled protobuf message in buf.
+// Varints are passed as numbers and length delimited fields as slices of buf, so nothing is copied.
+func rangeFields(buf []byte, field func(number uint64, varint uint64, bytes []byte) error) error {
+	for len(buf) > 0 {
+		key, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return errors.E(errors.Op("Read field key"), errors.Malformed, "invalid varint")
+		}
+		buf = buf[n:]
+
+		var varint uint64
+		var bytes []byte
+		switch key & 7 {
+		case proto.WireVarint:
+			varint, n = binary.Uvarint(buf)
+			if n <= 0 {
+				return errors.E(errors.Op("Read varint field"), errors.Malformed, "invalid varint")
+			}
+			buf = buf[n:]
+		case proto.WireBytes:
+			length, n := binary.Uvarint(buf)
+			if n <= 0 || length > uint64(len(buf)-n) {
+				return errors.E(errors.Op("Read length delimited field"), errors.Malformed, "invalid length")
+			}
+			bytes = buf[n : n+int(length) : n+int(length)]
+			buf = buf[n+int(length):]
+		case proto.WireFixed64:
+			if len(buf) < 8 {
+				return errors.E(errors.Op("Read fixed64 field"), errors.Malformed, "unexpected end of message")
+			}
+			buf = buf[8:]
+		case proto.WireFixed32:
+			if len(buf) < 4 {
+				return errors.E(errors.Op("Read fixed32 field"), errors.Malformed, "unexpected end of message")
+			}
+			buf = buf[4:]
+		default:
+			return errors.E(errors.Op("Read field"), errors.Malformed, "unsupported wire type")
+		}
+
+		err := field(key>>3, varint, bytes)
+		if !errors.IsEmpty(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeWireMessage reads a WireMessage like proto.Unmarshal, except that its channel ID and data
+// are slices of buf instead of copies. buf mustn't be changed while the message is in use.
+func decodeWireMessage(buf []byte) (*pb.WireMessage, error) {
+	wireMessage := &pb.WireMessage{}
+	err := rangeFields(buf, func(number uint64, varint uint64, bytes []byte) error {
+		switch number {
+		case wireChannelIDField:
+			wireMessage.ChannelID = bytes
+		case wireOperationField:
+			wireMessage.Operation = pb.Operation(int32(varint))
+		case wireDataField:
+			wireMessage.Data = bytes
+		}
+		return nil
+	})
+	if !errors.IsEmpty(err) {
+		return nil, err
+	}
+	return wireMessage, nil
+}
+
+// peekOrderID reads the ID of a marshaled order without unmarshaling the rest of it
+func peekOrderID(data []byte) ([]byte, error) {
+	var id []byte
+	err := rangeFields(data, func(number uint64, varint uint64, bytes []byte) error {
+		if number == orderIDField {
+			id = bytes
+		}
+		return nil
+	})
+	return id, err
+}
+
+// appendToBatch adds a marshaled WireMessage to a marshaled WireMessageBatch without unmarshaling either
+func appendToBatch(batch []byte, buf []byte) []byte {
+	batch = append(batch, proto.EncodeVarint(batchMessagesField<<3|proto.WireBytes)...)
+	batch = append(batch, proto.EncodeVarint(uint64(len(buf)))...)
+	return append(batch, buf...)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+// messagesPerSecond is the rate of a busy node, so a benchmark op is a second of its traffic
+const messagesPerSecond int = 10000
+
+func TestDecodeWireMessage(t *testing.T) {
+	orderInBytes, err := proto.Marshal(&pb.Order{Id: []byte("order"), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	assert.NoError(t, err)
+	buf, err := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_LOCK, Data: orderInBytes})
+	assert.NoError(t, err)
+
+	expected := &pb.WireMessage{}
+	assert.NoError(t, proto.Unmarshal(buf, expected))
+	wireMessage, err := decodeWireMessage(buf)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(expected, wireMessage))
+
+	// The data isn't copied out of the buffer
+	assert.Equal(t, &buf[len(buf)-len(orderInBytes)], &wireMessage.Data[0])
+
+	orderID, err := peekOrderID(wireMessage.GetData())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("order"), orderID)
+
+	_, err = decodeWireMessage(buf[:len(buf)-1])
+	assert.True(t, errors.Is(errors.Malformed, err))
+}
+
+func BenchmarkUnmarshalWireMessage(b *testing.B) {
+	orderInBytes, _ := proto.Marshal(&pb.Order{Id: []byte("order"), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	buf, _ := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_CREATE, Data: orderInBytes})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wireMessage := &pb.WireMessage{}
+		proto.Unmarshal(buf, wireMessage)
+	}
+}
+
+func BenchmarkDecodeWireMessage(b *testing.B) {
+	orderInBytes, _ := proto.Marshal(&pb.Order{Id: []byte("order"), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice})
+	buf, _ := proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_CREATE, Data: orderInBytes})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		decodeWireMessage(buf)
+	}
+}
+
+// BenchmarkReceiveDuplicates receives a second of already stored orders, as gossip repeats them, and reports the allocations per message
+func BenchmarkReceiveDuplicates(b *testing.B) {
+	makerService := newOwnershipTestService()
+	receiverService := newOwnershipTestService()
+	makerID, _, _ := makerService.getMaker()
+
+	messages := make([][]byte, 100)
+	for i := range messages {
+		created, err := makerService.Create(context.Background(), &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice + float32(i)})
+		if !errors.IsEmpty(err) {
+			b.Fatal(err)
+		}
+		orderInBytes, _ := proto.Marshal(created.GetCreatedOrder())
+		messages[i], _ = proto.Marshal(&pb.WireMessage{ChannelID: []byte(assetPair), Operation: pb.Operation_CREATE, Data: orderInBytes})
+		if err := receive(receiverService, messages[i], makerID); !errors.IsEmpty(err) {
+			b.Fatal(err)
+		}
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < messagesPerSecond; j++ {
+			receive(receiverService, messages[j%len(messages)], makerID)
+		}
+	}
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.Mallocs-before.Mallocs)/float64(b.N*messagesPerSecond), "allocs/msg")
+}
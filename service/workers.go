@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+)
+
+// Workers limits how many orders are created and received at once, so that a burst of them can't pile up
+// writes faster than the storage compacts them
+type Workers struct {
+	slots chan struct{}
+}
+
+// NewWorkers returns Workers that process up to size orders at once
+func NewWorkers(size uint) *Workers {
+	return &Workers{slots: make(chan struct{}, size)}
+}
+
+// RegisterWorkers registers the workers that Create and Receive take turns on. Orders aren't limited without them.
+func (s *OrderService) RegisterWorkers(workers *Workers) {
+	s.workers = workers
+}
+
+// tryAcquire takes a worker if one is free, without waiting
+func (workers *Workers) tryAcquire() bool {
+	if workers == nil {
+		return true
+	}
+	select {
+	case workers.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// acquire waits for a free worker, and tells if it got one before ctx was done
+func (workers *Workers) acquire(ctx context.Context) bool {
+	if workers == nil {
+		return true
+	}
+	select {
+	case workers.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a worker taken with tryAcquire or acquire
+func (workers *Workers) release() {
+	if workers == nil {
+		return
+	}
+	<-workers.slots
+}
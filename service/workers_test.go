@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWorkers(t *testing.T) {
+	ctx := context.Background()
+	workersService := &OrderService{Logger: new(util.PlaceholderLogger)}
+	workersService.RegisterStorage(&inmemory.Storage{Db: make(map[string]string)})
+	workers := NewWorkers(1)
+	workersService.RegisterWorkers(workers)
+	request := &pb.CreateRequest{ChannelID: []byte(assetPair), Asset: asset1, CounterAsset: asset2, Amount: testAmount, Price: testPrice}
+
+	// A busy node tells clients to back off instead of queueing them
+	assert.True(t, workers.tryAcquire())
+	_, err := workersService.Create(ctx, request)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Received messages wait for a worker until they're given up on
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, workersService.Receive(cancelled, interfaces.IncomingMessage{}))
+
+	workers.release()
+	_, err = workersService.Create(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, workers.tryAcquire())
+}
@@ -0,0 +1,325 @@
+// Package ethereum settles trades through an escrow contract on an Ethereum chain. When a fill is executed, the
+// maker's side of the trade is locked in the contract with lock(bytes32 tradeID, bytes32 orderID, uint256 amount,
+// uint256 expiry), and the order is SETTLED once that transaction has enough confirmations. If it fails or isn't
+// confirmed before the timeout, the escrow is released with refund(bytes32 tradeID) and the order is ABORTED.
+//
+// Transactions are sent with eth_sendTransaction, so the Ethereum node signs them and has to have the configured
+// account unlocked. Pending trades are kept in the node's storage, so ones that are still pending when the node
+// stops are settled or refunded once it's back.
+package ethereum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/settlement"
+	"golang.org/x/crypto/sha3"
+)
+
+// Name is the name the engine is registered under
+const Name string = "ethereum"
+
+const lockSignature string = "lock(bytes32,bytes32,uint256,uint256)"
+const refundSignature string = "refund(bytes32)"
+
+func init() {
+	settlement.Register(Name, func(config interfaces.Config, logger interfaces.Logger) (settlement.Engine, error) {
+		engine, err := New(config, logger)
+		if !errors.IsEmpty(err) {
+			return nil, err
+		}
+		return engine, nil
+	})
+}
+
+// pendingTrade is a trade whose escrow transaction hasn't been confirmed yet
+type pendingTrade struct {
+	trade    *settlement.Trade
+	txHash   string
+	deadline time.Time
+}
+
+// Engine locks trades in an escrow contract and reports them SETTLED or ABORTED based on chain confirmations
+type Engine struct {
+	Logger        interfaces.Logger
+	rpc           *rpcClient
+	contract      string
+	account       string
+	confirmations uint64
+	pollInterval  time.Duration
+	timeout       time.Duration
+
+	lock     sync.Mutex
+	storage  interfaces.Storage
+	executed map[string]*pendingTrade
+	pending  map[string]*pendingTrade
+	report   settlement.Report
+	done     chan struct{}
+	once     sync.Once
+}
+
+// New returns an Engine that sends escrow transactions to the Ethereum node in the config
+func New(config interfaces.Config, logger interfaces.Logger) (*Engine, error) {
+	if config.GetEthereumRPCURL() == "" {
+		return nil, errors.E(errors.Op("Check Ethereum config"), errors.Invalid, "settlement.ethereum.rpcURL is required")
+	}
+	contract, err := parseAddress(config.GetEthereumContract())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Parse escrow contract address"), err)
+	}
+	account, err := parseAddress(config.GetEthereumAccount())
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Parse Ethereum account"), err)
+	}
+	if config.GetEthereumPollInterval() == 0 {
+		return nil, errors.E(errors.Op("Check Ethereum config"), errors.Invalid, "settlement.ethereum.pollInterval has to be positive")
+	}
+	if config.GetEthereumTimeout() == 0 {
+		return nil, errors.E(errors.Op("Check Ethereum config"), errors.Invalid, "settlement.ethereum.timeout has to be positive")
+	}
+	return &Engine{
+		Logger:        logger,
+		rpc:           newRPCClient(config.GetEthereumRPCURL()),
+		contract:      contract,
+		account:       account,
+		confirmations: uint64(config.GetEthereumConfirmations()),
+		pollInterval:  time.Duration(config.GetEthereumPollInterval()) * time.Second,
+		timeout:       time.Duration(config.GetEthereumTimeout()) * time.Second,
+		executed:      make(map[string]*pendingTrade),
+		pending:       make(map[string]*pendingTrade),
+		done:          make(chan struct{}),
+	}, nil
+}
+
+// Prepare checks that the trade has something to escrow and that the Ethereum node can be reached
+func (e *Engine) Prepare(ctx context.Context, trade *settlement.Trade) error {
+	if trade.Fill.GetAmount() == 0 {
+		return errors.E(errors.Op("Prepare escrow"), errors.Invalid, "fill has no amount to escrow")
+	}
+	_, err := e.rpc.blockNumber(ctx)
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Reach Ethereum node"), err)
+	}
+	return nil
+}
+
+// Execute sends the transaction that locks the trade in the escrow contract
+func (e *Engine) Execute(ctx context.Context, trade *settlement.Trade) error {
+	tradeID := getTradeID(trade)
+	deadline := time.Now().Add(e.timeout)
+	data := encodeCall(lockSignature,
+		tradeID,
+		sha256.Sum256(trade.Order.GetId()),
+		encodeUint(new(big.Int).SetUint64(trade.Fill.GetAmount())),
+		encodeUint(big.NewInt(deadline.Unix())),
+	)
+	txHash, err := e.rpc.sendTransaction(ctx, &transaction{From: e.account, To: e.contract, Data: data})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send escrow transaction"), err)
+	}
+
+	key := hex.EncodeToString(tradeID[:])
+	pending := &pendingTrade{trade: trade, txHash: txHash, deadline: deadline}
+	e.lock.Lock()
+	e.executed[key] = pending
+	e.lock.Unlock()
+	e.remember(ctx, key, pending, false)
+	e.Logger.Infof("Escrow of order %x on channel %s sent in transaction %s", trade.Order.GetId(), trade.ChannelID, txHash)
+	return nil
+}
+
+// Confirm starts watching the escrow transaction of a recorded fill for confirmations
+func (e *Engine) Confirm(ctx context.Context, trade *settlement.Trade) error {
+	tradeID := getTradeID(trade)
+	key := hex.EncodeToString(tradeID[:])
+
+	e.lock.Lock()
+	pending, ok := e.executed[key]
+	if !ok {
+		e.lock.Unlock()
+		return errors.E(errors.Op("Confirm escrow"), errors.Invalid, "trade wasn't executed")
+	}
+	delete(e.executed, key)
+	e.pending[key] = pending
+	e.lock.Unlock()
+	e.remember(ctx, key, pending, true)
+	return nil
+}
+
+// Abort refunds the escrow if its transaction was already sent
+func (e *Engine) Abort(ctx context.Context, trade *settlement.Trade, reason error) error {
+	tradeID := getTradeID(trade)
+	key := hex.EncodeToString(tradeID[:])
+
+	e.lock.Lock()
+	_, ok := e.executed[key]
+	delete(e.executed, key)
+	e.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	err := e.refund(ctx, tradeID)
+	if !errors.IsEmpty(err) {
+		return err
+	}
+	e.forget(ctx, key)
+	return nil
+}
+
+// Watch polls the pending escrow transactions in the background and reports their outcome until Close is called
+func (e *Engine) Watch(report settlement.Report) {
+	e.lock.Lock()
+	e.report = report
+	e.lock.Unlock()
+	go e.poll()
+}
+
+// Close stops watching pending escrow transactions
+func (e *Engine) Close() {
+	e.once.Do(func() {
+		close(e.done)
+	})
+}
+
+func (e *Engine) poll() {
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+			e.checkPending(context.Background())
+		}
+	}
+}
+
+// checkPending reports the pending trades whose escrow has been confirmed, has failed or has timed out
+func (e *Engine) checkPending(ctx context.Context) {
+	e.lock.Lock()
+	pending := make(map[string]*pendingTrade, len(e.pending))
+	for key, trade := range e.pending {
+		pending[key] = trade
+	}
+	report := e.report
+	e.lock.Unlock()
+	if len(pending) == 0 || report == nil {
+		return
+	}
+
+	head, err := e.rpc.blockNumber(ctx)
+	if !errors.IsEmpty(err) {
+		e.Logger.Warn(errors.E(errors.Op("Get latest block"), err))
+		return
+	}
+	for key, trade := range pending {
+		state, err := e.checkTrade(ctx, trade, head)
+		if !errors.IsEmpty(err) {
+			e.Logger.Warn(errors.E(errors.Op("Check escrow transaction "+trade.txHash), err))
+			continue
+		}
+		if state == pb.State_FILLED {
+			continue
+		}
+		e.lock.Lock()
+		delete(e.pending, key)
+		e.lock.Unlock()
+		e.forget(ctx, key)
+		report(ctx, trade.trade, state)
+	}
+}
+
+// checkTrade returns SETTLED or ABORTED once the outcome of a trade is known, and FILLED while it's still pending.
+// Only an escrow that wasn't mined by its deadline is refunded. A mined one keeps waiting for its confirmations.
+func (e *Engine) checkTrade(ctx context.Context, trade *pendingTrade, head uint64) (pb.State, error) {
+	txReceipt, err := e.rpc.transactionReceipt(ctx, trade.txHash)
+	if !errors.IsEmpty(err) {
+		return pb.State_FILLED, err
+	}
+	if txReceipt != nil {
+		if txReceipt.Status == "0x0" {
+			e.Logger.Warnf("Escrow transaction %s of order %x failed", trade.txHash, trade.trade.Order.GetId())
+			return pb.State_ABORTED, nil
+		}
+		block, err := parseQuantity(txReceipt.BlockNumber)
+		if !errors.IsEmpty(err) {
+			return pb.State_FILLED, err
+		}
+		if head >= block && head-block+1 >= e.confirmations {
+			return pb.State_SETTLED, nil
+		}
+		return pb.State_FILLED, nil
+	}
+	if time.Now().Before(trade.deadline) {
+		return pb.State_FILLED, nil
+	}
+
+	e.Logger.Warnf("Escrow transaction %s of order %x wasn't mined in time", trade.txHash, trade.trade.Order.GetId())
+	err = e.refund(ctx, getTradeID(trade.trade))
+	if !errors.IsEmpty(err) {
+		return pb.State_FILLED, err
+	}
+	return pb.State_ABORTED, nil
+}
+
+// refund sends the transaction that releases the escrow of a trade
+func (e *Engine) refund(ctx context.Context, tradeID [32]byte) error {
+	txHash, err := e.rpc.sendTransaction(ctx, &transaction{From: e.account, To: e.contract, Data: encodeCall(refundSignature, tradeID)})
+	if !errors.IsEmpty(err) {
+		return errors.E(errors.Op("Send refund transaction"), err)
+	}
+	e.Logger.Infof("Escrow %x refunded in transaction %s", tradeID, txHash)
+	return nil
+}
+
+// getTradeID identifies a trade in the escrow contract by the order it fills and the state of the order it was signed for
+func getTradeID(trade *settlement.Trade) [32]byte {
+	nonce := make([]byte, 4)
+	binary.BigEndian.PutUint32(nonce, trade.Fill.GetNonce())
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(trade.ChannelID)
+	hash.Write(trade.Fill.GetOrderID())
+	hash.Write(nonce)
+	hash.Write(trade.Fill.GetTakerPubKey())
+	var tradeID [32]byte
+	copy(tradeID[:], hash.Sum(nil))
+	return tradeID
+}
+
+// encodeCall ABI encodes a call of the contract function with the given signature and static arguments
+func encodeCall(signature string, args ...[32]byte) string {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(signature))
+	data := hash.Sum(nil)[:4]
+	for _, arg := range args {
+		data = append(data, arg[:]...)
+	}
+	return "0x" + hex.EncodeToString(data)
+}
+
+// encodeUint ABI encodes a non-negative integer as a uint256
+func encodeUint(value *big.Int) [32]byte {
+	var word [32]byte
+	raw := value.Bytes()
+	copy(word[32-len(raw):], raw)
+	return word
+}
+
+// parseAddress checks that address is a hex encoded 20 byte Ethereum address
+func parseAddress(address string) (string, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+	decoded, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	if err != nil || len(decoded) != 20 {
+		return "", errors.E(errors.Op("Parse address"), errors.Invalid, "\""+address+"\" isn't an Ethereum address")
+	}
+	return "0x" + hex.EncodeToString(decoded), nil
+}
@@ -0,0 +1,256 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sprawl/sprawl/config"
+	"github.com/sprawl/sprawl/database/inmemory"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/settlement"
+	"github.com/sprawl/sprawl/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfigPath = "../../config/test"
+const rpcURLEnvVar = "SPRAWL_SETTLEMENT_ETHEREUM_RPCURL"
+const contractEnvVar = "SPRAWL_SETTLEMENT_ETHEREUM_CONTRACT"
+const accountEnvVar = "SPRAWL_SETTLEMENT_ETHEREUM_ACCOUNT"
+const confirmationsEnvVar = "SPRAWL_SETTLEMENT_ETHEREUM_CONFIRMATIONS"
+const timeoutEnvVar = "SPRAWL_SETTLEMENT_ETHEREUM_TIMEOUT"
+const testContract = "0x00000000000000000000000000000000000000c0"
+const testAccount = "0x00000000000000000000000000000000000000a0"
+
+var ctx = context.Background()
+
+// fakeNode answers the JSON-RPC calls of the engine and records the transactions sent to it
+type fakeNode struct {
+	lock     sync.Mutex
+	head     uint64
+	sent     []transaction
+	receipts map[string]*receipt
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &rpcRequest{}
+	json.NewDecoder(r.Body).Decode(request)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	var result interface{}
+	switch request.Method {
+	case "eth_blockNumber":
+		result = fmt.Sprintf("0x%x", n.head)
+	case "eth_sendTransaction":
+		params, _ := json.Marshal(request.Params[0])
+		tx := transaction{}
+		json.Unmarshal(params, &tx)
+		n.sent = append(n.sent, tx)
+		result = fmt.Sprintf("0x%064x", len(n.sent))
+	case "eth_getTransactionReceipt":
+		result = n.receipts[request.Params[0].(string)]
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": result})
+}
+
+func newTestEngine(t *testing.T, url string) *Engine {
+	os.Setenv(rpcURLEnvVar, url)
+	os.Setenv(contractEnvVar, testContract)
+	os.Setenv(accountEnvVar, testAccount)
+	os.Setenv(confirmationsEnvVar, "2")
+	defer os.Unsetenv(rpcURLEnvVar)
+	defer os.Unsetenv(contractEnvVar)
+	defer os.Unsetenv(accountEnvVar)
+	defer os.Unsetenv(confirmationsEnvVar)
+
+	var testConfig interfaces.Config = &config.Config{}
+	testConfig.ReadConfig(testConfigPath)
+	engine, err := settlement.New(Name, testConfig, new(util.PlaceholderLogger))
+	assert.NoError(t, err)
+	return engine.(*Engine)
+}
+
+func newTestTrade(orderID string) *settlement.Trade {
+	return &settlement.Trade{
+		ChannelID: []byte("testChannel"),
+		Order:     &pb.Order{Id: []byte(orderID), State: pb.State_FILLED},
+		Fill:      &pb.Fill{OrderID: []byte(orderID), Amount: 10, Nonce: 1, TakerPubKey: []byte("taker")},
+	}
+}
+
+func TestNewRequiresConfig(t *testing.T) {
+	var testConfig interfaces.Config = &config.Config{}
+	testConfig.ReadConfig(testConfigPath)
+	_, err := settlement.New(Name, testConfig, new(util.PlaceholderLogger))
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestNewRequiresTimeout(t *testing.T) {
+	os.Setenv(timeoutEnvVar, "0")
+	defer os.Unsetenv(timeoutEnvVar)
+	os.Setenv(rpcURLEnvVar, "http://localhost:8545")
+	os.Setenv(contractEnvVar, testContract)
+	os.Setenv(accountEnvVar, testAccount)
+	defer os.Unsetenv(rpcURLEnvVar)
+	defer os.Unsetenv(contractEnvVar)
+	defer os.Unsetenv(accountEnvVar)
+
+	var testConfig interfaces.Config = &config.Config{}
+	testConfig.ReadConfig(testConfigPath)
+	_, err := settlement.New(Name, testConfig, new(util.PlaceholderLogger))
+	assert.True(t, errors.Is(errors.Invalid, err))
+}
+
+func TestSettle(t *testing.T) {
+	node := &fakeNode{head: 100, receipts: make(map[string]*receipt)}
+	server := httptest.NewServer(node)
+	defer server.Close()
+	engine := newTestEngine(t, server.URL)
+	defer engine.Close()
+
+	reports := make(map[string]pb.State)
+	engine.report = func(ctx context.Context, trade *settlement.Trade, state pb.State) {
+		reports[string(trade.Order.GetId())] = state
+	}
+
+	trade := newTestTrade("settled")
+	assert.NoError(t, engine.Prepare(ctx, trade))
+	assert.NoError(t, engine.Execute(ctx, trade))
+	assert.NoError(t, engine.Confirm(ctx, trade))
+	assert.Len(t, node.sent, 1)
+	assert.Equal(t, testAccount, node.sent[0].From)
+	assert.Equal(t, testContract, node.sent[0].To)
+	assert.True(t, strings.HasPrefix(node.sent[0].Data, encodeCall(lockSignature)))
+
+	// Not mined yet
+	engine.checkPending(ctx)
+	assert.Empty(t, reports)
+
+	// Mined in the latest block, which is one confirmation
+	txHash := fmt.Sprintf("0x%064x", 1)
+	node.receipts[txHash] = &receipt{BlockNumber: "0x64", Status: "0x1"}
+	engine.checkPending(ctx)
+	assert.Empty(t, reports)
+
+	node.head = 101
+	engine.checkPending(ctx)
+	assert.Equal(t, pb.State_SETTLED, reports["settled"])
+	assert.Empty(t, engine.pending)
+}
+
+func TestAbort(t *testing.T) {
+	node := &fakeNode{head: 100, receipts: make(map[string]*receipt)}
+	server := httptest.NewServer(node)
+	defer server.Close()
+	engine := newTestEngine(t, server.URL)
+	defer engine.Close()
+
+	reports := make(map[string]pb.State)
+	engine.report = func(ctx context.Context, trade *settlement.Trade, state pb.State) {
+		reports[string(trade.Order.GetId())] = state
+	}
+
+	// A trade aborted before execution has nothing to refund
+	trade := newTestTrade("prepared")
+	assert.NoError(t, engine.Prepare(ctx, trade))
+	assert.NoError(t, engine.Abort(ctx, trade, nil))
+	assert.Empty(t, node.sent)
+
+	// A trade aborted after execution is refunded
+	trade = newTestTrade("executed")
+	assert.NoError(t, engine.Execute(ctx, trade))
+	assert.NoError(t, engine.Abort(ctx, trade, nil))
+	assert.Len(t, node.sent, 2)
+	assert.True(t, strings.HasPrefix(node.sent[1].Data, encodeCall(refundSignature)))
+
+	// A failed escrow transaction aborts the trade
+	trade = newTestTrade("failed")
+	assert.NoError(t, engine.Execute(ctx, trade))
+	assert.NoError(t, engine.Confirm(ctx, trade))
+	node.receipts[fmt.Sprintf("0x%064x", 3)] = &receipt{BlockNumber: "0x64", Status: "0x0"}
+	engine.checkPending(ctx)
+	assert.Equal(t, pb.State_ABORTED, reports["failed"])
+
+	// An escrow that isn't confirmed in time is refunded and the trade aborted
+	engine.timeout = 0
+	trade = newTestTrade("timedOut")
+	assert.NoError(t, engine.Execute(ctx, trade))
+	assert.NoError(t, engine.Confirm(ctx, trade))
+	engine.checkPending(ctx)
+	assert.Equal(t, pb.State_ABORTED, reports["timedOut"])
+	assert.Len(t, node.sent, 5)
+	assert.True(t, strings.HasPrefix(node.sent[4].Data, encodeCall(refundSignature)))
+
+	// An escrow mined by its deadline isn't refunded, it keeps waiting for its confirmations
+	trade = newTestTrade("mined")
+	assert.NoError(t, engine.Execute(ctx, trade))
+	assert.NoError(t, engine.Confirm(ctx, trade))
+	node.receipts[fmt.Sprintf("0x%064x", 6)] = &receipt{BlockNumber: "0x64", Status: "0x1"}
+	engine.checkPending(ctx)
+	assert.NotContains(t, reports, "mined")
+	assert.Len(t, node.sent, 6)
+	node.head = 101
+	engine.checkPending(ctx)
+	assert.Equal(t, pb.State_SETTLED, reports["mined"])
+	assert.Len(t, node.sent, 6)
+}
+
+func TestPendingTradesSurviveRestart(t *testing.T) {
+	node := &fakeNode{head: 100, receipts: make(map[string]*receipt)}
+	server := httptest.NewServer(node)
+	defer server.Close()
+	storage := &inmemory.Storage{Db: make(map[string]string)}
+	engine := newTestEngine(t, server.URL)
+	engine.RegisterStorage(storage)
+
+	confirmed := newTestTrade("confirmed")
+	assert.NoError(t, engine.Execute(ctx, confirmed))
+	assert.NoError(t, engine.Confirm(ctx, confirmed))
+	unrecorded := newTestTrade("unrecorded")
+	assert.NoError(t, engine.Execute(ctx, unrecorded))
+	engine.Close()
+	entries, err := storage.GetAllWithPrefix(ctx, string(interfaces.SettlementPrefix))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// After a restart the recorded trade is watched again and the unrecorded one refunded
+	restarted := newTestEngine(t, server.URL)
+	defer restarted.Close()
+	restarted.RegisterStorage(storage)
+	assert.Len(t, restarted.pending, 1)
+	assert.Len(t, node.sent, 3)
+	assert.True(t, strings.HasPrefix(node.sent[2].Data, encodeCall(refundSignature)))
+
+	reports := make(map[string]pb.State)
+	restarted.report = func(ctx context.Context, trade *settlement.Trade, state pb.State) {
+		reports[string(trade.Order.GetId())] = state
+	}
+	node.receipts[fmt.Sprintf("0x%064x", 1)] = &receipt{BlockNumber: "0x64", Status: "0x1"}
+	node.head = 101
+	restarted.checkPending(ctx)
+	assert.Equal(t, pb.State_SETTLED, reports["confirmed"])
+	entries, err = storage.GetAllWithPrefix(ctx, string(interfaces.SettlementPrefix))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestPrepareWithoutAmount(t *testing.T) {
+	node := &fakeNode{receipts: make(map[string]*receipt)}
+	server := httptest.NewServer(node)
+	defer server.Close()
+	engine := newTestEngine(t, server.URL)
+	defer engine.Close()
+
+	trade := newTestTrade("empty")
+	trade.Fill.Amount = 0
+	assert.True(t, errors.Is(errors.Invalid, engine.Prepare(ctx, trade)))
+}
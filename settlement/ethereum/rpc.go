@@ -0,0 +1,136 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sprawl/sprawl/errors"
+)
+
+const rpcTimeout time.Duration = 10 * time.Second
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// transaction is the argument of eth_sendTransaction
+type transaction struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Data string `json:"data"`
+}
+
+// receipt is the part of eth_getTransactionReceipt's result the engine needs
+type receipt struct {
+	BlockNumber string `json:"blockNumber"`
+	Status      string `json:"status"`
+}
+
+// rpcClient calls the JSON-RPC API of an Ethereum node
+type rpcClient struct {
+	nextID uint64
+	url    string
+	client *http.Client
+}
+
+func newRPCClient(url string) *rpcClient {
+	return &rpcClient{url: url, client: &http.Client{Timeout: rpcTimeout}}
+}
+
+// call calls method with params and unmarshals its result into result
+func (c *rpcClient) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(&rpcRequest{JSONRPC: "2.0", ID: atomic.AddUint64(&c.nextID, 1), Method: method, Params: params})
+	if err != nil {
+		return errors.E(errors.Op("Marshal "+method), err)
+	}
+	request, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.E(errors.Op("Create "+method+" request"), err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := c.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return errors.E(errors.Op("Call "+method), err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return errors.E(errors.Op("Call "+method), "Ethereum node responded with "+httpResponse.Status)
+	}
+
+	response := &rpcResponse{}
+	err = json.NewDecoder(httpResponse.Body).Decode(response)
+	if err != nil {
+		return errors.E(errors.Op("Unmarshal "+method+" response"), err)
+	}
+	if response.Error != nil {
+		return errors.E(errors.Op("Call "+method), response.Error.Message)
+	}
+	if result == nil {
+		return nil
+	}
+	err = json.Unmarshal(response.Result, result)
+	if err != nil {
+		return errors.E(errors.Op("Unmarshal "+method+" result"), err)
+	}
+	return nil
+}
+
+// blockNumber returns the number of the latest block
+func (c *rpcClient) blockNumber(ctx context.Context) (uint64, error) {
+	var number string
+	err := c.call(ctx, &number, "eth_blockNumber")
+	if err != nil {
+		return 0, err
+	}
+	return parseQuantity(number)
+}
+
+// sendTransaction sends a transaction for the Ethereum node to sign and returns its hash
+func (c *rpcClient) sendTransaction(ctx context.Context, tx *transaction) (string, error) {
+	var hash string
+	err := c.call(ctx, &hash, "eth_sendTransaction", tx)
+	return hash, err
+}
+
+// transactionReceipt returns the receipt of a transaction, or nil if it hasn't been mined yet
+func (c *rpcClient) transactionReceipt(ctx context.Context, hash string) (*receipt, error) {
+	var txReceipt *receipt
+	err := c.call(ctx, &txReceipt, "eth_getTransactionReceipt", hash)
+	return txReceipt, err
+}
+
+// parseQuantity parses a hex encoded JSON-RPC quantity like "0x1b4"
+func parseQuantity(quantity string) (uint64, error) {
+	if !strings.HasPrefix(quantity, "0x") {
+		return 0, errors.E(errors.Op("Parse quantity"), errors.Malformed, "quantity "+quantity+" isn't hex encoded")
+	}
+	value, err := strconv.ParseUint(quantity[2:], 16, 64)
+	if err != nil {
+		return 0, errors.E(errors.Op("Parse quantity"), errors.Malformed, err)
+	}
+	return value, nil
+}
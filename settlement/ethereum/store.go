@@ -0,0 +1,134 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+	"github.com/sprawl/sprawl/settlement"
+)
+
+// storedTrade is a pending trade as it's kept in storage. Confirmed tells if the fill of the trade was recorded.
+type storedTrade struct {
+	ChannelID []byte
+	Order     []byte
+	Fill      []byte
+	TxHash    string
+	Deadline  time.Time
+	Confirmed bool
+}
+
+func getTradeStorageKey(key string) []byte {
+	return []byte(string(interfaces.SettlementPrefix) + Name + "-" + key)
+}
+
+// RegisterStorage keeps the pending trades in storage and picks up the ones left from before a restart.
+// Trades whose fill was recorded are watched again, and ones whose fill never was are refunded.
+func (e *Engine) RegisterStorage(storage interfaces.Storage) {
+	e.lock.Lock()
+	e.storage = storage
+	e.lock.Unlock()
+
+	ctx := context.Background()
+	entries, err := storage.GetAllWithPrefix(ctx, string(getTradeStorageKey("")))
+	if !errors.IsEmpty(err) {
+		e.Logger.Error(errors.E(errors.Op("Get pending trades"), err))
+		return
+	}
+	prefixLength := len(getTradeStorageKey(""))
+	for storageKey, data := range entries {
+		key := storageKey[prefixLength:]
+		pending, confirmed, err := unmarshalTrade([]byte(data))
+		if !errors.IsEmpty(err) {
+			e.Logger.Warn(errors.E(errors.Op("Unmarshal pending trade "+key), err))
+			continue
+		}
+		if confirmed {
+			e.lock.Lock()
+			e.pending[key] = pending
+			e.lock.Unlock()
+			continue
+		}
+		err = e.refund(ctx, getTradeID(pending.trade))
+		if !errors.IsEmpty(err) {
+			e.Logger.Warn(errors.E(errors.Op("Refund unrecorded trade"), err))
+			continue
+		}
+		e.forget(ctx, key)
+	}
+	if len(entries) > 0 {
+		e.Logger.Infof("Picked up %d pending escrows from storage", len(entries))
+	}
+}
+
+// remember stores a pending trade, if the engine has storage
+func (e *Engine) remember(ctx context.Context, key string, pending *pendingTrade, confirmed bool) {
+	e.lock.Lock()
+	storage := e.storage
+	e.lock.Unlock()
+	if storage == nil {
+		return
+	}
+	data, err := marshalTrade(pending, confirmed)
+	if errors.IsEmpty(err) {
+		err = storage.Put(ctx, getTradeStorageKey(key), data)
+	}
+	if !errors.IsEmpty(err) {
+		e.Logger.Warn(errors.E(errors.Op("Store pending trade"), err))
+	}
+}
+
+// forget deletes a trade that isn't pending anymore from storage, if the engine has storage
+func (e *Engine) forget(ctx context.Context, key string) {
+	e.lock.Lock()
+	storage := e.storage
+	e.lock.Unlock()
+	if storage == nil {
+		return
+	}
+	err := storage.Delete(ctx, getTradeStorageKey(key))
+	if !errors.IsEmpty(err) {
+		e.Logger.Warn(errors.E(errors.Op("Delete pending trade"), err))
+	}
+}
+
+func marshalTrade(pending *pendingTrade, confirmed bool) ([]byte, error) {
+	order, err := proto.Marshal(pending.trade.Order)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal order of trade"), err)
+	}
+	fill, err := proto.Marshal(pending.trade.Fill)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Marshal fill of trade"), err)
+	}
+	return json.Marshal(&storedTrade{
+		ChannelID: pending.trade.ChannelID,
+		Order:     order,
+		Fill:      fill,
+		TxHash:    pending.txHash,
+		Deadline:  pending.deadline,
+		Confirmed: confirmed,
+	})
+}
+
+func unmarshalTrade(data []byte) (*pendingTrade, bool, error) {
+	stored := &storedTrade{}
+	err := json.Unmarshal(data, stored)
+	if !errors.IsEmpty(err) {
+		return nil, false, errors.E(errors.Op("Unmarshal trade"), errors.Malformed, err)
+	}
+	trade := &settlement.Trade{ChannelID: stored.ChannelID, Order: &pb.Order{}, Fill: &pb.Fill{}}
+	err = proto.Unmarshal(stored.Order, trade.Order)
+	if !errors.IsEmpty(err) {
+		return nil, false, errors.E(errors.Op("Unmarshal order of trade"), errors.Malformed, err)
+	}
+	err = proto.Unmarshal(stored.Fill, trade.Fill)
+	if !errors.IsEmpty(err) {
+		return nil, false, errors.E(errors.Op("Unmarshal fill of trade"), errors.Malformed, err)
+	}
+	return &pendingTrade{trade: trade, txHash: stored.TxHash, deadline: stored.Deadline}, stored.Confirmed, nil
+}
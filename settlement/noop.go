@@ -0,0 +1,40 @@
+package settlement
+
+import (
+	"context"
+
+	"github.com/sprawl/sprawl/interfaces"
+)
+
+// NoopName is the name of the Noop engine, which is used when no other engine is configured
+const NoopName string = "noop"
+
+func init() {
+	Register(NoopName, func(config interfaces.Config, logger interfaces.Logger) (Engine, error) {
+		return Noop{}, nil
+	})
+}
+
+// Noop accepts every trade without moving anything, leaving the settlement to the traders themselves.
+// It's the reference for what an Engine is called with.
+type Noop struct{}
+
+// Prepare accepts the trade
+func (Noop) Prepare(ctx context.Context, trade *Trade) error {
+	return nil
+}
+
+// Execute does nothing
+func (Noop) Execute(ctx context.Context, trade *Trade) error {
+	return nil
+}
+
+// Confirm does nothing
+func (Noop) Confirm(ctx context.Context, trade *Trade) error {
+	return nil
+}
+
+// Abort does nothing, as nothing was done
+func (Noop) Abort(ctx context.Context, trade *Trade, reason error) error {
+	return nil
+}
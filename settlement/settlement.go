@@ -0,0 +1,99 @@
+// Package settlement moves the assets of trades made on Sprawl. Fills only record what was traded,
+// while a settlement Engine pays them out on a chain or payment rail. Engines are plugged in by registering
+// them under a name with Register, usually from the init function of their own package, and selected with
+// the settlement.engine config.
+package settlement
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/sprawl/sprawl/pb"
+)
+
+// Trade is a fill of an order that is being settled
+type Trade struct {
+	ChannelID []byte
+	Order     *pb.Order
+	Fill      *pb.Fill
+}
+
+// Engine settles trades in two phases. Prepare checks that the trade can be settled and reserves what it needs,
+// before the fill is signed. Execute settles the trade before the fill is broadcast, and Confirm is called once
+// the fill has been recorded. Abort is called instead of the remaining steps if anything fails after Prepare,
+// so the engine can release or reverse what it has done.
+type Engine interface {
+	Prepare(ctx context.Context, trade *Trade) error
+	Execute(ctx context.Context, trade *Trade) error
+	Confirm(ctx context.Context, trade *Trade) error
+	Abort(ctx context.Context, trade *Trade, reason error) error
+}
+
+// Report records the outcome of a trade that was settled after Confirm, as SETTLED or ABORTED
+type Report func(ctx context.Context, trade *Trade, state pb.State)
+
+// Watcher is implemented by engines that only learn the outcome of a trade after Confirm, for example from
+// chain confirmations. The node gives them a Report to call once they know it.
+type Watcher interface {
+	Watch(report Report)
+}
+
+// Storer is implemented by engines that keep the trades they're settling in the node's storage,
+// so the trades are still settled or refunded after a restart
+type Storer interface {
+	RegisterStorage(storage interfaces.Storage)
+}
+
+// Closer is implemented by engines that keep running in the background, so the node can stop them when it closes
+type Closer interface {
+	Close()
+}
+
+// Factory constructs an Engine from the node's config
+type Factory func(config interfaces.Config, logger interfaces.Logger) (Engine, error)
+
+var factories = make(map[string]Factory)
+var factoryLock sync.RWMutex
+
+// Register makes an Engine available under name. It panics if name is registered twice, like database/sql drivers.
+func Register(name string, factory Factory) {
+	factoryLock.Lock()
+	defer factoryLock.Unlock()
+	if factory == nil {
+		panic("settlement: Register factory is nil")
+	}
+	if _, ok := factories[name]; ok {
+		panic("settlement: Register called twice for engine " + name)
+	}
+	factories[name] = factory
+}
+
+// Engines returns the names of the registered engines in order
+func Engines() []string {
+	factoryLock.RLock()
+	defer factoryLock.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New constructs the Engine registered under name
+func New(name string, config interfaces.Config, logger interfaces.Logger) (Engine, error) {
+	factoryLock.RLock()
+	factory, ok := factories[name]
+	factoryLock.RUnlock()
+	if !ok {
+		return nil, errors.E(errors.Op("Construct settlement engine"), errors.Invalid, "no settlement engine registered as "+name)
+	}
+	engine, err := factory(config, logger)
+	if !errors.IsEmpty(err) {
+		return nil, errors.E(errors.Op("Construct settlement engine "+name), err)
+	}
+	return engine, nil
+}
@@ -0,0 +1,41 @@
+package settlement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sprawl/sprawl/errors"
+	"github.com/sprawl/sprawl/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	assert.Contains(t, Engines(), NoopName)
+	engine, err := New(NoopName, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Noop{}, engine)
+
+	_, err = New("missing", nil, nil)
+	assert.True(t, errors.Is(errors.Invalid, err))
+
+	Register("failing", func(config interfaces.Config, logger interfaces.Logger) (Engine, error) {
+		return nil, errors.E(errors.Op("Connect"), "no chain")
+	})
+	_, err = New("failing", nil, nil)
+	assert.False(t, errors.IsEmpty(err))
+
+	assert.Panics(t, func() {
+		Register(NoopName, func(config interfaces.Config, logger interfaces.Logger) (Engine, error) {
+			return Noop{}, nil
+		})
+	})
+}
+
+func TestNoop(t *testing.T) {
+	ctx := context.Background()
+	trade := &Trade{}
+	assert.NoError(t, Noop{}.Prepare(ctx, trade))
+	assert.NoError(t, Noop{}.Execute(ctx, trade))
+	assert.NoError(t, Noop{}.Confirm(ctx, trade))
+	assert.NoError(t, Noop{}.Abort(ctx, trade, nil))
+}